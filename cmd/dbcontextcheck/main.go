@@ -0,0 +1,15 @@
+// Command dbcontextcheck reports database.DB calls made without WithContext. Run by make vet
+// as a go vet tool:
+//
+//	go build -o .bin/dbcontextcheck ./cmd/dbcontextcheck
+//	go vet -vettool=$PWD/.bin/dbcontextcheck ./...
+package main
+
+import (
+	"github.com/banglin/go-nd/internal/analysis/dbcontext"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(dbcontext.Analyzer)
+}
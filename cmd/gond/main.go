@@ -1,29 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/grpc/gateway"
 	"github.com/banglin/go-nd/internal/grpc/interceptors"
+	"github.com/banglin/go-nd/internal/grpc/serveropts"
 	grpcservices "github.com/banglin/go-nd/internal/grpc/services"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/router"
 	"github.com/banglin/go-nd/internal/services"
 	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"github.com/banglin/go-nd/internal/tracing"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -36,8 +42,38 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Export traces of job provisioning and NDFC requests when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	log := logger.L()
 
+	// Fail fast on a bad port description template rather than at first provision
+	if _, err := services.ParsePortDescriptionTemplate(cfg.NexusDashboard.PortDescriptionTemplate); err != nil {
+		logger.Fatal("Invalid ND_PORT_DESCRIPTION_TEMPLATE", zap.Error(err))
+	}
+	if err := services.ValidateJobContractProtocol(cfg.NexusDashboard.JobContractProtocol); err != nil {
+		logger.Fatal("Invalid ND_JOB_CONTRACT_PROTOCOL", zap.Error(err))
+	}
+	if err := util.ValidatePortNameFormat(cfg.NexusDashboard.PortNameFormat); err != nil {
+		logger.Fatal("Invalid ND_PORT_NAME_FORMAT", zap.Error(err))
+	}
+	if err := ndclient.ValidateTransportConfig(&cfg.NexusDashboard, cfg.Server.Mode); err != nil {
+		logger.Fatal("Invalid NDFC HTTP client configuration", zap.Error(err))
+	}
+	if cfg.SecretError != nil {
+		logger.Fatal("Failed to load secrets from SECRET_STORE", zap.Error(cfg.SecretError))
+	}
+
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
@@ -79,6 +115,17 @@ func main() {
 		logger.Fatal("At least one of ENABLE_HTTP or ENABLE_GRPC must be true")
 	}
 
+	// Shared contract lists, reloaded from configuration on SIGHUP
+	registry := services.NewRegistry(&cfg.NexusDashboard)
+	stopReload := registry.ReloadOnSIGHUP(config.Reload)
+	defer stopReload()
+
+	// Warn about configured NDFC objects that do not exist. Not fatal: local-only
+	// operations still work, and provisioning fails per job until NDFC is fixed.
+	if ndClient != nil {
+		go checkNDFCConfig(services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard, registry))
+	}
+
 	// WaitGroup for graceful shutdown
 	var wg sync.WaitGroup
 
@@ -86,20 +133,27 @@ func main() {
 	var syncWorker *backgroundsync.Worker
 	if cfg.Server.EnableSync && ndClient != nil {
 		syncWorker = backgroundsync.NewWorker(ndClient, &cfg.NexusDashboard, cfg.Server.InstanceID)
+		jobSvc := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard, registry)
+		jobSvc.SetRetentionDays(cfg.Server.RetentionDays)
+		syncWorker.AddTask(jobSvc.OrphanedAllocationTask())
+		syncWorker.AddTask(jobSvc.JobEventCleanupTask())
+		syncWorker.AddTask(jobSvc.JobPruneTask())
+		syncWorker.AddTask(jobSvc.JobPortReconcileTask())
+		syncWorker.AddTask(jobSvc.NodeDecommissionTask())
+		syncWorker.AddTask(services.NewSecurityGroupService(database.DB, ndClient).AssociationValidationTask())
 		syncWorker.Start()
 		logger.Info("Background sync worker started")
 	}
 
 	// Start HTTP server
-	var httpServer *gin.Engine
+	var httpServer *http.Server
 	if cfg.Server.EnableHTTP {
-		httpServer = router.Setup(ndClient, cfg)
+		httpServer = router.NewServer(router.Setup(ndClient, cfg, registry), cfg.Server.Port)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			addr := ":" + cfg.Server.Port
-			logger.Info("Starting HTTP server", zap.String("address", addr))
-			if err := httpServer.Run(addr); err != nil {
+			logger.Info("Starting HTTP server", zap.String("address", httpServer.Addr))
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				logger.Error("HTTP server error", zap.Error(err))
 			}
 		}()
@@ -107,14 +161,17 @@ func main() {
 
 	// Start gRPC server
 	var grpcServer *grpc.Server
-	var healthServer *health.Server
+	var healthReporter *serveropts.HealthReporter
+	var gatewayServer *http.Server
 	if cfg.Server.EnableGRPC {
 		if cfg.GRPC.AuthToken == "" {
 			logger.Fatal("GRPC_AUTH_TOKEN is required when ENABLE_GRPC=true")
 		}
 
 		// Create job service
-		jobService := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard)
+		jobService := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard, registry)
+		jobService.SetMaxProvisionTimeout(time.Duration(cfg.Server.MaxProvisionTimeoutMinutes) * time.Minute)
+		jobService.SetInstanceID(cfg.Server.InstanceID)
 
 		// Create interceptors
 		recoveryInterceptor := interceptors.NewRecoveryInterceptor(log)
@@ -125,13 +182,23 @@ func main() {
 			"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
 			"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
 		})
+		methodTimeouts, err := interceptors.LoadMethodTimeouts(cfg.GRPC.MethodTimeoutsFile,
+			time.Duration(cfg.Server.MaxProvisionTimeoutMinutes)*time.Minute)
+		if err != nil {
+			logger.Fatal("Invalid gRPC method timeouts", zap.Error(err))
+		}
+		timeoutInterceptor := interceptors.NewTimeoutInterceptor(time.Duration(cfg.GRPC.DefaultTimeoutSec)*time.Second, methodTimeouts)
+		if err := serveropts.RegisterCompression(cfg.GRPC.Compression); err != nil {
+			logger.Fatal("Invalid GRPC_COMPRESSION", zap.Error(err))
+		}
 
 		// Create gRPC server
-		grpcServer = grpc.NewServer(
+		opts := append(serveropts.Server(cfg.GRPC),
 			grpc.ChainUnaryInterceptor(
 				recoveryInterceptor.Unary(),
 				loggingInterceptor.Unary(),
 				authInterceptor.Unary(),
+				timeoutInterceptor.Unary(),
 			),
 			grpc.ChainStreamInterceptor(
 				recoveryInterceptor.Stream(),
@@ -139,22 +206,31 @@ func main() {
 				authInterceptor.Stream(),
 			),
 		)
+		grpcServer = grpc.NewServer(opts...)
 
 		// Register services
 		grpcservices.RegisterJobsService(grpcServer, jobService, log)
 		grpcservices.RegisterComputeNodesService(grpcServer, log)
-		grpcservices.RegisterFabricsService(grpcServer, ndClient, log)
+		// Without NDFC there is nothing to deploy to: DeployFabric is unimplemented
+		var deployer services.FabricDeployer
+		if ndClient != nil {
+			deployer = jobService.DeployBatcher()
+		}
+		grpcservices.RegisterFabricsService(grpcServer, ndClient, services.NewFabricService(database.DB),
+			backgroundsync.NewUplinkCache(cache.Default(), time.Duration(cfg.NexusDashboard.UplinkCacheTTLMinutes)*time.Minute),
+			deployer, log)
+		grpcservices.RegisterSecurityService(grpcServer, services.NewContractService(database.DB, ndClient),
+			services.NewSecurityGroupService(database.DB, ndClient), log)
 		grpcservices.RegisterStorageTenantsService(grpcServer, log)
 
-		// Register health service
-		healthServer = health.NewServer()
-		healthpb.RegisterHealthServer(grpcServer, healthServer)
-		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		// Register health service, with the jobs and fabrics services following NDFC health
+		healthReporter = serveropts.RegisterHealth(grpcServer)
+		healthReporter.WatchCircuitBreaker(ndClient)
+		healthReporter.WatchSync(syncWorker)
 
 		// Register reflection
-		if cfg.GRPC.Reflection {
-			reflection.Register(grpcServer)
-			log.Info("gRPC reflection enabled")
+		if serveropts.RegisterReflection(grpcServer, cfg.GRPC) {
+			log.Info("gRPC reflection enabled", zap.Strings("services", cfg.GRPC.ReflectedServices))
 		}
 
 		// Start listening
@@ -171,6 +247,43 @@ func main() {
 				logger.Error("gRPC server error", zap.Error(err))
 			}
 		}()
+
+		// Start HTTP/JSON gateway in front of the gRPC server
+		if cfg.GRPC.GatewayEnabled {
+			gatewayCtx, cancelGateway := context.WithCancel(context.Background())
+			defer cancelGateway()
+			handler, err := gateway.NewHandler(gatewayCtx, "127.0.0.1:"+cfg.GRPC.Port, serveropts.Call(cfg.GRPC)...)
+			if err != nil {
+				logger.Fatal("Failed to create gRPC gateway", zap.Error(err))
+			}
+			gatewayServer = &http.Server{
+				Addr:              ":" + cfg.GRPC.GatewayPort,
+				Handler:           handler,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				logger.Info("Starting gRPC gateway", zap.String("address", gatewayServer.Addr))
+				if err := gatewayServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("gRPC gateway error", zap.Error(err))
+				}
+			}()
+		}
+	}
+
+	// Serve Prometheus metrics on their own port
+	var metricsServer *http.Server
+	if cfg.Server.MetricsPort != "" {
+		metricsServer = metrics.NewServer(cfg.Server.MetricsPort)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("Starting metrics server", zap.String("address", metricsServer.Addr))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Metrics server error", zap.Error(err))
+			}
+		}()
 	}
 
 	// Graceful shutdown
@@ -181,19 +294,72 @@ func main() {
 	logger.Info("Shutting down servers...")
 
 	if syncWorker != nil {
-		syncWorker.Stop()
+		if err := syncWorker.StopWithDrain(backgroundsync.DefaultDrainTimeout); err != nil {
+			logger.Warn("NDFC sync aborted before it finished", zap.Error(err))
+		}
+	}
+
+	// Stop accepting HTTP requests and wait for the in-flight ones
+	if httpServer != nil {
+		shutdownTimeout := time.Duration(cfg.Server.ShutdownTimeoutSec) * time.Second
+		if shutdownTimeout <= 0 {
+			shutdownTimeout = router.DefaultShutdownTimeout
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("HTTP server shutdown error", zap.Error(err))
+		}
+		cancel()
+	}
+
+	if gatewayServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := gatewayServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("gRPC gateway shutdown error", zap.Error(err))
+		}
+		cancel()
+	}
+
+	if metricsServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Metrics server shutdown error", zap.Error(err))
+		}
+		cancel()
 	}
 
 	if grpcServer != nil {
-		if healthServer != nil {
-			healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		if healthReporter != nil {
+			healthReporter.Shutdown()
 		}
 		grpcServer.GracefulStop()
 	}
 
-	// Note: Gin doesn't have a built-in graceful shutdown in Run()
-	// For production, use http.Server with Shutdown()
-
 	wg.Wait()
 	logger.Info("Server shutdown complete")
 }
+
+// startupNDFCCheckTimeout bounds the startup NDFC configuration check
+const startupNDFCCheckTimeout = 30 * time.Second
+
+// checkNDFCConfig logs a warning for each configured NDFC object that does not exist
+func checkNDFCConfig(jobService *services.JobService) {
+	ctx, cancel := context.WithTimeout(context.Background(), startupNDFCCheckTimeout)
+	defer cancel()
+
+	status, err := jobService.CheckNDFCConfig(ctx)
+	if err != nil {
+		logger.Warn("NDFC configuration check failed", zap.Error(err))
+		return
+	}
+	for check, msg := range status.Errors {
+		logger.Warn("NDFC configuration check failed", zap.String("check", check), zap.String("error", msg))
+	}
+	for _, missing := range status.Missing(jobService.NDFCConfigNames()) {
+		logger.Warn("Configured NDFC object not found; provisioning that depends on it will fail",
+			zap.String("object", missing))
+	}
+	if status.ComputeNetworkVLAN != "" {
+		logger.Info("Compute network VLAN", zap.String("vlan", status.ComputeNetworkVLAN))
+	}
+}
@@ -1,26 +1,32 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/grpc/interceptors"
+	"github.com/banglin/go-nd/internal/grpc/serveropts"
 	grpcservices "github.com/banglin/go-nd/internal/grpc/services"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/services"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"github.com/banglin/go-nd/internal/tracing"
+	"github.com/banglin/go-nd/internal/util"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -33,13 +39,43 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Export traces of job provisioning and NDFC requests when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	log := logger.L()
 
+	// Fail fast on a bad port description template rather than at first provision
+	if _, err := services.ParsePortDescriptionTemplate(cfg.NexusDashboard.PortDescriptionTemplate); err != nil {
+		logger.Fatal("Invalid ND_PORT_DESCRIPTION_TEMPLATE", zap.Error(err))
+	}
+	if err := services.ValidateJobContractProtocol(cfg.NexusDashboard.JobContractProtocol); err != nil {
+		logger.Fatal("Invalid ND_JOB_CONTRACT_PROTOCOL", zap.Error(err))
+	}
+	if err := util.ValidatePortNameFormat(cfg.NexusDashboard.PortNameFormat); err != nil {
+		logger.Fatal("Invalid ND_PORT_NAME_FORMAT", zap.Error(err))
+	}
+	if err := ndclient.ValidateTransportConfig(&cfg.NexusDashboard, cfg.Server.Mode); err != nil {
+		logger.Fatal("Invalid NDFC HTTP client configuration", zap.Error(err))
+	}
+	if cfg.SecretError != nil {
+		logger.Fatal("Failed to load secrets from SECRET_STORE", zap.Error(cfg.SecretError))
+	}
+
 	// Get gRPC-specific config from environment
 	grpcPort := getEnv("GRPC_PORT", "9090")
-	grpcAuthToken := os.Getenv("GRPC_AUTH_TOKEN")
+	grpcAuthToken := cfg.GRPC.AuthToken
 	if grpcAuthToken == "" {
-		logger.Fatal("GRPC_AUTH_TOKEN environment variable is required")
+		logger.Fatal("GRPC_AUTH_TOKEN is required")
 	}
 
 	// Initialize database
@@ -72,8 +108,15 @@ func main() {
 		logger.Warn("Failed to create Nexus Dashboard client", zap.Error(err))
 	}
 
+	// Shared contract lists, reloaded from configuration on SIGHUP
+	registry := services.NewRegistry(&cfg.NexusDashboard)
+	stopReload := registry.ReloadOnSIGHUP(config.Reload)
+	defer stopReload()
+
 	// Create job service (reuse existing service layer)
-	jobService := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard)
+	jobService := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard, registry)
+	jobService.SetMaxProvisionTimeout(time.Duration(cfg.Server.MaxProvisionTimeoutMinutes) * time.Minute)
+	jobService.SetInstanceID(cfg.Server.InstanceID)
 
 	// Create interceptors
 	recoveryInterceptor := interceptors.NewRecoveryInterceptor(log)
@@ -84,13 +127,23 @@ func main() {
 		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
 		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
 	})
+	methodTimeouts, err := interceptors.LoadMethodTimeouts(cfg.GRPC.MethodTimeoutsFile,
+		time.Duration(cfg.Server.MaxProvisionTimeoutMinutes)*time.Minute)
+	if err != nil {
+		log.Fatal("Invalid gRPC method timeouts", zap.Error(err))
+	}
+	timeoutInterceptor := interceptors.NewTimeoutInterceptor(time.Duration(cfg.GRPC.DefaultTimeoutSec)*time.Second, methodTimeouts)
+	if err := serveropts.RegisterCompression(cfg.GRPC.Compression); err != nil {
+		log.Fatal("Invalid GRPC_COMPRESSION", zap.Error(err))
+	}
 
-	// Create gRPC server with interceptors (order matters: recovery -> logging -> auth)
-	server := grpc.NewServer(
+	// Create gRPC server with interceptors (order matters: recovery -> logging -> auth -> timeout)
+	opts := append(serveropts.Server(cfg.GRPC),
 		grpc.ChainUnaryInterceptor(
 			recoveryInterceptor.Unary(),
 			loggingInterceptor.Unary(),
 			authInterceptor.Unary(),
+			timeoutInterceptor.Unary(),
 		),
 		grpc.ChainStreamInterceptor(
 			recoveryInterceptor.Stream(),
@@ -98,21 +151,29 @@ func main() {
 			authInterceptor.Stream(),
 		),
 	)
+	server := grpc.NewServer(opts...)
 
 	// Register services
 	grpcservices.RegisterJobsService(server, jobService, log)
 	grpcservices.RegisterComputeNodesService(server, log)
-	grpcservices.RegisterFabricsService(server, ndClient, log)
+	// Without NDFC there is nothing to deploy to: DeployFabric is unimplemented
+	var deployer services.FabricDeployer
+	if ndClient != nil {
+		deployer = jobService.DeployBatcher()
+	}
+	grpcservices.RegisterFabricsService(server, ndClient, services.NewFabricService(database.DB),
+		backgroundsync.NewUplinkCache(cache.Default(), time.Duration(cfg.NexusDashboard.UplinkCacheTTLMinutes)*time.Minute),
+		deployer, log)
+	grpcservices.RegisterSecurityService(server, services.NewContractService(database.DB, ndClient),
+		services.NewSecurityGroupService(database.DB, ndClient), log)
 
-	// Register health service
-	healthServer := health.NewServer()
-	healthpb.RegisterHealthServer(server, healthServer)
-	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	// Register health service, with the jobs service following the NDFC circuit breaker
+	healthReporter := serveropts.RegisterHealth(server)
+	healthReporter.WatchCircuitBreaker(ndClient)
 
 	// Register reflection for grpcurl/grpcui (disable in production if needed)
-	if getEnv("GRPC_REFLECTION", "true") == "true" {
-		reflection.Register(server)
-		log.Info("gRPC reflection enabled")
+	if serveropts.RegisterReflection(server, cfg.GRPC) {
+		log.Info("gRPC reflection enabled", zap.Strings("services", cfg.GRPC.ReflectedServices))
 	}
 
 	// Start listening
@@ -122,6 +183,18 @@ func main() {
 		log.Fatal("Failed to listen", zap.String("addr", addr), zap.Error(err))
 	}
 
+	// Serve Prometheus metrics on their own port
+	var metricsServer *http.Server
+	if cfg.Server.MetricsPort != "" {
+		metricsServer = metrics.NewServer(cfg.Server.MetricsPort)
+		go func() {
+			log.Info("Starting metrics server", zap.String("addr", metricsServer.Addr))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Metrics server error", zap.Error(err))
+			}
+		}()
+	}
+
 	// Handle graceful shutdown
 	go func() {
 		sigCh := make(chan os.Signal, 1)
@@ -129,7 +202,10 @@ func main() {
 		<-sigCh
 
 		log.Info("Shutting down gRPC server...")
-		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthReporter.Shutdown()
+		if metricsServer != nil {
+			_ = metricsServer.Close()
+		}
 		server.GracefulStop()
 	}()
 
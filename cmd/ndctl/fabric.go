@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// switchSyncResult is the response of POST /fabrics/:id/switches/sync
+type switchSyncResult struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+	Total   int    `json:"total"`
+}
+
+// portSyncResult is the response of POST /fabrics/:id/ports/sync
+type portSyncResult struct {
+	Message  string          `json:"message"`
+	Switches int             `json:"switches"`
+	Ports    int             `json:"ports"`
+	Errors   int             `json:"errors"`
+	Details  json.RawMessage `json:"details,omitempty"`
+}
+
+func newFabricCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fabric",
+		Short: "Fabric commands",
+	}
+	cmd.AddCommand(newFabricSyncCmd(opts))
+	return cmd
+}
+
+func newFabricSyncCmd(opts *options) *cobra.Command {
+	var fabricID string
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync a fabric's switches and then their ports from NDFC",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newAPIClient(opts)
+			base := "/fabrics/" + url.PathEscape(fabricID)
+
+			// Ports are synced per known switch, so switches go first
+			var switches switchSyncResult
+			if err := client.post(cmd.Context(), base+"/switches/sync", "", nil, &switches); err != nil {
+				return err
+			}
+			var ports portSyncResult
+			if err := client.post(cmd.Context(), base+"/ports/sync", "", nil, &ports); err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), map[string]any{"switches": switches, "ports": ports})
+			}
+			rows := [][]string{
+				{"switches", strconv.Itoa(switches.Count), fmt.Sprintf("%d returned by NDFC (spines are skipped)", switches.Total)},
+				{"ports", strconv.Itoa(ports.Ports), fmt.Sprintf("%d switches, %d errors", ports.Switches, ports.Errors)},
+			}
+			return printTable(cmd.OutOrStdout(), []string{"STEP", "SYNCED", "DETAIL"}, rows)
+		},
+	}
+	cmd.Flags().StringVar(&fabricID, "fabric-id", "", "fabric ID or name (required)")
+	_ = cmd.MarkFlagRequired("fabric-id")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// job is the subset of a job shown in table output
+type job struct {
+	ID          string    `json:"id"`
+	SlurmJobID  string    `json:"slurm_job_id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	FabricName  string    `json:"fabric_name"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// jobPage is one page of GET /jobs
+type jobPage struct {
+	Jobs       []json.RawMessage `json:"jobs"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+func newJobsCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Job commands",
+	}
+	cmd.AddCommand(newJobsListCmd(opts))
+	return cmd
+}
+
+func newJobsListCmd(opts *options) *cobra.Command {
+	var status string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Follow the pages to list every job
+			client := newAPIClient(opts)
+			query := url.Values{"limit": {"1000"}}
+			if status != "" {
+				query.Set("status", status)
+			}
+			raw := []json.RawMessage{}
+			for {
+				var page jobPage
+				if err := client.get(cmd.Context(), "/jobs?"+query.Encode(), &page); err != nil {
+					return err
+				}
+				raw = append(raw, page.Jobs...)
+				if page.NextCursor == "" {
+					break
+				}
+				query.Set("cursor", page.NextCursor)
+			}
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), raw)
+			}
+
+			rows := make([][]string, len(raw))
+			for i, r := range raw {
+				var j job
+				if err := json.Unmarshal(r, &j); err != nil {
+					return err
+				}
+				rows[i] = []string{j.SlurmJobID, j.Name, j.Status, j.FabricName, j.SubmittedAt.Format(time.RFC3339), j.ID}
+			}
+			return printTable(cmd.OutOrStdout(), []string{"SLURM_JOB_ID", "NAME", "STATUS", "FABRIC", "SUBMITTED", "ID"}, rows)
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "only list jobs with this status (e.g. active)")
+	return cmd
+}
@@ -0,0 +1,24 @@
+// Command ndctl is a thin command-line client for the go-nd REST API.
+//
+// Usage:
+//
+//	ndctl ports list --fabric-id 4000 --available-only
+//	ndctl jobs list --status active
+//	ndctl nodes import --file nodes.csv
+//	ndctl fabric sync --fabric-id 4000
+//
+// The server is taken from --base-url (or GOND_BASE_URL) and the API key from
+// --api-key (or GOND_API_KEY). Output is a table unless --output json is given.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
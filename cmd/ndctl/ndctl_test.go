@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCmd executes ndctl with args against srv and returns its stdout
+func runCmd(t *testing.T, srv *httptest.Server, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newRootCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append([]string{"--base-url", srv.URL, "--api-key", "secret"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+// writeJSON is an http.HandlerFunc body helper
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestPortsList_AvailableOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/fabrics/4000/ports" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		writeJSON(w, http.StatusOK, []map[string]any{
+			{"id": "p1", "name": "Ethernet1/1", "switch_name": "leaf-1", "speed": "100G", "admin_state": "true"},
+			{"id": "p2", "name": "Ethernet1/2", "switch_name": "leaf-1", "speed": "100G", "admin_state": "true", "current_job_slurm_id": "12345"},
+		})
+	}))
+	defer srv.Close()
+
+	out, err := runCmd(t, srv, "ports", "list", "--fabric-id", "4000", "--available-only")
+	if err != nil {
+		t.Fatalf("ports list: %v", err)
+	}
+	if !strings.Contains(out, "Ethernet1/1") || strings.Contains(out, "Ethernet1/2") {
+		t.Errorf("expected only the unallocated port, got:\n%s", out)
+	}
+	if !strings.HasPrefix(out, "SWITCH") {
+		t.Errorf("expected a table header, got:\n%s", out)
+	}
+
+	out, err = runCmd(t, srv, "ports", "list", "--fabric-id", "4000", "--output", "json")
+	if err != nil {
+		t.Fatalf("ports list json: %v", err)
+	}
+	var ports []map[string]any
+	if err := json.Unmarshal([]byte(out), &ports); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(ports) != 2 {
+		t.Errorf("expected 2 ports without --available-only, got %d", len(ports))
+	}
+}
+
+func TestJobsList_Status(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/jobs" || r.URL.Query().Get("status") != "active" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		// Two pages, linked by next_cursor
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(w, http.StatusOK, map[string]any{"total_count": 2, "next_cursor": "page-2", "jobs": []map[string]any{
+				{"id": "j1", "slurm_job_id": "12345", "name": "train", "status": "active", "fabric_name": "DevNet_Fabric", "submitted_at": "2026-01-02T03:04:05Z"},
+			}})
+			return
+		}
+		if r.URL.Query().Get("cursor") != "page-2" {
+			t.Errorf("unexpected cursor in %s", r.URL)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"total_count": 2, "jobs": []map[string]any{
+			{"id": "j2", "slurm_job_id": "12344", "name": "eval", "status": "active", "fabric_name": "DevNet_Fabric", "submitted_at": "2026-01-02T03:00:00Z"},
+		}})
+	}))
+	defer srv.Close()
+
+	out, err := runCmd(t, srv, "jobs", "list", "--status", "active")
+	if err != nil {
+		t.Fatalf("jobs list: %v", err)
+	}
+	for _, want := range []string{"SLURM_JOB_ID", "12345", "train", "active", "DevNet_Fabric", "12344", "eval"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestNodesImport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/compute-nodes/import" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Content-Type"); got != "text/csv" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.HasPrefix(string(body), "name,") {
+			t.Errorf("unexpected body %q", body)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"id": "imp-1", "status": "completed", "total_rows": 2, "imported_count": 2})
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "nodes.csv")
+	if err := os.WriteFile(file, []byte("name,hostname\nnode-1,node-1.local\nnode-2,node-2.local\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCmd(t, srv, "nodes", "import", "--file", file)
+	if err != nil {
+		t.Fatalf("nodes import: %v", err)
+	}
+	if !strings.Contains(out, "imp-1") || !strings.Contains(out, "completed") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestFabricSync(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/fabrics/4000/switches/sync":
+			writeJSON(w, http.StatusOK, map[string]any{"message": "Switches synced", "count": 4, "total": 6})
+		case "/api/v1/fabrics/4000/ports/sync":
+			writeJSON(w, http.StatusOK, map[string]any{"message": "Ports synced for all switches", "switches": 4, "ports": 192, "errors": 0})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := runCmd(t, srv, "fabric", "sync", "--fabric-id", "4000")
+	if err != nil {
+		t.Fatalf("fabric sync: %v", err)
+	}
+	want := []string{"POST /api/v1/fabrics/4000/switches/sync", "POST /api/v1/fabrics/4000/ports/sync"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if !strings.Contains(out, "192") {
+		t.Errorf("expected port count in output:\n%s", out)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Fabric not found"})
+	}))
+	defer srv.Close()
+
+	_, err := runCmd(t, srv, "fabric", "sync", "--fabric-id", "missing")
+	if err == nil || !strings.Contains(err.Error(), "Fabric not found") {
+		t.Errorf("expected the API error message, got %v", err)
+	}
+}
+
+func TestBaseURLFromEnv(t *testing.T) {
+	t.Setenv("GOND_BASE_URL", "http://gond.example:9000")
+	t.Setenv("GOND_API_KEY", "from-env")
+
+	cmd := newRootCmd()
+	if got := cmd.PersistentFlags().Lookup("base-url").DefValue; got != "http://gond.example:9000" {
+		t.Errorf("base-url default = %q", got)
+	}
+	if got := cmd.PersistentFlags().Lookup("api-key").DefValue; got != "from-env" {
+		t.Errorf("api-key default = %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// nodeImport is the import summary returned by POST /compute-nodes/import
+type nodeImport struct {
+	ID            string          `json:"id"`
+	Status        string          `json:"status"`
+	TotalRows     int             `json:"total_rows"`
+	ImportedCount int             `json:"imported_count"`
+	ErrorCount    int             `json:"error_count"`
+	Errors        json.RawMessage `json:"errors,omitempty"`
+}
+
+func newNodesCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "Compute node commands",
+	}
+	cmd.AddCommand(newNodesImportCmd(opts))
+	return cmd
+}
+
+func newNodesImportCmd(opts *options) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Bulk import compute nodes from a CSV or YAML file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			// The server picks the parser from the Content-Type
+			contentType := "text/csv"
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".yaml", ".yml":
+				contentType = "application/yaml"
+			}
+
+			var raw json.RawMessage
+			if err := newAPIClient(opts).post(cmd.Context(), "/compute-nodes/import", contentType, f, &raw); err != nil {
+				return err
+			}
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), raw)
+			}
+
+			var imp nodeImport
+			if err := json.Unmarshal(raw, &imp); err != nil {
+				return err
+			}
+			row := []string{imp.ID, imp.Status, strconv.Itoa(imp.TotalRows), strconv.Itoa(imp.ImportedCount), strconv.Itoa(imp.ErrorCount)}
+			if err := printTable(cmd.OutOrStdout(), []string{"IMPORT_ID", "STATUS", "ROWS", "IMPORTED", "ERRORS"}, [][]string{row}); err != nil {
+				return err
+			}
+			if imp.ErrorCount > 0 && len(imp.Errors) > 0 {
+				return printJSON(cmd.OutOrStdout(), imp.Errors)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "CSV or YAML file of compute nodes (required)")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
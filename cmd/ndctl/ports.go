@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+// port is the subset of a fabric port search result shown in table output
+type port struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	SwitchName         string  `json:"switch_name"`
+	SwitchSerialNumber string  `json:"switch_serial_number"`
+	AdminState         string  `json:"admin_state"`
+	Speed              string  `json:"speed"`
+	CurrentJobSlurmID  *string `json:"current_job_slurm_id"`
+}
+
+func newPortsCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ports",
+		Short: "Switch port commands",
+	}
+	cmd.AddCommand(newPortsListCmd(opts))
+	return cmd
+}
+
+func newPortsListCmd(opts *options) *cobra.Command {
+	var fabricID string
+	var availableOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the switch ports of a fabric",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw []json.RawMessage
+			path := "/fabrics/" + url.PathEscape(fabricID) + "/ports"
+			if err := newAPIClient(opts).get(cmd.Context(), path, &raw); err != nil {
+				return err
+			}
+
+			// A port is available when no job currently holds its compute node
+			var ports []port
+			var keep []json.RawMessage
+			for _, r := range raw {
+				var p port
+				if err := json.Unmarshal(r, &p); err != nil {
+					return fmt.Errorf("decode port: %w", err)
+				}
+				if availableOnly && p.CurrentJobSlurmID != nil {
+					continue
+				}
+				ports = append(ports, p)
+				keep = append(keep, r)
+			}
+
+			if opts.output == outputJSON {
+				if keep == nil {
+					keep = []json.RawMessage{}
+				}
+				return printJSON(cmd.OutOrStdout(), keep)
+			}
+			rows := make([][]string, len(ports))
+			for i, p := range ports {
+				job := "-"
+				if p.CurrentJobSlurmID != nil {
+					job = *p.CurrentJobSlurmID
+				}
+				rows[i] = []string{p.SwitchName, p.Name, p.Speed, p.AdminState, job, p.ID}
+			}
+			return printTable(cmd.OutOrStdout(), []string{"SWITCH", "PORT", "SPEED", "ADMIN", "JOB", "ID"}, rows)
+		},
+	}
+	cmd.Flags().StringVar(&fabricID, "fabric-id", "", "fabric ID or name (required)")
+	cmd.Flags().BoolVar(&availableOnly, "available-only", false, "only list ports not allocated to a job")
+	_ = cmd.MarkFlagRequired("fabric-id")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultBaseURL = "http://localhost:8080"
+
+// Output formats for --output
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// options holds the global flags shared by every subcommand
+type options struct {
+	baseURL string
+	apiKey  string
+	output  string
+}
+
+func newRootCmd() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:           "ndctl",
+		Short:         "Command-line client for the go-nd REST API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != outputTable && opts.output != outputJSON {
+				return fmt.Errorf("--output must be %q or %q", outputTable, outputJSON)
+			}
+			return nil
+		},
+	}
+
+	baseURL := os.Getenv("GOND_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	cmd.PersistentFlags().StringVar(&opts.baseURL, "base-url", baseURL, "go-nd server URL (env GOND_BASE_URL)")
+	cmd.PersistentFlags().StringVar(&opts.apiKey, "api-key", os.Getenv("GOND_API_KEY"), "API key sent as a bearer token (env GOND_API_KEY)")
+	cmd.PersistentFlags().StringVarP(&opts.output, "output", "o", outputTable, "output format: table or json")
+
+	cmd.AddCommand(
+		newPortsCmd(opts),
+		newJobsCmd(opts),
+		newNodesCmd(opts),
+		newFabricCmd(opts),
+	)
+	return cmd
+}
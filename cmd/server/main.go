@@ -1,17 +1,26 @@
 package main
 
 import (
-	"os"
+	"context"
+	"errors"
+	"net"
+	"net/http"
 	"os/signal"
+	gosync "sync"
 	"syscall"
+	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/router"
+	"github.com/banglin/go-nd/internal/services"
 	"github.com/banglin/go-nd/internal/sync"
+	"github.com/banglin/go-nd/internal/tracing"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -26,6 +35,36 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Export traces of job provisioning and NDFC requests when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	// Fail fast on a bad port description template rather than at first provision
+	if _, err := services.ParsePortDescriptionTemplate(cfg.NexusDashboard.PortDescriptionTemplate); err != nil {
+		logger.Fatal("Invalid ND_PORT_DESCRIPTION_TEMPLATE", zap.Error(err))
+	}
+	if err := services.ValidateJobContractProtocol(cfg.NexusDashboard.JobContractProtocol); err != nil {
+		logger.Fatal("Invalid ND_JOB_CONTRACT_PROTOCOL", zap.Error(err))
+	}
+	if err := util.ValidatePortNameFormat(cfg.NexusDashboard.PortNameFormat); err != nil {
+		logger.Fatal("Invalid ND_PORT_NAME_FORMAT", zap.Error(err))
+	}
+	if err := ndclient.ValidateTransportConfig(&cfg.NexusDashboard, cfg.Server.Mode); err != nil {
+		logger.Fatal("Invalid NDFC HTTP client configuration", zap.Error(err))
+	}
+	if cfg.SecretError != nil {
+		logger.Fatal("Failed to load secrets from SECRET_STORE", zap.Error(cfg.SecretError))
+	}
+
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
@@ -43,6 +82,7 @@ func main() {
 	if err := database.Migrate(); err != nil {
 		logger.Fatal("Failed to run migrations", zap.Error(err))
 	}
+	metrics.ProvisioningSummary.SetSource(services.MonthlyProvisioningSummary(database.DB))
 
 	// Initialize Valkey cache
 	if err := cache.Initialize(&cfg.Valkey); err != nil {
@@ -59,32 +99,83 @@ func main() {
 		// Continue without ND client for local-only operations
 	}
 
+	// Shared contract lists, reloaded from configuration on SIGHUP
+	registry := services.NewRegistry(&cfg.NexusDashboard)
+	stopReload := registry.ReloadOnSIGHUP(config.Reload)
+	defer stopReload()
+
 	// Start background sync worker
 	var syncWorker *sync.Worker
 	if ndClient != nil {
 		syncWorker = sync.NewWorker(ndClient, &cfg.NexusDashboard, cfg.Server.InstanceID)
+		jobSvc := services.NewJobService(database.DB, ndClient, &cfg.NexusDashboard, registry)
+		jobSvc.SetRetentionDays(cfg.Server.RetentionDays)
+		syncWorker.AddTask(jobSvc.OrphanedAllocationTask())
+		syncWorker.AddTask(jobSvc.JobEventCleanupTask())
+		syncWorker.AddTask(jobSvc.JobPruneTask())
+		syncWorker.AddTask(jobSvc.JobPortReconcileTask())
+		syncWorker.AddTask(jobSvc.NodeDecommissionTask())
+		syncWorker.AddTask(services.NewSecurityGroupService(database.DB, ndClient).AssociationValidationTask())
 		syncWorker.Start()
 	}
 
 	// Setup router
-	r := router.Setup(ndClient, cfg)
+	r := router.Setup(ndClient, cfg, registry)
 
-	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	// Graceful shutdown: stop the sync worker and metrics server and drain in-flight requests
+	// on SIGINT/SIGTERM. main waits for all of it before closing the database and cache.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	shutdownTimeout := time.Duration(cfg.Server.ShutdownTimeoutSec) * time.Second
+	var shutdown gosync.WaitGroup
+	defer shutdown.Wait()
+
+	// Serve Prometheus metrics on their own port
+	var metricsServer *http.Server
+	if cfg.Server.MetricsPort != "" {
+		metricsServer = metrics.NewServer(cfg.Server.MetricsPort)
+		shutdown.Add(1)
+		go func() {
+			defer shutdown.Done()
+			logger.Info("Starting metrics server", zap.String("address", metricsServer.Addr))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Metrics server error", zap.Error(err))
+			}
+		}()
+	}
 
+	shutdown.Add(1)
 	go func() {
-		<-quit
+		defer shutdown.Done()
+		<-ctx.Done()
 		logger.Info("Shutting down server...")
 		if syncWorker != nil {
-			syncWorker.Stop()
+			if err := syncWorker.StopWithDrain(sync.DefaultDrainTimeout); err != nil {
+				logger.Warn("NDFC sync aborted before it finished", zap.Error(err))
+			}
+		}
+		if metricsServer != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("Metrics server shutdown error", zap.Error(err))
+			}
 		}
 	}()
 
 	// Start server
-	addr := ":" + cfg.Server.Port
-	logger.Info("Starting server", zap.String("address", addr))
-	if err := r.Run(addr); err != nil {
-		logger.Fatal("Failed to start server", zap.Error(err))
+	srv := router.NewServer(r, cfg.Server.Port)
+	listener, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		logger.Fatal("Failed to start server", zap.String("address", srv.Addr), zap.Error(err))
+	}
+	logger.Info("Starting server", zap.String("address", srv.Addr))
+	err = router.Serve(ctx, srv, listener, shutdownTimeout)
+	stop() // Also shuts down the rest when the server failed rather than being signalled
+	if err != nil {
+		logger.Error("HTTP server error", zap.Error(err))
+		return
 	}
+	shutdown.Wait()
+	logger.Info("Server shutdown complete")
 }
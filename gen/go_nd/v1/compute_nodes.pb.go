@@ -7,6 +7,7 @@
 package go_ndv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -34,6 +35,11 @@ type ComputeNode struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	PortMappings  []*PortMapping         `protobuf:"bytes,9,rep,name=port_mappings,json=portMappings,proto3" json:"port_mappings,omitempty"`
+	BmcAddress    string                 `protobuf:"bytes,10,opt,name=bmc_address,json=bmcAddress,proto3" json:"bmc_address,omitempty"`                                                 // Out-of-band management address
+	BmcUsername   string                 `protobuf:"bytes,11,opt,name=bmc_username,json=bmcUsername,proto3" json:"bmc_username,omitempty"`                                              // BMC login user (password is not stored)
+	BmcPort       int32                  `protobuf:"varint,12,opt,name=bmc_port,json=bmcPort,proto3" json:"bmc_port,omitempty"`                                                         // IPMI port (default 623)
+	Labels        map[string]string      `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Scheduling hints (e.g. gpu=a100)
+	HostnameValid bool                   `protobuf:"varint,14,opt,name=hostname_valid,json=hostnameValid,proto3" json:"hostname_valid,omitempty"`                                       // False for hostnames stored before RFC 1123 validation
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -131,6 +137,41 @@ func (x *ComputeNode) GetPortMappings() []*PortMapping {
 	return nil
 }
 
+func (x *ComputeNode) GetBmcAddress() string {
+	if x != nil {
+		return x.BmcAddress
+	}
+	return ""
+}
+
+func (x *ComputeNode) GetBmcUsername() string {
+	if x != nil {
+		return x.BmcUsername
+	}
+	return ""
+}
+
+func (x *ComputeNode) GetBmcPort() int32 {
+	if x != nil {
+		return x.BmcPort
+	}
+	return 0
+}
+
+func (x *ComputeNode) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ComputeNode) GetHostnameValid() bool {
+	if x != nil {
+		return x.HostnameValid
+	}
+	return false
+}
+
 // PortMapping maps a compute node to a switch port
 type PortMapping struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -244,6 +285,7 @@ func (x *PortMapping) GetCreatedAt() *timestamppb.Timestamp {
 type ListComputeNodesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	LabelSelector map[string]string      `protobuf:"bytes,2,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only nodes carrying every key=value
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -285,6 +327,13 @@ func (x *ListComputeNodesRequest) GetPagination() *PaginationRequest {
 	return nil
 }
 
+func (x *ListComputeNodesRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
 // ListComputeNodesResponse returns compute nodes
 type ListComputeNodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -340,10 +389,11 @@ func (x *ListComputeNodesResponse) GetPagination() *PaginationResponse {
 
 // GetComputeNodeRequest retrieves a compute node
 type GetComputeNodeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeAllocation bool                   `protobuf:"varint,2,opt,name=include_allocation,json=includeAllocation,proto3" json:"include_allocation,omitempty"` // Also return the job the node is allocated to
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetComputeNodeRequest) Reset() {
@@ -383,12 +433,20 @@ func (x *GetComputeNodeRequest) GetId() string {
 	return ""
 }
 
+func (x *GetComputeNodeRequest) GetIncludeAllocation() bool {
+	if x != nil {
+		return x.IncludeAllocation
+	}
+	return false
+}
+
 // GetComputeNodeResponse returns a compute node
 type GetComputeNodeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ComputeNode   *ComputeNode           `protobuf:"bytes,1,opt,name=compute_node,json=computeNode,proto3" json:"compute_node,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ComputeNode       *ComputeNode           `protobuf:"bytes,1,opt,name=compute_node,json=computeNode,proto3" json:"compute_node,omitempty"`
+	CurrentAllocation *ComputeNodeAllocation `protobuf:"bytes,2,opt,name=current_allocation,json=currentAllocation,proto3" json:"current_allocation,omitempty"` // Set with include_allocation when the node is allocated
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetComputeNodeResponse) Reset() {
@@ -428,6 +486,74 @@ func (x *GetComputeNodeResponse) GetComputeNode() *ComputeNode {
 	return nil
 }
 
+func (x *GetComputeNodeResponse) GetCurrentAllocation() *ComputeNodeAllocation {
+	if x != nil {
+		return x.CurrentAllocation
+	}
+	return nil
+}
+
+// ComputeNodeAllocation is the job a compute node is currently allocated to
+type ComputeNodeAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobSlurmId    string                 `protobuf:"bytes,1,opt,name=job_slurm_id,json=jobSlurmId,proto3" json:"job_slurm_id,omitempty"`
+	JobStatus     string                 `protobuf:"bytes,2,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`
+	AllocatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=allocated_at,json=allocatedAt,proto3" json:"allocated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputeNodeAllocation) Reset() {
+	*x = ComputeNodeAllocation{}
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputeNodeAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeNodeAllocation) ProtoMessage() {}
+
+func (x *ComputeNodeAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeNodeAllocation.ProtoReflect.Descriptor instead.
+func (*ComputeNodeAllocation) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ComputeNodeAllocation) GetJobSlurmId() string {
+	if x != nil {
+		return x.JobSlurmId
+	}
+	return ""
+}
+
+func (x *ComputeNodeAllocation) GetJobStatus() string {
+	if x != nil {
+		return x.JobStatus
+	}
+	return ""
+}
+
+func (x *ComputeNodeAllocation) GetAllocatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AllocatedAt
+	}
+	return nil
+}
+
 // CreateComputeNodeRequest creates a compute node
 type CreateComputeNodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -436,13 +562,16 @@ type CreateComputeNodeRequest struct {
 	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	MacAddress    string                 `protobuf:"bytes,4,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	BmcAddress    string                 `protobuf:"bytes,6,opt,name=bmc_address,json=bmcAddress,proto3" json:"bmc_address,omitempty"`
+	BmcUsername   string                 `protobuf:"bytes,7,opt,name=bmc_username,json=bmcUsername,proto3" json:"bmc_username,omitempty"`
+	BmcPort       int32                  `protobuf:"varint,8,opt,name=bmc_port,json=bmcPort,proto3" json:"bmc_port,omitempty"` // Defaults to 623
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateComputeNodeRequest) Reset() {
 	*x = CreateComputeNodeRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[6]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -454,7 +583,7 @@ func (x *CreateComputeNodeRequest) String() string {
 func (*CreateComputeNodeRequest) ProtoMessage() {}
 
 func (x *CreateComputeNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[6]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +596,7 @@ func (x *CreateComputeNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateComputeNodeRequest.ProtoReflect.Descriptor instead.
 func (*CreateComputeNodeRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{6}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CreateComputeNodeRequest) GetName() string {
@@ -505,6 +634,27 @@ func (x *CreateComputeNodeRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateComputeNodeRequest) GetBmcAddress() string {
+	if x != nil {
+		return x.BmcAddress
+	}
+	return ""
+}
+
+func (x *CreateComputeNodeRequest) GetBmcUsername() string {
+	if x != nil {
+		return x.BmcUsername
+	}
+	return ""
+}
+
+func (x *CreateComputeNodeRequest) GetBmcPort() int32 {
+	if x != nil {
+		return x.BmcPort
+	}
+	return 0
+}
+
 // CreateComputeNodeResponse returns the created compute node
 type CreateComputeNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -515,7 +665,7 @@ type CreateComputeNodeResponse struct {
 
 func (x *CreateComputeNodeResponse) Reset() {
 	*x = CreateComputeNodeResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[7]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +677,7 @@ func (x *CreateComputeNodeResponse) String() string {
 func (*CreateComputeNodeResponse) ProtoMessage() {}
 
 func (x *CreateComputeNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[7]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +690,7 @@ func (x *CreateComputeNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateComputeNodeResponse.ProtoReflect.Descriptor instead.
 func (*CreateComputeNodeResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{7}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateComputeNodeResponse) GetComputeNode() *ComputeNode {
@@ -559,13 +709,16 @@ type UpdateComputeNodeRequest struct {
 	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	MacAddress    string                 `protobuf:"bytes,5,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
 	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	BmcAddress    string                 `protobuf:"bytes,7,opt,name=bmc_address,json=bmcAddress,proto3" json:"bmc_address,omitempty"`
+	BmcUsername   string                 `protobuf:"bytes,8,opt,name=bmc_username,json=bmcUsername,proto3" json:"bmc_username,omitempty"`
+	BmcPort       int32                  `protobuf:"varint,9,opt,name=bmc_port,json=bmcPort,proto3" json:"bmc_port,omitempty"` // 0 = unchanged
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateComputeNodeRequest) Reset() {
 	*x = UpdateComputeNodeRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[8]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +730,7 @@ func (x *UpdateComputeNodeRequest) String() string {
 func (*UpdateComputeNodeRequest) ProtoMessage() {}
 
 func (x *UpdateComputeNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[8]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +743,7 @@ func (x *UpdateComputeNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateComputeNodeRequest.ProtoReflect.Descriptor instead.
 func (*UpdateComputeNodeRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{8}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateComputeNodeRequest) GetId() string {
@@ -635,6 +788,27 @@ func (x *UpdateComputeNodeRequest) GetDescription() string {
 	return ""
 }
 
+func (x *UpdateComputeNodeRequest) GetBmcAddress() string {
+	if x != nil {
+		return x.BmcAddress
+	}
+	return ""
+}
+
+func (x *UpdateComputeNodeRequest) GetBmcUsername() string {
+	if x != nil {
+		return x.BmcUsername
+	}
+	return ""
+}
+
+func (x *UpdateComputeNodeRequest) GetBmcPort() int32 {
+	if x != nil {
+		return x.BmcPort
+	}
+	return 0
+}
+
 // UpdateComputeNodeResponse returns the updated compute node
 type UpdateComputeNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -645,7 +819,7 @@ type UpdateComputeNodeResponse struct {
 
 func (x *UpdateComputeNodeResponse) Reset() {
 	*x = UpdateComputeNodeResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[9]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -657,7 +831,7 @@ func (x *UpdateComputeNodeResponse) String() string {
 func (*UpdateComputeNodeResponse) ProtoMessage() {}
 
 func (x *UpdateComputeNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[9]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +844,7 @@ func (x *UpdateComputeNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateComputeNodeResponse.ProtoReflect.Descriptor instead.
 func (*UpdateComputeNodeResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{9}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateComputeNodeResponse) GetComputeNode() *ComputeNode {
@@ -690,7 +864,7 @@ type DeleteComputeNodeRequest struct {
 
 func (x *DeleteComputeNodeRequest) Reset() {
 	*x = DeleteComputeNodeRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[10]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -702,7 +876,7 @@ func (x *DeleteComputeNodeRequest) String() string {
 func (*DeleteComputeNodeRequest) ProtoMessage() {}
 
 func (x *DeleteComputeNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[10]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,7 +889,7 @@ func (x *DeleteComputeNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteComputeNodeRequest.ProtoReflect.Descriptor instead.
 func (*DeleteComputeNodeRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{10}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DeleteComputeNodeRequest) GetId() string {
@@ -734,7 +908,7 @@ type DeleteComputeNodeResponse struct {
 
 func (x *DeleteComputeNodeResponse) Reset() {
 	*x = DeleteComputeNodeResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[11]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -746,7 +920,7 @@ func (x *DeleteComputeNodeResponse) String() string {
 func (*DeleteComputeNodeResponse) ProtoMessage() {}
 
 func (x *DeleteComputeNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[11]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,7 +933,7 @@ func (x *DeleteComputeNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteComputeNodeResponse.ProtoReflect.Descriptor instead.
 func (*DeleteComputeNodeResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{11}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{12}
 }
 
 // ListPortMappingsRequest lists port mappings for a compute node
@@ -772,7 +946,7 @@ type ListPortMappingsRequest struct {
 
 func (x *ListPortMappingsRequest) Reset() {
 	*x = ListPortMappingsRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[12]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +958,7 @@ func (x *ListPortMappingsRequest) String() string {
 func (*ListPortMappingsRequest) ProtoMessage() {}
 
 func (x *ListPortMappingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[12]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +971,7 @@ func (x *ListPortMappingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortMappingsRequest.ProtoReflect.Descriptor instead.
 func (*ListPortMappingsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{12}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListPortMappingsRequest) GetComputeNodeId() string {
@@ -817,7 +991,7 @@ type ListPortMappingsResponse struct {
 
 func (x *ListPortMappingsResponse) Reset() {
 	*x = ListPortMappingsResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[13]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -829,7 +1003,7 @@ func (x *ListPortMappingsResponse) String() string {
 func (*ListPortMappingsResponse) ProtoMessage() {}
 
 func (x *ListPortMappingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[13]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,7 +1016,7 @@ func (x *ListPortMappingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortMappingsResponse.ProtoReflect.Descriptor instead.
 func (*ListPortMappingsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{13}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListPortMappingsResponse) GetPortMappings() []*PortMapping {
@@ -865,7 +1039,7 @@ type AddPortMappingRequest struct {
 
 func (x *AddPortMappingRequest) Reset() {
 	*x = AddPortMappingRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[14]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -877,7 +1051,7 @@ func (x *AddPortMappingRequest) String() string {
 func (*AddPortMappingRequest) ProtoMessage() {}
 
 func (x *AddPortMappingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[14]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +1064,7 @@ func (x *AddPortMappingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPortMappingRequest.ProtoReflect.Descriptor instead.
 func (*AddPortMappingRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{14}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AddPortMappingRequest) GetComputeNodeId() string {
@@ -931,7 +1105,7 @@ type AddPortMappingResponse struct {
 
 func (x *AddPortMappingResponse) Reset() {
 	*x = AddPortMappingResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[15]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -943,7 +1117,7 @@ func (x *AddPortMappingResponse) String() string {
 func (*AddPortMappingResponse) ProtoMessage() {}
 
 func (x *AddPortMappingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[15]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -956,7 +1130,7 @@ func (x *AddPortMappingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPortMappingResponse.ProtoReflect.Descriptor instead.
 func (*AddPortMappingResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{15}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AddPortMappingResponse) GetPortMapping() *PortMapping {
@@ -976,7 +1150,7 @@ type DeletePortMappingRequest struct {
 
 func (x *DeletePortMappingRequest) Reset() {
 	*x = DeletePortMappingRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[16]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -988,7 +1162,7 @@ func (x *DeletePortMappingRequest) String() string {
 func (*DeletePortMappingRequest) ProtoMessage() {}
 
 func (x *DeletePortMappingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[16]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1175,7 @@ func (x *DeletePortMappingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePortMappingRequest.ProtoReflect.Descriptor instead.
 func (*DeletePortMappingRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{16}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeletePortMappingRequest) GetId() string {
@@ -1020,7 +1194,7 @@ type DeletePortMappingResponse struct {
 
 func (x *DeletePortMappingResponse) Reset() {
 	*x = DeletePortMappingResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[17]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1032,7 +1206,7 @@ func (x *DeletePortMappingResponse) String() string {
 func (*DeletePortMappingResponse) ProtoMessage() {}
 
 func (x *DeletePortMappingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[17]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1219,7 @@ func (x *DeletePortMappingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePortMappingResponse.ProtoReflect.Descriptor instead.
 func (*DeletePortMappingResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{17}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{18}
 }
 
 // ComputeNodeInterface represents a network interface on a compute node
@@ -1059,13 +1233,14 @@ type ComputeNodeInterface struct {
 	MacAddress    string                 `protobuf:"bytes,6,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Label         string                 `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"` // Operator annotation (e.g., "primary 100G NIC")
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ComputeNodeInterface) Reset() {
 	*x = ComputeNodeInterface{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[18]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1077,7 +1252,7 @@ func (x *ComputeNodeInterface) String() string {
 func (*ComputeNodeInterface) ProtoMessage() {}
 
 func (x *ComputeNodeInterface) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[18]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,7 +1265,7 @@ func (x *ComputeNodeInterface) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ComputeNodeInterface.ProtoReflect.Descriptor instead.
 func (*ComputeNodeInterface) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{18}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ComputeNodeInterface) GetId() string {
@@ -1149,6 +1324,13 @@ func (x *ComputeNodeInterface) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ComputeNodeInterface) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 // ListInterfacesRequest lists interfaces for a compute node
 type ListInterfacesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1159,7 +1341,7 @@ type ListInterfacesRequest struct {
 
 func (x *ListInterfacesRequest) Reset() {
 	*x = ListInterfacesRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[19]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1171,7 +1353,7 @@ func (x *ListInterfacesRequest) String() string {
 func (*ListInterfacesRequest) ProtoMessage() {}
 
 func (x *ListInterfacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[19]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +1366,7 @@ func (x *ListInterfacesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInterfacesRequest.ProtoReflect.Descriptor instead.
 func (*ListInterfacesRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{19}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ListInterfacesRequest) GetComputeNodeId() string {
@@ -1204,7 +1386,7 @@ type ListInterfacesResponse struct {
 
 func (x *ListInterfacesResponse) Reset() {
 	*x = ListInterfacesResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[20]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1216,7 +1398,7 @@ func (x *ListInterfacesResponse) String() string {
 func (*ListInterfacesResponse) ProtoMessage() {}
 
 func (x *ListInterfacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[20]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1229,7 +1411,7 @@ func (x *ListInterfacesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInterfacesResponse.ProtoReflect.Descriptor instead.
 func (*ListInterfacesResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{20}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListInterfacesResponse) GetInterfaces() []*ComputeNodeInterface {
@@ -1247,13 +1429,14 @@ type CreateInterfaceRequest struct {
 	Hostname      string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
 	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	MacAddress    string                 `protobuf:"bytes,5,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	Label         string                 `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateInterfaceRequest) Reset() {
 	*x = CreateInterfaceRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[21]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1265,7 +1448,7 @@ func (x *CreateInterfaceRequest) String() string {
 func (*CreateInterfaceRequest) ProtoMessage() {}
 
 func (x *CreateInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[21]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1278,7 +1461,7 @@ func (x *CreateInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*CreateInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{21}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CreateInterfaceRequest) GetComputeNodeId() string {
@@ -1316,6 +1499,13 @@ func (x *CreateInterfaceRequest) GetMacAddress() string {
 	return ""
 }
 
+func (x *CreateInterfaceRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 // CreateInterfaceResponse returns the created interface
 type CreateInterfaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1326,7 +1516,7 @@ type CreateInterfaceResponse struct {
 
 func (x *CreateInterfaceResponse) Reset() {
 	*x = CreateInterfaceResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[22]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1338,7 +1528,7 @@ func (x *CreateInterfaceResponse) String() string {
 func (*CreateInterfaceResponse) ProtoMessage() {}
 
 func (x *CreateInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[22]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,7 +1541,7 @@ func (x *CreateInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*CreateInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{22}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateInterfaceResponse) GetInterface() *ComputeNodeInterface {
@@ -1369,13 +1559,14 @@ type UpdateInterfaceRequest struct {
 	Hostname      string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
 	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	MacAddress    string                 `protobuf:"bytes,5,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	Label         string                 `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateInterfaceRequest) Reset() {
 	*x = UpdateInterfaceRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[23]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1387,7 +1578,7 @@ func (x *UpdateInterfaceRequest) String() string {
 func (*UpdateInterfaceRequest) ProtoMessage() {}
 
 func (x *UpdateInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[23]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1400,7 +1591,7 @@ func (x *UpdateInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*UpdateInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{23}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *UpdateInterfaceRequest) GetId() string {
@@ -1438,6 +1629,13 @@ func (x *UpdateInterfaceRequest) GetMacAddress() string {
 	return ""
 }
 
+func (x *UpdateInterfaceRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 // UpdateInterfaceResponse returns the updated interface
 type UpdateInterfaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1448,7 +1646,7 @@ type UpdateInterfaceResponse struct {
 
 func (x *UpdateInterfaceResponse) Reset() {
 	*x = UpdateInterfaceResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[24]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1460,7 +1658,7 @@ func (x *UpdateInterfaceResponse) String() string {
 func (*UpdateInterfaceResponse) ProtoMessage() {}
 
 func (x *UpdateInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[24]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1473,7 +1671,7 @@ func (x *UpdateInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*UpdateInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{24}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *UpdateInterfaceResponse) GetInterface() *ComputeNodeInterface {
@@ -1494,7 +1692,7 @@ type DeleteInterfaceRequest struct {
 
 func (x *DeleteInterfaceRequest) Reset() {
 	*x = DeleteInterfaceRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[25]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1506,7 +1704,7 @@ func (x *DeleteInterfaceRequest) String() string {
 func (*DeleteInterfaceRequest) ProtoMessage() {}
 
 func (x *DeleteInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[25]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,7 +1717,7 @@ func (x *DeleteInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*DeleteInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{25}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DeleteInterfaceRequest) GetId() string {
@@ -1545,7 +1743,7 @@ type DeleteInterfaceResponse struct {
 
 func (x *DeleteInterfaceResponse) Reset() {
 	*x = DeleteInterfaceResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[26]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1557,7 +1755,7 @@ func (x *DeleteInterfaceResponse) String() string {
 func (*DeleteInterfaceResponse) ProtoMessage() {}
 
 func (x *DeleteInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[26]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1570,7 +1768,7 @@ func (x *DeleteInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*DeleteInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{26}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{27}
 }
 
 // AssignPortToInterfaceRequest assigns a port mapping to an interface
@@ -1585,7 +1783,7 @@ type AssignPortToInterfaceRequest struct {
 
 func (x *AssignPortToInterfaceRequest) Reset() {
 	*x = AssignPortToInterfaceRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[27]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1597,7 +1795,7 @@ func (x *AssignPortToInterfaceRequest) String() string {
 func (*AssignPortToInterfaceRequest) ProtoMessage() {}
 
 func (x *AssignPortToInterfaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[27]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1610,7 +1808,7 @@ func (x *AssignPortToInterfaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPortToInterfaceRequest.ProtoReflect.Descriptor instead.
 func (*AssignPortToInterfaceRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{27}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *AssignPortToInterfaceRequest) GetComputeNodeId() string {
@@ -1644,7 +1842,7 @@ type AssignPortToInterfaceResponse struct {
 
 func (x *AssignPortToInterfaceResponse) Reset() {
 	*x = AssignPortToInterfaceResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[28]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1656,7 +1854,7 @@ func (x *AssignPortToInterfaceResponse) String() string {
 func (*AssignPortToInterfaceResponse) ProtoMessage() {}
 
 func (x *AssignPortToInterfaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[28]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1669,7 +1867,7 @@ func (x *AssignPortToInterfaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPortToInterfaceResponse.ProtoReflect.Descriptor instead.
 func (*AssignPortToInterfaceResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{28}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *AssignPortToInterfaceResponse) GetPortMapping() *PortMapping {
@@ -1691,7 +1889,7 @@ type BulkPortAssignment struct {
 
 func (x *BulkPortAssignment) Reset() {
 	*x = BulkPortAssignment{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[29]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1703,7 +1901,7 @@ func (x *BulkPortAssignment) String() string {
 func (*BulkPortAssignment) ProtoMessage() {}
 
 func (x *BulkPortAssignment) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[29]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1716,7 +1914,7 @@ func (x *BulkPortAssignment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkPortAssignment.ProtoReflect.Descriptor instead.
 func (*BulkPortAssignment) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{29}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *BulkPortAssignment) GetSwitchPortId() string {
@@ -1754,7 +1952,7 @@ type BulkAssignmentResult struct {
 
 func (x *BulkAssignmentResult) Reset() {
 	*x = BulkAssignmentResult{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[30]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1766,7 +1964,7 @@ func (x *BulkAssignmentResult) String() string {
 func (*BulkAssignmentResult) ProtoMessage() {}
 
 func (x *BulkAssignmentResult) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[30]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1779,7 +1977,7 @@ func (x *BulkAssignmentResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkAssignmentResult.ProtoReflect.Descriptor instead.
 func (*BulkAssignmentResult) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{30}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *BulkAssignmentResult) GetSwitchPortId() string {
@@ -1819,15 +2017,17 @@ func (x *BulkAssignmentResult) GetError() string {
 
 // BulkAssignPortMappingsRequest assigns multiple ports
 type BulkAssignPortMappingsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Assignments   []*BulkPortAssignment  `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Assignments []*BulkPortAssignment  `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	// Roll back every assignment if any fails; by default successful assignments are committed
+	Atomic        bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BulkAssignPortMappingsRequest) Reset() {
 	*x = BulkAssignPortMappingsRequest{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[31]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1839,7 +2039,7 @@ func (x *BulkAssignPortMappingsRequest) String() string {
 func (*BulkAssignPortMappingsRequest) ProtoMessage() {}
 
 func (x *BulkAssignPortMappingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[31]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1852,7 +2052,7 @@ func (x *BulkAssignPortMappingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkAssignPortMappingsRequest.ProtoReflect.Descriptor instead.
 func (*BulkAssignPortMappingsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{31}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *BulkAssignPortMappingsRequest) GetAssignments() []*BulkPortAssignment {
@@ -1862,18 +2062,27 @@ func (x *BulkAssignPortMappingsRequest) GetAssignments() []*BulkPortAssignment {
 	return nil
 }
 
+func (x *BulkAssignPortMappingsRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
 // BulkAssignPortMappingsResponse returns results
 type BulkAssignPortMappingsResponse struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	Results       []*BulkAssignmentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Failed        int32                   `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Committed     bool                    `protobuf:"varint,4,opt,name=committed,proto3" json:"committed,omitempty"` // False when an atomic request was rolled back
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BulkAssignPortMappingsResponse) Reset() {
 	*x = BulkAssignPortMappingsResponse{}
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[32]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1885,7 +2094,7 @@ func (x *BulkAssignPortMappingsResponse) String() string {
 func (*BulkAssignPortMappingsResponse) ProtoMessage() {}
 
 func (x *BulkAssignPortMappingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[32]
+	mi := &file_go_nd_v1_compute_nodes_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1898,7 +2107,7 @@ func (x *BulkAssignPortMappingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkAssignPortMappingsResponse.ProtoReflect.Descriptor instead.
 func (*BulkAssignPortMappingsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{32}
+	return file_go_nd_v1_compute_nodes_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *BulkAssignPortMappingsResponse) GetResults() []*BulkAssignmentResult {
@@ -1915,11 +2124,25 @@ func (x *BulkAssignPortMappingsResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *BulkAssignPortMappingsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkAssignPortMappingsResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
 var File_go_nd_v1_compute_nodes_proto protoreflect.FileDescriptor
 
 const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\n" +
-	"\x1cgo_nd/v1/compute_nodes.proto\x12\bgo_nd.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\xe1\x02\n" +
+	"\x1cgo_nd/v1/compute_nodes.proto\x12\bgo_nd.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\xdd\x04\n" +
 	"\vComputeNode\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -1933,7 +2156,17 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12:\n" +
-	"\rport_mappings\x18\t \x03(\v2\x15.go_nd.v1.PortMappingR\fportMappings\"\xbd\x02\n" +
+	"\rport_mappings\x18\t \x03(\v2\x15.go_nd.v1.PortMappingR\fportMappings\x12\x1f\n" +
+	"\vbmc_address\x18\n" +
+	" \x01(\tR\n" +
+	"bmcAddress\x12!\n" +
+	"\fbmc_username\x18\v \x01(\tR\vbmcUsername\x12\x19\n" +
+	"\bbmc_port\x18\f \x01(\x05R\abmcPort\x129\n" +
+	"\x06labels\x18\r \x03(\v2!.go_nd.v1.ComputeNode.LabelsEntryR\x06labels\x12%\n" +
+	"\x0ehostname_valid\x18\x0e \x01(\bR\rhostnameValid\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x02\n" +
 	"\vPortMapping\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
 	"\x0fcompute_node_id\x18\x02 \x01(\tR\rcomputeNodeId\x12$\n" +
@@ -1945,20 +2178,32 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\bnic_name\x18\a \x01(\tR\anicName\x12\x12\n" +
 	"\x04vlan\x18\b \x01(\x05R\x04vlan\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"V\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf5\x01\n" +
 	"\x17ListComputeNodesRequest\x12;\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2\x1b.go_nd.v1.PaginationRequestR\n" +
-	"pagination\"\x94\x01\n" +
+	"pagination\x12[\n" +
+	"\x0elabel_selector\x18\x02 \x03(\v24.go_nd.v1.ListComputeNodesRequest.LabelSelectorEntryR\rlabelSelector\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x94\x01\n" +
 	"\x18ListComputeNodesResponse\x12:\n" +
 	"\rcompute_nodes\x18\x01 \x03(\v2\x15.go_nd.v1.ComputeNodeR\fcomputeNodes\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.go_nd.v1.PaginationResponseR\n" +
-	"pagination\"'\n" +
+	"pagination\"V\n" +
 	"\x15GetComputeNodeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x12include_allocation\x18\x02 \x01(\bR\x11includeAllocation\"\xa2\x01\n" +
 	"\x16GetComputeNodeResponse\x128\n" +
-	"\fcompute_node\x18\x01 \x01(\v2\x15.go_nd.v1.ComputeNodeR\vcomputeNode\"\xac\x01\n" +
+	"\fcompute_node\x18\x01 \x01(\v2\x15.go_nd.v1.ComputeNodeR\vcomputeNode\x12N\n" +
+	"\x12current_allocation\x18\x02 \x01(\v2\x1f.go_nd.v1.ComputeNodeAllocationR\x11currentAllocation\"\x97\x01\n" +
+	"\x15ComputeNodeAllocation\x12 \n" +
+	"\fjob_slurm_id\x18\x01 \x01(\tR\n" +
+	"jobSlurmId\x12\x1d\n" +
+	"\n" +
+	"job_status\x18\x02 \x01(\tR\tjobStatus\x12=\n" +
+	"\fallocated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vallocatedAt\"\x8b\x02\n" +
 	"\x18CreateComputeNodeRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1d\n" +
@@ -1966,9 +2211,13 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x1f\n" +
 	"\vmac_address\x18\x04 \x01(\tR\n" +
 	"macAddress\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\"U\n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vbmc_address\x18\x06 \x01(\tR\n" +
+	"bmcAddress\x12!\n" +
+	"\fbmc_username\x18\a \x01(\tR\vbmcUsername\x12\x19\n" +
+	"\bbmc_port\x18\b \x01(\x05R\abmcPort\"U\n" +
 	"\x19CreateComputeNodeResponse\x128\n" +
-	"\fcompute_node\x18\x01 \x01(\v2\x15.go_nd.v1.ComputeNodeR\vcomputeNode\"\xbc\x01\n" +
+	"\fcompute_node\x18\x01 \x01(\v2\x15.go_nd.v1.ComputeNodeR\vcomputeNode\"\x9b\x02\n" +
 	"\x18UpdateComputeNodeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -1977,7 +2226,11 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1f\n" +
 	"\vmac_address\x18\x05 \x01(\tR\n" +
 	"macAddress\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\"U\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vbmc_address\x18\a \x01(\tR\n" +
+	"bmcAddress\x12!\n" +
+	"\fbmc_username\x18\b \x01(\tR\vbmcUsername\x12\x19\n" +
+	"\bbmc_port\x18\t \x01(\x05R\abmcPort\"U\n" +
 	"\x19UpdateComputeNodeResponse\x128\n" +
 	"\fcompute_node\x18\x01 \x01(\v2\x15.go_nd.v1.ComputeNodeR\vcomputeNode\"*\n" +
 	"\x18DeleteComputeNodeRequest\x12\x0e\n" +
@@ -1996,7 +2249,7 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\fport_mapping\x18\x01 \x01(\v2\x15.go_nd.v1.PortMappingR\vportMapping\"*\n" +
 	"\x18DeletePortMappingRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
-	"\x19DeletePortMappingResponse\"\xb4\x02\n" +
+	"\x19DeletePortMappingResponse\"\xca\x02\n" +
 	"\x14ComputeNodeInterface\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
 	"\x0fcompute_node_id\x18\x02 \x01(\tR\rcomputeNodeId\x12\x12\n" +
@@ -2009,13 +2262,14 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"?\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05label\x18\t \x01(\tR\x05label\"?\n" +
 	"\x15ListInterfacesRequest\x12&\n" +
 	"\x0fcompute_node_id\x18\x01 \x01(\tR\rcomputeNodeId\"X\n" +
 	"\x16ListInterfacesResponse\x12>\n" +
 	"\n" +
 	"interfaces\x18\x01 \x03(\v2\x1e.go_nd.v1.ComputeNodeInterfaceR\n" +
-	"interfaces\"\xb0\x01\n" +
+	"interfaces\"\xc6\x01\n" +
 	"\x16CreateInterfaceRequest\x12&\n" +
 	"\x0fcompute_node_id\x18\x01 \x01(\tR\rcomputeNodeId\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1a\n" +
@@ -2023,9 +2277,10 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1f\n" +
 	"\vmac_address\x18\x05 \x01(\tR\n" +
-	"macAddress\"W\n" +
+	"macAddress\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\"W\n" +
 	"\x17CreateInterfaceResponse\x12<\n" +
-	"\tinterface\x18\x01 \x01(\v2\x1e.go_nd.v1.ComputeNodeInterfaceR\tinterface\"\xac\x01\n" +
+	"\tinterface\x18\x01 \x01(\v2\x1e.go_nd.v1.ComputeNodeInterfaceR\tinterface\"\xc2\x01\n" +
 	"\x16UpdateInterfaceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
 	"\x0fcompute_node_id\x18\x02 \x01(\tR\rcomputeNodeId\x12\x1a\n" +
@@ -2033,7 +2288,8 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1f\n" +
 	"\vmac_address\x18\x05 \x01(\tR\n" +
-	"macAddress\"W\n" +
+	"macAddress\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\"W\n" +
 	"\x17UpdateInterfaceResponse\x12<\n" +
 	"\tinterface\x18\x01 \x01(\v2\x1e.go_nd.v1.ComputeNodeInterfaceR\tinterface\"P\n" +
 	"\x16DeleteInterfaceRequest\x12\x0e\n" +
@@ -2056,28 +2312,30 @@ const file_go_nd_v1_compute_nodes_proto_rawDesc = "" +
 	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1d\n" +
 	"\n" +
 	"mapping_id\x18\x04 \x01(\tR\tmappingId\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"_\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"w\n" +
 	"\x1dBulkAssignPortMappingsRequest\x12>\n" +
-	"\vassignments\x18\x01 \x03(\v2\x1c.go_nd.v1.BulkPortAssignmentR\vassignments\"p\n" +
+	"\vassignments\x18\x01 \x03(\v2\x1c.go_nd.v1.BulkPortAssignmentR\vassignments\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"\xa6\x01\n" +
 	"\x1eBulkAssignPortMappingsResponse\x128\n" +
 	"\aresults\x18\x01 \x03(\v2\x1e.go_nd.v1.BulkAssignmentResultR\aresults\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xa1\n" +
-	"\n" +
-	"\x13ComputeNodesService\x12Y\n" +
-	"\x10ListComputeNodes\x12!.go_nd.v1.ListComputeNodesRequest\x1a\".go_nd.v1.ListComputeNodesResponse\x12S\n" +
-	"\x0eGetComputeNode\x12\x1f.go_nd.v1.GetComputeNodeRequest\x1a .go_nd.v1.GetComputeNodeResponse\x12\\\n" +
-	"\x11CreateComputeNode\x12\".go_nd.v1.CreateComputeNodeRequest\x1a#.go_nd.v1.CreateComputeNodeResponse\x12\\\n" +
-	"\x11UpdateComputeNode\x12\".go_nd.v1.UpdateComputeNodeRequest\x1a#.go_nd.v1.UpdateComputeNodeResponse\x12\\\n" +
-	"\x11DeleteComputeNode\x12\".go_nd.v1.DeleteComputeNodeRequest\x1a#.go_nd.v1.DeleteComputeNodeResponse\x12Y\n" +
-	"\x10ListPortMappings\x12!.go_nd.v1.ListPortMappingsRequest\x1a\".go_nd.v1.ListPortMappingsResponse\x12S\n" +
-	"\x0eAddPortMapping\x12\x1f.go_nd.v1.AddPortMappingRequest\x1a .go_nd.v1.AddPortMappingResponse\x12\\\n" +
-	"\x11DeletePortMapping\x12\".go_nd.v1.DeletePortMappingRequest\x1a#.go_nd.v1.DeletePortMappingResponse\x12S\n" +
-	"\x0eListInterfaces\x12\x1f.go_nd.v1.ListInterfacesRequest\x1a .go_nd.v1.ListInterfacesResponse\x12V\n" +
-	"\x0fCreateInterface\x12 .go_nd.v1.CreateInterfaceRequest\x1a!.go_nd.v1.CreateInterfaceResponse\x12V\n" +
-	"\x0fUpdateInterface\x12 .go_nd.v1.UpdateInterfaceRequest\x1a!.go_nd.v1.UpdateInterfaceResponse\x12V\n" +
-	"\x0fDeleteInterface\x12 .go_nd.v1.DeleteInterfaceRequest\x1a!.go_nd.v1.DeleteInterfaceResponse\x12h\n" +
-	"\x15AssignPortToInterface\x12&.go_nd.v1.AssignPortToInterfaceRequest\x1a'.go_nd.v1.AssignPortToInterfaceResponse\x12k\n" +
-	"\x16BulkAssignPortMappings\x12'.go_nd.v1.BulkAssignPortMappingsRequest\x1a(.go_nd.v1.BulkAssignPortMappingsResponseB\x8d\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tcommitted\x18\x04 \x01(\bR\tcommitted2\xce\x0f\n" +
+	"\x13ComputeNodesService\x12t\n" +
+	"\x10ListComputeNodes\x12!.go_nd.v1.ListComputeNodesRequest\x1a\".go_nd.v1.ListComputeNodesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/compute-nodes\x12s\n" +
+	"\x0eGetComputeNode\x12\x1f.go_nd.v1.GetComputeNodeRequest\x1a .go_nd.v1.GetComputeNodeResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/compute-nodes/{id}\x12z\n" +
+	"\x11CreateComputeNode\x12\".go_nd.v1.CreateComputeNodeRequest\x1a#.go_nd.v1.CreateComputeNodeResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/compute-nodes\x12\x7f\n" +
+	"\x11UpdateComputeNode\x12\".go_nd.v1.UpdateComputeNodeRequest\x1a#.go_nd.v1.UpdateComputeNodeResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/v1/compute-nodes/{id}\x12|\n" +
+	"\x11DeleteComputeNode\x12\".go_nd.v1.DeleteComputeNodeRequest\x1a#.go_nd.v1.DeleteComputeNodeResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/compute-nodes/{id}\x12\x94\x01\n" +
+	"\x10ListPortMappings\x12!.go_nd.v1.ListPortMappingsRequest\x1a\".go_nd.v1.ListPortMappingsResponse\"9\x82\xd3\xe4\x93\x023\x121/v1/compute-nodes/{compute_node_id}/port-mappings\x12\x91\x01\n" +
+	"\x0eAddPortMapping\x12\x1f.go_nd.v1.AddPortMappingRequest\x1a .go_nd.v1.AddPortMappingResponse\"<\x82\xd3\xe4\x93\x026:\x01*\"1/v1/compute-nodes/{compute_node_id}/port-mappings\x12|\n" +
+	"\x11DeletePortMapping\x12\".go_nd.v1.DeletePortMappingRequest\x1a#.go_nd.v1.DeletePortMappingResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/port-mappings/{id}\x12\x8b\x01\n" +
+	"\x0eListInterfaces\x12\x1f.go_nd.v1.ListInterfacesRequest\x1a .go_nd.v1.ListInterfacesResponse\"6\x82\xd3\xe4\x93\x020\x12./v1/compute-nodes/{compute_node_id}/interfaces\x12\x91\x01\n" +
+	"\x0fCreateInterface\x12 .go_nd.v1.CreateInterfaceRequest\x1a!.go_nd.v1.CreateInterfaceResponse\"9\x82\xd3\xe4\x93\x023:\x01*\"./v1/compute-nodes/{compute_node_id}/interfaces\x12\x96\x01\n" +
+	"\x0fUpdateInterface\x12 .go_nd.v1.UpdateInterfaceRequest\x1a!.go_nd.v1.UpdateInterfaceResponse\">\x82\xd3\xe4\x93\x028:\x01*\x1a3/v1/compute-nodes/{compute_node_id}/interfaces/{id}\x12\x93\x01\n" +
+	"\x0fDeleteInterface\x12 .go_nd.v1.DeleteInterfaceRequest\x1a!.go_nd.v1.DeleteInterfaceResponse\";\x82\xd3\xe4\x93\x025*3/v1/compute-nodes/{compute_node_id}/interfaces/{id}\x12\xbf\x01\n" +
+	"\x15AssignPortToInterface\x12&.go_nd.v1.AssignPortToInterfaceRequest\x1a'.go_nd.v1.AssignPortToInterfaceResponse\"U\x82\xd3\xe4\x93\x02O:\x01*\"J/v1/compute-nodes/{compute_node_id}/port-mappings/{port_mapping_id}:assign\x12\x94\x01\n" +
+	"\x16BulkAssignPortMappings\x12'.go_nd.v1.BulkAssignPortMappingsRequest\x1a(.go_nd.v1.BulkAssignPortMappingsResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/port-mappings:bulkAssignB\x8d\x01\n" +
 	"\fcom.go_nd.v1B\x11ComputeNodesProtoP\x01Z-github.com/banglin/go-nd/gen/go_nd/v1;go_ndv1\xa2\x02\x03GXX\xaa\x02\aGoNd.V1\xca\x02\aGoNd\\V1\xe2\x02\x13GoNd\\V1\\GPBMetadata\xea\x02\bGoNd::V1b\x06proto3"
 
 var (
@@ -2092,7 +2350,7 @@ func file_go_nd_v1_compute_nodes_proto_rawDescGZIP() []byte {
 	return file_go_nd_v1_compute_nodes_proto_rawDescData
 }
 
-var file_go_nd_v1_compute_nodes_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_go_nd_v1_compute_nodes_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_go_nd_v1_compute_nodes_proto_goTypes = []any{
 	(*ComputeNode)(nil),                    // 0: go_nd.v1.ComputeNode
 	(*PortMapping)(nil),                    // 1: go_nd.v1.PortMapping
@@ -2100,91 +2358,98 @@ var file_go_nd_v1_compute_nodes_proto_goTypes = []any{
 	(*ListComputeNodesResponse)(nil),       // 3: go_nd.v1.ListComputeNodesResponse
 	(*GetComputeNodeRequest)(nil),          // 4: go_nd.v1.GetComputeNodeRequest
 	(*GetComputeNodeResponse)(nil),         // 5: go_nd.v1.GetComputeNodeResponse
-	(*CreateComputeNodeRequest)(nil),       // 6: go_nd.v1.CreateComputeNodeRequest
-	(*CreateComputeNodeResponse)(nil),      // 7: go_nd.v1.CreateComputeNodeResponse
-	(*UpdateComputeNodeRequest)(nil),       // 8: go_nd.v1.UpdateComputeNodeRequest
-	(*UpdateComputeNodeResponse)(nil),      // 9: go_nd.v1.UpdateComputeNodeResponse
-	(*DeleteComputeNodeRequest)(nil),       // 10: go_nd.v1.DeleteComputeNodeRequest
-	(*DeleteComputeNodeResponse)(nil),      // 11: go_nd.v1.DeleteComputeNodeResponse
-	(*ListPortMappingsRequest)(nil),        // 12: go_nd.v1.ListPortMappingsRequest
-	(*ListPortMappingsResponse)(nil),       // 13: go_nd.v1.ListPortMappingsResponse
-	(*AddPortMappingRequest)(nil),          // 14: go_nd.v1.AddPortMappingRequest
-	(*AddPortMappingResponse)(nil),         // 15: go_nd.v1.AddPortMappingResponse
-	(*DeletePortMappingRequest)(nil),       // 16: go_nd.v1.DeletePortMappingRequest
-	(*DeletePortMappingResponse)(nil),      // 17: go_nd.v1.DeletePortMappingResponse
-	(*ComputeNodeInterface)(nil),           // 18: go_nd.v1.ComputeNodeInterface
-	(*ListInterfacesRequest)(nil),          // 19: go_nd.v1.ListInterfacesRequest
-	(*ListInterfacesResponse)(nil),         // 20: go_nd.v1.ListInterfacesResponse
-	(*CreateInterfaceRequest)(nil),         // 21: go_nd.v1.CreateInterfaceRequest
-	(*CreateInterfaceResponse)(nil),        // 22: go_nd.v1.CreateInterfaceResponse
-	(*UpdateInterfaceRequest)(nil),         // 23: go_nd.v1.UpdateInterfaceRequest
-	(*UpdateInterfaceResponse)(nil),        // 24: go_nd.v1.UpdateInterfaceResponse
-	(*DeleteInterfaceRequest)(nil),         // 25: go_nd.v1.DeleteInterfaceRequest
-	(*DeleteInterfaceResponse)(nil),        // 26: go_nd.v1.DeleteInterfaceResponse
-	(*AssignPortToInterfaceRequest)(nil),   // 27: go_nd.v1.AssignPortToInterfaceRequest
-	(*AssignPortToInterfaceResponse)(nil),  // 28: go_nd.v1.AssignPortToInterfaceResponse
-	(*BulkPortAssignment)(nil),             // 29: go_nd.v1.BulkPortAssignment
-	(*BulkAssignmentResult)(nil),           // 30: go_nd.v1.BulkAssignmentResult
-	(*BulkAssignPortMappingsRequest)(nil),  // 31: go_nd.v1.BulkAssignPortMappingsRequest
-	(*BulkAssignPortMappingsResponse)(nil), // 32: go_nd.v1.BulkAssignPortMappingsResponse
-	(*timestamppb.Timestamp)(nil),          // 33: google.protobuf.Timestamp
-	(*PaginationRequest)(nil),              // 34: go_nd.v1.PaginationRequest
-	(*PaginationResponse)(nil),             // 35: go_nd.v1.PaginationResponse
+	(*ComputeNodeAllocation)(nil),          // 6: go_nd.v1.ComputeNodeAllocation
+	(*CreateComputeNodeRequest)(nil),       // 7: go_nd.v1.CreateComputeNodeRequest
+	(*CreateComputeNodeResponse)(nil),      // 8: go_nd.v1.CreateComputeNodeResponse
+	(*UpdateComputeNodeRequest)(nil),       // 9: go_nd.v1.UpdateComputeNodeRequest
+	(*UpdateComputeNodeResponse)(nil),      // 10: go_nd.v1.UpdateComputeNodeResponse
+	(*DeleteComputeNodeRequest)(nil),       // 11: go_nd.v1.DeleteComputeNodeRequest
+	(*DeleteComputeNodeResponse)(nil),      // 12: go_nd.v1.DeleteComputeNodeResponse
+	(*ListPortMappingsRequest)(nil),        // 13: go_nd.v1.ListPortMappingsRequest
+	(*ListPortMappingsResponse)(nil),       // 14: go_nd.v1.ListPortMappingsResponse
+	(*AddPortMappingRequest)(nil),          // 15: go_nd.v1.AddPortMappingRequest
+	(*AddPortMappingResponse)(nil),         // 16: go_nd.v1.AddPortMappingResponse
+	(*DeletePortMappingRequest)(nil),       // 17: go_nd.v1.DeletePortMappingRequest
+	(*DeletePortMappingResponse)(nil),      // 18: go_nd.v1.DeletePortMappingResponse
+	(*ComputeNodeInterface)(nil),           // 19: go_nd.v1.ComputeNodeInterface
+	(*ListInterfacesRequest)(nil),          // 20: go_nd.v1.ListInterfacesRequest
+	(*ListInterfacesResponse)(nil),         // 21: go_nd.v1.ListInterfacesResponse
+	(*CreateInterfaceRequest)(nil),         // 22: go_nd.v1.CreateInterfaceRequest
+	(*CreateInterfaceResponse)(nil),        // 23: go_nd.v1.CreateInterfaceResponse
+	(*UpdateInterfaceRequest)(nil),         // 24: go_nd.v1.UpdateInterfaceRequest
+	(*UpdateInterfaceResponse)(nil),        // 25: go_nd.v1.UpdateInterfaceResponse
+	(*DeleteInterfaceRequest)(nil),         // 26: go_nd.v1.DeleteInterfaceRequest
+	(*DeleteInterfaceResponse)(nil),        // 27: go_nd.v1.DeleteInterfaceResponse
+	(*AssignPortToInterfaceRequest)(nil),   // 28: go_nd.v1.AssignPortToInterfaceRequest
+	(*AssignPortToInterfaceResponse)(nil),  // 29: go_nd.v1.AssignPortToInterfaceResponse
+	(*BulkPortAssignment)(nil),             // 30: go_nd.v1.BulkPortAssignment
+	(*BulkAssignmentResult)(nil),           // 31: go_nd.v1.BulkAssignmentResult
+	(*BulkAssignPortMappingsRequest)(nil),  // 32: go_nd.v1.BulkAssignPortMappingsRequest
+	(*BulkAssignPortMappingsResponse)(nil), // 33: go_nd.v1.BulkAssignPortMappingsResponse
+	nil,                                    // 34: go_nd.v1.ComputeNode.LabelsEntry
+	nil,                                    // 35: go_nd.v1.ListComputeNodesRequest.LabelSelectorEntry
+	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),              // 37: go_nd.v1.PaginationRequest
+	(*PaginationResponse)(nil),             // 38: go_nd.v1.PaginationResponse
 }
 var file_go_nd_v1_compute_nodes_proto_depIdxs = []int32{
-	33, // 0: go_nd.v1.ComputeNode.created_at:type_name -> google.protobuf.Timestamp
-	33, // 1: go_nd.v1.ComputeNode.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 0: go_nd.v1.ComputeNode.created_at:type_name -> google.protobuf.Timestamp
+	36, // 1: go_nd.v1.ComputeNode.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 2: go_nd.v1.ComputeNode.port_mappings:type_name -> go_nd.v1.PortMapping
-	33, // 3: go_nd.v1.PortMapping.created_at:type_name -> google.protobuf.Timestamp
-	34, // 4: go_nd.v1.ListComputeNodesRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	0,  // 5: go_nd.v1.ListComputeNodesResponse.compute_nodes:type_name -> go_nd.v1.ComputeNode
-	35, // 6: go_nd.v1.ListComputeNodesResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	0,  // 7: go_nd.v1.GetComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
-	0,  // 8: go_nd.v1.CreateComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
-	0,  // 9: go_nd.v1.UpdateComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
-	1,  // 10: go_nd.v1.ListPortMappingsResponse.port_mappings:type_name -> go_nd.v1.PortMapping
-	1,  // 11: go_nd.v1.AddPortMappingResponse.port_mapping:type_name -> go_nd.v1.PortMapping
-	33, // 12: go_nd.v1.ComputeNodeInterface.created_at:type_name -> google.protobuf.Timestamp
-	33, // 13: go_nd.v1.ComputeNodeInterface.updated_at:type_name -> google.protobuf.Timestamp
-	18, // 14: go_nd.v1.ListInterfacesResponse.interfaces:type_name -> go_nd.v1.ComputeNodeInterface
-	18, // 15: go_nd.v1.CreateInterfaceResponse.interface:type_name -> go_nd.v1.ComputeNodeInterface
-	18, // 16: go_nd.v1.UpdateInterfaceResponse.interface:type_name -> go_nd.v1.ComputeNodeInterface
-	1,  // 17: go_nd.v1.AssignPortToInterfaceResponse.port_mapping:type_name -> go_nd.v1.PortMapping
-	29, // 18: go_nd.v1.BulkAssignPortMappingsRequest.assignments:type_name -> go_nd.v1.BulkPortAssignment
-	30, // 19: go_nd.v1.BulkAssignPortMappingsResponse.results:type_name -> go_nd.v1.BulkAssignmentResult
-	2,  // 20: go_nd.v1.ComputeNodesService.ListComputeNodes:input_type -> go_nd.v1.ListComputeNodesRequest
-	4,  // 21: go_nd.v1.ComputeNodesService.GetComputeNode:input_type -> go_nd.v1.GetComputeNodeRequest
-	6,  // 22: go_nd.v1.ComputeNodesService.CreateComputeNode:input_type -> go_nd.v1.CreateComputeNodeRequest
-	8,  // 23: go_nd.v1.ComputeNodesService.UpdateComputeNode:input_type -> go_nd.v1.UpdateComputeNodeRequest
-	10, // 24: go_nd.v1.ComputeNodesService.DeleteComputeNode:input_type -> go_nd.v1.DeleteComputeNodeRequest
-	12, // 25: go_nd.v1.ComputeNodesService.ListPortMappings:input_type -> go_nd.v1.ListPortMappingsRequest
-	14, // 26: go_nd.v1.ComputeNodesService.AddPortMapping:input_type -> go_nd.v1.AddPortMappingRequest
-	16, // 27: go_nd.v1.ComputeNodesService.DeletePortMapping:input_type -> go_nd.v1.DeletePortMappingRequest
-	19, // 28: go_nd.v1.ComputeNodesService.ListInterfaces:input_type -> go_nd.v1.ListInterfacesRequest
-	21, // 29: go_nd.v1.ComputeNodesService.CreateInterface:input_type -> go_nd.v1.CreateInterfaceRequest
-	23, // 30: go_nd.v1.ComputeNodesService.UpdateInterface:input_type -> go_nd.v1.UpdateInterfaceRequest
-	25, // 31: go_nd.v1.ComputeNodesService.DeleteInterface:input_type -> go_nd.v1.DeleteInterfaceRequest
-	27, // 32: go_nd.v1.ComputeNodesService.AssignPortToInterface:input_type -> go_nd.v1.AssignPortToInterfaceRequest
-	31, // 33: go_nd.v1.ComputeNodesService.BulkAssignPortMappings:input_type -> go_nd.v1.BulkAssignPortMappingsRequest
-	3,  // 34: go_nd.v1.ComputeNodesService.ListComputeNodes:output_type -> go_nd.v1.ListComputeNodesResponse
-	5,  // 35: go_nd.v1.ComputeNodesService.GetComputeNode:output_type -> go_nd.v1.GetComputeNodeResponse
-	7,  // 36: go_nd.v1.ComputeNodesService.CreateComputeNode:output_type -> go_nd.v1.CreateComputeNodeResponse
-	9,  // 37: go_nd.v1.ComputeNodesService.UpdateComputeNode:output_type -> go_nd.v1.UpdateComputeNodeResponse
-	11, // 38: go_nd.v1.ComputeNodesService.DeleteComputeNode:output_type -> go_nd.v1.DeleteComputeNodeResponse
-	13, // 39: go_nd.v1.ComputeNodesService.ListPortMappings:output_type -> go_nd.v1.ListPortMappingsResponse
-	15, // 40: go_nd.v1.ComputeNodesService.AddPortMapping:output_type -> go_nd.v1.AddPortMappingResponse
-	17, // 41: go_nd.v1.ComputeNodesService.DeletePortMapping:output_type -> go_nd.v1.DeletePortMappingResponse
-	20, // 42: go_nd.v1.ComputeNodesService.ListInterfaces:output_type -> go_nd.v1.ListInterfacesResponse
-	22, // 43: go_nd.v1.ComputeNodesService.CreateInterface:output_type -> go_nd.v1.CreateInterfaceResponse
-	24, // 44: go_nd.v1.ComputeNodesService.UpdateInterface:output_type -> go_nd.v1.UpdateInterfaceResponse
-	26, // 45: go_nd.v1.ComputeNodesService.DeleteInterface:output_type -> go_nd.v1.DeleteInterfaceResponse
-	28, // 46: go_nd.v1.ComputeNodesService.AssignPortToInterface:output_type -> go_nd.v1.AssignPortToInterfaceResponse
-	32, // 47: go_nd.v1.ComputeNodesService.BulkAssignPortMappings:output_type -> go_nd.v1.BulkAssignPortMappingsResponse
-	34, // [34:48] is the sub-list for method output_type
-	20, // [20:34] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	34, // 3: go_nd.v1.ComputeNode.labels:type_name -> go_nd.v1.ComputeNode.LabelsEntry
+	36, // 4: go_nd.v1.PortMapping.created_at:type_name -> google.protobuf.Timestamp
+	37, // 5: go_nd.v1.ListComputeNodesRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	35, // 6: go_nd.v1.ListComputeNodesRequest.label_selector:type_name -> go_nd.v1.ListComputeNodesRequest.LabelSelectorEntry
+	0,  // 7: go_nd.v1.ListComputeNodesResponse.compute_nodes:type_name -> go_nd.v1.ComputeNode
+	38, // 8: go_nd.v1.ListComputeNodesResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	0,  // 9: go_nd.v1.GetComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
+	6,  // 10: go_nd.v1.GetComputeNodeResponse.current_allocation:type_name -> go_nd.v1.ComputeNodeAllocation
+	36, // 11: go_nd.v1.ComputeNodeAllocation.allocated_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: go_nd.v1.CreateComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
+	0,  // 13: go_nd.v1.UpdateComputeNodeResponse.compute_node:type_name -> go_nd.v1.ComputeNode
+	1,  // 14: go_nd.v1.ListPortMappingsResponse.port_mappings:type_name -> go_nd.v1.PortMapping
+	1,  // 15: go_nd.v1.AddPortMappingResponse.port_mapping:type_name -> go_nd.v1.PortMapping
+	36, // 16: go_nd.v1.ComputeNodeInterface.created_at:type_name -> google.protobuf.Timestamp
+	36, // 17: go_nd.v1.ComputeNodeInterface.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 18: go_nd.v1.ListInterfacesResponse.interfaces:type_name -> go_nd.v1.ComputeNodeInterface
+	19, // 19: go_nd.v1.CreateInterfaceResponse.interface:type_name -> go_nd.v1.ComputeNodeInterface
+	19, // 20: go_nd.v1.UpdateInterfaceResponse.interface:type_name -> go_nd.v1.ComputeNodeInterface
+	1,  // 21: go_nd.v1.AssignPortToInterfaceResponse.port_mapping:type_name -> go_nd.v1.PortMapping
+	30, // 22: go_nd.v1.BulkAssignPortMappingsRequest.assignments:type_name -> go_nd.v1.BulkPortAssignment
+	31, // 23: go_nd.v1.BulkAssignPortMappingsResponse.results:type_name -> go_nd.v1.BulkAssignmentResult
+	2,  // 24: go_nd.v1.ComputeNodesService.ListComputeNodes:input_type -> go_nd.v1.ListComputeNodesRequest
+	4,  // 25: go_nd.v1.ComputeNodesService.GetComputeNode:input_type -> go_nd.v1.GetComputeNodeRequest
+	7,  // 26: go_nd.v1.ComputeNodesService.CreateComputeNode:input_type -> go_nd.v1.CreateComputeNodeRequest
+	9,  // 27: go_nd.v1.ComputeNodesService.UpdateComputeNode:input_type -> go_nd.v1.UpdateComputeNodeRequest
+	11, // 28: go_nd.v1.ComputeNodesService.DeleteComputeNode:input_type -> go_nd.v1.DeleteComputeNodeRequest
+	13, // 29: go_nd.v1.ComputeNodesService.ListPortMappings:input_type -> go_nd.v1.ListPortMappingsRequest
+	15, // 30: go_nd.v1.ComputeNodesService.AddPortMapping:input_type -> go_nd.v1.AddPortMappingRequest
+	17, // 31: go_nd.v1.ComputeNodesService.DeletePortMapping:input_type -> go_nd.v1.DeletePortMappingRequest
+	20, // 32: go_nd.v1.ComputeNodesService.ListInterfaces:input_type -> go_nd.v1.ListInterfacesRequest
+	22, // 33: go_nd.v1.ComputeNodesService.CreateInterface:input_type -> go_nd.v1.CreateInterfaceRequest
+	24, // 34: go_nd.v1.ComputeNodesService.UpdateInterface:input_type -> go_nd.v1.UpdateInterfaceRequest
+	26, // 35: go_nd.v1.ComputeNodesService.DeleteInterface:input_type -> go_nd.v1.DeleteInterfaceRequest
+	28, // 36: go_nd.v1.ComputeNodesService.AssignPortToInterface:input_type -> go_nd.v1.AssignPortToInterfaceRequest
+	32, // 37: go_nd.v1.ComputeNodesService.BulkAssignPortMappings:input_type -> go_nd.v1.BulkAssignPortMappingsRequest
+	3,  // 38: go_nd.v1.ComputeNodesService.ListComputeNodes:output_type -> go_nd.v1.ListComputeNodesResponse
+	5,  // 39: go_nd.v1.ComputeNodesService.GetComputeNode:output_type -> go_nd.v1.GetComputeNodeResponse
+	8,  // 40: go_nd.v1.ComputeNodesService.CreateComputeNode:output_type -> go_nd.v1.CreateComputeNodeResponse
+	10, // 41: go_nd.v1.ComputeNodesService.UpdateComputeNode:output_type -> go_nd.v1.UpdateComputeNodeResponse
+	12, // 42: go_nd.v1.ComputeNodesService.DeleteComputeNode:output_type -> go_nd.v1.DeleteComputeNodeResponse
+	14, // 43: go_nd.v1.ComputeNodesService.ListPortMappings:output_type -> go_nd.v1.ListPortMappingsResponse
+	16, // 44: go_nd.v1.ComputeNodesService.AddPortMapping:output_type -> go_nd.v1.AddPortMappingResponse
+	18, // 45: go_nd.v1.ComputeNodesService.DeletePortMapping:output_type -> go_nd.v1.DeletePortMappingResponse
+	21, // 46: go_nd.v1.ComputeNodesService.ListInterfaces:output_type -> go_nd.v1.ListInterfacesResponse
+	23, // 47: go_nd.v1.ComputeNodesService.CreateInterface:output_type -> go_nd.v1.CreateInterfaceResponse
+	25, // 48: go_nd.v1.ComputeNodesService.UpdateInterface:output_type -> go_nd.v1.UpdateInterfaceResponse
+	27, // 49: go_nd.v1.ComputeNodesService.DeleteInterface:output_type -> go_nd.v1.DeleteInterfaceResponse
+	29, // 50: go_nd.v1.ComputeNodesService.AssignPortToInterface:output_type -> go_nd.v1.AssignPortToInterfaceResponse
+	33, // 51: go_nd.v1.ComputeNodesService.BulkAssignPortMappings:output_type -> go_nd.v1.BulkAssignPortMappingsResponse
+	38, // [38:52] is the sub-list for method output_type
+	24, // [24:38] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_go_nd_v1_compute_nodes_proto_init() }
@@ -2199,7 +2464,7 @@ func file_go_nd_v1_compute_nodes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_go_nd_v1_compute_nodes_proto_rawDesc), len(file_go_nd_v1_compute_nodes_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   33,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -7,8 +7,10 @@
 package go_ndv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -118,7 +120,9 @@ type Switch struct {
 	FabricId      string                 `protobuf:"bytes,6,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	PortCount     int32                  `protobuf:"varint,9,opt,name=port_count,json=portCount,proto3" json:"port_count,omitempty"` // Denormalized count
+	PortCount     int32                  `protobuf:"varint,9,opt,name=port_count,json=portCount,proto3" json:"port_count,omitempty"`            // Denormalized count
+	LastSyncedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_synced_at,json=lastSyncedAt,proto3" json:"last_synced_at,omitempty"` // Last successful port sync (unset if never synced)
+	Role          string                 `protobuf:"bytes,11,opt,name=role,proto3" json:"role,omitempty"`                                       // leaf, spine, or border (empty if unknown)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -216,6 +220,20 @@ func (x *Switch) GetPortCount() int32 {
 	return 0
 }
 
+func (x *Switch) GetLastSyncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncedAt
+	}
+	return nil
+}
+
+func (x *Switch) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 // SwitchPort represents a port on a switch
 type SwitchPort struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -230,6 +248,7 @@ type SwitchPort struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	IsBreakout    bool                   `protobuf:"varint,12,opt,name=is_breakout,json=isBreakout,proto3" json:"is_breakout,omitempty"` // Breakout sub-port (Ethernetx/x/x)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -341,6 +360,13 @@ func (x *SwitchPort) GetLastSeenAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *SwitchPort) GetIsBreakout() bool {
+	if x != nil {
+		return x.IsBreakout
+	}
+	return false
+}
+
 // Network represents a network in a fabric
 type Network struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -410,6 +436,91 @@ func (x *Network) GetVlanId() int32 {
 	return 0
 }
 
+// VRF represents an NDFC VRF
+type VRF struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fabric        string                 `protobuf:"bytes,2,opt,name=fabric,proto3" json:"fabric,omitempty"`
+	VrfId         int32                  `protobuf:"varint,3,opt,name=vrf_id,json=vrfId,proto3" json:"vrf_id,omitempty"`
+	Template      string                 `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"`
+	Tenant        string                 `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VRF) Reset() {
+	*x = VRF{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VRF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VRF) ProtoMessage() {}
+
+func (x *VRF) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VRF.ProtoReflect.Descriptor instead.
+func (*VRF) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VRF) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VRF) GetFabric() string {
+	if x != nil {
+		return x.Fabric
+	}
+	return ""
+}
+
+func (x *VRF) GetVrfId() int32 {
+	if x != nil {
+		return x.VrfId
+	}
+	return 0
+}
+
+func (x *VRF) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *VRF) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *VRF) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // ListFabricsRequest lists fabrics
 type ListFabricsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -420,7 +531,7 @@ type ListFabricsRequest struct {
 
 func (x *ListFabricsRequest) Reset() {
 	*x = ListFabricsRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[4]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -432,7 +543,7 @@ func (x *ListFabricsRequest) String() string {
 func (*ListFabricsRequest) ProtoMessage() {}
 
 func (x *ListFabricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[4]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +556,7 @@ func (x *ListFabricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFabricsRequest.ProtoReflect.Descriptor instead.
 func (*ListFabricsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{4}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListFabricsRequest) GetPagination() *PaginationRequest {
@@ -466,7 +577,7 @@ type ListFabricsResponse struct {
 
 func (x *ListFabricsResponse) Reset() {
 	*x = ListFabricsResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[5]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -478,7 +589,7 @@ func (x *ListFabricsResponse) String() string {
 func (*ListFabricsResponse) ProtoMessage() {}
 
 func (x *ListFabricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[5]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +602,7 @@ func (x *ListFabricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFabricsResponse.ProtoReflect.Descriptor instead.
 func (*ListFabricsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{5}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListFabricsResponse) GetFabrics() []*Fabric {
@@ -518,7 +629,7 @@ type GetFabricRequest struct {
 
 func (x *GetFabricRequest) Reset() {
 	*x = GetFabricRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[6]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +641,7 @@ func (x *GetFabricRequest) String() string {
 func (*GetFabricRequest) ProtoMessage() {}
 
 func (x *GetFabricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[6]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +654,7 @@ func (x *GetFabricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFabricRequest.ProtoReflect.Descriptor instead.
 func (*GetFabricRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{6}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetFabricRequest) GetId() string {
@@ -563,7 +674,7 @@ type GetFabricResponse struct {
 
 func (x *GetFabricResponse) Reset() {
 	*x = GetFabricResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[7]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -575,7 +686,7 @@ func (x *GetFabricResponse) String() string {
 func (*GetFabricResponse) ProtoMessage() {}
 
 func (x *GetFabricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[7]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +699,7 @@ func (x *GetFabricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFabricResponse.ProtoReflect.Descriptor instead.
 func (*GetFabricResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{7}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetFabricResponse) GetFabric() *Fabric {
@@ -609,7 +720,7 @@ type CreateFabricRequest struct {
 
 func (x *CreateFabricRequest) Reset() {
 	*x = CreateFabricRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[8]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -621,7 +732,7 @@ func (x *CreateFabricRequest) String() string {
 func (*CreateFabricRequest) ProtoMessage() {}
 
 func (x *CreateFabricRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[8]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,7 +745,7 @@ func (x *CreateFabricRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFabricRequest.ProtoReflect.Descriptor instead.
 func (*CreateFabricRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{8}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateFabricRequest) GetName() string {
@@ -661,7 +772,7 @@ type CreateFabricResponse struct {
 
 func (x *CreateFabricResponse) Reset() {
 	*x = CreateFabricResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[9]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +784,7 @@ func (x *CreateFabricResponse) String() string {
 func (*CreateFabricResponse) ProtoMessage() {}
 
 func (x *CreateFabricResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[9]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +797,7 @@ func (x *CreateFabricResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFabricResponse.ProtoReflect.Descriptor instead.
 func (*CreateFabricResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{9}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateFabricResponse) GetFabric() *Fabric {
@@ -696,6 +807,136 @@ func (x *CreateFabricResponse) GetFabric() *Fabric {
 	return nil
 }
 
+// DeleteFabricRequest deletes a fabric by ID or name
+type DeleteFabricRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"` // Skip checks and cascade-delete switches, ports, and port mappings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFabricRequest) Reset() {
+	*x = DeleteFabricRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFabricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFabricRequest) ProtoMessage() {}
+
+func (x *DeleteFabricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFabricRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFabricRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteFabricRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteFabricRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// DeleteFabricResponse returns what was deleted
+type DeleteFabricResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	FabricId         string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	FabricName       string                 `protobuf:"bytes,2,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
+	DeletedSwitches  int64                  `protobuf:"varint,3,opt,name=deleted_switches,json=deletedSwitches,proto3" json:"deleted_switches,omitempty"`
+	DeletedPorts     int64                  `protobuf:"varint,4,opt,name=deleted_ports,json=deletedPorts,proto3" json:"deleted_ports,omitempty"`
+	UnlinkedMappings int64                  `protobuf:"varint,5,opt,name=unlinked_mappings,json=unlinkedMappings,proto3" json:"unlinked_mappings,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteFabricResponse) Reset() {
+	*x = DeleteFabricResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFabricResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFabricResponse) ProtoMessage() {}
+
+func (x *DeleteFabricResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFabricResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFabricResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteFabricResponse) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *DeleteFabricResponse) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *DeleteFabricResponse) GetDeletedSwitches() int64 {
+	if x != nil {
+		return x.DeletedSwitches
+	}
+	return 0
+}
+
+func (x *DeleteFabricResponse) GetDeletedPorts() int64 {
+	if x != nil {
+		return x.DeletedPorts
+	}
+	return 0
+}
+
+func (x *DeleteFabricResponse) GetUnlinkedMappings() int64 {
+	if x != nil {
+		return x.UnlinkedMappings
+	}
+	return 0
+}
+
 // SyncFabricsRequest syncs fabrics from ND
 type SyncFabricsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -705,7 +946,7 @@ type SyncFabricsRequest struct {
 
 func (x *SyncFabricsRequest) Reset() {
 	*x = SyncFabricsRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[10]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -717,7 +958,7 @@ func (x *SyncFabricsRequest) String() string {
 func (*SyncFabricsRequest) ProtoMessage() {}
 
 func (x *SyncFabricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[10]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,7 +971,7 @@ func (x *SyncFabricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncFabricsRequest.ProtoReflect.Descriptor instead.
 func (*SyncFabricsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{10}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{13}
 }
 
 // SyncFabricsResponse returns sync results
@@ -744,7 +985,7 @@ type SyncFabricsResponse struct {
 
 func (x *SyncFabricsResponse) Reset() {
 	*x = SyncFabricsResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[11]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -756,7 +997,7 @@ func (x *SyncFabricsResponse) String() string {
 func (*SyncFabricsResponse) ProtoMessage() {}
 
 func (x *SyncFabricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[11]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,7 +1010,7 @@ func (x *SyncFabricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncFabricsResponse.ProtoReflect.Descriptor instead.
 func (*SyncFabricsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{11}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *SyncFabricsResponse) GetSyncedCount() int32 {
@@ -786,30 +1027,30 @@ func (x *SyncFabricsResponse) GetFabrics() []*Fabric {
 	return nil
 }
 
-// ListSwitchesRequest lists switches in a fabric
-type ListSwitchesRequest struct {
+// DeployFabricRequest deploys a fabric's configuration
+type DeployFabricRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`                // Fabric ID or name
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"` // Deploy only these switches (empty = whole fabric)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSwitchesRequest) Reset() {
-	*x = ListSwitchesRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[12]
+func (x *DeployFabricRequest) Reset() {
+	*x = DeployFabricRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSwitchesRequest) String() string {
+func (x *DeployFabricRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSwitchesRequest) ProtoMessage() {}
+func (*DeployFabricRequest) ProtoMessage() {}
 
-func (x *ListSwitchesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[12]
+func (x *DeployFabricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,49 +1061,49 @@ func (x *ListSwitchesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSwitchesRequest.ProtoReflect.Descriptor instead.
-func (*ListSwitchesRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use DeployFabricRequest.ProtoReflect.Descriptor instead.
+func (*DeployFabricRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListSwitchesRequest) GetFabricId() string {
+func (x *DeployFabricRequest) GetFabricId() string {
 	if x != nil {
 		return x.FabricId
 	}
 	return ""
 }
 
-func (x *ListSwitchesRequest) GetPagination() *PaginationRequest {
+func (x *DeployFabricRequest) GetSerialNumbers() []string {
 	if x != nil {
-		return x.Pagination
+		return x.SerialNumbers
 	}
 	return nil
 }
 
-// ListSwitchesResponse returns switches
-type ListSwitchesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Switches      []*Switch              `protobuf:"bytes,1,rep,name=switches,proto3" json:"switches,omitempty"`
-	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// DeployFabricResponse returns the deploy result
+type DeployFabricResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Deployed        bool                   `protobuf:"varint,1,opt,name=deployed,proto3" json:"deployed,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListSwitchesResponse) Reset() {
-	*x = ListSwitchesResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[13]
+func (x *DeployFabricResponse) Reset() {
+	*x = DeployFabricResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSwitchesResponse) String() string {
+func (x *DeployFabricResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSwitchesResponse) ProtoMessage() {}
+func (*DeployFabricResponse) ProtoMessage() {}
 
-func (x *ListSwitchesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[13]
+func (x *DeployFabricResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,9 +1114,115 @@ func (x *ListSwitchesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSwitchesResponse.ProtoReflect.Descriptor instead.
-func (*ListSwitchesResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DeployFabricResponse.ProtoReflect.Descriptor instead.
+func (*DeployFabricResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeployFabricResponse) GetDeployed() bool {
+	if x != nil {
+		return x.Deployed
+	}
+	return false
+}
+
+func (x *DeployFabricResponse) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// ListSwitchesRequest lists switches in a fabric
+type ListSwitchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSwitchesRequest) Reset() {
+	*x = ListSwitchesRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSwitchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwitchesRequest) ProtoMessage() {}
+
+func (x *ListSwitchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwitchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSwitchesRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSwitchesRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *ListSwitchesRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListSwitchesResponse returns switches
+type ListSwitchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Switches      []*Switch              `protobuf:"bytes,1,rep,name=switches,proto3" json:"switches,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSwitchesResponse) Reset() {
+	*x = ListSwitchesResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSwitchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwitchesResponse) ProtoMessage() {}
+
+func (x *ListSwitchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwitchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSwitchesResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListSwitchesResponse) GetSwitches() []*Switch {
@@ -903,7 +1250,7 @@ type GetSwitchRequest struct {
 
 func (x *GetSwitchRequest) Reset() {
 	*x = GetSwitchRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[14]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -915,7 +1262,7 @@ func (x *GetSwitchRequest) String() string {
 func (*GetSwitchRequest) ProtoMessage() {}
 
 func (x *GetSwitchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[14]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,7 +1275,7 @@ func (x *GetSwitchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSwitchRequest.ProtoReflect.Descriptor instead.
 func (*GetSwitchRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{14}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetSwitchRequest) GetFabricId() string {
@@ -955,7 +1302,7 @@ type GetSwitchResponse struct {
 
 func (x *GetSwitchResponse) Reset() {
 	*x = GetSwitchResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[15]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -967,7 +1314,7 @@ func (x *GetSwitchResponse) String() string {
 func (*GetSwitchResponse) ProtoMessage() {}
 
 func (x *GetSwitchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[15]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -980,7 +1327,7 @@ func (x *GetSwitchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSwitchResponse.ProtoReflect.Descriptor instead.
 func (*GetSwitchResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{15}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetSwitchResponse) GetSwitch() *Switch {
@@ -1004,7 +1351,7 @@ type CreateSwitchRequest struct {
 
 func (x *CreateSwitchRequest) Reset() {
 	*x = CreateSwitchRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[16]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1016,7 +1363,7 @@ func (x *CreateSwitchRequest) String() string {
 func (*CreateSwitchRequest) ProtoMessage() {}
 
 func (x *CreateSwitchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[16]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1029,7 +1376,7 @@ func (x *CreateSwitchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSwitchRequest.ProtoReflect.Descriptor instead.
 func (*CreateSwitchRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{16}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CreateSwitchRequest) GetFabricId() string {
@@ -1077,7 +1424,7 @@ type CreateSwitchResponse struct {
 
 func (x *CreateSwitchResponse) Reset() {
 	*x = CreateSwitchResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[17]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1436,7 @@ func (x *CreateSwitchResponse) String() string {
 func (*CreateSwitchResponse) ProtoMessage() {}
 
 func (x *CreateSwitchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[17]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1449,7 @@ func (x *CreateSwitchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSwitchResponse.ProtoReflect.Descriptor instead.
 func (*CreateSwitchResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{17}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CreateSwitchResponse) GetSwitch() *Switch {
@@ -1112,6 +1459,145 @@ func (x *CreateSwitchResponse) GetSwitch() *Switch {
 	return nil
 }
 
+// UpdateSwitchRequest updates locally overridable switch fields.
+// serial_number and fabric_id are immutable; listing them in update_mask is rejected.
+type UpdateSwitchRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	SwitchId               string                 `protobuf:"bytes,1,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"` // Required
+	Name                   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Model                  string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	IpAddress              string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UpdateMask             *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`                                        // Fields to write (name, model, ip_address); empty = all non-empty fields
+	UpdatePortDescriptions bool                   `protobuf:"varint,6,opt,name=update_port_descriptions,json=updatePortDescriptions,proto3" json:"update_port_descriptions,omitempty"` // On rename, replace the old name in port descriptions across the fabric
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UpdateSwitchRequest) Reset() {
+	*x = UpdateSwitchRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSwitchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSwitchRequest) ProtoMessage() {}
+
+func (x *UpdateSwitchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSwitchRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSwitchRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateSwitchRequest) GetSwitchId() string {
+	if x != nil {
+		return x.SwitchId
+	}
+	return ""
+}
+
+func (x *UpdateSwitchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSwitchRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *UpdateSwitchRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *UpdateSwitchRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateSwitchRequest) GetUpdatePortDescriptions() bool {
+	if x != nil {
+		return x.UpdatePortDescriptions
+	}
+	return false
+}
+
+// UpdateSwitchResponse returns the updated switch
+type UpdateSwitchResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Switch                  *Switch                `protobuf:"bytes,1,opt,name=switch,proto3" json:"switch,omitempty"`
+	UpdatedPortDescriptions int64                  `protobuf:"varint,2,opt,name=updated_port_descriptions,json=updatedPortDescriptions,proto3" json:"updated_port_descriptions,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UpdateSwitchResponse) Reset() {
+	*x = UpdateSwitchResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSwitchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSwitchResponse) ProtoMessage() {}
+
+func (x *UpdateSwitchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSwitchResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSwitchResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateSwitchResponse) GetSwitch() *Switch {
+	if x != nil {
+		return x.Switch
+	}
+	return nil
+}
+
+func (x *UpdateSwitchResponse) GetUpdatedPortDescriptions() int64 {
+	if x != nil {
+		return x.UpdatedPortDescriptions
+	}
+	return 0
+}
+
 // SyncSwitchesRequest syncs switches from ND
 type SyncSwitchesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1122,7 +1608,7 @@ type SyncSwitchesRequest struct {
 
 func (x *SyncSwitchesRequest) Reset() {
 	*x = SyncSwitchesRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[18]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1134,7 +1620,7 @@ func (x *SyncSwitchesRequest) String() string {
 func (*SyncSwitchesRequest) ProtoMessage() {}
 
 func (x *SyncSwitchesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[18]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1147,7 +1633,7 @@ func (x *SyncSwitchesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncSwitchesRequest.ProtoReflect.Descriptor instead.
 func (*SyncSwitchesRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{18}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SyncSwitchesRequest) GetFabricId() string {
@@ -1168,7 +1654,7 @@ type SyncSwitchesResponse struct {
 
 func (x *SyncSwitchesResponse) Reset() {
 	*x = SyncSwitchesResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[19]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1180,7 +1666,7 @@ func (x *SyncSwitchesResponse) String() string {
 func (*SyncSwitchesResponse) ProtoMessage() {}
 
 func (x *SyncSwitchesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[19]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1193,7 +1679,7 @@ func (x *SyncSwitchesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncSwitchesResponse.ProtoReflect.Descriptor instead.
 func (*SyncSwitchesResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{19}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *SyncSwitchesResponse) GetSyncedCount() int32 {
@@ -1210,30 +1696,30 @@ func (x *SyncSwitchesResponse) GetSwitches() []*Switch {
 	return nil
 }
 
-// ListNetworksRequest lists networks in a fabric
-type ListNetworksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// SyncStaleSwitchesRequest syncs ports for stale switches in a fabric
+type SyncStaleSwitchesRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	FabricId              string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`                                           // Fabric ID or name
+	StaleThresholdMinutes int32                  `protobuf:"varint,2,opt,name=stale_threshold_minutes,json=staleThresholdMinutes,proto3" json:"stale_threshold_minutes,omitempty"` // Switches synced within this window are skipped (default 60)
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-func (x *ListNetworksRequest) Reset() {
-	*x = ListNetworksRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[20]
+func (x *SyncStaleSwitchesRequest) Reset() {
+	*x = SyncStaleSwitchesRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNetworksRequest) String() string {
+func (x *SyncStaleSwitchesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNetworksRequest) ProtoMessage() {}
+func (*SyncStaleSwitchesRequest) ProtoMessage() {}
 
-func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[20]
+func (x *SyncStaleSwitchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1244,21 +1730,143 @@ func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNetworksRequest.ProtoReflect.Descriptor instead.
-func (*ListNetworksRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SyncStaleSwitchesRequest.ProtoReflect.Descriptor instead.
+func (*SyncStaleSwitchesRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListNetworksRequest) GetFabricId() string {
+func (x *SyncStaleSwitchesRequest) GetFabricId() string {
 	if x != nil {
 		return x.FabricId
 	}
 	return ""
 }
 
-func (x *ListNetworksRequest) GetPagination() *PaginationRequest {
+func (x *SyncStaleSwitchesRequest) GetStaleThresholdMinutes() int32 {
 	if x != nil {
-		return x.Pagination
+		return x.StaleThresholdMinutes
+	}
+	return 0
+}
+
+// SyncStaleSwitchesResponse returns stale sync results
+type SyncStaleSwitchesResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SyncedCount           int32                  `protobuf:"varint,1,opt,name=synced_count,json=syncedCount,proto3" json:"synced_count,omitempty"`
+	SkippedCount          int32                  `protobuf:"varint,2,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	StaleThresholdMinutes int32                  `protobuf:"varint,3,opt,name=stale_threshold_minutes,json=staleThresholdMinutes,proto3" json:"stale_threshold_minutes,omitempty"`
+	FailedCount           int32                  `protobuf:"varint,4,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"` // Stale switches whose port sync failed
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SyncStaleSwitchesResponse) Reset() {
+	*x = SyncStaleSwitchesResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStaleSwitchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStaleSwitchesResponse) ProtoMessage() {}
+
+func (x *SyncStaleSwitchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStaleSwitchesResponse.ProtoReflect.Descriptor instead.
+func (*SyncStaleSwitchesResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SyncStaleSwitchesResponse) GetSyncedCount() int32 {
+	if x != nil {
+		return x.SyncedCount
+	}
+	return 0
+}
+
+func (x *SyncStaleSwitchesResponse) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *SyncStaleSwitchesResponse) GetStaleThresholdMinutes() int32 {
+	if x != nil {
+		return x.StaleThresholdMinutes
+	}
+	return 0
+}
+
+func (x *SyncStaleSwitchesResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+// ListNetworksRequest lists networks in a fabric
+type ListNetworksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworksRequest) Reset() {
+	*x = ListNetworksRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworksRequest) ProtoMessage() {}
+
+func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworksRequest.ProtoReflect.Descriptor instead.
+func (*ListNetworksRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListNetworksRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *ListNetworksRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
 	}
 	return nil
 }
@@ -1274,7 +1882,7 @@ type ListNetworksResponse struct {
 
 func (x *ListNetworksResponse) Reset() {
 	*x = ListNetworksResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[21]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1286,7 +1894,7 @@ func (x *ListNetworksResponse) String() string {
 func (*ListNetworksResponse) ProtoMessage() {}
 
 func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[21]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,7 +1907,7 @@ func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNetworksResponse.ProtoReflect.Descriptor instead.
 func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{21}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ListNetworksResponse) GetNetworks() []*Network {
@@ -1316,6 +1924,194 @@ func (x *ListNetworksResponse) GetPagination() *PaginationResponse {
 	return nil
 }
 
+// ListVRFsRequest lists VRFs in a fabric
+type ListVRFsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVRFsRequest) Reset() {
+	*x = ListVRFsRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVRFsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVRFsRequest) ProtoMessage() {}
+
+func (x *ListVRFsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVRFsRequest.ProtoReflect.Descriptor instead.
+func (*ListVRFsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListVRFsRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+// ListVRFsResponse returns VRFs
+type ListVRFsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vrfs          []*VRF                 `protobuf:"bytes,1,rep,name=vrfs,proto3" json:"vrfs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVRFsResponse) Reset() {
+	*x = ListVRFsResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVRFsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVRFsResponse) ProtoMessage() {}
+
+func (x *ListVRFsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVRFsResponse.ProtoReflect.Descriptor instead.
+func (*ListVRFsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListVRFsResponse) GetVrfs() []*VRF {
+	if x != nil {
+		return x.Vrfs
+	}
+	return nil
+}
+
+// GetNetworkVLANRequest looks up a network's VLAN
+type GetNetworkVLANRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	NetworkName   string                 `protobuf:"bytes,2,opt,name=network_name,json=networkName,proto3" json:"network_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetworkVLANRequest) Reset() {
+	*x = GetNetworkVLANRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkVLANRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkVLANRequest) ProtoMessage() {}
+
+func (x *GetNetworkVLANRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkVLANRequest.ProtoReflect.Descriptor instead.
+func (*GetNetworkVLANRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetNetworkVLANRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *GetNetworkVLANRequest) GetNetworkName() string {
+	if x != nil {
+		return x.NetworkName
+	}
+	return ""
+}
+
+// GetNetworkVLANResponse returns a network's VLAN
+type GetNetworkVLANResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vlan          string                 `protobuf:"bytes,1,opt,name=vlan,proto3" json:"vlan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetworkVLANResponse) Reset() {
+	*x = GetNetworkVLANResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkVLANResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkVLANResponse) ProtoMessage() {}
+
+func (x *GetNetworkVLANResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkVLANResponse.ProtoReflect.Descriptor instead.
+func (*GetNetworkVLANResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetNetworkVLANResponse) GetVlan() string {
+	if x != nil {
+		return x.Vlan
+	}
+	return ""
+}
+
 // ListPortsRequest lists ports on a switch
 type ListPortsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1328,7 +2124,7 @@ type ListPortsRequest struct {
 
 func (x *ListPortsRequest) Reset() {
 	*x = ListPortsRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[22]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1340,7 +2136,7 @@ func (x *ListPortsRequest) String() string {
 func (*ListPortsRequest) ProtoMessage() {}
 
 func (x *ListPortsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[22]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1353,7 +2149,7 @@ func (x *ListPortsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortsRequest.ProtoReflect.Descriptor instead.
 func (*ListPortsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{22}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ListPortsRequest) GetFabricId() string {
@@ -1388,7 +2184,7 @@ type ListPortsResponse struct {
 
 func (x *ListPortsResponse) Reset() {
 	*x = ListPortsResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[23]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1400,7 +2196,7 @@ func (x *ListPortsResponse) String() string {
 func (*ListPortsResponse) ProtoMessage() {}
 
 func (x *ListPortsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[23]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,7 +2209,7 @@ func (x *ListPortsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortsResponse.ProtoReflect.Descriptor instead.
 func (*ListPortsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{23}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ListPortsResponse) GetPorts() []*SwitchPort {
@@ -1442,7 +2238,7 @@ type GetPortRequest struct {
 
 func (x *GetPortRequest) Reset() {
 	*x = GetPortRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[24]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1454,7 +2250,7 @@ func (x *GetPortRequest) String() string {
 func (*GetPortRequest) ProtoMessage() {}
 
 func (x *GetPortRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[24]
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1467,7 +2263,7 @@ func (x *GetPortRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortRequest.ProtoReflect.Descriptor instead.
 func (*GetPortRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{24}
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetPortRequest) GetFabricId() string {
@@ -1481,39 +2277,420 @@ func (x *GetPortRequest) GetSwitchId() string {
 	if x != nil {
 		return x.SwitchId
 	}
-	return ""
+	return ""
+}
+
+func (x *GetPortRequest) GetPortId() string {
+	if x != nil {
+		return x.PortId
+	}
+	return ""
+}
+
+// GetPortResponse returns a port
+type GetPortResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          *SwitchPort            `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortResponse) Reset() {
+	*x = GetPortResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortResponse) ProtoMessage() {}
+
+func (x *GetPortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortResponse.ProtoReflect.Descriptor instead.
+func (*GetPortResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetPortResponse) GetPort() *SwitchPort {
+	if x != nil {
+		return x.Port
+	}
+	return nil
+}
+
+// CreatePortRequest creates a port
+type CreatePortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PortNumber    string                 `protobuf:"bytes,4,opt,name=port_number,json=portNumber,proto3" json:"port_number,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePortRequest) Reset() {
+	*x = CreatePortRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePortRequest) ProtoMessage() {}
+
+func (x *CreatePortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePortRequest.ProtoReflect.Descriptor instead.
+func (*CreatePortRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreatePortRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *CreatePortRequest) GetSwitchId() string {
+	if x != nil {
+		return x.SwitchId
+	}
+	return ""
+}
+
+func (x *CreatePortRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePortRequest) GetPortNumber() string {
+	if x != nil {
+		return x.PortNumber
+	}
+	return ""
+}
+
+func (x *CreatePortRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CreatePortResponse returns the created port
+type CreatePortResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          *SwitchPort            `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePortResponse) Reset() {
+	*x = CreatePortResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePortResponse) ProtoMessage() {}
+
+func (x *CreatePortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePortResponse.ProtoReflect.Descriptor instead.
+func (*CreatePortResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreatePortResponse) GetPort() *SwitchPort {
+	if x != nil {
+		return x.Port
+	}
+	return nil
+}
+
+// SyncPortsRequest syncs ports from ND
+type SyncPortsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncPortsRequest) Reset() {
+	*x = SyncPortsRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPortsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPortsRequest) ProtoMessage() {}
+
+func (x *SyncPortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPortsRequest.ProtoReflect.Descriptor instead.
+func (*SyncPortsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SyncPortsRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+func (x *SyncPortsRequest) GetSwitchId() string {
+	if x != nil {
+		return x.SwitchId
+	}
+	return ""
+}
+
+// SyncPortsResponse returns sync results
+type SyncPortsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SyncedCount   int32                  `protobuf:"varint,1,opt,name=synced_count,json=syncedCount,proto3" json:"synced_count,omitempty"`
+	Ports         []*SwitchPort          `protobuf:"bytes,2,rep,name=ports,proto3" json:"ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncPortsResponse) Reset() {
+	*x = SyncPortsResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPortsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPortsResponse) ProtoMessage() {}
+
+func (x *SyncPortsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPortsResponse.ProtoReflect.Descriptor instead.
+func (*SyncPortsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SyncPortsResponse) GetSyncedCount() int32 {
+	if x != nil {
+		return x.SyncedCount
+	}
+	return 0
+}
+
+func (x *SyncPortsResponse) GetPorts() []*SwitchPort {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+// BulkSyncPortsRequest selects the switches to sync: switch_ids, or if empty every switch
+// of fabric_id (ID or name)
+type BulkSyncPortsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SwitchIds     []string               `protobuf:"bytes,1,rep,name=switch_ids,json=switchIds,proto3" json:"switch_ids,omitempty"`
+	FabricId      string                 `protobuf:"bytes,2,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkSyncPortsRequest) Reset() {
+	*x = BulkSyncPortsRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkSyncPortsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkSyncPortsRequest) ProtoMessage() {}
+
+func (x *BulkSyncPortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkSyncPortsRequest.ProtoReflect.Descriptor instead.
+func (*BulkSyncPortsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BulkSyncPortsRequest) GetSwitchIds() []string {
+	if x != nil {
+		return x.SwitchIds
+	}
+	return nil
+}
+
+func (x *BulkSyncPortsRequest) GetFabricId() string {
+	if x != nil {
+		return x.FabricId
+	}
+	return ""
+}
+
+// SyncSwitchResult is one switch's outcome in a bulk port sync
+type SyncSwitchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SyncedCount   int32                  `protobuf:"varint,1,opt,name=synced_count,json=syncedCount,proto3" json:"synced_count,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Empty if the sync succeeded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncSwitchResult) Reset() {
+	*x = SyncSwitchResult{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncSwitchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncSwitchResult) ProtoMessage() {}
+
+func (x *SyncSwitchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncSwitchResult.ProtoReflect.Descriptor instead.
+func (*SyncSwitchResult) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SyncSwitchResult) GetSyncedCount() int32 {
+	if x != nil {
+		return x.SyncedCount
+	}
+	return 0
 }
 
-func (x *GetPortRequest) GetPortId() string {
+func (x *SyncSwitchResult) GetErrorMessage() string {
 	if x != nil {
-		return x.PortId
+		return x.ErrorMessage
 	}
 	return ""
 }
 
-// GetPortResponse returns a port
-type GetPortResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          *SwitchPort            `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+// BulkSyncPortsResponse returns per-switch results by switch ID
+type BulkSyncPortsResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Results       map[string]*SyncSwitchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DurationMs    int64                        `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPortResponse) Reset() {
-	*x = GetPortResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[25]
+func (x *BulkSyncPortsResponse) Reset() {
+	*x = BulkSyncPortsResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPortResponse) String() string {
+func (x *BulkSyncPortsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPortResponse) ProtoMessage() {}
+func (*BulkSyncPortsResponse) ProtoMessage() {}
 
-func (x *GetPortResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[25]
+func (x *BulkSyncPortsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,45 +2701,50 @@ func (x *GetPortResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPortResponse.ProtoReflect.Descriptor instead.
-func (*GetPortResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use BulkSyncPortsResponse.ProtoReflect.Descriptor instead.
+func (*BulkSyncPortsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetPortResponse) GetPort() *SwitchPort {
+func (x *BulkSyncPortsResponse) GetResults() map[string]*SyncSwitchResult {
 	if x != nil {
-		return x.Port
+		return x.Results
 	}
 	return nil
 }
 
-// CreatePortRequest creates a port
-type CreatePortRequest struct {
+func (x *BulkSyncPortsResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// DeletePortsRequest deletes ports
+type DeletePortsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
 	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	PortNumber    string                 `protobuf:"bytes,4,opt,name=port_number,json=portNumber,proto3" json:"port_number,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	PortIds       []string               `protobuf:"bytes,3,rep,name=port_ids,json=portIds,proto3" json:"port_ids,omitempty"` // If empty, deletes all ports on switch
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePortRequest) Reset() {
-	*x = CreatePortRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[26]
+func (x *DeletePortsRequest) Reset() {
+	*x = DeletePortsRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePortRequest) String() string {
+func (x *DeletePortsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePortRequest) ProtoMessage() {}
+func (*DeletePortsRequest) ProtoMessage() {}
 
-func (x *CreatePortRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[26]
+func (x *DeletePortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1573,69 +2755,55 @@ func (x *CreatePortRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePortRequest.ProtoReflect.Descriptor instead.
-func (*CreatePortRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use DeletePortsRequest.ProtoReflect.Descriptor instead.
+func (*DeletePortsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *CreatePortRequest) GetFabricId() string {
+func (x *DeletePortsRequest) GetFabricId() string {
 	if x != nil {
 		return x.FabricId
 	}
 	return ""
 }
 
-func (x *CreatePortRequest) GetSwitchId() string {
+func (x *DeletePortsRequest) GetSwitchId() string {
 	if x != nil {
 		return x.SwitchId
 	}
 	return ""
 }
 
-func (x *CreatePortRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreatePortRequest) GetPortNumber() string {
-	if x != nil {
-		return x.PortNumber
-	}
-	return ""
-}
-
-func (x *CreatePortRequest) GetDescription() string {
+func (x *DeletePortsRequest) GetPortIds() []string {
 	if x != nil {
-		return x.Description
+		return x.PortIds
 	}
-	return ""
+	return nil
 }
 
-// CreatePortResponse returns the created port
-type CreatePortResponse struct {
+// DeletePortsResponse confirms deletion
+type DeletePortsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          *SwitchPort            `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePortResponse) Reset() {
-	*x = CreatePortResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[27]
+func (x *DeletePortsResponse) Reset() {
+	*x = DeletePortsResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePortResponse) String() string {
+func (x *DeletePortsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePortResponse) ProtoMessage() {}
+func (*DeletePortsResponse) ProtoMessage() {}
 
-func (x *CreatePortResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[27]
+func (x *DeletePortsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1646,42 +2814,41 @@ func (x *CreatePortResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePortResponse.ProtoReflect.Descriptor instead.
-func (*CreatePortResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use DeletePortsResponse.ProtoReflect.Descriptor instead.
+func (*DeletePortsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreatePortResponse) GetPort() *SwitchPort {
+func (x *DeletePortsResponse) GetDeletedCount() int32 {
 	if x != nil {
-		return x.Port
+		return x.DeletedCount
 	}
-	return nil
+	return 0
 }
 
-// SyncPortsRequest syncs ports from ND
-type SyncPortsRequest struct {
+// GetFabricRoleSummaryRequest summarizes a fabric's switch roles
+type GetFabricRoleSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
-	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"` // Fabric ID or name
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncPortsRequest) Reset() {
-	*x = SyncPortsRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[28]
+func (x *GetFabricRoleSummaryRequest) Reset() {
+	*x = GetFabricRoleSummaryRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncPortsRequest) String() string {
+func (x *GetFabricRoleSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncPortsRequest) ProtoMessage() {}
+func (*GetFabricRoleSummaryRequest) ProtoMessage() {}
 
-func (x *SyncPortsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[28]
+func (x *GetFabricRoleSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,49 +2859,47 @@ func (x *SyncPortsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncPortsRequest.ProtoReflect.Descriptor instead.
-func (*SyncPortsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use GetFabricRoleSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetFabricRoleSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *SyncPortsRequest) GetFabricId() string {
+func (x *GetFabricRoleSummaryRequest) GetFabricId() string {
 	if x != nil {
 		return x.FabricId
 	}
 	return ""
 }
 
-func (x *SyncPortsRequest) GetSwitchId() string {
-	if x != nil {
-		return x.SwitchId
-	}
-	return ""
-}
-
-// SyncPortsResponse returns sync results
-type SyncPortsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SyncedCount   int32                  `protobuf:"varint,1,opt,name=synced_count,json=syncedCount,proto3" json:"synced_count,omitempty"`
-	Ports         []*SwitchPort          `protobuf:"bytes,2,rep,name=ports,proto3" json:"ports,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// GetFabricRoleSummaryResponse counts switches per role and leaf port capacity
+type GetFabricRoleSummaryResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Leaf               int32                  `protobuf:"varint,1,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Spine              int32                  `protobuf:"varint,2,opt,name=spine,proto3" json:"spine,omitempty"`
+	Border             int32                  `protobuf:"varint,3,opt,name=border,proto3" json:"border,omitempty"`
+	Unknown            int32                  `protobuf:"varint,4,opt,name=unknown,proto3" json:"unknown,omitempty"` // Switches without a recognized role
+	Total              int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	LeafPortsTotal     int32                  `protobuf:"varint,6,opt,name=leaf_ports_total,json=leafPortsTotal,proto3" json:"leaf_ports_total,omitempty"`             // Present ports on leaf switches
+	LeafPortsAvailable int32                  `protobuf:"varint,7,opt,name=leaf_ports_available,json=leafPortsAvailable,proto3" json:"leaf_ports_available,omitempty"` // Of those, ports no compute node is mapped to
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *SyncPortsResponse) Reset() {
-	*x = SyncPortsResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[29]
+func (x *GetFabricRoleSummaryResponse) Reset() {
+	*x = GetFabricRoleSummaryResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncPortsResponse) String() string {
+func (x *GetFabricRoleSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncPortsResponse) ProtoMessage() {}
+func (*GetFabricRoleSummaryResponse) ProtoMessage() {}
 
-func (x *SyncPortsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[29]
+func (x *GetFabricRoleSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1745,50 +2910,83 @@ func (x *SyncPortsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncPortsResponse.ProtoReflect.Descriptor instead.
-func (*SyncPortsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use GetFabricRoleSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetFabricRoleSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *SyncPortsResponse) GetSyncedCount() int32 {
+func (x *GetFabricRoleSummaryResponse) GetLeaf() int32 {
 	if x != nil {
-		return x.SyncedCount
+		return x.Leaf
 	}
 	return 0
 }
 
-func (x *SyncPortsResponse) GetPorts() []*SwitchPort {
+func (x *GetFabricRoleSummaryResponse) GetSpine() int32 {
 	if x != nil {
-		return x.Ports
+		return x.Spine
 	}
-	return nil
+	return 0
 }
 
-// DeletePortsRequest deletes ports
-type DeletePortsRequest struct {
+func (x *GetFabricRoleSummaryResponse) GetBorder() int32 {
+	if x != nil {
+		return x.Border
+	}
+	return 0
+}
+
+func (x *GetFabricRoleSummaryResponse) GetUnknown() int32 {
+	if x != nil {
+		return x.Unknown
+	}
+	return 0
+}
+
+func (x *GetFabricRoleSummaryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetFabricRoleSummaryResponse) GetLeafPortsTotal() int32 {
+	if x != nil {
+		return x.LeafPortsTotal
+	}
+	return 0
+}
+
+func (x *GetFabricRoleSummaryResponse) GetLeafPortsAvailable() int32 {
+	if x != nil {
+		return x.LeafPortsAvailable
+	}
+	return 0
+}
+
+// GetFabricHealthRequest checks fabric health
+type GetFabricHealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"`
-	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`
-	PortIds       []string               `protobuf:"bytes,3,rep,name=port_ids,json=portIds,proto3" json:"port_ids,omitempty"` // If empty, deletes all ports on switch
+	FabricId      string                 `protobuf:"bytes,1,opt,name=fabric_id,json=fabricId,proto3" json:"fabric_id,omitempty"` // Optional fabric ID or name; empty reports on all fabrics
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePortsRequest) Reset() {
-	*x = DeletePortsRequest{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[30]
+func (x *GetFabricHealthRequest) Reset() {
+	*x = GetFabricHealthRequest{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePortsRequest) String() string {
+func (x *GetFabricHealthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePortsRequest) ProtoMessage() {}
+func (*GetFabricHealthRequest) ProtoMessage() {}
 
-func (x *DeletePortsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[30]
+func (x *GetFabricHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1799,55 +2997,48 @@ func (x *DeletePortsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePortsRequest.ProtoReflect.Descriptor instead.
-func (*DeletePortsRequest) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use GetFabricHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetFabricHealthRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *DeletePortsRequest) GetFabricId() string {
+func (x *GetFabricHealthRequest) GetFabricId() string {
 	if x != nil {
 		return x.FabricId
 	}
 	return ""
 }
 
-func (x *DeletePortsRequest) GetSwitchId() string {
-	if x != nil {
-		return x.SwitchId
-	}
-	return ""
-}
-
-func (x *DeletePortsRequest) GetPortIds() []string {
-	if x != nil {
-		return x.PortIds
-	}
-	return nil
-}
-
-// DeletePortsResponse confirms deletion
-type DeletePortsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeletePortsResponse) Reset() {
-	*x = DeletePortsResponse{}
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[31]
+// FabricHealthResponse reports NDFC connectivity and sync status.
+// Checks that fail are left zero/unset rather than failing the call.
+type FabricHealthResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NdfcReachable   bool                   `protobuf:"varint,1,opt,name=ndfc_reachable,json=ndfcReachable,proto3" json:"ndfc_reachable,omitempty"`
+	NdfcVersion     string                 `protobuf:"bytes,2,opt,name=ndfc_version,json=ndfcVersion,proto3" json:"ndfc_version,omitempty"`                // Empty if NDFC is unreachable
+	LastFabricSync  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_fabric_sync,json=lastFabricSync,proto3" json:"last_fabric_sync,omitempty"`     // Most recent fabric upsert from NDFC
+	LastSwitchSync  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_switch_sync,json=lastSwitchSync,proto3" json:"last_switch_sync,omitempty"`     // Most recent successful switch port sync
+	StalePortsCount int32                  `protobuf:"varint,5,opt,name=stale_ports_count,json=stalePortsCount,proto3" json:"stale_ports_count,omitempty"` // Ports not seen by sync in 24h
+	OrphanedSgCount int32                  `protobuf:"varint,6,opt,name=orphaned_sg_count,json=orphanedSgCount,proto3" json:"orphaned_sg_count,omitempty"` // Security groups older than an hour with no job
+	PendingDeploys  int32                  `protobuf:"varint,7,opt,name=pending_deploys,json=pendingDeploys,proto3" json:"pending_deploys,omitempty"`      // Deploy batches waiting to run
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FabricHealthResponse) Reset() {
+	*x = FabricHealthResponse{}
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePortsResponse) String() string {
+func (x *FabricHealthResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePortsResponse) ProtoMessage() {}
+func (*FabricHealthResponse) ProtoMessage() {}
 
-func (x *DeletePortsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_fabrics_proto_msgTypes[31]
+func (x *FabricHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_fabrics_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1858,14 +3049,56 @@ func (x *DeletePortsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePortsResponse.ProtoReflect.Descriptor instead.
-func (*DeletePortsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use FabricHealthResponse.ProtoReflect.Descriptor instead.
+func (*FabricHealthResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_fabrics_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DeletePortsResponse) GetDeletedCount() int32 {
+func (x *FabricHealthResponse) GetNdfcReachable() bool {
 	if x != nil {
-		return x.DeletedCount
+		return x.NdfcReachable
+	}
+	return false
+}
+
+func (x *FabricHealthResponse) GetNdfcVersion() string {
+	if x != nil {
+		return x.NdfcVersion
+	}
+	return ""
+}
+
+func (x *FabricHealthResponse) GetLastFabricSync() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFabricSync
+	}
+	return nil
+}
+
+func (x *FabricHealthResponse) GetLastSwitchSync() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSwitchSync
+	}
+	return nil
+}
+
+func (x *FabricHealthResponse) GetStalePortsCount() int32 {
+	if x != nil {
+		return x.StalePortsCount
+	}
+	return 0
+}
+
+func (x *FabricHealthResponse) GetOrphanedSgCount() int32 {
+	if x != nil {
+		return x.OrphanedSgCount
+	}
+	return 0
+}
+
+func (x *FabricHealthResponse) GetPendingDeploys() int32 {
+	if x != nil {
+		return x.PendingDeploys
 	}
 	return 0
 }
@@ -1874,7 +3107,7 @@ var File_go_nd_v1_fabrics_proto protoreflect.FileDescriptor
 
 const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\n" +
-	"\x16go_nd/v1/fabrics.proto\x12\bgo_nd.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\xd9\x01\n" +
+	"\x16go_nd/v1/fabrics.proto\x12\bgo_nd.v1\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\xd9\x01\n" +
 	"\x06Fabric\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -1883,7 +3116,7 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
-	"\fswitch_count\x18\x06 \x01(\x05R\vswitchCount\"\xb8\x02\n" +
+	"\fswitch_count\x18\x06 \x01(\x05R\vswitchCount\"\x8e\x03\n" +
 	"\x06Switch\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
@@ -1897,7 +3130,10 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
 	"\n" +
-	"port_count\x18\t \x01(\x05R\tportCount\"\x9a\x03\n" +
+	"port_count\x18\t \x01(\x05R\tportCount\x12@\n" +
+	"\x0elast_synced_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncedAt\x12\x12\n" +
+	"\x04role\x18\v \x01(\tR\x04role\"\xbb\x03\n" +
 	"\n" +
 	"SwitchPort\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -1917,12 +3153,21 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
 	"\flast_seen_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"lastSeenAt\"`\n" +
+	"lastSeenAt\x12\x1f\n" +
+	"\vis_breakout\x18\f \x01(\bR\n" +
+	"isBreakout\"`\n" +
 	"\aNetwork\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06fabric\x18\x02 \x01(\tR\x06fabric\x12\x10\n" +
 	"\x03vrf\x18\x03 \x01(\tR\x03vrf\x12\x17\n" +
-	"\avlan_id\x18\x04 \x01(\x05R\x06vlanId\"Q\n" +
+	"\avlan_id\x18\x04 \x01(\x05R\x06vlanId\"\x94\x01\n" +
+	"\x03VRF\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06fabric\x18\x02 \x01(\tR\x06fabric\x12\x15\n" +
+	"\x06vrf_id\x18\x03 \x01(\x05R\x05vrfId\x12\x1a\n" +
+	"\btemplate\x18\x04 \x01(\tR\btemplate\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\"Q\n" +
 	"\x12ListFabricsRequest\x12;\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2\x1b.go_nd.v1.PaginationRequestR\n" +
@@ -1940,11 +3185,27 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\"@\n" +
 	"\x14CreateFabricResponse\x12(\n" +
-	"\x06fabric\x18\x01 \x01(\v2\x10.go_nd.v1.FabricR\x06fabric\"\x14\n" +
+	"\x06fabric\x18\x01 \x01(\v2\x10.go_nd.v1.FabricR\x06fabric\";\n" +
+	"\x13DeleteFabricRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\xd1\x01\n" +
+	"\x14DeleteFabricResponse\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12\x1f\n" +
+	"\vfabric_name\x18\x02 \x01(\tR\n" +
+	"fabricName\x12)\n" +
+	"\x10deleted_switches\x18\x03 \x01(\x03R\x0fdeletedSwitches\x12#\n" +
+	"\rdeleted_ports\x18\x04 \x01(\x03R\fdeletedPorts\x12+\n" +
+	"\x11unlinked_mappings\x18\x05 \x01(\x03R\x10unlinkedMappings\"\x14\n" +
 	"\x12SyncFabricsRequest\"d\n" +
 	"\x13SyncFabricsResponse\x12!\n" +
 	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\x12*\n" +
-	"\afabrics\x18\x02 \x03(\v2\x10.go_nd.v1.FabricR\afabrics\"o\n" +
+	"\afabrics\x18\x02 \x03(\v2\x10.go_nd.v1.FabricR\afabrics\"Y\n" +
+	"\x13DeployFabricRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\"]\n" +
+	"\x14DeployFabricResponse\x12\x1a\n" +
+	"\bdeployed\x18\x01 \x01(\bR\bdeployed\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\"o\n" +
 	"\x13ListSwitchesRequest\x12\x1b\n" +
 	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12;\n" +
 	"\n" +
@@ -1968,12 +3229,32 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\x05 \x01(\tR\tipAddress\"@\n" +
 	"\x14CreateSwitchResponse\x12(\n" +
-	"\x06switch\x18\x01 \x01(\v2\x10.go_nd.v1.SwitchR\x06switch\"2\n" +
+	"\x06switch\x18\x01 \x01(\v2\x10.go_nd.v1.SwitchR\x06switch\"\xf2\x01\n" +
+	"\x13UpdateSwitchRequest\x12\x1b\n" +
+	"\tswitch_id\x18\x01 \x01(\tR\bswitchId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x128\n" +
+	"\x18update_port_descriptions\x18\x06 \x01(\bR\x16updatePortDescriptions\"|\n" +
+	"\x14UpdateSwitchResponse\x12(\n" +
+	"\x06switch\x18\x01 \x01(\v2\x10.go_nd.v1.SwitchR\x06switch\x12:\n" +
+	"\x19updated_port_descriptions\x18\x02 \x01(\x03R\x17updatedPortDescriptions\"2\n" +
 	"\x13SyncSwitchesRequest\x12\x1b\n" +
 	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\"g\n" +
 	"\x14SyncSwitchesResponse\x12!\n" +
 	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\x12,\n" +
 	"\bswitches\x18\x02 \x03(\v2\x10.go_nd.v1.SwitchR\bswitches\"o\n" +
+	"\x18SyncStaleSwitchesRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x126\n" +
+	"\x17stale_threshold_minutes\x18\x02 \x01(\x05R\x15staleThresholdMinutes\"\xbe\x01\n" +
+	"\x19SyncStaleSwitchesResponse\x12!\n" +
+	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\x12#\n" +
+	"\rskipped_count\x18\x02 \x01(\x05R\fskippedCount\x126\n" +
+	"\x17stale_threshold_minutes\x18\x03 \x01(\x05R\x15staleThresholdMinutes\x12!\n" +
+	"\ffailed_count\x18\x04 \x01(\x05R\vfailedCount\"o\n" +
 	"\x13ListNetworksRequest\x12\x1b\n" +
 	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12;\n" +
 	"\n" +
@@ -1983,7 +3264,16 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\bnetworks\x18\x01 \x03(\v2\x11.go_nd.v1.NetworkR\bnetworks\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.go_nd.v1.PaginationResponseR\n" +
-	"pagination\"\x89\x01\n" +
+	"pagination\".\n" +
+	"\x0fListVRFsRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\"5\n" +
+	"\x10ListVRFsResponse\x12!\n" +
+	"\x04vrfs\x18\x01 \x03(\v2\r.go_nd.v1.VRFR\x04vrfs\"W\n" +
+	"\x15GetNetworkVLANRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12!\n" +
+	"\fnetwork_name\x18\x02 \x01(\tR\vnetworkName\",\n" +
+	"\x16GetNetworkVLANResponse\x12\x12\n" +
+	"\x04vlan\x18\x01 \x01(\tR\x04vlan\"\x89\x01\n" +
 	"\x10ListPortsRequest\x12\x1b\n" +
 	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12\x1b\n" +
 	"\tswitch_id\x18\x02 \x01(\tR\bswitchId\x12;\n" +
@@ -2015,29 +3305,72 @@ const file_go_nd_v1_fabrics_proto_rawDesc = "" +
 	"\tswitch_id\x18\x02 \x01(\tR\bswitchId\"b\n" +
 	"\x11SyncPortsResponse\x12!\n" +
 	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\x12*\n" +
-	"\x05ports\x18\x02 \x03(\v2\x14.go_nd.v1.SwitchPortR\x05ports\"i\n" +
+	"\x05ports\x18\x02 \x03(\v2\x14.go_nd.v1.SwitchPortR\x05ports\"R\n" +
+	"\x14BulkSyncPortsRequest\x12\x1d\n" +
+	"\n" +
+	"switch_ids\x18\x01 \x03(\tR\tswitchIds\x12\x1b\n" +
+	"\tfabric_id\x18\x02 \x01(\tR\bfabricId\"Z\n" +
+	"\x10SyncSwitchResult\x12!\n" +
+	"\fsynced_count\x18\x01 \x01(\x05R\vsyncedCount\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xd8\x01\n" +
+	"\x15BulkSyncPortsResponse\x12F\n" +
+	"\aresults\x18\x01 \x03(\v2,.go_nd.v1.BulkSyncPortsResponse.ResultsEntryR\aresults\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\x1aV\n" +
+	"\fResultsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.go_nd.v1.SyncSwitchResultR\x05value:\x028\x01\"i\n" +
 	"\x12DeletePortsRequest\x12\x1b\n" +
 	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\x12\x1b\n" +
 	"\tswitch_id\x18\x02 \x01(\tR\bswitchId\x12\x19\n" +
 	"\bport_ids\x18\x03 \x03(\tR\aportIds\":\n" +
 	"\x13DeletePortsResponse\x12#\n" +
-	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount2\xa0\b\n" +
-	"\x0eFabricsService\x12J\n" +
-	"\vListFabrics\x12\x1c.go_nd.v1.ListFabricsRequest\x1a\x1d.go_nd.v1.ListFabricsResponse\x12D\n" +
-	"\tGetFabric\x12\x1a.go_nd.v1.GetFabricRequest\x1a\x1b.go_nd.v1.GetFabricResponse\x12M\n" +
-	"\fCreateFabric\x12\x1d.go_nd.v1.CreateFabricRequest\x1a\x1e.go_nd.v1.CreateFabricResponse\x12J\n" +
-	"\vSyncFabrics\x12\x1c.go_nd.v1.SyncFabricsRequest\x1a\x1d.go_nd.v1.SyncFabricsResponse\x12M\n" +
-	"\fListSwitches\x12\x1d.go_nd.v1.ListSwitchesRequest\x1a\x1e.go_nd.v1.ListSwitchesResponse\x12D\n" +
-	"\tGetSwitch\x12\x1a.go_nd.v1.GetSwitchRequest\x1a\x1b.go_nd.v1.GetSwitchResponse\x12M\n" +
-	"\fCreateSwitch\x12\x1d.go_nd.v1.CreateSwitchRequest\x1a\x1e.go_nd.v1.CreateSwitchResponse\x12M\n" +
-	"\fSyncSwitches\x12\x1d.go_nd.v1.SyncSwitchesRequest\x1a\x1e.go_nd.v1.SyncSwitchesResponse\x12M\n" +
-	"\fListNetworks\x12\x1d.go_nd.v1.ListNetworksRequest\x1a\x1e.go_nd.v1.ListNetworksResponse\x12D\n" +
-	"\tListPorts\x12\x1a.go_nd.v1.ListPortsRequest\x1a\x1b.go_nd.v1.ListPortsResponse\x12>\n" +
-	"\aGetPort\x12\x18.go_nd.v1.GetPortRequest\x1a\x19.go_nd.v1.GetPortResponse\x12G\n" +
+	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount\":\n" +
+	"\x1bGetFabricRoleSummaryRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\"\xec\x01\n" +
+	"\x1cGetFabricRoleSummaryResponse\x12\x12\n" +
+	"\x04leaf\x18\x01 \x01(\x05R\x04leaf\x12\x14\n" +
+	"\x05spine\x18\x02 \x01(\x05R\x05spine\x12\x16\n" +
+	"\x06border\x18\x03 \x01(\x05R\x06border\x12\x18\n" +
+	"\aunknown\x18\x04 \x01(\x05R\aunknown\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x05R\x05total\x12(\n" +
+	"\x10leaf_ports_total\x18\x06 \x01(\x05R\x0eleafPortsTotal\x120\n" +
+	"\x14leaf_ports_available\x18\a \x01(\x05R\x12leafPortsAvailable\"5\n" +
+	"\x16GetFabricHealthRequest\x12\x1b\n" +
+	"\tfabric_id\x18\x01 \x01(\tR\bfabricId\"\xed\x02\n" +
+	"\x14FabricHealthResponse\x12%\n" +
+	"\x0endfc_reachable\x18\x01 \x01(\bR\rndfcReachable\x12!\n" +
+	"\fndfc_version\x18\x02 \x01(\tR\vndfcVersion\x12D\n" +
+	"\x10last_fabric_sync\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastFabricSync\x12D\n" +
+	"\x10last_switch_sync\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastSwitchSync\x12*\n" +
+	"\x11stale_ports_count\x18\x05 \x01(\x05R\x0fstalePortsCount\x12*\n" +
+	"\x11orphaned_sg_count\x18\x06 \x01(\x05R\x0forphanedSgCount\x12'\n" +
+	"\x0fpending_deploys\x18\a \x01(\x05R\x0ependingDeploys2\x95\x16\n" +
+	"\x0eFabricsService\x12_\n" +
+	"\vListFabrics\x12\x1c.go_nd.v1.ListFabricsRequest\x1a\x1d.go_nd.v1.ListFabricsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/fabrics\x12^\n" +
+	"\tGetFabric\x12\x1a.go_nd.v1.GetFabricRequest\x1a\x1b.go_nd.v1.GetFabricResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/fabrics/{id}\x12e\n" +
+	"\fCreateFabric\x12\x1d.go_nd.v1.CreateFabricRequest\x1a\x1e.go_nd.v1.CreateFabricResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/fabrics\x12g\n" +
+	"\fDeleteFabric\x12\x1d.go_nd.v1.DeleteFabricRequest\x1a\x1e.go_nd.v1.DeleteFabricResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/fabrics/{id}\x12g\n" +
+	"\vSyncFabrics\x12\x1c.go_nd.v1.SyncFabricsRequest\x1a\x1d.go_nd.v1.SyncFabricsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/fabrics:sync\x12x\n" +
+	"\fDeployFabric\x12\x1d.go_nd.v1.DeployFabricRequest\x1a\x1e.go_nd.v1.DeployFabricResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/fabrics/{fabric_id}:deploy\x12w\n" +
+	"\fListSwitches\x12\x1d.go_nd.v1.ListSwitchesRequest\x1a\x1e.go_nd.v1.ListSwitchesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/fabrics/{fabric_id}/switches\x12z\n" +
+	"\tGetSwitch\x12\x1a.go_nd.v1.GetSwitchRequest\x1a\x1b.go_nd.v1.GetSwitchResponse\"4\x82\xd3\xe4\x93\x02.\x12,/v1/fabrics/{fabric_id}/switches/{switch_id}\x12z\n" +
+	"\fCreateSwitch\x12\x1d.go_nd.v1.CreateSwitchRequest\x1a\x1e.go_nd.v1.CreateSwitchResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/fabrics/{fabric_id}/switches\x12r\n" +
+	"\fUpdateSwitch\x12\x1d.go_nd.v1.UpdateSwitchRequest\x1a\x1e.go_nd.v1.UpdateSwitchResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*2\x18/v1/switches/{switch_id}\x12\x7f\n" +
+	"\fSyncSwitches\x12\x1d.go_nd.v1.SyncSwitchesRequest\x1a\x1e.go_nd.v1.SyncSwitchesResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/fabrics/{fabric_id}/switches:sync\x12\x93\x01\n" +
+	"\x11SyncStaleSwitches\x12\".go_nd.v1.SyncStaleSwitchesRequest\x1a#.go_nd.v1.SyncStaleSwitchesResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/fabrics/{fabric_id}/switches:syncStale\x12w\n" +
+	"\fListNetworks\x12\x1d.go_nd.v1.ListNetworksRequest\x1a\x1e.go_nd.v1.ListNetworksResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/fabrics/{fabric_id}/networks\x12g\n" +
+	"\bListVRFs\x12\x19.go_nd.v1.ListVRFsRequest\x1a\x1a.go_nd.v1.ListVRFsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/fabrics/{fabric_id}/vrfs\x12\x91\x01\n" +
+	"\x0eGetNetworkVLAN\x12\x1f.go_nd.v1.GetNetworkVLANRequest\x1a .go_nd.v1.GetNetworkVLANResponse\"<\x82\xd3\xe4\x93\x026\x124/v1/fabrics/{fabric_id}/networks/{network_name}/vlan\x12\x80\x01\n" +
+	"\tListPorts\x12\x1a.go_nd.v1.ListPortsRequest\x1a\x1b.go_nd.v1.ListPortsResponse\":\x82\xd3\xe4\x93\x024\x122/v1/fabrics/{fabric_id}/switches/{switch_id}/ports\x12\x84\x01\n" +
+	"\aGetPort\x12\x18.go_nd.v1.GetPortRequest\x1a\x19.go_nd.v1.GetPortResponse\"D\x82\xd3\xe4\x93\x02>\x12</v1/fabrics/{fabric_id}/switches/{switch_id}/ports/{port_id}\x12\x86\x01\n" +
 	"\n" +
-	"CreatePort\x12\x1b.go_nd.v1.CreatePortRequest\x1a\x1c.go_nd.v1.CreatePortResponse\x12D\n" +
-	"\tSyncPorts\x12\x1a.go_nd.v1.SyncPortsRequest\x1a\x1b.go_nd.v1.SyncPortsResponse\x12J\n" +
-	"\vDeletePorts\x12\x1c.go_nd.v1.DeletePortsRequest\x1a\x1d.go_nd.v1.DeletePortsResponseB\x88\x01\n" +
+	"CreatePort\x12\x1b.go_nd.v1.CreatePortRequest\x1a\x1c.go_nd.v1.CreatePortResponse\"=\x82\xd3\xe4\x93\x027:\x01*\"2/v1/fabrics/{fabric_id}/switches/{switch_id}/ports\x12\x88\x01\n" +
+	"\tSyncPorts\x12\x1a.go_nd.v1.SyncPortsRequest\x1a\x1b.go_nd.v1.SyncPortsResponse\"B\x82\xd3\xe4\x93\x02<:\x01*\"7/v1/fabrics/{fabric_id}/switches/{switch_id}/ports:sync\x12o\n" +
+	"\rBulkSyncPorts\x12\x1e.go_nd.v1.BulkSyncPortsRequest\x1a\x1f.go_nd.v1.BulkSyncPortsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/ports:bulkSync\x12\x86\x01\n" +
+	"\vDeletePorts\x12\x1c.go_nd.v1.DeletePortsRequest\x1a\x1d.go_nd.v1.DeletePortsResponse\":\x82\xd3\xe4\x93\x024*2/v1/fabrics/{fabric_id}/switches/{switch_id}/ports\x12\x93\x01\n" +
+	"\x14GetFabricRoleSummary\x12%.go_nd.v1.GetFabricRoleSummaryRequest\x1a&.go_nd.v1.GetFabricRoleSummaryResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/fabrics/{fabric_id}/switch-roles\x12o\n" +
+	"\x0fGetFabricHealth\x12 .go_nd.v1.GetFabricHealthRequest\x1a\x1e.go_nd.v1.FabricHealthResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/health/fabricsB\x88\x01\n" +
 	"\fcom.go_nd.v1B\fFabricsProtoP\x01Z-github.com/banglin/go-nd/gen/go_nd/v1;go_ndv1\xa2\x02\x03GXX\xaa\x02\aGoNd.V1\xca\x02\aGoNd\\V1\xe2\x02\x13GoNd\\V1\\GPBMetadata\xea\x02\bGoNd::V1b\x06proto3"
 
 var (
@@ -2052,106 +3385,154 @@ func file_go_nd_v1_fabrics_proto_rawDescGZIP() []byte {
 	return file_go_nd_v1_fabrics_proto_rawDescData
 }
 
-var file_go_nd_v1_fabrics_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_go_nd_v1_fabrics_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_go_nd_v1_fabrics_proto_goTypes = []any{
-	(*Fabric)(nil),                // 0: go_nd.v1.Fabric
-	(*Switch)(nil),                // 1: go_nd.v1.Switch
-	(*SwitchPort)(nil),            // 2: go_nd.v1.SwitchPort
-	(*Network)(nil),               // 3: go_nd.v1.Network
-	(*ListFabricsRequest)(nil),    // 4: go_nd.v1.ListFabricsRequest
-	(*ListFabricsResponse)(nil),   // 5: go_nd.v1.ListFabricsResponse
-	(*GetFabricRequest)(nil),      // 6: go_nd.v1.GetFabricRequest
-	(*GetFabricResponse)(nil),     // 7: go_nd.v1.GetFabricResponse
-	(*CreateFabricRequest)(nil),   // 8: go_nd.v1.CreateFabricRequest
-	(*CreateFabricResponse)(nil),  // 9: go_nd.v1.CreateFabricResponse
-	(*SyncFabricsRequest)(nil),    // 10: go_nd.v1.SyncFabricsRequest
-	(*SyncFabricsResponse)(nil),   // 11: go_nd.v1.SyncFabricsResponse
-	(*ListSwitchesRequest)(nil),   // 12: go_nd.v1.ListSwitchesRequest
-	(*ListSwitchesResponse)(nil),  // 13: go_nd.v1.ListSwitchesResponse
-	(*GetSwitchRequest)(nil),      // 14: go_nd.v1.GetSwitchRequest
-	(*GetSwitchResponse)(nil),     // 15: go_nd.v1.GetSwitchResponse
-	(*CreateSwitchRequest)(nil),   // 16: go_nd.v1.CreateSwitchRequest
-	(*CreateSwitchResponse)(nil),  // 17: go_nd.v1.CreateSwitchResponse
-	(*SyncSwitchesRequest)(nil),   // 18: go_nd.v1.SyncSwitchesRequest
-	(*SyncSwitchesResponse)(nil),  // 19: go_nd.v1.SyncSwitchesResponse
-	(*ListNetworksRequest)(nil),   // 20: go_nd.v1.ListNetworksRequest
-	(*ListNetworksResponse)(nil),  // 21: go_nd.v1.ListNetworksResponse
-	(*ListPortsRequest)(nil),      // 22: go_nd.v1.ListPortsRequest
-	(*ListPortsResponse)(nil),     // 23: go_nd.v1.ListPortsResponse
-	(*GetPortRequest)(nil),        // 24: go_nd.v1.GetPortRequest
-	(*GetPortResponse)(nil),       // 25: go_nd.v1.GetPortResponse
-	(*CreatePortRequest)(nil),     // 26: go_nd.v1.CreatePortRequest
-	(*CreatePortResponse)(nil),    // 27: go_nd.v1.CreatePortResponse
-	(*SyncPortsRequest)(nil),      // 28: go_nd.v1.SyncPortsRequest
-	(*SyncPortsResponse)(nil),     // 29: go_nd.v1.SyncPortsResponse
-	(*DeletePortsRequest)(nil),    // 30: go_nd.v1.DeletePortsRequest
-	(*DeletePortsResponse)(nil),   // 31: go_nd.v1.DeletePortsResponse
-	(*timestamppb.Timestamp)(nil), // 32: google.protobuf.Timestamp
-	(*PaginationRequest)(nil),     // 33: go_nd.v1.PaginationRequest
-	(*PaginationResponse)(nil),    // 34: go_nd.v1.PaginationResponse
+	(*Fabric)(nil),                       // 0: go_nd.v1.Fabric
+	(*Switch)(nil),                       // 1: go_nd.v1.Switch
+	(*SwitchPort)(nil),                   // 2: go_nd.v1.SwitchPort
+	(*Network)(nil),                      // 3: go_nd.v1.Network
+	(*VRF)(nil),                          // 4: go_nd.v1.VRF
+	(*ListFabricsRequest)(nil),           // 5: go_nd.v1.ListFabricsRequest
+	(*ListFabricsResponse)(nil),          // 6: go_nd.v1.ListFabricsResponse
+	(*GetFabricRequest)(nil),             // 7: go_nd.v1.GetFabricRequest
+	(*GetFabricResponse)(nil),            // 8: go_nd.v1.GetFabricResponse
+	(*CreateFabricRequest)(nil),          // 9: go_nd.v1.CreateFabricRequest
+	(*CreateFabricResponse)(nil),         // 10: go_nd.v1.CreateFabricResponse
+	(*DeleteFabricRequest)(nil),          // 11: go_nd.v1.DeleteFabricRequest
+	(*DeleteFabricResponse)(nil),         // 12: go_nd.v1.DeleteFabricResponse
+	(*SyncFabricsRequest)(nil),           // 13: go_nd.v1.SyncFabricsRequest
+	(*SyncFabricsResponse)(nil),          // 14: go_nd.v1.SyncFabricsResponse
+	(*DeployFabricRequest)(nil),          // 15: go_nd.v1.DeployFabricRequest
+	(*DeployFabricResponse)(nil),         // 16: go_nd.v1.DeployFabricResponse
+	(*ListSwitchesRequest)(nil),          // 17: go_nd.v1.ListSwitchesRequest
+	(*ListSwitchesResponse)(nil),         // 18: go_nd.v1.ListSwitchesResponse
+	(*GetSwitchRequest)(nil),             // 19: go_nd.v1.GetSwitchRequest
+	(*GetSwitchResponse)(nil),            // 20: go_nd.v1.GetSwitchResponse
+	(*CreateSwitchRequest)(nil),          // 21: go_nd.v1.CreateSwitchRequest
+	(*CreateSwitchResponse)(nil),         // 22: go_nd.v1.CreateSwitchResponse
+	(*UpdateSwitchRequest)(nil),          // 23: go_nd.v1.UpdateSwitchRequest
+	(*UpdateSwitchResponse)(nil),         // 24: go_nd.v1.UpdateSwitchResponse
+	(*SyncSwitchesRequest)(nil),          // 25: go_nd.v1.SyncSwitchesRequest
+	(*SyncSwitchesResponse)(nil),         // 26: go_nd.v1.SyncSwitchesResponse
+	(*SyncStaleSwitchesRequest)(nil),     // 27: go_nd.v1.SyncStaleSwitchesRequest
+	(*SyncStaleSwitchesResponse)(nil),    // 28: go_nd.v1.SyncStaleSwitchesResponse
+	(*ListNetworksRequest)(nil),          // 29: go_nd.v1.ListNetworksRequest
+	(*ListNetworksResponse)(nil),         // 30: go_nd.v1.ListNetworksResponse
+	(*ListVRFsRequest)(nil),              // 31: go_nd.v1.ListVRFsRequest
+	(*ListVRFsResponse)(nil),             // 32: go_nd.v1.ListVRFsResponse
+	(*GetNetworkVLANRequest)(nil),        // 33: go_nd.v1.GetNetworkVLANRequest
+	(*GetNetworkVLANResponse)(nil),       // 34: go_nd.v1.GetNetworkVLANResponse
+	(*ListPortsRequest)(nil),             // 35: go_nd.v1.ListPortsRequest
+	(*ListPortsResponse)(nil),            // 36: go_nd.v1.ListPortsResponse
+	(*GetPortRequest)(nil),               // 37: go_nd.v1.GetPortRequest
+	(*GetPortResponse)(nil),              // 38: go_nd.v1.GetPortResponse
+	(*CreatePortRequest)(nil),            // 39: go_nd.v1.CreatePortRequest
+	(*CreatePortResponse)(nil),           // 40: go_nd.v1.CreatePortResponse
+	(*SyncPortsRequest)(nil),             // 41: go_nd.v1.SyncPortsRequest
+	(*SyncPortsResponse)(nil),            // 42: go_nd.v1.SyncPortsResponse
+	(*BulkSyncPortsRequest)(nil),         // 43: go_nd.v1.BulkSyncPortsRequest
+	(*SyncSwitchResult)(nil),             // 44: go_nd.v1.SyncSwitchResult
+	(*BulkSyncPortsResponse)(nil),        // 45: go_nd.v1.BulkSyncPortsResponse
+	(*DeletePortsRequest)(nil),           // 46: go_nd.v1.DeletePortsRequest
+	(*DeletePortsResponse)(nil),          // 47: go_nd.v1.DeletePortsResponse
+	(*GetFabricRoleSummaryRequest)(nil),  // 48: go_nd.v1.GetFabricRoleSummaryRequest
+	(*GetFabricRoleSummaryResponse)(nil), // 49: go_nd.v1.GetFabricRoleSummaryResponse
+	(*GetFabricHealthRequest)(nil),       // 50: go_nd.v1.GetFabricHealthRequest
+	(*FabricHealthResponse)(nil),         // 51: go_nd.v1.FabricHealthResponse
+	nil,                                  // 52: go_nd.v1.BulkSyncPortsResponse.ResultsEntry
+	(*timestamppb.Timestamp)(nil),        // 53: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),            // 54: go_nd.v1.PaginationRequest
+	(*PaginationResponse)(nil),           // 55: go_nd.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),        // 56: google.protobuf.FieldMask
 }
 var file_go_nd_v1_fabrics_proto_depIdxs = []int32{
-	32, // 0: go_nd.v1.Fabric.created_at:type_name -> google.protobuf.Timestamp
-	32, // 1: go_nd.v1.Fabric.updated_at:type_name -> google.protobuf.Timestamp
-	32, // 2: go_nd.v1.Switch.created_at:type_name -> google.protobuf.Timestamp
-	32, // 3: go_nd.v1.Switch.updated_at:type_name -> google.protobuf.Timestamp
-	32, // 4: go_nd.v1.SwitchPort.created_at:type_name -> google.protobuf.Timestamp
-	32, // 5: go_nd.v1.SwitchPort.updated_at:type_name -> google.protobuf.Timestamp
-	32, // 6: go_nd.v1.SwitchPort.last_seen_at:type_name -> google.protobuf.Timestamp
-	33, // 7: go_nd.v1.ListFabricsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	0,  // 8: go_nd.v1.ListFabricsResponse.fabrics:type_name -> go_nd.v1.Fabric
-	34, // 9: go_nd.v1.ListFabricsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	0,  // 10: go_nd.v1.GetFabricResponse.fabric:type_name -> go_nd.v1.Fabric
-	0,  // 11: go_nd.v1.CreateFabricResponse.fabric:type_name -> go_nd.v1.Fabric
-	0,  // 12: go_nd.v1.SyncFabricsResponse.fabrics:type_name -> go_nd.v1.Fabric
-	33, // 13: go_nd.v1.ListSwitchesRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	1,  // 14: go_nd.v1.ListSwitchesResponse.switches:type_name -> go_nd.v1.Switch
-	34, // 15: go_nd.v1.ListSwitchesResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	1,  // 16: go_nd.v1.GetSwitchResponse.switch:type_name -> go_nd.v1.Switch
-	1,  // 17: go_nd.v1.CreateSwitchResponse.switch:type_name -> go_nd.v1.Switch
-	1,  // 18: go_nd.v1.SyncSwitchesResponse.switches:type_name -> go_nd.v1.Switch
-	33, // 19: go_nd.v1.ListNetworksRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	3,  // 20: go_nd.v1.ListNetworksResponse.networks:type_name -> go_nd.v1.Network
-	34, // 21: go_nd.v1.ListNetworksResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	33, // 22: go_nd.v1.ListPortsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	2,  // 23: go_nd.v1.ListPortsResponse.ports:type_name -> go_nd.v1.SwitchPort
-	34, // 24: go_nd.v1.ListPortsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	2,  // 25: go_nd.v1.GetPortResponse.port:type_name -> go_nd.v1.SwitchPort
-	2,  // 26: go_nd.v1.CreatePortResponse.port:type_name -> go_nd.v1.SwitchPort
-	2,  // 27: go_nd.v1.SyncPortsResponse.ports:type_name -> go_nd.v1.SwitchPort
-	4,  // 28: go_nd.v1.FabricsService.ListFabrics:input_type -> go_nd.v1.ListFabricsRequest
-	6,  // 29: go_nd.v1.FabricsService.GetFabric:input_type -> go_nd.v1.GetFabricRequest
-	8,  // 30: go_nd.v1.FabricsService.CreateFabric:input_type -> go_nd.v1.CreateFabricRequest
-	10, // 31: go_nd.v1.FabricsService.SyncFabrics:input_type -> go_nd.v1.SyncFabricsRequest
-	12, // 32: go_nd.v1.FabricsService.ListSwitches:input_type -> go_nd.v1.ListSwitchesRequest
-	14, // 33: go_nd.v1.FabricsService.GetSwitch:input_type -> go_nd.v1.GetSwitchRequest
-	16, // 34: go_nd.v1.FabricsService.CreateSwitch:input_type -> go_nd.v1.CreateSwitchRequest
-	18, // 35: go_nd.v1.FabricsService.SyncSwitches:input_type -> go_nd.v1.SyncSwitchesRequest
-	20, // 36: go_nd.v1.FabricsService.ListNetworks:input_type -> go_nd.v1.ListNetworksRequest
-	22, // 37: go_nd.v1.FabricsService.ListPorts:input_type -> go_nd.v1.ListPortsRequest
-	24, // 38: go_nd.v1.FabricsService.GetPort:input_type -> go_nd.v1.GetPortRequest
-	26, // 39: go_nd.v1.FabricsService.CreatePort:input_type -> go_nd.v1.CreatePortRequest
-	28, // 40: go_nd.v1.FabricsService.SyncPorts:input_type -> go_nd.v1.SyncPortsRequest
-	30, // 41: go_nd.v1.FabricsService.DeletePorts:input_type -> go_nd.v1.DeletePortsRequest
-	5,  // 42: go_nd.v1.FabricsService.ListFabrics:output_type -> go_nd.v1.ListFabricsResponse
-	7,  // 43: go_nd.v1.FabricsService.GetFabric:output_type -> go_nd.v1.GetFabricResponse
-	9,  // 44: go_nd.v1.FabricsService.CreateFabric:output_type -> go_nd.v1.CreateFabricResponse
-	11, // 45: go_nd.v1.FabricsService.SyncFabrics:output_type -> go_nd.v1.SyncFabricsResponse
-	13, // 46: go_nd.v1.FabricsService.ListSwitches:output_type -> go_nd.v1.ListSwitchesResponse
-	15, // 47: go_nd.v1.FabricsService.GetSwitch:output_type -> go_nd.v1.GetSwitchResponse
-	17, // 48: go_nd.v1.FabricsService.CreateSwitch:output_type -> go_nd.v1.CreateSwitchResponse
-	19, // 49: go_nd.v1.FabricsService.SyncSwitches:output_type -> go_nd.v1.SyncSwitchesResponse
-	21, // 50: go_nd.v1.FabricsService.ListNetworks:output_type -> go_nd.v1.ListNetworksResponse
-	23, // 51: go_nd.v1.FabricsService.ListPorts:output_type -> go_nd.v1.ListPortsResponse
-	25, // 52: go_nd.v1.FabricsService.GetPort:output_type -> go_nd.v1.GetPortResponse
-	27, // 53: go_nd.v1.FabricsService.CreatePort:output_type -> go_nd.v1.CreatePortResponse
-	29, // 54: go_nd.v1.FabricsService.SyncPorts:output_type -> go_nd.v1.SyncPortsResponse
-	31, // 55: go_nd.v1.FabricsService.DeletePorts:output_type -> go_nd.v1.DeletePortsResponse
-	42, // [42:56] is the sub-list for method output_type
-	28, // [28:42] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	53, // 0: go_nd.v1.Fabric.created_at:type_name -> google.protobuf.Timestamp
+	53, // 1: go_nd.v1.Fabric.updated_at:type_name -> google.protobuf.Timestamp
+	53, // 2: go_nd.v1.Switch.created_at:type_name -> google.protobuf.Timestamp
+	53, // 3: go_nd.v1.Switch.updated_at:type_name -> google.protobuf.Timestamp
+	53, // 4: go_nd.v1.Switch.last_synced_at:type_name -> google.protobuf.Timestamp
+	53, // 5: go_nd.v1.SwitchPort.created_at:type_name -> google.protobuf.Timestamp
+	53, // 6: go_nd.v1.SwitchPort.updated_at:type_name -> google.protobuf.Timestamp
+	53, // 7: go_nd.v1.SwitchPort.last_seen_at:type_name -> google.protobuf.Timestamp
+	54, // 8: go_nd.v1.ListFabricsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	0,  // 9: go_nd.v1.ListFabricsResponse.fabrics:type_name -> go_nd.v1.Fabric
+	55, // 10: go_nd.v1.ListFabricsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	0,  // 11: go_nd.v1.GetFabricResponse.fabric:type_name -> go_nd.v1.Fabric
+	0,  // 12: go_nd.v1.CreateFabricResponse.fabric:type_name -> go_nd.v1.Fabric
+	0,  // 13: go_nd.v1.SyncFabricsResponse.fabrics:type_name -> go_nd.v1.Fabric
+	54, // 14: go_nd.v1.ListSwitchesRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	1,  // 15: go_nd.v1.ListSwitchesResponse.switches:type_name -> go_nd.v1.Switch
+	55, // 16: go_nd.v1.ListSwitchesResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	1,  // 17: go_nd.v1.GetSwitchResponse.switch:type_name -> go_nd.v1.Switch
+	1,  // 18: go_nd.v1.CreateSwitchResponse.switch:type_name -> go_nd.v1.Switch
+	56, // 19: go_nd.v1.UpdateSwitchRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 20: go_nd.v1.UpdateSwitchResponse.switch:type_name -> go_nd.v1.Switch
+	1,  // 21: go_nd.v1.SyncSwitchesResponse.switches:type_name -> go_nd.v1.Switch
+	54, // 22: go_nd.v1.ListNetworksRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	3,  // 23: go_nd.v1.ListNetworksResponse.networks:type_name -> go_nd.v1.Network
+	55, // 24: go_nd.v1.ListNetworksResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	4,  // 25: go_nd.v1.ListVRFsResponse.vrfs:type_name -> go_nd.v1.VRF
+	54, // 26: go_nd.v1.ListPortsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	2,  // 27: go_nd.v1.ListPortsResponse.ports:type_name -> go_nd.v1.SwitchPort
+	55, // 28: go_nd.v1.ListPortsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	2,  // 29: go_nd.v1.GetPortResponse.port:type_name -> go_nd.v1.SwitchPort
+	2,  // 30: go_nd.v1.CreatePortResponse.port:type_name -> go_nd.v1.SwitchPort
+	2,  // 31: go_nd.v1.SyncPortsResponse.ports:type_name -> go_nd.v1.SwitchPort
+	52, // 32: go_nd.v1.BulkSyncPortsResponse.results:type_name -> go_nd.v1.BulkSyncPortsResponse.ResultsEntry
+	53, // 33: go_nd.v1.FabricHealthResponse.last_fabric_sync:type_name -> google.protobuf.Timestamp
+	53, // 34: go_nd.v1.FabricHealthResponse.last_switch_sync:type_name -> google.protobuf.Timestamp
+	44, // 35: go_nd.v1.BulkSyncPortsResponse.ResultsEntry.value:type_name -> go_nd.v1.SyncSwitchResult
+	5,  // 36: go_nd.v1.FabricsService.ListFabrics:input_type -> go_nd.v1.ListFabricsRequest
+	7,  // 37: go_nd.v1.FabricsService.GetFabric:input_type -> go_nd.v1.GetFabricRequest
+	9,  // 38: go_nd.v1.FabricsService.CreateFabric:input_type -> go_nd.v1.CreateFabricRequest
+	11, // 39: go_nd.v1.FabricsService.DeleteFabric:input_type -> go_nd.v1.DeleteFabricRequest
+	13, // 40: go_nd.v1.FabricsService.SyncFabrics:input_type -> go_nd.v1.SyncFabricsRequest
+	15, // 41: go_nd.v1.FabricsService.DeployFabric:input_type -> go_nd.v1.DeployFabricRequest
+	17, // 42: go_nd.v1.FabricsService.ListSwitches:input_type -> go_nd.v1.ListSwitchesRequest
+	19, // 43: go_nd.v1.FabricsService.GetSwitch:input_type -> go_nd.v1.GetSwitchRequest
+	21, // 44: go_nd.v1.FabricsService.CreateSwitch:input_type -> go_nd.v1.CreateSwitchRequest
+	23, // 45: go_nd.v1.FabricsService.UpdateSwitch:input_type -> go_nd.v1.UpdateSwitchRequest
+	25, // 46: go_nd.v1.FabricsService.SyncSwitches:input_type -> go_nd.v1.SyncSwitchesRequest
+	27, // 47: go_nd.v1.FabricsService.SyncStaleSwitches:input_type -> go_nd.v1.SyncStaleSwitchesRequest
+	29, // 48: go_nd.v1.FabricsService.ListNetworks:input_type -> go_nd.v1.ListNetworksRequest
+	31, // 49: go_nd.v1.FabricsService.ListVRFs:input_type -> go_nd.v1.ListVRFsRequest
+	33, // 50: go_nd.v1.FabricsService.GetNetworkVLAN:input_type -> go_nd.v1.GetNetworkVLANRequest
+	35, // 51: go_nd.v1.FabricsService.ListPorts:input_type -> go_nd.v1.ListPortsRequest
+	37, // 52: go_nd.v1.FabricsService.GetPort:input_type -> go_nd.v1.GetPortRequest
+	39, // 53: go_nd.v1.FabricsService.CreatePort:input_type -> go_nd.v1.CreatePortRequest
+	41, // 54: go_nd.v1.FabricsService.SyncPorts:input_type -> go_nd.v1.SyncPortsRequest
+	43, // 55: go_nd.v1.FabricsService.BulkSyncPorts:input_type -> go_nd.v1.BulkSyncPortsRequest
+	46, // 56: go_nd.v1.FabricsService.DeletePorts:input_type -> go_nd.v1.DeletePortsRequest
+	48, // 57: go_nd.v1.FabricsService.GetFabricRoleSummary:input_type -> go_nd.v1.GetFabricRoleSummaryRequest
+	50, // 58: go_nd.v1.FabricsService.GetFabricHealth:input_type -> go_nd.v1.GetFabricHealthRequest
+	6,  // 59: go_nd.v1.FabricsService.ListFabrics:output_type -> go_nd.v1.ListFabricsResponse
+	8,  // 60: go_nd.v1.FabricsService.GetFabric:output_type -> go_nd.v1.GetFabricResponse
+	10, // 61: go_nd.v1.FabricsService.CreateFabric:output_type -> go_nd.v1.CreateFabricResponse
+	12, // 62: go_nd.v1.FabricsService.DeleteFabric:output_type -> go_nd.v1.DeleteFabricResponse
+	14, // 63: go_nd.v1.FabricsService.SyncFabrics:output_type -> go_nd.v1.SyncFabricsResponse
+	16, // 64: go_nd.v1.FabricsService.DeployFabric:output_type -> go_nd.v1.DeployFabricResponse
+	18, // 65: go_nd.v1.FabricsService.ListSwitches:output_type -> go_nd.v1.ListSwitchesResponse
+	20, // 66: go_nd.v1.FabricsService.GetSwitch:output_type -> go_nd.v1.GetSwitchResponse
+	22, // 67: go_nd.v1.FabricsService.CreateSwitch:output_type -> go_nd.v1.CreateSwitchResponse
+	24, // 68: go_nd.v1.FabricsService.UpdateSwitch:output_type -> go_nd.v1.UpdateSwitchResponse
+	26, // 69: go_nd.v1.FabricsService.SyncSwitches:output_type -> go_nd.v1.SyncSwitchesResponse
+	28, // 70: go_nd.v1.FabricsService.SyncStaleSwitches:output_type -> go_nd.v1.SyncStaleSwitchesResponse
+	30, // 71: go_nd.v1.FabricsService.ListNetworks:output_type -> go_nd.v1.ListNetworksResponse
+	32, // 72: go_nd.v1.FabricsService.ListVRFs:output_type -> go_nd.v1.ListVRFsResponse
+	34, // 73: go_nd.v1.FabricsService.GetNetworkVLAN:output_type -> go_nd.v1.GetNetworkVLANResponse
+	36, // 74: go_nd.v1.FabricsService.ListPorts:output_type -> go_nd.v1.ListPortsResponse
+	38, // 75: go_nd.v1.FabricsService.GetPort:output_type -> go_nd.v1.GetPortResponse
+	40, // 76: go_nd.v1.FabricsService.CreatePort:output_type -> go_nd.v1.CreatePortResponse
+	42, // 77: go_nd.v1.FabricsService.SyncPorts:output_type -> go_nd.v1.SyncPortsResponse
+	45, // 78: go_nd.v1.FabricsService.BulkSyncPorts:output_type -> go_nd.v1.BulkSyncPortsResponse
+	47, // 79: go_nd.v1.FabricsService.DeletePorts:output_type -> go_nd.v1.DeletePortsResponse
+	49, // 80: go_nd.v1.FabricsService.GetFabricRoleSummary:output_type -> go_nd.v1.GetFabricRoleSummaryResponse
+	51, // 81: go_nd.v1.FabricsService.GetFabricHealth:output_type -> go_nd.v1.FabricHealthResponse
+	59, // [59:82] is the sub-list for method output_type
+	36, // [36:59] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_go_nd_v1_fabrics_proto_init() }
@@ -2166,7 +3547,7 @@ func file_go_nd_v1_fabrics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_go_nd_v1_fabrics_proto_rawDesc), len(file_go_nd_v1_fabrics_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,20 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FabricsService_ListFabrics_FullMethodName  = "/go_nd.v1.FabricsService/ListFabrics"
-	FabricsService_GetFabric_FullMethodName    = "/go_nd.v1.FabricsService/GetFabric"
-	FabricsService_CreateFabric_FullMethodName = "/go_nd.v1.FabricsService/CreateFabric"
-	FabricsService_SyncFabrics_FullMethodName  = "/go_nd.v1.FabricsService/SyncFabrics"
-	FabricsService_ListSwitches_FullMethodName = "/go_nd.v1.FabricsService/ListSwitches"
-	FabricsService_GetSwitch_FullMethodName    = "/go_nd.v1.FabricsService/GetSwitch"
-	FabricsService_CreateSwitch_FullMethodName = "/go_nd.v1.FabricsService/CreateSwitch"
-	FabricsService_SyncSwitches_FullMethodName = "/go_nd.v1.FabricsService/SyncSwitches"
-	FabricsService_ListNetworks_FullMethodName = "/go_nd.v1.FabricsService/ListNetworks"
-	FabricsService_ListPorts_FullMethodName    = "/go_nd.v1.FabricsService/ListPorts"
-	FabricsService_GetPort_FullMethodName      = "/go_nd.v1.FabricsService/GetPort"
-	FabricsService_CreatePort_FullMethodName   = "/go_nd.v1.FabricsService/CreatePort"
-	FabricsService_SyncPorts_FullMethodName    = "/go_nd.v1.FabricsService/SyncPorts"
-	FabricsService_DeletePorts_FullMethodName  = "/go_nd.v1.FabricsService/DeletePorts"
+	FabricsService_ListFabrics_FullMethodName          = "/go_nd.v1.FabricsService/ListFabrics"
+	FabricsService_GetFabric_FullMethodName            = "/go_nd.v1.FabricsService/GetFabric"
+	FabricsService_CreateFabric_FullMethodName         = "/go_nd.v1.FabricsService/CreateFabric"
+	FabricsService_DeleteFabric_FullMethodName         = "/go_nd.v1.FabricsService/DeleteFabric"
+	FabricsService_SyncFabrics_FullMethodName          = "/go_nd.v1.FabricsService/SyncFabrics"
+	FabricsService_DeployFabric_FullMethodName         = "/go_nd.v1.FabricsService/DeployFabric"
+	FabricsService_ListSwitches_FullMethodName         = "/go_nd.v1.FabricsService/ListSwitches"
+	FabricsService_GetSwitch_FullMethodName            = "/go_nd.v1.FabricsService/GetSwitch"
+	FabricsService_CreateSwitch_FullMethodName         = "/go_nd.v1.FabricsService/CreateSwitch"
+	FabricsService_UpdateSwitch_FullMethodName         = "/go_nd.v1.FabricsService/UpdateSwitch"
+	FabricsService_SyncSwitches_FullMethodName         = "/go_nd.v1.FabricsService/SyncSwitches"
+	FabricsService_SyncStaleSwitches_FullMethodName    = "/go_nd.v1.FabricsService/SyncStaleSwitches"
+	FabricsService_ListNetworks_FullMethodName         = "/go_nd.v1.FabricsService/ListNetworks"
+	FabricsService_ListVRFs_FullMethodName             = "/go_nd.v1.FabricsService/ListVRFs"
+	FabricsService_GetNetworkVLAN_FullMethodName       = "/go_nd.v1.FabricsService/GetNetworkVLAN"
+	FabricsService_ListPorts_FullMethodName            = "/go_nd.v1.FabricsService/ListPorts"
+	FabricsService_GetPort_FullMethodName              = "/go_nd.v1.FabricsService/GetPort"
+	FabricsService_CreatePort_FullMethodName           = "/go_nd.v1.FabricsService/CreatePort"
+	FabricsService_SyncPorts_FullMethodName            = "/go_nd.v1.FabricsService/SyncPorts"
+	FabricsService_BulkSyncPorts_FullMethodName        = "/go_nd.v1.FabricsService/BulkSyncPorts"
+	FabricsService_DeletePorts_FullMethodName          = "/go_nd.v1.FabricsService/DeletePorts"
+	FabricsService_GetFabricRoleSummary_FullMethodName = "/go_nd.v1.FabricsService/GetFabricRoleSummary"
+	FabricsService_GetFabricHealth_FullMethodName      = "/go_nd.v1.FabricsService/GetFabricHealth"
 )
 
 // FabricsServiceClient is the client API for FabricsService service.
@@ -47,18 +56,30 @@ type FabricsServiceClient interface {
 	GetFabric(ctx context.Context, in *GetFabricRequest, opts ...grpc.CallOption) (*GetFabricResponse, error)
 	// CreateFabric creates a new fabric
 	CreateFabric(ctx context.Context, in *CreateFabricRequest, opts ...grpc.CallOption) (*CreateFabricResponse, error)
+	// DeleteFabric soft-deletes a fabric, refusing if it has active jobs or allocations unless forced
+	DeleteFabric(ctx context.Context, in *DeleteFabricRequest, opts ...grpc.CallOption) (*DeleteFabricResponse, error)
 	// SyncFabrics syncs fabrics from Nexus Dashboard
 	SyncFabrics(ctx context.Context, in *SyncFabricsRequest, opts ...grpc.CallOption) (*SyncFabricsResponse, error)
+	// DeployFabric deploys pending NDFC configuration for a fabric, batched with job deploys
+	DeployFabric(ctx context.Context, in *DeployFabricRequest, opts ...grpc.CallOption) (*DeployFabricResponse, error)
 	// ListSwitches lists switches in a fabric
 	ListSwitches(ctx context.Context, in *ListSwitchesRequest, opts ...grpc.CallOption) (*ListSwitchesResponse, error)
 	// GetSwitch retrieves a switch by ID
 	GetSwitch(ctx context.Context, in *GetSwitchRequest, opts ...grpc.CallOption) (*GetSwitchResponse, error)
 	// CreateSwitch creates a new switch
 	CreateSwitch(ctx context.Context, in *CreateSwitchRequest, opts ...grpc.CallOption) (*CreateSwitchResponse, error)
+	// UpdateSwitch updates a switch's local name, model or IP address without re-syncing from NDFC
+	UpdateSwitch(ctx context.Context, in *UpdateSwitchRequest, opts ...grpc.CallOption) (*UpdateSwitchResponse, error)
 	// SyncSwitches syncs switches from Nexus Dashboard
 	SyncSwitches(ctx context.Context, in *SyncSwitchesRequest, opts ...grpc.CallOption) (*SyncSwitchesResponse, error)
+	// SyncStaleSwitches syncs ports only for switches never synced or last synced before the threshold
+	SyncStaleSwitches(ctx context.Context, in *SyncStaleSwitchesRequest, opts ...grpc.CallOption) (*SyncStaleSwitchesResponse, error)
 	// ListNetworks lists networks in a fabric
 	ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error)
+	// ListVRFs lists the VRFs defined in NDFC for a fabric
+	ListVRFs(ctx context.Context, in *ListVRFsRequest, opts ...grpc.CallOption) (*ListVRFsResponse, error)
+	// GetNetworkVLAN returns the VLAN NDFC assigned to a network
+	GetNetworkVLAN(ctx context.Context, in *GetNetworkVLANRequest, opts ...grpc.CallOption) (*GetNetworkVLANResponse, error)
 	// ListPorts lists ports on a switch
 	ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error)
 	// GetPort retrieves a port by ID
@@ -67,8 +88,14 @@ type FabricsServiceClient interface {
 	CreatePort(ctx context.Context, in *CreatePortRequest, opts ...grpc.CallOption) (*CreatePortResponse, error)
 	// SyncPorts syncs ports from Nexus Dashboard
 	SyncPorts(ctx context.Context, in *SyncPortsRequest, opts ...grpc.CallOption) (*SyncPortsResponse, error)
+	// BulkSyncPorts syncs ports for several switches, or every switch of a fabric, in parallel
+	BulkSyncPorts(ctx context.Context, in *BulkSyncPortsRequest, opts ...grpc.CallOption) (*BulkSyncPortsResponse, error)
 	// DeletePorts deletes ports from a switch
 	DeletePorts(ctx context.Context, in *DeletePortsRequest, opts ...grpc.CallOption) (*DeletePortsResponse, error)
+	// GetFabricRoleSummary counts a fabric's switches by role with its leaf port capacity
+	GetFabricRoleSummary(ctx context.Context, in *GetFabricRoleSummaryRequest, opts ...grpc.CallOption) (*GetFabricRoleSummaryResponse, error)
+	// GetFabricHealth reports NDFC connectivity and local sync status
+	GetFabricHealth(ctx context.Context, in *GetFabricHealthRequest, opts ...grpc.CallOption) (*FabricHealthResponse, error)
 }
 
 type fabricsServiceClient struct {
@@ -109,6 +136,16 @@ func (c *fabricsServiceClient) CreateFabric(ctx context.Context, in *CreateFabri
 	return out, nil
 }
 
+func (c *fabricsServiceClient) DeleteFabric(ctx context.Context, in *DeleteFabricRequest, opts ...grpc.CallOption) (*DeleteFabricResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFabricResponse)
+	err := c.cc.Invoke(ctx, FabricsService_DeleteFabric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) SyncFabrics(ctx context.Context, in *SyncFabricsRequest, opts ...grpc.CallOption) (*SyncFabricsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SyncFabricsResponse)
@@ -119,6 +156,16 @@ func (c *fabricsServiceClient) SyncFabrics(ctx context.Context, in *SyncFabricsR
 	return out, nil
 }
 
+func (c *fabricsServiceClient) DeployFabric(ctx context.Context, in *DeployFabricRequest, opts ...grpc.CallOption) (*DeployFabricResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployFabricResponse)
+	err := c.cc.Invoke(ctx, FabricsService_DeployFabric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) ListSwitches(ctx context.Context, in *ListSwitchesRequest, opts ...grpc.CallOption) (*ListSwitchesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListSwitchesResponse)
@@ -149,6 +196,16 @@ func (c *fabricsServiceClient) CreateSwitch(ctx context.Context, in *CreateSwitc
 	return out, nil
 }
 
+func (c *fabricsServiceClient) UpdateSwitch(ctx context.Context, in *UpdateSwitchRequest, opts ...grpc.CallOption) (*UpdateSwitchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSwitchResponse)
+	err := c.cc.Invoke(ctx, FabricsService_UpdateSwitch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) SyncSwitches(ctx context.Context, in *SyncSwitchesRequest, opts ...grpc.CallOption) (*SyncSwitchesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SyncSwitchesResponse)
@@ -159,6 +216,16 @@ func (c *fabricsServiceClient) SyncSwitches(ctx context.Context, in *SyncSwitche
 	return out, nil
 }
 
+func (c *fabricsServiceClient) SyncStaleSwitches(ctx context.Context, in *SyncStaleSwitchesRequest, opts ...grpc.CallOption) (*SyncStaleSwitchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncStaleSwitchesResponse)
+	err := c.cc.Invoke(ctx, FabricsService_SyncStaleSwitches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNetworksResponse)
@@ -169,6 +236,26 @@ func (c *fabricsServiceClient) ListNetworks(ctx context.Context, in *ListNetwork
 	return out, nil
 }
 
+func (c *fabricsServiceClient) ListVRFs(ctx context.Context, in *ListVRFsRequest, opts ...grpc.CallOption) (*ListVRFsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVRFsResponse)
+	err := c.cc.Invoke(ctx, FabricsService_ListVRFs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fabricsServiceClient) GetNetworkVLAN(ctx context.Context, in *GetNetworkVLANRequest, opts ...grpc.CallOption) (*GetNetworkVLANResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetworkVLANResponse)
+	err := c.cc.Invoke(ctx, FabricsService_GetNetworkVLAN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListPortsResponse)
@@ -209,6 +296,16 @@ func (c *fabricsServiceClient) SyncPorts(ctx context.Context, in *SyncPortsReque
 	return out, nil
 }
 
+func (c *fabricsServiceClient) BulkSyncPorts(ctx context.Context, in *BulkSyncPortsRequest, opts ...grpc.CallOption) (*BulkSyncPortsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkSyncPortsResponse)
+	err := c.cc.Invoke(ctx, FabricsService_BulkSyncPorts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fabricsServiceClient) DeletePorts(ctx context.Context, in *DeletePortsRequest, opts ...grpc.CallOption) (*DeletePortsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeletePortsResponse)
@@ -219,6 +316,26 @@ func (c *fabricsServiceClient) DeletePorts(ctx context.Context, in *DeletePortsR
 	return out, nil
 }
 
+func (c *fabricsServiceClient) GetFabricRoleSummary(ctx context.Context, in *GetFabricRoleSummaryRequest, opts ...grpc.CallOption) (*GetFabricRoleSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFabricRoleSummaryResponse)
+	err := c.cc.Invoke(ctx, FabricsService_GetFabricRoleSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fabricsServiceClient) GetFabricHealth(ctx context.Context, in *GetFabricHealthRequest, opts ...grpc.CallOption) (*FabricHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FabricHealthResponse)
+	err := c.cc.Invoke(ctx, FabricsService_GetFabricHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FabricsServiceServer is the server API for FabricsService service.
 // All implementations must embed UnimplementedFabricsServiceServer
 // for forward compatibility.
@@ -231,18 +348,30 @@ type FabricsServiceServer interface {
 	GetFabric(context.Context, *GetFabricRequest) (*GetFabricResponse, error)
 	// CreateFabric creates a new fabric
 	CreateFabric(context.Context, *CreateFabricRequest) (*CreateFabricResponse, error)
+	// DeleteFabric soft-deletes a fabric, refusing if it has active jobs or allocations unless forced
+	DeleteFabric(context.Context, *DeleteFabricRequest) (*DeleteFabricResponse, error)
 	// SyncFabrics syncs fabrics from Nexus Dashboard
 	SyncFabrics(context.Context, *SyncFabricsRequest) (*SyncFabricsResponse, error)
+	// DeployFabric deploys pending NDFC configuration for a fabric, batched with job deploys
+	DeployFabric(context.Context, *DeployFabricRequest) (*DeployFabricResponse, error)
 	// ListSwitches lists switches in a fabric
 	ListSwitches(context.Context, *ListSwitchesRequest) (*ListSwitchesResponse, error)
 	// GetSwitch retrieves a switch by ID
 	GetSwitch(context.Context, *GetSwitchRequest) (*GetSwitchResponse, error)
 	// CreateSwitch creates a new switch
 	CreateSwitch(context.Context, *CreateSwitchRequest) (*CreateSwitchResponse, error)
+	// UpdateSwitch updates a switch's local name, model or IP address without re-syncing from NDFC
+	UpdateSwitch(context.Context, *UpdateSwitchRequest) (*UpdateSwitchResponse, error)
 	// SyncSwitches syncs switches from Nexus Dashboard
 	SyncSwitches(context.Context, *SyncSwitchesRequest) (*SyncSwitchesResponse, error)
+	// SyncStaleSwitches syncs ports only for switches never synced or last synced before the threshold
+	SyncStaleSwitches(context.Context, *SyncStaleSwitchesRequest) (*SyncStaleSwitchesResponse, error)
 	// ListNetworks lists networks in a fabric
 	ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error)
+	// ListVRFs lists the VRFs defined in NDFC for a fabric
+	ListVRFs(context.Context, *ListVRFsRequest) (*ListVRFsResponse, error)
+	// GetNetworkVLAN returns the VLAN NDFC assigned to a network
+	GetNetworkVLAN(context.Context, *GetNetworkVLANRequest) (*GetNetworkVLANResponse, error)
 	// ListPorts lists ports on a switch
 	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
 	// GetPort retrieves a port by ID
@@ -251,8 +380,14 @@ type FabricsServiceServer interface {
 	CreatePort(context.Context, *CreatePortRequest) (*CreatePortResponse, error)
 	// SyncPorts syncs ports from Nexus Dashboard
 	SyncPorts(context.Context, *SyncPortsRequest) (*SyncPortsResponse, error)
+	// BulkSyncPorts syncs ports for several switches, or every switch of a fabric, in parallel
+	BulkSyncPorts(context.Context, *BulkSyncPortsRequest) (*BulkSyncPortsResponse, error)
 	// DeletePorts deletes ports from a switch
 	DeletePorts(context.Context, *DeletePortsRequest) (*DeletePortsResponse, error)
+	// GetFabricRoleSummary counts a fabric's switches by role with its leaf port capacity
+	GetFabricRoleSummary(context.Context, *GetFabricRoleSummaryRequest) (*GetFabricRoleSummaryResponse, error)
+	// GetFabricHealth reports NDFC connectivity and local sync status
+	GetFabricHealth(context.Context, *GetFabricHealthRequest) (*FabricHealthResponse, error)
 	mustEmbedUnimplementedFabricsServiceServer()
 }
 
@@ -272,9 +407,15 @@ func (UnimplementedFabricsServiceServer) GetFabric(context.Context, *GetFabricRe
 func (UnimplementedFabricsServiceServer) CreateFabric(context.Context, *CreateFabricRequest) (*CreateFabricResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateFabric not implemented")
 }
+func (UnimplementedFabricsServiceServer) DeleteFabric(context.Context, *DeleteFabricRequest) (*DeleteFabricResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFabric not implemented")
+}
 func (UnimplementedFabricsServiceServer) SyncFabrics(context.Context, *SyncFabricsRequest) (*SyncFabricsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SyncFabrics not implemented")
 }
+func (UnimplementedFabricsServiceServer) DeployFabric(context.Context, *DeployFabricRequest) (*DeployFabricResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeployFabric not implemented")
+}
 func (UnimplementedFabricsServiceServer) ListSwitches(context.Context, *ListSwitchesRequest) (*ListSwitchesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSwitches not implemented")
 }
@@ -284,12 +425,24 @@ func (UnimplementedFabricsServiceServer) GetSwitch(context.Context, *GetSwitchRe
 func (UnimplementedFabricsServiceServer) CreateSwitch(context.Context, *CreateSwitchRequest) (*CreateSwitchResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateSwitch not implemented")
 }
+func (UnimplementedFabricsServiceServer) UpdateSwitch(context.Context, *UpdateSwitchRequest) (*UpdateSwitchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSwitch not implemented")
+}
 func (UnimplementedFabricsServiceServer) SyncSwitches(context.Context, *SyncSwitchesRequest) (*SyncSwitchesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SyncSwitches not implemented")
 }
+func (UnimplementedFabricsServiceServer) SyncStaleSwitches(context.Context, *SyncStaleSwitchesRequest) (*SyncStaleSwitchesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SyncStaleSwitches not implemented")
+}
 func (UnimplementedFabricsServiceServer) ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNetworks not implemented")
 }
+func (UnimplementedFabricsServiceServer) ListVRFs(context.Context, *ListVRFsRequest) (*ListVRFsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVRFs not implemented")
+}
+func (UnimplementedFabricsServiceServer) GetNetworkVLAN(context.Context, *GetNetworkVLANRequest) (*GetNetworkVLANResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNetworkVLAN not implemented")
+}
 func (UnimplementedFabricsServiceServer) ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListPorts not implemented")
 }
@@ -302,9 +455,18 @@ func (UnimplementedFabricsServiceServer) CreatePort(context.Context, *CreatePort
 func (UnimplementedFabricsServiceServer) SyncPorts(context.Context, *SyncPortsRequest) (*SyncPortsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SyncPorts not implemented")
 }
+func (UnimplementedFabricsServiceServer) BulkSyncPorts(context.Context, *BulkSyncPortsRequest) (*BulkSyncPortsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkSyncPorts not implemented")
+}
 func (UnimplementedFabricsServiceServer) DeletePorts(context.Context, *DeletePortsRequest) (*DeletePortsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeletePorts not implemented")
 }
+func (UnimplementedFabricsServiceServer) GetFabricRoleSummary(context.Context, *GetFabricRoleSummaryRequest) (*GetFabricRoleSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFabricRoleSummary not implemented")
+}
+func (UnimplementedFabricsServiceServer) GetFabricHealth(context.Context, *GetFabricHealthRequest) (*FabricHealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFabricHealth not implemented")
+}
 func (UnimplementedFabricsServiceServer) mustEmbedUnimplementedFabricsServiceServer() {}
 func (UnimplementedFabricsServiceServer) testEmbeddedByValue()                        {}
 
@@ -380,6 +542,24 @@ func _FabricsService_CreateFabric_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_DeleteFabric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFabricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).DeleteFabric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_DeleteFabric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).DeleteFabric(ctx, req.(*DeleteFabricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_SyncFabrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SyncFabricsRequest)
 	if err := dec(in); err != nil {
@@ -398,6 +578,24 @@ func _FabricsService_SyncFabrics_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_DeployFabric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployFabricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).DeployFabric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_DeployFabric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).DeployFabric(ctx, req.(*DeployFabricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_ListSwitches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSwitchesRequest)
 	if err := dec(in); err != nil {
@@ -452,6 +650,24 @@ func _FabricsService_CreateSwitch_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_UpdateSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).UpdateSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_UpdateSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).UpdateSwitch(ctx, req.(*UpdateSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_SyncSwitches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SyncSwitchesRequest)
 	if err := dec(in); err != nil {
@@ -470,6 +686,24 @@ func _FabricsService_SyncSwitches_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_SyncStaleSwitches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncStaleSwitchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).SyncStaleSwitches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_SyncStaleSwitches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).SyncStaleSwitches(ctx, req.(*SyncStaleSwitchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListNetworksRequest)
 	if err := dec(in); err != nil {
@@ -488,6 +722,42 @@ func _FabricsService_ListNetworks_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_ListVRFs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVRFsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).ListVRFs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_ListVRFs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).ListVRFs(ctx, req.(*ListVRFsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FabricsService_GetNetworkVLAN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkVLANRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).GetNetworkVLAN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_GetNetworkVLAN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).GetNetworkVLAN(ctx, req.(*GetNetworkVLANRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPortsRequest)
 	if err := dec(in); err != nil {
@@ -560,6 +830,24 @@ func _FabricsService_SyncPorts_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_BulkSyncPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSyncPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).BulkSyncPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_BulkSyncPorts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).BulkSyncPorts(ctx, req.(*BulkSyncPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FabricsService_DeletePorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeletePortsRequest)
 	if err := dec(in); err != nil {
@@ -578,6 +866,42 @@ func _FabricsService_DeletePorts_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FabricsService_GetFabricRoleSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFabricRoleSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).GetFabricRoleSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_GetFabricRoleSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).GetFabricRoleSummary(ctx, req.(*GetFabricRoleSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FabricsService_GetFabricHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFabricHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FabricsServiceServer).GetFabricHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FabricsService_GetFabricHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FabricsServiceServer).GetFabricHealth(ctx, req.(*GetFabricHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FabricsService_ServiceDesc is the grpc.ServiceDesc for FabricsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -597,10 +921,18 @@ var FabricsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateFabric",
 			Handler:    _FabricsService_CreateFabric_Handler,
 		},
+		{
+			MethodName: "DeleteFabric",
+			Handler:    _FabricsService_DeleteFabric_Handler,
+		},
 		{
 			MethodName: "SyncFabrics",
 			Handler:    _FabricsService_SyncFabrics_Handler,
 		},
+		{
+			MethodName: "DeployFabric",
+			Handler:    _FabricsService_DeployFabric_Handler,
+		},
 		{
 			MethodName: "ListSwitches",
 			Handler:    _FabricsService_ListSwitches_Handler,
@@ -613,14 +945,30 @@ var FabricsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSwitch",
 			Handler:    _FabricsService_CreateSwitch_Handler,
 		},
+		{
+			MethodName: "UpdateSwitch",
+			Handler:    _FabricsService_UpdateSwitch_Handler,
+		},
 		{
 			MethodName: "SyncSwitches",
 			Handler:    _FabricsService_SyncSwitches_Handler,
 		},
+		{
+			MethodName: "SyncStaleSwitches",
+			Handler:    _FabricsService_SyncStaleSwitches_Handler,
+		},
 		{
 			MethodName: "ListNetworks",
 			Handler:    _FabricsService_ListNetworks_Handler,
 		},
+		{
+			MethodName: "ListVRFs",
+			Handler:    _FabricsService_ListVRFs_Handler,
+		},
+		{
+			MethodName: "GetNetworkVLAN",
+			Handler:    _FabricsService_GetNetworkVLAN_Handler,
+		},
 		{
 			MethodName: "ListPorts",
 			Handler:    _FabricsService_ListPorts_Handler,
@@ -637,10 +985,22 @@ var FabricsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncPorts",
 			Handler:    _FabricsService_SyncPorts_Handler,
 		},
+		{
+			MethodName: "BulkSyncPorts",
+			Handler:    _FabricsService_BulkSyncPorts_Handler,
+		},
 		{
 			MethodName: "DeletePorts",
 			Handler:    _FabricsService_DeletePorts_Handler,
 		},
+		{
+			MethodName: "GetFabricRoleSummary",
+			Handler:    _FabricsService_GetFabricRoleSummary_Handler,
+		},
+		{
+			MethodName: "GetFabricHealth",
+			Handler:    _FabricsService_GetFabricHealth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "go_nd/v1/fabrics.proto",
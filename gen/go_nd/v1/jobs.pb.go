@@ -7,8 +7,10 @@
 package go_ndv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -87,26 +89,79 @@ func (JobStatus) EnumDescriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{0}
 }
 
+// Kind of a JobEvent
+type JobEventType int32
+
+const (
+	JobEventType_JOB_EVENT_TYPE_UNSPECIFIED    JobEventType = 0
+	JobEventType_JOB_EVENT_TYPE_STATUS_CHANGED JobEventType = 1 // The job moved to a new status
+	JobEventType_JOB_EVENT_TYPE_HEARTBEAT      JobEventType = 2 // No status change for a while; the stream is still alive
+)
+
+// Enum value maps for JobEventType.
+var (
+	JobEventType_name = map[int32]string{
+		0: "JOB_EVENT_TYPE_UNSPECIFIED",
+		1: "JOB_EVENT_TYPE_STATUS_CHANGED",
+		2: "JOB_EVENT_TYPE_HEARTBEAT",
+	}
+	JobEventType_value = map[string]int32{
+		"JOB_EVENT_TYPE_UNSPECIFIED":    0,
+		"JOB_EVENT_TYPE_STATUS_CHANGED": 1,
+		"JOB_EVENT_TYPE_HEARTBEAT":      2,
+	}
+)
+
+func (x JobEventType) Enum() *JobEventType {
+	p := new(JobEventType)
+	*p = x
+	return p
+}
+
+func (x JobEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_go_nd_v1_jobs_proto_enumTypes[1].Descriptor()
+}
+
+func (JobEventType) Type() protoreflect.EnumType {
+	return &file_go_nd_v1_jobs_proto_enumTypes[1]
+}
+
+func (x JobEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobEventType.Descriptor instead.
+func (JobEventType) EnumDescriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{1}
+}
+
 // Job represents a Slurm job with security provisioning
 type Job struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                     // Internal UUID
-	SlurmJobId      string                 `protobuf:"bytes,2,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`                 // Slurm job ID (unique)
-	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                 // Job name
-	TenantKey       string                 `protobuf:"bytes,4,opt,name=tenant_key,json=tenantKey,proto3" json:"tenant_key,omitempty"`                      // Storage tenant key for tenant-specific storage access
-	Status          JobStatus              `protobuf:"varint,5,opt,name=status,proto3,enum=go_nd.v1.JobStatus" json:"status,omitempty"`                    // Current status
-	ErrorMessage    string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`             // Error details if failed
-	FabricName      string                 `protobuf:"bytes,7,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`                   // NDFC fabric name
-	VrfName         string                 `protobuf:"bytes,8,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`                            // VRF name
-	ContractName    string                 `protobuf:"bytes,9,opt,name=contract_name,json=contractName,proto3" json:"contract_name,omitempty"`             // Contract name
-	SubmittedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`               // When job was submitted
-	ProvisionedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=provisioned_at,json=provisionedAt,proto3" json:"provisioned_at,omitempty"`         // When provisioning completed
-	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`               // When job completed
-	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                     // Expiration time (if set)
-	ComputeNodes    []*JobComputeNode      `protobuf:"bytes,14,rep,name=compute_nodes,json=computeNodes,proto3" json:"compute_nodes,omitempty"`            // Assigned compute nodes
-	SecurityGroupId string                 `protobuf:"bytes,15,opt,name=security_group_id,json=securityGroupId,proto3" json:"security_group_id,omitempty"` // Associated security group ID
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                // Internal UUID
+	SlurmJobId              string                 `protobuf:"bytes,2,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`                                            // Slurm job ID (unique)
+	Name                    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                                            // Job name
+	TenantKey               string                 `protobuf:"bytes,4,opt,name=tenant_key,json=tenantKey,proto3" json:"tenant_key,omitempty"`                                                 // Storage tenant key for tenant-specific storage access
+	Status                  JobStatus              `protobuf:"varint,5,opt,name=status,proto3,enum=go_nd.v1.JobStatus" json:"status,omitempty"`                                               // Current status
+	ErrorMessage            string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`                                        // Error details if failed
+	FabricName              string                 `protobuf:"bytes,7,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`                                              // NDFC fabric name
+	VrfName                 string                 `protobuf:"bytes,8,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`                                                       // VRF name
+	ContractName            string                 `protobuf:"bytes,9,opt,name=contract_name,json=contractName,proto3" json:"contract_name,omitempty"`                                        // Contract name
+	SubmittedAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`                                          // When job was submitted
+	ProvisionedAt           *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=provisioned_at,json=provisionedAt,proto3" json:"provisioned_at,omitempty"`                                    // When provisioning completed
+	CompletedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`                                          // When job completed
+	ExpiresAt               *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                                // Expiration time (if set)
+	ComputeNodes            []*JobComputeNode      `protobuf:"bytes,14,rep,name=compute_nodes,json=computeNodes,proto3" json:"compute_nodes,omitempty"`                                       // Assigned compute nodes
+	SecurityGroupId         string                 `protobuf:"bytes,15,opt,name=security_group_id,json=securityGroupId,proto3" json:"security_group_id,omitempty"`                            // Associated security group ID
+	Description             string                 `protobuf:"bytes,16,opt,name=description,proto3" json:"description,omitempty"`                                                             // Free-form job description
+	ProvisionTimeoutMinutes int32                  `protobuf:"varint,17,opt,name=provision_timeout_minutes,json=provisionTimeoutMinutes,proto3" json:"provision_timeout_minutes,omitempty"`   // Effective NDFC provisioning timeout
+	Tags                    map[string]string      `protobuf:"bytes,18,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // User-defined labels
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *Job) Reset() {
@@ -244,6 +299,27 @@ func (x *Job) GetSecurityGroupId() string {
 	return ""
 }
 
+func (x *Job) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Job) GetProvisionTimeoutMinutes() int32 {
+	if x != nil {
+		return x.ProvisionTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *Job) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 // JobComputeNode links a job to a compute node
 type JobComputeNode struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -315,13 +391,18 @@ func (x *JobComputeNode) GetComputeNodeName() string {
 
 // SubmitJobRequest creates a new job
 type SubmitJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`     // Required: Slurm job ID
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                     // Optional: Job name
-	ComputeNodes  []string               `protobuf:"bytes,3,rep,name=compute_nodes,json=computeNodes,proto3" json:"compute_nodes,omitempty"` // Required: List of compute node names
-	Tenant        string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`                                 // Optional: Storage tenant key for tenant-specific storage access
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobId      string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`                                                                                     // Required: Slurm job ID
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                     // Optional: Job name
+	ComputeNodes    []string               `protobuf:"bytes,3,rep,name=compute_nodes,json=computeNodes,proto3" json:"compute_nodes,omitempty"`                                                                                 // Required: List of compute node names
+	Tenant          string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                                                                                 // Optional: Storage tenant key for tenant-specific storage access
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                                                                                                       // Optional: Used in port descriptions as "HPC:<slurm_job_id>/<description>"
+	RequiredLabels  map[string]string      `protobuf:"bytes,6,rep,name=required_labels,json=requiredLabels,proto3" json:"required_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Optional: Labels every compute node must carry (FailedPrecondition otherwise)
+	TimeoutMinutes  int32                  `protobuf:"varint,7,opt,name=timeout_minutes,json=timeoutMinutes,proto3" json:"timeout_minutes,omitempty"`                                                                          // Optional: NDFC provisioning timeout override (0 = default 10m; capped by MAX_PROVISION_TIMEOUT_MINUTES)
+	MinNodeFraction float32                `protobuf:"fixed32,8,opt,name=min_node_fraction,json=minNodeFraction,proto3" json:"min_node_fraction,omitempty"`                                                                    // Optional: Skip nodes allocated to other jobs if this fraction of compute_nodes is available (0 = all required)
+	Tags            map[string]string      `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                           // Optional: Labels for grouping and filtering jobs (e.g. Slurm account and comment)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SubmitJobRequest) Reset() {
@@ -382,11 +463,47 @@ func (x *SubmitJobRequest) GetTenant() string {
 	return ""
 }
 
+func (x *SubmitJobRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetRequiredLabels() map[string]string {
+	if x != nil {
+		return x.RequiredLabels
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetTimeoutMinutes() int32 {
+	if x != nil {
+		return x.TimeoutMinutes
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetMinNodeFraction() float32 {
+	if x != nil {
+		return x.MinNodeFraction
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 // SubmitJobResponse returns the created/existing job
 type SubmitJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"` // true if new job created, false if existing returned
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`                              // true if new job created, false if existing returned
+	SkippedNodes  []string               `protobuf:"bytes,3,rep,name=skipped_nodes,json=skippedNodes,proto3" json:"skipped_nodes,omitempty"` // Requested nodes left out under min_node_fraction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -435,28 +552,37 @@ func (x *SubmitJobResponse) GetCreated() bool {
 	return false
 }
 
-// GetJobRequest retrieves a job by Slurm job ID
-type GetJobRequest struct {
+func (x *SubmitJobResponse) GetSkippedNodes() []string {
+	if x != nil {
+		return x.SkippedNodes
+	}
+	return nil
+}
+
+// JobContractRule is a rule of a contract created for a job
+type JobContractRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
+	Direction     string                 `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`                           // e.g. "bidirectional"
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                                 // "permit" or "deny"
+	ProtocolName  string                 `protobuf:"bytes,3,opt,name=protocol_name,json=protocolName,proto3" json:"protocol_name,omitempty"` // NDFC protocol name
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
+func (x *JobContractRule) Reset() {
+	*x = JobContractRule{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *JobContractRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*JobContractRule) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+func (x *JobContractRule) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -468,40 +594,55 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobContractRule.ProtoReflect.Descriptor instead.
+func (*JobContractRule) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetJobRequest) GetSlurmJobId() string {
+func (x *JobContractRule) GetDirection() string {
 	if x != nil {
-		return x.SlurmJobId
+		return x.Direction
 	}
 	return ""
 }
 
-// GetJobResponse returns the job
-type GetJobResponse struct {
+func (x *JobContractRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *JobContractRule) GetProtocolName() string {
+	if x != nil {
+		return x.ProtocolName
+	}
+	return ""
+}
+
+// JobContractRuleSet is one contract created for a job instead of the default job contract
+type JobContractRuleSet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules         []*JobContractRule     `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
+func (x *JobContractRuleSet) Reset() {
+	*x = JobContractRuleSet{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobResponse) String() string {
+func (x *JobContractRuleSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (*JobContractRuleSet) ProtoMessage() {}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+func (x *JobContractRuleSet) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -513,45 +654,57 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobContractRuleSet.ProtoReflect.Descriptor instead.
+func (*JobContractRuleSet) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *JobContractRuleSet) GetName() string {
 	if x != nil {
-		return x.Job
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-// ListJobsRequest lists jobs with optional filters
-type ListJobsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Filter by status (empty = all)
-	Statuses []JobStatus `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=go_nd.v1.JobStatus" json:"statuses,omitempty"`
-	// Filter by fabric name
-	FabricName string `protobuf:"bytes,2,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
-	// Pagination
-	Pagination    *PaginationRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *JobContractRuleSet) GetRules() []*JobContractRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
 }
 
-func (x *ListJobsRequest) Reset() {
-	*x = ListJobsRequest{}
+// ProvisionJobRequest provisions a new job
+type ProvisionJobRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobId             string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`                                                                                     // Required: Slurm job ID
+	Name                   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                     // Optional: Job name
+	ComputeNodes           []string               `protobuf:"bytes,3,rep,name=compute_nodes,json=computeNodes,proto3" json:"compute_nodes,omitempty"`                                                                                 // Required: Compute node names or hostnames
+	Tenant                 string                 `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                                                                                 // Optional: Storage tenant key
+	Description            string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                                                                                                       // Optional: Used in port descriptions as "HPC:<slurm_job_id>/<description>"
+	RequiredLabels         map[string]string      `protobuf:"bytes,6,rep,name=required_labels,json=requiredLabels,proto3" json:"required_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Optional: Labels every compute node must carry (FailedPrecondition otherwise)
+	TimeoutMinutes         int32                  `protobuf:"varint,7,opt,name=timeout_minutes,json=timeoutMinutes,proto3" json:"timeout_minutes,omitempty"`                                                                          // Optional: NDFC provisioning timeout override (0 = default 10m)
+	MinNodeFraction        float32                `protobuf:"fixed32,8,opt,name=min_node_fraction,json=minNodeFraction,proto3" json:"min_node_fraction,omitempty"`                                                                    // Optional: Skip nodes allocated to other jobs if this fraction is available (0 = all required)
+	Tags                   map[string]string      `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                           // Optional: Labels for grouping and filtering jobs
+	ContractRules          []*JobContractRuleSet  `protobuf:"bytes,10,rep,name=contract_rules,json=contractRules,proto3" json:"contract_rules,omitempty"`                                                                             // Optional: One contract per rule set instead of the default job contract
+	SkipSelectorValidation bool                   `protobuf:"varint,11,opt,name=skip_selector_validation,json=skipSelectorValidation,proto3" json:"skip_selector_validation,omitempty"`                                               // Optional: Do not check the NDFC security group holds every port selector
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ProvisionJobRequest) Reset() {
+	*x = ProvisionJobRequest{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsRequest) String() string {
+func (x *ProvisionJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsRequest) ProtoMessage() {}
+func (*ProvisionJobRequest) ProtoMessage() {}
 
-func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+func (x *ProvisionJobRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -563,55 +716,111 @@ func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
-func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProvisionJobRequest.ProtoReflect.Descriptor instead.
+func (*ProvisionJobRequest) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListJobsRequest) GetStatuses() []JobStatus {
+func (x *ProvisionJobRequest) GetSlurmJobId() string {
 	if x != nil {
-		return x.Statuses
+		return x.SlurmJobId
+	}
+	return ""
+}
+
+func (x *ProvisionJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProvisionJobRequest) GetComputeNodes() []string {
+	if x != nil {
+		return x.ComputeNodes
 	}
 	return nil
 }
 
-func (x *ListJobsRequest) GetFabricName() string {
+func (x *ProvisionJobRequest) GetTenant() string {
 	if x != nil {
-		return x.FabricName
+		return x.Tenant
 	}
 	return ""
 }
 
-func (x *ListJobsRequest) GetPagination() *PaginationRequest {
+func (x *ProvisionJobRequest) GetDescription() string {
 	if x != nil {
-		return x.Pagination
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProvisionJobRequest) GetRequiredLabels() map[string]string {
+	if x != nil {
+		return x.RequiredLabels
 	}
 	return nil
 }
 
-// ListJobsResponse returns matching jobs
-type ListJobsResponse struct {
+func (x *ProvisionJobRequest) GetTimeoutMinutes() int32 {
+	if x != nil {
+		return x.TimeoutMinutes
+	}
+	return 0
+}
+
+func (x *ProvisionJobRequest) GetMinNodeFraction() float32 {
+	if x != nil {
+		return x.MinNodeFraction
+	}
+	return 0
+}
+
+func (x *ProvisionJobRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ProvisionJobRequest) GetContractRules() []*JobContractRuleSet {
+	if x != nil {
+		return x.ContractRules
+	}
+	return nil
+}
+
+func (x *ProvisionJobRequest) GetSkipSelectorValidation() bool {
+	if x != nil {
+		return x.SkipSelectorValidation
+	}
+	return false
+}
+
+// ProvisionJobResponse returns the provisioned job
+type ProvisionJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	SkippedNodes  []string               `protobuf:"bytes,2,rep,name=skipped_nodes,json=skippedNodes,proto3" json:"skipped_nodes,omitempty"` // Requested nodes left out under min_node_fraction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsResponse) Reset() {
-	*x = ListJobsResponse{}
+func (x *ProvisionJobResponse) Reset() {
+	*x = ProvisionJobResponse{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsResponse) String() string {
+func (x *ProvisionJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsResponse) ProtoMessage() {}
+func (*ProvisionJobResponse) ProtoMessage() {}
 
-func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+func (x *ProvisionJobResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -623,47 +832,47 @@ func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
-func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProvisionJobResponse.ProtoReflect.Descriptor instead.
+func (*ProvisionJobResponse) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListJobsResponse) GetJobs() []*Job {
+func (x *ProvisionJobResponse) GetJob() *Job {
 	if x != nil {
-		return x.Jobs
+		return x.Job
 	}
 	return nil
 }
 
-func (x *ListJobsResponse) GetPagination() *PaginationResponse {
+func (x *ProvisionJobResponse) GetSkippedNodes() []string {
 	if x != nil {
-		return x.Pagination
+		return x.SkippedNodes
 	}
 	return nil
 }
 
-// CompleteJobRequest marks a job as completed
-type CompleteJobRequest struct {
+// GetJobRequest retrieves a job by Slurm job ID
+type GetJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteJobRequest) Reset() {
-	*x = CompleteJobRequest{}
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteJobRequest) String() string {
+func (x *GetJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteJobRequest) ProtoMessage() {}
+func (*GetJobRequest) ProtoMessage() {}
 
-func (x *CompleteJobRequest) ProtoReflect() protoreflect.Message {
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -675,40 +884,40 @@ func (x *CompleteJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteJobRequest.ProtoReflect.Descriptor instead.
-func (*CompleteJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CompleteJobRequest) GetSlurmJobId() string {
+func (x *GetJobRequest) GetSlurmJobId() string {
 	if x != nil {
 		return x.SlurmJobId
 	}
 	return ""
 }
 
-// CompleteJobResponse confirms completion
-type CompleteJobResponse struct {
+// GetJobResponse returns the job
+type GetJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteJobResponse) Reset() {
-	*x = CompleteJobResponse{}
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteJobResponse) String() string {
+func (x *GetJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteJobResponse) ProtoMessage() {}
+func (*GetJobResponse) ProtoMessage() {}
 
-func (x *CompleteJobResponse) ProtoReflect() protoreflect.Message {
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -720,39 +929,40 @@ func (x *CompleteJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteJobResponse.ProtoReflect.Descriptor instead.
-func (*CompleteJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CompleteJobResponse) GetJob() *Job {
+func (x *GetJobResponse) GetJob() *Job {
 	if x != nil {
 		return x.Job
 	}
 	return nil
 }
 
-// CleanupExpiredJobsRequest triggers cleanup of expired jobs
-type CleanupExpiredJobsRequest struct {
+// BulkGetJobsRequest retrieves several jobs by Slurm job ID
+type BulkGetJobsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobIds   []string               `protobuf:"bytes,1,rep,name=slurm_job_ids,json=slurmJobIds,proto3" json:"slurm_job_ids,omitempty"` // Required: 1-100 Slurm job IDs
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CleanupExpiredJobsRequest) Reset() {
-	*x = CleanupExpiredJobsRequest{}
+func (x *BulkGetJobsRequest) Reset() {
+	*x = BulkGetJobsRequest{}
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CleanupExpiredJobsRequest) String() string {
+func (x *BulkGetJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CleanupExpiredJobsRequest) ProtoMessage() {}
+func (*BulkGetJobsRequest) ProtoMessage() {}
 
-func (x *CleanupExpiredJobsRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkGetJobsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_go_nd_v1_jobs_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -764,15 +974,427 @@ func (x *CleanupExpiredJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CleanupExpiredJobsRequest.ProtoReflect.Descriptor instead.
-func (*CleanupExpiredJobsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkGetJobsRequest.ProtoReflect.Descriptor instead.
+func (*BulkGetJobsRequest) Descriptor() ([]byte, []int) {
 	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{10}
 }
 
-// CleanupExpiredJobsResponse reports cleanup results
-type CleanupExpiredJobsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CleanedCount  int32                  `protobuf:"varint,1,opt,name=cleaned_count,json=cleanedCount,proto3" json:"cleaned_count,omitempty"`
+func (x *BulkGetJobsRequest) GetSlurmJobIds() []string {
+	if x != nil {
+		return x.SlurmJobIds
+	}
+	return nil
+}
+
+// JobOrError is a job found by BulkGetJobs, or why it was not
+type JobOrError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*JobOrError_Job
+	//	*JobOrError_Error
+	Result        isJobOrError_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobOrError) Reset() {
+	*x = JobOrError{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobOrError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobOrError) ProtoMessage() {}
+
+func (x *JobOrError) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobOrError.ProtoReflect.Descriptor instead.
+func (*JobOrError) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *JobOrError) GetResult() isJobOrError_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *JobOrError) GetJob() *Job {
+	if x != nil {
+		if x, ok := x.Result.(*JobOrError_Job); ok {
+			return x.Job
+		}
+	}
+	return nil
+}
+
+func (x *JobOrError) GetError() string {
+	if x != nil {
+		if x, ok := x.Result.(*JobOrError_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+type isJobOrError_Result interface {
+	isJobOrError_Result()
+}
+
+type JobOrError_Job struct {
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3,oneof"`
+}
+
+type JobOrError_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"` // e.g. "not found"
+}
+
+func (*JobOrError_Job) isJobOrError_Result() {}
+
+func (*JobOrError_Error) isJobOrError_Result() {}
+
+// BulkGetJobsResponse maps each requested Slurm job ID to its job or error
+type BulkGetJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          map[string]*JobOrError `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkGetJobsResponse) Reset() {
+	*x = BulkGetJobsResponse{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkGetJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkGetJobsResponse) ProtoMessage() {}
+
+func (x *BulkGetJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkGetJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkGetJobsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BulkGetJobsResponse) GetJobs() map[string]*JobOrError {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// ListJobsRequest lists jobs with optional filters
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter by status (empty = all)
+	Statuses []JobStatus `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=go_nd.v1.JobStatus" json:"statuses,omitempty"`
+	// Filter by fabric name
+	FabricName string `protobuf:"bytes,2,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
+	// Pagination
+	Pagination *PaginationRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Filter by tags (jobs must carry all of them)
+	Tags          map[string]string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListJobsRequest) GetStatuses() []JobStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// ListJobsResponse returns matching jobs
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// CompleteJobRequest marks a job as completed, with its optional Slurm exit status
+type CompleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
+	ExitCode      *int32                 `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`            // Non-zero exits are recorded as the job's error
+	Signal        *int32                 `protobuf:"varint,3,opt,name=signal,proto3,oneof" json:"signal,omitempty"`                                // Signal that killed the job
+	FailedReason  *string                `protobuf:"bytes,4,opt,name=failed_reason,json=failedReason,proto3,oneof" json:"failed_reason,omitempty"` // Slurm's reason for a failed exit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteJobRequest) Reset() {
+	*x = CompleteJobRequest{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteJobRequest) ProtoMessage() {}
+
+func (x *CompleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteJobRequest.ProtoReflect.Descriptor instead.
+func (*CompleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompleteJobRequest) GetSlurmJobId() string {
+	if x != nil {
+		return x.SlurmJobId
+	}
+	return ""
+}
+
+func (x *CompleteJobRequest) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
+	}
+	return 0
+}
+
+func (x *CompleteJobRequest) GetSignal() int32 {
+	if x != nil && x.Signal != nil {
+		return *x.Signal
+	}
+	return 0
+}
+
+func (x *CompleteJobRequest) GetFailedReason() string {
+	if x != nil && x.FailedReason != nil {
+		return *x.FailedReason
+	}
+	return ""
+}
+
+// CompleteJobResponse confirms completion
+type CompleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteJobResponse) Reset() {
+	*x = CompleteJobResponse{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteJobResponse) ProtoMessage() {}
+
+func (x *CompleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteJobResponse.ProtoReflect.Descriptor instead.
+func (*CompleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CompleteJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// CleanupExpiredJobsRequest triggers cleanup of expired jobs
+type CleanupExpiredJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupExpiredJobsRequest) Reset() {
+	*x = CleanupExpiredJobsRequest{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupExpiredJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupExpiredJobsRequest) ProtoMessage() {}
+
+func (x *CleanupExpiredJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupExpiredJobsRequest.ProtoReflect.Descriptor instead.
+func (*CleanupExpiredJobsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{17}
+}
+
+// CleanupExpiredJobsResponse reports cleanup results
+type CleanupExpiredJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CleanedCount  int32                  `protobuf:"varint,1,opt,name=cleaned_count,json=cleanedCount,proto3" json:"cleaned_count,omitempty"`
 	CleanedJobIds []string               `protobuf:"bytes,2,rep,name=cleaned_job_ids,json=cleanedJobIds,proto3" json:"cleaned_job_ids,omitempty"`
 	FailedJobIds  []string               `protobuf:"bytes,3,rep,name=failed_job_ids,json=failedJobIds,proto3" json:"failed_job_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -781,7 +1403,7 @@ type CleanupExpiredJobsResponse struct {
 
 func (x *CleanupExpiredJobsResponse) Reset() {
 	*x = CleanupExpiredJobsResponse{}
-	mi := &file_go_nd_v1_jobs_proto_msgTypes[11]
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -793,7 +1415,7 @@ func (x *CleanupExpiredJobsResponse) String() string {
 func (*CleanupExpiredJobsResponse) ProtoMessage() {}
 
 func (x *CleanupExpiredJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_go_nd_v1_jobs_proto_msgTypes[11]
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +1428,7 @@ func (x *CleanupExpiredJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupExpiredJobsResponse.ProtoReflect.Descriptor instead.
 func (*CleanupExpiredJobsResponse) Descriptor() ([]byte, []int) {
-	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{11}
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CleanupExpiredJobsResponse) GetCleanedCount() int32 {
@@ -830,11 +1452,263 @@ func (x *CleanupExpiredJobsResponse) GetFailedJobIds() []string {
 	return nil
 }
 
+// UpdateJobMetadataRequest changes the metadata fields named in update_mask
+type UpdateJobMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Tags          map[string]string      `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Merged into the job's tags; an empty value deletes the key
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`                                             // Paths: name, description, tags. Empty updates the fields that are set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateJobMetadataRequest) Reset() {
+	*x = UpdateJobMetadataRequest{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateJobMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateJobMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateJobMetadataRequest) GetSlurmJobId() string {
+	if x != nil {
+		return x.SlurmJobId
+	}
+	return ""
+}
+
+func (x *UpdateJobMetadataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateJobMetadataRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateJobMetadataRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateJobMetadataRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// UpdateJobMetadataResponse returns the updated job
+type UpdateJobMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateJobMetadataResponse) Reset() {
+	*x = UpdateJobMetadataResponse{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobMetadataResponse) ProtoMessage() {}
+
+func (x *UpdateJobMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpdateJobMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateJobMetadataResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// WatchJobRequest opens a stream of a job's events
+type WatchJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlurmJobId    string                 `protobuf:"bytes,1,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchJobRequest) Reset() {
+	*x = WatchJobRequest{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobRequest) ProtoMessage() {}
+
+func (x *WatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchJobRequest) GetSlurmJobId() string {
+	if x != nil {
+		return x.SlurmJobId
+	}
+	return ""
+}
+
+// JobEvent is a message of the WatchJob stream
+type JobEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           JobEventType           `protobuf:"varint,1,opt,name=type,proto3,enum=go_nd.v1.JobEventType" json:"type,omitempty"`
+	SlurmJobId     string                 `protobuf:"bytes,2,opt,name=slurm_job_id,json=slurmJobId,proto3" json:"slurm_job_id,omitempty"`
+	Status         JobStatus              `protobuf:"varint,3,opt,name=status,proto3,enum=go_nd.v1.JobStatus" json:"status,omitempty"`                                       // New status (unspecified for heartbeats)
+	PreviousStatus JobStatus              `protobuf:"varint,4,opt,name=previous_status,json=previousStatus,proto3,enum=go_nd.v1.JobStatus" json:"previous_status,omitempty"` // Status before the change (unspecified for heartbeats and the initial status)
+	ErrorMessage   string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`                                // Error details when the job failed
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                          // When the status changed or the heartbeat was sent
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_jobs_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_jobs_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *JobEvent) GetType() JobEventType {
+	if x != nil {
+		return x.Type
+	}
+	return JobEventType_JOB_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *JobEvent) GetSlurmJobId() string {
+	if x != nil {
+		return x.SlurmJobId
+	}
+	return ""
+}
+
+func (x *JobEvent) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *JobEvent) GetPreviousStatus() JobStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *JobEvent) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *JobEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
 var File_go_nd_v1_jobs_proto protoreflect.FileDescriptor
 
 const file_go_nd_v1_jobs_proto_rawDesc = "" +
 	"\n" +
-	"\x13go_nd/v1/jobs.proto\x12\bgo_nd.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\x84\x05\n" +
+	"\x13go_nd/v1/jobs.proto\x12\bgo_nd.v1\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\xc8\x06\n" +
 	"\x03Job\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
 	"\fslurm_job_id\x18\x02 \x01(\tR\n" +
@@ -855,48 +1729,143 @@ const file_go_nd_v1_jobs_proto_rawDesc = "" +
 	"\n" +
 	"expires_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12=\n" +
 	"\rcompute_nodes\x18\x0e \x03(\v2\x18.go_nd.v1.JobComputeNodeR\fcomputeNodes\x12*\n" +
-	"\x11security_group_id\x18\x0f \x01(\tR\x0fsecurityGroupId\"\x8b\x01\n" +
+	"\x11security_group_id\x18\x0f \x01(\tR\x0fsecurityGroupId\x12 \n" +
+	"\vdescription\x18\x10 \x01(\tR\vdescription\x12:\n" +
+	"\x19provision_timeout_minutes\x18\x11 \x01(\x05R\x17provisionTimeoutMinutes\x12+\n" +
+	"\x04tags\x18\x12 \x03(\v2\x17.go_nd.v1.Job.TagsEntryR\x04tags\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x01\n" +
 	"\x0eJobComputeNode\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12&\n" +
 	"\x0fcompute_node_id\x18\x03 \x01(\tR\rcomputeNodeId\x12*\n" +
-	"\x11compute_node_name\x18\x04 \x01(\tR\x0fcomputeNodeName\"\x85\x01\n" +
+	"\x11compute_node_name\x18\x04 \x01(\tR\x0fcomputeNodeName\"\x8b\x04\n" +
 	"\x10SubmitJobRequest\x12 \n" +
 	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
 	"slurmJobId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
 	"\rcompute_nodes\x18\x03 \x03(\tR\fcomputeNodes\x12\x16\n" +
-	"\x06tenant\x18\x04 \x01(\tR\x06tenant\"N\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12W\n" +
+	"\x0frequired_labels\x18\x06 \x03(\v2..go_nd.v1.SubmitJobRequest.RequiredLabelsEntryR\x0erequiredLabels\x12'\n" +
+	"\x0ftimeout_minutes\x18\a \x01(\x05R\x0etimeoutMinutes\x12*\n" +
+	"\x11min_node_fraction\x18\b \x01(\x02R\x0fminNodeFraction\x128\n" +
+	"\x04tags\x18\t \x03(\v2$.go_nd.v1.SubmitJobRequest.TagsEntryR\x04tags\x1aA\n" +
+	"\x13RequiredLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
 	"\x11SubmitJobResponse\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\x12\x18\n" +
-	"\acreated\x18\x02 \x01(\bR\acreated\"1\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12#\n" +
+	"\rskipped_nodes\x18\x03 \x03(\tR\fskippedNodes\"l\n" +
+	"\x0fJobContractRule\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12#\n" +
+	"\rprotocol_name\x18\x03 \x01(\tR\fprotocolName\"Y\n" +
+	"\x12JobContractRuleSet\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x05rules\x18\x02 \x03(\v2\x19.go_nd.v1.JobContractRuleR\x05rules\"\x93\x05\n" +
+	"\x13ProvisionJobRequest\x12 \n" +
+	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
+	"slurmJobId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rcompute_nodes\x18\x03 \x03(\tR\fcomputeNodes\x12\x16\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12Z\n" +
+	"\x0frequired_labels\x18\x06 \x03(\v21.go_nd.v1.ProvisionJobRequest.RequiredLabelsEntryR\x0erequiredLabels\x12'\n" +
+	"\x0ftimeout_minutes\x18\a \x01(\x05R\x0etimeoutMinutes\x12*\n" +
+	"\x11min_node_fraction\x18\b \x01(\x02R\x0fminNodeFraction\x12;\n" +
+	"\x04tags\x18\t \x03(\v2'.go_nd.v1.ProvisionJobRequest.TagsEntryR\x04tags\x12C\n" +
+	"\x0econtract_rules\x18\n" +
+	" \x03(\v2\x1c.go_nd.v1.JobContractRuleSetR\rcontractRules\x128\n" +
+	"\x18skip_selector_validation\x18\v \x01(\bR\x16skipSelectorValidation\x1aA\n" +
+	"\x13RequiredLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\x14ProvisionJobResponse\x12\x1f\n" +
+	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\x12#\n" +
+	"\rskipped_nodes\x18\x02 \x03(\tR\fskippedNodes\"1\n" +
 	"\rGetJobRequest\x12 \n" +
 	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
 	"slurmJobId\"1\n" +
 	"\x0eGetJobResponse\x12\x1f\n" +
-	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\"\xa0\x01\n" +
+	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\"8\n" +
+	"\x12BulkGetJobsRequest\x12\"\n" +
+	"\rslurm_job_ids\x18\x01 \x03(\tR\vslurmJobIds\"Q\n" +
+	"\n" +
+	"JobOrError\x12!\n" +
+	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobH\x00R\x03job\x12\x16\n" +
+	"\x05error\x18\x02 \x01(\tH\x00R\x05errorB\b\n" +
+	"\x06result\"\xa1\x01\n" +
+	"\x13BulkGetJobsResponse\x12;\n" +
+	"\x04jobs\x18\x01 \x03(\v2'.go_nd.v1.BulkGetJobsResponse.JobsEntryR\x04jobs\x1aM\n" +
+	"\tJobsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.go_nd.v1.JobOrErrorR\x05value:\x028\x01\"\x92\x02\n" +
 	"\x0fListJobsRequest\x12/\n" +
 	"\bstatuses\x18\x01 \x03(\x0e2\x13.go_nd.v1.JobStatusR\bstatuses\x12\x1f\n" +
 	"\vfabric_name\x18\x02 \x01(\tR\n" +
 	"fabricName\x12;\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1b.go_nd.v1.PaginationRequestR\n" +
-	"pagination\"s\n" +
+	"pagination\x127\n" +
+	"\x04tags\x18\x04 \x03(\v2#.go_nd.v1.ListJobsRequest.TagsEntryR\x04tags\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
 	"\x10ListJobsResponse\x12!\n" +
 	"\x04jobs\x18\x01 \x03(\v2\r.go_nd.v1.JobR\x04jobs\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.go_nd.v1.PaginationResponseR\n" +
-	"pagination\"6\n" +
+	"pagination\"\xca\x01\n" +
 	"\x12CompleteJobRequest\x12 \n" +
 	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
-	"slurmJobId\"6\n" +
+	"slurmJobId\x12 \n" +
+	"\texit_code\x18\x02 \x01(\x05H\x00R\bexitCode\x88\x01\x01\x12\x1b\n" +
+	"\x06signal\x18\x03 \x01(\x05H\x01R\x06signal\x88\x01\x01\x12(\n" +
+	"\rfailed_reason\x18\x04 \x01(\tH\x02R\ffailedReason\x88\x01\x01B\f\n" +
+	"\n" +
+	"_exit_codeB\t\n" +
+	"\a_signalB\x10\n" +
+	"\x0e_failed_reason\"6\n" +
 	"\x13CompleteJobResponse\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\"\x1b\n" +
 	"\x19CleanupExpiredJobsRequest\"\x8f\x01\n" +
 	"\x1aCleanupExpiredJobsResponse\x12#\n" +
 	"\rcleaned_count\x18\x01 \x01(\x05R\fcleanedCount\x12&\n" +
 	"\x0fcleaned_job_ids\x18\x02 \x03(\tR\rcleanedJobIds\x12$\n" +
-	"\x0efailed_job_ids\x18\x03 \x03(\tR\ffailedJobIds*\xe2\x01\n" +
+	"\x0efailed_job_ids\x18\x03 \x03(\tR\ffailedJobIds\"\xaa\x02\n" +
+	"\x18UpdateJobMetadataRequest\x12 \n" +
+	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
+	"slurmJobId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
+	"\x04tags\x18\x04 \x03(\v2,.go_nd.v1.UpdateJobMetadataRequest.TagsEntryR\x04tags\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"<\n" +
+	"\x19UpdateJobMetadataResponse\x12\x1f\n" +
+	"\x03job\x18\x01 \x01(\v2\r.go_nd.v1.JobR\x03job\"3\n" +
+	"\x0fWatchJobRequest\x12 \n" +
+	"\fslurm_job_id\x18\x01 \x01(\tR\n" +
+	"slurmJobId\"\xa2\x02\n" +
+	"\bJobEvent\x12*\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x16.go_nd.v1.JobEventTypeR\x04type\x12 \n" +
+	"\fslurm_job_id\x18\x02 \x01(\tR\n" +
+	"slurmJobId\x12+\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.go_nd.v1.JobStatusR\x06status\x12<\n" +
+	"\x0fprevious_status\x18\x04 \x01(\x0e2\x13.go_nd.v1.JobStatusR\x0epreviousStatus\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp*\xe2\x01\n" +
 	"\tJobStatus\x12\x1a\n" +
 	"\x16JOB_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12JOB_STATUS_PENDING\x10\x01\x12\x1b\n" +
@@ -905,13 +1874,22 @@ const file_go_nd_v1_jobs_proto_rawDesc = "" +
 	"\x19JOB_STATUS_DEPROVISIONING\x10\x04\x12\x18\n" +
 	"\x14JOB_STATUS_COMPLETED\x10\x05\x12\x1d\n" +
 	"\x19JOB_STATUS_CLEANUP_FAILED\x10\x06\x12\x15\n" +
-	"\x11JOB_STATUS_FAILED\x10\a2\x80\x03\n" +
-	"\vJobsService\x12D\n" +
-	"\tSubmitJob\x12\x1a.go_nd.v1.SubmitJobRequest\x1a\x1b.go_nd.v1.SubmitJobResponse\x12;\n" +
-	"\x06GetJob\x12\x17.go_nd.v1.GetJobRequest\x1a\x18.go_nd.v1.GetJobResponse\x12A\n" +
-	"\bListJobs\x12\x19.go_nd.v1.ListJobsRequest\x1a\x1a.go_nd.v1.ListJobsResponse\x12J\n" +
-	"\vCompleteJob\x12\x1c.go_nd.v1.CompleteJobRequest\x1a\x1d.go_nd.v1.CompleteJobResponse\x12_\n" +
-	"\x12CleanupExpiredJobs\x12#.go_nd.v1.CleanupExpiredJobsRequest\x1a$.go_nd.v1.CleanupExpiredJobsResponseB\x85\x01\n" +
+	"\x11JOB_STATUS_FAILED\x10\a*o\n" +
+	"\fJobEventType\x12\x1e\n" +
+	"\x1aJOB_EVENT_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dJOB_EVENT_TYPE_STATUS_CHANGED\x10\x01\x12\x1c\n" +
+	"\x18JOB_EVENT_TYPE_HEARTBEAT\x10\x022\xd8\a\n" +
+	"\vJobsService\x12Y\n" +
+	"\tSubmitJob\x12\x1a.go_nd.v1.SubmitJobRequest\x1a\x1b.go_nd.v1.SubmitJobResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/jobs\x12l\n" +
+	"\fProvisionJob\x12\x1d.go_nd.v1.ProvisionJobRequest\x1a\x1e.go_nd.v1.ProvisionJobResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/jobs:provision\x12\\\n" +
+	"\x06GetJob\x12\x17.go_nd.v1.GetJobRequest\x1a\x18.go_nd.v1.GetJobResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/jobs/{slurm_job_id}\x12g\n" +
+	"\vBulkGetJobs\x12\x1c.go_nd.v1.BulkGetJobsRequest\x1a\x1d.go_nd.v1.BulkGetJobsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/jobs:bulkGet\x12S\n" +
+	"\bListJobs\x12\x19.go_nd.v1.ListJobsRequest\x1a\x1a.go_nd.v1.ListJobsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/jobs\x12w\n" +
+	"\vCompleteJob\x12\x1c.go_nd.v1.CompleteJobRequest\x1a\x1d.go_nd.v1.CompleteJobResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/jobs/{slurm_job_id}:complete\x12\x83\x01\n" +
+	"\x12CleanupExpiredJobs\x12#.go_nd.v1.CleanupExpiredJobsRequest\x1a$.go_nd.v1.CleanupExpiredJobsResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/jobs:cleanupExpired\x12\x80\x01\n" +
+	"\x11UpdateJobMetadata\x12\".go_nd.v1.UpdateJobMetadataRequest\x1a#.go_nd.v1.UpdateJobMetadataResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*2\x17/v1/jobs/{slurm_job_id}\x12b\n" +
+	"\bWatchJob\x12\x19.go_nd.v1.WatchJobRequest\x1a\x12.go_nd.v1.JobEvent\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/jobs/{slurm_job_id}:watch0\x01B\x85\x01\n" +
 	"\fcom.go_nd.v1B\tJobsProtoP\x01Z-github.com/banglin/go-nd/gen/go_nd/v1;go_ndv1\xa2\x02\x03GXX\xaa\x02\aGoNd.V1\xca\x02\aGoNd\\V1\xe2\x02\x13GoNd\\V1\\GPBMetadata\xea\x02\bGoNd::V1b\x06proto3"
 
 var (
@@ -926,55 +1904,103 @@ func file_go_nd_v1_jobs_proto_rawDescGZIP() []byte {
 	return file_go_nd_v1_jobs_proto_rawDescData
 }
 
-var file_go_nd_v1_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_go_nd_v1_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_go_nd_v1_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_go_nd_v1_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_go_nd_v1_jobs_proto_goTypes = []any{
 	(JobStatus)(0),                     // 0: go_nd.v1.JobStatus
-	(*Job)(nil),                        // 1: go_nd.v1.Job
-	(*JobComputeNode)(nil),             // 2: go_nd.v1.JobComputeNode
-	(*SubmitJobRequest)(nil),           // 3: go_nd.v1.SubmitJobRequest
-	(*SubmitJobResponse)(nil),          // 4: go_nd.v1.SubmitJobResponse
-	(*GetJobRequest)(nil),              // 5: go_nd.v1.GetJobRequest
-	(*GetJobResponse)(nil),             // 6: go_nd.v1.GetJobResponse
-	(*ListJobsRequest)(nil),            // 7: go_nd.v1.ListJobsRequest
-	(*ListJobsResponse)(nil),           // 8: go_nd.v1.ListJobsResponse
-	(*CompleteJobRequest)(nil),         // 9: go_nd.v1.CompleteJobRequest
-	(*CompleteJobResponse)(nil),        // 10: go_nd.v1.CompleteJobResponse
-	(*CleanupExpiredJobsRequest)(nil),  // 11: go_nd.v1.CleanupExpiredJobsRequest
-	(*CleanupExpiredJobsResponse)(nil), // 12: go_nd.v1.CleanupExpiredJobsResponse
-	(*timestamppb.Timestamp)(nil),      // 13: google.protobuf.Timestamp
-	(*PaginationRequest)(nil),          // 14: go_nd.v1.PaginationRequest
-	(*PaginationResponse)(nil),         // 15: go_nd.v1.PaginationResponse
+	(JobEventType)(0),                  // 1: go_nd.v1.JobEventType
+	(*Job)(nil),                        // 2: go_nd.v1.Job
+	(*JobComputeNode)(nil),             // 3: go_nd.v1.JobComputeNode
+	(*SubmitJobRequest)(nil),           // 4: go_nd.v1.SubmitJobRequest
+	(*SubmitJobResponse)(nil),          // 5: go_nd.v1.SubmitJobResponse
+	(*JobContractRule)(nil),            // 6: go_nd.v1.JobContractRule
+	(*JobContractRuleSet)(nil),         // 7: go_nd.v1.JobContractRuleSet
+	(*ProvisionJobRequest)(nil),        // 8: go_nd.v1.ProvisionJobRequest
+	(*ProvisionJobResponse)(nil),       // 9: go_nd.v1.ProvisionJobResponse
+	(*GetJobRequest)(nil),              // 10: go_nd.v1.GetJobRequest
+	(*GetJobResponse)(nil),             // 11: go_nd.v1.GetJobResponse
+	(*BulkGetJobsRequest)(nil),         // 12: go_nd.v1.BulkGetJobsRequest
+	(*JobOrError)(nil),                 // 13: go_nd.v1.JobOrError
+	(*BulkGetJobsResponse)(nil),        // 14: go_nd.v1.BulkGetJobsResponse
+	(*ListJobsRequest)(nil),            // 15: go_nd.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),           // 16: go_nd.v1.ListJobsResponse
+	(*CompleteJobRequest)(nil),         // 17: go_nd.v1.CompleteJobRequest
+	(*CompleteJobResponse)(nil),        // 18: go_nd.v1.CompleteJobResponse
+	(*CleanupExpiredJobsRequest)(nil),  // 19: go_nd.v1.CleanupExpiredJobsRequest
+	(*CleanupExpiredJobsResponse)(nil), // 20: go_nd.v1.CleanupExpiredJobsResponse
+	(*UpdateJobMetadataRequest)(nil),   // 21: go_nd.v1.UpdateJobMetadataRequest
+	(*UpdateJobMetadataResponse)(nil),  // 22: go_nd.v1.UpdateJobMetadataResponse
+	(*WatchJobRequest)(nil),            // 23: go_nd.v1.WatchJobRequest
+	(*JobEvent)(nil),                   // 24: go_nd.v1.JobEvent
+	nil,                                // 25: go_nd.v1.Job.TagsEntry
+	nil,                                // 26: go_nd.v1.SubmitJobRequest.RequiredLabelsEntry
+	nil,                                // 27: go_nd.v1.SubmitJobRequest.TagsEntry
+	nil,                                // 28: go_nd.v1.ProvisionJobRequest.RequiredLabelsEntry
+	nil,                                // 29: go_nd.v1.ProvisionJobRequest.TagsEntry
+	nil,                                // 30: go_nd.v1.BulkGetJobsResponse.JobsEntry
+	nil,                                // 31: go_nd.v1.ListJobsRequest.TagsEntry
+	nil,                                // 32: go_nd.v1.UpdateJobMetadataRequest.TagsEntry
+	(*timestamppb.Timestamp)(nil),      // 33: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),          // 34: go_nd.v1.PaginationRequest
+	(*PaginationResponse)(nil),         // 35: go_nd.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),      // 36: google.protobuf.FieldMask
 }
 var file_go_nd_v1_jobs_proto_depIdxs = []int32{
 	0,  // 0: go_nd.v1.Job.status:type_name -> go_nd.v1.JobStatus
-	13, // 1: go_nd.v1.Job.submitted_at:type_name -> google.protobuf.Timestamp
-	13, // 2: go_nd.v1.Job.provisioned_at:type_name -> google.protobuf.Timestamp
-	13, // 3: go_nd.v1.Job.completed_at:type_name -> google.protobuf.Timestamp
-	13, // 4: go_nd.v1.Job.expires_at:type_name -> google.protobuf.Timestamp
-	2,  // 5: go_nd.v1.Job.compute_nodes:type_name -> go_nd.v1.JobComputeNode
-	1,  // 6: go_nd.v1.SubmitJobResponse.job:type_name -> go_nd.v1.Job
-	1,  // 7: go_nd.v1.GetJobResponse.job:type_name -> go_nd.v1.Job
-	0,  // 8: go_nd.v1.ListJobsRequest.statuses:type_name -> go_nd.v1.JobStatus
-	14, // 9: go_nd.v1.ListJobsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
-	1,  // 10: go_nd.v1.ListJobsResponse.jobs:type_name -> go_nd.v1.Job
-	15, // 11: go_nd.v1.ListJobsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
-	1,  // 12: go_nd.v1.CompleteJobResponse.job:type_name -> go_nd.v1.Job
-	3,  // 13: go_nd.v1.JobsService.SubmitJob:input_type -> go_nd.v1.SubmitJobRequest
-	5,  // 14: go_nd.v1.JobsService.GetJob:input_type -> go_nd.v1.GetJobRequest
-	7,  // 15: go_nd.v1.JobsService.ListJobs:input_type -> go_nd.v1.ListJobsRequest
-	9,  // 16: go_nd.v1.JobsService.CompleteJob:input_type -> go_nd.v1.CompleteJobRequest
-	11, // 17: go_nd.v1.JobsService.CleanupExpiredJobs:input_type -> go_nd.v1.CleanupExpiredJobsRequest
-	4,  // 18: go_nd.v1.JobsService.SubmitJob:output_type -> go_nd.v1.SubmitJobResponse
-	6,  // 19: go_nd.v1.JobsService.GetJob:output_type -> go_nd.v1.GetJobResponse
-	8,  // 20: go_nd.v1.JobsService.ListJobs:output_type -> go_nd.v1.ListJobsResponse
-	10, // 21: go_nd.v1.JobsService.CompleteJob:output_type -> go_nd.v1.CompleteJobResponse
-	12, // 22: go_nd.v1.JobsService.CleanupExpiredJobs:output_type -> go_nd.v1.CleanupExpiredJobsResponse
-	18, // [18:23] is the sub-list for method output_type
-	13, // [13:18] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	33, // 1: go_nd.v1.Job.submitted_at:type_name -> google.protobuf.Timestamp
+	33, // 2: go_nd.v1.Job.provisioned_at:type_name -> google.protobuf.Timestamp
+	33, // 3: go_nd.v1.Job.completed_at:type_name -> google.protobuf.Timestamp
+	33, // 4: go_nd.v1.Job.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: go_nd.v1.Job.compute_nodes:type_name -> go_nd.v1.JobComputeNode
+	25, // 6: go_nd.v1.Job.tags:type_name -> go_nd.v1.Job.TagsEntry
+	26, // 7: go_nd.v1.SubmitJobRequest.required_labels:type_name -> go_nd.v1.SubmitJobRequest.RequiredLabelsEntry
+	27, // 8: go_nd.v1.SubmitJobRequest.tags:type_name -> go_nd.v1.SubmitJobRequest.TagsEntry
+	2,  // 9: go_nd.v1.SubmitJobResponse.job:type_name -> go_nd.v1.Job
+	6,  // 10: go_nd.v1.JobContractRuleSet.rules:type_name -> go_nd.v1.JobContractRule
+	28, // 11: go_nd.v1.ProvisionJobRequest.required_labels:type_name -> go_nd.v1.ProvisionJobRequest.RequiredLabelsEntry
+	29, // 12: go_nd.v1.ProvisionJobRequest.tags:type_name -> go_nd.v1.ProvisionJobRequest.TagsEntry
+	7,  // 13: go_nd.v1.ProvisionJobRequest.contract_rules:type_name -> go_nd.v1.JobContractRuleSet
+	2,  // 14: go_nd.v1.ProvisionJobResponse.job:type_name -> go_nd.v1.Job
+	2,  // 15: go_nd.v1.GetJobResponse.job:type_name -> go_nd.v1.Job
+	2,  // 16: go_nd.v1.JobOrError.job:type_name -> go_nd.v1.Job
+	30, // 17: go_nd.v1.BulkGetJobsResponse.jobs:type_name -> go_nd.v1.BulkGetJobsResponse.JobsEntry
+	0,  // 18: go_nd.v1.ListJobsRequest.statuses:type_name -> go_nd.v1.JobStatus
+	34, // 19: go_nd.v1.ListJobsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	31, // 20: go_nd.v1.ListJobsRequest.tags:type_name -> go_nd.v1.ListJobsRequest.TagsEntry
+	2,  // 21: go_nd.v1.ListJobsResponse.jobs:type_name -> go_nd.v1.Job
+	35, // 22: go_nd.v1.ListJobsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	2,  // 23: go_nd.v1.CompleteJobResponse.job:type_name -> go_nd.v1.Job
+	32, // 24: go_nd.v1.UpdateJobMetadataRequest.tags:type_name -> go_nd.v1.UpdateJobMetadataRequest.TagsEntry
+	36, // 25: go_nd.v1.UpdateJobMetadataRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 26: go_nd.v1.UpdateJobMetadataResponse.job:type_name -> go_nd.v1.Job
+	1,  // 27: go_nd.v1.JobEvent.type:type_name -> go_nd.v1.JobEventType
+	0,  // 28: go_nd.v1.JobEvent.status:type_name -> go_nd.v1.JobStatus
+	0,  // 29: go_nd.v1.JobEvent.previous_status:type_name -> go_nd.v1.JobStatus
+	33, // 30: go_nd.v1.JobEvent.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 31: go_nd.v1.BulkGetJobsResponse.JobsEntry.value:type_name -> go_nd.v1.JobOrError
+	4,  // 32: go_nd.v1.JobsService.SubmitJob:input_type -> go_nd.v1.SubmitJobRequest
+	8,  // 33: go_nd.v1.JobsService.ProvisionJob:input_type -> go_nd.v1.ProvisionJobRequest
+	10, // 34: go_nd.v1.JobsService.GetJob:input_type -> go_nd.v1.GetJobRequest
+	12, // 35: go_nd.v1.JobsService.BulkGetJobs:input_type -> go_nd.v1.BulkGetJobsRequest
+	15, // 36: go_nd.v1.JobsService.ListJobs:input_type -> go_nd.v1.ListJobsRequest
+	17, // 37: go_nd.v1.JobsService.CompleteJob:input_type -> go_nd.v1.CompleteJobRequest
+	19, // 38: go_nd.v1.JobsService.CleanupExpiredJobs:input_type -> go_nd.v1.CleanupExpiredJobsRequest
+	21, // 39: go_nd.v1.JobsService.UpdateJobMetadata:input_type -> go_nd.v1.UpdateJobMetadataRequest
+	23, // 40: go_nd.v1.JobsService.WatchJob:input_type -> go_nd.v1.WatchJobRequest
+	5,  // 41: go_nd.v1.JobsService.SubmitJob:output_type -> go_nd.v1.SubmitJobResponse
+	9,  // 42: go_nd.v1.JobsService.ProvisionJob:output_type -> go_nd.v1.ProvisionJobResponse
+	11, // 43: go_nd.v1.JobsService.GetJob:output_type -> go_nd.v1.GetJobResponse
+	14, // 44: go_nd.v1.JobsService.BulkGetJobs:output_type -> go_nd.v1.BulkGetJobsResponse
+	16, // 45: go_nd.v1.JobsService.ListJobs:output_type -> go_nd.v1.ListJobsResponse
+	18, // 46: go_nd.v1.JobsService.CompleteJob:output_type -> go_nd.v1.CompleteJobResponse
+	20, // 47: go_nd.v1.JobsService.CleanupExpiredJobs:output_type -> go_nd.v1.CleanupExpiredJobsResponse
+	22, // 48: go_nd.v1.JobsService.UpdateJobMetadata:output_type -> go_nd.v1.UpdateJobMetadataResponse
+	24, // 49: go_nd.v1.JobsService.WatchJob:output_type -> go_nd.v1.JobEvent
+	41, // [41:50] is the sub-list for method output_type
+	32, // [32:41] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_go_nd_v1_jobs_proto_init() }
@@ -983,13 +2009,18 @@ func file_go_nd_v1_jobs_proto_init() {
 		return
 	}
 	file_go_nd_v1_common_proto_init()
+	file_go_nd_v1_jobs_proto_msgTypes[11].OneofWrappers = []any{
+		(*JobOrError_Job)(nil),
+		(*JobOrError_Error)(nil),
+	}
+	file_go_nd_v1_jobs_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_go_nd_v1_jobs_proto_rawDesc), len(file_go_nd_v1_jobs_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,10 +20,14 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	JobsService_SubmitJob_FullMethodName          = "/go_nd.v1.JobsService/SubmitJob"
+	JobsService_ProvisionJob_FullMethodName       = "/go_nd.v1.JobsService/ProvisionJob"
 	JobsService_GetJob_FullMethodName             = "/go_nd.v1.JobsService/GetJob"
+	JobsService_BulkGetJobs_FullMethodName        = "/go_nd.v1.JobsService/BulkGetJobs"
 	JobsService_ListJobs_FullMethodName           = "/go_nd.v1.JobsService/ListJobs"
 	JobsService_CompleteJob_FullMethodName        = "/go_nd.v1.JobsService/CompleteJob"
 	JobsService_CleanupExpiredJobs_FullMethodName = "/go_nd.v1.JobsService/CleanupExpiredJobs"
+	JobsService_UpdateJobMetadata_FullMethodName  = "/go_nd.v1.JobsService/UpdateJobMetadata"
+	JobsService_WatchJob_FullMethodName           = "/go_nd.v1.JobsService/WatchJob"
 )
 
 // JobsServiceClient is the client API for JobsService service.
@@ -35,14 +39,26 @@ type JobsServiceClient interface {
 	// SubmitJob creates a new job and provisions security groups for the compute nodes.
 	// Idempotent: returns existing job if slurm_job_id already exists and is active.
 	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error)
+	// ProvisionJob creates a new job and provisions it, like SubmitJob, but is not idempotent:
+	// a job that already exists is ALREADY_EXISTS. Unknown compute nodes are NOT_FOUND and
+	// nodes allocated to other jobs RESOURCE_EXHAUSTED.
+	ProvisionJob(ctx context.Context, in *ProvisionJobRequest, opts ...grpc.CallOption) (*ProvisionJobResponse, error)
 	// GetJob retrieves a job by its Slurm job ID.
 	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	// BulkGetJobs retrieves up to 100 jobs by Slurm job ID in one request.
+	// Unknown IDs are returned with an error instead of failing the request.
+	BulkGetJobs(ctx context.Context, in *BulkGetJobsRequest, opts ...grpc.CallOption) (*BulkGetJobsResponse, error)
 	// ListJobs lists all jobs with optional filtering.
 	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 	// CompleteJob marks a job as completed and triggers deprovisioning.
 	CompleteJob(ctx context.Context, in *CompleteJobRequest, opts ...grpc.CallOption) (*CompleteJobResponse, error)
 	// CleanupExpiredJobs removes expired jobs and their resources.
 	CleanupExpiredJobs(ctx context.Context, in *CleanupExpiredJobsRequest, opts ...grpc.CallOption) (*CleanupExpiredJobsResponse, error)
+	// Update a job's name, description and tags
+	UpdateJobMetadata(ctx context.Context, in *UpdateJobMetadataRequest, opts ...grpc.CallOption) (*UpdateJobMetadataResponse, error)
+	// WatchJob streams the job's current status, then its status changes as they happen, with a
+	// heartbeat every 30 seconds while the status does not change. The stream runs until the client disconnects.
+	WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobEvent], error)
 }
 
 type jobsServiceClient struct {
@@ -63,6 +79,16 @@ func (c *jobsServiceClient) SubmitJob(ctx context.Context, in *SubmitJobRequest,
 	return out, nil
 }
 
+func (c *jobsServiceClient) ProvisionJob(ctx context.Context, in *ProvisionJobRequest, opts ...grpc.CallOption) (*ProvisionJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProvisionJobResponse)
+	err := c.cc.Invoke(ctx, JobsService_ProvisionJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobsServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetJobResponse)
@@ -73,6 +99,16 @@ func (c *jobsServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts
 	return out, nil
 }
 
+func (c *jobsServiceClient) BulkGetJobs(ctx context.Context, in *BulkGetJobsRequest, opts ...grpc.CallOption) (*BulkGetJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkGetJobsResponse)
+	err := c.cc.Invoke(ctx, JobsService_BulkGetJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobsServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListJobsResponse)
@@ -103,6 +139,35 @@ func (c *jobsServiceClient) CleanupExpiredJobs(ctx context.Context, in *CleanupE
 	return out, nil
 }
 
+func (c *jobsServiceClient) UpdateJobMetadata(ctx context.Context, in *UpdateJobMetadataRequest, opts ...grpc.CallOption) (*UpdateJobMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateJobMetadataResponse)
+	err := c.cc.Invoke(ctx, JobsService_UpdateJobMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsServiceClient) WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobsService_ServiceDesc.Streams[0], JobsService_WatchJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchJobRequest, JobEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobsService_WatchJobClient = grpc.ServerStreamingClient[JobEvent]
+
 // JobsServiceServer is the server API for JobsService service.
 // All implementations must embed UnimplementedJobsServiceServer
 // for forward compatibility.
@@ -112,14 +177,26 @@ type JobsServiceServer interface {
 	// SubmitJob creates a new job and provisions security groups for the compute nodes.
 	// Idempotent: returns existing job if slurm_job_id already exists and is active.
 	SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error)
+	// ProvisionJob creates a new job and provisions it, like SubmitJob, but is not idempotent:
+	// a job that already exists is ALREADY_EXISTS. Unknown compute nodes are NOT_FOUND and
+	// nodes allocated to other jobs RESOURCE_EXHAUSTED.
+	ProvisionJob(context.Context, *ProvisionJobRequest) (*ProvisionJobResponse, error)
 	// GetJob retrieves a job by its Slurm job ID.
 	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
+	// BulkGetJobs retrieves up to 100 jobs by Slurm job ID in one request.
+	// Unknown IDs are returned with an error instead of failing the request.
+	BulkGetJobs(context.Context, *BulkGetJobsRequest) (*BulkGetJobsResponse, error)
 	// ListJobs lists all jobs with optional filtering.
 	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
 	// CompleteJob marks a job as completed and triggers deprovisioning.
 	CompleteJob(context.Context, *CompleteJobRequest) (*CompleteJobResponse, error)
 	// CleanupExpiredJobs removes expired jobs and their resources.
 	CleanupExpiredJobs(context.Context, *CleanupExpiredJobsRequest) (*CleanupExpiredJobsResponse, error)
+	// Update a job's name, description and tags
+	UpdateJobMetadata(context.Context, *UpdateJobMetadataRequest) (*UpdateJobMetadataResponse, error)
+	// WatchJob streams the job's current status, then its status changes as they happen, with a
+	// heartbeat every 30 seconds while the status does not change. The stream runs until the client disconnects.
+	WatchJob(*WatchJobRequest, grpc.ServerStreamingServer[JobEvent]) error
 	mustEmbedUnimplementedJobsServiceServer()
 }
 
@@ -133,9 +210,15 @@ type UnimplementedJobsServiceServer struct{}
 func (UnimplementedJobsServiceServer) SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitJob not implemented")
 }
+func (UnimplementedJobsServiceServer) ProvisionJob(context.Context, *ProvisionJobRequest) (*ProvisionJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProvisionJob not implemented")
+}
 func (UnimplementedJobsServiceServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJob not implemented")
 }
+func (UnimplementedJobsServiceServer) BulkGetJobs(context.Context, *BulkGetJobsRequest) (*BulkGetJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkGetJobs not implemented")
+}
 func (UnimplementedJobsServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListJobs not implemented")
 }
@@ -145,6 +228,12 @@ func (UnimplementedJobsServiceServer) CompleteJob(context.Context, *CompleteJobR
 func (UnimplementedJobsServiceServer) CleanupExpiredJobs(context.Context, *CleanupExpiredJobsRequest) (*CleanupExpiredJobsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CleanupExpiredJobs not implemented")
 }
+func (UnimplementedJobsServiceServer) UpdateJobMetadata(context.Context, *UpdateJobMetadataRequest) (*UpdateJobMetadataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateJobMetadata not implemented")
+}
+func (UnimplementedJobsServiceServer) WatchJob(*WatchJobRequest, grpc.ServerStreamingServer[JobEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchJob not implemented")
+}
 func (UnimplementedJobsServiceServer) mustEmbedUnimplementedJobsServiceServer() {}
 func (UnimplementedJobsServiceServer) testEmbeddedByValue()                     {}
 
@@ -184,6 +273,24 @@ func _JobsService_SubmitJob_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobsService_ProvisionJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).ProvisionJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_ProvisionJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).ProvisionJob(ctx, req.(*ProvisionJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _JobsService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetJobRequest)
 	if err := dec(in); err != nil {
@@ -202,6 +309,24 @@ func _JobsService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobsService_BulkGetJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkGetJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).BulkGetJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_BulkGetJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).BulkGetJobs(ctx, req.(*BulkGetJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _JobsService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobsRequest)
 	if err := dec(in); err != nil {
@@ -256,6 +381,35 @@ func _JobsService_CleanupExpiredJobs_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobsService_UpdateJobMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateJobMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).UpdateJobMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_UpdateJobMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).UpdateJobMetadata(ctx, req.(*UpdateJobMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobsService_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobsServiceServer).WatchJob(m, &grpc.GenericServerStream[WatchJobRequest, JobEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobsService_WatchJobServer = grpc.ServerStreamingServer[JobEvent]
+
 // JobsService_ServiceDesc is the grpc.ServiceDesc for JobsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,10 +421,18 @@ var JobsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitJob",
 			Handler:    _JobsService_SubmitJob_Handler,
 		},
+		{
+			MethodName: "ProvisionJob",
+			Handler:    _JobsService_ProvisionJob_Handler,
+		},
 		{
 			MethodName: "GetJob",
 			Handler:    _JobsService_GetJob_Handler,
 		},
+		{
+			MethodName: "BulkGetJobs",
+			Handler:    _JobsService_BulkGetJobs_Handler,
+		},
 		{
 			MethodName: "ListJobs",
 			Handler:    _JobsService_ListJobs_Handler,
@@ -283,7 +445,17 @@ var JobsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanupExpiredJobs",
 			Handler:    _JobsService_CleanupExpiredJobs_Handler,
 		},
+		{
+			MethodName: "UpdateJobMetadata",
+			Handler:    _JobsService_UpdateJobMetadata_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJob",
+			Handler:       _JobsService_WatchJob_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "go_nd/v1/jobs.proto",
 }
@@ -0,0 +1,1651 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: go_nd/v1/security.proto
+
+package go_ndv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SecurityGroup represents a security group. Groups read from NDFC have no id.
+type SecurityGroup struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	NdObjectId  string                 `protobuf:"bytes,4,opt,name=nd_object_id,json=ndObjectId,proto3" json:"nd_object_id,omitempty"`
+	FabricName  string                 `protobuf:"bytes,5,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Selector fields are only set for groups read from or created in NDFC
+	SourceFabric            string                    `protobuf:"bytes,8,opt,name=source_fabric,json=sourceFabric,proto3" json:"source_fabric,omitempty"`
+	Attach                  bool                      `protobuf:"varint,9,opt,name=attach,proto3" json:"attach,omitempty"`
+	IpSelectors             []*IPSelector             `protobuf:"bytes,10,rep,name=ip_selectors,json=ipSelectors,proto3" json:"ip_selectors,omitempty"`
+	NetworkSelectors        []*NetworkSelector        `protobuf:"bytes,11,rep,name=network_selectors,json=networkSelectors,proto3" json:"network_selectors,omitempty"`
+	NetworkPortSelectors    []*NetworkPortSelector    `protobuf:"bytes,12,rep,name=network_port_selectors,json=networkPortSelectors,proto3" json:"network_port_selectors,omitempty"`
+	VmInstanceUuidSelectors []*VMInstanceUUIDSelector `protobuf:"bytes,13,rep,name=vm_instance_uuid_selectors,json=vmInstanceUuidSelectors,proto3" json:"vm_instance_uuid_selectors,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *SecurityGroup) Reset() {
+	*x = SecurityGroup{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityGroup) ProtoMessage() {}
+
+func (x *SecurityGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityGroup.ProtoReflect.Descriptor instead.
+func (*SecurityGroup) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SecurityGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetNdObjectId() string {
+	if x != nil {
+		return x.NdObjectId
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SecurityGroup) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SecurityGroup) GetSourceFabric() string {
+	if x != nil {
+		return x.SourceFabric
+	}
+	return ""
+}
+
+func (x *SecurityGroup) GetAttach() bool {
+	if x != nil {
+		return x.Attach
+	}
+	return false
+}
+
+func (x *SecurityGroup) GetIpSelectors() []*IPSelector {
+	if x != nil {
+		return x.IpSelectors
+	}
+	return nil
+}
+
+func (x *SecurityGroup) GetNetworkSelectors() []*NetworkSelector {
+	if x != nil {
+		return x.NetworkSelectors
+	}
+	return nil
+}
+
+func (x *SecurityGroup) GetNetworkPortSelectors() []*NetworkPortSelector {
+	if x != nil {
+		return x.NetworkPortSelectors
+	}
+	return nil
+}
+
+func (x *SecurityGroup) GetVmInstanceUuidSelectors() []*VMInstanceUUIDSelector {
+	if x != nil {
+		return x.VmInstanceUuidSelectors
+	}
+	return nil
+}
+
+// IPSelector matches endpoints by IP address or subnet
+type IPSelector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "Connected Endpoints" or "External Subnets"
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	VrfName       string                 `protobuf:"bytes,3,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	DeletedStr    string                 `protobuf:"bytes,5,opt,name=deleted_str,json=deletedStr,proto3" json:"deleted_str,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPSelector) Reset() {
+	*x = IPSelector{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPSelector) ProtoMessage() {}
+
+func (x *IPSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPSelector.ProtoReflect.Descriptor instead.
+func (*IPSelector) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IPSelector) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *IPSelector) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *IPSelector) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
+func (x *IPSelector) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *IPSelector) GetDeletedStr() string {
+	if x != nil {
+		return x.DeletedStr
+	}
+	return ""
+}
+
+// NetworkSelector matches all endpoints of a network
+type NetworkSelector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VrfName       string                 `protobuf:"bytes,1,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	Network       string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkSelector) Reset() {
+	*x = NetworkSelector{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSelector) ProtoMessage() {}
+
+func (x *NetworkSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSelector.ProtoReflect.Descriptor instead.
+func (*NetworkSelector) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NetworkSelector) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
+func (x *NetworkSelector) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+// NetworkPortSelector matches a network on one switch interface
+type NetworkPortSelector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	SwitchId      string                 `protobuf:"bytes,2,opt,name=switch_id,json=switchId,proto3" json:"switch_id,omitempty"`                // Switch serial number
+	InterfaceName string                 `protobuf:"bytes,3,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"` // Full name, e.g. Ethernet1/5
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkPortSelector) Reset() {
+	*x = NetworkPortSelector{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPortSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPortSelector) ProtoMessage() {}
+
+func (x *NetworkPortSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPortSelector.ProtoReflect.Descriptor instead.
+func (*NetworkPortSelector) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NetworkPortSelector) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *NetworkPortSelector) GetSwitchId() string {
+	if x != nil {
+		return x.SwitchId
+	}
+	return ""
+}
+
+func (x *NetworkPortSelector) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+// VMInstanceUUIDSelector matches a VM NIC
+type VMInstanceUUIDSelector struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VCenter        string                 `protobuf:"bytes,1,opt,name=v_center,json=vCenter,proto3" json:"v_center,omitempty"`
+	VmUuid         string                 `protobuf:"bytes,2,opt,name=vm_uuid,json=vmUuid,proto3" json:"vm_uuid,omitempty"`
+	VmNicMac       string                 `protobuf:"bytes,3,opt,name=vm_nic_mac,json=vmNicMac,proto3" json:"vm_nic_mac,omitempty"`
+	VmNicName      string                 `protobuf:"bytes,4,opt,name=vm_nic_name,json=vmNicName,proto3" json:"vm_nic_name,omitempty"`
+	Vlan           string                 `protobuf:"bytes,5,opt,name=vlan,proto3" json:"vlan,omitempty"`
+	Ip             string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	Network        string                 `protobuf:"bytes,7,opt,name=network,proto3" json:"network,omitempty"`
+	VrfName        string                 `protobuf:"bytes,8,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	Host           string                 `protobuf:"bytes,9,opt,name=host,proto3" json:"host,omitempty"`
+	Switch         string                 `protobuf:"bytes,10,opt,name=switch,proto3" json:"switch,omitempty"`
+	SwitchIntf     string                 `protobuf:"bytes,11,opt,name=switch_intf,json=switchIntf,proto3" json:"switch_intf,omitempty"`
+	Name           string                 `protobuf:"bytes,12,opt,name=name,proto3" json:"name,omitempty"`
+	ConfiguredSgid string                 `protobuf:"bytes,13,opt,name=configured_sgid,json=configuredSgid,proto3" json:"configured_sgid,omitempty"`
+	Type           string                 `protobuf:"bytes,14,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	DeletedStr     string                 `protobuf:"bytes,16,opt,name=deleted_str,json=deletedStr,proto3" json:"deleted_str,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VMInstanceUUIDSelector) Reset() {
+	*x = VMInstanceUUIDSelector{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMInstanceUUIDSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMInstanceUUIDSelector) ProtoMessage() {}
+
+func (x *VMInstanceUUIDSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMInstanceUUIDSelector.ProtoReflect.Descriptor instead.
+func (*VMInstanceUUIDSelector) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VMInstanceUUIDSelector) GetVCenter() string {
+	if x != nil {
+		return x.VCenter
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetVmUuid() string {
+	if x != nil {
+		return x.VmUuid
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetVmNicMac() string {
+	if x != nil {
+		return x.VmNicMac
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetVmNicName() string {
+	if x != nil {
+		return x.VmNicName
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetVlan() string {
+	if x != nil {
+		return x.Vlan
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetSwitch() string {
+	if x != nil {
+		return x.Switch
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetSwitchIntf() string {
+	if x != nil {
+		return x.SwitchIntf
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetConfiguredSgid() string {
+	if x != nil {
+		return x.ConfiguredSgid
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *VMInstanceUUIDSelector) GetDeletedStr() string {
+	if x != nil {
+		return x.DeletedStr
+	}
+	return ""
+}
+
+// SecurityContract represents a security contract
+type SecurityContract struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	NdObjectId    string                 `protobuf:"bytes,4,opt,name=nd_object_id,json=ndObjectId,proto3" json:"nd_object_id,omitempty"`
+	FabricName    string                 `protobuf:"bytes,5,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
+	Rules         []*ContractRule        `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecurityContract) Reset() {
+	*x = SecurityContract{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityContract) ProtoMessage() {}
+
+func (x *SecurityContract) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityContract.ProtoReflect.Descriptor instead.
+func (*SecurityContract) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SecurityContract) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecurityContract) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecurityContract) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SecurityContract) GetNdObjectId() string {
+	if x != nil {
+		return x.NdObjectId
+	}
+	return ""
+}
+
+func (x *SecurityContract) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *SecurityContract) GetRules() []*ContractRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *SecurityContract) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SecurityContract) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ContractRule represents a rule within a security contract
+type ContractRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SrcPort       string                 `protobuf:"bytes,5,opt,name=src_port,json=srcPort,proto3" json:"src_port,omitempty"`
+	DstPort       string                 `protobuf:"bytes,6,opt,name=dst_port,json=dstPort,proto3" json:"dst_port,omitempty"`
+	Priority      int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContractRule) Reset() {
+	*x = ContractRule{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractRule) ProtoMessage() {}
+
+func (x *ContractRule) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractRule.ProtoReflect.Descriptor instead.
+func (*ContractRule) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ContractRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ContractRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContractRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ContractRule) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ContractRule) GetSrcPort() string {
+	if x != nil {
+		return x.SrcPort
+	}
+	return ""
+}
+
+func (x *ContractRule) GetDstPort() string {
+	if x != nil {
+		return x.DstPort
+	}
+	return ""
+}
+
+func (x *ContractRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// CloneContractRequest clones a security contract
+type CloneContractRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Source contract ID or name
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`          // Name for the cloned contract
+	FabricName    string                 `protobuf:"bytes,3,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"` // Defaults to the source contract's fabric
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneContractRequest) Reset() {
+	*x = CloneContractRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneContractRequest) ProtoMessage() {}
+
+func (x *CloneContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneContractRequest.ProtoReflect.Descriptor instead.
+func (*CloneContractRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CloneContractRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CloneContractRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *CloneContractRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+// CloneContractResponse returns the cloned contract
+type CloneContractResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contract      *SecurityContract      `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneContractResponse) Reset() {
+	*x = CloneContractResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneContractResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneContractResponse) ProtoMessage() {}
+
+func (x *CloneContractResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneContractResponse.ProtoReflect.Descriptor instead.
+func (*CloneContractResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CloneContractResponse) GetContract() *SecurityContract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+// GetSecurityGroupsRequest lists security groups
+type GetSecurityGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricName    string                 `protobuf:"bytes,1,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`       // Fetch from NDFC for this fabric; empty lists the local DB
+	Fabric        string                 `protobuf:"bytes,2,opt,name=fabric,proto3" json:"fabric,omitempty"`                                 // Local listings only: filter by fabric
+	NameContains  string                 `protobuf:"bytes,3,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"` // Case-insensitive name substring
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`                                     // name_asc (default), name_desc or id_asc
+	Pagination    *PaginationRequest     `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`                         // page_size defaults to 50 (max 500)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecurityGroupsRequest) Reset() {
+	*x = GetSecurityGroupsRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecurityGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecurityGroupsRequest) ProtoMessage() {}
+
+func (x *GetSecurityGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecurityGroupsRequest.ProtoReflect.Descriptor instead.
+func (*GetSecurityGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSecurityGroupsRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *GetSecurityGroupsRequest) GetFabric() string {
+	if x != nil {
+		return x.Fabric
+	}
+	return ""
+}
+
+func (x *GetSecurityGroupsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *GetSecurityGroupsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetSecurityGroupsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// GetSecurityGroupsResponse returns one page of security groups
+type GetSecurityGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*SecurityGroup       `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecurityGroupsResponse) Reset() {
+	*x = GetSecurityGroupsResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecurityGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecurityGroupsResponse) ProtoMessage() {}
+
+func (x *GetSecurityGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecurityGroupsResponse.ProtoReflect.Descriptor instead.
+func (*GetSecurityGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSecurityGroupsResponse) GetGroups() []*SecurityGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GetSecurityGroupsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListSecurityGroupsRequest lists security groups
+type ListSecurityGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FabricName    string                 `protobuf:"bytes,1,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`       // Fetch from NDFC for this fabric; empty lists the local DB
+	Fabric        string                 `protobuf:"bytes,2,opt,name=fabric,proto3" json:"fabric,omitempty"`                                 // Local listings only: filter by fabric
+	NameContains  string                 `protobuf:"bytes,3,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"` // Case-insensitive name substring
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`                                     // name_asc (default), name_desc or id_asc
+	Pagination    *PaginationRequest     `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`                         // page_size defaults to 50 (max 500)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityGroupsRequest) Reset() {
+	*x = ListSecurityGroupsRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityGroupsRequest) ProtoMessage() {}
+
+func (x *ListSecurityGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListSecurityGroupsRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *ListSecurityGroupsRequest) GetFabric() string {
+	if x != nil {
+		return x.Fabric
+	}
+	return ""
+}
+
+func (x *ListSecurityGroupsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListSecurityGroupsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListSecurityGroupsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListSecurityGroupsResponse returns one page of security groups
+type ListSecurityGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*SecurityGroup       `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityGroupsResponse) Reset() {
+	*x = ListSecurityGroupsResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityGroupsResponse) ProtoMessage() {}
+
+func (x *ListSecurityGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSecurityGroupsResponse) GetGroups() []*SecurityGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ListSecurityGroupsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// GetSecurityGroupRequest gets a security group
+type GetSecurityGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Local group ID, or the group name when fabric_name is set
+	FabricName    string                 `protobuf:"bytes,2,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"` // Fetch from NDFC for this fabric
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecurityGroupRequest) Reset() {
+	*x = GetSecurityGroupRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecurityGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecurityGroupRequest) ProtoMessage() {}
+
+func (x *GetSecurityGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecurityGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetSecurityGroupRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSecurityGroupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSecurityGroupRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+// GetSecurityGroupResponse returns a security group
+type GetSecurityGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *SecurityGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecurityGroupResponse) Reset() {
+	*x = GetSecurityGroupResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecurityGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecurityGroupResponse) ProtoMessage() {}
+
+func (x *GetSecurityGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecurityGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetSecurityGroupResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSecurityGroupResponse) GetGroup() *SecurityGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// CreateSecurityGroupRequest creates a security group
+type CreateSecurityGroupRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	GroupName            string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	FabricName           string                 `protobuf:"bytes,2,opt,name=fabric_name,json=fabricName,proto3" json:"fabric_name,omitempty"`
+	SourceFabric         string                 `protobuf:"bytes,3,opt,name=source_fabric,json=sourceFabric,proto3" json:"source_fabric,omitempty"`
+	Attach               bool                   `protobuf:"varint,4,opt,name=attach,proto3" json:"attach,omitempty"`
+	IpSelectors          []*IPSelector          `protobuf:"bytes,5,rep,name=ip_selectors,json=ipSelectors,proto3" json:"ip_selectors,omitempty"`
+	NetworkSelectors     []*NetworkSelector     `protobuf:"bytes,6,rep,name=network_selectors,json=networkSelectors,proto3" json:"network_selectors,omitempty"`
+	NetworkPortSelectors []*NetworkPortSelector `protobuf:"bytes,7,rep,name=network_port_selectors,json=networkPortSelectors,proto3" json:"network_port_selectors,omitempty"` // Short interface names are normalized
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateSecurityGroupRequest) Reset() {
+	*x = CreateSecurityGroupRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecurityGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecurityGroupRequest) ProtoMessage() {}
+
+func (x *CreateSecurityGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecurityGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateSecurityGroupRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateSecurityGroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *CreateSecurityGroupRequest) GetFabricName() string {
+	if x != nil {
+		return x.FabricName
+	}
+	return ""
+}
+
+func (x *CreateSecurityGroupRequest) GetSourceFabric() string {
+	if x != nil {
+		return x.SourceFabric
+	}
+	return ""
+}
+
+func (x *CreateSecurityGroupRequest) GetAttach() bool {
+	if x != nil {
+		return x.Attach
+	}
+	return false
+}
+
+func (x *CreateSecurityGroupRequest) GetIpSelectors() []*IPSelector {
+	if x != nil {
+		return x.IpSelectors
+	}
+	return nil
+}
+
+func (x *CreateSecurityGroupRequest) GetNetworkSelectors() []*NetworkSelector {
+	if x != nil {
+		return x.NetworkSelectors
+	}
+	return nil
+}
+
+func (x *CreateSecurityGroupRequest) GetNetworkPortSelectors() []*NetworkPortSelector {
+	if x != nil {
+		return x.NetworkPortSelectors
+	}
+	return nil
+}
+
+// CreateSecurityGroupResponse returns the local record with the selectors as created in NDFC
+type CreateSecurityGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *SecurityGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecurityGroupResponse) Reset() {
+	*x = CreateSecurityGroupResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecurityGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecurityGroupResponse) ProtoMessage() {}
+
+func (x *CreateSecurityGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecurityGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateSecurityGroupResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateSecurityGroupResponse) GetGroup() *SecurityGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// DeleteSecurityGroupRequest deletes a security group
+type DeleteSecurityGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecurityGroupRequest) Reset() {
+	*x = DeleteSecurityGroupRequest{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecurityGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecurityGroupRequest) ProtoMessage() {}
+
+func (x *DeleteSecurityGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecurityGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecurityGroupRequest) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteSecurityGroupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteSecurityGroupResponse confirms deletion
+type DeleteSecurityGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecurityGroupResponse) Reset() {
+	*x = DeleteSecurityGroupResponse{}
+	mi := &file_go_nd_v1_security_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecurityGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecurityGroupResponse) ProtoMessage() {}
+
+func (x *DeleteSecurityGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_go_nd_v1_security_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecurityGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecurityGroupResponse) Descriptor() ([]byte, []int) {
+	return file_go_nd_v1_security_proto_rawDescGZIP(), []int{18}
+}
+
+var File_go_nd_v1_security_proto protoreflect.FileDescriptor
+
+const file_go_nd_v1_security_proto_rawDesc = "" +
+	"\n" +
+	"\x17go_nd/v1/security.proto\x12\bgo_nd.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15go_nd/v1/common.proto\"\x80\x05\n" +
+	"\rSecurityGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
+	"\fnd_object_id\x18\x04 \x01(\tR\n" +
+	"ndObjectId\x12\x1f\n" +
+	"\vfabric_name\x18\x05 \x01(\tR\n" +
+	"fabricName\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12#\n" +
+	"\rsource_fabric\x18\b \x01(\tR\fsourceFabric\x12\x16\n" +
+	"\x06attach\x18\t \x01(\bR\x06attach\x127\n" +
+	"\fip_selectors\x18\n" +
+	" \x03(\v2\x14.go_nd.v1.IPSelectorR\vipSelectors\x12F\n" +
+	"\x11network_selectors\x18\v \x03(\v2\x19.go_nd.v1.NetworkSelectorR\x10networkSelectors\x12S\n" +
+	"\x16network_port_selectors\x18\f \x03(\v2\x1d.go_nd.v1.NetworkPortSelectorR\x14networkPortSelectors\x12]\n" +
+	"\x1avm_instance_uuid_selectors\x18\r \x03(\v2 .go_nd.v1.VMInstanceUUIDSelectorR\x17vmInstanceUuidSelectors\"\x8b\x01\n" +
+	"\n" +
+	"IPSelector\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x19\n" +
+	"\bvrf_name\x18\x03 \x01(\tR\avrfName\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1f\n" +
+	"\vdeleted_str\x18\x05 \x01(\tR\n" +
+	"deletedStr\"F\n" +
+	"\x0fNetworkSelector\x12\x19\n" +
+	"\bvrf_name\x18\x01 \x01(\tR\avrfName\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\"s\n" +
+	"\x13NetworkPortSelector\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x1b\n" +
+	"\tswitch_id\x18\x02 \x01(\tR\bswitchId\x12%\n" +
+	"\x0einterface_name\x18\x03 \x01(\tR\rinterfaceName\"\xc1\x03\n" +
+	"\x16VMInstanceUUIDSelector\x12\x19\n" +
+	"\bv_center\x18\x01 \x01(\tR\avCenter\x12\x17\n" +
+	"\avm_uuid\x18\x02 \x01(\tR\x06vmUuid\x12\x1c\n" +
+	"\n" +
+	"vm_nic_mac\x18\x03 \x01(\tR\bvmNicMac\x12\x1e\n" +
+	"\vvm_nic_name\x18\x04 \x01(\tR\tvmNicName\x12\x12\n" +
+	"\x04vlan\x18\x05 \x01(\tR\x04vlan\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x18\n" +
+	"\anetwork\x18\a \x01(\tR\anetwork\x12\x19\n" +
+	"\bvrf_name\x18\b \x01(\tR\avrfName\x12\x12\n" +
+	"\x04host\x18\t \x01(\tR\x04host\x12\x16\n" +
+	"\x06switch\x18\n" +
+	" \x01(\tR\x06switch\x12\x1f\n" +
+	"\vswitch_intf\x18\v \x01(\tR\n" +
+	"switchIntf\x12\x12\n" +
+	"\x04name\x18\f \x01(\tR\x04name\x12'\n" +
+	"\x0fconfigured_sgid\x18\r \x01(\tR\x0econfiguredSgid\x12\x12\n" +
+	"\x04type\x18\x0e \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x0f \x01(\tR\tcreatedBy\x12\x1f\n" +
+	"\vdeleted_str\x18\x10 \x01(\tR\n" +
+	"deletedStr\"\xbf\x02\n" +
+	"\x10SecurityContract\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
+	"\fnd_object_id\x18\x04 \x01(\tR\n" +
+	"ndObjectId\x12\x1f\n" +
+	"\vfabric_name\x18\x05 \x01(\tR\n" +
+	"fabricName\x12,\n" +
+	"\x05rules\x18\x06 \x03(\v2\x16.go_nd.v1.ContractRuleR\x05rules\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb8\x01\n" +
+	"\fContractRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1a\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\x12\x19\n" +
+	"\bsrc_port\x18\x05 \x01(\tR\asrcPort\x12\x19\n" +
+	"\bdst_port\x18\x06 \x01(\tR\adstPort\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\"b\n" +
+	"\x14CloneContractRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\x12\x1f\n" +
+	"\vfabric_name\x18\x03 \x01(\tR\n" +
+	"fabricName\"O\n" +
+	"\x15CloneContractResponse\x126\n" +
+	"\bcontract\x18\x01 \x01(\v2\x1a.go_nd.v1.SecurityContractR\bcontract\"\xc9\x01\n" +
+	"\x18GetSecurityGroupsRequest\x12\x1f\n" +
+	"\vfabric_name\x18\x01 \x01(\tR\n" +
+	"fabricName\x12\x16\n" +
+	"\x06fabric\x18\x02 \x01(\tR\x06fabric\x12#\n" +
+	"\rname_contains\x18\x03 \x01(\tR\fnameContains\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12;\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1b.go_nd.v1.PaginationRequestR\n" +
+	"pagination\"\x8a\x01\n" +
+	"\x19GetSecurityGroupsResponse\x12/\n" +
+	"\x06groups\x18\x01 \x03(\v2\x17.go_nd.v1.SecurityGroupR\x06groups\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.go_nd.v1.PaginationResponseR\n" +
+	"pagination\"\xca\x01\n" +
+	"\x19ListSecurityGroupsRequest\x12\x1f\n" +
+	"\vfabric_name\x18\x01 \x01(\tR\n" +
+	"fabricName\x12\x16\n" +
+	"\x06fabric\x18\x02 \x01(\tR\x06fabric\x12#\n" +
+	"\rname_contains\x18\x03 \x01(\tR\fnameContains\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12;\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1b.go_nd.v1.PaginationRequestR\n" +
+	"pagination\"\x8b\x01\n" +
+	"\x1aListSecurityGroupsResponse\x12/\n" +
+	"\x06groups\x18\x01 \x03(\v2\x17.go_nd.v1.SecurityGroupR\x06groups\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.go_nd.v1.PaginationResponseR\n" +
+	"pagination\"J\n" +
+	"\x17GetSecurityGroupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vfabric_name\x18\x02 \x01(\tR\n" +
+	"fabricName\"I\n" +
+	"\x18GetSecurityGroupResponse\x12-\n" +
+	"\x05group\x18\x01 \x01(\v2\x17.go_nd.v1.SecurityGroupR\x05group\"\xef\x02\n" +
+	"\x1aCreateSecurityGroupRequest\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x1f\n" +
+	"\vfabric_name\x18\x02 \x01(\tR\n" +
+	"fabricName\x12#\n" +
+	"\rsource_fabric\x18\x03 \x01(\tR\fsourceFabric\x12\x16\n" +
+	"\x06attach\x18\x04 \x01(\bR\x06attach\x127\n" +
+	"\fip_selectors\x18\x05 \x03(\v2\x14.go_nd.v1.IPSelectorR\vipSelectors\x12F\n" +
+	"\x11network_selectors\x18\x06 \x03(\v2\x19.go_nd.v1.NetworkSelectorR\x10networkSelectors\x12S\n" +
+	"\x16network_port_selectors\x18\a \x03(\v2\x1d.go_nd.v1.NetworkPortSelectorR\x14networkPortSelectors\"L\n" +
+	"\x1bCreateSecurityGroupResponse\x12-\n" +
+	"\x05group\x18\x01 \x01(\v2\x17.go_nd.v1.SecurityGroupR\x05group\",\n" +
+	"\x1aDeleteSecurityGroupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1d\n" +
+	"\x1bDeleteSecurityGroupResponse2\xfb\x05\n" +
+	"\x0fSecurityService\x12~\n" +
+	"\rCloneContract\x12\x1e.go_nd.v1.CloneContractRequest\x1a\x1f.go_nd.v1.CloneContractResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/security/contracts/{id}:clone\x12a\n" +
+	"\x11GetSecurityGroups\x12\".go_nd.v1.GetSecurityGroupsRequest\x1a#.go_nd.v1.GetSecurityGroupsResponse\"\x03\x88\x02\x01\x12|\n" +
+	"\x12ListSecurityGroups\x12#.go_nd.v1.ListSecurityGroupsRequest\x1a$.go_nd.v1.ListSecurityGroupsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/security/groups\x12{\n" +
+	"\x10GetSecurityGroup\x12!.go_nd.v1.GetSecurityGroupRequest\x1a\".go_nd.v1.GetSecurityGroupResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/security/groups/{id}\x12\x82\x01\n" +
+	"\x13CreateSecurityGroup\x12$.go_nd.v1.CreateSecurityGroupRequest\x1a%.go_nd.v1.CreateSecurityGroupResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/security/groups\x12\x84\x01\n" +
+	"\x13DeleteSecurityGroup\x12$.go_nd.v1.DeleteSecurityGroupRequest\x1a%.go_nd.v1.DeleteSecurityGroupResponse\" \x82\xd3\xe4\x93\x02\x1a*\x18/v1/security/groups/{id}B\x89\x01\n" +
+	"\fcom.go_nd.v1B\rSecurityProtoP\x01Z-github.com/banglin/go-nd/gen/go_nd/v1;go_ndv1\xa2\x02\x03GXX\xaa\x02\aGoNd.V1\xca\x02\aGoNd\\V1\xe2\x02\x13GoNd\\V1\\GPBMetadata\xea\x02\bGoNd::V1b\x06proto3"
+
+var (
+	file_go_nd_v1_security_proto_rawDescOnce sync.Once
+	file_go_nd_v1_security_proto_rawDescData []byte
+)
+
+func file_go_nd_v1_security_proto_rawDescGZIP() []byte {
+	file_go_nd_v1_security_proto_rawDescOnce.Do(func() {
+		file_go_nd_v1_security_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_go_nd_v1_security_proto_rawDesc), len(file_go_nd_v1_security_proto_rawDesc)))
+	})
+	return file_go_nd_v1_security_proto_rawDescData
+}
+
+var file_go_nd_v1_security_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_go_nd_v1_security_proto_goTypes = []any{
+	(*SecurityGroup)(nil),               // 0: go_nd.v1.SecurityGroup
+	(*IPSelector)(nil),                  // 1: go_nd.v1.IPSelector
+	(*NetworkSelector)(nil),             // 2: go_nd.v1.NetworkSelector
+	(*NetworkPortSelector)(nil),         // 3: go_nd.v1.NetworkPortSelector
+	(*VMInstanceUUIDSelector)(nil),      // 4: go_nd.v1.VMInstanceUUIDSelector
+	(*SecurityContract)(nil),            // 5: go_nd.v1.SecurityContract
+	(*ContractRule)(nil),                // 6: go_nd.v1.ContractRule
+	(*CloneContractRequest)(nil),        // 7: go_nd.v1.CloneContractRequest
+	(*CloneContractResponse)(nil),       // 8: go_nd.v1.CloneContractResponse
+	(*GetSecurityGroupsRequest)(nil),    // 9: go_nd.v1.GetSecurityGroupsRequest
+	(*GetSecurityGroupsResponse)(nil),   // 10: go_nd.v1.GetSecurityGroupsResponse
+	(*ListSecurityGroupsRequest)(nil),   // 11: go_nd.v1.ListSecurityGroupsRequest
+	(*ListSecurityGroupsResponse)(nil),  // 12: go_nd.v1.ListSecurityGroupsResponse
+	(*GetSecurityGroupRequest)(nil),     // 13: go_nd.v1.GetSecurityGroupRequest
+	(*GetSecurityGroupResponse)(nil),    // 14: go_nd.v1.GetSecurityGroupResponse
+	(*CreateSecurityGroupRequest)(nil),  // 15: go_nd.v1.CreateSecurityGroupRequest
+	(*CreateSecurityGroupResponse)(nil), // 16: go_nd.v1.CreateSecurityGroupResponse
+	(*DeleteSecurityGroupRequest)(nil),  // 17: go_nd.v1.DeleteSecurityGroupRequest
+	(*DeleteSecurityGroupResponse)(nil), // 18: go_nd.v1.DeleteSecurityGroupResponse
+	(*timestamppb.Timestamp)(nil),       // 19: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),           // 20: go_nd.v1.PaginationRequest
+	(*PaginationResponse)(nil),          // 21: go_nd.v1.PaginationResponse
+}
+var file_go_nd_v1_security_proto_depIdxs = []int32{
+	19, // 0: go_nd.v1.SecurityGroup.created_at:type_name -> google.protobuf.Timestamp
+	19, // 1: go_nd.v1.SecurityGroup.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: go_nd.v1.SecurityGroup.ip_selectors:type_name -> go_nd.v1.IPSelector
+	2,  // 3: go_nd.v1.SecurityGroup.network_selectors:type_name -> go_nd.v1.NetworkSelector
+	3,  // 4: go_nd.v1.SecurityGroup.network_port_selectors:type_name -> go_nd.v1.NetworkPortSelector
+	4,  // 5: go_nd.v1.SecurityGroup.vm_instance_uuid_selectors:type_name -> go_nd.v1.VMInstanceUUIDSelector
+	6,  // 6: go_nd.v1.SecurityContract.rules:type_name -> go_nd.v1.ContractRule
+	19, // 7: go_nd.v1.SecurityContract.created_at:type_name -> google.protobuf.Timestamp
+	19, // 8: go_nd.v1.SecurityContract.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 9: go_nd.v1.CloneContractResponse.contract:type_name -> go_nd.v1.SecurityContract
+	20, // 10: go_nd.v1.GetSecurityGroupsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	0,  // 11: go_nd.v1.GetSecurityGroupsResponse.groups:type_name -> go_nd.v1.SecurityGroup
+	21, // 12: go_nd.v1.GetSecurityGroupsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	20, // 13: go_nd.v1.ListSecurityGroupsRequest.pagination:type_name -> go_nd.v1.PaginationRequest
+	0,  // 14: go_nd.v1.ListSecurityGroupsResponse.groups:type_name -> go_nd.v1.SecurityGroup
+	21, // 15: go_nd.v1.ListSecurityGroupsResponse.pagination:type_name -> go_nd.v1.PaginationResponse
+	0,  // 16: go_nd.v1.GetSecurityGroupResponse.group:type_name -> go_nd.v1.SecurityGroup
+	1,  // 17: go_nd.v1.CreateSecurityGroupRequest.ip_selectors:type_name -> go_nd.v1.IPSelector
+	2,  // 18: go_nd.v1.CreateSecurityGroupRequest.network_selectors:type_name -> go_nd.v1.NetworkSelector
+	3,  // 19: go_nd.v1.CreateSecurityGroupRequest.network_port_selectors:type_name -> go_nd.v1.NetworkPortSelector
+	0,  // 20: go_nd.v1.CreateSecurityGroupResponse.group:type_name -> go_nd.v1.SecurityGroup
+	7,  // 21: go_nd.v1.SecurityService.CloneContract:input_type -> go_nd.v1.CloneContractRequest
+	9,  // 22: go_nd.v1.SecurityService.GetSecurityGroups:input_type -> go_nd.v1.GetSecurityGroupsRequest
+	11, // 23: go_nd.v1.SecurityService.ListSecurityGroups:input_type -> go_nd.v1.ListSecurityGroupsRequest
+	13, // 24: go_nd.v1.SecurityService.GetSecurityGroup:input_type -> go_nd.v1.GetSecurityGroupRequest
+	15, // 25: go_nd.v1.SecurityService.CreateSecurityGroup:input_type -> go_nd.v1.CreateSecurityGroupRequest
+	17, // 26: go_nd.v1.SecurityService.DeleteSecurityGroup:input_type -> go_nd.v1.DeleteSecurityGroupRequest
+	8,  // 27: go_nd.v1.SecurityService.CloneContract:output_type -> go_nd.v1.CloneContractResponse
+	10, // 28: go_nd.v1.SecurityService.GetSecurityGroups:output_type -> go_nd.v1.GetSecurityGroupsResponse
+	12, // 29: go_nd.v1.SecurityService.ListSecurityGroups:output_type -> go_nd.v1.ListSecurityGroupsResponse
+	14, // 30: go_nd.v1.SecurityService.GetSecurityGroup:output_type -> go_nd.v1.GetSecurityGroupResponse
+	16, // 31: go_nd.v1.SecurityService.CreateSecurityGroup:output_type -> go_nd.v1.CreateSecurityGroupResponse
+	18, // 32: go_nd.v1.SecurityService.DeleteSecurityGroup:output_type -> go_nd.v1.DeleteSecurityGroupResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_go_nd_v1_security_proto_init() }
+func file_go_nd_v1_security_proto_init() {
+	if File_go_nd_v1_security_proto != nil {
+		return
+	}
+	file_go_nd_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_go_nd_v1_security_proto_rawDesc), len(file_go_nd_v1_security_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_go_nd_v1_security_proto_goTypes,
+		DependencyIndexes: file_go_nd_v1_security_proto_depIdxs,
+		MessageInfos:      file_go_nd_v1_security_proto_msgTypes,
+	}.Build()
+	File_go_nd_v1_security_proto = out.File
+	file_go_nd_v1_security_proto_goTypes = nil
+	file_go_nd_v1_security_proto_depIdxs = nil
+}
@@ -7,6 +7,7 @@
 package go_ndv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -601,7 +602,7 @@ var File_go_nd_v1_storage_tenants_proto protoreflect.FileDescriptor
 
 const file_go_nd_v1_storage_tenants_proto_rawDesc = "" +
 	"\n" +
-	"\x1ego_nd/v1/storage_tenants.proto\x12\bgo_nd.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x02\n" +
+	"\x1ego_nd/v1/storage_tenants.proto\x12\bgo_nd.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x02\n" +
 	"\rStorageTenant\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
@@ -635,13 +636,13 @@ const file_go_nd_v1_storage_tenants_proto_rawDesc = "" +
 	"\x0estorage_tenant\x18\x01 \x01(\v2\x17.go_nd.v1.StorageTenantR\rstorageTenant\".\n" +
 	"\x1aDeleteStorageTenantRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"\x1d\n" +
-	"\x1bDeleteStorageTenantResponse2\xff\x03\n" +
-	"\x15StorageTenantsService\x12_\n" +
-	"\x12ListStorageTenants\x12#.go_nd.v1.ListStorageTenantsRequest\x1a$.go_nd.v1.ListStorageTenantsResponse\x12Y\n" +
-	"\x10GetStorageTenant\x12!.go_nd.v1.GetStorageTenantRequest\x1a\".go_nd.v1.GetStorageTenantResponse\x12b\n" +
-	"\x13CreateStorageTenant\x12$.go_nd.v1.CreateStorageTenantRequest\x1a%.go_nd.v1.CreateStorageTenantResponse\x12b\n" +
-	"\x13UpdateStorageTenant\x12$.go_nd.v1.UpdateStorageTenantRequest\x1a%.go_nd.v1.UpdateStorageTenantResponse\x12b\n" +
-	"\x13DeleteStorageTenant\x12$.go_nd.v1.DeleteStorageTenantRequest\x1a%.go_nd.v1.DeleteStorageTenantResponseB\x8f\x01\n" +
+	"\x1bDeleteStorageTenantResponse2\xab\x05\n" +
+	"\x15StorageTenantsService\x12|\n" +
+	"\x12ListStorageTenants\x12#.go_nd.v1.ListStorageTenantsRequest\x1a$.go_nd.v1.ListStorageTenantsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/storage-tenants\x12|\n" +
+	"\x10GetStorageTenant\x12!.go_nd.v1.GetStorageTenantRequest\x1a\".go_nd.v1.GetStorageTenantResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/storage-tenants/{key}\x12\x82\x01\n" +
+	"\x13CreateStorageTenant\x12$.go_nd.v1.CreateStorageTenantRequest\x1a%.go_nd.v1.CreateStorageTenantResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/storage-tenants\x12\x88\x01\n" +
+	"\x13UpdateStorageTenant\x12$.go_nd.v1.UpdateStorageTenantRequest\x1a%.go_nd.v1.UpdateStorageTenantResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\x1a\x19/v1/storage-tenants/{key}\x12\x85\x01\n" +
+	"\x13DeleteStorageTenant\x12$.go_nd.v1.DeleteStorageTenantRequest\x1a%.go_nd.v1.DeleteStorageTenantResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/storage-tenants/{key}B\x8f\x01\n" +
 	"\fcom.go_nd.v1B\x13StorageTenantsProtoP\x01Z-github.com/banglin/go-nd/gen/go_nd/v1;go_ndv1\xa2\x02\x03GXX\xaa\x02\aGoNd.V1\xca\x02\aGoNd\\V1\xe2\x02\x13GoNd\\V1\\GPBMetadata\xea\x02\bGoNd::V1b\x06proto3"
 
 var (
@@ -0,0 +1,61 @@
+// Package dbcontext defines an analyzer that reports GORM calls on the shared database.DB
+// handle made without WithContext, which keep running after the request that started them
+// is cancelled.
+package dbcontext
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// databasePkgSuffix identifies the package declaring the shared handle. Matched as a suffix
+// so the analysistest fixtures can provide their own copy.
+const databasePkgSuffix = "internal/database"
+
+// Analyzer reports database.DB.X(...) calls where X is not WithContext. Test files and the
+// database package itself (migrations at startup) are not checked.
+var Analyzer = &analysis.Analyzer{
+	Name: "dbcontext",
+	Doc:  "report database.DB calls without WithContext",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if isDatabasePkg(pass.Pkg.Path()) {
+		return nil, nil
+	}
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name == "WithContext" || !isSharedDB(pass, sel.X) {
+				return true
+			}
+			if _, isMethod := pass.TypesInfo.Selections[sel]; isMethod {
+				pass.Reportf(sel.Pos(), "database.DB.%s without WithContext: use database.DB.WithContext(ctx).%s",
+					sel.Sel.Name, sel.Sel.Name)
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// isSharedDB reports whether expr is the DB variable of the database package
+func isSharedDB(pass *analysis.Pass, expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "DB" {
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Var)
+	return ok && v.Pkg() != nil && isDatabasePkg(v.Pkg().Path())
+}
+
+func isDatabasePkg(path string) bool {
+	return strings.HasSuffix(path, "/"+databasePkgSuffix)
+}
@@ -0,0 +1,11 @@
+package dbcontext
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "example.com/handlers", "example.com/internal/database")
+}
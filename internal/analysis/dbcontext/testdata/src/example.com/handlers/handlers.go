@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"context"
+
+	"example.com/internal/database"
+)
+
+func use(db *database.Gorm) {}
+
+func handler(ctx context.Context) {
+	database.DB.First(nil)            // want `database.DB.First without WithContext`
+	database.DB.Where("x").First(nil) // want `database.DB.Where without WithContext`
+	database.DB.WithContext(ctx).Where("x").First(nil)
+	use(database.DB)
+	db := database.DB
+	db.First(nil)
+}
@@ -0,0 +1,5 @@
+package handlers
+
+import "example.com/internal/database"
+
+func seed() { database.DB.First(nil) }
@@ -0,0 +1,15 @@
+package database
+
+import "context"
+
+// Gorm stands in for *gorm.DB
+type Gorm struct{}
+
+func (g *Gorm) WithContext(ctx context.Context) *Gorm { return g }
+func (g *Gorm) First(dest interface{}) *Gorm          { return g }
+func (g *Gorm) Where(query interface{}) *Gorm         { return g }
+
+var DB = &Gorm{}
+
+// Migrate runs at startup and is not checked
+func Migrate() { DB.First(nil) }
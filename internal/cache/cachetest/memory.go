@@ -0,0 +1,242 @@
+// Package cachetest provides an in-memory cache.CacheClient for unit tests that would
+// otherwise need a Valkey server.
+package cachetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+)
+
+// InMemoryCacheClient implements cache.CacheClient in process memory. Keys expire after
+// their TTL like in Valkey (a TTL <= 0 keeps the key forever), and Publish delivers to
+// Subscribe handlers of this client only.
+type InMemoryCacheClient struct {
+	entries sync.Map   // key -> *entry
+	mu      sync.Mutex // Serializes writes so read-modify-write operations (SetNX, SAdd, ...) are atomic
+
+	subMu       sync.Mutex
+	nextSub     int
+	subscribers map[string]map[int]func(channel, message string) // channel -> subscription ID -> handler
+}
+
+// entry is a stored string or set with its expiry (zero = none). Entries are never
+// modified in place; updates store a new entry.
+type entry struct {
+	value     string
+	set       []string
+	expiresAt time.Time
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+var _ cache.CacheClient = (*InMemoryCacheClient)(nil)
+
+// NewInMemoryCache returns an empty in-memory cache
+func NewInMemoryCache() cache.CacheClient {
+	return &InMemoryCacheClient{subscribers: make(map[string]map[int]func(channel, message string))}
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// load returns the live entry for key, dropping it if it has expired
+func (m *InMemoryCacheClient) load(key string) (entry, bool) {
+	v, ok := m.entries.Load(key)
+	if !ok {
+		return entry{}, false
+	}
+	e := v.(*entry)
+	if e.expired(time.Now()) {
+		m.entries.CompareAndDelete(key, v)
+		return entry{}, false
+	}
+	return *e, true
+}
+
+func (m *InMemoryCacheClient) SetNX(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.load(key); ok {
+		return false, nil
+	}
+	m.entries.Store(key, &entry{value: value, expiresAt: expiry(ttl)})
+	return true, nil
+}
+
+func (m *InMemoryCacheClient) AcquireLock(ctx context.Context, key, value string, ttl time.Duration) (func() error, error) {
+	acquired, err := m.SetNX(ctx, key, value, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, cache.ErrLockNotAcquired
+	}
+	return func() error { return m.ReleaseLock(ctx, key, value) }, nil
+}
+
+func (m *InMemoryCacheClient) ReleaseLock(_ context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.load(key); ok && e.value == value {
+		m.entries.Delete(key)
+	}
+	return nil
+}
+
+func (m *InMemoryCacheClient) ExtendLockIfOwner(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.load(key)
+	if !ok || e.value != value {
+		return false, nil
+	}
+	e.expiresAt = expiry(ttl)
+	m.entries.Store(key, &e)
+	return true, nil
+}
+
+func (m *InMemoryCacheClient) SetString(_ context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Store(key, &entry{value: value, expiresAt: expiry(ttl)})
+	return nil
+}
+
+func (m *InMemoryCacheClient) GetString(_ context.Context, key string) (string, error) {
+	e, ok := m.load(key)
+	if !ok {
+		return "", cache.ErrKeyNotFound
+	}
+	return e.value, nil
+}
+
+func (m *InMemoryCacheClient) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Store(key, &entry{value: string(data), expiresAt: expiry(ttl)})
+	return nil
+}
+
+func (m *InMemoryCacheClient) Get(_ context.Context, key string, dest interface{}) error {
+	e, ok := m.load(key)
+	if !ok {
+		return cache.ErrCacheMiss
+	}
+	return json.Unmarshal([]byte(e.value), dest)
+}
+
+func (m *InMemoryCacheClient) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		m.entries.Delete(key)
+	}
+	return nil
+}
+
+func (m *InMemoryCacheClient) Expire(_ context.Context, key string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.load(key); ok {
+		e.expiresAt = expiry(ttl)
+		m.entries.Store(key, &e)
+	}
+	return nil
+}
+
+func (m *InMemoryCacheClient) SAdd(_ context.Context, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, _ := m.load(key)
+	set := slices.Clone(e.set)
+	for _, member := range members {
+		if !slices.Contains(set, member) {
+			set = append(set, member)
+		}
+	}
+	m.entries.Store(key, &entry{set: set, expiresAt: e.expiresAt})
+	return nil
+}
+
+func (m *InMemoryCacheClient) SMembers(_ context.Context, key string) ([]string, error) {
+	e, _ := m.load(key)
+	return slices.Clone(e.set), nil
+}
+
+func (m *InMemoryCacheClient) IncrByEx(_ context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.load(key)
+	var current int64
+	if ok {
+		if _, err := fmt.Sscan(e.value, &current); err != nil {
+			return 0, 0, fmt.Errorf("value of %s is not an integer", key)
+		}
+	} else {
+		e.expiresAt = expiry(ttl)
+	}
+	current++
+	e.value = fmt.Sprint(current)
+	m.entries.Store(key, &e)
+
+	var remaining time.Duration
+	if !e.expiresAt.IsZero() {
+		remaining = time.Until(e.expiresAt)
+	}
+	return current, remaining, nil
+}
+
+func (m *InMemoryCacheClient) Publish(_ context.Context, channel, message string) error {
+	m.subMu.Lock()
+	handlers := make([]func(channel, message string), 0, len(m.subscribers[channel]))
+	for _, handler := range m.subscribers[channel] {
+		handlers = append(handlers, handler)
+	}
+	m.subMu.Unlock()
+	for _, handler := range handlers {
+		handler(channel, message)
+	}
+	return nil
+}
+
+// Subscribe delivers messages published on channels to handler until ctx is cancelled
+func (m *InMemoryCacheClient) Subscribe(ctx context.Context, channels []string, handler func(channel, message string)) error {
+	m.subMu.Lock()
+	id := m.nextSub
+	m.nextSub++
+	for _, channel := range channels {
+		if m.subscribers[channel] == nil {
+			m.subscribers[channel] = make(map[int]func(channel, message string))
+		}
+		m.subscribers[channel][id] = handler
+	}
+	m.subMu.Unlock()
+	if subscribed := cache.SubscribedHook(ctx); subscribed != nil {
+		subscribed()
+	}
+
+	<-ctx.Done()
+
+	m.subMu.Lock()
+	for _, channel := range channels {
+		delete(m.subscribers[channel], id)
+	}
+	m.subMu.Unlock()
+	return ctx.Err()
+}
@@ -0,0 +1,174 @@
+package cachetest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+)
+
+func TestInMemoryCache_StringsAndExpiry(t *testing.T) {
+	c := NewInMemoryCache()
+	ctx := context.Background()
+
+	if ok, _ := c.SetNX(ctx, "k", "v1", 20*time.Millisecond); !ok {
+		t.Fatal("SetNX on empty key = false")
+	}
+	if ok, _ := c.SetNX(ctx, "k", "v2", time.Minute); ok {
+		t.Error("SetNX on existing key = true")
+	}
+	if v, err := c.GetString(ctx, "k"); err != nil || v != "v1" {
+		t.Errorf("GetString = %q, %v; want v1", v, err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.GetString(ctx, "k"); !errors.Is(err, cache.ErrKeyNotFound) {
+		t.Errorf("GetString after TTL err = %v, want ErrKeyNotFound", err)
+	}
+	if ok, _ := c.SetNX(ctx, "k", "v3", 0); !ok {
+		t.Error("SetNX after expiry = false")
+	}
+}
+
+func TestInMemoryCache_JSONSetsAndCounters(t *testing.T) {
+	c := NewInMemoryCache()
+	ctx := context.Background()
+
+	var got map[string]bool
+	if err := c.Get(ctx, "j", &got); !errors.Is(err, cache.ErrCacheMiss) {
+		t.Errorf("Get on missing key err = %v, want ErrCacheMiss", err)
+	}
+	if err := c.Set(ctx, "j", map[string]bool{"a": true}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, "j", &got); err != nil || !got["a"] {
+		t.Errorf("Get = %v, %v", got, err)
+	}
+
+	_ = c.SAdd(ctx, "s", "b", "a")
+	_ = c.SAdd(ctx, "s", "a", "c")
+	members, _ := c.SMembers(ctx, "s")
+	slices.Sort(members)
+	if !slices.Equal(members, []string{"a", "b", "c"}) {
+		t.Errorf("SMembers = %v", members)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		n, ttl, err := c.IncrByEx(ctx, "n", time.Minute)
+		if err != nil || n != want || ttl <= 0 || ttl > time.Minute {
+			t.Errorf("IncrByEx = %d, %v, %v; want %d with TTL <= 1m", n, ttl, err, want)
+		}
+	}
+}
+
+func TestInMemoryCache_Lock(t *testing.T) {
+	c := NewInMemoryCache()
+	ctx := context.Background()
+
+	release, err := c.AcquireLock(ctx, "lock", "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AcquireLock(ctx, "lock", "other", time.Minute); !errors.Is(err, cache.ErrLockNotAcquired) {
+		t.Errorf("second AcquireLock err = %v, want ErrLockNotAcquired", err)
+	}
+	_ = c.ReleaseLock(ctx, "lock", "other")
+	if _, err := c.GetString(ctx, "lock"); err != nil {
+		t.Error("ReleaseLock by another owner removed the lock")
+	}
+	if err := release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AcquireLock(ctx, "lock", "other", time.Minute); err != nil {
+		t.Errorf("AcquireLock after release: %v", err)
+	}
+}
+
+func TestInMemoryCache_PubSub(t *testing.T) {
+	c := NewInMemoryCache()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	received := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Subscribe(ctx, []string{"ch"}, func(_, message string) { received <- message })
+	}()
+
+	// Subscribe registers asynchronously; publish until the handler sees a message
+	deadline := time.After(time.Second)
+	for delivered := false; !delivered; {
+		_ = c.Publish(context.Background(), "ch", "hello")
+		select {
+		case msg := <-received:
+			if msg != "hello" {
+				t.Errorf("message = %q", msg)
+			}
+			delivered = true
+		case <-deadline:
+			t.Fatal("message not delivered")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Subscribe returned %v, want context.Canceled", err)
+	}
+}
+
+// TestSubscribe_WithSubscribed tests that a message published once the WithSubscribed callback
+// ran is delivered, by the in-memory cache and by a ValkeyClient
+func TestSubscribe_WithSubscribed(t *testing.T) {
+	mr, valkeyClient := NewMiniredis(t)
+	memory := NewInMemoryCache()
+	stores := map[string]struct {
+		store   cache.Store
+		publish func(channel, message string) error
+	}{
+		"in-memory": {memory, func(channel, message string) error { return memory.Publish(context.Background(), channel, message) }},
+		// miniredis refuses PUBLISH on the subscribed connection, so publish server-side
+		"valkey": {valkeyClient, func(channel, message string) error { mr.Publish(channel, message); return nil }},
+	}
+	for name, tt := range stores {
+		ctx, cancel := context.WithCancel(context.Background())
+		subscribed := make(chan struct{})
+		received := make(chan string, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- tt.store.Subscribe(cache.WithSubscribed(ctx, func() { close(subscribed) }), []string{"ch1", "ch2"},
+				func(channel, message string) { received <- channel + ":" + message })
+		}()
+
+		select {
+		case <-subscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: subscription not confirmed", name)
+		}
+		if err := tt.publish("ch2", "hello"); err != nil {
+			t.Fatalf("%s: Publish: %v", name, err)
+		}
+		select {
+		case msg := <-received:
+			if msg != "ch2:hello" {
+				t.Errorf("%s: message = %q", name, msg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("%s: message published after confirmation not delivered", name)
+		}
+
+		cancel()
+		<-done
+	}
+
+	// Without Valkey the subscription is confirmed right away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	confirmed := false
+	_ = cache.NoOpCacheClient{}.Subscribe(cache.WithSubscribed(ctx, func() { confirmed = true; cancel() }), []string{"ch"}, nil)
+	if !confirmed {
+		t.Error("NoOpCacheClient: subscription not confirmed")
+	}
+}
@@ -0,0 +1,29 @@
+package cachetest
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/valkey-io/valkey-go"
+)
+
+// NewMiniredis starts an in-process Redis server and installs a client for it as the global
+// cache.Client. Client-side caching is off, as miniredis does not support CLIENT TRACKING.
+// The client is closed and the previous global restored when the test finishes.
+func NewMiniredis(t testing.TB) (*miniredis.Miniredis, *cache.ValkeyClient) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	conn, err := valkey.NewClient(valkey.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("connect to miniredis: %v", err)
+	}
+	prev := cache.Client
+	client := cache.NewValkeyClient(conn)
+	cache.Client = client
+	t.Cleanup(func() {
+		client.Close()
+		cache.Client = prev
+	})
+	return mr, client
+}
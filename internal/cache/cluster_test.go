@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/mock"
+	"go.uber.org/mock/gomock"
+)
+
+// newMockClusterClient wraps a slot-checking mock client reporting cluster mode
+func newMockClusterClient(t *testing.T) (*mock.Client, *ValkeyClient) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	client := mock.NewClient(ctrl, mock.WithSlotCheck())
+	client.EXPECT().Mode().Return(valkey.ClientModeCluster)
+	return client, NewValkeyClient(client)
+}
+
+func TestClientOption_ClusterModeRequiresAddresses(t *testing.T) {
+	_, err := clientOption(&config.ValkeyConfig{ClusterMode: true})
+	if err == nil || !strings.Contains(err.Error(), "VALKEY_CLUSTER_ADDRESSES") {
+		t.Fatalf("err = %v, want descriptive error naming VALKEY_CLUSTER_ADDRESSES", err)
+	}
+	if err := Initialize(&config.ValkeyConfig{ClusterMode: true}); err == nil {
+		t.Fatal("Initialize should fail when cluster addresses are empty")
+	}
+}
+
+func TestClientOption_Cluster(t *testing.T) {
+	opt, err := clientOption(&config.ValkeyConfig{
+		Address:          "localhost:6379",
+		DB:               3,
+		ClusterMode:      true,
+		ClusterAddresses: []string{"n1:7000", "n2:7000"},
+		PoolSize:         20,
+		MinIdleConns:     5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opt.InitAddress) != 2 || opt.InitAddress[0] != "n1:7000" {
+		t.Errorf("InitAddress = %v, want cluster addresses", opt.InitAddress)
+	}
+	if opt.SelectDB != 0 {
+		t.Errorf("SelectDB = %d, want 0 in cluster mode", opt.SelectDB)
+	}
+	if opt.BlockingPoolSize != 20 || opt.BlockingPoolMinSize != 5 || opt.BlockingPoolCleanup != defaultPoolCleanup {
+		t.Errorf("pool = %d/%d/%v, want 20/5/%v", opt.BlockingPoolSize, opt.BlockingPoolMinSize,
+			opt.BlockingPoolCleanup, defaultPoolCleanup)
+	}
+}
+
+func TestClientOption_SingleNode(t *testing.T) {
+	opt, err := clientOption(&config.ValkeyConfig{Address: "localhost:6379", DB: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opt.InitAddress) != 1 || opt.InitAddress[0] != "localhost:6379" || opt.SelectDB != 2 {
+		t.Errorf("opt = %v db %d, want single node db 2", opt.InitAddress, opt.SelectDB)
+	}
+	if opt.BlockingPoolCleanup != 0 {
+		t.Errorf("BlockingPoolCleanup = %v, want unset without MinIdleConns", opt.BlockingPoolCleanup)
+	}
+}
+
+func TestClusterClient_SatisfiesStore(t *testing.T) {
+	_, vc := newMockClusterClient(t)
+	var _ Store = vc
+	if !vc.IsCluster() {
+		t.Fatal("IsCluster() = false, want true")
+	}
+}
+
+func TestClusterClient_StoreOperations(t *testing.T) {
+	client, vc := newMockClusterClient(t)
+	ctx := context.Background()
+
+	client.EXPECT().Do(ctx, mock.Match("SET", "lock", "v", "NX", "EX", "30")).Return(mock.Result(mock.ValkeyString("OK")))
+	client.EXPECT().Do(ctx, mock.Match("SET", "k", "v", "EX", "60")).Return(mock.Result(mock.ValkeyString("OK")))
+	client.EXPECT().Do(ctx, mock.Match("GET", "k")).Return(mock.Result(mock.ValkeyString("v")))
+
+	if ok, err := vc.SetNX(ctx, "lock", "v", 30*time.Second); err != nil || !ok {
+		t.Fatalf("SetNX = %v, %v", ok, err)
+	}
+	if err := vc.SetString(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if got, err := vc.GetString(ctx, "k"); err != nil || got != "v" {
+		t.Fatalf("GetString = %q, %v", got, err)
+	}
+}
+
+func TestClusterClient_DeleteSplitsKeysAcrossSlots(t *testing.T) {
+	client, vc := newMockClusterClient(t)
+	ctx := context.Background()
+
+	// A single multi-key DEL across slots would fail the slot check with CROSSSLOT
+	client.EXPECT().DoMulti(ctx, mock.Match("DEL", "a"), mock.Match("DEL", "b")).
+		Return([]valkey.ValkeyResult{mock.Result(mock.ValkeyInt64(1)), mock.Result(mock.ValkeyInt64(1))})
+
+	if err := vc.Delete(ctx, "a", "b"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+}
+
+func TestClusterClient_Subscribe(t *testing.T) {
+	client, vc := newMockClusterClient(t)
+	ctx := context.Background()
+
+	client.EXPECT().Receive(ctx, mock.Match("SUBSCRIBE", "events"), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ valkey.Completed, fn func(valkey.PubSubMessage)) error {
+			fn(valkey.PubSubMessage{Channel: "events", Message: "hello"})
+			return nil
+		})
+
+	var got string
+	if err := vc.Subscribe(ctx, []string{"events"}, func(channel, message string) {
+		got = channel + ":" + message
+	}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if got != "events:hello" {
+		t.Errorf("handler got %q, want events:hello", got)
+	}
+}
+
+func TestClusterClient_PingNodes(t *testing.T) {
+	client, vc := newMockClusterClient(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	up := mock.NewClient(ctrl)
+	up.EXPECT().Do(ctx, mock.Match("PING")).Return(mock.Result(mock.ValkeyString("PONG")))
+	down := mock.NewClient(ctrl)
+	down.EXPECT().Do(ctx, mock.Match("PING")).Return(mock.ErrorResult(errors.New("connection refused")))
+	client.EXPECT().Nodes().Return(map[string]valkey.Client{"n1:7000": up, "n2:7000": down})
+
+	results := vc.PingNodes(ctx)
+	if len(results) != 2 {
+		t.Fatalf("results = %v, want 2 nodes", results)
+	}
+	if results["n1:7000"] != nil {
+		t.Errorf("n1 = %v, want nil", results["n1:7000"])
+	}
+	if results["n2:7000"] == nil {
+		t.Error("n2 should report an error")
+	}
+}
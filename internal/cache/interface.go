@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// CacheClient is the cache API used by the deploy batcher, uplink cache and connectivity
+// results. ValkeyClient implements it; NoOpCacheClient stands in when Valkey is unavailable
+// and cachetest.NewInMemoryCache in unit tests.
+type CacheClient interface {
+	Store
+
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	AcquireLock(ctx context.Context, key string, value string, ttl time.Duration) (func() error, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	IncrByEx(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error)
+}
+
+var _ CacheClient = (*ValkeyClient)(nil)
+
+// Default returns the global Valkey client, or a NoOpCacheClient if Initialize failed or
+// was not called. Use it instead of Client where a CacheClient is expected, so a missing
+// Valkey is never passed on as a nil *ValkeyClient.
+func Default() CacheClient {
+	if Client == nil {
+		return NoOpCacheClient{}
+	}
+	return Client
+}
+
+// NoOpCacheClient is a cache that stores nothing. Reads miss, writes are discarded, and
+// locks are always granted, as for a single instance without Valkey.
+type NoOpCacheClient struct{}
+
+var _ CacheClient = NoOpCacheClient{}
+
+func (NoOpCacheClient) SetNX(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (NoOpCacheClient) ReleaseLock(context.Context, string, string) error { return nil }
+
+func (NoOpCacheClient) ExtendLockIfOwner(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (NoOpCacheClient) SetString(context.Context, string, string, time.Duration) error { return nil }
+
+func (NoOpCacheClient) GetString(context.Context, string) (string, error) {
+	return "", ErrKeyNotFound
+}
+
+func (NoOpCacheClient) Delete(context.Context, ...string) error { return nil }
+
+func (NoOpCacheClient) Publish(context.Context, string, string) error { return nil }
+
+// Subscribe confirms the subscription right away and blocks until ctx is cancelled; no
+// messages are ever delivered
+func (NoOpCacheClient) Subscribe(ctx context.Context, _ []string, _ func(channel, message string)) error {
+	if subscribed := SubscribedHook(ctx); subscribed != nil {
+		subscribed()
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (NoOpCacheClient) Get(context.Context, string, interface{}) error { return ErrCacheMiss }
+
+func (NoOpCacheClient) Set(context.Context, string, interface{}, time.Duration) error { return nil }
+
+func (NoOpCacheClient) AcquireLock(context.Context, string, string, time.Duration) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+func (NoOpCacheClient) Expire(context.Context, string, time.Duration) error { return nil }
+
+func (NoOpCacheClient) SAdd(context.Context, string, ...string) error { return nil }
+
+func (NoOpCacheClient) SMembers(context.Context, string) ([]string, error) { return nil, nil }
+
+// IncrByEx always reports a first increment with the full TTL remaining
+func (NoOpCacheClient) IncrByEx(_ context.Context, _ string, ttl time.Duration) (int64, time.Duration, error) {
+	return 1, ttl, nil
+}
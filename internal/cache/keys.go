@@ -1,11 +1,14 @@
 package cache
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/banglin/go-nd/internal/metrics"
 )
 
 // Key prefix - simple app-level prefix
@@ -25,24 +28,31 @@ const (
 	domainDB     = "db"
 	domainIdempo = "idempo"
 	domainCache  = "cachekeys"
+	domainNode   = "node"
 )
 
 // Default TTLs
 const (
-	TTLAuthToken      = 55 * time.Minute // assuming 1hr token, minus buffer
-	TTLAuthLoginLock  = time.Minute
-	TTLFabrics        = 5 * time.Minute
-	TTLSwitches       = 2 * time.Minute
-	TTLPorts          = time.Minute
-	TTLSecurityGroups = time.Minute
-	TTLContracts      = time.Minute
-	TTLProtocols      = 5 * time.Minute
-	TTLAssociations   = 30 * time.Second
-	TTLIdempotency    = 30 * time.Minute
-	TTLLock           = 2 * time.Minute
-	TTLLease          = time.Minute
-	TTLJobStatus      = 5 * time.Minute
-	TTLDBLookup       = 5 * time.Minute
+	TTLAuthToken         = 55 * time.Minute // assuming 1hr token, minus buffer
+	TTLAuthLoginLock     = time.Minute
+	TTLFabrics           = 5 * time.Minute
+	TTLSwitches          = 2 * time.Minute
+	TTLPorts             = time.Minute
+	TTLSecurityGroups    = time.Minute
+	TTLContracts         = time.Minute
+	TTLProtocols         = 5 * time.Minute
+	TTLAssociations      = 30 * time.Second
+	TTLIdempotency       = 30 * time.Minute
+	TTLIdempotencyLock   = 30 * time.Second
+	TTLJobSubmission     = 30 * time.Second
+	TTLLock              = 2 * time.Minute
+	TTLLease             = time.Minute
+	TTLJobStatus         = 5 * time.Minute
+	TTLDBLookup          = 5 * time.Minute
+	TTLConnectivityCheck = 5 * time.Minute
+	TTLAttachments       = 5 * time.Minute
+	TTLSharedAssociation = 30 * time.Minute
+	TTLJobPruneLastRun   = 90 * 24 * time.Hour // Well past the 30-day prune interval
 )
 
 // Auth keys
@@ -89,6 +99,26 @@ func Port(fabricName, switchID, portID string) string {
 	return fmt.Sprintf("%s:%s:port:%s:%s:%s", keyPrefix, domainLAN, fabricName, switchID, portID)
 }
 
+// Uplinks returns the key for a fabric's uplink (inter-switch link) ports
+func Uplinks(fabricName string) string {
+	return fmt.Sprintf("%s:%s:uplinks:%s", keyPrefix, domainLAN, fabricName)
+}
+
+// InvalidateUplinkCache removes the cached uplink ports for a fabric from the global cache
+// client, so the next lookup fetches them from NDFC
+func InvalidateUplinkCache(ctx context.Context, fabricName string) error {
+	if err := Default().Delete(ctx, Uplinks(fabricName)); err != nil {
+		return err
+	}
+	metrics.UplinkPortsCached.WithLabelValues(fabricName).Set(0)
+	return nil
+}
+
+// Attachments returns the key for a network's switch attachments in NDFC
+func Attachments(fabricName, networkName string) string {
+	return fmt.Sprintf("%s:%s:attachments:%s:%s", keyPrefix, domainLAN, fabricName, networkName)
+}
+
 // Security keys
 
 // SecurityGroups returns the key for security groups in a fabric
@@ -121,6 +151,12 @@ func Associations(fabric string) string {
 	return fmt.Sprintf("%s:%s:associations:%s", keyPrefix, domainSec, fabric)
 }
 
+// SharedAssociation returns the key marking a Slurm job's shared contract association as
+// created, so a retried provisioning of the job skips it
+func SharedAssociation(fabric, slurmJobID, srcGroup, dstGroup, contract string) string {
+	return fmt.Sprintf("%s:%s:assoc:%s:%s:%s:%s:%s", keyPrefix, domainSec, fabric, slurmJobID, srcGroup, dstGroup, contract)
+}
+
 // Cache invalidation keys
 
 // FabricCacheKeys returns the key for the set of cache keys to invalidate for a fabric
@@ -135,6 +171,23 @@ func IdempotencyKey(operation string, payloadHash string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", keyPrefix, domainIdempo, operation, payloadHash)
 }
 
+// IdempotencyRequest returns the lock held while the request with a client's Idempotency-Key
+// header runs
+func IdempotencyRequest(key string) string {
+	return fmt.Sprintf("%s:%s:idempotency:%s:lock", keyPrefix, domainIdempo, key)
+}
+
+// IdempotencyResponse returns the key of the cached response to the request with a client's
+// Idempotency-Key header
+func IdempotencyResponse(key string) string {
+	return fmt.Sprintf("%s:%s:idempotency:%s:response", keyPrefix, domainIdempo, key)
+}
+
+// JobSubmission returns the idempotency key claimed while a Slurm job is being submitted
+func JobSubmission(slurmJobID string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", keyPrefix, domainIdempo, domainJob, slurmJobID)
+}
+
 // Lock keys
 
 // LockSecurityGroup returns the lock key for a security group
@@ -184,6 +237,11 @@ func JobLastEvent(jobID string) string {
 	return fmt.Sprintf("%s:%s:%s:lastEvent", keyPrefix, domainJob, jobID)
 }
 
+// JobEventsChannel returns the pub/sub channel on which a Slurm job's status changes are published
+func JobEventsChannel(slurmJobID string) string {
+	return fmt.Sprintf("%s:events:%s", domainJob, slurmJobID)
+}
+
 // Lease/worker keys
 
 // JobLease returns the key for a job lease
@@ -196,6 +254,11 @@ func WorkerHeartbeat(workerID string) string {
 	return fmt.Sprintf("%s:%s:%s:hb", keyPrefix, domainWorker, workerID)
 }
 
+// JobPruneLastRun returns the key holding when the sync leader last pruned jobs past retention
+func JobPruneLastRun() string {
+	return fmt.Sprintf("%s:%s:jobPrune:lastRun", keyPrefix, domainWorker)
+}
+
 // Rate limiting keys
 
 // RateLimit returns the key for rate limiting an endpoint
@@ -220,6 +283,52 @@ func DBContractByName(fabric, name string) string {
 	return fmt.Sprintf("%s:%s:contractByName:%s:%s", keyPrefix, domainDB, fabric, name)
 }
 
+// Compute node keys
+
+// ConnectivityCheck returns the key for a compute node's last connectivity check result
+func ConnectivityCheck(nodeID string) string {
+	return fmt.Sprintf("%s:%s:%s:connectivity", keyPrefix, domainNode, nodeID)
+}
+
+// Deploy batch keys. These predate keyPrefix and keep their format, so batches queued by
+// instances running an older version are still found.
+
+// DeployBatchStart returns the key holding the ID of a fabric's pending deploy batch, set by
+// the DeployBatcher with the batch's first request
+func DeployBatchStart(fabricName string) string {
+	return fmt.Sprintf("deploy:batch:%s:start", fabricName)
+}
+
+// DeployBatchStartPattern matches the DeployBatchStart keys of all fabrics
+func DeployBatchStartPattern() string {
+	return DeployBatchStart("*")
+}
+
+// DeployBatchLast returns the key holding when a fabric's pending deploy batch last got a request
+func DeployBatchLast(fabricName string) string {
+	return fmt.Sprintf("deploy:batch:%s:last", fabricName)
+}
+
+// DeployBatchLock returns the lock held by the instance executing a fabric's deploy
+func DeployBatchLock(fabricName string) string {
+	return fmt.Sprintf("deploy:batch:%s:lock", fabricName)
+}
+
+// DeployBatchResult returns the key holding the result of a deploy batch ("ok" or an error message)
+func DeployBatchResult(fabricName, batchID string) string {
+	return fmt.Sprintf("deploy:batch:%s:result:%s", fabricName, batchID)
+}
+
+// DeployBatchSerials returns the set of switch serial numbers requested in a deploy batch
+func DeployBatchSerials(fabricName, batchID string) string {
+	return fmt.Sprintf("deploy:batch:%s:serials:%s", fabricName, batchID)
+}
+
+// DeployBatchRequests returns the key counting the requests of a deploy batch
+func DeployBatchRequests(fabricName, batchID string) string {
+	return fmt.Sprintf("deploy:batch:%s:requests:%s", fabricName, batchID)
+}
+
 // Helper functions
 
 // HashPayload creates a SHA256 hash of a payload for idempotency keys
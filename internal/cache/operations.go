@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/valkey-io/valkey-go"
@@ -86,6 +87,33 @@ func (v *ValkeyClient) ExtendLock(ctx context.Context, key string, ttl time.Dura
 	return nil
 }
 
+// ExtendLockIfOwner resets a lock's TTL only if value still owns it.
+// Returns false if the lock expired or is now held by someone else.
+func (v *ValkeyClient) ExtendLockIfOwner(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	// Lua script: extend only if value matches (atomic)
+	script := `if redis.call("get",KEYS[1]) == ARGV[1] then return redis.call("pexpire",KEYS[1],ARGV[2]) else return 0 end`
+	cmd := v.client.B().Eval().Script(script).Numkeys(1).Key(key).Arg(value, strconv.FormatInt(ttl.Milliseconds(), 10)).Build()
+	result, err := v.client.Do(ctx, cmd).ToInt64()
+	if err != nil {
+		// Fallback for NOPERM or Lua not supported: check-then-extend (not atomic)
+		currentValue, getErr := v.GetString(ctx, key)
+		if getErr != nil {
+			if errors.Is(getErr, ErrKeyNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("extend lock %s: %w", key, getErr)
+		}
+		if currentValue != value {
+			return false, nil
+		}
+		cmd := v.client.B().Pexpire().Key(key).Milliseconds(ttl.Milliseconds()).Build()
+		if result, err = v.client.Do(ctx, cmd).ToInt64(); err != nil {
+			return false, fmt.Errorf("extend lock %s: %w", key, err)
+		}
+	}
+	return result == 1, nil
+}
+
 // SAdd adds members to a set
 func (v *ValkeyClient) SAdd(ctx context.Context, key string, members ...string) error {
 	cmd := v.client.B().Sadd().Key(key).Member(members...).Build()
@@ -185,9 +213,9 @@ func (v *ValkeyClient) Incr(ctx context.Context, key string) (int64, error) {
 	return v.client.Do(ctx, cmd).ToInt64()
 }
 
-// IncrWithTTL increments a counter and sets TTL if it's a new key.
+// IncrByEx increments a counter and sets TTL if it's a new key.
 // Returns current count and remaining TTL (for rate limiting).
-func (v *ValkeyClient) IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
+func (v *ValkeyClient) IncrByEx(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
 	// Use a Lua script to atomically INCR, set TTL on new keys, and return both count and PTTL
 	script := `
 		local current = redis.call('INCR', KEYS[1])
@@ -286,3 +314,30 @@ func (v *ValkeyClient) SetString(ctx context.Context, key, value string, ttl tim
 	cmd := v.client.B().Set().Key(key).Value(value).Ex(ttl).Build()
 	return v.client.Do(ctx, cmd).Error()
 }
+
+// Publish sends a message to a pub/sub channel
+func (v *ValkeyClient) Publish(ctx context.Context, channel, message string) error {
+	cmd := v.client.B().Publish().Channel(channel).Message(message).Build()
+	return v.client.Do(ctx, cmd).Error()
+}
+
+// Subscribe listens on channels and calls handler for each message.
+// Blocks until ctx is cancelled or the subscription fails.
+// In cluster mode messages are delivered by whichever node the client subscribes through.
+func (v *ValkeyClient) Subscribe(ctx context.Context, channels []string, handler func(channel, message string)) error {
+	if subscribed := SubscribedHook(ctx); subscribed != nil {
+		// Confirmations arrive one per channel on the connection's reader
+		remaining := len(channels)
+		ctx = valkey.WithOnSubscriptionHook(ctx, func(s valkey.PubSubSubscription) {
+			if s.Kind == "subscribe" {
+				if remaining--; remaining == 0 {
+					subscribed()
+				}
+			}
+		})
+	}
+	cmd := v.client.B().Subscribe().Channel(channels...).Build()
+	return v.client.Receive(ctx, cmd, func(msg valkey.PubSubMessage) {
+		handler(msg.Channel, msg.Message)
+	})
+}
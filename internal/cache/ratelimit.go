@@ -22,8 +22,8 @@ type RateLimitResult struct {
 // CheckRateLimit checks if a request is allowed under the rate limit
 // Returns the result and whether the request should proceed
 func (v *ValkeyClient) CheckRateLimit(ctx context.Context, key string, limit int64, window time.Duration) (*RateLimitResult, error) {
-	// IncrWithTTL returns count and remaining TTL atomically (single RTT)
-	current, remainingTTL, err := v.IncrWithTTL(ctx, key, window)
+	// IncrByEx returns count and remaining TTL atomically (single RTT)
+	current, remainingTTL, err := v.IncrByEx(ctx, key, window)
 	if err != nil {
 		return nil, fmt.Errorf("rate limit check: %w", err)
 	}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store is the subset of ValkeyClient used for locks, raw values and pub/sub.
+// It behaves the same whether the client is connected to a single node or a cluster.
+type Store interface {
+	SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	ReleaseLock(ctx context.Context, key string, value string) error
+	ExtendLockIfOwner(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	SetString(ctx context.Context, key, value string, ttl time.Duration) error
+	GetString(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
+	Publish(ctx context.Context, channel, message string) error
+	Subscribe(ctx context.Context, channels []string, handler func(channel, message string)) error
+}
+
+var _ Store = (*ValkeyClient)(nil)
+
+type subscribedKey struct{}
+
+// WithSubscribed returns a copy of ctx that makes Store.Subscribe call subscribed once, after
+// the server confirmed every channel. Messages published from then on are delivered.
+func WithSubscribed(ctx context.Context, subscribed func()) context.Context {
+	return context.WithValue(ctx, subscribedKey{}, subscribed)
+}
+
+// SubscribedHook returns the callback set with WithSubscribed, or nil. Store implementations
+// call it once they are subscribed.
+func SubscribedHook(ctx context.Context) func() {
+	hook, _ := ctx.Value(subscribedKey{}).(func())
+	return hook
+}
@@ -15,11 +15,40 @@ import (
 var ErrCacheMiss = errors.New("cache miss")
 
 type ValkeyClient struct {
-	client valkey.Client
+	client  valkey.Client
+	cluster bool // Multi-key commands are split per key, SCAN visits every node
 }
 
 var Client *ValkeyClient
 
+// defaultPoolCleanup is how often idle pooled connections above MinIdleConns are closed
+const defaultPoolCleanup = 5 * time.Minute
+
+// clientOption builds the valkey-go options for single-node or cluster mode
+func clientOption(cfg *config.ValkeyConfig) (valkey.ClientOption, error) {
+	opt := valkey.ClientOption{
+		InitAddress:      []string{cfg.Address},
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SelectDB:         cfg.DB,
+		BlockingPoolSize: cfg.PoolSize,
+	}
+	if cfg.MinIdleConns > 0 {
+		opt.BlockingPoolMinSize = cfg.MinIdleConns
+		opt.BlockingPoolCleanup = defaultPoolCleanup
+	}
+
+	if cfg.ClusterMode {
+		if len(cfg.ClusterAddresses) == 0 {
+			return opt, errors.New("valkey cluster mode is enabled (VALKEY_CLUSTER_MODE=true) but VALKEY_CLUSTER_ADDRESSES is empty")
+		}
+		opt.InitAddress = cfg.ClusterAddresses
+		opt.SelectDB = 0 // Cluster mode only has database 0
+		opt.ShuffleInit = true
+	}
+	return opt, nil
+}
+
 func Initialize(cfg *config.ValkeyConfig) error {
 	// Close existing client if re-initializing
 	if Client != nil {
@@ -27,12 +56,12 @@ func Initialize(cfg *config.ValkeyConfig) error {
 		Client = nil
 	}
 
-	client, err := valkey.NewClient(valkey.ClientOption{
-		InitAddress: []string{cfg.Address},
-		Username:    cfg.Username,
-		Password:    cfg.Password,
-		SelectDB:    cfg.DB,
-	})
+	opt, err := clientOption(cfg)
+	if err != nil {
+		return err
+	}
+
+	client, err := valkey.NewClient(opt)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Valkey: %w", err)
 	}
@@ -43,10 +72,36 @@ func Initialize(cfg *config.ValkeyConfig) error {
 		return fmt.Errorf("valkey ping failed: %w", err)
 	}
 
-	Client = &ValkeyClient{client: client}
+	if cfg.ClusterMode && client.Mode() != valkey.ClientModeCluster {
+		client.Close()
+		return fmt.Errorf("valkey cluster mode is enabled but %v is not a cluster", cfg.ClusterAddresses)
+	}
+
+	Client = NewValkeyClient(client)
 	return nil
 }
 
+// NewValkeyClient wraps a connected valkey-go client, detecting cluster mode from the
+// client. Initialize uses it for the global Client.
+func NewValkeyClient(client valkey.Client) *ValkeyClient {
+	return &ValkeyClient{client: client, cluster: client.Mode() == valkey.ClientModeCluster}
+}
+
+// IsCluster reports whether the client is connected to a Valkey/Redis cluster
+func (v *ValkeyClient) IsCluster() bool {
+	return v.cluster
+}
+
+// PingNodes pings each shard of a cluster (or the single node) and returns
+// the error per node address; a nil error means the node answered.
+func (v *ValkeyClient) PingNodes(ctx context.Context) map[string]error {
+	results := make(map[string]error)
+	for addr, node := range v.client.Nodes() {
+		results[addr] = node.Do(ctx, node.B().Ping().Build()).Error()
+	}
+	return results
+}
+
 func (v *ValkeyClient) Close() {
 	v.client.Close()
 }
@@ -86,9 +141,27 @@ func (v *ValkeyClient) Get(ctx context.Context, key string, dest interface{}) er
 	return json.Unmarshal([]byte(str), dest)
 }
 
+// Delete removes keys. In cluster mode keys may live in different slots,
+// so each key is deleted with its own DEL (pipelined) instead of one multi-key DEL.
 func (v *ValkeyClient) Delete(ctx context.Context, keys ...string) error {
-	cmd := v.client.B().Del().Key(keys...).Build()
-	return v.client.Do(ctx, cmd).Error()
+	if len(keys) == 0 {
+		return nil
+	}
+	if !v.cluster || len(keys) == 1 {
+		cmd := v.client.B().Del().Key(keys...).Build()
+		return v.client.Do(ctx, cmd).Error()
+	}
+
+	cmds := make(valkey.Commands, len(keys))
+	for i, key := range keys {
+		cmds[i] = v.client.B().Del().Key(key).Build()
+	}
+	for _, res := range v.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (v *ValkeyClient) Exists(ctx context.Context, key string) (bool, error) {
@@ -106,12 +179,61 @@ func (v *ValkeyClient) SetWithTTL(ctx context.Context, key string, value interfa
 
 // InvalidatePattern deletes all keys matching the pattern using SCAN (not KEYS).
 // SCAN is O(1) per call and won't block Valkey on large keyspaces.
+// In cluster mode every node is scanned, since each only holds its own slots.
 func (v *ValkeyClient) InvalidatePattern(ctx context.Context, pattern string) error {
+	if !v.cluster {
+		return v.invalidatePatternOn(ctx, v.client, pattern)
+	}
+	for addr, node := range v.client.Nodes() {
+		if err := v.invalidatePatternOn(ctx, node, pattern); err != nil {
+			return fmt.Errorf("node %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// CountPattern counts keys matching the pattern using SCAN, across every node in cluster mode
+func (v *ValkeyClient) CountPattern(ctx context.Context, pattern string) (int64, error) {
+	if !v.cluster {
+		return countPatternOn(ctx, v.client, pattern)
+	}
+	var total int64
+	for addr, node := range v.client.Nodes() {
+		n, err := countPatternOn(ctx, node, pattern)
+		if err != nil {
+			return 0, fmt.Errorf("node %s: %w", addr, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// countPatternOn scans one node (or the single-node client) and counts matching keys
+func countPatternOn(ctx context.Context, node valkey.Client, pattern string) (int64, error) {
+	var cursor uint64
+	var count int64
+	for {
+		cmd := node.B().Scan().Cursor(cursor).Match(pattern).Count(1000).Build()
+		scanEntry, err := node.Do(ctx, cmd).AsScanEntry()
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(scanEntry.Elements))
+
+		cursor = scanEntry.Cursor
+		if cursor == 0 {
+			return count, nil
+		}
+	}
+}
+
+// invalidatePatternOn scans one node (or the single-node client) and deletes matching keys
+func (v *ValkeyClient) invalidatePatternOn(ctx context.Context, node valkey.Client, pattern string) error {
 	var cursor uint64
 
 	for {
-		cmd := v.client.B().Scan().Cursor(cursor).Match(pattern).Count(1000).Build()
-		res := v.client.Do(ctx, cmd)
+		cmd := node.B().Scan().Cursor(cursor).Match(pattern).Count(1000).Build()
+		res := node.Do(ctx, cmd)
 		if res.Error() != nil {
 			return res.Error()
 		}
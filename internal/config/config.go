@@ -1,13 +1,27 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// hotReloadKeys are the settings Reload re-reads from the .env file
+var hotReloadKeys = []string{"ND_SHARED_CONTRACTS", "ND_STORAGE_SHARED_CONTRACTS"}
+
+// explicitEnv holds the hot-reloadable keys set in the process environment before the .env
+// file was loaded; as with the initial load, the .env file never overrides them
+var explicitEnv = map[string]bool{}
+
 func init() {
+	for _, key := range hotReloadKeys {
+		if _, ok := os.LookupEnv(key); ok {
+			explicitEnv[key] = true
+		}
+	}
 	// Load .env file if it exists (ignore error if not found)
 	_ = godotenv.Load()
 }
@@ -19,6 +33,11 @@ type Config struct {
 	NexusDashboard NexusDashboardConfig
 	VCenter        VCenterConfig
 	GRPC           GRPCConfig
+	Tracing        TracingConfig
+
+	// SecretError reports a failure to load secrets from the SECRET_STORE; affected values
+	// keep their defaults. Callers should fail startup on it.
+	SecretError error
 }
 
 type ServerConfig struct {
@@ -28,12 +47,42 @@ type ServerConfig struct {
 	EnableGRPC bool   // Enable gRPC server
 	EnableSync bool   // Enable background sync worker
 	InstanceID string // Unique instance ID for distributed locking (auto-generated if empty)
+	// MaxProvisionTimeoutMinutes caps the per-job provisioning timeout a submitter may request
+	MaxProvisionTimeoutMinutes int
+	// SlowRequestThresholdMS is the HTTP request duration above which a request is logged as slow
+	SlowRequestThresholdMS int
+	// LogSlowRequestBodies logs the first 1 KB of slow POST/PUT request bodies, secrets masked
+	LogSlowRequestBodies bool
+	// RetentionDays is how long completed and failed jobs are kept before they are pruned
+	RetentionDays int
+	// EndpointTimeoutDefaultSec is the HTTP request timeout for endpoints not in EndpointTimeoutsFile
+	EndpointTimeoutDefaultSec int
+	// EndpointTimeoutsFile is a JSON file of per-endpoint timeouts in seconds (e.g. {"/api/v1/fabrics/sync": 600})
+	EndpointTimeoutsFile string
+	// MetricsPort serves /metrics on its own listener; empty serves it on the API port
+	MetricsPort string
+	// ShutdownTimeoutSec is how long the HTTP server waits for in-flight requests on SIGTERM
+	ShutdownTimeoutSec int
 }
 
 type GRPCConfig struct {
-	Port       string
-	AuthToken  string
-	Reflection bool
+	Port               string
+	AuthToken          string
+	Reflection         bool
+	MethodTimeoutsFile string // JSON file of per-method timeouts (e.g. {"/go_nd.v1.FabricsService/SyncFabrics": "10m"})
+	DefaultTimeoutSec  int    // Timeout for methods not listed in MethodTimeoutsFile
+
+	ReflectedServices []string // Services reflection lists and describes when Reflection is on (empty = all)
+
+	MaxRecvMsgSizeMB       int // Largest request the server accepts
+	MaxSendMsgSizeMB       int // Largest response the server sends (e.g. SyncPorts on large fabrics)
+	KeepaliveMaxAgeMinutes int // Close connections after this long (0 = never)
+	KeepaliveGraceSeconds  int // Time in-flight calls get to finish once a connection is closed
+
+	Compression string // Codec clients may compress calls with: "none", "gzip" or "zstd"
+
+	GatewayEnabled bool   // Serve the gRPC API as HTTP/JSON (grpc-gateway) alongside gRPC; off by default
+	GatewayPort    string // HTTP/JSON gateway port
 }
 
 type DatabaseConfig struct {
@@ -50,21 +99,50 @@ type DatabaseConfig struct {
 }
 
 type NexusDashboardConfig struct {
-	BaseURL               string
-	Username              string
-	Password              string
-	APIKey                string // Takes priority over username/password if set
-	Insecure              bool
-	ComputeFabricName     string
-	ComputeVRFName        string
-	ComputeNetworkName    string // Network name for security group selection
-	ComputeAccessVLAN     string // Default access VLAN for compute interfaces (fallback if not in mapping)
-	ComputeContractPrefix string // Optional prefix for job-specific contract names
-	StorageFabricName     string // Fabric for storage NIC provisioning
-	StorageVRFName        string // VRF for storage NIC provisioning
-	StorageNetworkName    string // Default/idle storage network (nodes attach here when not in a job)
-	VMFabricName          string // VRF is per-tenant, not global
-	SyncIntervalHours     int    // Interval for background sync of fabrics/switches/ports (0 = disabled)
+	BaseURL                 string
+	Username                string
+	Password                string
+	APIKey                  string // Takes priority over username/password if set
+	Insecure                bool
+	ComputeFabricName       string
+	ComputeVRFName          string
+	ComputeNetworkName      string // Network name for security group selection
+	ComputeAccessVLAN       string // Default access VLAN for compute interfaces (fallback if not in mapping)
+	ComputeContractPrefix   string // Optional prefix for job-specific contract names
+	StorageFabricName       string // Fabric for storage NIC provisioning
+	StorageVRFName          string // VRF for storage NIC provisioning
+	StorageNetworkName      string // Default/idle storage network (nodes attach here when not in a job)
+	VMFabricName            string // VRF is per-tenant, not global
+	SyncIntervalHours       int    // Interval for background sync of fabrics/switches/ports (0 = disabled)
+	DeployPollMS            int    // Deploy batcher coordinator poll interval in ms (0 = default 500ms)
+	DeployResultPollMS      int    // Deploy batcher result watcher poll interval in ms (0 = default 2s)
+	SharedContracts         string // Job shared contracts as "dstGroup:contract,..." (empty = built-in defaults)
+	StorageSharedContracts  string // Storage shared contracts as "dstGroup:contract,..." (empty = built-in defaults)
+	BMCPowerCycleCmd        string // External command run with the BMC address as last argument (empty = disabled)
+	BMCPowerCycleTimeoutSec int    // Timeout for the power-cycle command in seconds
+	UplinkCacheTTLMinutes   int    // TTL for cached per-fabric uplink ports in Valkey
+	PortDescriptionTemplate string // text/template for access port descriptions, evaluated with the provision input
+	JobContractProtocol     string // Protocol of the job contract's self-association rule ("default" = all traffic)
+	PortNameFormat          string // Displayed switch port names: "long" (NDFC, Ethernet1/1) or "short" (Eth1/1)
+
+	ConfigSaveBeforeDeploy bool     // Run config-save before config-deploy on every fabric
+	ConfigSaveFabrics      []string // Fabrics that need config-save before config-deploy when ConfigSaveBeforeDeploy is off
+
+	MaxPortsPerNetwork int // Max ports attached to one network by provisioning (0 = unlimited)
+
+	AutoDecommissionDays   int  // Decommission nodes not seen on a port sync for this many days (0 = disabled)
+	AutoDecommissionDryRun bool // Only log the nodes auto-decommission would mark
+
+	// HTTP client settings for NDFC requests
+	HTTPProxy                    string // Proxy URL (empty = HTTPS_PROXY/NO_PROXY environment)
+	TLSMinVersion                string // TLS12 or TLS13 (empty = Go default, TLS 1.2)
+	IdleConnTimeoutSeconds       int    // How long idle connections are kept open
+	ResponseHeaderTimeoutSeconds int    // Max wait for response headers after sending a request (0 = client timeout only)
+	MaxIdleConnsPerHost          int    // Idle connections kept open to NDFC
+	RetryMaxRetries              int    // Retries of requests failing with 429/503, or 502/504 for idempotent ones (0 = none)
+	RetryBaseDelayMS             int    // Wait before the first retry in ms, doubled per retry, with jitter
+	RetryMaxDelayMS              int    // Longest wait between retries in ms
+	CircuitBreakerDisabled       bool   // Send every request instead of failing fast after repeated errors
 }
 
 type VCenterConfig struct {
@@ -74,15 +152,33 @@ type VCenterConfig struct {
 	Insecure bool
 }
 
+// TracingConfig configures OpenTelemetry trace export
+type TracingConfig struct {
+	OTLPEndpoint string // OTLP/HTTP collector URL (e.g. http://otel-collector:4318); empty disables tracing
+	ServiceName  string
+}
+
 type ValkeyConfig struct {
 	Address  string
 	Username string
 	Password string
 	DB       int
+
+	ClusterMode      bool     // Connect to a Valkey/Redis cluster instead of a single node
+	ClusterAddresses []string // Seed node addresses for cluster mode
+	PoolSize         int      // Max pooled connections for blocking commands (0 = client default)
+	MinIdleConns     int      // Idle pooled connections kept open (0 = no minimum)
 }
 
 func Load() *Config {
-	return &Config{
+	var secretErrs []error
+	secrets, err := NewSecureStore()
+	if err != nil {
+		secretErrs = append(secretErrs, err)
+		secrets = EnvSecureStore{}
+	}
+
+	cfg := &Config{
 		Server: ServerConfig{
 			Port:       getEnv("SERVER_PORT", "8080"),
 			Mode:       getEnv("GIN_MODE", "debug"),
@@ -90,11 +186,35 @@ func Load() *Config {
 			EnableGRPC: getEnvBool("ENABLE_GRPC", false),
 			EnableSync: getEnvBool("ENABLE_SYNC", true),
 			InstanceID: getEnv("INSTANCE_ID", ""),
+
+			MaxProvisionTimeoutMinutes: getEnvInt("MAX_PROVISION_TIMEOUT_MINUTES", 60),
+			SlowRequestThresholdMS:     SlowRequestThresholdMS(),
+			LogSlowRequestBodies:       getEnvBool("LOG_SLOW_REQUEST_BODIES", false),
+			RetentionDays:              getEnvInt("JOB_RETENTION_DAYS", 365),
+			EndpointTimeoutDefaultSec:  getEnvInt("ENDPOINT_TIMEOUT_DEFAULT_SECONDS", 30),
+			EndpointTimeoutsFile:       getEnv("ENDPOINT_TIMEOUTS_FILE", ""),
+			MetricsPort:                getEnv("METRICS_PORT", ""),
+			ShutdownTimeoutSec:         getEnvInt("SERVER_SHUTDOWN_TIMEOUT_SECONDS", 30),
 		},
 		GRPC: GRPCConfig{
 			Port:       getEnv("GRPC_PORT", "50051"),
-			AuthToken:  getEnv("GRPC_AUTH_TOKEN", ""),
+			AuthToken:  getSecret(secrets, "GRPC_AUTH_TOKEN", "", &secretErrs),
 			Reflection: getEnvBool("GRPC_REFLECTION", true),
+
+			MethodTimeoutsFile: getEnv("GRPC_METHOD_TIMEOUTS_FILE", ""),
+			DefaultTimeoutSec:  getEnvInt("GRPC_DEFAULT_TIMEOUT_SEC", 30),
+
+			ReflectedServices: getEnvList("GRPC_REFLECTED_SERVICES"),
+
+			MaxRecvMsgSizeMB:       getEnvInt("GRPC_MAX_RECV_MSG_SIZE_MB", 16),
+			MaxSendMsgSizeMB:       getEnvInt("GRPC_MAX_SEND_MSG_SIZE_MB", 16),
+			KeepaliveMaxAgeMinutes: getEnvInt("GRPC_KEEPALIVE_MAX_AGE_MINUTES", 0),
+			KeepaliveGraceSeconds:  getEnvInt("GRPC_KEEPALIVE_GRACE_SECONDS", 30),
+
+			Compression: getEnv("GRPC_COMPRESSION", "gzip"),
+
+			GatewayEnabled: getEnvBool("ENABLE_GRPC_GATEWAY", false),
+			GatewayPort:    getEnv("GRPC_GATEWAY_PORT", "8081"),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -113,23 +233,53 @@ func Load() *Config {
 			Username: getEnv("VALKEY_USERNAME", "gond"),
 			Password: getEnv("VALKEY_PASSWORD", "gond"),
 			DB:       getEnvInt("VALKEY_DB", 0),
+
+			ClusterMode:      getEnvBool("VALKEY_CLUSTER_MODE", false),
+			ClusterAddresses: getEnvList("VALKEY_CLUSTER_ADDRESSES"),
+			PoolSize:         getEnvInt("VALKEY_POOL_SIZE", 0),
+			MinIdleConns:     getEnvInt("VALKEY_MIN_IDLE_CONNS", 0),
 		},
 		NexusDashboard: NexusDashboardConfig{
-			BaseURL:               getEnv("ND_BASE_URL", "https://nexus-dashboard.example.com"),
-			Username:              getEnv("ND_USERNAME", "admin"),
-			Password:              getEnv("ND_PASSWORD", ""),
-			APIKey:                getEnv("ND_API_KEY", ""),
-			Insecure:              getEnvBool("ND_INSECURE", false),
-			ComputeFabricName:     getEnv("ND_COMPUTE_FABRIC_NAME", ""),
-			ComputeVRFName:        getEnv("ND_COMPUTE_VRF_NAME", ""),
-			ComputeNetworkName:    getEnv("ND_COMPUTE_NETWORK_NAME", ""),
-			ComputeAccessVLAN:     getEnv("ND_COMPUTE_ACCESS_VLAN", "2301"),
-			ComputeContractPrefix: getEnv("ND_COMPUTE_CONTRACT_PREFIX", ""),
-			StorageFabricName:     getEnv("ND_STORAGE_FABRIC_NAME", ""),
-			StorageVRFName:        getEnv("ND_STORAGE_VRF_NAME", ""),
-			StorageNetworkName:    getEnv("ND_STORAGE_NETWORK_NAME", ""),
-			VMFabricName:          getEnv("ND_VM_FABRIC_NAME", ""),
-			SyncIntervalHours:     getEnvInt("ND_SYNC_INTERVAL_HOURS", 6),
+			BaseURL:                 getEnv("ND_BASE_URL", "https://nexus-dashboard.example.com"),
+			Username:                getEnv("ND_USERNAME", "admin"),
+			Password:                getSecret(secrets, "ND_PASSWORD", "", &secretErrs),
+			APIKey:                  getSecret(secrets, "ND_API_KEY", "", &secretErrs),
+			Insecure:                getEnvBool("ND_INSECURE", false),
+			ComputeFabricName:       getEnv("ND_COMPUTE_FABRIC_NAME", ""),
+			ComputeVRFName:          getEnv("ND_COMPUTE_VRF_NAME", ""),
+			ComputeNetworkName:      getEnv("ND_COMPUTE_NETWORK_NAME", ""),
+			ComputeAccessVLAN:       getEnv("ND_COMPUTE_ACCESS_VLAN", "2301"),
+			ComputeContractPrefix:   getEnv("ND_COMPUTE_CONTRACT_PREFIX", ""),
+			StorageFabricName:       getEnv("ND_STORAGE_FABRIC_NAME", ""),
+			StorageVRFName:          getEnv("ND_STORAGE_VRF_NAME", ""),
+			StorageNetworkName:      getEnv("ND_STORAGE_NETWORK_NAME", ""),
+			VMFabricName:            getEnv("ND_VM_FABRIC_NAME", ""),
+			SyncIntervalHours:       getEnvInt("ND_SYNC_INTERVAL_HOURS", 6),
+			DeployPollMS:            getEnvInt("DEPLOY_BATCHER_POLL_MS", 500),
+			DeployResultPollMS:      getEnvInt("DEPLOY_BATCHER_RESULT_POLL_MS", 2000),
+			SharedContracts:         getEnv("ND_SHARED_CONTRACTS", ""),
+			StorageSharedContracts:  getEnv("ND_STORAGE_SHARED_CONTRACTS", ""),
+			BMCPowerCycleCmd:        getEnv("ND_BMC_POWER_CYCLE_CMD", ""),
+			BMCPowerCycleTimeoutSec: getEnvInt("ND_BMC_POWER_CYCLE_TIMEOUT_SEC", 60),
+			UplinkCacheTTLMinutes:   getEnvInt("ND_UPLINK_CACHE_TTL_MINUTES", 60),
+			PortDescriptionTemplate: getEnv("ND_PORT_DESCRIPTION_TEMPLATE", "HPC Job {{.SlurmJobID}}"),
+			JobContractProtocol:     getEnv("ND_JOB_CONTRACT_PROTOCOL", "default"),
+			PortNameFormat:          getEnv("ND_PORT_NAME_FORMAT", "long"),
+			ConfigSaveBeforeDeploy:  getEnvBool("ND_CONFIG_SAVE_BEFORE_DEPLOY", false),
+			ConfigSaveFabrics:       getEnvList("ND_CONFIG_SAVE_FABRICS"),
+			MaxPortsPerNetwork:      getEnvInt("ND_MAX_PORTS_PER_NETWORK", 0),
+			AutoDecommissionDays:    getEnvInt("ND_AUTO_DECOMMISSION_DAYS", 0),
+			AutoDecommissionDryRun:  getEnvBool("ND_AUTO_DECOMMISSION_DRY_RUN", false),
+
+			HTTPProxy:                    getEnv("ND_HTTP_PROXY", ""),
+			TLSMinVersion:                getEnv("ND_TLS_MIN_VERSION", ""),
+			IdleConnTimeoutSeconds:       getEnvInt("ND_IDLE_CONN_TIMEOUT_SECONDS", 90),
+			ResponseHeaderTimeoutSeconds: getEnvInt("ND_RESPONSE_HEADER_TIMEOUT_SECONDS", 0),
+			MaxIdleConnsPerHost:          getEnvInt("ND_MAX_IDLE_CONNS_PER_HOST", 10),
+			RetryMaxRetries:              getEnvInt("ND_RETRY_MAX_RETRIES", 3),
+			RetryBaseDelayMS:             getEnvInt("ND_RETRY_BASE_DELAY_MS", 200),
+			RetryMaxDelayMS:              getEnvInt("ND_RETRY_MAX_DELAY_MS", 5000),
+			CircuitBreakerDisabled:       getEnvBool("ND_CIRCUIT_BREAKER_DISABLED", false),
 		},
 		VCenter: VCenterConfig{
 			URL:      getEnv("VCENTER_URL", ""),
@@ -137,7 +287,39 @@ func Load() *Config {
 			Password: getEnv("VCENTER_PASSWORD", ""),
 			Insecure: getEnvBool("VCENTER_INSECURE", false),
 		},
+		Tracing: TracingConfig{
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "gond"),
+		},
 	}
+	cfg.SecretError = errors.Join(secretErrs...)
+	return cfg
+}
+
+// Reload re-reads the hot-reloadable settings (hotReloadKeys) from the .env file and returns
+// fresh configuration. Used for SIGHUP hot-reload. Keys set in the process environment keep
+// their value, and no other setting changes; a key removed from the file falls back to its
+// default.
+func Reload() *Config {
+	if values, err := godotenv.Read(); err == nil {
+		for _, key := range hotReloadKeys {
+			if explicitEnv[key] {
+				continue
+			}
+			if value, ok := values[key]; ok {
+				_ = os.Setenv(key, value)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		}
+	}
+	return Load()
+}
+
+// SlowRequestThresholdMS returns SLOW_REQUEST_THRESHOLD_MS. The metrics package reads it at
+// init for a bucket of the HTTP request duration histogram.
+func SlowRequestThresholdMS() int {
+	return getEnvInt("SLOW_REQUEST_THRESHOLD_MS", 5000)
 }
 
 func getEnv(key, defaultValue string) string {
@@ -164,3 +346,14 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma-separated env var, dropping empty entries
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
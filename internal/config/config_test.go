@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReload_OnlyHotReloadKeysFromEnvFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("ND_SHARED_CONTRACTS", "SG_AD:old")
+	t.Setenv("ND_STORAGE_SHARED_CONTRACTS", "SG_DNS:explicit")
+	t.Setenv("SERVER_PORT", "9090")
+	explicitEnv = map[string]bool{"ND_STORAGE_SHARED_CONTRACTS": true}
+	t.Cleanup(func() { explicitEnv = map[string]bool{} })
+
+	env := "ND_SHARED_CONTRACTS=SG_AD:new\nND_STORAGE_SHARED_CONTRACTS=SG_DNS:file\nSERVER_PORT=7070\n"
+	if err := os.WriteFile(filepath.Join(".", ".env"), []byte(env), 0o600); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+
+	cfg := Reload()
+	if got := cfg.NexusDashboard.SharedContracts; got != "SG_AD:new" {
+		t.Errorf("SharedContracts = %q, want the .env value", got)
+	}
+	if got := cfg.NexusDashboard.StorageSharedContracts; got != "SG_DNS:explicit" {
+		t.Errorf("StorageSharedContracts = %q, want the explicit environment value", got)
+	}
+	if got := cfg.Server.Port; got != "9090" {
+		t.Errorf("Port = %q, want 9090: only hot-reloadable keys are reloaded", got)
+	}
+}
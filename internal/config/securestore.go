@@ -0,0 +1,215 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSecretNotFound is returned by a SecureStore that has no value for the key
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecureStore looks up sensitive configuration values (API keys, passwords, tokens)
+type SecureStore interface {
+	GetSecret(key string) (string, error)
+}
+
+// Secret store kinds selected with SECRET_STORE
+const (
+	SecretStoreEnv   = "env"
+	SecretStoreFile  = "file"
+	SecretStoreVault = "vault"
+)
+
+// DefaultVaultSecretPath is the Vault API path read when VAULT_SECRET_PATH is unset (KV v2 mount "secret")
+const DefaultVaultSecretPath = "secret/data/gond"
+
+// NewSecureStore returns the store selected by SECRET_STORE (env, file or vault; default env).
+// The file store reads SECRET_FILE_PATH; the Vault store reads VAULT_ADDR, VAULT_TOKEN and
+// VAULT_SECRET_PATH.
+func NewSecureStore() (SecureStore, error) {
+	switch kind := strings.ToLower(getEnv("SECRET_STORE", SecretStoreEnv)); kind {
+	case SecretStoreEnv:
+		return EnvSecureStore{}, nil
+	case SecretStoreFile:
+		return NewFileSecureStore(os.Getenv("SECRET_FILE_PATH"))
+	case SecretStoreVault:
+		return NewVaultSecureStore(os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"),
+			getEnv("VAULT_SECRET_PATH", DefaultVaultSecretPath))
+	default:
+		return nil, fmt.Errorf("unknown SECRET_STORE %q (want env, file or vault)", kind)
+	}
+}
+
+// EnvSecureStore reads secrets from environment variables
+type EnvSecureStore struct{}
+
+// GetSecret returns the environment variable named key
+func (EnvSecureStore) GetSecret(key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrSecretNotFound, key)
+}
+
+// FileSecureStore reads secrets from a file of KEY=value lines. Blank lines and lines
+// starting with # are ignored.
+type FileSecureStore struct {
+	values map[string]string
+}
+
+// NewFileSecureStore loads the secrets file at path
+func NewFileSecureStore(path string) (*FileSecureStore, error) {
+	if path == "" {
+		return nil, errors.New("SECRET_FILE_PATH is required for the file secret store")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open secrets file: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			return nil, fmt.Errorf("secrets file %s line %d: want KEY=value", path, n)
+		}
+		values[key] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read secrets file: %w", err)
+	}
+	return &FileSecureStore{values: values}, nil
+}
+
+// GetSecret returns the value of key from the secrets file
+func (s *FileSecureStore) GetSecret(key string) (string, error) {
+	if value := s.values[key]; value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrSecretNotFound, key)
+}
+
+// vaultRequestTimeout bounds the Vault read
+const vaultRequestTimeout = 10 * time.Second
+
+// VaultSecureStore reads secrets from one HashiCorp Vault KV secret using the HTTP API.
+// Each key is a field of the secret. Both KV v1 and v2 (path including "data/") are supported.
+// The secret is read once, on first use.
+type VaultSecureStore struct {
+	addr   string
+	token  string
+	path   string
+	client *http.Client
+
+	once   sync.Once
+	values map[string]string
+	err    error
+}
+
+// NewVaultSecureStore creates a store reading the secret at path (e.g. "secret/data/gond")
+// from the Vault server at addr
+func NewVaultSecureStore(addr, token, path string) (*VaultSecureStore, error) {
+	if addr == "" || token == "" {
+		return nil, errors.New("VAULT_ADDR and VAULT_TOKEN are required for the vault secret store")
+	}
+	return &VaultSecureStore{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		path:   strings.Trim(path, "/"),
+		client: &http.Client{Timeout: vaultRequestTimeout},
+	}, nil
+}
+
+// GetSecret returns field key of the Vault secret
+func (s *VaultSecureStore) GetSecret(key string) (string, error) {
+	s.once.Do(func() { s.values, s.err = s.read() })
+	if s.err != nil {
+		return "", s.err
+	}
+	if value := s.values[key]; value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrSecretNotFound, key)
+}
+
+// read fetches the secret's fields
+func (s *VaultSecureStore) read() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.addr+"/v1/"+s.path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault read %s: %w", s.path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("vault read %s: %w", s.path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault read %s: status %d: %s", s.path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("vault read %s: decode: %w", s.path, err)
+	}
+	fields := secret.Data
+	// KV v2 nests the fields under data.data
+	if nested, ok := fields["data"]; ok {
+		var v2 map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &v2); err == nil {
+			fields = v2
+		}
+	}
+
+	values := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			values[k] = value
+		}
+	}
+	return values, nil
+}
+
+// getSecret returns the secret for key, or defaultValue if the store has none. Other store
+// errors are recorded in *errs once each.
+func getSecret(store SecureStore, key, defaultValue string, errs *[]error) string {
+	value, err := store.GetSecret(key)
+	if err == nil {
+		return value
+	}
+	if errors.Is(err, ErrSecretNotFound) {
+		return defaultValue
+	}
+	for _, recorded := range *errs {
+		if errors.Is(recorded, err) {
+			return defaultValue
+		}
+	}
+	*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+	return defaultValue
+}
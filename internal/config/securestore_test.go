@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnvSecureStore(t *testing.T) {
+	t.Setenv("ND_API_KEY", "env-key")
+	t.Setenv("ND_PASSWORD", "")
+
+	store := EnvSecureStore{}
+	if got, err := store.GetSecret("ND_API_KEY"); err != nil || got != "env-key" {
+		t.Errorf("GetSecret(ND_API_KEY) = %q, %v; want env-key", got, err)
+	}
+	if _, err := store.GetSecret("ND_PASSWORD"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret(ND_PASSWORD) err = %v, want ErrSecretNotFound", err)
+	}
+}
+
+func TestFileSecureStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets")
+	content := "# gond secrets\n\nND_API_KEY=file-key\nGRPC_AUTH_TOKEN = tok=en \n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileSecureStore(path)
+	if err != nil {
+		t.Fatalf("NewFileSecureStore: %v", err)
+	}
+	for key, want := range map[string]string{"ND_API_KEY": "file-key", "GRPC_AUTH_TOKEN": "tok=en"} {
+		if got, err := store.GetSecret(key); err != nil || got != want {
+			t.Errorf("GetSecret(%s) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+	if _, err := store.GetSecret("ND_PASSWORD"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret(ND_PASSWORD) err = %v, want ErrSecretNotFound", err)
+	}
+
+	if err := os.WriteFile(path, []byte("ND_API_KEY\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileSecureStore(path); err == nil {
+		t.Error("expected error for a line without =")
+	}
+	if _, err := NewFileSecureStore(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestVaultSecureStore(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gond":
+			_, _ = w.Write([]byte(`{"data":{"data":{"ND_API_KEY":"vault-key","ND_PASSWORD":"vault-pass"},"metadata":{"version":1}}}`))
+		case "/v1/kv/gond":
+			_, _ = w.Write([]byte(`{"data":{"GRPC_AUTH_TOKEN":"v1-token"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	// KV v2; the secret is read once for all keys
+	store, err := NewVaultSecureStore(server.URL+"/", "root", DefaultVaultSecretPath)
+	if err != nil {
+		t.Fatalf("NewVaultSecureStore: %v", err)
+	}
+	for key, want := range map[string]string{"ND_API_KEY": "vault-key", "ND_PASSWORD": "vault-pass"} {
+		if got, err := store.GetSecret(key); err != nil || got != want {
+			t.Errorf("GetSecret(%s) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+	if _, err := store.GetSecret("GRPC_AUTH_TOKEN"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("GetSecret(GRPC_AUTH_TOKEN) err = %v, want ErrSecretNotFound", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("vault requests = %d, want 1", n)
+	}
+
+	// KV v1
+	store, _ = NewVaultSecureStore(server.URL, "root", "kv/gond")
+	if got, err := store.GetSecret("GRPC_AUTH_TOKEN"); err != nil || got != "v1-token" {
+		t.Errorf("v1 GetSecret = %q, %v; want v1-token", got, err)
+	}
+
+	// Vault errors are not reported as missing secrets
+	store, _ = NewVaultSecureStore(server.URL, "wrong", DefaultVaultSecretPath)
+	if _, err := store.GetSecret("ND_API_KEY"); err == nil || errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("forbidden GetSecret err = %v, want a vault error", err)
+	}
+
+	if _, err := NewVaultSecureStore("", "root", DefaultVaultSecretPath); err == nil {
+		t.Error("expected error without VAULT_ADDR")
+	}
+}
+
+func TestLoad_SecretStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets")
+	if err := os.WriteFile(path, []byte("ND_API_KEY=file-key\nGRPC_AUTH_TOKEN=file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SECRET_STORE", "file")
+	t.Setenv("SECRET_FILE_PATH", path)
+	t.Setenv("ND_API_KEY", "env-key")
+	t.Setenv("ND_PASSWORD", "env-pass")
+
+	cfg := Load()
+	if cfg.SecretError != nil {
+		t.Fatalf("SecretError = %v", cfg.SecretError)
+	}
+	if cfg.NexusDashboard.APIKey != "file-key" || cfg.GRPC.AuthToken != "file-token" {
+		t.Errorf("APIKey = %q, AuthToken = %q; want values from the file", cfg.NexusDashboard.APIKey, cfg.GRPC.AuthToken)
+	}
+	// Secrets missing from the file are not read from the environment
+	if cfg.NexusDashboard.Password != "" {
+		t.Errorf("Password = %q, want empty", cfg.NexusDashboard.Password)
+	}
+
+	t.Setenv("SECRET_STORE", "keychain")
+	if cfg := Load(); cfg.SecretError == nil {
+		t.Error("expected SecretError for an unknown SECRET_STORE")
+	}
+}
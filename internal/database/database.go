@@ -8,6 +8,8 @@ import (
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/logger"
 	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/util"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -81,21 +83,37 @@ func Migrate() error {
 
 	logger.Info("Running database migrations...")
 
+	// Must run before AutoMigrate adds the VLAN check constraint
+	if err := normalizePortMappingVLANs(DB); err != nil {
+		return fmt.Errorf("failed to normalize port mapping VLANs: %w", err)
+	}
+
+	// Replaced by idx_compute_nodes_active_name, which ignores soft-deleted nodes
+	if err := dropIndexIfExists(DB, &models.ComputeNode{}, "idx_compute_nodes_name"); err != nil {
+		return fmt.Errorf("failed to drop compute node name index: %w", err)
+	}
+
 	err := DB.AutoMigrate(
 		&models.Fabric{},
+		&models.Network{},
 		&models.Switch{},
 		&models.SwitchPort{},
 		&models.ComputeNode{},
 		&models.ComputeNodeInterface{},
 		&models.ComputeNodePortMapping{},
+		&models.ComputeNodePortMappingHistory{},
+		&models.ComputeNodeLabel{},
+		&models.NodeImport{},
 		&models.SecurityGroup{},
 		&models.PortSelector{},
 		&models.SecurityContract{},
 		&models.ContractRule{},
+		&models.SecurityProtocol{},
 		&models.SecurityAssociation{},
 		&models.Job{},
 		&models.JobComputeNode{},
 		&models.ComputeNodeAllocation{},
+		&models.JobEvent{},
 		&models.Tenant{},
 		&models.StorageTenant{},
 		&models.JobStorageAccess{},
@@ -105,10 +123,88 @@ func Migrate() error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := backfillContractRuleDirections(DB); err != nil {
+		return fmt.Errorf("failed to backfill contract rule directions: %w", err)
+	}
+
+	if err := markInvalidHostnames(DB); err != nil {
+		return fmt.Errorf("failed to mark invalid compute node hostnames: %w", err)
+	}
+
 	logger.Info("Database migrations completed")
 	return nil
 }
 
+// normalizePortMappingVLANs sets unset (NULL) and out-of-range port mapping
+// VLANs to 0 so existing rows satisfy chk_port_mapping_vlan
+func normalizePortMappingVLANs(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.ComputeNodePortMapping{}) {
+		return nil
+	}
+	result := db.Unscoped().Model(&models.ComputeNodePortMapping{}).
+		Where("vlan IS NULL OR vlan < 0 OR vlan > 4094").
+		UpdateColumn("vlan", 0) // UpdateColumn skips the mapping history hooks
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("Normalized port mapping VLANs to 0", zap.Int64("count", result.RowsAffected))
+	}
+	return nil
+}
+
+// backfillContractRuleDirections sets the direction of rules stored before the column was
+// added, which NDFC and contract validation would otherwise reject
+func backfillContractRuleDirections(db *gorm.DB) error {
+	result := db.Unscoped().Model(&models.ContractRule{}).
+		Where("direction IS NULL OR direction = ''").
+		UpdateColumn("direction", models.DefaultContractRuleDirection)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("Backfilled contract rule directions", zap.Int64("count", result.RowsAffected))
+	}
+	return nil
+}
+
+// dropIndexIfExists drops a model's index that the model no longer declares
+func dropIndexIfExists(db *gorm.DB, model interface{}, name string) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(model) || !migrator.HasIndex(model, name) {
+		return nil
+	}
+	logger.Info("Dropping index", zap.String("index", name))
+	return migrator.DropIndex(model, name)
+}
+
+// markInvalidHostnames sets hostname_valid=false on compute nodes whose hostname was stored
+// before RFC 1123 validation and does not pass it
+func markInvalidHostnames(db *gorm.DB) error {
+	var nodes []models.ComputeNode
+	if err := db.Select("id", "hostname").
+		Where("hostname <> '' AND hostname_valid = ?", true).
+		Find(&nodes).Error; err != nil {
+		return err
+	}
+	var invalid []string
+	for _, n := range nodes {
+		if util.ValidateHostname(n.Hostname) != nil {
+			invalid = append(invalid, n.ID)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	if err := db.Model(&models.ComputeNode{}).Where("id IN ?", invalid).
+		UpdateColumn("hostname_valid", false).Error; err != nil {
+		return err
+	}
+	logger.Warn("Compute nodes have invalid hostnames; list them with ?hostname_valid=false",
+		zap.Int("count", len(invalid)))
+	return nil
+}
+
 func Close() error {
 	if DB == nil {
 		return nil // Already closed or never initialized
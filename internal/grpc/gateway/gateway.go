@@ -0,0 +1,78 @@
+// Package gateway serves the gRPC API as HTTP/JSON via grpc-gateway, for clients that cannot
+// speak gRPC. Requests are transcoded and forwarded to the gRPC server over loopback, so the
+// gRPC interceptors (auth, logging, timeouts) apply unchanged.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/middleware"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// registrations register every gRPC service with the gateway mux
+var registrations = []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+	v1.RegisterJobsServiceHandlerFromEndpoint,
+	v1.RegisterComputeNodesServiceHandlerFromEndpoint,
+	v1.RegisterFabricsServiceHandlerFromEndpoint,
+	v1.RegisterSecurityServiceHandlerFromEndpoint,
+	v1.RegisterStorageTenantsServiceHandlerFromEndpoint,
+}
+
+// NewHandler returns an HTTP handler transcoding HTTP/JSON requests into calls on the gRPC
+// server at grpcEndpoint (e.g. "127.0.0.1:50051"). The Authorization header is forwarded as
+// "authorization" metadata for the gRPC auth interceptor. JSON uses the proto field names
+// (snake_case) like the Gin API. callOpts apply to every call, e.g. serveropts.Call so large
+// responses are not rejected. Browser origins are limited to middleware.CORSOrigins like the
+// Gin API. Connections are closed when ctx is done.
+func NewHandler(ctx context.Context, grpcEndpoint string, callOpts ...grpc.CallOption) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+
+	// Loopback to the local gRPC server; TLS, if any, terminates in front of the gateway
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	for _, register := range registrations {
+		if err := register(ctx, mux, grpcEndpoint, opts); err != nil {
+			return nil, fmt.Errorf("register gateway handler: %w", err)
+		}
+	}
+
+	return withCORS(mux, middleware.CORSOrigins), nil
+}
+
+// withCORS allows browser clients from the given origins and answers preflight requests
+func withCORS(next http.Handler, origins []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !slices.Contains(origins, origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Set("Access-Control-Allow-Credentials", "true")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			h.Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
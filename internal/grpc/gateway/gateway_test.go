@@ -0,0 +1,207 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/grpc/interceptors"
+	"github.com/banglin/go-nd/internal/grpc/services"
+	"github.com/banglin/go-nd/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const testToken = "secret"
+
+// startGRPC serves StorageTenantsService behind the auth interceptor on a loopback port,
+// backed by an in-memory database with one tenant
+func startGRPC(t *testing.T) string {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.StorageTenant{})
+	tenant := models.StorageTenant{ID: "t1", Key: "tenant1", Description: "first",
+		StorageNetworkName: "TENANT1_STORAGE_NET", StorageDstGroupName: "SG_AD", StorageContractName: "tenant1-storage"}
+	if err := db.Create(&tenant).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	auth := interceptors.NewAuthInterceptor(testToken, nil)
+	server := grpc.NewServer(grpc.UnaryInterceptor(auth.Unary()))
+	services.RegisterStorageTenantsService(server, zap.NewNop())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func newTestGateway(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	endpoint := startGRPC(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	handler, err := NewHandler(ctx, endpoint)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, endpoint
+}
+
+func doRequest(t *testing.T, method, url, body string, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, data
+}
+
+func bearer() http.Header {
+	return http.Header{"Authorization": {"Bearer " + testToken}}
+}
+
+func TestGateway_MatchesGRPCResponse(t *testing.T) {
+	server, endpoint := newTestGateway(t)
+
+	resp, body := doRequest(t, http.MethodGet, server.URL+"/v1/storage-tenants", "", bearer())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", resp.StatusCode, body)
+	}
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+	grpcResp, err := v1.NewStorageTenantsServiceClient(conn).ListStorageTenants(ctx, &v1.ListStorageTenantsRequest{})
+	if err != nil {
+		t.Fatalf("ListStorageTenants: %v", err)
+	}
+	want, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(grpcResp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotJSON, wantJSON interface{}
+	if err := json.Unmarshal(body, &gotJSON); err != nil {
+		t.Fatalf("decode gateway body: %v", err)
+	}
+	if err := json.Unmarshal(want, &wantJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotJSON, wantJSON) {
+		t.Errorf("gateway body = %s, want %s", body, want)
+	}
+	if !strings.Contains(string(body), `"storage_network_name":"TENANT1_STORAGE_NET"`) {
+		t.Errorf("body %s does not use proto field names", body)
+	}
+}
+
+func TestGateway_PathParamsAndBody(t *testing.T) {
+	server, _ := newTestGateway(t)
+
+	resp, body := doRequest(t, http.MethodPost, server.URL+"/v1/storage-tenants",
+		`{"key":"tenant2","name":"Tenant 2","storage_network_name":"TENANT2_STORAGE_NET","storage_dst_group_name":"SG_AD","storage_contract_name":"tenant2-storage"}`,
+		bearer())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("create status = %d, body = %s", resp.StatusCode, body)
+	}
+
+	resp, body = doRequest(t, http.MethodGet, server.URL+"/v1/storage-tenants/tenant2", "", bearer())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get status = %d, body = %s", resp.StatusCode, body)
+	}
+	var got struct {
+		StorageTenant struct {
+			Key                string `json:"key"`
+			StorageNetworkName string `json:"storage_network_name"`
+		} `json:"storage_tenant"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.StorageTenant.Key != "tenant2" || got.StorageTenant.StorageNetworkName != "TENANT2_STORAGE_NET" {
+		t.Errorf("tenant = %+v", got.StorageTenant)
+	}
+
+	if resp, body := doRequest(t, http.MethodGet, server.URL+"/v1/storage-tenants/missing", "", bearer()); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing tenant status = %d, body = %s", resp.StatusCode, body)
+	}
+}
+
+func TestGateway_ForwardsAuthorization(t *testing.T) {
+	server, _ := newTestGateway(t)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+	}{
+		{"missing", nil, http.StatusUnauthorized},
+		{"wrong token", http.Header{"Authorization": {"Bearer wrong"}}, http.StatusUnauthorized},
+		{"valid", bearer(), http.StatusOK},
+	}
+	for _, tt := range tests {
+		if resp, body := doRequest(t, http.MethodGet, server.URL+"/v1/storage-tenants", "", tt.header); resp.StatusCode != tt.want {
+			t.Errorf("%s: status = %d, want %d (body %s)", tt.name, resp.StatusCode, tt.want, body)
+		}
+	}
+}
+
+func TestGateway_CORS(t *testing.T) {
+	server, _ := newTestGateway(t)
+
+	resp, _ := doRequest(t, http.MethodOptions, server.URL+"/v1/storage-tenants", "", http.Header{
+		"Origin":                        {"http://localhost:3000"},
+		"Access-Control-Request-Method": {"GET"},
+	})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want 204", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Allow-Origin = %q", got)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(got, "Authorization") {
+		t.Errorf("Allow-Headers = %q, want Authorization", got)
+	}
+
+	resp, _ = doRequest(t, http.MethodGet, server.URL+"/v1/storage-tenants", "", http.Header{
+		"Origin":        {"http://evil.example"},
+		"Authorization": {"Bearer " + testToken},
+	})
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("disallowed origin got Allow-Origin %q", got)
+	}
+}
@@ -11,8 +11,22 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// TokenPrincipal identifies a caller authenticated with the shared auth token. All token
+// holders are the same principal.
+const TokenPrincipal = "grpc-token"
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal the auth interceptor authenticated the call as,
+// or "" if it was not authenticated (no interceptor, or a method that skips auth).
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
 // AuthInterceptor validates Bearer tokens from gRPC metadata.
 // Token is expected in "authorization" metadata key with "Bearer <token>" format.
+// Authenticated calls carry TokenPrincipal in their context (see PrincipalFromContext).
 type AuthInterceptor struct {
 	token       []byte
 	skipMethods map[string]bool // Methods that don't require auth (e.g., health checks)
@@ -47,7 +61,7 @@ func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 			return nil, err
 		}
 
-		return handler(ctx, req)
+		return handler(context.WithValue(ctx, principalKey{}, TokenPrincipal), req)
 	}
 }
 
@@ -67,10 +81,23 @@ func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 			return err
 		}
 
-		return handler(srv, ss)
+		return handler(srv, &principalStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), principalKey{}, TokenPrincipal),
+		})
 	}
 }
 
+// principalStream is a server stream whose context carries the authenticated principal
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
 // authenticate validates the token from context metadata.
 func (a *AuthInterceptor) authenticate(ctx context.Context) error {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor_SetsPrincipal(t *testing.T) {
+	const health = "/grpc.health.v1.Health/Check"
+	ai := NewAuthInterceptor("secret", []string{health})
+
+	var principal string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal = PrincipalFromContext(ctx)
+		return nil, nil
+	}
+	call := func(method, authorization string) error {
+		principal = "unset"
+		ctx := metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs("authorization", authorization, "x-actor-id", "alice"))
+		_, err := ai.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call("/go_nd.v1.ComputeNodesService/CreatePortMapping", "Bearer secret"); err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+	if principal != TokenPrincipal {
+		t.Errorf("principal = %q, want %q (client metadata must not set it)", principal, TokenPrincipal)
+	}
+
+	if err := call("/go_nd.v1.ComputeNodesService/CreatePortMapping", "Bearer wrong"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong token: %v, want Unauthenticated", err)
+	}
+	if principal != "unset" {
+		t.Error("handler ran for a wrong token")
+	}
+
+	if err := call(health, ""); err != nil {
+		t.Fatalf("skipped method: %v", err)
+	}
+	if principal != "" {
+		t.Errorf("skipped method principal = %q, want none", principal)
+	}
+}
@@ -0,0 +1,130 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMethodTimeout applies to methods without an entry in the timeout map.
+const DefaultMethodTimeout = 30 * time.Second
+
+// DefaultMethodTimeouts are the built-in limits for methods that wait on NDFC for longer
+// than DefaultMethodTimeout. They match the HTTP endpoint timeouts (middleware
+// DefaultEndpointTimeouts). Entries loaded from GRPC_METHOD_TIMEOUTS_FILE override these.
+var DefaultMethodTimeouts = map[string]time.Duration{
+	"/go_nd.v1.FabricsService/SyncFabrics":       10 * time.Minute,
+	"/go_nd.v1.FabricsService/SyncSwitches":      5 * time.Minute,
+	"/go_nd.v1.FabricsService/SyncStaleSwitches": 5 * time.Minute,
+	"/go_nd.v1.FabricsService/SyncPorts":         5 * time.Minute,
+	"/go_nd.v1.FabricsService/BulkSyncPorts":     5 * time.Minute,
+	// Deploys wait for the batch window and the NDFC deploy itself
+	"/go_nd.v1.FabricsService/DeployFabric": 5 * time.Minute,
+	// Provisioning is bounded by the per-job timeout; LoadMethodTimeouts replaces these with
+	// MAX_PROVISION_TIMEOUT_MINUTES
+	"/go_nd.v1.JobsService/SubmitJob":    60 * time.Minute,
+	"/go_nd.v1.JobsService/ProvisionJob": 60 * time.Minute,
+	// Deprovisioning removes the job's security groups, contracts and attachments in NDFC
+	"/go_nd.v1.JobsService/CompleteJob":        10 * time.Minute,
+	"/go_nd.v1.JobsService/CleanupExpiredJobs": 30 * time.Minute,
+}
+
+// TimeoutInterceptor bounds each unary call with a per-method deadline.
+type TimeoutInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+// NewTimeoutInterceptor creates a new timeout interceptor. Methods missing from
+// methodTimeouts use defaultTimeout.
+func NewTimeoutInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) *TimeoutInterceptor {
+	if defaultTimeout <= 0 {
+		defaultTimeout = DefaultMethodTimeout
+	}
+	return &TimeoutInterceptor{
+		defaultTimeout: defaultTimeout,
+		methodTimeouts: methodTimeouts,
+	}
+}
+
+// timeoutFor returns the timeout for a full method name.
+func (t *TimeoutInterceptor) timeoutFor(method string) time.Duration {
+	if d, ok := t.methodTimeouts[method]; ok {
+		return d
+	}
+	return t.defaultTimeout
+}
+
+// Unary returns a unary server interceptor enforcing per-method timeouts.
+func (t *TimeoutInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		timeout := t.timeoutFor(info.FullMethod)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		// Handlers often wrap errors in their own status, so also check the context itself
+		if err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "%s exceeded its %s timeout", info.FullMethod, timeout)
+		}
+		return resp, err
+	}
+}
+
+// provisionMethods wait for a job's provisioning, so their timeout follows the cap on
+// per-job provisioning timeouts
+var provisionMethods = []string{
+	"/go_nd.v1.JobsService/SubmitJob",
+	"/go_nd.v1.JobsService/ProvisionJob",
+}
+
+// LoadMethodTimeouts reads a JSON object mapping full method names to duration
+// strings (e.g. {"/go_nd.v1.FabricsService/SyncFabrics": "10m"}) and merges it
+// over DefaultMethodTimeouts, with the provisioning methods set to provisionTimeout
+// (MAX_PROVISION_TIMEOUT_MINUTES; <= 0 keeps the default). An empty path returns the defaults.
+func LoadMethodTimeouts(path string, provisionTimeout time.Duration) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(DefaultMethodTimeouts))
+	for method, d := range DefaultMethodTimeouts {
+		timeouts[method] = d
+	}
+	if provisionTimeout > 0 {
+		for _, method := range provisionMethods {
+			timeouts[method] = provisionTimeout
+		}
+	}
+	if path == "" {
+		return timeouts, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read method timeouts: %w", err)
+	}
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse method timeouts %s: %w", path, err)
+	}
+	for method, value := range raw {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("method timeout for %s: %w", method, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("method timeout for %s must be positive", method)
+		}
+		timeouts[method] = d
+	}
+	return timeouts, nil
+}
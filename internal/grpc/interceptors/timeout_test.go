@@ -0,0 +1,140 @@
+package interceptors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTimeoutInterceptor_AppliesMethodTimeout(t *testing.T) {
+	const method = "/go_nd.v1.FabricsService/SyncFabrics"
+	ti := NewTimeoutInterceptor(time.Minute, map[string]time.Duration{method: 20 * time.Millisecond})
+
+	var deadline time.Time
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, _ = ctx.Deadline()
+		<-ctx.Done()
+		// Mimic a service that wraps the cancellation in its own status
+		return nil, status.Error(codes.Internal, ctx.Err().Error())
+	}
+
+	start := time.Now()
+	_, err := ti.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	if got := deadline.Sub(start); got > time.Second {
+		t.Errorf("deadline %v after start, want the 20ms method timeout", got)
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("code = %v, want DeadlineExceeded", status.Code(err))
+	}
+	if !strings.Contains(status.Convert(err).Message(), "20ms") {
+		t.Errorf("message %q does not name the timeout", status.Convert(err).Message())
+	}
+}
+
+func TestTimeoutInterceptor_DefaultTimeout(t *testing.T) {
+	ti := NewTimeoutInterceptor(0, nil)
+
+	var deadline time.Time
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, _ = ctx.Deadline()
+		return "ok", nil
+	}
+
+	start := time.Now()
+	resp, err := ti.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/go_nd.v1.FabricsService/ListFabrics"}, handler)
+	if err != nil || resp != "ok" {
+		t.Fatalf("resp = %v, err = %v", resp, err)
+	}
+	if got := deadline.Sub(start); got < DefaultMethodTimeout || got > DefaultMethodTimeout+time.Second {
+		t.Errorf("deadline %v after start, want %v", got, DefaultMethodTimeout)
+	}
+}
+
+func TestTimeoutInterceptor_PassesThroughOtherErrors(t *testing.T) {
+	ti := NewTimeoutInterceptor(time.Minute, nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	_, err := ti.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/x/Y"}, handler)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestLoadMethodTimeouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeouts.json")
+	content := `{"/go_nd.v1.FabricsService/SyncFabrics": "15m", "/go_nd.v1.JobsService/SubmitJob": "2m"}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	timeouts, err := LoadMethodTimeouts(path, 90*time.Minute)
+	if err != nil {
+		t.Fatalf("LoadMethodTimeouts: %v", err)
+	}
+	if got := timeouts["/go_nd.v1.FabricsService/SyncFabrics"]; got != 15*time.Minute {
+		t.Errorf("SyncFabrics = %v, want file override 15m", got)
+	}
+	if got := timeouts["/go_nd.v1.JobsService/SubmitJob"]; got != 2*time.Minute {
+		t.Errorf("SubmitJob = %v, want file override 2m", got)
+	}
+	if got := timeouts["/go_nd.v1.JobsService/ProvisionJob"]; got != 90*time.Minute {
+		t.Errorf("ProvisionJob = %v, want the 90m provisioning cap", got)
+	}
+	if got := timeouts["/go_nd.v1.FabricsService/SyncSwitches"]; got != 5*time.Minute {
+		t.Errorf("SyncSwitches = %v, want built-in 5m", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"/x/Y": "soon"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMethodTimeouts(path, 0); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
+
+// longRunningMethods call NDFC for longer than DefaultMethodTimeout allows
+var longRunningMethods = []string{
+	"/go_nd.v1.FabricsService/SyncFabrics",
+	"/go_nd.v1.FabricsService/SyncSwitches",
+	"/go_nd.v1.FabricsService/SyncStaleSwitches",
+	"/go_nd.v1.FabricsService/SyncPorts",
+	"/go_nd.v1.FabricsService/BulkSyncPorts",
+	"/go_nd.v1.FabricsService/DeployFabric",
+	"/go_nd.v1.JobsService/SubmitJob",
+	"/go_nd.v1.JobsService/ProvisionJob",
+	"/go_nd.v1.JobsService/CompleteJob",
+	"/go_nd.v1.JobsService/CleanupExpiredJobs",
+}
+
+func TestDefaultMethodTimeouts_CoverLongRunningMethods(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, desc := range []grpc.ServiceDesc{v1.FabricsService_ServiceDesc, v1.JobsService_ServiceDesc} {
+		for _, m := range desc.Methods {
+			methods["/"+desc.ServiceName+"/"+m.MethodName] = true
+		}
+	}
+
+	for _, method := range longRunningMethods {
+		if !methods[method] {
+			t.Errorf("%s is not a unary method", method)
+		}
+		if d, ok := DefaultMethodTimeouts[method]; !ok || d <= DefaultMethodTimeout {
+			t.Errorf("%s timeout = %v, want an entry above %v", method, d, DefaultMethodTimeout)
+		}
+	}
+	for method := range DefaultMethodTimeouts {
+		if !methods[method] {
+			t.Errorf("timeout entry %s names no method", method)
+		}
+	}
+}
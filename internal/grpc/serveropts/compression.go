@@ -0,0 +1,127 @@
+package serveropts
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// gRPC compression codecs for GRPC_COMPRESSION
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// zstdMaxWindow bounds the memory a zstd request may make the server allocate
+const zstdMaxWindow = 64 << 20
+
+// RegisterCompression registers the codec's compressor with gRPC, so the server accepts
+// requests compressed with it and compresses its responses to them. Compression stays opt-in
+// per call: responses are only compressed for clients that send grpc.UseCompressor (see
+// UseCompression). Compressed bytes are counted in nd_grpc_compressed_bytes_total. Call it
+// before the server starts; "none" (or empty) registers nothing.
+func RegisterCompression(codec string) error {
+	switch codec {
+	case "", CompressionNone:
+		return nil
+	case CompressionGzip:
+		encoding.RegisterCompressor(newCompressor(CompressionGzip,
+			func() resettableWriter { return gzip.NewWriter(nil) },
+			func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }))
+	case CompressionZstd:
+		encoding.RegisterCompressor(newCompressor(CompressionZstd,
+			func() resettableWriter {
+				enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+				return enc
+			},
+			func(r io.Reader) (io.Reader, error) {
+				dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(zstdMaxWindow))
+				if err != nil {
+					return nil, err
+				}
+				return dec.IOReadCloser(), nil
+			}))
+	default:
+		return fmt.Errorf("unknown gRPC compression %q (expected none, gzip or zstd)", codec)
+	}
+	return nil
+}
+
+// UseCompression returns the call options of a client that compresses its requests with
+// codec and so receives compressed responses. None for "none" or an empty codec.
+func UseCompression(codec string) []grpc.CallOption {
+	if codec == "" || codec == CompressionNone {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(codec)}
+}
+
+// resettableWriter is a compressing writer that can be reused for another destination
+type resettableWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// compressor is an encoding.Compressor that pools its writers and counts compressed bytes
+type compressor struct {
+	name      string
+	writers   sync.Pool
+	newReader func(io.Reader) (io.Reader, error)
+	bytes     prometheus.Counter
+}
+
+func newCompressor(name string, newWriter func() resettableWriter, newReader func(io.Reader) (io.Reader, error)) *compressor {
+	return &compressor{
+		name:      name,
+		writers:   sync.Pool{New: func() any { return newWriter() }},
+		newReader: newReader,
+		bytes:     metrics.GRPCCompressedBytesTotal.WithLabelValues(name),
+	}
+}
+
+func (c *compressor) Name() string {
+	return c.name
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	z := c.writers.Get().(resettableWriter)
+	z.Reset(&countingWriter{w: w, bytes: c.bytes})
+	return &pooledWriter{resettableWriter: z, pool: &c.writers}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	return c.newReader(r)
+}
+
+// pooledWriter returns its writer to the pool once closed
+type pooledWriter struct {
+	resettableWriter
+	pool *sync.Pool
+}
+
+func (w *pooledWriter) Close() error {
+	err := w.resettableWriter.Close()
+	w.resettableWriter.Reset(nil)
+	w.pool.Put(w.resettableWriter)
+	return err
+}
+
+// countingWriter adds the bytes written to w to a counter
+type countingWriter struct {
+	w     io.Writer
+	bytes prometheus.Counter
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.bytes.Add(float64(n))
+	return n, err
+}
@@ -0,0 +1,152 @@
+package serveropts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRegisterCompression_UnknownCodec(t *testing.T) {
+	if err := RegisterCompression("lz4"); err == nil {
+		t.Error("expected an error for an unknown codec")
+	}
+	if err := RegisterCompression(CompressionNone); err != nil {
+		t.Errorf("none: %v", err)
+	}
+	if opts := UseCompression(CompressionNone); opts != nil {
+		t.Errorf("UseCompression(none) = %v, want no call options", opts)
+	}
+}
+
+func TestCompression_CompressedCalls(t *testing.T) {
+	for _, codec := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(codec, func(t *testing.T) {
+			if err := RegisterCompression(codec); err != nil {
+				t.Fatalf("RegisterCompression: %v", err)
+			}
+			cfg := config.GRPCConfig{}
+			client := startHealthServer(t, Server(cfg), append(Call(cfg), UseCompression(codec)...)...)
+			before := testutil.ToFloat64(metrics.GRPCCompressedBytesTotal.WithLabelValues(codec))
+
+			// 1 MB of repeated text compresses to a few KB; the server decompresses it and
+			// handles the call (unknown service)
+			request := &healthpb.HealthCheckRequest{Service: strings.Repeat("leaf-switch-port ", 64*1024)}
+			if _, err := client.Check(context.Background(), request); status.Code(err) != codes.NotFound {
+				t.Fatalf("compressed Check: %v, want NotFound", err)
+			}
+
+			written := testutil.ToFloat64(metrics.GRPCCompressedBytesTotal.WithLabelValues(codec)) - before
+			if written <= 0 || written > float64(proto.Size(request))/10 {
+				t.Errorf("compressed bytes = %.0f for a %d byte request", written, proto.Size(request))
+			}
+		})
+	}
+}
+
+// syncPortsResponse returns a SyncPortsResponse with n ports spread over 48-port switches
+func syncPortsResponse(n int) *v1.SyncPortsResponse {
+	now := timestamppb.New(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	resp := &v1.SyncPortsResponse{SyncedCount: int32(n)}
+	for i := 0; i < n; i++ {
+		switchID := fmt.Sprintf("2f3c9a1e-7b4d-4e8a-9c61-%012d", i/48)
+		name := fmt.Sprintf("Ethernet1/%d", i%48+1)
+		resp.Ports = append(resp.Ports, &v1.SwitchPort{
+			Id:          switchID + ":" + name,
+			Name:        name,
+			PortNumber:  fmt.Sprintf("%d", i%48+1),
+			Description: fmt.Sprintf("node-%04d eth%d", i/2, i%2),
+			AdminState:  "true",
+			Speed:       "100Gb",
+			IsPresent:   true,
+			SwitchId:    switchID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			LastSeenAt:  now,
+		})
+	}
+	return resp
+}
+
+// BenchmarkSyncPortsResponse marshals and compresses a 10000-port SyncPorts response with
+// each codec, reporting the bytes sent per response
+func BenchmarkSyncPortsResponse(b *testing.B) {
+	for _, codec := range []string{CompressionGzip, CompressionZstd} {
+		if err := RegisterCompression(codec); err != nil {
+			b.Fatal(err)
+		}
+	}
+	resp := syncPortsResponse(10000)
+
+	for _, codec := range []string{CompressionNone, CompressionGzip, CompressionZstd} {
+		b.Run(codec, func(b *testing.B) {
+			var buf bytes.Buffer
+			for i := 0; i < b.N; i++ {
+				data, err := proto.Marshal(resp)
+				if err != nil {
+					b.Fatal(err)
+				}
+				buf.Reset()
+				if codec == CompressionNone {
+					buf.Write(data)
+					continue
+				}
+				w, err := encoding.GetCompressor(codec).Compress(&buf)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := w.Write(data); err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(buf.Len()), "bytes/response")
+		})
+	}
+}
+
+// TestCompression_Decompress checks that each codec reads back what it wrote
+func TestCompression_Decompress(t *testing.T) {
+	data, err := proto.Marshal(syncPortsResponse(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, codec := range []string{CompressionGzip, CompressionZstd} {
+		if err := RegisterCompression(codec); err != nil {
+			t.Fatal(err)
+		}
+		c := encoding.GetCompressor(codec)
+		for i := 0; i < 2; i++ { // The second round reuses a pooled writer
+			var buf bytes.Buffer
+			w, _ := c.Compress(&buf)
+			_, _ = w.Write(data)
+			if err := w.Close(); err != nil {
+				t.Fatalf("%s close: %v", codec, err)
+			}
+			r, err := c.Decompress(&buf)
+			if err != nil {
+				t.Fatalf("%s decompress: %v", codec, err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil || !bytes.Equal(got, data) {
+				t.Fatalf("%s round trip: %d bytes, %v; want %d bytes", codec, len(got), err, len(data))
+			}
+		}
+	}
+}
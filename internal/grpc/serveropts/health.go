@@ -0,0 +1,91 @@
+package serveropts
+
+import (
+	"sync"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/ndclient"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Services with their own health status. The jobs service follows the NDFC circuit breaker,
+// the fabrics service the NDFC sync; compute nodes have no NDFC dependency.
+var (
+	JobsServiceName         = v1.JobsService_ServiceDesc.ServiceName
+	FabricsServiceName      = v1.FabricsService_ServiceDesc.ServiceName
+	ComputeNodesServiceName = v1.ComputeNodesService_ServiceDesc.ServiceName
+)
+
+// HealthReporter sets gRPC health statuses and mirrors them in the nd_grpc_service_health gauge
+type HealthReporter struct {
+	server *health.Server
+
+	mu       sync.Mutex
+	shutdown bool
+}
+
+// RegisterHealth registers the health service on s with the overall ("") and per-service
+// statuses serving
+func RegisterHealth(s *grpc.Server) *HealthReporter {
+	h := &HealthReporter{server: health.NewServer()}
+	healthpb.RegisterHealthServer(s, h.server)
+	for _, service := range []string{"", JobsServiceName, FabricsServiceName, ComputeNodesServiceName} {
+		h.SetServing(service, true)
+	}
+	return h
+}
+
+// SetServing sets the health status of service ("" for the server overall). Ignored after
+// Shutdown.
+func (h *HealthReporter) SetServing(service string, serving bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.shutdown {
+		return
+	}
+	status, value := healthpb.HealthCheckResponse_NOT_SERVING, 0.0
+	if serving {
+		status, value = healthpb.HealthCheckResponse_SERVING, 1.0
+	}
+	h.server.SetServingStatus(service, status)
+	if service != "" {
+		metrics.GRPCServiceHealth.WithLabelValues(service).Set(value)
+	}
+}
+
+// WatchCircuitBreaker reports the jobs service not serving while the NDFC circuit breaker
+// is open. A nil client is ignored.
+func (h *HealthReporter) WatchCircuitBreaker(client *ndclient.Client) {
+	if client == nil {
+		return
+	}
+	client.OnCircuitStateChange(func(open bool) {
+		h.SetServing(JobsServiceName, !open)
+	})
+}
+
+// WatchSync reports the fabrics service not serving while the NDFC sync fails persistently.
+// A nil worker is ignored.
+func (h *HealthReporter) WatchSync(worker *backgroundsync.Worker) {
+	if worker == nil {
+		return
+	}
+	worker.OnSyncHealthChange(func(healthy bool) {
+		h.SetServing(FabricsServiceName, healthy)
+	})
+}
+
+// Shutdown sets every status to not serving and ignores later updates
+func (h *HealthReporter) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shutdown = true
+	h.server.Shutdown()
+	for _, service := range []string{JobsServiceName, FabricsServiceName, ComputeNodesServiceName} {
+		metrics.GRPCServiceHealth.WithLabelValues(service).Set(0)
+	}
+}
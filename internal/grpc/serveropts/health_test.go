@@ -0,0 +1,72 @@
+package serveropts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func checkHealth(t *testing.T, h *HealthReporter, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("check %s: %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestHealthReporter_CircuitBreakerOpens(t *testing.T) {
+	ndfc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(ndfc.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: ndfc.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := RegisterHealth(grpc.NewServer())
+	h.WatchCircuitBreaker(client)
+	if got := checkHealth(t, h, JobsServiceName); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("jobs service = %v before NDFC failures, want SERVING", got)
+	}
+
+	for i := 0; i < ndclient.DefaultCircuitFailureThreshold; i++ {
+		_ = client.Get(context.Background(), "/x", nil)
+	}
+
+	if got := checkHealth(t, h, JobsServiceName); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("jobs service = %v with the circuit open, want NOT_SERVING", got)
+	}
+	if v := testutil.ToFloat64(metrics.GRPCServiceHealth.WithLabelValues(JobsServiceName)); v != 0 {
+		t.Errorf("jobs service gauge = %v, want 0", v)
+	}
+	for _, service := range []string{"", FabricsServiceName, ComputeNodesServiceName} {
+		if got := checkHealth(t, h, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("service %q = %v, want SERVING", service, got)
+		}
+	}
+	if v := testutil.ToFloat64(metrics.GRPCServiceHealth.WithLabelValues(ComputeNodesServiceName)); v != 1 {
+		t.Errorf("compute nodes service gauge = %v, want 1", v)
+	}
+}
+
+func TestHealthReporter_Shutdown(t *testing.T) {
+	h := RegisterHealth(grpc.NewServer())
+	h.Shutdown()
+	h.SetServing(JobsServiceName, true) // Ignored after shutdown
+
+	for _, service := range []string{"", JobsServiceName, ComputeNodesServiceName} {
+		if got := checkHealth(t, h, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("service %q = %v after shutdown, want NOT_SERVING", service, got)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package serveropts
+
+import (
+	"strings"
+
+	"github.com/banglin/go-nd/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RegisterReflection registers the reflection service (v1 and v1alpha) on s if
+// cfg.Reflection is set and reports whether it did. With cfg.ReflectedServices, reflection
+// only lists and describes those services, given by full (go_nd.v1.FabricsService) or short
+// (FabricsService) name.
+func RegisterReflection(s *grpc.Server, cfg config.GRPCConfig) bool {
+	if !cfg.Reflection {
+		return false
+	}
+	if len(cfg.ReflectedServices) == 0 {
+		reflection.Register(s)
+		return true
+	}
+
+	f := newServiceFilter(cfg.ReflectedServices)
+	opts := reflection.ServerOptions{
+		Services:           filteredServices{server: s, filter: f},
+		DescriptorResolver: filteredResolver{files: protoregistry.GlobalFiles, filter: f},
+	}
+	v1reflectiongrpc.RegisterServerReflectionServer(s, reflection.NewServerV1(opts))
+	v1alphareflectiongrpc.RegisterServerReflectionServer(s, reflection.NewServer(opts))
+	return true
+}
+
+// serviceFilter is the set of services reflection may describe
+type serviceFilter map[string]bool
+
+func newServiceFilter(names []string) serviceFilter {
+	f := make(serviceFilter, len(names))
+	for _, name := range names {
+		f[name] = true
+	}
+	return f
+}
+
+func (f serviceFilter) allows(fullName string) bool {
+	return f[fullName] || f[fullName[strings.LastIndex(fullName, ".")+1:]]
+}
+
+// allowsFile reports whether a proto file may be described: files without services are
+// shared types, files with services need at least one allowed service
+func (f serviceFilter) allowsFile(file protoreflect.FileDescriptor) bool {
+	services := file.Services()
+	if services.Len() == 0 {
+		return true
+	}
+	for i := 0; i < services.Len(); i++ {
+		if f.allows(string(services.Get(i).FullName())) {
+			return true
+		}
+	}
+	return false
+}
+
+// filteredServices lists only the allowed services of a server
+type filteredServices struct {
+	server reflection.ServiceInfoProvider
+	filter serviceFilter
+}
+
+func (p filteredServices) GetServiceInfo() map[string]grpc.ServiceInfo {
+	all := p.server.GetServiceInfo()
+	info := make(map[string]grpc.ServiceInfo, len(all))
+	for name, si := range all {
+		if p.filter.allows(name) {
+			info[name] = si
+		}
+	}
+	return info
+}
+
+// filteredResolver hides the files (and their symbols) of services that are not allowed
+type filteredResolver struct {
+	files  protodesc.Resolver
+	filter serviceFilter
+}
+
+func (r filteredResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	file, err := r.files.FindFileByPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !r.filter.allowsFile(file) {
+		return nil, protoregistry.NotFound
+	}
+	return file, nil
+}
+
+func (r filteredResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	desc, err := r.files.FindDescriptorByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !r.filter.allowsFile(desc.ParentFile()) {
+		return nil, protoregistry.NotFound
+	}
+	return desc, nil
+}
@@ -0,0 +1,116 @@
+package serveropts
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// startReflectionServer serves the jobs and fabrics services with reflection configured
+// by cfg and returns a reflection stream, as used by grpcurl
+func startReflectionServer(t *testing.T, cfg config.GRPCConfig) rpb.ServerReflection_ServerReflectionInfoClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	v1.RegisterJobsServiceServer(server, v1.UnimplementedJobsServiceServer{})
+	v1.RegisterFabricsServiceServer(server, v1.UnimplementedFabricsServiceServer{})
+	if !RegisterReflection(server, cfg) {
+		t.Fatal("RegisterReflection = false, want true")
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("reflection stream: %v", err)
+	}
+	return stream
+}
+
+func reflect(t *testing.T, stream rpb.ServerReflection_ServerReflectionInfoClient, req *rpb.ServerReflectionRequest) *rpb.ServerReflectionResponse {
+	t.Helper()
+	if err := stream.Send(req); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	return resp
+}
+
+// listServices is the equivalent of grpcurl list
+func listServices(t *testing.T, stream rpb.ServerReflection_ServerReflectionInfoClient) string {
+	t.Helper()
+	resp := reflect(t, stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	var names []string
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		names = append(names, s.GetName())
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// describes reports whether reflection returns the descriptor of symbol (grpcurl describe)
+func describes(t *testing.T, stream rpb.ServerReflection_ServerReflectionInfoClient, symbol string) bool {
+	t.Helper()
+	resp := reflect(t, stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+	return len(resp.GetFileDescriptorResponse().GetFileDescriptorProto()) > 0
+}
+
+func TestRegisterReflection_AllServices(t *testing.T) {
+	stream := startReflectionServer(t, config.GRPCConfig{Reflection: true})
+
+	want := "go_nd.v1.FabricsService,go_nd.v1.JobsService,grpc.reflection.v1.ServerReflection,grpc.reflection.v1alpha.ServerReflection"
+	if got := listServices(t, stream); got != want {
+		t.Errorf("services = %s, want %s", got, want)
+	}
+	if !describes(t, stream, "go_nd.v1.JobsService") {
+		t.Error("JobsService not described")
+	}
+}
+
+func TestRegisterReflection_ReflectedServices(t *testing.T) {
+	stream := startReflectionServer(t, config.GRPCConfig{
+		Reflection:        true,
+		ReflectedServices: []string{"FabricsService", "go_nd.v1.Unknown"},
+	})
+
+	if got := listServices(t, stream); got != "go_nd.v1.FabricsService" {
+		t.Errorf("services = %s, want only go_nd.v1.FabricsService", got)
+	}
+	if !describes(t, stream, "go_nd.v1.FabricsService") {
+		t.Error("FabricsService not described")
+	}
+	for _, symbol := range []string{"go_nd.v1.JobsService", "go_nd.v1.JobsService.SubmitJob", "go_nd.v1.SubmitJobRequest"} {
+		if describes(t, stream, symbol) {
+			t.Errorf("%s described, want hidden", symbol)
+		}
+	}
+}
+
+func TestRegisterReflection_Disabled(t *testing.T) {
+	if RegisterReflection(grpc.NewServer(), config.GRPCConfig{ReflectedServices: []string{"JobsService"}}) {
+		t.Error("RegisterReflection = true with Reflection off")
+	}
+}
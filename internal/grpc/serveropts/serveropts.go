@@ -0,0 +1,48 @@
+// Package serveropts builds the gRPC server and client options, and the reflection
+// service, shared by the gRPC binaries from config.GRPCConfig.
+package serveropts
+
+import (
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// DefaultMaxMsgSizeMB is used when a configured message size limit is not positive
+const DefaultMaxMsgSizeMB = 16
+
+// msgSize converts a limit in MB to bytes
+func msgSize(mb int) int {
+	if mb <= 0 {
+		mb = DefaultMaxMsgSizeMB
+	}
+	return mb * 1024 * 1024
+}
+
+// Server returns the message size limits and keepalive parameters for a gRPC server.
+// Connections are closed after KeepaliveMaxAgeMinutes (0 = never, the default), allowing
+// in-flight calls KeepaliveGraceSeconds to finish; longer calls and streams are cut off.
+func Server(cfg config.GRPCConfig) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(msgSize(cfg.MaxRecvMsgSizeMB)),
+		grpc.MaxSendMsgSize(msgSize(cfg.MaxSendMsgSizeMB)),
+	}
+	if cfg.KeepaliveMaxAgeMinutes > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge:      time.Duration(cfg.KeepaliveMaxAgeMinutes) * time.Minute,
+			MaxConnectionAgeGrace: time.Duration(cfg.KeepaliveGraceSeconds) * time.Second,
+		}))
+	}
+	return opts
+}
+
+// Call returns the call options for a client of a server configured with Server, so it
+// accepts responses as large as the server may send
+func Call(cfg config.GRPCConfig) []grpc.CallOption {
+	return []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(msgSize(cfg.MaxSendMsgSizeMB)),
+		grpc.MaxCallSendMsgSize(msgSize(cfg.MaxRecvMsgSizeMB)),
+	}
+}
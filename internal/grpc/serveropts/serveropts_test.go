@@ -0,0 +1,70 @@
+package serveropts
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// startHealthServer serves the health service with opts on a loopback port and returns a client
+func startHealthServer(t *testing.T, opts []grpc.ServerOption, callOpts ...grpc.CallOption) healthpb.HealthClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(callOpts...))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestServer_NonDefaultMessageSizes(t *testing.T) {
+	cfg := config.GRPCConfig{MaxRecvMsgSizeMB: 8, MaxSendMsgSizeMB: 32, KeepaliveMaxAgeMinutes: 5, KeepaliveGraceSeconds: 30}
+	client := startHealthServer(t, Server(cfg), Call(cfg)...)
+	ctx := context.Background()
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Check = %v, %v; want SERVING", resp, err)
+	}
+
+	// 5 MB is over gRPC's 4 MB default but within the 8 MB limit: the server handles the
+	// call (unknown service) instead of rejecting the message
+	large := &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 5*1024*1024)}
+	if _, err := client.Check(ctx, large); status.Code(err) != codes.NotFound {
+		t.Errorf("5 MB request: %v, want NotFound", err)
+	}
+
+	tooLarge := &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 9*1024*1024)}
+	if _, err := client.Check(ctx, tooLarge); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("9 MB request: %v, want ResourceExhausted", err)
+	}
+}
+
+func TestServer_DefaultsForUnsetLimits(t *testing.T) {
+	client := startHealthServer(t, Server(config.GRPCConfig{}))
+
+	large := &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 5*1024*1024)}
+	if _, err := client.Check(context.Background(), large); status.Code(err) != codes.NotFound {
+		t.Errorf("5 MB request with default %d MB limit: %v, want NotFound", DefaultMaxMsgSizeMB, err)
+	}
+}
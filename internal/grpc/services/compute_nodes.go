@@ -2,10 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
 	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/grpc/interceptors"
 	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/banglin/go-nd/internal/util"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -13,6 +18,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // ComputeNodesServiceServer implements the gRPC ComputeNodesService.
@@ -31,7 +37,8 @@ func RegisterComputeNodesService(server *grpc.Server, logger *zap.Logger) {
 // ListComputeNodes lists all compute nodes.
 func (s *ComputeNodesServiceServer) ListComputeNodes(ctx context.Context, req *v1.ListComputeNodesRequest) (*v1.ListComputeNodesResponse, error) {
 	var nodes []models.ComputeNode
-	if err := database.DB.WithContext(ctx).Preload("PortMappings.SwitchPort.Switch").Find(&nodes).Error; err != nil {
+	query := services.FilterNodesByLabels(database.DB.WithContext(ctx), req.LabelSelector)
+	if err := query.Preload("PortMappings.SwitchPort.Switch").Preload("Labels").Find(&nodes).Error; err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -52,13 +59,27 @@ func (s *ComputeNodesServiceServer) GetComputeNode(ctx context.Context, req *v1.
 	}
 
 	var node models.ComputeNode
-	if err := database.DB.WithContext(ctx).Preload("PortMappings.SwitchPort.Switch").First(&node, "id = ?", req.Id).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Preload("PortMappings.SwitchPort.Switch").Preload("Labels").First(&node, "id = ?", req.Id).Error; err != nil {
 		return nil, status.Error(codes.NotFound, "compute node not found")
 	}
 
-	return &v1.GetComputeNodeResponse{
+	resp := &v1.GetComputeNodeResponse{
 		ComputeNode: computeNodeToProto(&node),
-	}, nil
+	}
+	if req.IncludeAllocation {
+		allocation, err := services.GetNodeAllocation(ctx, database.DB, node.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if allocation != nil {
+			resp.CurrentAllocation = &v1.ComputeNodeAllocation{
+				JobSlurmId:  allocation.JobSlurmID,
+				JobStatus:   allocation.JobStatus,
+				AllocatedAt: timestamppb.New(allocation.AllocatedAt),
+			}
+		}
+	}
+	return resp, nil
 }
 
 // CreateComputeNode creates a new compute node.
@@ -66,6 +87,12 @@ func (s *ComputeNodesServiceServer) CreateComputeNode(ctx context.Context, req *
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "name is required")
 	}
+	if err := util.ValidateHostname(req.Hostname); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := util.ValidateBMCAddress(req.BmcAddress); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	node := models.ComputeNode{
 		ID:          uuid.New().String(),
@@ -74,6 +101,12 @@ func (s *ComputeNodesServiceServer) CreateComputeNode(ctx context.Context, req *
 		IPAddress:   req.IpAddress,
 		MACAddress:  req.MacAddress,
 		Description: req.Description,
+		BMCAddress:  req.BmcAddress,
+		BMCUsername: req.BmcUsername,
+		BMCPort:     int(req.BmcPort),
+	}
+	if node.BMCPort == 0 {
+		node.BMCPort = services.DefaultBMCPort
 	}
 
 	if err := database.DB.WithContext(ctx).Create(&node).Error; err != nil {
@@ -100,7 +133,11 @@ func (s *ComputeNodesServiceServer) UpdateComputeNode(ctx context.Context, req *
 		node.Name = req.Name
 	}
 	if req.Hostname != "" {
+		if err := util.ValidateHostname(req.Hostname); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		node.Hostname = req.Hostname
+		node.HostnameValid = true
 	}
 	if req.IpAddress != "" {
 		node.IPAddress = req.IpAddress
@@ -111,6 +148,18 @@ func (s *ComputeNodesServiceServer) UpdateComputeNode(ctx context.Context, req *
 	if req.Description != "" {
 		node.Description = req.Description
 	}
+	if req.BmcAddress != "" {
+		if err := util.ValidateBMCAddress(req.BmcAddress); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		node.BMCAddress = req.BmcAddress
+	}
+	if req.BmcUsername != "" {
+		node.BMCUsername = req.BmcUsername
+	}
+	if req.BmcPort != 0 {
+		node.BMCPort = int(req.BmcPort)
+	}
 
 	if err := database.DB.WithContext(ctx).Save(&node).Error; err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -166,6 +215,12 @@ func (s *ComputeNodesServiceServer) AddPortMapping(ctx context.Context, req *v1.
 	if req.SwitchPortId == "" {
 		return nil, status.Error(codes.InvalidArgument, "switch_port_id is required")
 	}
+	// 0 is the proto default and leaves the mapping untagged
+	if req.Vlan != 0 {
+		if err := util.ValidateVLAN(int(req.Vlan)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 
 	// Verify compute node exists
 	var node models.ComputeNode
@@ -251,10 +306,22 @@ func (s *ComputeNodesServiceServer) CreateInterface(ctx context.Context, req *v1
 		return nil, status.Error(codes.NotFound, "compute node not found")
 	}
 
-	// Check max 2 interfaces
-	var count int64
-	database.DB.Model(&models.ComputeNodeInterface{}).Where("compute_node_id = ?", req.ComputeNodeId).Count(&count)
+	// Check interface capacity (max 2). Nodes without port mappings yet, or with one
+	// mapped interface, may add an interface; the port mapping can follow later.
+	var count, mapped int64
+	if err := database.DB.WithContext(ctx).Model(&models.ComputeNodeInterface{}).
+		Where("compute_node_id = ?", req.ComputeNodeId).Count(&count).Error; err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	if count >= 2 {
+		if err := database.DB.WithContext(ctx).Model(&models.ComputeNodePortMapping{}).
+			Where("compute_node_id = ? AND interface_id IS NOT NULL", req.ComputeNodeId).
+			Distinct("interface_id").Count(&mapped).Error; err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if mapped >= count {
+			return nil, status.Errorf(codes.ResourceExhausted, "node already has %d interfaces, each with a port mapping", count)
+		}
 		return nil, status.Error(codes.FailedPrecondition, "node already has maximum of 2 interfaces")
 	}
 
@@ -271,6 +338,7 @@ func (s *ComputeNodesServiceServer) CreateInterface(ctx context.Context, req *v1
 		Hostname:      req.Hostname,
 		IPAddress:     req.IpAddress,
 		MACAddress:    req.MacAddress,
+		Label:         req.Label,
 	}
 
 	if err := database.DB.WithContext(ctx).Create(&iface).Error; err != nil {
@@ -302,6 +370,9 @@ func (s *ComputeNodesServiceServer) UpdateInterface(ctx context.Context, req *v1
 	if req.MacAddress != "" {
 		iface.MACAddress = req.MacAddress
 	}
+	if req.Label != "" {
+		iface.Label = req.Label
+	}
 
 	if err := database.DB.WithContext(ctx).Save(&iface).Error; err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -352,113 +423,140 @@ func (s *ComputeNodesServiceServer) AssignPortToInterface(ctx context.Context, r
 		mapping.InterfaceID = nil
 	}
 
-	if err := database.DB.WithContext(ctx).Save(&mapping).Error; err != nil {
+	if err := database.DB.WithContext(actorContext(ctx)).Save(&mapping).Error; err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	database.DB.Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
+	database.DB.WithContext(ctx).Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
 
 	return &v1.AssignPortToInterfaceResponse{
 		PortMapping: portMappingToProto(&mapping),
 	}, nil
 }
 
-// BulkAssignPortMappings assigns multiple ports to nodes/interfaces.
+// errBulkAssignAborted rolls back an atomic bulk assignment with failed items
+var errBulkAssignAborted = errors.New("bulk assignment aborted")
+
+// BulkAssignPortMappings assigns multiple ports to nodes/interfaces in one transaction.
+// Failed assignments are rolled back individually and the rest committed, unless atomic is
+// set, in which case any failure rolls back the whole batch and committed is false.
 func (s *ComputeNodesServiceServer) BulkAssignPortMappings(ctx context.Context, req *v1.BulkAssignPortMappingsRequest) (*v1.BulkAssignPortMappingsResponse, error) {
 	results := make([]*v1.BulkAssignmentResult, 0, len(req.Assignments))
-
-	for _, assignment := range req.Assignments {
-		result := &v1.BulkAssignmentResult{
-			SwitchPortId: assignment.SwitchPortId,
-		}
-
-		// Find existing mapping
-		var mapping models.ComputeNodePortMapping
-		err := database.DB.WithContext(ctx).Where("switch_port_id = ?", assignment.SwitchPortId).First(&mapping).Error
-
-		if assignment.NodeId == "" {
-			// Unassign
-			if err == nil {
-				if err := database.DB.WithContext(ctx).Delete(&mapping).Error; err != nil {
-					result.Success = false
-					result.Error = err.Error()
-				} else {
-					result.Success = true
-					result.Action = "deleted"
-				}
-			} else {
-				result.Success = true
-				result.Action = "no_change"
-			}
-		} else {
-			// Assign to node
-			var node models.ComputeNode
-			if err := database.DB.WithContext(ctx).Where("id = ? OR name = ?", assignment.NodeId, assignment.NodeId).First(&node).Error; err != nil {
-				result.Success = false
-				result.Error = "node not found"
-				results = append(results, result)
-				continue
+	var failed int32
+
+	err := database.DB.WithContext(actorContext(ctx)).Transaction(func(tx *gorm.DB) error {
+		for i, assignment := range req.Assignments {
+			// A savepoint per assignment undoes a failed item without aborting the transaction
+			savepoint := fmt.Sprintf("bulk_assign_%d", i)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return err
 			}
-
-			var interfaceID *string
-			if assignment.InterfaceId != "" {
-				var iface models.ComputeNodeInterface
-				if err := database.DB.WithContext(ctx).Where("id = ? AND compute_node_id = ?", assignment.InterfaceId, node.ID).First(&iface).Error; err != nil {
-					result.Success = false
-					result.Error = "interface not found or doesn't belong to this node"
-					results = append(results, result)
-					continue
-				}
-				// Check interface doesn't already have a port
-				var existingMapping models.ComputeNodePortMapping
-				if err := database.DB.WithContext(ctx).Where("interface_id = ? AND switch_port_id != ?", assignment.InterfaceId, assignment.SwitchPortId).First(&existingMapping).Error; err == nil {
-					result.Success = false
-					result.Error = "interface already has a port assigned"
-					results = append(results, result)
-					continue
-				}
-				interfaceID = &assignment.InterfaceId
-			}
-
-			if err == nil {
-				// Update existing
-				mapping.ComputeNodeID = node.ID
-				mapping.InterfaceID = interfaceID
-				if err := database.DB.WithContext(ctx).Save(&mapping).Error; err != nil {
-					result.Success = false
-					result.Error = err.Error()
-				} else {
-					result.Success = true
-					result.Action = "updated"
-					result.MappingId = mapping.ID
-				}
-			} else {
-				// Create new
-				mapping = models.ComputeNodePortMapping{
-					ID:            uuid.New().String(),
-					ComputeNodeID: node.ID,
-					SwitchPortID:  assignment.SwitchPortId,
-					InterfaceID:   interfaceID,
-				}
-				if err := database.DB.WithContext(ctx).Create(&mapping).Error; err != nil {
-					result.Success = false
-					result.Error = err.Error()
-				} else {
-					result.Success = true
-					result.Action = "created"
-					result.MappingId = mapping.ID
+			result := bulkAssignPort(tx, assignment)
+			if !result.Success {
+				failed++
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
 				}
 			}
+			results = append(results, result)
+		}
+		if req.Atomic && failed > 0 {
+			return errBulkAssignAborted
 		}
-		results = append(results, result)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAssignAborted) {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &v1.BulkAssignPortMappingsResponse{
-		Results: results,
-		Total:   int32(len(results)),
+		Results:   results,
+		Total:     int32(len(results)),
+		Failed:    failed,
+		Committed: err == nil,
 	}, nil
 }
 
+// bulkAssignPort applies a single bulk assignment within tx.
+func bulkAssignPort(tx *gorm.DB, assignment *v1.BulkPortAssignment) *v1.BulkAssignmentResult {
+	result := &v1.BulkAssignmentResult{
+		SwitchPortId: assignment.SwitchPortId,
+	}
+
+	// Find existing mapping
+	var mapping models.ComputeNodePortMapping
+	err := tx.Where("switch_port_id = ?", assignment.SwitchPortId).First(&mapping).Error
+
+	if assignment.NodeId == "" {
+		// Unassign
+		if err != nil {
+			result.Success = true
+			result.Action = "no_change"
+			return result
+		}
+		if err := tx.Delete(&mapping).Error; err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Success = true
+		result.Action = "deleted"
+		return result
+	}
+
+	// Assign to node
+	var node models.ComputeNode
+	if err := tx.Where("id = ? OR name = ?", assignment.NodeId, assignment.NodeId).First(&node).Error; err != nil {
+		result.Error = "node not found"
+		return result
+	}
+
+	var interfaceID *string
+	if assignment.InterfaceId != "" {
+		var iface models.ComputeNodeInterface
+		if err := tx.Where("id = ? AND compute_node_id = ?", assignment.InterfaceId, node.ID).First(&iface).Error; err != nil {
+			result.Error = "interface not found or doesn't belong to this node"
+			return result
+		}
+		// Check interface doesn't already have a port
+		var existingMapping models.ComputeNodePortMapping
+		if err := tx.Where("interface_id = ? AND switch_port_id != ?", assignment.InterfaceId, assignment.SwitchPortId).First(&existingMapping).Error; err == nil {
+			result.Error = "interface already has a port assigned"
+			return result
+		}
+		interfaceID = &assignment.InterfaceId
+	}
+
+	if err == nil {
+		// Update existing
+		mapping.ComputeNodeID = node.ID
+		mapping.InterfaceID = interfaceID
+		if err := tx.Save(&mapping).Error; err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Success = true
+		result.Action = "updated"
+		result.MappingId = mapping.ID
+		return result
+	}
+
+	// Create new
+	mapping = models.ComputeNodePortMapping{
+		ID:            uuid.New().String(),
+		ComputeNodeID: node.ID,
+		SwitchPortID:  assignment.SwitchPortId,
+		InterfaceID:   interfaceID,
+	}
+	if err := tx.Create(&mapping).Error; err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Success = true
+	result.Action = "created"
+	result.MappingId = mapping.ID
+	return result
+}
+
 // interfaceToProto converts a models.ComputeNodeInterface to proto.
 func interfaceToProto(i *models.ComputeNodeInterface) *v1.ComputeNodeInterface {
 	if i == nil {
@@ -471,6 +569,7 @@ func interfaceToProto(i *models.ComputeNodeInterface) *v1.ComputeNodeInterface {
 		Hostname:      i.Hostname,
 		IpAddress:     i.IPAddress,
 		MacAddress:    i.MACAddress,
+		Label:         i.Label,
 		CreatedAt:     timestamppb.New(i.CreatedAt),
 		UpdatedAt:     timestamppb.New(i.UpdatedAt),
 	}
@@ -483,23 +582,44 @@ func computeNodeToProto(n *models.ComputeNode) *v1.ComputeNode {
 	}
 
 	node := &v1.ComputeNode{
-		Id:          n.ID,
-		Name:        n.Name,
-		Hostname:    n.Hostname,
-		IpAddress:   n.IPAddress,
-		MacAddress:  n.MACAddress,
-		Description: n.Description,
-		CreatedAt:   timestamppb.New(n.CreatedAt),
-		UpdatedAt:   timestamppb.New(n.UpdatedAt),
+		Id:            n.ID,
+		Name:          n.Name,
+		Hostname:      n.Hostname,
+		IpAddress:     n.IPAddress,
+		MacAddress:    n.MACAddress,
+		Description:   n.Description,
+		BmcAddress:    n.BMCAddress,
+		BmcUsername:   n.BMCUsername,
+		BmcPort:       int32(n.BMCPort),
+		CreatedAt:     timestamppb.New(n.CreatedAt),
+		UpdatedAt:     timestamppb.New(n.UpdatedAt),
+		HostnameValid: n.HostnameValid,
 	}
 
 	for _, m := range n.PortMappings {
 		node.PortMappings = append(node.PortMappings, portMappingToProto(&m))
 	}
+	if len(n.Labels) > 0 {
+		node.Labels = make(map[string]string, len(n.Labels))
+		for _, l := range n.Labels {
+			node.Labels[l.Key] = l.Value
+		}
+	}
 
 	return node
 }
 
+// actorContext tags ctx with the caller's actor ID for port mapping history: the principal
+// the auth interceptor authenticated, or "api" for an unauthenticated call. Client metadata
+// is not trusted for it.
+func actorContext(ctx context.Context) context.Context {
+	actor := interceptors.PrincipalFromContext(ctx)
+	if actor == "" {
+		actor = "api"
+	}
+	return models.WithActor(ctx, actor)
+}
+
 // portMappingToProto converts a models.ComputeNodePortMapping to proto.
 func portMappingToProto(m *models.ComputeNodePortMapping) *v1.PortMapping {
 	if m == nil {
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// useSQLiteDB points database.DB at an in-memory SQLite DB for the duration of the test
+func useSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeInterface{},
+		&models.ComputeNodePortMapping{}, &models.ComputeNodePortMappingHistory{})
+
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+	return db
+}
+
+func seed(t *testing.T, db *gorm.DB, values ...interface{}) {
+	t.Helper()
+	for _, v := range values {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestCreateInterface_NoPortMappingsAllowed(t *testing.T) {
+	db := useSQLiteDB(t)
+	seed(t, db, &models.ComputeNode{ID: "n1", Name: "node1"})
+
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	resp, err := s.CreateInterface(context.Background(), &v1.CreateInterfaceRequest{
+		ComputeNodeId: "n1", Role: "compute", Label: "primary 100G NIC",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Interface.Label != "primary 100G NIC" {
+		t.Errorf("label = %q, want %q", resp.Interface.Label, "primary 100G NIC")
+	}
+}
+
+func TestCreateInterface_SecondInterfaceAllowed(t *testing.T) {
+	db := useSQLiteDB(t)
+	seed(t, db,
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodeInterface{ID: "i1", ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1", InterfaceID: strPtr("i1")},
+	)
+
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	if _, err := s.CreateInterface(context.Background(), &v1.CreateInterfaceRequest{
+		ComputeNodeId: "n1", Role: "storage",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateInterface_ResourceExhausted(t *testing.T) {
+	db := useSQLiteDB(t)
+	seed(t, db,
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodeInterface{ID: "i1", ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+		&models.ComputeNodeInterface{ID: "i2", ComputeNodeID: "n1", Role: models.InterfaceRoleStorage},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1", InterfaceID: strPtr("i1")},
+		&models.ComputeNodePortMapping{ID: "m2", ComputeNodeID: "n1", SwitchPortID: "p2", InterfaceID: strPtr("i2")},
+	)
+
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	_, err := s.CreateInterface(context.Background(), &v1.CreateInterfaceRequest{
+		ComputeNodeId: "n1", Role: "compute",
+	})
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("code = %v (%v), want ResourceExhausted", got, err)
+	}
+}
+
+func TestCreateInterface_MaxInterfacesWithoutMappings(t *testing.T) {
+	db := useSQLiteDB(t)
+	seed(t, db,
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodeInterface{ID: "i1", ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+		&models.ComputeNodeInterface{ID: "i2", ComputeNodeID: "n1", Role: models.InterfaceRoleStorage},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1", InterfaceID: strPtr("i1")},
+	)
+
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	_, err := s.CreateInterface(context.Background(), &v1.CreateInterfaceRequest{
+		ComputeNodeId: "n1", Role: "compute",
+	})
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Fatalf("code = %v (%v), want FailedPrecondition", got, err)
+	}
+}
+
+func TestAddPortMapping_VLANBoundaries(t *testing.T) {
+	tests := []struct {
+		vlan int32
+		want codes.Code
+	}{
+		{0, codes.OK}, // Unset
+		{1, codes.OK},
+		{4094, codes.OK},
+		{4095, codes.InvalidArgument},
+		{-1, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		db := useSQLiteDB(t)
+		if err := db.AutoMigrate(&models.Switch{}, &models.SwitchPort{}); err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+		seed(t, db,
+			&models.ComputeNode{ID: "n1", Name: "node1"},
+			&models.Switch{ID: "s1", Name: "leaf1", FabricID: "f1"},
+			&models.SwitchPort{ID: "p1", SwitchID: "s1", Name: "Ethernet1/1"},
+		)
+
+		s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+		resp, err := s.AddPortMapping(context.Background(), &v1.AddPortMappingRequest{
+			ComputeNodeId: "n1", SwitchPortId: "p1", Vlan: tt.vlan,
+		})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("vlan %d: code = %v (%v), want %v", tt.vlan, got, err, tt.want)
+			continue
+		}
+		if err == nil && resp.PortMapping.Vlan != tt.vlan {
+			t.Errorf("vlan %d: stored vlan = %d", tt.vlan, resp.PortMapping.Vlan)
+		}
+	}
+}
+
+func TestBulkAssignPortMappings_PartialAndAtomic(t *testing.T) {
+	assignments := []*v1.BulkPortAssignment{
+		{SwitchPortId: "p1", NodeId: "node1"},
+		{SwitchPortId: "p2", NodeId: "missing"},
+		{SwitchPortId: "p3", NodeId: "n1", InterfaceId: "i1"},
+		{SwitchPortId: "p4", NodeId: "n1", InterfaceId: "other"},
+		{SwitchPortId: "p5", NodeId: "n2"},
+	}
+
+	tests := []struct {
+		atomic        bool
+		wantCommitted bool
+		wantMappings  map[string]string // switch port -> node
+	}{
+		{false, true, map[string]string{"p1": "n1", "p3": "n1", "p5": "n2"}},
+		{true, false, map[string]string{"p5": "n1"}},
+	}
+	for _, tt := range tests {
+		db := useSQLiteDB(t)
+		seed(t, db,
+			&models.ComputeNode{ID: "n1", Name: "node1"},
+			&models.ComputeNode{ID: "n2", Name: "node2"},
+			&models.ComputeNodeInterface{ID: "i1", ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+			&models.ComputeNodePortMapping{ID: "m5", ComputeNodeID: "n1", SwitchPortID: "p5"},
+		)
+
+		s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+		resp, err := s.BulkAssignPortMappings(context.Background(), &v1.BulkAssignPortMappingsRequest{
+			Assignments: assignments, Atomic: tt.atomic,
+		})
+		if err != nil {
+			t.Fatalf("atomic=%v: unexpected error: %v", tt.atomic, err)
+		}
+		if resp.Committed != tt.wantCommitted || resp.Failed != 2 || resp.Total != 5 {
+			t.Errorf("atomic=%v: committed=%v failed=%d total=%d, want committed=%v failed=2 total=5",
+				tt.atomic, resp.Committed, resp.Failed, resp.Total, tt.wantCommitted)
+		}
+		for i, wantSuccess := range []bool{true, false, true, false, true} {
+			if resp.Results[i].Success != wantSuccess {
+				t.Errorf("atomic=%v: result %d success = %v (%s), want %v",
+					tt.atomic, i, resp.Results[i].Success, resp.Results[i].Error, wantSuccess)
+			}
+		}
+
+		var mappings []models.ComputeNodePortMapping
+		if err := db.Find(&mappings).Error; err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string, len(mappings))
+		for _, m := range mappings {
+			got[m.SwitchPortID] = m.ComputeNodeID
+		}
+		if !reflect.DeepEqual(got, tt.wantMappings) {
+			t.Errorf("atomic=%v: mappings = %v, want %v", tt.atomic, got, tt.wantMappings)
+		}
+	}
+}
+
+func TestCreateComputeNode_ValidatesHostname(t *testing.T) {
+	useSQLiteDB(t)
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+
+	_, err := s.CreateComputeNode(context.Background(), &v1.CreateComputeNodeRequest{Name: "node1", Hostname: "-node1.example.com"})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Fatalf("code = %v (%v), want InvalidArgument", got, err)
+	}
+
+	for _, hostname := range []string{"node1.example.com", ""} {
+		resp, err := s.CreateComputeNode(context.Background(), &v1.CreateComputeNodeRequest{Name: "node-" + hostname, Hostname: hostname})
+		if err != nil {
+			t.Fatalf("hostname %q: unexpected error: %v", hostname, err)
+		}
+		if !resp.ComputeNode.HostnameValid {
+			t.Errorf("hostname %q: hostname_valid = false, want true", hostname)
+		}
+	}
+}
+
+func TestUpdateComputeNode_FixesInvalidHostname(t *testing.T) {
+	db := useSQLiteDB(t)
+	seed(t, db, &models.ComputeNode{ID: "n1", Name: "node1", Hostname: "Node_1"})
+	if err := db.Model(&models.ComputeNode{}).Where("id = ?", "n1").UpdateColumn("hostname_valid", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	if _, err := s.UpdateComputeNode(context.Background(), &v1.UpdateComputeNodeRequest{Id: "n1", Hostname: "Node_2"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid hostname err = %v, want InvalidArgument", err)
+	}
+	resp, err := s.UpdateComputeNode(context.Background(), &v1.UpdateComputeNodeRequest{Id: "n1", Hostname: "node1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ComputeNode.Hostname != "node1" || !resp.ComputeNode.HostnameValid {
+		t.Errorf("node = %s valid=%v, want node1 valid", resp.ComputeNode.Hostname, resp.ComputeNode.HostnameValid)
+	}
+}
+
+func TestGetComputeNode_IncludeAllocation(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.ComputeNodeAllocation{}, &models.Job{}, &models.ComputeNodeLabel{},
+		&models.SwitchPort{}, &models.Switch{}); err != nil {
+		t.Fatal(err)
+	}
+	seed(t, db,
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNode{ID: "n2", Name: "node2"},
+		&models.Job{ID: "j1", SlurmJobID: "4242", Status: string(models.JobStatusActive)},
+		&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j1", AllocatedAt: time.Now()},
+	)
+	s := &ComputeNodesServiceServer{logger: zap.NewNop()}
+	ctx := context.Background()
+
+	resp, err := s.GetComputeNode(ctx, &v1.GetComputeNodeRequest{Id: "n1", IncludeAllocation: true})
+	if err != nil {
+		t.Fatalf("GetComputeNode: %v", err)
+	}
+	if a := resp.CurrentAllocation; a == nil || a.JobSlurmId != "4242" || a.JobStatus != "active" {
+		t.Errorf("allocation = %v, want Slurm job 4242", a)
+	}
+	if resp, err := s.GetComputeNode(ctx, &v1.GetComputeNodeRequest{Id: "n2", IncludeAllocation: true}); err != nil || resp.CurrentAllocation != nil {
+		t.Errorf("unallocated node = %v, %v, want no allocation", resp.GetCurrentAllocation(), err)
+	}
+	if resp, err := s.GetComputeNode(ctx, &v1.GetComputeNodeRequest{Id: "n1"}); err != nil || resp.CurrentAllocation != nil {
+		t.Errorf("without include_allocation = %v, %v, want no allocation", resp.GetCurrentAllocation(), err)
+	}
+}
@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/services"
 	"github.com/banglin/go-nd/internal/sync"
 
 	"github.com/google/uuid"
@@ -22,13 +28,20 @@ import (
 type FabricsServiceServer struct {
 	v1.UnimplementedFabricsServiceServer
 	ndClient *ndclient.Client
+	fabrics  *services.FabricService
+	uplinks  *sync.UplinkCache
+	deployer services.FabricDeployer
 	logger   *zap.Logger
 }
 
 // RegisterFabricsService registers the FabricsService with the gRPC server.
-func RegisterFabricsService(server *grpc.Server, ndClient *ndclient.Client, logger *zap.Logger) {
+// deployer may be nil, in which case DeployFabric is unimplemented.
+func RegisterFabricsService(server *grpc.Server, ndClient *ndclient.Client, fabrics *services.FabricService, uplinks *sync.UplinkCache, deployer services.FabricDeployer, logger *zap.Logger) {
 	v1.RegisterFabricsServiceServer(server, &FabricsServiceServer{
 		ndClient: ndClient,
+		fabrics:  fabrics,
+		uplinks:  uplinks,
+		deployer: deployer,
 		logger:   logger,
 	})
 }
@@ -90,6 +103,33 @@ func (s *FabricsServiceServer) CreateFabric(ctx context.Context, req *v1.CreateF
 	}, nil
 }
 
+// DeleteFabric soft-deletes a fabric. Without force, it fails with FailedPrecondition
+// listing the active jobs and allocations that block deletion.
+func (s *FabricsServiceServer) DeleteFabric(ctx context.Context, req *v1.DeleteFabricRequest) (*v1.DeleteFabricResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	result, err := s.fabrics.DeleteFabric(ctx, req.Id, req.Force)
+	if errors.Is(err, services.ErrFabricInUse) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: %s", err, strings.Join(result.BlockingReasons, "; "))
+	}
+	if errors.Is(err, services.ErrFabricNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &v1.DeleteFabricResponse{
+		FabricId:         result.FabricID,
+		FabricName:       result.FabricName,
+		DeletedSwitches:  result.DeletedSwitches,
+		DeletedPorts:     result.DeletedPorts,
+		UnlinkedMappings: result.UnlinkedMappings,
+	}, nil
+}
+
 // SyncFabrics syncs fabrics from Nexus Dashboard.
 func (s *FabricsServiceServer) SyncFabrics(ctx context.Context, req *v1.SyncFabricsRequest) (*v1.SyncFabricsResponse, error) {
 	if s.ndClient == nil {
@@ -116,6 +156,41 @@ func (s *FabricsServiceServer) SyncFabrics(ctx context.Context, req *v1.SyncFabr
 	}, nil
 }
 
+// DeployFabric deploys a fabric's configuration through the deploy batcher, waiting for the
+// batch to complete.
+func (s *FabricsServiceServer) DeployFabric(ctx context.Context, req *v1.DeployFabricRequest) (*v1.DeployFabricResponse, error) {
+	if s.deployer == nil {
+		return nil, status.Error(codes.Unimplemented, "deploy batcher not configured")
+	}
+	if req.FabricId == "" {
+		return nil, status.Error(codes.InvalidArgument, "fabric_id is required")
+	}
+
+	fabric, err := s.fabrics.GetFabric(ctx, req.FabricId)
+	if errors.Is(err, services.ErrFabricNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	metrics.ManualDeploysTotal.WithLabelValues(metrics.ManualDeployTriggerGRPC).Inc()
+	duration, err := services.DeployFabric(ctx, s.deployer, fabric.Name, req.SerialNumbers)
+	switch {
+	case errors.Is(err, ndclient.ErrCircuitOpen):
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &v1.DeployFabricResponse{
+		Deployed:        true,
+		DurationSeconds: int32(duration.Round(time.Second) / time.Second),
+	}, nil
+}
+
 // ListSwitches lists switches in a fabric.
 func (s *FabricsServiceServer) ListSwitches(ctx context.Context, req *v1.ListSwitchesRequest) (*v1.ListSwitchesResponse, error) {
 	if req.FabricId == "" {
@@ -183,6 +258,40 @@ func (s *FabricsServiceServer) CreateSwitch(ctx context.Context, req *v1.CreateS
 	}, nil
 }
 
+// UpdateSwitch updates a switch's local name, model or IP address.
+func (s *FabricsServiceServer) UpdateSwitch(ctx context.Context, req *v1.UpdateSwitchRequest) (*v1.UpdateSwitchResponse, error) {
+	if req.SwitchId == "" {
+		return nil, status.Error(codes.InvalidArgument, "switch_id is required")
+	}
+
+	result, err := s.fabrics.UpdateSwitch(ctx, req.SwitchId, services.SwitchUpdate{
+		Name:                   req.Name,
+		Model:                  req.Model,
+		IPAddress:              req.IpAddress,
+		Fields:                 req.GetUpdateMask().GetPaths(),
+		UpdatePortDescriptions: req.UpdatePortDescriptions,
+	})
+	if err != nil {
+		return nil, mapSwitchUpdateError(err)
+	}
+
+	return &v1.UpdateSwitchResponse{
+		Switch:                  switchToProto(result.Switch),
+		UpdatedPortDescriptions: result.UpdatedPortDescriptions,
+	}, nil
+}
+
+// mapSwitchUpdateError converts switch update errors to gRPC status errors.
+func mapSwitchUpdateError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrSwitchNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrImmutableSwitchField), errors.Is(err, services.ErrInvalidSwitchUpdate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 // SyncSwitches syncs switches from Nexus Dashboard.
 func (s *FabricsServiceServer) SyncSwitches(ctx context.Context, req *v1.SyncSwitchesRequest) (*v1.SyncSwitchesResponse, error) {
 	if req.FabricId == "" {
@@ -217,16 +326,49 @@ func (s *FabricsServiceServer) SyncSwitches(ctx context.Context, req *v1.SyncSwi
 	}, nil
 }
 
-// ListNetworks lists networks in a fabric.
-func (s *FabricsServiceServer) ListNetworks(ctx context.Context, req *v1.ListNetworksRequest) (*v1.ListNetworksResponse, error) {
+// SyncStaleSwitches syncs ports for switches that were never synced or whose
+// last sync is older than stale_threshold_minutes (default 60).
+func (s *FabricsServiceServer) SyncStaleSwitches(ctx context.Context, req *v1.SyncStaleSwitchesRequest) (*v1.SyncStaleSwitchesResponse, error) {
 	if req.FabricId == "" {
 		return nil, status.Error(codes.InvalidArgument, "fabric_id is required")
 	}
+	if req.StaleThresholdMinutes < 0 {
+		return nil, status.Error(codes.InvalidArgument, "stale_threshold_minutes must not be negative")
+	}
 	if s.ndClient == nil {
 		return nil, status.Error(codes.FailedPrecondition, "Nexus Dashboard client not configured")
 	}
 
-	networks, err := s.ndClient.LANFabric().GetNetworksNDFC(ctx, req.FabricId)
+	var fabric models.Fabric
+	if err := database.DB.WithContext(ctx).First(&fabric, "id = ?", req.FabricId).Error; err != nil {
+		if err := database.DB.WithContext(ctx).First(&fabric, "name = ?", req.FabricId).Error; err != nil {
+			return nil, status.Error(codes.NotFound, "fabric not found")
+		}
+	}
+
+	uplinks := sync.GetUplinksWithCache(ctx, s.ndClient.LANFabric(), fabric.Name, s.uplinks)
+	threshold := time.Duration(req.StaleThresholdMinutes) * time.Minute
+	result, err := sync.SyncStaleSwitches(ctx, database.DB, s.ndClient.LANFabric(), &fabric, uplinks, threshold)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &v1.SyncStaleSwitchesResponse{
+		SyncedCount:           int32(result.Synced),
+		SkippedCount:          int32(result.Skipped),
+		StaleThresholdMinutes: int32(result.StaleThreshold / time.Minute),
+		FailedCount:           int32(result.Failed),
+	}, nil
+}
+
+// ListNetworks lists networks in a fabric. fabric_id may be the fabric's ID or name.
+func (s *FabricsServiceServer) ListNetworks(ctx context.Context, req *v1.ListNetworksRequest) (*v1.ListNetworksResponse, error) {
+	fabricName, err := s.ndfcFabricName(ctx, req.FabricId)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := s.ndClient.LANFabric().GetNetworksNDFC(ctx, fabricName)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -246,6 +388,82 @@ func (s *FabricsServiceServer) ListNetworks(ctx context.Context, req *v1.ListNet
 	}, nil
 }
 
+// ListVRFs lists the NDFC VRFs of a fabric. fabric_id may be the fabric's ID or name.
+func (s *FabricsServiceServer) ListVRFs(ctx context.Context, req *v1.ListVRFsRequest) (*v1.ListVRFsResponse, error) {
+	fabricName, err := s.ndfcFabricName(ctx, req.FabricId)
+	if err != nil {
+		return nil, err
+	}
+
+	vrfs, err := s.ndClient.LANFabric().GetVRFsNDFC(ctx, fabricName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoVRFs := make([]*v1.VRF, len(vrfs))
+	for i, v := range vrfs {
+		protoVRFs[i] = &v1.VRF{
+			Name:     v.VRFName,
+			Fabric:   v.Fabric,
+			VrfId:    int32(v.VRFID),
+			Template: v.VRFTemplate,
+			Tenant:   v.TenantName,
+			Status:   v.VRFStatus,
+		}
+	}
+	return &v1.ListVRFsResponse{Vrfs: protoVRFs}, nil
+}
+
+// GetNetworkVLAN returns the VLAN NDFC assigned to a network. fabric_id may be the fabric's
+// ID or name.
+func (s *FabricsServiceServer) GetNetworkVLAN(ctx context.Context, req *v1.GetNetworkVLANRequest) (*v1.GetNetworkVLANResponse, error) {
+	if req.NetworkName == "" {
+		return nil, status.Error(codes.InvalidArgument, "network_name is required")
+	}
+	fabricName, err := s.ndfcFabricName(ctx, req.FabricId)
+	if err != nil {
+		return nil, err
+	}
+
+	vlan, err := s.ndClient.LANFabric().GetNetworkVLAN(ctx, fabricName, req.NetworkName)
+	if errors.Is(err, lanfabric.ErrNetworkNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &v1.GetNetworkVLANResponse{Vlan: vlan}, nil
+}
+
+// ndfcFabricName resolves a fabric ID or name to the fabric name NDFC knows it by: a local
+// fabric's name, or failing that the NDFC fabric with that numeric ID or name. Returns a gRPC
+// status error if it cannot be resolved or NDFC is not configured.
+func (s *FabricsServiceServer) ndfcFabricName(ctx context.Context, fabricID string) (string, error) {
+	if fabricID == "" {
+		return "", status.Error(codes.InvalidArgument, "fabric_id is required")
+	}
+	if s.ndClient == nil {
+		return "", status.Error(codes.FailedPrecondition, "Nexus Dashboard client not configured")
+	}
+	fabric, err := s.fabrics.GetFabric(ctx, fabricID)
+	if err == nil {
+		return fabric.Name, nil
+	}
+	if !errors.Is(err, services.ErrFabricNotFound) {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	// Not synced locally: NDFC fabric IDs and names are resolved against NDFC
+	name, err := s.ndClient.LANFabric().ResolveFabricName(ctx, fabricID)
+	if errors.Is(err, lanfabric.ErrFabricNotFound) || ndclient.IsNotFoundError(err) {
+		return "", status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return name, nil
+}
+
 // ListPorts lists ports on a switch.
 func (s *FabricsServiceServer) ListPorts(ctx context.Context, req *v1.ListPortsRequest) (*v1.ListPortsResponse, error) {
 	if req.SwitchId == "" {
@@ -298,6 +516,7 @@ func (s *FabricsServiceServer) CreatePort(ctx context.Context, req *v1.CreatePor
 		PortNumber:  req.PortNumber,
 		Description: req.Description,
 		IsPresent:   true,
+		IsBreakout:  lanfabric.IsBreakoutPort(req.Name),
 		SwitchID:    req.SwitchId,
 	}
 
@@ -327,7 +546,7 @@ func (s *FabricsServiceServer) SyncPorts(ctx context.Context, req *v1.SyncPortsR
 	// Get uplink ports to exclude
 	var uplinks map[string]bool
 	if sw.Fabric != nil {
-		uplinks = sync.GetUplinksWithCache(ctx, s.ndClient.LANFabric(), sw.Fabric.Name, cache.Client)
+		uplinks = sync.GetUplinksWithCache(ctx, s.ndClient.LANFabric(), sw.Fabric.Name, s.uplinks)
 	} else {
 		uplinks = make(map[string]bool)
 	}
@@ -352,6 +571,44 @@ func (s *FabricsServiceServer) SyncPorts(ctx context.Context, req *v1.SyncPortsR
 	}, nil
 }
 
+// BulkSyncPorts syncs ports for several switches in parallel, or for every switch of
+// fabric_id (ID or name) when switch_ids is empty. A failed switch is reported in its result and does not
+// stop the others.
+func (s *FabricsServiceServer) BulkSyncPorts(ctx context.Context, req *v1.BulkSyncPortsRequest) (*v1.BulkSyncPortsResponse, error) {
+	if len(req.SwitchIds) == 0 && req.FabricId == "" {
+		return nil, status.Error(codes.InvalidArgument, "switch_ids or fabric_id is required")
+	}
+	if s.ndClient == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Nexus Dashboard client not configured")
+	}
+	var fabricID string
+	if req.FabricId != "" {
+		fabric, err := s.fabrics.GetFabric(ctx, req.FabricId)
+		if errors.Is(err, services.ErrFabricNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		fabricID = fabric.ID
+	}
+
+	start := time.Now()
+	results, err := sync.BulkSyncSwitchPorts(ctx, database.DB, s.ndClient.LANFabric(), fabricID, req.SwitchIds, s.uplinks)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &v1.BulkSyncPortsResponse{
+		Results:    make(map[string]*v1.SyncSwitchResult, len(results)),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	for id, r := range results {
+		resp.Results[id] = &v1.SyncSwitchResult{SyncedCount: int32(r.Synced), ErrorMessage: r.Error}
+	}
+	return resp, nil
+}
+
 // DeletePorts deletes ports from a switch.
 func (s *FabricsServiceServer) DeletePorts(ctx context.Context, req *v1.DeletePortsRequest) (*v1.DeletePortsResponse, error) {
 	if req.SwitchId == "" {
@@ -373,6 +630,56 @@ func (s *FabricsServiceServer) DeletePorts(ctx context.Context, req *v1.DeletePo
 	}, nil
 }
 
+// GetFabricRoleSummary counts a fabric's switches by role with its leaf port capacity
+func (s *FabricsServiceServer) GetFabricRoleSummary(ctx context.Context, req *v1.GetFabricRoleSummaryRequest) (*v1.GetFabricRoleSummaryResponse, error) {
+	if req.FabricId == "" {
+		return nil, status.Error(codes.InvalidArgument, "fabric_id is required")
+	}
+	summary, err := s.fabrics.GetSwitchRoleSummary(ctx, req.FabricId)
+	if err != nil {
+		if errors.Is(err, services.ErrFabricNotFound) {
+			return nil, status.Error(codes.NotFound, "fabric not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &v1.GetFabricRoleSummaryResponse{
+		Leaf:               int32(summary.Leaf),
+		Spine:              int32(summary.Spine),
+		Border:             int32(summary.Border),
+		Unknown:            int32(summary.Unknown),
+		Total:              int32(summary.Total),
+		LeafPortsTotal:     int32(summary.LeafPortsTotal),
+		LeafPortsAvailable: int32(summary.LeafPortsAvailable),
+	}, nil
+}
+
+// GetFabricHealth reports NDFC connectivity and local sync status. Individual
+// checks that fail (e.g. NDFC unreachable) are left zero instead of failing the call.
+func (s *FabricsServiceServer) GetFabricHealth(ctx context.Context, req *v1.GetFabricHealthRequest) (*v1.FabricHealthResponse, error) {
+	health, err := s.fabrics.Health(ctx, s.ndClient, cache.Client, req.FabricId)
+	if err != nil {
+		if errors.Is(err, services.ErrFabricNotFound) {
+			return nil, status.Error(codes.NotFound, "fabric not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &v1.FabricHealthResponse{
+		NdfcReachable:   health.NDFCReachable,
+		NdfcVersion:     health.NDFCVersion,
+		StalePortsCount: int32(health.StalePorts),
+		OrphanedSgCount: int32(health.OrphanedSGs),
+		PendingDeploys:  int32(health.PendingDeploys),
+	}
+	if health.LastFabricSync != nil {
+		resp.LastFabricSync = timestamppb.New(*health.LastFabricSync)
+	}
+	if health.LastSwitchSync != nil {
+		resp.LastSwitchSync = timestamppb.New(*health.LastSwitchSync)
+	}
+	return resp, nil
+}
+
 // fabricToProto converts a models.Fabric to proto.
 func fabricToProto(f *models.Fabric) *v1.Fabric {
 	if f == nil {
@@ -395,7 +702,7 @@ func switchToProto(sw *models.Switch) *v1.Switch {
 		return nil
 	}
 
-	return &v1.Switch{
+	pb := &v1.Switch{
 		Id:           sw.ID,
 		Name:         sw.Name,
 		SerialNumber: sw.SerialNumber,
@@ -405,7 +712,12 @@ func switchToProto(sw *models.Switch) *v1.Switch {
 		CreatedAt:    timestamppb.New(sw.CreatedAt),
 		UpdatedAt:    timestamppb.New(sw.UpdatedAt),
 		PortCount:    int32(len(sw.Ports)),
+		Role:         sw.Role,
+	}
+	if sw.LastSyncedAt != nil {
+		pb.LastSyncedAt = timestamppb.New(*sw.LastSyncedAt)
 	}
+	return pb
 }
 
 // switchPortToProto converts a models.SwitchPort to proto.
@@ -422,6 +734,7 @@ func switchPortToProto(p *models.SwitchPort) *v1.SwitchPort {
 		AdminState:  p.AdminState,
 		Speed:       p.Speed,
 		IsPresent:   p.IsPresent,
+		IsBreakout:  p.IsBreakout,
 		SwitchId:    p.SwitchID,
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
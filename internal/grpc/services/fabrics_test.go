@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeDeployer records deploy requests instead of deploying
+type fakeDeployer struct {
+	mu       sync.Mutex
+	fabrics  []string
+	switches []string
+}
+
+func (d *fakeDeployer) RequestDeploy(_ context.Context, fabricName string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fabrics = append(d.fabrics, fabricName)
+	return nil
+}
+
+func (d *fakeDeployer) RequestDeployForSwitch(_ context.Context, fabricName, serialNumber string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.switches = append(d.switches, fabricName+"/"+serialNumber)
+	return nil
+}
+
+func newFabricsTestServer(t *testing.T, deployer services.FabricDeployer) *FabricsServiceServer {
+	t.Helper()
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Fabric{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db, &models.Fabric{ID: "fab-1", Name: "DevNet_VxLAN_Fabric"})
+	return &FabricsServiceServer{
+		fabrics:  services.NewFabricService(db),
+		deployer: deployer,
+		logger:   zap.NewNop(),
+	}
+}
+
+func TestDeployFabric(t *testing.T) {
+	deployer := &fakeDeployer{}
+	srv := newFabricsTestServer(t, deployer)
+	before := testutil.ToFloat64(metrics.ManualDeploysTotal.WithLabelValues(metrics.ManualDeployTriggerGRPC))
+
+	resp, err := srv.DeployFabric(context.Background(), &v1.DeployFabricRequest{FabricId: "fab-1"})
+	if err != nil {
+		t.Fatalf("DeployFabric: %v", err)
+	}
+	if !resp.Deployed {
+		t.Error("deployed = false")
+	}
+	if !slices.Equal(deployer.fabrics, []string{"DevNet_VxLAN_Fabric"}) {
+		t.Errorf("RequestDeploy fabrics = %v, want the fabric name", deployer.fabrics)
+	}
+
+	// Serial numbers deploy only those switches
+	_, err = srv.DeployFabric(context.Background(), &v1.DeployFabricRequest{
+		FabricId: "DevNet_VxLAN_Fabric", SerialNumbers: []string{"SN1", "SN2"},
+	})
+	if err != nil {
+		t.Fatalf("DeployFabric with serials: %v", err)
+	}
+	slices.Sort(deployer.switches)
+	if !slices.Equal(deployer.switches, []string{"DevNet_VxLAN_Fabric/SN1", "DevNet_VxLAN_Fabric/SN2"}) {
+		t.Errorf("RequestDeployForSwitch = %v", deployer.switches)
+	}
+	if len(deployer.fabrics) != 1 {
+		t.Errorf("RequestDeploy called %d times, want 1", len(deployer.fabrics))
+	}
+
+	if d := testutil.ToFloat64(metrics.ManualDeploysTotal.WithLabelValues(metrics.ManualDeployTriggerGRPC)) - before; d != 2 {
+		t.Errorf("nd_manual_deploys_total{trigger=grpc} increased by %v, want 2", d)
+	}
+}
+
+func TestDeployFabric_Errors(t *testing.T) {
+	srv := newFabricsTestServer(t, &fakeDeployer{})
+	if _, err := srv.DeployFabric(context.Background(), &v1.DeployFabricRequest{FabricId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing fabric code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.DeployFabric(context.Background(), &v1.DeployFabricRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty fabric_id code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	srv.deployer = nil
+	if _, err := srv.DeployFabric(context.Background(), &v1.DeployFabricRequest{FabricId: "fab-1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("without batcher code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+// newFakeNDFCClient returns a client for a fake NDFC that serves VRFs and networks for the
+// fabric named DevNet_VxLAN_Fabric (NDFC fabric ID 7) only
+func newFakeNDFCClient(t *testing.T) *ndclient.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/rest/control/fabrics"):
+			_, _ = w.Write([]byte(`[{"id":7,"fabricName":"DevNet_VxLAN_Fabric"}]`))
+		case strings.HasSuffix(r.URL.Path, "/lan-fabric/fabrics/7"):
+			_, _ = w.Write([]byte(`{"id":7,"fabricName":"DevNet_VxLAN_Fabric"}`))
+		case strings.HasSuffix(r.URL.Path, "/lan-fabric/fabrics/8"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/top-down/fabrics/DevNet_VxLAN_Fabric/vrfs"):
+			_, _ = w.Write([]byte(`[{"fabric":"DevNet_VxLAN_Fabric","vrfName":"hpc","vrfId":50000,"tenantName":"t1"}]`))
+		case strings.HasSuffix(r.URL.Path, "/top-down/fabrics/DevNet_VxLAN_Fabric/networks"):
+			_, _ = w.Write([]byte(`[{"networkName":"hpcnet","networkTemplateConfig":"{\"vlanId\":\"2301\"}"}]`))
+		default:
+			t.Errorf("unexpected NDFC request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client
+}
+
+func TestListVRFsAndGetNetworkVLAN(t *testing.T) {
+	srv := newFabricsTestServer(t, nil)
+	ctx := context.Background()
+	if _, err := srv.ListVRFs(ctx, &v1.ListVRFsRequest{FabricId: "fab-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("without NDFC client code = %v, want FailedPrecondition", status.Code(err))
+	}
+	srv.ndClient = newFakeNDFCClient(t)
+
+	// fab-1 is looked up by NDFC under its fabric name
+	resp, err := srv.ListVRFs(ctx, &v1.ListVRFsRequest{FabricId: "fab-1"})
+	if err != nil {
+		t.Fatalf("ListVRFs: %v", err)
+	}
+	if len(resp.Vrfs) != 1 || resp.Vrfs[0].Name != "hpc" || resp.Vrfs[0].VrfId != 50000 || resp.Vrfs[0].Tenant != "t1" {
+		t.Errorf("vrfs = %v", resp.Vrfs)
+	}
+	// Fabrics not synced locally resolve through NDFC by numeric ID or name
+	for _, id := range []string{"7", "DevNet_VxLAN_Fabric"} {
+		if resp, err := srv.ListVRFs(ctx, &v1.ListVRFsRequest{FabricId: id}); err != nil || len(resp.Vrfs) != 1 {
+			t.Errorf("ListVRFs(%s) = %v, %v, want one VRF", id, resp, err)
+		}
+	}
+	for _, id := range []string{"missing", "8"} {
+		if _, err := srv.ListVRFs(ctx, &v1.ListVRFsRequest{FabricId: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("missing fabric %s code = %v, want NotFound", id, status.Code(err))
+		}
+	}
+
+	networks, err := srv.ListNetworks(ctx, &v1.ListNetworksRequest{FabricId: "fab-1"})
+	if err != nil {
+		t.Fatalf("ListNetworks: %v", err)
+	}
+	if len(networks.Networks) != 1 || networks.Networks[0].Name != "hpcnet" {
+		t.Errorf("networks = %v", networks.Networks)
+	}
+
+	vlan, err := srv.GetNetworkVLAN(ctx, &v1.GetNetworkVLANRequest{FabricId: "fab-1", NetworkName: "hpcnet"})
+	if err != nil {
+		t.Fatalf("GetNetworkVLAN: %v", err)
+	}
+	if vlan.Vlan != "2301" {
+		t.Errorf("vlan = %q, want 2301", vlan.Vlan)
+	}
+	if _, err := srv.GetNetworkVLAN(ctx, &v1.GetNetworkVLANRequest{FabricId: "fab-1", NetworkName: "other"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing network code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.GetNetworkVLAN(ctx, &v1.GetNetworkVLANRequest{FabricId: "fab-1"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty network_name code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestBulkSyncPorts_Validation(t *testing.T) {
+	srv := newFabricsTestServer(t, nil)
+	ctx := context.Background()
+
+	if _, err := srv.BulkSyncPorts(ctx, &v1.BulkSyncPortsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty request code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := srv.BulkSyncPorts(ctx, &v1.BulkSyncPortsRequest{FabricId: "fab-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("no ND client code = %v, want FailedPrecondition", status.Code(err))
+	}
+	srv.ndClient = newFakeNDFCClient(t)
+	if _, err := srv.BulkSyncPorts(ctx, &v1.BulkSyncPortsRequest{FabricId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing fabric code = %v, want NotFound", status.Code(err))
+	}
+}
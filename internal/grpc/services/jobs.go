@@ -2,9 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
 
 	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/services"
 
 	"go.uber.org/zap"
@@ -14,11 +21,19 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// watchJobHeartbeatInterval is how long a WatchJob stream stays quiet before sending a heartbeat
+const watchJobHeartbeatInterval = 30 * time.Second
+
+// completeJobMaxRetries bounds retries when a concurrent request changes the job mid-completion
+const completeJobMaxRetries = 3
+
 // JobsServiceServer implements the gRPC JobsService.
 type JobsServiceServer struct {
 	v1.UnimplementedJobsServiceServer
 	svc    *services.JobService
 	logger *zap.Logger
+
+	heartbeatInterval time.Duration // WatchJob heartbeat period (0 = watchJobHeartbeatInterval)
 }
 
 // RegisterJobsService registers the JobsService with the gRPC server.
@@ -31,29 +46,106 @@ func RegisterJobsService(server *grpc.Server, svc *services.JobService, logger *
 
 // SubmitJob creates a new job and provisions security groups.
 func (s *JobsServiceServer) SubmitJob(ctx context.Context, req *v1.SubmitJobRequest) (*v1.SubmitJobResponse, error) {
-	if req.SlurmJobId == "" {
-		return nil, status.Error(codes.InvalidArgument, "slurm_job_id is required")
-	}
-	if len(req.ComputeNodes) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "compute_nodes is required")
+	if err := validateProvisionRequest(req.SlurmJobId, req.ComputeNodes, req.TimeoutMinutes, req.MinNodeFraction); err != nil {
+		return nil, err
 	}
 
 	result, err := s.svc.Provision(ctx, services.ProvisionInput{
-		SlurmJobID:   req.SlurmJobId,
-		Name:         req.Name,
-		Tenant:       req.Tenant,
-		ComputeNodes: req.ComputeNodes,
+		SlurmJobID:     req.SlurmJobId,
+		Name:           req.Name,
+		Description:    req.Description,
+		Tenant:         req.Tenant,
+		ComputeNodes:   req.ComputeNodes,
+		RequiredLabels: req.RequiredLabels,
+		TimeoutMinutes: int(req.TimeoutMinutes),
+		Tags:           req.Tags,
+
+		MinNodeFraction: float64(req.MinNodeFraction),
 	})
 	if err != nil {
 		return nil, mapError(err)
 	}
 
 	return &v1.SubmitJobResponse{
-		Job:     jobToProto(result.Job),
-		Created: result.Created,
+		Job:          jobToProto(result.Job),
+		Created:      result.Created,
+		SkippedNodes: result.SkippedNodes,
+	}, nil
+}
+
+// ProvisionJob creates and provisions a new job. Unlike SubmitJob, a job that already exists
+// is not returned but rejected with AlreadyExists.
+func (s *JobsServiceServer) ProvisionJob(ctx context.Context, req *v1.ProvisionJobRequest) (*v1.ProvisionJobResponse, error) {
+	if err := validateProvisionRequest(req.SlurmJobId, req.ComputeNodes, req.TimeoutMinutes, req.MinNodeFraction); err != nil {
+		return nil, err
+	}
+
+	contractRules := make([]services.ContractRuleSet, 0, len(req.ContractRules))
+	for _, set := range req.ContractRules {
+		rules := make([]ndclient.ContractRule, 0, len(set.Rules))
+		for _, rule := range set.Rules {
+			rules = append(rules, ndclient.ContractRule{
+				Direction:    rule.Direction,
+				Action:       rule.Action,
+				ProtocolName: rule.ProtocolName,
+			})
+		}
+		contractRules = append(contractRules, services.ContractRuleSet{Name: set.Name, Rules: rules})
+	}
+
+	result, err := s.svc.Provision(ctx, services.ProvisionInput{
+		SlurmJobID:     req.SlurmJobId,
+		Name:           req.Name,
+		Description:    req.Description,
+		Tenant:         req.Tenant,
+		ComputeNodes:   req.ComputeNodes,
+		RequiredLabels: req.RequiredLabels,
+		TimeoutMinutes: int(req.TimeoutMinutes),
+		ContractRules:  contractRules,
+		Tags:           req.Tags,
+
+		MinNodeFraction:        float64(req.MinNodeFraction),
+		SkipSelectorValidation: req.SkipSelectorValidation,
+	})
+	if err != nil {
+		return nil, mapProvisionError(err)
+	}
+	if !result.Created {
+		return nil, status.Errorf(codes.AlreadyExists, "job %s already exists with status %s", req.SlurmJobId, result.Job.Status)
+	}
+
+	return &v1.ProvisionJobResponse{
+		Job:          jobToProto(result.Job),
+		SkippedNodes: result.SkippedNodes,
 	}, nil
 }
 
+// mapProvisionError maps a Provision error for ProvisionJob, where another request still
+// submitting the same job means the job exists rather than that the call should be retried
+func mapProvisionError(err error) error {
+	if errors.Is(err, services.ErrJobSubmissionInProgress) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return mapError(err)
+}
+
+// validateProvisionRequest checks the fields SubmitJob and ProvisionJob share
+func validateProvisionRequest(slurmJobID string, computeNodes []string, timeoutMinutes int32, minNodeFraction float32) error {
+	if slurmJobID == "" {
+		return status.Error(codes.InvalidArgument, "slurm_job_id is required")
+	}
+	if len(computeNodes) == 0 {
+		return status.Error(codes.InvalidArgument, "compute_nodes is required")
+	}
+	if timeoutMinutes < 0 {
+		return status.Error(codes.InvalidArgument, "timeout_minutes must not be negative")
+	}
+	if math.IsNaN(float64(minNodeFraction)) || minNodeFraction < 0 || minNodeFraction > 1 {
+		return status.Error(codes.InvalidArgument, "min_node_fraction must be between 0 and 1")
+	}
+	return nil
+}
+
 // GetJob retrieves a job by Slurm job ID.
 func (s *JobsServiceServer) GetJob(ctx context.Context, req *v1.GetJobRequest) (*v1.GetJobResponse, error) {
 	if req.SlurmJobId == "" {
@@ -70,60 +162,103 @@ func (s *JobsServiceServer) GetJob(ctx context.Context, req *v1.GetJobRequest) (
 	}, nil
 }
 
-// ListJobs lists jobs with optional filtering.
-func (s *JobsServiceServer) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
-	// Determine status filter - use first status if multiple provided
-	statusFilter := ""
-	if len(req.Statuses) > 0 {
-		statusFilter = protoStatusToModel(req.Statuses[0])
+// BulkGetJobs retrieves up to 100 jobs by Slurm job ID with one database query.
+func (s *JobsServiceServer) BulkGetJobs(ctx context.Context, req *v1.BulkGetJobsRequest) (*v1.BulkGetJobsResponse, error) {
+	if len(req.SlurmJobIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "slurm_job_ids is required")
+	}
+	if len(req.SlurmJobIds) > services.MaxBulkGetJobs {
+		return nil, status.Error(codes.InvalidArgument, services.ErrTooManyJobIDs.Error())
 	}
 
-	jobs, err := s.svc.ListJobs(ctx, statusFilter)
+	jobs, err := s.svc.BulkGetJobs(ctx, req.SlurmJobIds)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	// Filter by fabric if specified
-	var filtered []models.Job
-	if req.FabricName != "" {
-		for _, j := range jobs {
-			if j.FabricName == req.FabricName {
-				filtered = append(filtered, j)
-			}
+	resp := &v1.BulkGetJobsResponse{Jobs: make(map[string]*v1.JobOrError, len(req.SlurmJobIds))}
+	for _, id := range req.SlurmJobIds {
+		if job, ok := jobs[id]; ok {
+			resp.Jobs[id] = &v1.JobOrError{Result: &v1.JobOrError_Job{Job: jobToProto(job)}}
+		} else {
+			resp.Jobs[id] = &v1.JobOrError{Result: &v1.JobOrError_Error{Error: "not found"}}
+		}
+	}
+	return resp, nil
+}
+
+// ListJobs lists jobs with optional filtering, newest first. page_token is the
+// next_page_token of the previous page.
+func (s *JobsServiceServer) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
+	opts := services.JobListOptions{FabricName: req.FabricName, Tags: req.Tags}
+	// Determine status filter - use first status if multiple provided
+	if len(req.Statuses) > 0 {
+		opts.Status = protoStatusToModel(req.Statuses[0])
+	}
+	if p := req.Pagination; p != nil {
+		if p.PageSize < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
 		}
-	} else {
-		filtered = jobs
+		opts.Limit = int(p.PageSize)
+		opts.Cursor = p.PageToken
 	}
 
-	protoJobs := make([]*v1.Job, len(filtered))
-	for i := range filtered {
-		protoJobs[i] = jobToProto(&filtered[i])
+	page, err := s.svc.ListJobs(ctx, opts)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidJobListing) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, mapError(err)
+	}
+
+	protoJobs := make([]*v1.Job, len(page.Jobs))
+	for i := range page.Jobs {
+		protoJobs[i] = jobToProto(&page.Jobs[i])
 	}
 
 	return &v1.ListJobsResponse{
 		Jobs: protoJobs,
+		Pagination: &v1.PaginationResponse{
+			NextPageToken: page.NextCursor,
+			TotalCount:    int32(page.TotalCount),
+		},
 	}, nil
 }
 
-// CompleteJob marks a job as completed.
+// CompleteJob records the Slurm exit status of a job and deprovisions it. Completing a
+// completed job returns it unchanged.
 func (s *JobsServiceServer) CompleteJob(ctx context.Context, req *v1.CompleteJobRequest) (*v1.CompleteJobResponse, error) {
 	if req.SlurmJobId == "" {
 		return nil, status.Error(codes.InvalidArgument, "slurm_job_id is required")
 	}
 
-	// Get the job first
-	job, err := s.svc.GetJob(ctx, req.SlurmJobId)
-	if err != nil {
-		return nil, mapError(err)
+	var completion services.JobCompletion
+	if req.ExitCode != nil {
+		exitCode := int(*req.ExitCode)
+		completion.ExitCode = &exitCode
 	}
+	if req.Signal != nil {
+		signal := int(*req.Signal)
+		completion.Signal = &signal
+	}
+	completion.FailedReason = req.FailedReason
 
-	// Deprovision the job
-	if err := s.svc.Deprovision(ctx, job); err != nil {
+	// Re-read the job on each attempt so a retry sees the winner's status and version
+	err := services.RetryOnConflict(completeJobMaxRetries, func() error {
+		job, err := s.svc.GetJob(ctx, req.SlurmJobId)
+		if err != nil {
+			return err
+		}
+		if job.Status == string(models.JobStatusCompleted) {
+			return nil
+		}
+		return s.svc.Complete(ctx, job, completion)
+	})
+	if err != nil {
 		return nil, mapError(err)
 	}
 
-	// Fetch updated job
-	job, err = s.svc.GetJob(ctx, req.SlurmJobId)
+	job, err := s.svc.GetJob(ctx, req.SlurmJobId)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -135,17 +270,171 @@ func (s *JobsServiceServer) CompleteJob(ctx context.Context, req *v1.CompleteJob
 
 // CleanupExpiredJobs removes expired jobs.
 func (s *JobsServiceServer) CleanupExpiredJobs(ctx context.Context, req *v1.CleanupExpiredJobsRequest) (*v1.CleanupExpiredJobsResponse, error) {
-	cleanedJobIDs, err := s.svc.CleanupExpiredJobs(ctx)
+	result, err := s.svc.CleanupExpiredJobs(ctx)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
+	failedJobIDs := make([]string, 0, len(result.Errors))
+	for id := range result.Errors {
+		failedJobIDs = append(failedJobIDs, id)
+	}
+	sort.Strings(failedJobIDs)
+
 	return &v1.CleanupExpiredJobsResponse{
-		CleanedCount:  int32(len(cleanedJobIDs)),
-		CleanedJobIds: cleanedJobIDs,
+		CleanedCount:  int32(len(result.Cleaned)),
+		CleanedJobIds: result.Cleaned,
+		FailedJobIds:  failedJobIDs,
+	}, nil
+}
+
+// UpdateJobMetadata changes the name, description and tags of a job. Only the fields in
+// update_mask are written; without a mask, the fields that are set are.
+func (s *JobsServiceServer) UpdateJobMetadata(ctx context.Context, req *v1.UpdateJobMetadataRequest) (*v1.UpdateJobMetadataResponse, error) {
+	if req.SlurmJobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "slurm_job_id is required")
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if req.Name != "" {
+			paths = append(paths, "name")
+		}
+		if req.Description != "" {
+			paths = append(paths, "description")
+		}
+		if len(req.Tags) > 0 {
+			paths = append(paths, "tags")
+		}
+	}
+
+	var update services.JobMetadataUpdate
+	for _, path := range paths {
+		switch path {
+		case "name":
+			update.Name = &req.Name
+		case "description":
+			update.Description = &req.Description
+		case "tags":
+			update.Tags = make(map[string]*string, len(req.Tags))
+			for key, value := range req.Tags {
+				value := value
+				update.Tags[key] = &value
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask path %q cannot be updated (allowed: name, description, tags)", path)
+		}
+	}
+
+	job, err := s.svc.UpdateJobMetadata(ctx, req.SlurmJobId, update)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &v1.UpdateJobMetadataResponse{
+		Job: jobToProto(job),
 	}, nil
 }
 
+// WatchJob streams the status changes of a job, published by the JobService on its Valkey
+// channel, and a heartbeat after each heartbeat interval without a change. The subscription
+// is closed when the client disconnects.
+func (s *JobsServiceServer) WatchJob(req *v1.WatchJobRequest, stream grpc.ServerStreamingServer[v1.JobEvent]) error {
+	if req.SlurmJobId == "" {
+		return status.Error(codes.InvalidArgument, "slurm_job_id is required")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	events := make(chan services.JobStatusEvent, 16)
+	subscribed := make(chan struct{})
+	watchDone := make(chan struct{})
+	var watchErr error
+	go func() {
+		defer close(watchDone)
+		watchErr = s.svc.WatchJobStatus(cache.WithSubscribed(ctx, func() { close(subscribed) }), req.SlurmJobId,
+			func(event services.JobStatusEvent) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+				}
+			})
+	}()
+	// Unsubscribe before returning, however the stream ends
+	defer func() {
+		cancel()
+		<-watchDone
+	}()
+
+	// Read the current status only once subscribed, so a change in between is streamed after
+	// it rather than lost (it may repeat the current status)
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-watchDone:
+		s.logger.Warn("Job event subscription failed", zap.String("slurm_job_id", req.SlurmJobId), zap.Error(watchErr))
+		return status.Error(codes.Unavailable, "job event subscription failed")
+	}
+	job, err := s.svc.GetJob(ctx, req.SlurmJobId)
+	if err != nil {
+		return mapError(err)
+	}
+
+	interval := s.heartbeatInterval
+	if interval <= 0 {
+		interval = watchJobHeartbeatInterval
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+
+	// Start with the current status; it has no previous status
+	if err := stream.Send(jobStatusEventToProto(services.JobStatusEvent{
+		SlurmJobID: job.SlurmJobID,
+		Status:     job.Status,
+		Timestamp:  time.Now().UTC(),
+	})); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-watchDone:
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			s.logger.Warn("Job event subscription ended", zap.String("slurm_job_id", req.SlurmJobId), zap.Error(watchErr))
+			return status.Error(codes.Unavailable, "job event subscription ended")
+		case event := <-events:
+			heartbeat.Reset(interval)
+			if err := stream.Send(jobStatusEventToProto(event)); err != nil {
+				return err
+			}
+		case now := <-heartbeat.C:
+			if err := stream.Send(&v1.JobEvent{
+				Type:       v1.JobEventType_JOB_EVENT_TYPE_HEARTBEAT,
+				SlurmJobId: req.SlurmJobId,
+				Timestamp:  timestamppb.New(now),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// jobStatusEventToProto converts a published status change to a proto JobEvent message.
+func jobStatusEventToProto(e services.JobStatusEvent) *v1.JobEvent {
+	return &v1.JobEvent{
+		Type:           v1.JobEventType_JOB_EVENT_TYPE_STATUS_CHANGED,
+		SlurmJobId:     e.SlurmJobID,
+		Status:         modelStatusToProto(e.Status),
+		PreviousStatus: modelStatusToProto(e.PreviousStatus),
+		ErrorMessage:   e.ErrorMessage,
+		Timestamp:      timestamppb.New(e.Timestamp),
+	}
+}
+
 // jobToProto converts a models.Job to a proto Job message.
 func jobToProto(j *models.Job) *v1.Job {
 	if j == nil {
@@ -156,12 +445,15 @@ func jobToProto(j *models.Job) *v1.Job {
 		Id:           j.ID,
 		SlurmJobId:   j.SlurmJobID,
 		Name:         j.Name,
+		Description:  j.Description,
 		TenantKey:    j.TenantKey,
 		Status:       modelStatusToProto(j.Status),
 		FabricName:   j.FabricName,
 		VrfName:      j.VRFName,
 		ContractName: j.ContractName,
 		SubmittedAt:  timestamppb.New(j.SubmittedAt),
+
+		ProvisionTimeoutMinutes: int32(j.ProvisionTimeoutMinutes),
 	}
 
 	if j.ErrorMessage != nil {
@@ -179,6 +471,9 @@ func jobToProto(j *models.Job) *v1.Job {
 	if j.SecurityGroupID != nil {
 		job.SecurityGroupId = *j.SecurityGroupID
 	}
+	if len(j.Tags) > 0 {
+		_ = json.Unmarshal(j.Tags, &job.Tags)
+	}
 
 	// Convert compute nodes
 	for _, cn := range j.ComputeNodes {
@@ -246,6 +541,24 @@ func mapError(err error) error {
 		return nil
 	}
 
+	if errors.Is(err, services.ErrMissingRequiredLabels) || errors.Is(err, services.ErrInvalidJobState) ||
+		errors.Is(err, services.ErrInsufficientNodes) || errors.Is(err, services.ErrComputeNodesDecommissioned) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, services.ErrConcurrentModification) || errors.Is(err, services.ErrJobSubmissionInProgress) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, services.ErrComputeNodesNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, services.ErrComputeNodesAllocated) || errors.Is(err, services.ErrNetworkOversubscribed) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, services.ErrInvalidContract) || errors.Is(err, services.ErrInvalidJobMetadata) ||
+		errors.Is(err, services.ErrInvalidMinNodeFraction) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Check for common error patterns
 	errStr := err.Error()
 
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/services"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestJobsUpdateJobMetadata_FieldMask(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Job{}, &models.JobComputeNode{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db, &models.Job{ID: "j1", SlurmJobID: "100", Name: "old", Description: "keep me",
+		Status: string(models.JobStatusActive), UpdatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Tags: []byte(`{"project": "climate", "queue": "gpu"}`)})
+	server := &JobsServiceServer{svc: services.NewJobService(db, nil, &config.NexusDashboardConfig{}, nil), logger: zap.NewNop()}
+
+	// Only masked fields are written: description is cleared, name is left alone
+	resp, err := server.UpdateJobMetadata(context.Background(), &v1.UpdateJobMetadataRequest{
+		SlurmJobId: "100",
+		Name:       "ignored",
+		Tags:       map[string]string{"queue": "", "owner": "hpc-team"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "tags"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateJobMetadata: %v", err)
+	}
+	job := resp.Job
+	if job.Name != "old" || job.Description != "" || len(job.Tags) != 2 || job.Tags["project"] != "climate" || job.Tags["owner"] != "hpc-team" {
+		t.Errorf("job = %q %q %v", job.Name, job.Description, job.Tags)
+	}
+	if job.Status != v1.JobStatus_JOB_STATUS_ACTIVE {
+		t.Errorf("status = %v, want unchanged", job.Status)
+	}
+	var stored models.Job
+	if err := db.First(&stored, "id = ?", "j1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.UpdatedAt.Year() == 2020 {
+		t.Error("updated_at not bumped")
+	}
+
+	// Without a mask, the fields that are set are written
+	resp, err = server.UpdateJobMetadata(context.Background(), &v1.UpdateJobMetadataRequest{SlurmJobId: "100", Name: "new"})
+	if err != nil {
+		t.Fatalf("UpdateJobMetadata without mask: %v", err)
+	}
+	if resp.Job.Name != "new" || len(resp.Job.Tags) != 2 {
+		t.Errorf("job = %q %v, want name set and tags kept", resp.Job.Name, resp.Job.Tags)
+	}
+
+	tests := map[string]struct {
+		req  *v1.UpdateJobMetadataRequest
+		code codes.Code
+	}{
+		"status path": {&v1.UpdateJobMetadataRequest{SlurmJobId: "100", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}}}, codes.InvalidArgument},
+		"empty tag":   {&v1.UpdateJobMetadataRequest{SlurmJobId: "100", Tags: map[string]string{"": "x"}}, codes.InvalidArgument},
+		"no job id":   {&v1.UpdateJobMetadataRequest{Name: "x"}, codes.InvalidArgument},
+		"unknown job": {&v1.UpdateJobMetadataRequest{SlurmJobId: "999", Name: "x"}, codes.NotFound},
+	}
+	for name, tt := range tests {
+		if _, err := server.UpdateJobMetadata(context.Background(), tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: %v, want %v", name, err, tt.code)
+		}
+	}
+}
+
+func TestJobsListJobs_Pagination(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Job{}, &models.JobComputeNode{}, &models.SecurityGroup{},
+		&models.PortSelector{}, &models.SwitchPort{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		fabric := "f1"
+		if i == 5 {
+			fabric = "f2"
+		}
+		seed(t, db, &models.Job{ID: fmt.Sprintf("j%d", i), SlurmJobID: fmt.Sprint(i), Status: string(models.JobStatusActive),
+			FabricName: fabric, SubmittedAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+	server := &JobsServiceServer{svc: services.NewJobService(db, nil, &config.NexusDashboardConfig{}, nil), logger: zap.NewNop()}
+
+	// Walking the pages returns every job once, newest first
+	var got []string
+	req := &v1.ListJobsRequest{Pagination: &v1.PaginationRequest{PageSize: 2}}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("page walk did not end: %v", got)
+		}
+		resp, err := server.ListJobs(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListJobs: %v", err)
+		}
+		if resp.Pagination.TotalCount != 5 {
+			t.Errorf("total_count = %d, want 5", resp.Pagination.TotalCount)
+		}
+		for _, j := range resp.Jobs {
+			got = append(got, j.SlurmJobId)
+		}
+		if resp.Pagination.NextPageToken == "" {
+			break
+		}
+		req.Pagination.PageToken = resp.Pagination.NextPageToken
+	}
+	if want := []string{"5", "4", "3", "2", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jobs = %v, want %v", got, want)
+	}
+
+	tests := map[string]struct {
+		req      *v1.ListJobsRequest
+		code     codes.Code
+		wantJobs int
+	}{
+		"no pagination": {&v1.ListJobsRequest{}, codes.OK, 5},
+		"fabric filter": {&v1.ListJobsRequest{FabricName: "f1", Pagination: &v1.PaginationRequest{PageSize: 3}}, codes.OK, 3},
+		"bad token":     {&v1.ListJobsRequest{Pagination: &v1.PaginationRequest{PageToken: "garbage!"}}, codes.InvalidArgument, 0},
+		"negative size": {&v1.ListJobsRequest{Pagination: &v1.PaginationRequest{PageSize: -1}}, codes.InvalidArgument, 0},
+	}
+	for name, tt := range tests {
+		resp, err := server.ListJobs(context.Background(), tt.req)
+		if status.Code(err) != tt.code {
+			t.Errorf("%s: %v, want %v", name, err, tt.code)
+			continue
+		}
+		if err == nil && len(resp.Jobs) != tt.wantJobs {
+			t.Errorf("%s: %d jobs, want %d", name, len(resp.Jobs), tt.wantJobs)
+		}
+	}
+}
+
+// watchJobStream collects the events a WatchJob handler sends
+type watchJobStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *v1.JobEvent
+}
+
+func (s *watchJobStream) Context() context.Context { return s.ctx }
+
+func (s *watchJobStream) Send(event *v1.JobEvent) error {
+	s.events <- event
+	return nil
+}
+
+func TestJobsWatchJob(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Job{}, &models.JobComputeNode{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db, &models.Job{ID: "j1", SlurmJobID: "100", Status: string(models.JobStatusActive)})
+	store := cachetest.NewInMemoryCache()
+	svc := services.NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetStatusEventStore(store)
+	server := &JobsServiceServer{svc: svc, logger: zap.NewNop(), heartbeatInterval: 20 * time.Millisecond}
+
+	if err := server.WatchJob(&v1.WatchJobRequest{SlurmJobId: "999"}, &watchJobStream{ctx: context.Background()}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown job: %v, want NotFound", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchJobStream{ctx: ctx, events: make(chan *v1.JobEvent, 100)}
+	done := make(chan error, 1)
+	go func() { done <- server.WatchJob(&v1.WatchJobRequest{SlurmJobId: "100"}, stream) }()
+
+	// The current status comes first, without a previous status
+	event := <-stream.events
+	if event.Type != v1.JobEventType_JOB_EVENT_TYPE_STATUS_CHANGED || event.Status != v1.JobStatus_JOB_STATUS_ACTIVE ||
+		event.PreviousStatus != v1.JobStatus_JOB_STATUS_UNSPECIFIED || event.Timestamp == nil {
+		t.Errorf("initial event = %v, want the current status", event)
+	}
+
+	// Quiet stream: heartbeats only
+	event = <-stream.events
+	if event.Type != v1.JobEventType_JOB_EVENT_TYPE_HEARTBEAT || event.SlurmJobId != "100" || event.Timestamp == nil {
+		t.Errorf("first event = %v, want a heartbeat", event)
+	}
+
+	// Publish until the (asynchronous) subscription delivers the status change
+	message := `{"slurm_job_id": "100", "status": "deprovisioning", "previous_status": "active", "timestamp": "2026-10-01T12:00:00Z"}`
+	deadline := time.After(5 * time.Second)
+	for event.Type != v1.JobEventType_JOB_EVENT_TYPE_STATUS_CHANGED {
+		_ = store.Publish(ctx, cache.JobEventsChannel("100"), message)
+		select {
+		case event = <-stream.events:
+		case <-deadline:
+			t.Fatal("status change not streamed")
+		}
+	}
+	if event.Status != v1.JobStatus_JOB_STATUS_DEPROVISIONING || event.PreviousStatus != v1.JobStatus_JOB_STATUS_ACTIVE ||
+		!event.Timestamp.AsTime().Equal(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("status event = %v", event)
+	}
+
+	// Disconnecting ends the stream and its subscription
+	cancel()
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("WatchJob = %v, want Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchJob did not return after the client disconnected")
+	}
+}
+
+// subscribeHookStore runs beforeSubscribe when a subscription starts, before it is registered
+type subscribeHookStore struct {
+	cache.CacheClient
+	beforeSubscribe func()
+}
+
+func (s *subscribeHookStore) Subscribe(ctx context.Context, channels []string, handler func(channel, message string)) error {
+	s.beforeSubscribe()
+	return s.CacheClient.Subscribe(ctx, channels, handler)
+}
+
+// TestJobsWatchJob_TransitionWhileSubscribing tests that a status change published while the
+// subscription is being set up is not lost: the initial event already has the new status
+func TestJobsWatchJob_TransitionWhileSubscribing(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Job{}, &models.JobComputeNode{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db, &models.Job{ID: "j1", SlurmJobID: "100", Status: string(models.JobStatusActive)})
+	store := &subscribeHookStore{CacheClient: cachetest.NewInMemoryCache()}
+	store.beforeSubscribe = func() {
+		if err := db.Model(&models.Job{}).Where("slurm_job_id = ?", "100").
+			Update("status", string(models.JobStatusDeprovisioning)).Error; err != nil {
+			t.Error(err)
+		}
+		_ = store.Publish(context.Background(), cache.JobEventsChannel("100"),
+			`{"slurm_job_id": "100", "status": "deprovisioning", "previous_status": "active"}`)
+	}
+	svc := services.NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetStatusEventStore(store)
+	server := &JobsServiceServer{svc: svc, logger: zap.NewNop(), heartbeatInterval: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchJobStream{ctx: ctx, events: make(chan *v1.JobEvent, 100)}
+	go func() { _ = server.WatchJob(&v1.WatchJobRequest{SlurmJobId: "100"}, stream) }()
+
+	select {
+	case event := <-stream.events:
+		if event.Type != v1.JobEventType_JOB_EVENT_TYPE_STATUS_CHANGED || event.Status != v1.JobStatus_JOB_STATUS_DEPROVISIONING {
+			t.Errorf("initial event = %v, want the status changed while subscribing", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no initial event")
+	}
+}
+
+// startJobsServer serves svc's JobsService on a loopback listener and returns a client for it
+func startJobsServer(t *testing.T, svc *services.JobService) v1.JobsServiceClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	RegisterJobsService(server, svc, zap.NewNop())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return v1.NewJobsServiceClient(conn)
+}
+
+func TestJobsProvisionAndCompleteJob(t *testing.T) {
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.Switch{}, &models.SwitchPort{}, &models.ComputeNodeLabel{}, &models.Job{},
+		&models.JobComputeNode{}, &models.ComputeNodeAllocation{}, &models.SecurityGroup{}, &models.PortSelector{},
+		&models.SecurityAssociation{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db,
+		&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "s1"},
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1"},
+		&models.Job{ID: "j2", SlurmJobID: "200", Status: string(models.JobStatusActive), FabricName: "f1"},
+	)
+	// Without NDFC a provisioned job stays provisioning, with its nodes allocated
+	client := startJobsServer(t, services.NewJobService(db, nil, &config.NexusDashboardConfig{ComputeFabricName: "f1"}, nil))
+	ctx := context.Background()
+
+	resp, err := client.ProvisionJob(ctx, &v1.ProvisionJobRequest{
+		SlurmJobId:   "100",
+		Name:         "train",
+		ComputeNodes: []string{"node1"},
+		Tags:         map[string]string{"account": "phys101"},
+	})
+	if err != nil {
+		t.Fatalf("ProvisionJob: %v", err)
+	}
+	job := resp.Job
+	if job.SlurmJobId != "100" || job.Name != "train" || job.Tags["account"] != "phys101" ||
+		len(job.ComputeNodes) != 1 || job.ComputeNodes[0].ComputeNodeId != "n1" {
+		t.Errorf("job = %v", job)
+	}
+
+	tests := map[string]struct {
+		req  *v1.ProvisionJobRequest
+		code codes.Code
+	}{
+		"existing job":   {&v1.ProvisionJobRequest{SlurmJobId: "100", ComputeNodes: []string{"node1"}}, codes.AlreadyExists},
+		"unknown node":   {&v1.ProvisionJobRequest{SlurmJobId: "101", ComputeNodes: []string{"node9"}}, codes.NotFound},
+		"allocated node": {&v1.ProvisionJobRequest{SlurmJobId: "102", ComputeNodes: []string{"node1"}}, codes.ResourceExhausted},
+		"no job id":      {&v1.ProvisionJobRequest{ComputeNodes: []string{"node1"}}, codes.InvalidArgument},
+		"no nodes":       {&v1.ProvisionJobRequest{SlurmJobId: "103"}, codes.InvalidArgument},
+		"invalid contract": {&v1.ProvisionJobRequest{SlurmJobId: "104", ComputeNodes: []string{"node1"},
+			ContractRules: []*v1.JobContractRuleSet{{Name: "web"}}}, codes.InvalidArgument},
+		"duplicate contract": {&v1.ProvisionJobRequest{SlurmJobId: "105", ComputeNodes: []string{"node1"},
+			ContractRules: []*v1.JobContractRuleSet{
+				{Name: "web", Rules: []*v1.JobContractRule{{Direction: "bidirectional", Action: "permit"}}},
+				{Name: "web", Rules: []*v1.JobContractRule{{Direction: "bidirectional", Action: "permit"}}},
+			}}, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		if _, err := client.ProvisionJob(ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: %v, want %v", name, err, tt.code)
+		}
+	}
+
+	exitCode := int32(1)
+	reason := "OOM"
+	completed, err := client.CompleteJob(ctx, &v1.CompleteJobRequest{SlurmJobId: "200", ExitCode: &exitCode, FailedReason: &reason})
+	if err != nil {
+		t.Fatalf("CompleteJob: %v", err)
+	}
+	if completed.Job.Status != v1.JobStatus_JOB_STATUS_COMPLETED || completed.Job.ErrorMessage != "Slurm exit code 1: OOM" {
+		t.Errorf("completed job = %v %q", completed.Job.Status, completed.Job.ErrorMessage)
+	}
+	// Completing it again returns it unchanged
+	if again, err := client.CompleteJob(ctx, &v1.CompleteJobRequest{SlurmJobId: "200"}); err != nil ||
+		again.Job.Status != v1.JobStatus_JOB_STATUS_COMPLETED || again.Job.ErrorMessage != completed.Job.ErrorMessage {
+		t.Errorf("second CompleteJob = %v, %v", again, err)
+	}
+	if _, err := client.CompleteJob(ctx, &v1.CompleteJobRequest{SlurmJobId: "999"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown job: %v, want NotFound", err)
+	}
+}
+
+func TestMapProvisionError(t *testing.T) {
+	inFlight := fmt.Errorf("%w: job 100", services.ErrJobSubmissionInProgress)
+	if code := status.Code(mapProvisionError(inFlight)); code != codes.AlreadyExists {
+		t.Errorf("ProvisionJob in flight = %v, want AlreadyExists", code)
+	}
+	if code := status.Code(mapError(inFlight)); code != codes.Aborted {
+		t.Errorf("SubmitJob in flight = %v, want Aborted", code)
+	}
+	if code := status.Code(mapProvisionError(services.ErrComputeNodesAllocated)); code != codes.ResourceExhausted {
+		t.Errorf("allocated nodes = %v, want ResourceExhausted", code)
+	}
+	fraction := fmt.Errorf("%w, got NaN", services.ErrInvalidMinNodeFraction)
+	if code := status.Code(mapProvisionError(fraction)); code != codes.InvalidArgument {
+		t.Errorf("invalid min node fraction = %v, want InvalidArgument", code)
+	}
+}
+
+func TestValidateProvisionRequest_MinNodeFraction(t *testing.T) {
+	for _, f := range []float32{-0.1, 1.5, float32(math.NaN())} {
+		if code := status.Code(validateProvisionRequest("100", []string{"node1"}, 0, f)); code != codes.InvalidArgument {
+			t.Errorf("min_node_fraction %v = %v, want InvalidArgument", f, code)
+		}
+	}
+	if err := validateProvisionRequest("100", []string{"node1"}, 0, 0.5); err != nil {
+		t.Errorf("min_node_fraction 0.5: %v", err)
+	}
+}
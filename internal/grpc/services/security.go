@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SecurityServiceServer implements the gRPC SecurityService.
+type SecurityServiceServer struct {
+	v1.UnimplementedSecurityServiceServer
+	contracts *services.ContractService
+	groups    *services.SecurityGroupService
+	logger    *zap.Logger
+}
+
+// RegisterSecurityService registers the SecurityService with the gRPC server.
+func RegisterSecurityService(server *grpc.Server, contracts *services.ContractService, groups *services.SecurityGroupService, logger *zap.Logger) {
+	v1.RegisterSecurityServiceServer(server, &SecurityServiceServer{
+		contracts: contracts,
+		groups:    groups,
+		logger:    logger,
+	})
+}
+
+// CloneContract duplicates a contract's rules under a new name.
+func (s *SecurityServiceServer) CloneContract(ctx context.Context, req *v1.CloneContractRequest) (*v1.CloneContractResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.NewName == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_name is required")
+	}
+
+	contract, err := s.contracts.CloneContract(ctx, req.Id, req.NewName, req.FabricName)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &v1.CloneContractResponse{
+		Contract: contractToProto(contract),
+	}, nil
+}
+
+// GetSecurityGroups is the deprecated name of ListSecurityGroups.
+func (s *SecurityServiceServer) GetSecurityGroups(ctx context.Context, req *v1.GetSecurityGroupsRequest) (*v1.GetSecurityGroupsResponse, error) {
+	resp, err := s.ListSecurityGroups(ctx, &v1.ListSecurityGroupsRequest{
+		FabricName:   req.FabricName,
+		Fabric:       req.Fabric,
+		NameContains: req.NameContains,
+		Sort:         req.Sort,
+		Pagination:   req.Pagination,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetSecurityGroupsResponse{Groups: resp.Groups, Pagination: resp.Pagination}, nil
+}
+
+// ListSecurityGroups lists security groups from NDFC when fabric_name is set, otherwise
+// from the local DB. page_token is the offset returned as next_page_token.
+func (s *SecurityServiceServer) ListSecurityGroups(ctx context.Context, req *v1.ListSecurityGroupsRequest) (*v1.ListSecurityGroupsResponse, error) {
+	opts := services.SecurityGroupListOptions{
+		NameContains: req.NameContains,
+		Fabric:       req.Fabric,
+		Sort:         req.Sort,
+	}
+	if p := req.Pagination; p != nil {
+		opts.Limit = int(p.PageSize)
+		if p.PageToken != "" {
+			offset, err := strconv.Atoi(p.PageToken)
+			if err != nil || offset < 0 {
+				return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+			}
+			opts.Offset = offset
+		}
+	}
+
+	var groups []*v1.SecurityGroup
+	var total int64
+	if req.FabricName != "" {
+		page, n, err := s.groups.ListNDFC(ctx, req.FabricName, opts)
+		if err != nil {
+			return nil, mapSecurityGroupListError(err)
+		}
+		for i := range page {
+			groups = append(groups, ndfcSecurityGroupToProto(&page[i]))
+		}
+		total = n
+	} else {
+		page, n, err := s.groups.List(ctx, opts)
+		if err != nil {
+			return nil, mapSecurityGroupListError(err)
+		}
+		for i := range page {
+			groups = append(groups, securityGroupToProto(&page[i]))
+		}
+		total = n
+	}
+
+	pagination := &v1.PaginationResponse{TotalCount: int32(total)}
+	if next := opts.Offset + len(groups); len(groups) > 0 && int64(next) < total {
+		pagination.NextPageToken = strconv.Itoa(next)
+	}
+	return &v1.ListSecurityGroupsResponse{Groups: groups, Pagination: pagination}, nil
+}
+
+// GetSecurityGroup gets a local security group by ID, or an NDFC group by name when fabric_name is set.
+func (s *SecurityServiceServer) GetSecurityGroup(ctx context.Context, req *v1.GetSecurityGroupRequest) (*v1.GetSecurityGroupResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if req.FabricName != "" {
+		group, err := s.groups.GetNDFC(ctx, req.FabricName, req.Id)
+		if err != nil {
+			return nil, mapSecurityGroupError(err)
+		}
+		return &v1.GetSecurityGroupResponse{Group: ndfcSecurityGroupToProto(group)}, nil
+	}
+
+	group, err := s.groups.Get(ctx, req.Id)
+	if err != nil {
+		return nil, mapSecurityGroupError(err)
+	}
+	return &v1.GetSecurityGroupResponse{Group: securityGroupToProto(group)}, nil
+}
+
+// CreateSecurityGroup creates a security group in NDFC and records it locally.
+func (s *SecurityServiceServer) CreateSecurityGroup(ctx context.Context, req *v1.CreateSecurityGroupRequest) (*v1.CreateSecurityGroupResponse, error) {
+	if req.GroupName == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_name is required")
+	}
+	if req.FabricName == "" {
+		return nil, status.Error(codes.InvalidArgument, "fabric_name is required")
+	}
+
+	group := &ndclient.SecurityGroup{
+		FabricName:   req.FabricName,
+		SourceFabric: req.SourceFabric,
+		GroupName:    req.GroupName,
+		Attach:       req.Attach,
+	}
+	for _, sel := range req.IpSelectors {
+		group.IPSelectors = append(group.IPSelectors, ndclient.IPSelector{
+			Type:       sel.Type,
+			IP:         sel.Ip,
+			VRFName:    sel.VrfName,
+			CreatedBy:  sel.CreatedBy,
+			DeletedStr: "-",
+		})
+	}
+	for _, sel := range req.NetworkSelectors {
+		group.NetworkSelectors = append(group.NetworkSelectors, ndclient.NetworkSelector{
+			VRFName: sel.VrfName,
+			Network: sel.Network,
+		})
+	}
+	for _, sel := range req.NetworkPortSelectors {
+		group.NetworkPortSelectors = append(group.NetworkPortSelectors, ndclient.NetworkPortSelector{
+			Network:       sel.Network,
+			SwitchID:      sel.SwitchId,
+			InterfaceName: sel.InterfaceName,
+		})
+	}
+
+	local, remote, err := s.groups.Create(ctx, group)
+	if err != nil {
+		return nil, mapSecurityGroupError(err)
+	}
+
+	pb := securityGroupToProto(local)
+	created := ndfcSecurityGroupToProto(remote)
+	pb.SourceFabric = created.SourceFabric
+	pb.Attach = created.Attach
+	pb.IpSelectors = created.IpSelectors
+	pb.NetworkSelectors = created.NetworkSelectors
+	pb.NetworkPortSelectors = created.NetworkPortSelectors
+	pb.VmInstanceUuidSelectors = created.VmInstanceUuidSelectors
+	return &v1.CreateSecurityGroupResponse{Group: pb}, nil
+}
+
+// DeleteSecurityGroup deletes a security group and its associations from NDFC and the local DB.
+func (s *SecurityServiceServer) DeleteSecurityGroup(ctx context.Context, req *v1.DeleteSecurityGroupRequest) (*v1.DeleteSecurityGroupResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := s.groups.Delete(ctx, req.Id); err != nil {
+		return nil, mapSecurityGroupError(err)
+	}
+	return &v1.DeleteSecurityGroupResponse{}, nil
+}
+
+func mapSecurityGroupError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrSecurityGroupNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidSecurityGroup), errors.Is(err, services.ErrInvalidGroupObjectID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func mapSecurityGroupListError(err error) error {
+	if errors.Is(err, services.ErrInvalidGroupListing) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// securityGroupToProto converts a models.SecurityGroup to proto.
+func securityGroupToProto(g *models.SecurityGroup) *v1.SecurityGroup {
+	return &v1.SecurityGroup{
+		Id:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		NdObjectId:  g.NDObjectID,
+		FabricName:  g.FabricName,
+		CreatedAt:   timestamppb.New(g.CreatedAt),
+		UpdatedAt:   timestamppb.New(g.UpdatedAt),
+	}
+}
+
+// ndfcSecurityGroupToProto converts a security group read from NDFC to proto.
+func ndfcSecurityGroupToProto(g *ndclient.SecurityGroup) *v1.SecurityGroup {
+	pb := &v1.SecurityGroup{
+		Name:         g.GroupName,
+		FabricName:   g.FabricName,
+		SourceFabric: g.SourceFabric,
+		Attach:       g.Attach,
+	}
+	if g.GroupID != nil {
+		pb.NdObjectId = strconv.Itoa(*g.GroupID)
+	}
+	for _, sel := range g.IPSelectors {
+		pb.IpSelectors = append(pb.IpSelectors, &v1.IPSelector{
+			Type:       sel.Type,
+			Ip:         sel.IP,
+			VrfName:    sel.VRFName,
+			CreatedBy:  sel.CreatedBy,
+			DeletedStr: sel.DeletedStr,
+		})
+	}
+	for _, sel := range g.NetworkSelectors {
+		pb.NetworkSelectors = append(pb.NetworkSelectors, &v1.NetworkSelector{
+			VrfName: sel.VRFName,
+			Network: sel.Network,
+		})
+	}
+	for _, sel := range g.NetworkPortSelectors {
+		pb.NetworkPortSelectors = append(pb.NetworkPortSelectors, &v1.NetworkPortSelector{
+			Network:       sel.Network,
+			SwitchId:      sel.SwitchID,
+			InterfaceName: sel.InterfaceName,
+		})
+	}
+	for _, sel := range g.VMInstanceUUIDSelectors {
+		pb.VmInstanceUuidSelectors = append(pb.VmInstanceUuidSelectors, &v1.VMInstanceUUIDSelector{
+			VCenter:        sel.VCenter,
+			VmUuid:         sel.VMUUID,
+			VmNicMac:       sel.VMNicMac,
+			VmNicName:      sel.VMNicName,
+			Vlan:           sel.VLAN,
+			Ip:             sel.IP,
+			Network:        sel.Network,
+			VrfName:        sel.VRFName,
+			Host:           sel.Host,
+			Switch:         sel.Switch,
+			SwitchIntf:     sel.SwitchIntf,
+			Name:           sel.Name,
+			ConfiguredSgid: sel.ConfiguredSgid,
+			Type:           sel.Type,
+			CreatedBy:      sel.CreatedBy,
+			DeletedStr:     sel.DeletedStr,
+		})
+	}
+	return pb
+}
+
+// contractToProto converts a models.SecurityContract to proto.
+func contractToProto(c *models.SecurityContract) *v1.SecurityContract {
+	if c == nil {
+		return nil
+	}
+
+	rules := make([]*v1.ContractRule, len(c.Rules))
+	for i, r := range c.Rules {
+		rules[i] = &v1.ContractRule{
+			Id:       r.ID,
+			Name:     r.Name,
+			Action:   r.Action,
+			Protocol: r.Protocol,
+			SrcPort:  r.SrcPort,
+			DstPort:  r.DstPort,
+			Priority: int32(r.Priority),
+		}
+	}
+
+	return &v1.SecurityContract{
+		Id:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		NdObjectId:  c.NDObjectID,
+		FabricName:  c.FabricName,
+		Rules:       rules,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/banglin/go-nd/gen/go_nd/v1"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newSecurityTestServer serves two NDFC security groups and seeds one local group.
+// The returned counter reports how many NDFC group listings were requested.
+func newSecurityTestServer(t *testing.T) (*SecurityServiceServer, *atomic.Int32) {
+	t.Helper()
+	db := useSQLiteDB(t)
+	if err := db.AutoMigrate(&models.SecurityGroup{}, &models.PortSelector{}, &models.SecurityAssociation{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	seed(t, db, &models.SecurityGroup{ID: "g1", Name: "local-group", NDObjectID: "7", FabricName: "f1"})
+
+	var ndfcListings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups") {
+			ndfcListings.Add(1)
+			_, _ = w.Write([]byte(`[
+				{"fabricName": "f1", "groupId": 11, "groupName": "ndfc-b",
+				 "networkPortSelectors": [{"network": "net1", "switchId": "SN1", "interfaceName": "Ethernet1/1"}]},
+				{"fabricName": "f1", "groupId": 10, "groupName": "ndfc-a"}
+			]`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	return &SecurityServiceServer{
+		groups: services.NewSecurityGroupService(db, client),
+		logger: zap.NewNop(),
+	}, &ndfcListings
+}
+
+func TestListSecurityGroups_FabricNameReadsNDFC(t *testing.T) {
+	srv, ndfcListings := newSecurityTestServer(t)
+
+	resp, err := srv.ListSecurityGroups(context.Background(), &v1.ListSecurityGroupsRequest{FabricName: "f1"})
+	if err != nil {
+		t.Fatalf("ListSecurityGroups: %v", err)
+	}
+	if n := ndfcListings.Load(); n != 1 {
+		t.Errorf("NDFC listings = %d, want 1", n)
+	}
+	if len(resp.Groups) != 2 || resp.Groups[0].Name != "ndfc-a" || resp.Groups[1].Name != "ndfc-b" {
+		t.Fatalf("groups = %v, want ndfc-a, ndfc-b", resp.Groups)
+	}
+	if resp.Groups[1].NdObjectId != "11" || len(resp.Groups[1].NetworkPortSelectors) != 1 ||
+		resp.Groups[1].NetworkPortSelectors[0].InterfaceName != "Ethernet1/1" {
+		t.Errorf("ndfc-b = %v", resp.Groups[1])
+	}
+	if resp.Pagination.GetTotalCount() != 2 {
+		t.Errorf("total = %d, want 2", resp.Pagination.GetTotalCount())
+	}
+}
+
+func TestListSecurityGroups_WithoutFabricNameReadsLocalDB(t *testing.T) {
+	srv, ndfcListings := newSecurityTestServer(t)
+
+	resp, err := srv.ListSecurityGroups(context.Background(), &v1.ListSecurityGroupsRequest{})
+	if err != nil {
+		t.Fatalf("ListSecurityGroups: %v", err)
+	}
+	if n := ndfcListings.Load(); n != 0 {
+		t.Errorf("NDFC listings = %d, want 0", n)
+	}
+	if len(resp.Groups) != 1 || resp.Groups[0].Id != "g1" || resp.Groups[0].Name != "local-group" {
+		t.Errorf("groups = %v, want local-group", resp.Groups)
+	}
+}
+
+func TestListSecurityGroups_Pagination(t *testing.T) {
+	srv, _ := newSecurityTestServer(t)
+
+	resp, err := srv.ListSecurityGroups(context.Background(), &v1.ListSecurityGroupsRequest{
+		FabricName: "f1",
+		Pagination: &v1.PaginationRequest{PageSize: 1},
+	})
+	if err != nil {
+		t.Fatalf("ListSecurityGroups: %v", err)
+	}
+	if len(resp.Groups) != 1 || resp.Pagination.NextPageToken != "1" {
+		t.Fatalf("first page = %v, next = %q", resp.Groups, resp.Pagination.NextPageToken)
+	}
+
+	_, err = srv.ListSecurityGroups(context.Background(), &v1.ListSecurityGroupsRequest{
+		Pagination: &v1.PaginationRequest{PageToken: "abc"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad page_token code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestGetSecurityGroup(t *testing.T) {
+	srv, _ := newSecurityTestServer(t)
+	ctx := context.Background()
+
+	resp, err := srv.GetSecurityGroup(ctx, &v1.GetSecurityGroupRequest{Id: "g1"})
+	if err != nil || resp.Group.Name != "local-group" {
+		t.Errorf("local get = %v, %v", resp, err)
+	}
+	resp, err = srv.GetSecurityGroup(ctx, &v1.GetSecurityGroupRequest{Id: "ndfc-a", FabricName: "f1"})
+	if err != nil || resp.Group.NdObjectId != "10" {
+		t.Errorf("NDFC get = %v, %v", resp, err)
+	}
+
+	if _, err := srv.GetSecurityGroup(ctx, &v1.GetSecurityGroupRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing local code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.GetSecurityGroup(ctx, &v1.GetSecurityGroupRequest{Id: "missing", FabricName: "f1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing NDFC code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestCreateSecurityGroup_InvalidPortSelector(t *testing.T) {
+	srv, _ := newSecurityTestServer(t)
+
+	_, err := srv.CreateSecurityGroup(context.Background(), &v1.CreateSecurityGroupRequest{
+		GroupName:  "g",
+		FabricName: "f1",
+		NetworkPortSelectors: []*v1.NetworkPortSelector{
+			{Network: "net1", SwitchId: "SN1", InterfaceName: "mgmt0"},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
+	}
+}
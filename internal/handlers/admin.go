@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/sync"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles HTTP requests for operational endpoints
+type AdminHandler struct {
+	fabricName string // Fabric synced by the background worker
+}
+
+// NewAdminHandler creates a new AdminHandler; fabricName is the fabric synced by the background worker
+func NewAdminHandler(fabricName string) *AdminHandler {
+	return &AdminHandler{fabricName: fabricName}
+}
+
+// GetSyncLeader reports which instance currently leads background sync.
+// Optional ?fabric= selects the fabric (defaults to the configured sync fabric).
+// leader is null when no instance holds leadership.
+func (h *AdminHandler) GetSyncLeader(c *gin.Context) {
+	if cache.Client == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Valkey not configured; leader election disabled"})
+		return
+	}
+
+	fabric := c.DefaultQuery("fabric", h.fabricName)
+	key := sync.LeaderKey(fabric)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	leader, err := sync.CurrentLeader(ctx, cache.Client, key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := gin.H{"fabric": fabric, "key": key, "leader": nil}
+	if leader != "" {
+		resp["leader"] = leader
+		if ttl, err := cache.Client.PTTL(ctx, key); err == nil && ttl > 0 {
+			resp["ttl_ms"] = ttl.Milliseconds()
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -2,28 +2,52 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/logger"
 	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
 	"github.com/banglin/go-nd/internal/services"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type ComputeHandler struct {
 	storageService *services.StorageService
+	connectivity   *services.ConnectivityService
+	bmc            *services.BMCService
+	imports        *services.NodeImportService
+	ndClient       *ndclient.Client // Optional; used to detect NIC names from LLDP
 }
 
-func NewComputeHandler(storageService *services.StorageService) *ComputeHandler {
+func NewComputeHandler(storageService *services.StorageService, bmcService *services.BMCService) *ComputeHandler {
 	return &ComputeHandler{
 		storageService: storageService,
+		connectivity:   services.NewConnectivityService(cache.Default()),
+		bmc:            bmcService,
+		imports:        services.NewNodeImportService(database.DB, storageService),
 	}
 }
 
+// SetNDClient sets the NDFC client used to detect a port mapping's NIC name from LLDP when
+// none is given. Without one, mappings keep the NIC name they are created with.
+func (h *ComputeHandler) SetNDClient(client *ndclient.Client) {
+	h.ndClient = client
+}
+
 // CreateComputeNode creates a new compute node
 func (h *ComputeHandler) CreateComputeNode(c *gin.Context) {
 	var input struct {
@@ -32,12 +56,23 @@ func (h *ComputeHandler) CreateComputeNode(c *gin.Context) {
 		IPAddress   string `json:"ip_address"`
 		MACAddress  string `json:"mac_address"`
 		Description string `json:"description"`
+		BMCAddress  string `json:"bmc_address"`
+		BMCUsername string `json:"bmc_username"`
+		BMCPort     int    `json:"bmc_port" binding:"omitempty,min=1,max=65535"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := util.ValidateHostname(input.Hostname); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := util.ValidateBMCAddress(input.BMCAddress); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	node := models.ComputeNode{
 		ID:          uuid.New().String(),
@@ -46,9 +81,15 @@ func (h *ComputeHandler) CreateComputeNode(c *gin.Context) {
 		IPAddress:   input.IPAddress,
 		MACAddress:  input.MACAddress,
 		Description: input.Description,
+		BMCAddress:  input.BMCAddress,
+		BMCUsername: input.BMCUsername,
+		BMCPort:     input.BMCPort,
+	}
+	if node.BMCPort == 0 {
+		node.BMCPort = services.DefaultBMCPort
 	}
 
-	if err := database.DB.Create(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&node).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -69,43 +110,129 @@ func (h *ComputeHandler) CreateComputeNode(c *gin.Context) {
 	c.JSON(http.StatusCreated, node)
 }
 
-// GetComputeNodes returns all compute nodes
+// GetComputeNodes returns all compute nodes.
+// label.<key>=<value> query parameters return only nodes carrying every given label;
+// ?hostname_valid=false returns nodes whose stored hostname fails RFC 1123 validation;
+// ?allocated=true|false returns only nodes that are or are not allocated to a job;
+// ?last_seen_before=YYYY-MM-DD returns nodes no port sync has seen since that date.
 func (h *ComputeHandler) GetComputeNodes(c *gin.Context) {
+	selector := services.LabelSelectorFromQuery(c.Request.URL.Query())
+	for key, value := range selector {
+		if err := services.ValidateLabel(key, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	includeDeleted, err := strconv.ParseBool(c.DefaultQuery("include_deleted", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "include_deleted must be true or false"})
+		return
+	}
+
 	var nodes []models.ComputeNode
-	if err := database.DB.Preload("PortMappings").Find(&nodes).Error; err != nil {
+	query := services.FilterNodesByLabels(database.DB.WithContext(c.Request.Context()), selector)
+	if raw := c.Query("hostname_valid"); raw != "" {
+		valid, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "hostname_valid must be true or false"})
+			return
+		}
+		query = query.Where("hostname_valid = ?", valid)
+	}
+	if raw := c.Query("allocated"); raw != "" {
+		allocated, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "allocated must be true or false"})
+			return
+		}
+		query = services.FilterNodesByAllocation(query, allocated)
+	}
+	if raw := c.Query("last_seen_before"); raw != "" {
+		before, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid last_seen_before %q (expected YYYY-MM-DD)", raw)})
+			return
+		}
+		query = query.Where("last_seen_at < ?", before)
+	}
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+	if err := query.Preload("PortMappings").Preload("Labels").Find(&nodes).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, nodes)
+	if !includeDeleted {
+		c.JSON(http.StatusOK, nodes)
+		return
+	}
+
+	result := make([]computeNodeWithDeletion, len(nodes))
+	for i, node := range nodes {
+		result[i].ComputeNode = node
+		if node.DeletedAt.Valid {
+			result[i].DeletedAt = &node.DeletedAt.Time
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// computeNodeWithDeletion exposes the deletion time that models.ComputeNode hides from JSON
+type computeNodeWithDeletion struct {
+	models.ComputeNode
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // findComputeNode resolves a compute node by ID or name
-func (h *ComputeHandler) findComputeNode(idOrName string) (*models.ComputeNode, error) {
+func (h *ComputeHandler) findComputeNode(ctx context.Context, idOrName string) (*models.ComputeNode, error) {
 	var node models.ComputeNode
 	// Use Where().First() to avoid GORM logging "record not found" for expected fallback behavior
-	if err := database.DB.Where("id = ? OR name = ?", idOrName, idOrName).First(&node).Error; err == nil {
+	if err := database.DB.WithContext(ctx).Where("id = ? OR name = ?", idOrName, idOrName).First(&node).Error; err == nil {
 		return &node, nil
 	}
 	return nil, fmt.Errorf("compute node not found")
 }
 
-// GetComputeNode returns a single compute node by ID or name
+// GetComputeNode returns a single compute node by ID or name.
+// ?include_allocation=true adds the node's current job allocation as current_allocation.
 func (h *ComputeHandler) GetComputeNode(c *gin.Context) {
 	idOrName := c.Param("id")
-	node, err := h.findComputeNode(idOrName)
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
+	includeAllocation, err := strconv.ParseBool(c.DefaultQuery("include_allocation", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "include_allocation must be true or false"})
+		return
+	}
 	// Preload relationships
-	database.DB.Preload("PortMappings.SwitchPort.Switch").First(node, "id = ?", node.ID)
-	c.JSON(http.StatusOK, node)
+	database.DB.WithContext(c.Request.Context()).Preload("PortMappings.SwitchPort.Switch").Preload("Labels").First(node, "id = ?", node.ID)
+	if !includeAllocation {
+		c.JSON(http.StatusOK, node)
+		return
+	}
+
+	allocation, err := services.GetNodeAllocation(c.Request.Context(), database.DB, node.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, computeNodeWithAllocation{ComputeNode: *node, CurrentAllocation: allocation})
+}
+
+// computeNodeWithAllocation adds the node's current job allocation (null when unallocated)
+type computeNodeWithAllocation struct {
+	models.ComputeNode
+	CurrentAllocation *services.NodeAllocation `json:"current_allocation"`
 }
 
 // UpdateComputeNode updates a compute node (by ID or name)
 func (h *ComputeHandler) UpdateComputeNode(c *gin.Context) {
 	idOrName := c.Param("id")
-	node, err := h.findComputeNode(idOrName)
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
@@ -117,6 +244,9 @@ func (h *ComputeHandler) UpdateComputeNode(c *gin.Context) {
 		IPAddress   string `json:"ip_address"`
 		MACAddress  string `json:"mac_address"`
 		Description string `json:"description"`
+		BMCAddress  string `json:"bmc_address"`
+		BMCUsername string `json:"bmc_username"`
+		BMCPort     int    `json:"bmc_port" binding:"omitempty,min=1,max=65535"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -128,7 +258,12 @@ func (h *ComputeHandler) UpdateComputeNode(c *gin.Context) {
 		node.Name = input.Name
 	}
 	if input.Hostname != "" {
+		if err := util.ValidateHostname(input.Hostname); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		node.Hostname = input.Hostname
+		node.HostnameValid = true
 	}
 	if input.IPAddress != "" {
 		node.IPAddress = input.IPAddress
@@ -139,8 +274,21 @@ func (h *ComputeHandler) UpdateComputeNode(c *gin.Context) {
 	if input.Description != "" {
 		node.Description = input.Description
 	}
+	if input.BMCAddress != "" {
+		if err := util.ValidateBMCAddress(input.BMCAddress); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		node.BMCAddress = input.BMCAddress
+	}
+	if input.BMCUsername != "" {
+		node.BMCUsername = input.BMCUsername
+	}
+	if input.BMCPort != 0 {
+		node.BMCPort = input.BMCPort
+	}
 
-	if err := database.DB.Save(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Save(&node).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -148,21 +296,53 @@ func (h *ComputeHandler) UpdateComputeNode(c *gin.Context) {
 	c.JSON(http.StatusOK, node)
 }
 
-// DeleteComputeNode deletes a compute node (by ID or name)
+// DeleteComputeNode soft-deletes a compute node (by ID or name). The node is kept for audit,
+// listed with ?include_deleted=true, and its name may be reused by a new node.
 func (h *ComputeHandler) DeleteComputeNode(c *gin.Context) {
 	idOrName := c.Param("id")
-	node, err := h.findComputeNode(idOrName)
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
-	if err := database.DB.Delete(&models.ComputeNode{}, "id = ?", node.ID).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Delete(&models.ComputeNode{}, "id = ?", node.ID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Compute node deleted"})
 }
 
+// RestoreComputeNode undeletes a soft-deleted compute node by ID.
+// Returns 409 if a node with the same name has been created since.
+func (h *ComputeHandler) RestoreComputeNode(c *gin.Context) {
+	db := database.DB.WithContext(c.Request.Context())
+
+	var node models.ComputeNode
+	if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", c.Param("id")).First(&node).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deleted compute node not found"})
+		return
+	}
+
+	var active int64
+	if err := db.Model(&models.ComputeNode{}).Where("name = ?", node.Name).Count(&active).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if active > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("a compute node named %q already exists", node.Name)})
+		return
+	}
+
+	// The partial unique index still rejects a node of the same name created concurrently
+	if err := db.Unscoped().Model(&node).Update("deleted_at", nil).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	node.DeletedAt = gorm.DeletedAt{}
+
+	c.JSON(http.StatusOK, node)
+}
+
 // AddPortMapping maps a compute node to a switch port
 // Accepts either:
 //   - switch_port_id: full port ID
@@ -175,15 +355,22 @@ func (h *ComputeHandler) AddPortMapping(c *gin.Context) {
 		Switch       string `json:"switch"`         // Switch name/serial/ID (optional if switch_port_id provided)
 		PortName     string `json:"port_name"`      // Port name like "Ethernet1/1" (optional if switch_port_id provided)
 		NICName      string `json:"nic_name"`
+		VLAN         int    `json:"vlan"` // 1-4094; 0 or omitted leaves the mapping untagged
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if input.VLAN != 0 {
+		if err := util.ValidateVLAN(input.VLAN); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 
 	// Verify compute node exists (by ID or name)
-	node, err := h.findComputeNode(nodeIDOrName)
+	node, err := h.findComputeNode(c.Request.Context(), nodeIDOrName)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
@@ -193,23 +380,23 @@ func (h *ComputeHandler) AddPortMapping(c *gin.Context) {
 	var port models.SwitchPort
 	if input.SwitchPortID != "" {
 		// Direct port ID lookup
-		if err := database.DB.First(&port, "id = ?", input.SwitchPortID).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).First(&port, "id = ?", input.SwitchPortID).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Switch port not found"})
 			return
 		}
 	} else if input.Switch != "" && input.PortName != "" {
 		// Simplified lookup: find switch first, then port by name
 		var sw models.Switch
-		if err := database.DB.First(&sw, "id = ?", input.Switch).Error; err != nil {
-			if err := database.DB.Where("serial_number = ?", input.Switch).First(&sw).Error; err != nil {
-				if err := database.DB.Where("name = ?", input.Switch).First(&sw).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).First(&sw, "id = ?", input.Switch).Error; err != nil {
+			if err := database.DB.WithContext(c.Request.Context()).Where("serial_number = ?", input.Switch).First(&sw).Error; err != nil {
+				if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", input.Switch).First(&sw).Error; err != nil {
 					c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 					return
 				}
 			}
 		}
 		// Find port by name within this switch
-		if err := database.DB.Where("switch_id = ? AND name = ?", sw.ID, input.PortName).First(&port).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("switch_id = ? AND name = ?", sw.ID, input.PortName).First(&port).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Port not found on switch"})
 			return
 		}
@@ -219,19 +406,24 @@ func (h *ComputeHandler) AddPortMapping(c *gin.Context) {
 	}
 
 	// Delete any existing mapping for this port (reassignment)
-	if err := database.DB.Where("switch_port_id = ?", port.ID).Delete(&models.ComputeNodePortMapping{}).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("switch_port_id = ?", port.ID).Delete(&models.ComputeNodePortMapping{}).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove existing mapping: " + err.Error()})
 		return
 	}
 
+	if input.NICName == "" {
+		input.NICName = h.detectNICName(c.Request.Context(), node, &port)
+	}
+
 	mapping := models.ComputeNodePortMapping{
 		ID:            uuid.New().String(),
 		ComputeNodeID: node.ID,
 		SwitchPortID:  port.ID,
 		NICName:       input.NICName,
+		VLAN:          input.VLAN,
 	}
 
-	if err := database.DB.Create(&mapping).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&mapping).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -239,16 +431,48 @@ func (h *ComputeHandler) AddPortMapping(c *gin.Context) {
 	c.JSON(http.StatusCreated, mapping)
 }
 
+// detectNICName returns the node's NIC name on port as seen by the switch over LLDP, or ""
+// if there is no NDFC client or no matching neighbor. Failures are logged, not returned.
+func (h *ComputeHandler) detectNICName(ctx context.Context, node *models.ComputeNode, port *models.SwitchPort) string {
+	if h.ndClient == nil {
+		return ""
+	}
+	hostname := node.Hostname
+	if hostname == "" {
+		hostname = node.Name
+	}
+
+	var sw models.Switch
+	if err := database.DB.WithContext(ctx).Preload("Fabric").First(&sw, "id = ?", port.SwitchID).Error; err != nil || sw.Fabric == nil {
+		logger.Warn("NIC name detection: switch or fabric not found",
+			zap.String("port", port.ID), zap.Error(err))
+		return ""
+	}
+	neighbors, err := h.ndClient.LANFabric().GetLLDPNeighbors(ctx, sw.Fabric.Name, sw.SerialNumber)
+	if err != nil {
+		logger.Warn("NIC name detection: failed to get LLDP neighbors",
+			zap.String("switch", sw.SerialNumber), zap.Error(err))
+		return ""
+	}
+	neighbor, ok := lanfabric.FindLLDPNeighbor(neighbors, port.Name, hostname)
+	if !ok {
+		logger.Debug("NIC name detection: no LLDP neighbor matches the node",
+			zap.String("switch", sw.SerialNumber), zap.String("port", port.Name), zap.String("hostname", hostname))
+		return ""
+	}
+	return neighbor.RemotePort
+}
+
 // GetPortMappings returns all port mappings for a compute node (by ID or name)
 func (h *ComputeHandler) GetPortMappings(c *gin.Context) {
 	nodeIDOrName := c.Param("id")
-	node, err := h.findComputeNode(nodeIDOrName)
+	node, err := h.findComputeNode(c.Request.Context(), nodeIDOrName)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 	var mappings []models.ComputeNodePortMapping
-	if err := database.DB.Preload("SwitchPort.Switch").Where("compute_node_id = ?", node.ID).Find(&mappings).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Preload("SwitchPort.Switch").Where("compute_node_id = ?", node.ID).Find(&mappings).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -260,7 +484,7 @@ func (h *ComputeHandler) UpdatePortMapping(c *gin.Context) {
 	mappingID := c.Param("mappingId")
 
 	var mapping models.ComputeNodePortMapping
-	if err := database.DB.First(&mapping, "id = ?", mappingID).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&mapping, "id = ?", mappingID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Port mapping not found"})
 		return
 	}
@@ -284,7 +508,7 @@ func (h *ComputeHandler) UpdatePortMapping(c *gin.Context) {
 	// Update switch port if provided
 	if input.SwitchPortID != "" {
 		var port models.SwitchPort
-		if err := database.DB.First(&port, "id = ?", input.SwitchPortID).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).First(&port, "id = ?", input.SwitchPortID).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Switch port not found"})
 			return
 		}
@@ -292,36 +516,36 @@ func (h *ComputeHandler) UpdatePortMapping(c *gin.Context) {
 	} else if input.Switch != "" && input.PortName != "" {
 		// Simplified lookup: find switch first, then port by name
 		var sw models.Switch
-		if err := database.DB.First(&sw, "id = ?", input.Switch).Error; err != nil {
-			if err := database.DB.Where("serial_number = ?", input.Switch).First(&sw).Error; err != nil {
-				if err := database.DB.Where("name = ?", input.Switch).First(&sw).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).First(&sw, "id = ?", input.Switch).Error; err != nil {
+			if err := database.DB.WithContext(c.Request.Context()).Where("serial_number = ?", input.Switch).First(&sw).Error; err != nil {
+				if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", input.Switch).First(&sw).Error; err != nil {
 					c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 					return
 				}
 			}
 		}
 		var port models.SwitchPort
-		if err := database.DB.Where("switch_id = ? AND name = ?", sw.ID, input.PortName).First(&port).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("switch_id = ? AND name = ?", sw.ID, input.PortName).First(&port).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Port not found on switch"})
 			return
 		}
 		mapping.SwitchPortID = port.ID
 	}
 
-	if err := database.DB.Save(&mapping).Error; err != nil {
+	if err := database.DB.WithContext(actorContext(c)).Save(&mapping).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Reload with associations
-	database.DB.Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
+	database.DB.WithContext(c.Request.Context()).Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
 	c.JSON(http.StatusOK, mapping)
 }
 
 // DeletePortMapping removes a port mapping
 func (h *ComputeHandler) DeletePortMapping(c *gin.Context) {
 	mappingID := c.Param("mappingId")
-	if err := database.DB.Delete(&models.ComputeNodePortMapping{}, "id = ?", mappingID).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Delete(&models.ComputeNodePortMapping{}, "id = ?", mappingID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -334,9 +558,9 @@ func (h *ComputeHandler) GetComputeNodesBySwitch(c *gin.Context) {
 
 	// Resolve switch by ID, serial number, or name
 	var sw models.Switch
-	if err := database.DB.First(&sw, "id = ?", switchIDOrName).Error; err != nil {
-		if err := database.DB.Where("serial_number = ?", switchIDOrName).First(&sw).Error; err != nil {
-			if err := database.DB.Where("name = ?", switchIDOrName).First(&sw).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&sw, "id = ?", switchIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("serial_number = ?", switchIDOrName).First(&sw).Error; err != nil {
+			if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", switchIDOrName).First(&sw).Error; err != nil {
 				c.JSON(http.StatusOK, []models.ComputeNodePortMapping{})
 				return
 			}
@@ -344,7 +568,7 @@ func (h *ComputeHandler) GetComputeNodesBySwitch(c *gin.Context) {
 	}
 
 	var mappings []models.ComputeNodePortMapping
-	if err := database.DB.
+	if err := database.DB.WithContext(c.Request.Context()).
 		Joins("JOIN switch_ports ON switch_ports.id = compute_node_port_mappings.switch_port_id").
 		Where("switch_ports.switch_id = ?", sw.ID).
 		Preload("ComputeNode").
@@ -362,7 +586,7 @@ func (h *ComputeHandler) GetComputeNodesBySwitchPort(c *gin.Context) {
 	portID := c.Param("portId")
 
 	var mappings []models.ComputeNodePortMapping
-	if err := database.DB.
+	if err := database.DB.WithContext(c.Request.Context()).
 		Where("switch_port_id = ?", portID).
 		Preload("ComputeNode").
 		Find(&mappings).Error; err != nil {
@@ -372,3 +596,384 @@ func (h *ComputeHandler) GetComputeNodesBySwitchPort(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mappings)
 }
+
+// GetPortHistory lists port mapping changes that moved a mapping to or from a compute node.
+// Optional ?since=YYYY-MM-DD limits results to changes on or after that date.
+func (h *ComputeHandler) GetPortHistory(c *gin.Context) {
+	node, err := h.findComputeNode(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	var since time.Time
+	if s := c.Query("since"); s != "" {
+		since, err = time.Parse(time.DateOnly, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid since %q (expected YYYY-MM-DD)", s)})
+			return
+		}
+	}
+
+	history, err := services.NodePortHistory(c.Request.Context(), database.DB, node.ID, since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// GetLabels lists a compute node's labels
+func (h *ComputeHandler) GetLabels(c *gin.Context) {
+	node, err := h.findComputeNode(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	labels, err := services.ListNodeLabels(c.Request.Context(), database.DB, node.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, labels)
+}
+
+// CreateLabel adds a label to a compute node (409 if the key is already set; use PUT to replace)
+func (h *ComputeHandler) CreateLabel(c *gin.Context) {
+	node, err := h.findComputeNode(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	var input struct {
+		Key   string `json:"key" binding:"required"`
+		Value string `json:"value"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := services.CreateNodeLabel(c.Request.Context(), database.DB, node.ID, input.Key, input.Value)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, label)
+}
+
+// SetLabel creates or replaces the value of a compute node label
+func (h *ComputeHandler) SetLabel(c *gin.Context) {
+	node, err := h.findComputeNode(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	var input struct {
+		Value string `json:"value"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := services.SetNodeLabel(c.Request.Context(), database.DB, node.ID, c.Param("key"), input.Value)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel removes a label from a compute node
+func (h *ComputeHandler) DeleteLabel(c *gin.Context) {
+	node, err := h.findComputeNode(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	if err := services.DeleteNodeLabel(c.Request.Context(), database.DB, node.ID, c.Param("key")); err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Label deleted"})
+}
+
+// writeLabelError maps node label service errors to HTTP responses
+func writeLabelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidLabel):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLabelExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLabelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// actorContext returns the request context tagged with the caller's actor ID for
+// port mapping history. There is no user authentication on the REST API, so every change
+// made through it is attributed to "api"; a client-supplied header would be spoofable.
+func actorContext(c *gin.Context) context.Context {
+	return models.WithActor(c.Request.Context(), "api")
+}
+
+// CheckConnectivity performs a TCP reachability check against a compute node's SSH port
+func (h *ComputeHandler) CheckConnectivity(c *gin.Context) {
+	idOrName := c.Param("id")
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	result, err := h.connectivity.CheckNode(c.Request.Context(), node)
+	if err != nil {
+		if errors.Is(err, services.ErrNoIPAddress) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetConnectivityCheck returns the last stored connectivity check result for a compute node
+func (h *ComputeHandler) GetConnectivityCheck(c *gin.Context) {
+	idOrName := c.Param("id")
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	result, err := h.connectivity.GetLastResult(c.Request.Context(), node.ID)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No recent connectivity check for compute node"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// BulkCheckConnectivity runs connectivity checks against multiple compute nodes concurrently
+func (h *ComputeHandler) BulkCheckConnectivity(c *gin.Context) {
+	var input struct {
+		IDs []string `json:"ids" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var nodes []models.ComputeNode
+	if err := database.DB.WithContext(c.Request.Context()).Where("id IN ? OR name IN ?", input.IDs, input.IDs).Find(&nodes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Report IDs that did not resolve to a node alongside the check results
+	found := make(map[string]bool, len(nodes)*2)
+	for _, n := range nodes {
+		found[n.ID] = true
+		found[n.Name] = true
+	}
+	var notFound []string
+	for _, id := range input.IDs {
+		if !found[id] {
+			notFound = append(notFound, id)
+		}
+	}
+
+	results := h.connectivity.CheckNodes(c.Request.Context(), nodes)
+	c.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"not_found": notFound,
+	})
+}
+
+// GetBMC returns only the BMC fields of a compute node (no port mappings)
+func (h *ComputeHandler) GetBMC(c *gin.Context) {
+	idOrName := c.Param("id")
+	var node models.ComputeNode
+	if err := database.DB.WithContext(c.Request.Context()).Select("id", "name", "bmc_address", "bmc_username", "bmc_port").
+		Where("id = ? OR name = ?", idOrName, idOrName).First(&node).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+	c.JSON(http.StatusOK, services.BMCInfoFromNode(&node))
+}
+
+// PowerCycleBMC runs the configured external power-cycle command against a compute node's BMC
+func (h *ComputeHandler) PowerCycleBMC(c *gin.Context) {
+	idOrName := c.Param("id")
+	node, err := h.findComputeNode(c.Request.Context(), idOrName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	result, err := h.bmc.PowerCycle(c.Request.Context(), node)
+	switch {
+	case errors.Is(err, services.ErrBMCNotConfigured):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoBMCAddress) || errors.Is(err, util.ErrInvalidBMCAddress):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrBMCCommandFailed):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error(), "result": result})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// importFormat picks the import format from ?format= or the request Content-Type
+func importFormat(c *gin.Context) string {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		if format == "yml" {
+			return services.NodeImportFormatYAML
+		}
+		return format
+	}
+	if strings.Contains(c.ContentType(), "yaml") {
+		return services.NodeImportFormatYAML
+	}
+	return services.NodeImportFormatCSV
+}
+
+// importFileField is the multipart form field carrying an uploaded import file
+const importFileField = "file"
+
+// importFile returns the import file of a request and its format: the "file" field of a
+// multipart/form-data upload, whose format follows its extension (.yaml, .yml or CSV) unless
+// ?format= is given, or else the request body
+func importFile(c *gin.Context) (io.ReadCloser, string, error) {
+	if c.ContentType() != "multipart/form-data" {
+		return c.Request.Body, importFormat(c), nil
+	}
+	header, err := c.FormFile(importFileField)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: multipart upload has no %q file: %v", services.ErrInvalidImport, importFileField, err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	format := importFormat(c)
+	if c.Query("format") == "" {
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".yaml", ".yml":
+			format = services.NodeImportFormatYAML
+		}
+	}
+	return file, format, nil
+}
+
+// ImportComputeNodes creates compute nodes from a CSV (with header row) or YAML request body,
+// or the same file uploaded as the "file" field of a multipart form. With ?async=true the import runs in the background and the response carries its import_id.
+// ?deduplication_strategy=error|skip|update decides what happens to rows named like an existing node.
+func (h *ComputeHandler) ImportComputeNodes(c *gin.Context) {
+	mode, err := services.ParseImportDeduplicationMode(c.Query("deduplication_strategy"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	file, format, err := importFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	rows, err := services.ParseNodeImport(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("async") == "true" {
+		imp, err := h.imports.Start(c.Request.Context(), rows, mode)
+		if err != nil {
+			writeImportError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"import_id": imp.ID, "status": imp.Status})
+		return
+	}
+
+	imp, err := h.imports.Import(c.Request.Context(), rows, mode)
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, imp)
+}
+
+// GetImport returns the status of a compute node import
+func (h *ComputeHandler) GetImport(c *gin.Context) {
+	imp, err := h.imports.Get(c.Request.Context(), c.Param("importId"))
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, imp)
+}
+
+// StreamImportProgress streams "progress" server-sent events for a compute node import
+// every 100 rows until it finishes. Finished imports (or imports running on another
+// instance) get a single event with their stored state.
+func (h *ComputeHandler) StreamImportProgress(c *gin.Context) {
+	id := c.Param("importId")
+	imp, err := h.imports.Get(c.Request.Context(), id)
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+
+	events, unsubscribe, ok := h.imports.Subscribe(id)
+	if !ok {
+		c.SSEvent("progress", services.NodeImportProgressFromRecord(imp))
+		return
+	}
+	defer unsubscribe()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case p, open := <-events:
+			if !open {
+				// Final event may have been dropped; report the stored outcome
+				if imp, err := h.imports.Get(context.Background(), id); err == nil {
+					c.SSEvent("progress", services.NodeImportProgressFromRecord(imp))
+				}
+				return false
+			}
+			c.SSEvent("progress", p)
+			return !p.Done()
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func writeImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrImportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrImportInProgress):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// newComputeTestRouter serves the compute node CRUD routes from an empty test database
+func newComputeTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeInterface{},
+		&models.ComputeNodePortMapping{}, &models.ComputeNodeLabel{},
+		&models.ComputeNodeAllocation{}, &models.Job{})
+
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+
+	gin.SetMode(gin.TestMode)
+	h := &ComputeHandler{}
+	r := gin.New()
+	r.GET("/compute-nodes", h.GetComputeNodes)
+	r.GET("/compute-nodes/:id", h.GetComputeNode)
+	r.POST("/compute-nodes", h.CreateComputeNode)
+	r.DELETE("/compute-nodes/:id", h.DeleteComputeNode)
+	r.POST("/compute-nodes/:id/restore", h.RestoreComputeNode)
+	return r, db
+}
+
+func doJSON(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestComputeNode_SoftDeleteFreesName(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+
+	if w := doJSON(r, http.MethodPost, "/compute-nodes", `{"name":"node1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+	var old models.ComputeNode
+	if err := db.Where("name = ?", "node1").First(&old).Error; err != nil {
+		t.Fatal(err)
+	}
+	if w := doJSON(r, http.MethodPost, "/compute-nodes", `{"name":"node1"}`); w.Code == http.StatusCreated {
+		t.Fatal("duplicate active name was accepted")
+	}
+
+	if w := doJSON(r, http.MethodDelete, "/compute-nodes/node1", ""); w.Code != http.StatusOK {
+		t.Fatalf("delete: %d %s", w.Code, w.Body)
+	}
+	if w := doJSON(r, http.MethodPost, "/compute-nodes", `{"name":"node1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create after soft delete: %d %s", w.Code, w.Body)
+	}
+
+	// Only the new node is listed by default; include_deleted adds the old one with its deletion time
+	var nodes []map[string]interface{}
+	w := doJSON(r, http.MethodGet, "/compute-nodes", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &nodes); err != nil || len(nodes) != 1 {
+		t.Fatalf("list = %s, want 1 node", w.Body)
+	}
+	w = doJSON(r, http.MethodGet, "/compute-nodes?include_deleted=true", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &nodes); err != nil || len(nodes) != 2 {
+		t.Fatalf("list with deleted = %s, want 2 nodes", w.Body)
+	}
+	for _, n := range nodes {
+		_, deleted := n["deleted_at"]
+		if wantDeleted := n["id"] == old.ID; deleted != wantDeleted {
+			t.Errorf("node %v deleted_at present = %v, want %v", n["id"], deleted, wantDeleted)
+		}
+	}
+
+	// The old node cannot be restored while the new one holds its name
+	if w := doJSON(r, http.MethodPost, "/compute-nodes/"+old.ID+"/restore", ""); w.Code != http.StatusConflict {
+		t.Errorf("restore with active name: %d %s, want 409", w.Code, w.Body)
+	}
+}
+
+func TestRestoreComputeNode(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+	node := models.ComputeNode{ID: "n1", Name: "node1"}
+	if err := db.Create(&node).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if w := doJSON(r, http.MethodPost, "/compute-nodes/n1/restore", ""); w.Code != http.StatusNotFound {
+		t.Errorf("restore active node: %d, want 404", w.Code)
+	}
+	if err := db.Delete(&node).Error; err != nil {
+		t.Fatal(err)
+	}
+	if w := doJSON(r, http.MethodPost, "/compute-nodes/n1/restore", ""); w.Code != http.StatusOK {
+		t.Fatalf("restore: %d %s", w.Code, w.Body)
+	}
+	if err := db.First(&models.ComputeNode{}, "id = ?", "n1").Error; err != nil {
+		t.Errorf("restored node not found: %v", err)
+	}
+	if w := doJSON(r, http.MethodPost, "/compute-nodes/missing/restore", ""); w.Code != http.StatusNotFound {
+		t.Errorf("restore missing node: %d, want 404", w.Code)
+	}
+}
+
+func TestAddPortMapping_DetectsNICNameFromLLDP(t *testing.T) {
+	_, db := newComputeTestRouter(t)
+	if err := db.AutoMigrate(&models.Fabric{}, &models.Switch{}, &models.SwitchPort{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	for _, v := range []interface{}{
+		&models.Fabric{ID: "fab-1", Name: "DevNet_Fabric"},
+		&models.Switch{ID: "sw-1", Name: "leaf1", SerialNumber: "SN1", FabricID: "fab-1"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "sw-1"},
+		&models.SwitchPort{ID: "p2", Name: "Ethernet1/2", SwitchID: "sw-1"},
+		&models.ComputeNode{ID: "n1", Name: "node1", Hostname: "node1"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The node's second NIC is on Ethernet1/2; another host is on Ethernet1/1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/control/fabrics/DevNet_Fabric/switches/SN1/lldpNeighbors") {
+			t.Errorf("unexpected NDFC request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"localInterface":"Ethernet1/1","systemName":"node2","portId":"ens1f0"},
+			{"localInterface":"Ethernet1/2","systemName":"NODE1.example.com","portId":"ens1f1"}
+		]`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	h := &ComputeHandler{ndClient: client}
+	r := gin.New()
+	r.POST("/compute-nodes/:id/port-mappings", h.AddPortMapping)
+
+	tests := []struct {
+		body    string
+		wantNIC string
+	}{
+		{`{"switch_port_id":"p2"}`, "ens1f1"},
+		{`{"switch":"leaf1","port_name":"Ethernet1/1"}`, ""}, // Neighbor is another host
+		{`{"switch_port_id":"p2","nic_name":"eth9"}`, "eth9"},
+	}
+	for _, tt := range tests {
+		w := doJSON(r, http.MethodPost, "/compute-nodes/node1/port-mappings", tt.body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: %d %s", tt.body, w.Code, w.Body)
+		}
+		var mapping models.ComputeNodePortMapping
+		if err := json.Unmarshal(w.Body.Bytes(), &mapping); err != nil {
+			t.Fatal(err)
+		}
+		if mapping.NICName != tt.wantNIC {
+			t.Errorf("%s: nic_name = %q, want %q", tt.body, mapping.NICName, tt.wantNIC)
+		}
+	}
+}
+
+// TestCreateComputeNode_CancelledContext tests that handler queries use the request context,
+// so a client that has gone away does not leave the insert running
+func TestCreateComputeNode_CancelledContext(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/compute-nodes", strings.NewReader(`{"name":"node1"}`)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), context.Canceled.Error()) {
+		t.Errorf("cancelled create = %d %s, want 500 with %q", w.Code, w.Body, context.Canceled)
+	}
+	var n int64
+	if err := db.Model(&models.ComputeNode{}).Count(&n).Error; err != nil || n != 0 {
+		t.Errorf("%d compute nodes created (%v), want none", n, err)
+	}
+}
+
+func TestComputeNode_Allocation(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+	allocatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, v := range []interface{}{
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNode{ID: "n2", Name: "node2"},
+		&models.Job{ID: "j1", SlurmJobID: "4242", Status: string(models.JobStatusActive)},
+		&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j1", AllocatedAt: allocatedAt},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var allocated struct {
+		ID                string `json:"id"`
+		CurrentAllocation *struct {
+			JobSlurmID  string    `json:"job_slurm_id"`
+			JobStatus   string    `json:"job_status"`
+			AllocatedAt time.Time `json:"allocated_at"`
+		} `json:"current_allocation"`
+	}
+	w := doJSON(r, http.MethodGet, "/compute-nodes/node1?include_allocation=true", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("get node1: %d %s", w.Code, w.Body)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &allocated); err != nil {
+		t.Fatal(err)
+	}
+	a := allocated.CurrentAllocation
+	if allocated.ID != "n1" || a == nil || a.JobSlurmID != "4242" || a.JobStatus != "active" || !a.AllocatedAt.Equal(allocatedAt) {
+		t.Errorf("node1 = %s, want allocation to Slurm job 4242", w.Body)
+	}
+
+	w = doJSON(r, http.MethodGet, "/compute-nodes/n2?include_allocation=true", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"current_allocation":null`) {
+		t.Errorf("unallocated node = %d %s, want current_allocation null", w.Code, w.Body)
+	}
+	if w := doJSON(r, http.MethodGet, "/compute-nodes/n1", ""); strings.Contains(w.Body.String(), "current_allocation") {
+		t.Errorf("allocation returned without include_allocation: %s", w.Body)
+	}
+
+	for query, want := range map[string]string{"true": "n1", "false": "n2"} {
+		var nodes []models.ComputeNode
+		w := doJSON(r, http.MethodGet, "/compute-nodes?allocated="+query, "")
+		if err := json.Unmarshal(w.Body.Bytes(), &nodes); err != nil {
+			t.Fatalf("allocated=%s: %d %s", query, w.Code, w.Body)
+		}
+		if len(nodes) != 1 || nodes[0].ID != want {
+			t.Errorf("allocated=%s returned %d nodes, want only %s", query, len(nodes), want)
+		}
+	}
+	if w := doJSON(r, http.MethodGet, "/compute-nodes?allocated=maybe", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("allocated=maybe: %d, want 400", w.Code)
+	}
+}
+
+func TestGetComputeNodes_LastSeenBefore(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+	old := time.Date(2026, 1, 10, 8, 0, 0, 0, time.UTC)
+	recent := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	for _, n := range []models.ComputeNode{
+		{ID: "n1", Name: "node1", LastSeenAt: &old},
+		{ID: "n2", Name: "node2", LastSeenAt: &recent},
+		{ID: "n3", Name: "node3"},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var nodes []models.ComputeNode
+	w := doJSON(r, http.MethodGet, "/compute-nodes?last_seen_before=2026-02-01", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &nodes); err != nil {
+		t.Fatalf("last_seen_before: %d %s", w.Code, w.Body)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "n1" || nodes[0].Status != models.ComputeNodeStatusActive {
+		t.Errorf("last_seen_before=2026-02-01 returned %+v, want only active node1", nodes)
+	}
+	if w := doJSON(r, http.MethodGet, "/compute-nodes?last_seen_before=02/01/2026", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid date: %d, want 400", w.Code)
+	}
+}
+
+func TestImportComputeNodes_DeduplicationStrategy(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+	if err := db.AutoMigrate(&models.NodeImport{}); err != nil {
+		t.Fatal(err)
+	}
+	h := &ComputeHandler{imports: services.NewNodeImportService(db, nil)}
+	r.POST("/compute-nodes/import", h.ImportComputeNodes)
+	if w := doJSON(r, http.MethodPost, "/compute-nodes", `{"name": "node-1", "ip_address": "10.0.0.1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+
+	csv := "name,ip_address\nnode-1,10.0.0.101\nnode-2,10.0.0.2\n"
+	if w := doJSON(r, http.MethodPost, "/compute-nodes/import?format=csv&deduplication_strategy=merge", csv); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown strategy: code = %d, want 400", w.Code)
+	}
+
+	w := doJSON(r, http.MethodPost, "/compute-nodes/import?format=csv&deduplication_strategy=update", csv)
+	if w.Code != http.StatusOK {
+		t.Fatalf("import: %d %s", w.Code, w.Body)
+	}
+	var imp models.NodeImport
+	if err := json.Unmarshal(w.Body.Bytes(), &imp); err != nil {
+		t.Fatal(err)
+	}
+	if imp.ImportedCount != 1 || imp.UpdatedCount != 1 || imp.SkippedCount != 0 || imp.ErrorCount != 0 {
+		t.Errorf("import = %s", w.Body)
+	}
+}
+
+func TestImportComputeNodes_MultipartCSV(t *testing.T) {
+	r, db := newComputeTestRouter(t)
+	if err := db.AutoMigrate(&models.NodeImport{}); err != nil {
+		t.Fatal(err)
+	}
+	h := &ComputeHandler{imports: services.NewNodeImportService(db, nil)}
+	r.POST("/compute-nodes/import", h.ImportComputeNodes)
+	if err := db.Create(&models.ComputeNode{ID: "n1", Name: "node-1", IPAddress: "10.0.0.1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "nodes.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.Write([]byte("name,hostname,ip_address,mac_address\n" +
+		"node-1,node-1.hpc,10.0.0.101,00:1a:2b:3c:4d:01\n" + // Updated
+		"node-2,node-2.hpc,10.0.0.2,00:1a:2b:3c:4d:02\n" + // Created
+		"node-3,node-3.hpc,10.0.0.300,00:1a:2b:3c:4d:03\n" + // Invalid IP
+		"node-4,node-4.hpc,10.0.0.4,00:1a:2b:3c:4d\n")) // Invalid MAC
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/compute-nodes/import?deduplication_strategy=update", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("import: %d %s", w.Code, w.Body)
+	}
+
+	var imp models.NodeImport
+	if err := json.Unmarshal(w.Body.Bytes(), &imp); err != nil {
+		t.Fatal(err)
+	}
+	if imp.ImportedCount != 1 || imp.UpdatedCount != 1 || imp.SkippedCount != 0 || imp.ErrorCount != 2 {
+		t.Errorf("import = %s, want 1 created, 1 updated, 2 errors", w.Body)
+	}
+	var rowErrors []services.NodeImportError
+	if err := json.Unmarshal(imp.Errors, &rowErrors); err != nil || len(rowErrors) != 2 ||
+		rowErrors[0].Row != 3 || !strings.Contains(rowErrors[0].Error, "IP address") ||
+		rowErrors[1].Row != 4 || !strings.Contains(rowErrors[1].Error, "MAC address") {
+		t.Errorf("errors = %s, want rows 3 (IP) and 4 (MAC)", imp.Errors)
+	}
+	var updated models.ComputeNode
+	if err := db.First(&updated, "id = ?", "n1").Error; err != nil || updated.IPAddress != "10.0.0.101" || updated.Hostname != "node-1.hpc" {
+		t.Errorf("node-1 = %+v, %v; want its IP and hostname updated", updated, err)
+	}
+
+	// A multipart request without the file field is rejected
+	empty := httptest.NewRequest(http.MethodPost, "/compute-nodes/import", strings.NewReader("--x--\r\n"))
+	empty.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, empty)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing file: %d %s, want 400", w.Code, w.Body)
+	}
+}
@@ -1,24 +1,59 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/services"
 	"github.com/banglin/go-nd/internal/sync"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type FabricHandler struct {
 	ndClient *ndclient.Client
+	fabrics  *services.FabricService
+	uplinks  *sync.UplinkCache
+	deployer services.FabricDeployer
+
+	maxPortsPerNetwork int
+	portNameFormat     string // util.PortNameFormat* for port display names
 }
 
-func NewFabricHandler(client *ndclient.Client) *FabricHandler {
-	return &FabricHandler{ndClient: client}
+func NewFabricHandler(client *ndclient.Client, uplinks *sync.UplinkCache) *FabricHandler {
+	return &FabricHandler{
+		ndClient: client,
+		fabrics:  services.NewFabricService(database.DB),
+		uplinks:  uplinks,
+	}
+}
+
+// SetDeployer sets the deployer used by DeployFabric. Without one, DeployFabric returns 501.
+func (h *FabricHandler) SetDeployer(deployer services.FabricDeployer) {
+	h.deployer = deployer
+}
+
+// SetMaxPortsPerNetwork sets the per-network port limit reported by GetNetworkAttachmentCount
+func (h *FabricHandler) SetMaxPortsPerNetwork(n int) {
+	h.maxPortsPerNetwork = n
+}
+
+// SetPortNameFormat sets the format of the display_name returned with switch ports
+func (h *FabricHandler) SetPortNameFormat(format string) {
+	h.portNameFormat = format
 }
 
 // SyncFabrics syncs fabrics from Nexus Dashboard to local database
@@ -55,7 +90,7 @@ func (h *FabricHandler) CreateFabric(c *gin.Context) {
 		fabric.ID = uuid.New().String()
 	}
 
-	if err := database.DB.Create(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&fabric).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -66,7 +101,7 @@ func (h *FabricHandler) CreateFabric(c *gin.Context) {
 // GetFabrics returns all fabrics from local database
 func (h *FabricHandler) GetFabrics(c *gin.Context) {
 	var fabrics []models.Fabric
-	if err := database.DB.Find(&fabrics).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Find(&fabrics).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -77,8 +112,8 @@ func (h *FabricHandler) GetFabrics(c *gin.Context) {
 func (h *FabricHandler) GetFabric(c *gin.Context) {
 	fabricIDOrName := c.Param("id")
 	var fabric models.Fabric
-	if err := database.DB.Preload("Switches").First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Preload("Switches").Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Preload("Switches").First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Preload("Switches").Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
@@ -86,6 +121,76 @@ func (h *FabricHandler) GetFabric(c *gin.Context) {
 	c.JSON(http.StatusOK, fabric)
 }
 
+// DeleteFabric soft-deletes a fabric by ID or name.
+// Returns 409 with the blocking reasons if the fabric has active jobs or allocations,
+// unless ?force=true, which also permanently deletes the fabric's switches, ports, port
+// mappings and port selectors.
+func (h *FabricHandler) DeleteFabric(c *gin.Context) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "force must be true or false"})
+		return
+	}
+
+	result, err := h.fabrics.DeleteFabric(c.Request.Context(), c.Param("id"), force)
+	if errors.Is(err, services.ErrFabricNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+		return
+	}
+	if errors.Is(err, services.ErrFabricInUse) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "blocking_reasons": result.BlockingReasons})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeployFabric deploys a fabric's configuration through the deploy batcher, optionally only
+// on the switches in "serial_numbers", and waits for the batch to complete
+func (h *FabricHandler) DeployFabric(c *gin.Context) {
+	if h.deployer == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "deploy batcher not configured"})
+		return
+	}
+
+	var input struct {
+		SerialNumbers []string `json:"serial_numbers"`
+	}
+	if !bindOptionalJSON(c, &input) {
+		return
+	}
+
+	fabric, err := h.fabrics.GetFabric(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrFabricNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	metrics.ManualDeploysTotal.WithLabelValues(metrics.ManualDeployTriggerHTTP).Inc()
+	duration, err := services.DeployFabric(c.Request.Context(), h.deployer, fabric.Name, input.SerialNumbers)
+	if errors.Is(err, ndclient.ErrCircuitOpen) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deployed":         true,
+		"duration_seconds": int(duration.Round(time.Second) / time.Second),
+	})
+}
+
 // SyncSwitches syncs switches for a fabric from Nexus Dashboard
 // Uses the shared sync.SyncFabricSwitches helper for consistent upsert behavior
 func (h *FabricHandler) SyncSwitches(c *gin.Context) {
@@ -93,8 +198,8 @@ func (h *FabricHandler) SyncSwitches(c *gin.Context) {
 
 	// Find fabric by ID first, then by name
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
@@ -109,6 +214,47 @@ func (h *FabricHandler) SyncSwitches(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Switches synced", "count": result.Synced, "total": result.Total})
 }
 
+// SyncStaleSwitches syncs ports only for switches never synced or last synced
+// more than stale_threshold_minutes ago (JSON body, default 60)
+func (h *FabricHandler) SyncStaleSwitches(c *gin.Context) {
+	fabricIDOrName := c.Param("id")
+
+	var input struct {
+		StaleThresholdMinutes int `json:"stale_threshold_minutes"`
+	}
+	if !bindOptionalJSON(c, &input) {
+		return
+	}
+	if input.StaleThresholdMinutes < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "stale_threshold_minutes must not be negative"})
+		return
+	}
+
+	// Find fabric by ID first, then by name
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	uplinks := sync.GetUplinksWithCache(c.Request.Context(), h.ndClient.LANFabric(), fabric.Name, h.uplinks)
+	threshold := time.Duration(input.StaleThresholdMinutes) * time.Minute
+	result, err := sync.SyncStaleSwitches(c.Request.Context(), database.DB, h.ndClient.LANFabric(), &fabric, uplinks, threshold)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"synced_count":            result.Synced,
+		"skipped_count":           result.Skipped,
+		"failed_count":            result.Failed,
+		"stale_threshold_minutes": int(result.StaleThreshold / time.Minute),
+	})
+}
+
 // CreateSwitch creates a switch record manually (for testing/setup)
 func (h *FabricHandler) CreateSwitch(c *gin.Context) {
 	fabricID := c.Param("id")
@@ -138,7 +284,7 @@ func (h *FabricHandler) CreateSwitch(c *gin.Context) {
 		sw.ID = uuid.New().String()
 	}
 
-	if err := database.DB.Create(&sw).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&sw).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -152,34 +298,153 @@ func (h *FabricHandler) GetSwitches(c *gin.Context) {
 
 	// Find fabric by ID first, then by name (consistent with SyncSwitches)
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
+	query := database.DB.WithContext(c.Request.Context()).Where("fabric_id = ?", fabric.ID)
+	if role := c.Query("role"); role != "" {
+		if !models.IsValidSwitchRole(role) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "role must be leaf, spine, or border"})
+			return
+		}
+		query = query.Where("role = ?", role)
+	}
+
 	var switches []models.Switch
-	if err := database.DB.Where("fabric_id = ?", fabric.ID).Find(&switches).Error; err != nil {
+	if err := query.Find(&switches).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, switches)
 }
 
+// SetSwitchRole manually overrides a switch's role (leaf, spine, or border).
+// The override is local only: it is not pushed to NDFC and later syncs keep it.
+func (h *FabricHandler) SetSwitchRole(c *gin.Context) {
+	var input struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !models.IsValidSwitchRole(input.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be leaf, spine, or border"})
+		return
+	}
+
+	fabricIDOrName := c.Param("id")
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, c.Param("switchId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
+		return
+	}
+
+	sw.Role = input.Role
+	sw.RoleOverride = true
+	if err := database.DB.WithContext(c.Request.Context()).Model(sw).Select("role", "role_override").Updates(sw).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sw)
+}
+
+// GetSwitchRoleSummary counts a fabric's switches by role with its leaf port capacity
+func (h *FabricHandler) GetSwitchRoleSummary(c *gin.Context) {
+	summary, err := h.fabrics.GetSwitchRoleSummary(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrFabricNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// UpdateSwitch updates a switch's local name, model or IP address without re-syncing.
+// Only the fields present in the body are written; serial_number and fabric_id are rejected.
+// ?update_port_descriptions=true also replaces the old name in port descriptions on rename.
+func (h *FabricHandler) UpdateSwitch(c *gin.Context) {
+	var body map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update := services.SwitchUpdate{UpdatePortDescriptions: c.Query("update_port_descriptions") == "true"}
+	targets := map[string]*string{
+		services.SwitchFieldName:      &update.Name,
+		services.SwitchFieldModel:     &update.Model,
+		services.SwitchFieldIPAddress: &update.IPAddress,
+	}
+	for field, raw := range body {
+		update.Fields = append(update.Fields, field)
+		if target, ok := targets[field]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be a string", field)})
+				return
+			}
+		}
+	}
+	sort.Strings(update.Fields)
+
+	fabricIDOrName := c.Param("id")
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, c.Param("switchId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
+		return
+	}
+
+	result, err := h.fabrics.UpdateSwitch(c.Request.Context(), sw.ID, update)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSwitchNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
+		case errors.Is(err, services.ErrImmutableSwitchField), errors.Is(err, services.ErrInvalidSwitchUpdate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // findSwitch resolves a switch by ID, serial number, or name within a fabric
-func (h *FabricHandler) findSwitch(fabricID, switchIDOrSerial string) (*models.Switch, error) {
+func (h *FabricHandler) findSwitch(ctx context.Context, fabricID, switchIDOrSerial string) (*models.Switch, error) {
 	var sw models.Switch
 	// Try by ID first
-	if err := database.DB.Where("id = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
+	if err := database.DB.WithContext(ctx).Where("id = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
 		return &sw, nil
 	}
 	// Try by serial number
-	if err := database.DB.Where("serial_number = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
+	if err := database.DB.WithContext(ctx).Where("serial_number = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
 		return &sw, nil
 	}
 	// Try by name
-	if err := database.DB.Where("name = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
+	if err := database.DB.WithContext(ctx).Where("name = ? AND fabric_id = ?", switchIDOrSerial, fabricID).First(&sw).Error; err == nil {
 		return &sw, nil
 	}
 	return nil, fmt.Errorf("switch not found")
@@ -192,21 +457,21 @@ func (h *FabricHandler) GetSwitch(c *gin.Context) {
 
 	// Find fabric first
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
-	sw, err := h.findSwitch(fabric.ID, switchIDOrSerial)
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, switchIDOrSerial)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 		return
 	}
 
 	// Preload ports
-	database.DB.Preload("Ports").First(sw, "id = ?", sw.ID)
+	database.DB.WithContext(c.Request.Context()).Preload("Ports").First(sw, "id = ?", sw.ID)
 	c.JSON(http.StatusOK, sw)
 }
 
@@ -216,8 +481,8 @@ func (h *FabricHandler) SyncAllPorts(c *gin.Context) {
 
 	// Find fabric by ID first, then by name
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
@@ -225,7 +490,7 @@ func (h *FabricHandler) SyncAllPorts(c *gin.Context) {
 
 	// Get all switches for the fabric
 	var switches []models.Switch
-	if err := database.DB.Where("fabric_id = ?", fabric.ID).Find(&switches).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("fabric_id = ?", fabric.ID).Find(&switches).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -236,7 +501,7 @@ func (h *FabricHandler) SyncAllPorts(c *gin.Context) {
 	}
 
 	// Get uplink ports to exclude (inter-switch links) - uses cache if available
-	uplinks := sync.GetUplinksWithCache(c.Request.Context(), h.ndClient.LANFabric(), fabric.Name, cache.Client)
+	uplinks := sync.GetUplinksWithCache(c.Request.Context(), h.ndClient.LANFabric(), fabric.Name, h.uplinks)
 
 	var totalPorts int
 	var totalErrors int
@@ -289,21 +554,21 @@ func (h *FabricHandler) SyncSwitchPorts(c *gin.Context) {
 
 	// Find fabric first
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
-	sw, err := h.findSwitch(fabric.ID, switchIDOrSerial)
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, switchIDOrSerial)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 		return
 	}
 
 	// Get uplink ports to exclude (inter-switch links) - uses cache if available
-	uplinks := sync.GetUplinksWithCache(c.Request.Context(), h.ndClient.LANFabric(), fabric.Name, cache.Client)
+	uplinks := sync.GetUplinksWithCache(c.Request.Context(), h.ndClient.LANFabric(), fabric.Name, h.uplinks)
 
 	// Use shared helper for port sync
 	result, err := sync.SyncSwitchPorts(
@@ -327,6 +592,41 @@ func (h *FabricHandler) SyncSwitchPorts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Ports synced", "count": result.Synced, "total": result.Total})
 }
 
+// BulkSyncPorts syncs ports for several switches of a fabric in parallel. The optional body
+// {"switch_ids": [...]} picks the switches; without it every switch in the fabric is synced.
+// Per-switch failures are reported in the results without failing the request.
+func (h *FabricHandler) BulkSyncPorts(c *gin.Context) {
+	fabricIDOrName := c.Param("id")
+
+	var input struct {
+		SwitchIDs []string `json:"switch_ids"`
+	}
+	if !bindOptionalJSON(c, &input) {
+		return
+	}
+
+	// Find fabric by ID first, then by name
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	start := time.Now()
+	results, err := sync.BulkSyncSwitchPorts(c.Request.Context(), database.DB, h.ndClient.LANFabric(), fabric.ID, input.SwitchIDs, h.uplinks)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":     results,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+}
+
 // DeleteSwitchPorts deletes all ports for a switch (by ID, serial, or name)
 func (h *FabricHandler) DeleteSwitchPorts(c *gin.Context) {
 	fabricIDOrName := c.Param("id")
@@ -334,20 +634,20 @@ func (h *FabricHandler) DeleteSwitchPorts(c *gin.Context) {
 
 	// Find fabric first
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
-	sw, err := h.findSwitch(fabric.ID, switchIDOrSerial)
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, switchIDOrSerial)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 		return
 	}
 
-	result := database.DB.Where("switch_id = ?", sw.ID).Delete(&models.SwitchPort{})
+	result := database.DB.WithContext(c.Request.Context()).Where("switch_id = ?", sw.ID).Delete(&models.SwitchPort{})
 	if result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
 		return
@@ -362,24 +662,101 @@ func (h *FabricHandler) GetSwitchPorts(c *gin.Context) {
 
 	// Find fabric first
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
-	sw, err := h.findSwitch(fabric.ID, switchIDOrSerial)
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, switchIDOrSerial)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 		return
 	}
 
 	var ports []models.SwitchPort
-	if err := database.DB.Where("switch_id = ?", sw.ID).Find(&ports).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("switch_id = ?", sw.ID).Find(&ports).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range ports {
+		ports[i].DisplayName = util.DisplayPortName(ports[i].Name, h.portNameFormat)
+	}
+	c.JSON(http.StatusOK, ports)
+}
+
+// GetPortHistory lists port mapping changes involving ports on the fabric's switches.
+// Optional ?port_id= limits results to changes to or from a single switch port.
+func (h *FabricHandler) GetPortHistory(c *gin.Context) {
+	fabricIDOrName := c.Param("id")
+
+	// Find fabric by ID first, then by name
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	history, err := services.FabricPortHistory(c.Request.Context(), database.DB, fabric.ID, c.Query("port_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// SearchFabricPorts searches ports across all switches in a fabric.
+// Query params: description_contains (case-insensitive), admin_state (true|false), speed (e.g., 100G).
+// Each result includes the switch name/serial and the Slurm job currently using the port, if any.
+func (h *FabricHandler) SearchFabricPorts(c *gin.Context) {
+	fabricIDOrName := c.Param("id")
+
+	// Find fabric by ID first, then by name
+	var fabric models.Fabric
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+			return
+		}
+	}
+
+	filter := services.PortSearchFilter{
+		DescriptionContains: c.Query("description_contains"),
+		AdminState:          c.Query("admin_state"),
+		Speed:               c.Query("speed"),
+	}
+
+	ctx := c.Request.Context()
+	ports, err := services.SearchFabricPorts(ctx, database.DB, fabric.ID, filter)
+	if errors.Is(err, services.ErrInvalidPortFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Fill in owning job after the main query (single lookup for all ports)
+	portIDs := make([]string, len(ports))
+	for i := range ports {
+		portIDs[i] = ports[i].ID
+	}
+	owners, err := services.PortJobOwners(ctx, database.DB, portIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	for i := range ports {
+		if slurmJobID, ok := owners[ports[i].ID]; ok {
+			ports[i].CurrentJobSlurmID = &slurmJobID
+		}
+		ports[i].DisplayName = util.DisplayPortName(ports[i].Name, h.portNameFormat)
+	}
+
 	c.JSON(http.StatusOK, ports)
 }
 
@@ -387,10 +764,11 @@ func (h *FabricHandler) GetSwitchPorts(c *gin.Context) {
 func (h *FabricHandler) GetSwitchPort(c *gin.Context) {
 	portID := c.Param("portId")
 	var port models.SwitchPort
-	if err := database.DB.First(&port, "id = ?", portID).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&port, "id = ?", portID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Port not found"})
 		return
 	}
+	port.DisplayName = util.DisplayPortName(port.Name, h.portNameFormat)
 	c.JSON(http.StatusOK, port)
 }
 
@@ -401,14 +779,14 @@ func (h *FabricHandler) CreateSwitchPort(c *gin.Context) {
 
 	// Find fabric first
 	var fabric models.Fabric
-	if err := database.DB.First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
-		if err := database.DB.Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).First(&fabric, "id = ?", fabricIDOrName).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("name = ?", fabricIDOrName).First(&fabric).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
 			return
 		}
 	}
 
-	sw, err := h.findSwitch(fabric.ID, switchIDOrSerial)
+	sw, err := h.findSwitch(c.Request.Context(), fabric.ID, switchIDOrSerial)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Switch not found"})
 		return
@@ -434,11 +812,12 @@ func (h *FabricHandler) CreateSwitchPort(c *gin.Context) {
 		Description: input.Description,
 		AdminState:  input.AdminState,
 		IsPresent:   true,
+		IsBreakout:  lanfabric.IsBreakoutPort(input.Name),
 		Speed:       input.Speed,
 		SwitchID:    sw.ID,
 	}
 
-	if err := database.DB.Create(&port).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&port).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -462,3 +841,125 @@ func (h *FabricHandler) GetNetworks(c *gin.Context) {
 
 	c.JSON(http.StatusOK, networks)
 }
+
+// GetNetworkAttachmentCount returns how many ports provisioning has attached to a network and
+// the configured limit (0 = unlimited)
+func (h *FabricHandler) GetNetworkAttachmentCount(c *gin.Context) {
+	fabricName := c.Param("id")
+	networkName := c.Param("networkName")
+
+	current, err := services.NetworkAttachmentCount(c.Request.Context(), database.DB, fabricName, networkName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"fabric":  fabricName,
+		"network": networkName,
+		"current": current,
+		"max":     h.maxPortsPerNetwork,
+	})
+}
+
+// GetPortAttachments reports, for each network of the fabric that provisioning has used, the
+// switch ports NDFC has attached to it (for compliance audits)
+func (h *FabricHandler) GetPortAttachments(c *gin.Context) {
+	fabricName, ok := h.ndfcFabricName(c)
+	if !ok {
+		return
+	}
+
+	report, err := services.PortAttachmentReport(c.Request.Context(), database.DB, h.ndClient.LANFabric(), cache.Default(), fabricName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ndfcFabricName resolves the :id parameter (fabric ID or name) to the fabric name NDFC
+// knows it by. On failure it writes the error response and returns false.
+func (h *FabricHandler) ndfcFabricName(c *gin.Context) (string, bool) {
+	fabric, err := h.fabrics.GetFabric(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrFabricNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fabric not found"})
+		return "", false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return fabric.Name, true
+}
+
+// GetConfigState returns the fabric's config-deploy state in NDFC: the config-preview status
+// of each switch and the switches a deploy would push configuration to
+func (h *FabricHandler) GetConfigState(c *gin.Context) {
+	fabricName, ok := h.ndfcFabricName(c)
+	if !ok {
+		return
+	}
+
+	state, err := h.ndClient.GetFabricConfigState(c.Request.Context(), fabricName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// GetVRFs lists the VRFs NDFC has for a fabric, e.g. to check ND_COMPUTE_VRF_NAME before
+// provisioning
+func (h *FabricHandler) GetVRFs(c *gin.Context) {
+	fabricName, ok := h.ndfcFabricName(c)
+	if !ok {
+		return
+	}
+
+	vrfs, err := h.ndClient.LANFabric().GetVRFsNDFC(c.Request.Context(), fabricName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, vrfs)
+}
+
+// GetVRFExists reports whether NDFC has a VRF with the given name in the fabric
+func (h *FabricHandler) GetVRFExists(c *gin.Context) {
+	fabricName, ok := h.ndfcFabricName(c)
+	if !ok {
+		return
+	}
+
+	exists, err := h.ndClient.LANFabric().VRFExists(c.Request.Context(), fabricName, c.Param("vrfName"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"exists": exists})
+}
+
+// GetNetworkVLAN returns the VLAN NDFC assigned to a network
+func (h *FabricHandler) GetNetworkVLAN(c *gin.Context) {
+	fabricName, ok := h.ndfcFabricName(c)
+	if !ok {
+		return
+	}
+
+	vlan, err := h.ndClient.LANFabric().GetNetworkVLAN(c.Request.Context(), fabricName, c.Param("networkName"))
+	if errors.Is(err, lanfabric.ErrNetworkNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"vlan": vlan})
+}
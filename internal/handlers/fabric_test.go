@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/banglin/go-nd/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+// newFabricNDFCTestRouter serves the VRF and network VLAN routes for fabric fab-1 (named
+// DevNet_Fabric) against a fake NDFC that only knows the fabric by name
+func newFabricNDFCTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Fabric{})
+	if err := db.Create(&models.Fabric{ID: "fab-1", Name: "DevNet_Fabric"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/top-down/fabrics/DevNet_Fabric/vrfs"):
+			_, _ = w.Write([]byte(`[{"fabric":"DevNet_Fabric","vrfName":"hpc","vrfId":50000,"vrfStatus":"DEPLOYED"}]`))
+		case strings.HasSuffix(r.URL.Path, "/control/fabrics/DevNet_Fabric/config-preview"):
+			_, _ = w.Write([]byte(`[{"switchId":"SN1","hostName":"leaf1","status":"Out-of-Sync"},{"switchId":"SN2","hostName":"leaf2","status":"In-Sync"}]`))
+		case strings.HasSuffix(r.URL.Path, "/top-down/fabrics/DevNet_Fabric/networks"):
+			_, _ = w.Write([]byte(`[{"fabric":"DevNet_Fabric","networkName":"hpcnet","networkTemplateConfig":"{\"vlanId\":\"2301\"}"}]`))
+		default:
+			t.Errorf("unexpected NDFC request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	h := &FabricHandler{ndClient: client, fabrics: services.NewFabricService(db)}
+	r := gin.New()
+	r.GET("/fabrics/:id/vrfs", h.GetVRFs)
+	r.GET("/fabrics/:id/vrfs/:vrfName/exists", h.GetVRFExists)
+	r.GET("/fabrics/:id/networks/:networkName/vlan", h.GetNetworkVLAN)
+	r.GET("/fabrics/:id/config-state", h.GetConfigState)
+	return r
+}
+
+func TestGetVRFs_ResolvesFabricID(t *testing.T) {
+	r := newFabricNDFCTestRouter(t)
+
+	for _, id := range []string{"fab-1", "DevNet_Fabric"} {
+		w := doJSON(r, http.MethodGet, "/fabrics/"+id+"/vrfs", "")
+		var vrfs []map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &vrfs); w.Code != http.StatusOK || err != nil || len(vrfs) != 1 {
+			t.Fatalf("%s: %d %s, want one VRF", id, w.Code, w.Body)
+		}
+		if vrfs[0]["vrfName"] != "hpc" || vrfs[0]["vrfId"] != float64(50000) {
+			t.Errorf("%s: VRF = %v", id, vrfs[0])
+		}
+	}
+
+	if w := doJSON(r, http.MethodGet, "/fabrics/missing/vrfs", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown fabric: %d, want 404", w.Code)
+	}
+}
+
+func TestGetVRFExistsAndNetworkVLAN(t *testing.T) {
+	r := newFabricNDFCTestRouter(t)
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/fabrics/fab-1/vrfs/hpc/exists", http.StatusOK, `{"exists":true}`},
+		{"/fabrics/fab-1/vrfs/prod/exists", http.StatusOK, `{"exists":false}`},
+		{"/fabrics/fab-1/networks/hpcnet/vlan", http.StatusOK, `{"vlan":"2301"}`},
+		{"/fabrics/fab-1/networks/other/vlan", http.StatusNotFound, ""},
+		{"/fabrics/missing/networks/hpcnet/vlan", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := doJSON(r, http.MethodGet, tt.path, "")
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: code = %d, want %d (%s)", tt.path, w.Code, tt.wantCode, w.Body)
+			continue
+		}
+		if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+			t.Errorf("%s: body = %s, want %s", tt.path, w.Body, tt.wantBody)
+		}
+	}
+}
+
+func TestGetConfigState(t *testing.T) {
+	r := newFabricNDFCTestRouter(t)
+
+	w := doJSON(r, http.MethodGet, "/fabrics/fab-1/config-state", "")
+	want := `{"switches":[{"switchId":"SN1","hostName":"leaf1","status":"Out-of-Sync"},{"switchId":"SN2","hostName":"leaf2","status":"In-Sync"}],"outOfSyncSwitches":["SN1"]}`
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("config state = %d %s, want 200 %s", w.Code, w.Body, want)
+	}
+	if w := doJSON(r, http.MethodGet, "/fabrics/missing/config-state", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown fabric: %d, want 404", w.Code)
+	}
+}
+
+func TestGetSwitchPorts_DisplayNameKeepsStoredName(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Fabric{}, &models.Switch{}, &models.SwitchPort{})
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+	if err := db.Create(&models.Fabric{ID: "fab-1", Name: "DevNet_Fabric"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "fab-1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.SwitchPort{ID: "s1:Ethernet1/1", Name: "Ethernet1/1", SwitchID: "s1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	h := &FabricHandler{}
+	h.SetPortNameFormat(util.PortNameFormatShort)
+	r := gin.New()
+	r.GET("/fabrics/:id/switches/:switchId/ports", h.GetSwitchPorts)
+
+	w := doJSON(r, http.MethodGet, "/fabrics/fab-1/switches/s1/ports", "")
+	var ports []models.SwitchPort
+	if err := json.Unmarshal(w.Body.Bytes(), &ports); w.Code != http.StatusOK || err != nil || len(ports) != 1 {
+		t.Fatalf("list = %d %s, want one port", w.Code, w.Body)
+	}
+	if ports[0].ID != "s1:Ethernet1/1" || ports[0].Name != "Ethernet1/1" || ports[0].DisplayName != "Eth1/1" {
+		t.Errorf("port = %+v, want the NDFC name with display name Eth1/1", ports[0])
+	}
+
+}
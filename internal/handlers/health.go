@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/gin-gonic/gin"
+)
+
+// healthPingTimeout bounds the per-request Valkey shard pings
+const healthPingTimeout = 2 * time.Second
+
+// ndfcConfigCheckTimeout bounds the NDFC lookups behind the ndfc-config health check
+const ndfcConfigCheckTimeout = 15 * time.Second
+
+// Health reports service status. In Valkey cluster mode every shard is pinged
+// and the response is 503 "degraded" if any shard does not answer.
+func Health(c *gin.Context) {
+	if cache.Client == nil || !cache.Client.IsCluster() {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	shards := make(gin.H)
+	healthy := true
+	for addr, err := range cache.Client.PingNodes(ctx) {
+		if err != nil {
+			shards[addr] = err.Error()
+			healthy = false
+		} else {
+			shards[addr] = "ok"
+		}
+	}
+
+	if !healthy {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "degraded", "valkey_shards": shards})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "valkey_shards": shards})
+}
+
+// NDFCHealth returns a handler reporting the state of the client's NDFC circuit breaker:
+// closed, open or half_open, with its consecutive failures. The response is 503 while the
+// circuit is open, as NDFC requests then fail without being sent. With the breaker disabled
+// the state is "disabled".
+func NDFCHealth(client *ndclient.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if client == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "NDFC client not configured"})
+			return
+		}
+		if client.CircuitBreaker() == nil {
+			c.JSON(http.StatusOK, gin.H{"state": "disabled"})
+			return
+		}
+		status := client.CircuitBreaker().Status()
+		if status.State == ndclient.CircuitOpen {
+			c.JSON(http.StatusServiceUnavailable, status)
+			return
+		}
+		c.JSON(http.StatusOK, status)
+	}
+}
+
+// NDFCConfigHealth reports whether the NDFC fabrics, VRF and networks named in configuration
+// exist. The response is 503 if any configured object is missing or could not be checked.
+func (h *JobHandler) NDFCConfigHealth(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), ndfcConfigCheckTimeout)
+	defer cancel()
+
+	status, err := h.svc.CheckNDFCConfig(ctx)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	if len(status.Missing(h.svc.NDFCConfigNames())) > 0 || len(status.Errors) > 0 {
+		c.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
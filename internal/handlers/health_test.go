@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/gin-gonic/gin"
+)
+
+func TestNDFCHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.GET("/api/v1/health/ndfc", NDFCHealth(client))
+
+	check := func(wantCode int, wantState string, wantFailures int) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/health/ndfc", nil))
+		var body struct {
+			State               string  `json:"state"`
+			ConsecutiveFailures int     `json:"consecutive_failures"`
+			HalfOpenAt          *string `json:"half_open_at"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		if w.Code != wantCode || body.State != wantState || body.ConsecutiveFailures != wantFailures {
+			t.Errorf("GET /health/ndfc = %d %s, want %d with state %s and %d failures", w.Code, w.Body.String(), wantCode, wantState, wantFailures)
+		}
+		if (wantState == "open") != (body.HalfOpenAt != nil) {
+			t.Errorf("half_open_at = %v for state %s", body.HalfOpenAt, wantState)
+		}
+	}
+
+	check(http.StatusOK, "closed", 0)
+	_ = client.Get(context.Background(), "/x", nil)
+	check(http.StatusOK, "closed", 1)
+	for i := 1; i < ndclient.DefaultCircuitFailureThreshold; i++ {
+		_ = client.Get(context.Background(), "/x", nil)
+	}
+	check(http.StatusServiceUnavailable, "open", ndclient.DefaultCircuitFailureThreshold)
+
+	r.GET("/nil", NDFCHealth(nil))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nil", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a client: %d, want 503", w.Code)
+	}
+}
+
+func TestNDFCHealth_BreakerDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "k", CircuitBreakerDisabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.GET("/api/v1/health/ndfc", NDFCHealth(client))
+
+	// Every request still reaches NDFC
+	for i := 0; i < ndclient.DefaultCircuitFailureThreshold+1; i++ {
+		if err := client.Get(context.Background(), "/x", nil); errors.Is(err, ndclient.ErrCircuitOpen) {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/health/ndfc", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"disabled"`) {
+		t.Errorf("GET /health/ndfc = %d %s, want 200 disabled", w.Code, w.Body.String())
+	}
+}
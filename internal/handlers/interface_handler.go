@@ -2,20 +2,27 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/logger"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/services"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InterfaceHandler handles compute node interface operations
 type InterfaceHandler struct {
 	storageService *services.StorageService
+	deployer       services.FabricDeployer
 }
 
 // NewInterfaceHandler creates a new InterfaceHandler
@@ -25,19 +32,25 @@ func NewInterfaceHandler(storageService *services.StorageService) *InterfaceHand
 	}
 }
 
+// SetDeployer sets the deployer used to deploy storage SG changes. Without one, changed
+// selectors are left for the next deploy of the storage fabric.
+func (h *InterfaceHandler) SetDeployer(deployer services.FabricDeployer) {
+	h.deployer = deployer
+}
+
 // GetInterfaces returns all interfaces for a compute node
 func (h *InterfaceHandler) GetInterfaces(c *gin.Context) {
 	nodeID := c.Param("id")
 
 	// Find node by ID or name
 	var node models.ComputeNode
-	if err := database.DB.Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 
 	var interfaces []models.ComputeNodeInterface
-	if err := database.DB.Where("compute_node_id = ?", node.ID).
+	if err := database.DB.WithContext(c.Request.Context()).Where("compute_node_id = ?", node.ID).
 		Preload("PortMappings.SwitchPort.Switch").
 		Find(&interfaces).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interfaces"})
@@ -53,6 +66,7 @@ type CreateInterfaceInput struct {
 	Hostname   string `json:"hostname"`
 	IPAddress  string `json:"ip_address"`
 	MACAddress string `json:"mac_address"`
+	Label      string `json:"label"`
 }
 
 // CreateInterface creates a new interface for a compute node
@@ -73,14 +87,14 @@ func (h *InterfaceHandler) CreateInterface(c *gin.Context) {
 
 	// Find node by ID or name
 	var node models.ComputeNode
-	if err := database.DB.Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 
 	// Check max 2 interfaces per node
 	var interfaceCount int64
-	database.DB.Model(&models.ComputeNodeInterface{}).Where("compute_node_id = ?", node.ID).Count(&interfaceCount)
+	database.DB.WithContext(c.Request.Context()).Model(&models.ComputeNodeInterface{}).Where("compute_node_id = ?", node.ID).Count(&interfaceCount)
 	if interfaceCount >= 2 {
 		c.JSON(http.StatusConflict, gin.H{"error": "Node already has maximum of 2 interfaces (compute and storage)"})
 		return
@@ -88,7 +102,7 @@ func (h *InterfaceHandler) CreateInterface(c *gin.Context) {
 
 	// Check if interface with this role already exists (no duplicate roles)
 	var existing models.ComputeNodeInterface
-	if err := database.DB.Where("compute_node_id = ? AND role = ?", node.ID, input.Role).First(&existing).Error; err == nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("compute_node_id = ? AND role = ?", node.ID, input.Role).First(&existing).Error; err == nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "Interface with this role already exists for this node"})
 		return
 	}
@@ -100,9 +114,10 @@ func (h *InterfaceHandler) CreateInterface(c *gin.Context) {
 		Hostname:      input.Hostname,
 		IPAddress:     input.IPAddress,
 		MACAddress:    input.MACAddress,
+		Label:         input.Label,
 	}
 
-	if err := database.DB.Create(&iface).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&iface).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create interface"})
 		return
 	}
@@ -115,6 +130,7 @@ type UpdateInterfaceInput struct {
 	Hostname   *string `json:"hostname"`
 	IPAddress  *string `json:"ip_address"`
 	MACAddress *string `json:"mac_address"`
+	Label      *string `json:"label"`
 }
 
 // UpdateInterface updates an interface
@@ -130,13 +146,13 @@ func (h *InterfaceHandler) UpdateInterface(c *gin.Context) {
 
 	// Find node by ID or name
 	var node models.ComputeNode
-	if err := database.DB.Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 
 	var iface models.ComputeNodeInterface
-	if err := database.DB.Where("id = ? AND compute_node_id = ?", ifaceID, node.ID).First(&iface).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND compute_node_id = ?", ifaceID, node.ID).First(&iface).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Interface not found"})
 		return
 	}
@@ -151,18 +167,143 @@ func (h *InterfaceHandler) UpdateInterface(c *gin.Context) {
 	if input.MACAddress != nil {
 		updates["mac_address"] = *input.MACAddress
 	}
+	if input.Label != nil {
+		updates["label"] = *input.Label
+	}
 
 	if len(updates) > 0 {
-		if err := database.DB.Model(&iface).Updates(updates).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Model(&iface).Updates(updates).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update interface"})
 			return
 		}
 	}
 
-	database.DB.First(&iface, "id = ?", iface.ID)
+	database.DB.WithContext(c.Request.Context()).First(&iface, "id = ?", iface.ID)
+	c.JSON(http.StatusOK, iface)
+}
+
+// UpdateInterfaceRoleInput represents the input for changing an interface's role
+type UpdateInterfaceRoleInput struct {
+	Role string `json:"role" binding:"required,oneof=compute storage"`
+}
+
+// errInterfaceRoleConflict rolls back a role change the node's allocation or interfaces forbid
+var errInterfaceRoleConflict = errors.New("interface role conflict")
+
+// UpdateInterfaceRole changes an interface between compute and storage. The node must not be
+// allocated to a job, and no other interface on it may already have the new role. When the
+// interface becomes or stops being the storage interface, the node's storage SG selectors in
+// NDFC are reconciled and the storage fabric is deployed.
+func (h *InterfaceHandler) UpdateInterfaceRole(c *gin.Context) {
+	nodeID := c.Param("id")
+	ifaceID := c.Param("interfaceId")
+	ctx := c.Request.Context()
+
+	var input UpdateInterfaceRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	newRole := models.InterfaceRole(input.Role)
+
+	// Find node by ID or name
+	var node models.ComputeNode
+	if err := database.DB.WithContext(ctx).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
+		return
+	}
+
+	var iface models.ComputeNodeInterface
+	if err := database.DB.WithContext(ctx).Where("id = ? AND compute_node_id = ?", ifaceID, node.ID).First(&iface).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Interface not found"})
+		return
+	}
+	oldRole := iface.Role
+	if oldRole == newRole {
+		c.JSON(http.StatusOK, iface)
+		return
+	}
+
+	// Check and update under the node's row lock, which job allocation also takes, so a job
+	// cannot be allocated the node (or another interface take the role) in between
+	var conflict string
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&node, "id = ?", node.ID).Error; err != nil {
+			return err
+		}
+
+		// Changing roles under a running job would invalidate the job's network
+		alloc, err := services.GetNodeAllocation(ctx, tx, node.ID)
+		if err != nil {
+			return err
+		}
+		if alloc != nil {
+			conflict = fmt.Sprintf("Compute node is allocated to job %s", alloc.JobSlurmID)
+			return errInterfaceRoleConflict
+		}
+
+		var existing int64
+		if err := tx.Model(&models.ComputeNodeInterface{}).
+			Where("compute_node_id = ? AND role = ? AND id <> ?", node.ID, newRole, iface.ID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			conflict = fmt.Sprintf("Compute node already has a %s interface", newRole)
+			return errInterfaceRoleConflict
+		}
+
+		return tx.Model(&iface).Update("role", newRole).Error
+	})
+	if errors.Is(err, errInterfaceRoleConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": conflict})
+		return
+	}
+	if err != nil {
+		logger.Error("Interface role update failed", zap.String("interface", iface.ID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update interface role"})
+		return
+	}
+
+	// Add or remove the interface's ports in the node's storage SG (best-effort)
+	if h.storageService != nil && (oldRole == models.InterfaceRoleStorage || newRole == models.InterfaceRoleStorage) {
+		h.reconcileStorageRole(ctx, &node, &iface)
+	}
+
+	database.DB.WithContext(ctx).First(&iface, "id = ?", iface.ID)
 	c.JSON(http.StatusOK, iface)
 }
 
+// reconcileStorageRole reconciles the node's storage SG after iface gained or lost the storage
+// role, and deploys the storage fabric if the interface has mapped ports (its selectors changed)
+func (h *InterfaceHandler) reconcileStorageRole(ctx context.Context, node *models.ComputeNode, iface *models.ComputeNodeInterface) {
+	if err := h.storageService.ReconcileNodeStorageSG(ctx, node); err != nil {
+		logger.Warn("Failed to reconcile storage SG after interface role change",
+			zap.String("node", node.Name),
+			zap.String("interface", iface.ID),
+			zap.Error(err))
+		return
+	}
+
+	var mapped int64
+	if err := database.DB.WithContext(ctx).Model(&models.ComputeNodePortMapping{}).
+		Where("interface_id = ?", iface.ID).
+		Count(&mapped).Error; err != nil || mapped == 0 {
+		return
+	}
+
+	fabricName := h.storageService.GetStorageFabricName()
+	if h.deployer == nil || fabricName == "" {
+		return
+	}
+	if err := h.deployer.RequestDeploy(ctx, fabricName); err != nil {
+		logger.Warn("Failed to deploy storage SG changes",
+			zap.String("node", node.Name),
+			zap.String("fabric", fabricName),
+			zap.Error(err))
+	}
+}
+
 // DeleteInterface deletes an interface
 func (h *InterfaceHandler) DeleteInterface(c *gin.Context) {
 	nodeID := c.Param("id")
@@ -170,26 +311,26 @@ func (h *InterfaceHandler) DeleteInterface(c *gin.Context) {
 
 	// Find node by ID or name
 	var node models.ComputeNode
-	if err := database.DB.Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 
 	var iface models.ComputeNodeInterface
-	if err := database.DB.Where("id = ? AND compute_node_id = ?", ifaceID, node.ID).First(&iface).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND compute_node_id = ?", ifaceID, node.ID).First(&iface).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Interface not found"})
 		return
 	}
 
 	// Unlink any port mappings from this interface
-	if err := database.DB.Model(&models.ComputeNodePortMapping{}).
+	if err := database.DB.WithContext(c.Request.Context()).Model(&models.ComputeNodePortMapping{}).
 		Where("interface_id = ?", iface.ID).
 		Update("interface_id", nil).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink port mappings"})
 		return
 	}
 
-	if err := database.DB.Delete(&iface).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Delete(&iface).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete interface"})
 		return
 	}
@@ -215,13 +356,13 @@ func (h *InterfaceHandler) AssignPortMapping(c *gin.Context) {
 
 	// Find node by ID or name
 	var node models.ComputeNode
-	if err := database.DB.Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? OR name = ?", nodeID, nodeID).First(&node).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Compute node not found"})
 		return
 	}
 
 	var mapping models.ComputeNodePortMapping
-	if err := database.DB.Where("id = ? AND compute_node_id = ?", mappingID, node.ID).First(&mapping).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND compute_node_id = ?", mappingID, node.ID).First(&mapping).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Port mapping not found"})
 		return
 	}
@@ -232,7 +373,7 @@ func (h *InterfaceHandler) AssignPortMapping(c *gin.Context) {
 	if mapping.InterfaceID != nil {
 		oldInterfaceID = mapping.InterfaceID
 		var oldIface models.ComputeNodeInterface
-		if err := database.DB.First(&oldIface, "id = ?", *oldInterfaceID).Error; err == nil {
+		if err := database.DB.WithContext(c.Request.Context()).First(&oldIface, "id = ?", *oldInterfaceID).Error; err == nil {
 			wasStorageInterface = oldIface.Role == models.InterfaceRoleStorage
 		}
 	}
@@ -240,7 +381,7 @@ func (h *InterfaceHandler) AssignPortMapping(c *gin.Context) {
 	// Validate interface belongs to same node if provided
 	if input.InterfaceID != nil && *input.InterfaceID != "" {
 		var iface models.ComputeNodeInterface
-		if err := database.DB.Where("id = ? AND compute_node_id = ?", *input.InterfaceID, node.ID).First(&iface).Error; err != nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("id = ? AND compute_node_id = ?", *input.InterfaceID, node.ID).First(&iface).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Interface not found or doesn't belong to this node"})
 			return
 		}
@@ -249,7 +390,7 @@ func (h *InterfaceHandler) AssignPortMapping(c *gin.Context) {
 		mapping.InterfaceID = nil
 	}
 
-	if err := database.DB.Save(&mapping).Error; err != nil {
+	if err := database.DB.WithContext(actorContext(c)).Save(&mapping).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update port mapping"})
 		return
 	}
@@ -259,39 +400,37 @@ func (h *InterfaceHandler) AssignPortMapping(c *gin.Context) {
 		var isStorageInterface bool
 		if input.InterfaceID != nil && *input.InterfaceID != "" {
 			var iface models.ComputeNodeInterface
-			if err := database.DB.First(&iface, "id = ?", *input.InterfaceID).Error; err == nil {
+			if err := database.DB.WithContext(c.Request.Context()).First(&iface, "id = ?", *input.InterfaceID).Error; err == nil {
 				isStorageInterface = iface.Role == models.InterfaceRoleStorage
 			}
 		}
 		// Update if assigned to storage OR unassigned from storage
 		if isStorageInterface || wasStorageInterface {
-			h.updateStorageSGSelectors(node.ID, node.Name)
+			h.updateStorageSGSelectors(c.Request.Context(), node.ID, node.Name)
 		}
 	}
 	_ = oldInterfaceID // silence unused warning
 
 	// Reload with associations
-	database.DB.Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
+	database.DB.WithContext(c.Request.Context()).Preload("SwitchPort.Switch").First(&mapping, "id = ?", mapping.ID)
 	c.JSON(http.StatusOK, mapping)
 }
 
 // updateStorageSGSelectors updates the storage SG selectors in NDFC for a node
-func (h *InterfaceHandler) updateStorageSGSelectors(nodeID, nodeName string) {
+func (h *InterfaceHandler) updateStorageSGSelectors(ctx context.Context, nodeID, nodeName string) {
 	if h.storageService == nil {
 		return
 	}
 
-	ctx := context.Background()
-
 	// Get storage interface for this node
 	var storageIface models.ComputeNodeInterface
-	if err := database.DB.Where("compute_node_id = ? AND role = ?", nodeID, models.InterfaceRoleStorage).First(&storageIface).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Where("compute_node_id = ? AND role = ?", nodeID, models.InterfaceRoleStorage).First(&storageIface).Error; err != nil {
 		return
 	}
 
 	// Get all port mappings for the storage interface
 	var mappings []models.ComputeNodePortMapping
-	if err := database.DB.Where("interface_id = ?", storageIface.ID).
+	if err := database.DB.WithContext(ctx).Where("interface_id = ?", storageIface.ID).
 		Preload("SwitchPort.Switch").
 		Find(&mappings).Error; err != nil {
 		return
@@ -313,7 +452,7 @@ func (h *InterfaceHandler) updateStorageSGSelectors(nodeID, nodeName string) {
 
 	// Get the node
 	var node models.ComputeNode
-	if err := database.DB.First(&node, "id = ?", nodeID).Error; err != nil {
+	if err := database.DB.WithContext(ctx).First(&node, "id = ?", nodeID).Error; err != nil {
 		return
 	}
 
@@ -335,6 +474,7 @@ type BulkPortAssignment struct {
 	SwitchPortID string  `json:"switch_port_id" binding:"required"`
 	NodeID       *string `json:"node_id"`      // nil to unassign
 	InterfaceID  *string `json:"interface_id"` // nil for no interface assignment
+	VLAN         *int    `json:"vlan"`         // nil leaves the VLAN unchanged; 0 clears it
 }
 
 // BulkAssignPortMappingsInput represents the input for bulk port mapping assignment
@@ -342,8 +482,20 @@ type BulkAssignPortMappingsInput struct {
 	Assignments []BulkPortAssignment `json:"assignments" binding:"required"`
 }
 
-// BulkAssignPortMappings handles bulk assignment of switch ports to nodes and interfaces
+// errBulkAssignAborted rolls back an atomic bulk assignment with failed items
+var errBulkAssignAborted = errors.New("bulk assignment aborted")
+
+// BulkAssignPortMappings handles bulk assignment of switch ports to nodes and interfaces.
+// All assignments run in one transaction. By default (?atomic=false) failed assignments are
+// rolled back individually and the rest are committed; with ?atomic=true any failure rolls back
+// the whole batch and the response is 422 with committed=false.
 func (h *InterfaceHandler) BulkAssignPortMappings(c *gin.Context) {
+	atomic, err := strconv.ParseBool(c.DefaultQuery("atomic", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "atomic must be true or false"})
+		return
+	}
+
 	var input BulkAssignPortMappingsInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -355,133 +507,196 @@ func (h *InterfaceHandler) BulkAssignPortMappings(c *gin.Context) {
 		return
 	}
 
+	// Reject the whole batch on any bad VLAN, reporting every offending assignment
+	var vlanErrors []string
+	for i, assignment := range input.Assignments {
+		if assignment.VLAN == nil || *assignment.VLAN == 0 {
+			continue
+		}
+		if err := util.ValidateVLAN(*assignment.VLAN); err != nil {
+			vlanErrors = append(vlanErrors, fmt.Sprintf("assignment %d (%s): %v", i, assignment.SwitchPortID, err))
+		}
+	}
+	if len(vlanErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": vlanErrors[0], "errors": vlanErrors})
+		return
+	}
+
 	// Track nodes that need storage SG updates
 	affectedNodes := make(map[string]bool)
 	results := make([]gin.H, 0, len(input.Assignments))
-
-	for _, assignment := range input.Assignments {
-		result := gin.H{"switch_port_id": assignment.SwitchPortID}
-
-		// Find existing port mapping by switch_port_id
-		var mapping models.ComputeNodePortMapping
-		err := database.DB.Where("switch_port_id = ?", assignment.SwitchPortID).First(&mapping).Error
-
-		if assignment.NodeID == nil || *assignment.NodeID == "" {
-			// Unassign: delete the mapping if it exists
-			if err == nil {
-				// Track old node for storage SG update
-				if mapping.InterfaceID != nil {
-					var oldIface models.ComputeNodeInterface
-					if database.DB.First(&oldIface, "id = ?", *mapping.InterfaceID).Error == nil {
-						if oldIface.Role == models.InterfaceRoleStorage {
-							affectedNodes[mapping.ComputeNodeID] = true
-						}
-					}
-				}
-				if err := database.DB.Delete(&mapping).Error; err != nil {
-					result["error"] = "Failed to delete mapping"
-					result["success"] = false
-				} else {
-					result["success"] = true
-					result["action"] = "deleted"
-				}
-			} else {
-				result["success"] = true
-				result["action"] = "no_change"
-			}
-		} else {
-			// Assign to node
-			var node models.ComputeNode
-			if err := database.DB.Where("id = ? OR name = ?", *assignment.NodeID, *assignment.NodeID).First(&node).Error; err != nil {
-				result["error"] = "Node not found"
-				result["success"] = false
-				results = append(results, result)
-				continue
+	failed := 0
+
+	err = database.DB.WithContext(actorContext(c)).Transaction(func(tx *gorm.DB) error {
+		for i, assignment := range input.Assignments {
+			// A savepoint per assignment undoes a failed item without aborting the transaction
+			savepoint := fmt.Sprintf("bulk_assign_%d", i)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return err
 			}
-
-			// Validate interface if provided
-			var interfaceID *string
-			if assignment.InterfaceID != nil && *assignment.InterfaceID != "" {
-				var iface models.ComputeNodeInterface
-				if err := database.DB.Where("id = ? AND compute_node_id = ?", *assignment.InterfaceID, node.ID).First(&iface).Error; err != nil {
-					result["error"] = "Interface not found or doesn't belong to this node"
-					result["success"] = false
-					results = append(results, result)
-					continue
-				}
-				// Check if this interface already has a port mapping (excluding current port)
-				var existingMapping models.ComputeNodePortMapping
-				query := database.DB.Where("interface_id = ? AND switch_port_id != ?", *assignment.InterfaceID, assignment.SwitchPortID)
-				if query.First(&existingMapping).Error == nil {
-					result["error"] = "Interface already has a port assigned. Each interface can only have one port."
-					result["success"] = false
-					results = append(results, result)
-					continue
-				}
-				interfaceID = assignment.InterfaceID
-				if iface.Role == models.InterfaceRoleStorage {
-					affectedNodes[node.ID] = true
-				}
-			}
-
-			if err == nil {
-				// Update existing mapping
-				oldNodeID := mapping.ComputeNodeID
-				oldInterfaceID := mapping.InterfaceID
-
-				// Track old node if it had a storage interface
-				if oldInterfaceID != nil {
-					var oldIface models.ComputeNodeInterface
-					if database.DB.First(&oldIface, "id = ?", *oldInterfaceID).Error == nil {
-						if oldIface.Role == models.InterfaceRoleStorage {
-							affectedNodes[oldNodeID] = true
-						}
-					}
-				}
-
-				mapping.ComputeNodeID = node.ID
-				mapping.InterfaceID = interfaceID
-				if err := database.DB.Save(&mapping).Error; err != nil {
-					result["error"] = "Failed to update mapping"
-					result["success"] = false
-				} else {
-					result["success"] = true
-					result["action"] = "updated"
-					result["mapping_id"] = mapping.ID
+			result, nodes := bulkAssignPort(tx, assignment)
+			if result["success"] == true {
+				for _, nodeID := range nodes {
+					affectedNodes[nodeID] = true
 				}
 			} else {
-				// Create new mapping
-				mapping = models.ComputeNodePortMapping{
-					ID:            uuid.New().String(),
-					ComputeNodeID: node.ID,
-					SwitchPortID:  assignment.SwitchPortID,
-					InterfaceID:   interfaceID,
-				}
-				if err := database.DB.Create(&mapping).Error; err != nil {
-					result["error"] = "Failed to create mapping"
-					result["success"] = false
-				} else {
-					result["success"] = true
-					result["action"] = "created"
-					result["mapping_id"] = mapping.ID
+				failed++
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
 				}
 			}
+			results = append(results, result)
 		}
-		results = append(results, result)
+		if atomic && failed > 0 {
+			return errBulkAssignAborted
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAssignAborted) {
+		logger.Error("Bulk port mapping assignment failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign port mappings"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":     fmt.Sprintf("%d of %d assignments failed, nothing was committed", failed, len(input.Assignments)),
+			"results":   results,
+			"total":     len(input.Assignments),
+			"failed":    failed,
+			"committed": false,
+		})
+		return
 	}
 
 	// Update storage SGs for affected nodes
 	if h.storageService != nil {
 		for nodeID := range affectedNodes {
 			var node models.ComputeNode
-			if database.DB.First(&node, "id = ?", nodeID).Error == nil {
-				h.updateStorageSGSelectors(node.ID, node.Name)
+			if database.DB.WithContext(c.Request.Context()).First(&node, "id = ?", nodeID).Error == nil {
+				h.updateStorageSGSelectors(c.Request.Context(), node.ID, node.Name)
 			}
 		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"results": results,
-		"total":   len(input.Assignments),
+		"results":   results,
+		"total":     len(input.Assignments),
+		"failed":    failed,
+		"committed": true,
 	})
 }
+
+// bulkAssignPort applies a single bulk assignment within tx. It returns the item result and
+// the nodes whose storage SG selectors need updating if the assignment is kept.
+func bulkAssignPort(tx *gorm.DB, assignment BulkPortAssignment) (gin.H, []string) {
+	result := gin.H{"switch_port_id": assignment.SwitchPortID}
+	var affectedNodes []string
+
+	// Find existing port mapping by switch_port_id
+	var mapping models.ComputeNodePortMapping
+	err := tx.Where("switch_port_id = ?", assignment.SwitchPortID).First(&mapping).Error
+
+	if assignment.NodeID == nil || *assignment.NodeID == "" {
+		// Unassign: delete the mapping if it exists
+		if err != nil {
+			result["success"] = true
+			result["action"] = "no_change"
+			return result, nil
+		}
+		// Track old node for storage SG update
+		if mapping.InterfaceID != nil {
+			var oldIface models.ComputeNodeInterface
+			if tx.First(&oldIface, "id = ?", *mapping.InterfaceID).Error == nil {
+				if oldIface.Role == models.InterfaceRoleStorage {
+					affectedNodes = append(affectedNodes, mapping.ComputeNodeID)
+				}
+			}
+		}
+		if err := tx.Delete(&mapping).Error; err != nil {
+			result["error"] = "Failed to delete mapping"
+			result["success"] = false
+			return result, nil
+		}
+		result["success"] = true
+		result["action"] = "deleted"
+		return result, affectedNodes
+	}
+
+	// Assign to node
+	var node models.ComputeNode
+	if err := tx.Where("id = ? OR name = ?", *assignment.NodeID, *assignment.NodeID).First(&node).Error; err != nil {
+		result["error"] = "Node not found"
+		result["success"] = false
+		return result, nil
+	}
+
+	// Validate interface if provided
+	var interfaceID *string
+	if assignment.InterfaceID != nil && *assignment.InterfaceID != "" {
+		var iface models.ComputeNodeInterface
+		if err := tx.Where("id = ? AND compute_node_id = ?", *assignment.InterfaceID, node.ID).First(&iface).Error; err != nil {
+			result["error"] = "Interface not found or doesn't belong to this node"
+			result["success"] = false
+			return result, nil
+		}
+		// Check if this interface already has a port mapping (excluding current port)
+		var existingMapping models.ComputeNodePortMapping
+		query := tx.Where("interface_id = ? AND switch_port_id != ?", *assignment.InterfaceID, assignment.SwitchPortID)
+		if query.First(&existingMapping).Error == nil {
+			result["error"] = "Interface already has a port assigned. Each interface can only have one port."
+			result["success"] = false
+			return result, nil
+		}
+		interfaceID = assignment.InterfaceID
+		if iface.Role == models.InterfaceRoleStorage {
+			affectedNodes = append(affectedNodes, node.ID)
+		}
+	}
+
+	if err == nil {
+		// Update existing mapping, tracking the old node if it had a storage interface
+		if mapping.InterfaceID != nil {
+			var oldIface models.ComputeNodeInterface
+			if tx.First(&oldIface, "id = ?", *mapping.InterfaceID).Error == nil {
+				if oldIface.Role == models.InterfaceRoleStorage {
+					affectedNodes = append(affectedNodes, mapping.ComputeNodeID)
+				}
+			}
+		}
+
+		mapping.ComputeNodeID = node.ID
+		mapping.InterfaceID = interfaceID
+		if assignment.VLAN != nil {
+			mapping.VLAN = *assignment.VLAN
+		}
+		if err := tx.Save(&mapping).Error; err != nil {
+			result["error"] = "Failed to update mapping"
+			result["success"] = false
+			return result, nil
+		}
+		result["success"] = true
+		result["action"] = "updated"
+		result["mapping_id"] = mapping.ID
+		return result, affectedNodes
+	}
+
+	// Create new mapping
+	mapping = models.ComputeNodePortMapping{
+		ID:            uuid.New().String(),
+		ComputeNodeID: node.ID,
+		SwitchPortID:  assignment.SwitchPortID,
+		InterfaceID:   interfaceID,
+	}
+	if assignment.VLAN != nil {
+		mapping.VLAN = *assignment.VLAN
+	}
+	if err := tx.Create(&mapping).Error; err != nil {
+		result["error"] = "Failed to create mapping"
+		result["success"] = false
+		return result, nil
+	}
+	result["success"] = true
+	result["action"] = "created"
+	result["mapping_id"] = mapping.ID
+	return result, affectedNodes
+}
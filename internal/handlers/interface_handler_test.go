@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// newBulkAssignTestRouter seeds node1 and node2, a compute interface on node1 and an
+// existing mapping of p5 to node1, and points database.DB at the test database
+func newBulkAssignTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeInterface{},
+		&models.ComputeNodePortMapping{}, &models.ComputeNodePortMappingHistory{})
+	for _, r := range []interface{}{
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNode{ID: "n2", Name: "node2"},
+		&models.ComputeNodeInterface{ID: "i1", ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+		&models.ComputeNodePortMapping{ID: "m5", ComputeNodeID: "n1", SwitchPortID: "p5"},
+	} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+
+	gin.SetMode(gin.TestMode)
+	h := NewInterfaceHandler(nil)
+	r := gin.New()
+	r.POST("/port-mappings/bulk", h.BulkAssignPortMappings)
+	return r, db
+}
+
+// bulkAssignBody mixes valid assignments with an unknown node and a foreign interface
+const bulkAssignBody = `{"assignments": [
+	{"switch_port_id": "p1", "node_id": "node1", "vlan": 100},
+	{"switch_port_id": "p2", "node_id": "missing"},
+	{"switch_port_id": "p3", "node_id": "n1", "interface_id": "i1"},
+	{"switch_port_id": "p4", "node_id": "n1", "interface_id": "other"},
+	{"switch_port_id": "p5", "node_id": "n2"}
+]}`
+
+func portMappingNodes(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	var mappings []models.ComputeNodePortMapping
+	if err := db.Find(&mappings).Error; err != nil {
+		t.Fatal(err)
+	}
+	nodes := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		nodes[m.SwitchPortID] = m.ComputeNodeID
+	}
+	return nodes
+}
+
+func TestBulkAssignPortMappings_Modes(t *testing.T) {
+	tests := []struct {
+		query         string
+		wantCode      int
+		wantCommitted bool
+		wantMappings  map[string]string
+	}{
+		{"", http.StatusOK, true, map[string]string{"p1": "n1", "p3": "n1", "p5": "n2"}},
+		{"?atomic=false", http.StatusOK, true, map[string]string{"p1": "n1", "p3": "n1", "p5": "n2"}},
+		{"?atomic=true", http.StatusUnprocessableEntity, false, map[string]string{"p5": "n1"}},
+	}
+	for _, tt := range tests {
+		r, db := newBulkAssignTestRouter(t)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/port-mappings/bulk"+tt.query, strings.NewReader(bulkAssignBody)))
+		if w.Code != tt.wantCode {
+			t.Fatalf("%q: code = %d, want %d, body = %s", tt.query, w.Code, tt.wantCode, w.Body.String())
+		}
+		var resp struct {
+			Results []struct {
+				SwitchPortID string `json:"switch_port_id"`
+				Success      bool   `json:"success"`
+				Error        string `json:"error"`
+			} `json:"results"`
+			Failed    int  `json:"failed"`
+			Committed bool `json:"committed"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%q: decode: %v", tt.query, err)
+		}
+		if resp.Committed != tt.wantCommitted || resp.Failed != 2 || len(resp.Results) != 5 {
+			t.Errorf("%q: committed=%v failed=%d results=%d, want committed=%v failed=2 results=5",
+				tt.query, resp.Committed, resp.Failed, len(resp.Results), tt.wantCommitted)
+		}
+		for i, wantSuccess := range []bool{true, false, true, false, true} {
+			if i < len(resp.Results) && resp.Results[i].Success != wantSuccess {
+				t.Errorf("%q: result %d success = %v (%s), want %v", tt.query, i, resp.Results[i].Success, resp.Results[i].Error, wantSuccess)
+			}
+		}
+
+		if got := portMappingNodes(t, db); !reflect.DeepEqual(got, tt.wantMappings) {
+			t.Errorf("%q: mappings = %v, want %v", tt.query, got, tt.wantMappings)
+		}
+	}
+}
+
+func TestBulkAssignPortMappings_InvalidAtomic(t *testing.T) {
+	r, _ := newBulkAssignTestRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/port-mappings/bulk?atomic=maybe", strings.NewReader(bulkAssignBody)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("code = %d, want 400", w.Code)
+	}
+}
+
+// recordingDeployer records the fabrics passed to RequestDeploy
+type recordingDeployer struct {
+	mu      sync.Mutex
+	fabrics []string
+}
+
+func (d *recordingDeployer) RequestDeploy(_ context.Context, fabricName string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fabrics = append(d.fabrics, fabricName)
+	return nil
+}
+
+func (d *recordingDeployer) RequestDeployForSwitch(ctx context.Context, fabricName, _ string) error {
+	return d.RequestDeploy(ctx, fabricName)
+}
+
+// newInterfaceRoleTestRouter seeds node1 with compute interface i1 mapped to Ethernet1/1 of
+// switch FDO1, and an NDFC whose storage-node-node1 SG has no selectors. Security group
+// updates sent to NDFC are returned through the recorded slice.
+func newInterfaceRoleTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, *recordingDeployer, *[]ndclient.SecurityGroup) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{},
+		&models.ComputeNodeAllocation{}, &models.Job{}, &models.Switch{}, &models.SwitchPort{},
+		&models.StorageTenant{}, &models.JobStorageAccess{}, &models.SecurityGroup{})
+	ifaceID := "i1"
+	for _, r := range []interface{}{
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodeInterface{ID: ifaceID, ComputeNodeID: "n1", Role: models.InterfaceRoleCompute},
+		&models.Switch{ID: "sw1", Name: "leaf1", SerialNumber: "FDO1", FabricID: "fab"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "sw1"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", InterfaceID: &ifaceID, SwitchPortID: "p1"},
+	} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+
+	var mu sync.Mutex
+	updates := &[]ndclient.SecurityGroup{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/groups/") {
+			var group ndclient.SecurityGroup
+			_ = json.NewDecoder(r.Body).Decode(&group)
+			mu.Lock()
+			*updates = append(*updates, group)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if r.Method != http.MethodGet {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"groupId":40001,"groupName":"storage-node-node1","attach":false}]`))
+	}))
+	t.Cleanup(server.Close)
+	cfg := &config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test", StorageFabricName: "stor", StorageNetworkName: "stor-net"}
+	client, err := ndclient.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	deployer := &recordingDeployer{}
+	h := NewInterfaceHandler(services.NewStorageService(db, client, cfg, nil))
+	h.SetDeployer(deployer)
+	r := gin.New()
+	r.PUT("/compute-nodes/:id/interfaces/:interfaceId/role", h.UpdateInterfaceRole)
+	return r, db, deployer, updates
+}
+
+func TestUpdateInterfaceRole_UpdatesStorageSG(t *testing.T) {
+	r, db, deployer, updates := newInterfaceRoleTestRouter(t)
+
+	w := doJSON(r, http.MethodPut, "/compute-nodes/node1/interfaces/i1/role", `{"role":"storage"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d: %s", w.Code, w.Body)
+	}
+	var iface models.ComputeNodeInterface
+	if err := json.Unmarshal(w.Body.Bytes(), &iface); err != nil || iface.Role != models.InterfaceRoleStorage {
+		t.Fatalf("response = %s, want the interface with role storage", w.Body)
+	}
+	if err := db.First(&iface, "id = ?", "i1").Error; err != nil || iface.Role != models.InterfaceRoleStorage {
+		t.Errorf("stored role = %q (%v), want storage", iface.Role, err)
+	}
+
+	if len(*updates) != 1 {
+		t.Fatalf("SG updates = %d, want 1", len(*updates))
+	}
+	sg := (*updates)[0]
+	if sg.GroupName != "storage-node-node1" || len(sg.NetworkPortSelectors) != 1 ||
+		sg.NetworkPortSelectors[0].InterfaceName != "Ethernet1/1" || sg.NetworkPortSelectors[0].Network != "stor-net" {
+		t.Errorf("SG update = %+v, want Ethernet1/1 selected into stor-net", sg)
+	}
+	if !reflect.DeepEqual(deployer.fabrics, []string{"stor"}) {
+		t.Errorf("deploys = %v, want [stor]", deployer.fabrics)
+	}
+}
+
+func TestUpdateInterfaceRole_Conflicts(t *testing.T) {
+	t.Run("allocated node", func(t *testing.T) {
+		r, db, deployer, updates := newInterfaceRoleTestRouter(t)
+		for _, row := range []interface{}{
+			&models.Job{ID: "j1", SlurmJobID: "12345", Status: "active", FabricName: "fab"},
+			&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j1"},
+		} {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+		w := doJSON(r, http.MethodPut, "/compute-nodes/n1/interfaces/i1/role", `{"role":"storage"}`)
+		if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "12345") {
+			t.Errorf("code = %d %s, want 409 naming the job", w.Code, w.Body)
+		}
+		if len(*updates) != 0 || len(deployer.fabrics) != 0 {
+			t.Errorf("NDFC changed for a rejected role change: %d updates, deploys %v", len(*updates), deployer.fabrics)
+		}
+	})
+
+	t.Run("role taken", func(t *testing.T) {
+		r, db, _, _ := newInterfaceRoleTestRouter(t)
+		if err := db.Create(&models.ComputeNodeInterface{ID: "i2", ComputeNodeID: "n1", Role: models.InterfaceRoleStorage}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if w := doJSON(r, http.MethodPut, "/compute-nodes/n1/interfaces/i1/role", `{"role":"storage"}`); w.Code != http.StatusConflict {
+			t.Errorf("code = %d %s, want 409", w.Code, w.Body)
+		}
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		r, _, _, _ := newInterfaceRoleTestRouter(t)
+		if w := doJSON(r, http.MethodPut, "/compute-nodes/n1/interfaces/i1/role", `{"role":"mgmt"}`); w.Code != http.StatusBadRequest {
+			t.Errorf("code = %d %s, want 400", w.Code, w.Body)
+		}
+	})
+}
@@ -1,13 +1,22 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/services"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"gorm.io/gorm"
 )
 
@@ -17,18 +26,90 @@ type JobHandler struct {
 }
 
 // NewJobHandler creates a new JobHandler
-func NewJobHandler(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig) *JobHandler {
+func NewJobHandler(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig, registry *services.Registry) *JobHandler {
 	return &JobHandler{
-		svc: services.NewJobService(db, ndClient, cfg),
+		svc: services.NewJobService(db, ndClient, cfg, registry),
 	}
 }
 
+// SetMaxProvisionTimeout caps the per-job provisioning timeout accepted in job submissions
+func (h *JobHandler) SetMaxProvisionTimeout(d time.Duration) {
+	h.svc.SetMaxProvisionTimeout(d)
+}
+
+// SetRetentionDays sets how long finished jobs are kept, the default for retention previews
+func (h *JobHandler) SetRetentionDays(days int) {
+	h.svc.SetRetentionDays(days)
+}
+
+// DeployBatcher returns the batcher that deploys job configuration, for sharing with other handlers
+func (h *JobHandler) DeployBatcher() *services.DeployBatcher {
+	return h.svc.DeployBatcher()
+}
+
+// SetInstanceID identifies this instance in job submission idempotency keys
+func (h *JobHandler) SetInstanceID(id string) {
+	h.svc.SetInstanceID(id)
+}
+
+// completeJobMaxRetries bounds retries when a concurrent request changes the job mid-completion
+const completeJobMaxRetries = 3
+
+var errJobNotFound = errors.New("job not found")
+
+// maxOptionalBodyBytes caps the optional JSON bodies read by bindOptionalJSON
+const maxOptionalBodyBytes = 1 << 20
+
+// bindOptionalJSON binds the request body into v if the request has one, including chunked
+// bodies without a Content-Length. On failure it writes a 413 or 400 response and returns false.
+func bindOptionalJSON(c *gin.Context, v interface{}) bool {
+	if c.Request.Body == nil {
+		return true
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxOptionalBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return true
+	}
+	if err := binding.JSON.BindBody(data, v); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
 // SubmitJobInput represents the input from Slurm when a job is submitted
 type SubmitJobInput struct {
 	SlurmJobID   string   `json:"slurm_job_id" binding:"required"`
 	Name         string   `json:"name"`
-	Tenant       string   `json:"tenant"` // Storage tenant key for tenant-specific storage access
+	Description  string   `json:"description"` // Optional; used in switch port descriptions
+	Tenant       string   `json:"tenant"`      // Storage tenant key for tenant-specific storage access
 	ComputeNodes []string `json:"compute_nodes" binding:"required"`
+	// RequiredLabels must be present on every compute node (e.g. {"gpu": "a100"})
+	RequiredLabels map[string]string `json:"required_labels"`
+	// TimeoutMinutes overrides the NDFC provisioning timeout for large jobs, capped by the server
+	TimeoutMinutes int `json:"timeout_minutes" binding:"min=0"`
+	// ContractRules creates one contract per rule set instead of the default job contract
+	ContractRules []services.ContractRuleSet `json:"contract_rules"`
+	// MinNodeFraction allows provisioning without nodes allocated to other jobs, as long as
+	// this fraction of compute_nodes is available (0 = all nodes required)
+	MinNodeFraction float64 `json:"min_node_fraction" binding:"min=0,max=1"`
+	// Tags group jobs for filtering with ?tag[key]=value, e.g. {"account": "phys101"}
+	Tags map[string]string `json:"tags"`
+}
+
+// submitJobResponse is a submitted job with the requested nodes that were left out
+type submitJobResponse struct {
+	*models.Job
+	SkippedNodes []string `json:"skipped_nodes,omitempty"`
 }
 
 // SubmitJob handles job submission from Slurm and provisions security
@@ -40,10 +121,18 @@ func (h *JobHandler) SubmitJob(c *gin.Context) {
 	}
 
 	result, err := h.svc.Provision(c.Request.Context(), services.ProvisionInput{
-		SlurmJobID:   input.SlurmJobID,
-		Name:         input.Name,
-		Tenant:       input.Tenant,
-		ComputeNodes: input.ComputeNodes,
+		SlurmJobID:     input.SlurmJobID,
+		Name:           input.Name,
+		Description:    input.Description,
+		Tenant:         input.Tenant,
+		ComputeNodes:   input.ComputeNodes,
+		RequiredLabels: input.RequiredLabels,
+		TimeoutMinutes: input.TimeoutMinutes,
+		ContractRules:  input.ContractRules,
+
+		MinNodeFraction:        input.MinNodeFraction,
+		SkipSelectorValidation: c.Query("skip_selector_validation") == "true",
+		Tags:                   input.Tags,
 	})
 
 	if err != nil {
@@ -52,33 +141,98 @@ func (h *JobHandler) SubmitJob(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "job": result.Job})
 			return
 		}
+		if errors.Is(err, services.ErrInvalidContract) || errors.Is(err, services.ErrInvalidJobMetadata) ||
+			errors.Is(err, services.ErrInvalidMinNodeFraction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrMissingRequiredLabels) || errors.Is(err, services.ErrInvalidPortSelector) ||
+			errors.Is(err, services.ErrComputeNodesDecommissioned) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrJobSubmissionInProgress) || errors.Is(err, services.ErrInsufficientNodes) ||
+			errors.Is(err, services.ErrNetworkOversubscribed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	resp := submitJobResponse{Job: result.Job, SkippedNodes: result.SkippedNodes}
 	if result.Created {
-		c.JSON(http.StatusCreated, result.Job)
+		c.JSON(http.StatusCreated, resp)
 	} else {
-		c.JSON(http.StatusOK, result.Job)
+		c.JSON(http.StatusOK, resp)
 	}
 }
 
-// CompleteJob handles job completion and deprovisions security
-func (h *JobHandler) CompleteJob(c *gin.Context) {
-	slurmJobID := c.Param("slurm_job_id")
+// ValidateJob checks a job submission without provisioning anything: the compute nodes are
+// resolved to their switch ports, and allocations, port mappings and the NDFC VRF, network and
+// VLAN are checked. Returns 200 with "valid": false and the conflicts when it would fail.
+func (h *JobHandler) ValidateJob(c *gin.Context) {
+	var input SubmitJobInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	job, err := h.svc.GetJob(c.Request.Context(), slurmJobID)
+	result, err := h.svc.Provision(c.Request.Context(), services.ProvisionInput{
+		SlurmJobID:      input.SlurmJobID,
+		ComputeNodes:    input.ComputeNodes,
+		RequiredLabels:  input.RequiredLabels,
+		ContractRules:   input.ContractRules,
+		MinNodeFraction: input.MinNodeFraction,
+		Tags:            input.Tags,
+		DryRun:          true,
+	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		if errors.Is(err, services.ErrInvalidContract) || errors.Is(err, services.ErrInvalidJobMetadata) ||
+			errors.Is(err, services.ErrInvalidMinNodeFraction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, result.DryRun)
+}
 
-	if job.Status == "completed" {
-		c.JSON(http.StatusOK, gin.H{"message": "Job already completed", "job": job})
+// CompleteJob handles job completion and deprovisions security. An optional body
+// {"exit_code": 1, "signal": 9, "failed_reason": "..."} records the Slurm exit status.
+func (h *JobHandler) CompleteJob(c *gin.Context) {
+	slurmJobID := c.Param("slurm_job_id")
+
+	var completion services.JobCompletion
+	if !bindOptionalJSON(c, &completion) {
 		return
 	}
 
-	if err := h.svc.Deprovision(c.Request.Context(), job); err != nil {
+	// Re-read the job on each attempt so a retry sees the winner's status and version
+	var job *models.Job
+	alreadyCompleted := false
+	err := services.RetryOnConflict(completeJobMaxRetries, func() error {
+		var err error
+		job, err = h.svc.GetJob(c.Request.Context(), slurmJobID)
+		if err != nil {
+			return errJobNotFound
+		}
+		if job.Status == string(models.JobStatusCompleted) {
+			alreadyCompleted = true
+			return nil
+		}
+		return h.svc.Complete(c.Request.Context(), job, completion)
+	})
+
+	switch {
+	case errors.Is(err, errJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	case errors.Is(err, services.ErrConcurrentModification), errors.Is(err, services.ErrInvalidJobState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "job_id": job.ID, "status": job.Status})
+		return
+	case err != nil:
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to deprovision job",
 			"details": err.Error(),
@@ -87,6 +241,11 @@ func (h *JobHandler) CompleteJob(c *gin.Context) {
 		return
 	}
 
+	if alreadyCompleted {
+		c.JSON(http.StatusOK, gin.H{"message": "Job already completed", "job": job})
+		return
+	}
+
 	// Reload job to get updated state
 	job, _ = h.svc.GetJob(c.Request.Context(), slurmJobID)
 	c.JSON(http.StatusOK, job)
@@ -105,29 +264,272 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, job)
 }
 
-// ListJobs lists all jobs with optional status filter
+// UpdateJobMetadata changes the name, description or tags of a job without re-provisioning
+// it. The body holds any of {"name", "description", "tags"}; other fields are rejected. Tags
+// are merged into the job's tags, and a tag set to "" or null is removed.
+func (h *JobHandler) UpdateJobMetadata(c *gin.Context) {
+	var body map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fields := make([]string, 0, len(body))
+	for field := range body {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var update services.JobMetadataUpdate
+	for _, field := range fields {
+		var err error
+		switch field {
+		case "name":
+			err = json.Unmarshal(body[field], &update.Name)
+		case "description":
+			err = json.Unmarshal(body[field], &update.Description)
+		case "tags":
+			update.Tags = make(map[string]*string)
+			err = json.Unmarshal(body[field], &update.Tags)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("field %q cannot be updated (allowed: name, description, tags)", field)})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s: %v", field, err)})
+			return
+		}
+	}
+
+	job, err := h.svc.UpdateJobMetadata(c.Request.Context(), c.Param("slurm_job_id"), update)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+	case errors.Is(err, services.ErrInvalidJobMetadata):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// BulkGetJobsInput lists the Slurm job IDs to retrieve
+type BulkGetJobsInput struct {
+	SlurmJobIDs []string `json:"slurm_job_ids" binding:"required,min=1"`
+}
+
+// BulkGetJobs retrieves up to 100 jobs in one request, keyed by Slurm job ID. Unknown jobs
+// map to {"error": "not found"}.
+func (h *JobHandler) BulkGetJobs(c *gin.Context) {
+	var input BulkGetJobsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jobs, err := h.svc.BulkGetJobs(c.Request.Context(), input.SlurmJobIDs)
+	if errors.Is(err, services.ErrTooManyJobIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make(map[string]any, len(input.SlurmJobIDs))
+	for _, id := range input.SlurmJobIDs {
+		if job, ok := jobs[id]; ok {
+			resp[id] = job
+		} else {
+			resp[id] = gin.H{"error": "not found"}
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetJobSummary returns a compact job summary for Slurm accounting, from the local database
+// only. ?format=text returns key=value lines instead of JSON.
+func (h *JobHandler) GetJobSummary(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "text" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or text"})
+		return
+	}
+
+	summary, err := h.svc.GetJobSummary(c.Request.Context(), c.Param("slurm_job_id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "text" {
+		c.String(http.StatusOK, summary.Text())
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetJobEvents returns a job's provisioning events in order
+func (h *JobHandler) GetJobEvents(c *gin.Context) {
+	events, err := h.svc.ListJobEvents(c.Request.Context(), c.Param("slurm_job_id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+// ReconcileJobPorts updates an active job's security group selectors to its nodes' current
+// port mappings, e.g. after a node was recabled, and returns the ports that changed
+func (h *JobHandler) ReconcileJobPorts(c *gin.Context) {
+	job, err := h.svc.GetJob(c.Request.Context(), c.Param("slurm_job_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	updated, err := h.svc.ReconcileJobPorts(c.Request.Context(), job.ID)
+	if errors.Is(err, services.ErrInvalidJobState) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if updated == nil {
+		updated = []services.StalePort{}
+	}
+	c.JSON(http.StatusOK, gin.H{"updated_ports": updated})
+}
+
+// ListJobs lists jobs, newest first, with optional status, ?exit_code and ?tag[key]=value
+// filters (every tag must match). It returns
+// a page of ?limit jobs (default 100) with the total count and a next_cursor to pass as
+// ?cursor for the next page; ?offset skips jobs instead.
+// ?expires_before=YYYY-MM-DD instead previews the active jobs that expire before that date,
+// i.e. what expired-job cleanup would deprovision then.
 func (h *JobHandler) ListJobs(c *gin.Context) {
-	status := c.Query("status")
+	if s := c.Query("expires_before"); s != "" {
+		before, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid expires_before %q (expected YYYY-MM-DD)", s)})
+			return
+		}
+		jobs, err := h.svc.ListExpiredJobs(c.Request.Context(), before)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, jobs)
+		return
+	}
+
+	opts := services.JobListOptions{
+		Status: c.Query("status"),
+		Cursor: c.Query("cursor"),
+		Tags:   c.QueryMap("tag"), // ?tag[account]=phys101
+	}
+	if s := c.Query("exit_code"); s != "" {
+		code, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid exit_code %q", s)})
+			return
+		}
+		opts.ExitCode = &code
+	}
+	if s := c.Query("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > services.MaxJobPageSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", services.MaxJobPageSize)})
+			return
+		}
+		opts.Limit = n
+	}
+	if s := c.Query("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		opts.Offset = n
+	}
 
-	jobs, err := h.svc.ListJobs(c.Request.Context(), status)
+	page, err := h.svc.ListJobs(c.Request.Context(), opts)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidJobListing) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, jobs)
+	c.JSON(http.StatusOK, page)
 }
 
 // CleanupExpiredJobs finds and deprovisions expired jobs
 func (h *JobHandler) CleanupExpiredJobs(c *gin.Context) {
-	cleaned, err := h.svc.CleanupExpiredJobs(c.Request.Context())
+	result, err := h.svc.CleanupExpiredJobs(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":      fmt.Sprintf("Cleaned up %d expired jobs", len(cleaned)),
-		"cleaned_jobs": cleaned,
+		"message":      fmt.Sprintf("Cleaned up %d expired jobs", len(result.Cleaned)),
+		"cleaned_jobs": result.Cleaned,
 	})
 }
+
+// RetentionPreview reports how many jobs and related records pruning would remove for
+// ?max_age_days= (default: the configured retention), without removing them
+func (h *JobHandler) RetentionPreview(c *gin.Context) {
+	maxAgeDays := h.svc.RetentionDays()
+	if v := c.Query("max_age_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_age_days must be a positive integer"})
+			return
+		}
+		maxAgeDays = days
+	}
+
+	preview, err := h.svc.PreviewJobRetention(c.Request.Context(), maxAgeDays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
+
+// CleanupExpired manually triggers expired-job cleanup, reporting per-job failures.
+// ?dry_run=true returns the jobs that would be cleaned without deprovisioning them.
+func (h *JobHandler) CleanupExpired(c *gin.Context) {
+	if c.Query("dry_run") == "true" {
+		jobs, err := h.svc.ListExpiredJobs(c.Request.Context(), time.Now())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"dry_run": true, "jobs": jobs})
+		return
+	}
+
+	result, err := h.svc.CleanupExpiredJobs(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,532 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// newExpiredJobsTestRouter seeds an active expired job, an active job expiring in a week,
+// a completed expired job and an active job without expiry
+func newExpiredJobsTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobComputeNode{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SwitchPort{},
+		&models.JobStorageAccess{}, &models.JobEvent{})
+
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+	lastYear := now.AddDate(-1, 0, -10)
+	for _, j := range []models.Job{
+		{ID: "j1", SlurmJobID: "100", Status: string(models.JobStatusActive), FabricName: "f1", ExpiresAt: &expired},
+		{ID: "j2", SlurmJobID: "101", Status: string(models.JobStatusActive), FabricName: "f1", ExpiresAt: &nextWeek},
+		{ID: "j3", SlurmJobID: "102", Status: string(models.JobStatusCompleted), FabricName: "f1", ExpiresAt: &expired, CompletedAt: &lastYear},
+		{ID: "j4", SlurmJobID: "103", Status: string(models.JobStatusActive), FabricName: "f1"},
+	} {
+		if err := db.Create(&j).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	h := NewJobHandler(db, nil, &config.NexusDashboardConfig{}, nil)
+	r := gin.New()
+	r.GET("/jobs", h.ListJobs)
+	r.POST("/jobs/cleanup-expired", h.CleanupExpired)
+	r.POST("/jobs/:slurm_job_id/complete", h.CompleteJob)
+	r.POST("/jobs/bulk-get", h.BulkGetJobs)
+	r.GET("/jobs/retention-preview", h.RetentionPreview)
+	return r, db
+}
+
+func jobStatuses(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	var jobs []models.Job
+	if err := db.Order("slurm_job_id").Find(&jobs).Error; err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]string, len(jobs))
+	for _, j := range jobs {
+		statuses[j.SlurmJobID] = j.Status
+	}
+	return statuses
+}
+
+func TestCleanupExpired_DryRunDoesNotModify(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+	before := jobStatuses(t, db)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs/cleanup-expired?dry_run=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		DryRun bool         `json:"dry_run"`
+		Jobs   []models.Job `json:"jobs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.DryRun || len(resp.Jobs) != 1 || resp.Jobs[0].SlurmJobID != "100" {
+		t.Errorf("dry run = %+v, want only job 100", resp)
+	}
+
+	after := jobStatuses(t, db)
+	for id, status := range before {
+		if after[id] != status {
+			t.Errorf("job %s status changed %s -> %s during dry run", id, status, after[id])
+		}
+	}
+}
+
+func TestCleanupExpired_Deprovisions(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs/cleanup-expired", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d, body = %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Cleaned []string          `json:"cleaned"`
+		Errors  map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Cleaned) != 1 || resp.Cleaned[0] != "100" || len(resp.Errors) != 0 {
+		t.Errorf("response = %+v, want job 100 cleaned without errors", resp)
+	}
+
+	statuses := jobStatuses(t, db)
+	if statuses["100"] != string(models.JobStatusCompleted) {
+		t.Errorf("job 100 status = %s, want completed", statuses["100"])
+	}
+	if statuses["101"] != string(models.JobStatusActive) {
+		t.Errorf("job 101 status = %s, want active", statuses["101"])
+	}
+}
+
+func TestCompleteJob_ChunkedAndOversizeBodies(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+
+	// A chunked body has no Content-Length but is still read
+	req := httptest.NewRequest(http.MethodPost, "/jobs/101/complete", strings.NewReader(`{"exit_code": 2}`))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("chunked: code = %d, body = %s", w.Code, w.Body.String())
+	}
+	var job models.Job
+	if err := db.First(&job, "slurm_job_id = ?", "101").Error; err != nil {
+		t.Fatal(err)
+	}
+	if job.ExitCode == nil || *job.ExitCode != 2 {
+		t.Errorf("exit code = %v, want 2", job.ExitCode)
+	}
+
+	body := `{"failed_reason": "` + strings.Repeat("x", maxOptionalBodyBytes) + `"}`
+	req = httptest.NewRequest(http.MethodPost, "/jobs/103/complete", strings.NewReader(body))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversize: code = %d, want 413", w.Code)
+	}
+	if status := jobStatuses(t, db)["103"]; status != string(models.JobStatusActive) {
+		t.Errorf("job 103 status = %s, want active", status)
+	}
+}
+
+func TestListJobs_ExpiresBefore(t *testing.T) {
+	r, _ := newExpiredJobsTestRouter(t)
+
+	tests := []struct {
+		query    string
+		wantCode int
+		wantIDs  []string
+	}{
+		{"?expires_before=" + time.Now().AddDate(0, 0, 14).Format(time.DateOnly), http.StatusOK, []string{"100", "101"}},
+		{"?expires_before=2000-01-01", http.StatusOK, []string{}},
+		{"?expires_before=tomorrow", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs"+tt.query, nil))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: code = %d, want %d", tt.query, w.Code, tt.wantCode)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var jobs []models.Job
+		if err := json.Unmarshal(w.Body.Bytes(), &jobs); err != nil {
+			t.Fatalf("%s: decode: %v", tt.query, err)
+		}
+		if len(jobs) != len(tt.wantIDs) {
+			t.Errorf("%s: got %d jobs, want %v", tt.query, len(jobs), tt.wantIDs)
+			continue
+		}
+		for i, j := range jobs {
+			if j.SlurmJobID != tt.wantIDs[i] {
+				t.Errorf("%s: job %d = %s, want %s", tt.query, i, j.SlurmJobID, tt.wantIDs[i])
+			}
+		}
+	}
+}
+
+func TestListJobs_Pagination(t *testing.T) {
+	r, _ := newExpiredJobsTestRouter(t)
+
+	get := func(query string) (int, services.JobPage) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs"+query, nil))
+		var page services.JobPage
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatalf("%s: decode: %v", query, err)
+			}
+		}
+		return w.Code, page
+	}
+
+	first, page := get("?limit=3")
+	if first != http.StatusOK || len(page.Jobs) != 3 || page.TotalCount != 4 || page.NextCursor == "" {
+		t.Fatalf("first page: code %d, %d jobs, total %d, cursor %q", first, len(page.Jobs), page.TotalCount, page.NextCursor)
+	}
+	seen := map[string]bool{}
+	for _, j := range page.Jobs {
+		seen[j.SlurmJobID] = true
+	}
+	code, next := get("?limit=3&cursor=" + page.NextCursor)
+	if code != http.StatusOK || len(next.Jobs) != 1 || next.NextCursor != "" || seen[next.Jobs[0].SlurmJobID] {
+		t.Errorf("second page: code %d, %+v", code, next)
+	}
+
+	tests := []struct {
+		query     string
+		wantCode  int
+		wantJobs  int
+		wantTotal int64
+	}{
+		{"", http.StatusOK, 4, 4},
+		{"?status=completed", http.StatusOK, 1, 1},
+		{"?offset=2&limit=10", http.StatusOK, 2, 4},
+		{"?limit=0", http.StatusBadRequest, 0, 0},
+		{"?limit=5000", http.StatusBadRequest, 0, 0},
+		{"?limit=ten", http.StatusBadRequest, 0, 0},
+		{"?offset=-1", http.StatusBadRequest, 0, 0},
+		{"?cursor=garbage!", http.StatusBadRequest, 0, 0},
+		{"?offset=1&cursor=" + page.NextCursor, http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		code, page := get(tt.query)
+		if code != tt.wantCode {
+			t.Errorf("%s: code = %d, want %d", tt.query, code, tt.wantCode)
+			continue
+		}
+		if code == http.StatusOK && (len(page.Jobs) != tt.wantJobs || page.TotalCount != tt.wantTotal) {
+			t.Errorf("%s: %d jobs of %d, want %d of %d", tt.query, len(page.Jobs), page.TotalCount, tt.wantJobs, tt.wantTotal)
+		}
+	}
+}
+
+func TestListJobs_TagFilter(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+	for slurmJobID, tags := range map[string]string{
+		"100": `{"account": "phys101", "comment": "calib"}`,
+		"101": `{"account": "phys101"}`,
+		"102": `{"account": "chem"}`,
+	} {
+		if err := db.Model(&models.Job{}).Where("slurm_job_id = ?", slurmJobID).Update("tags", []byte(tags)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query    string
+		wantCode int
+		wantIDs  []string
+	}{
+		{"?tag[account]=phys101", http.StatusOK, []string{"100", "101"}},
+		{"?tag[account]=phys101&tag[comment]=calib", http.StatusOK, []string{"100"}},
+		{"?tag[account]=chem&status=completed", http.StatusOK, []string{"102"}},
+		{"?tag[account]=bio", http.StatusOK, nil},
+		{"?tag[bad%20key]=x", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs"+tt.query, nil))
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: code = %d, want %d (%s)", tt.query, w.Code, tt.wantCode, w.Body.String())
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var page services.JobPage
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, j := range page.Jobs {
+			ids = append(ids, j.SlurmJobID)
+		}
+		sort.Strings(ids)
+		if !reflect.DeepEqual(ids, tt.wantIDs) {
+			t.Errorf("%s: jobs = %v, want %v", tt.query, ids, tt.wantIDs)
+		}
+	}
+}
+
+// newJobSummaryTestRouter seeds active job 200 on nodes node03, node01 and node02 with
+// NDFC security group 12345
+func newJobSummaryTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobComputeNode{}, &models.ComputeNode{}, &models.SecurityGroup{})
+
+	submitted := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	provisioned := submitted.Add(90 * time.Second)
+	groupID := "sg1"
+	seed := []interface{}{
+		&models.SecurityGroup{ID: groupID, Name: "job-200", NDObjectID: "12345", FabricName: "f1"},
+		&models.Job{ID: "j1", SlurmJobID: "200", Name: "train\nrun", Status: string(models.JobStatusActive),
+			FabricName: "f1", VRFName: "compute", ContractName: "job-200", SubmittedAt: submitted,
+			ProvisionedAt: &provisioned, SecurityGroupID: &groupID},
+	}
+	for i, name := range []string{"node03", "node01", "node02"} {
+		id := fmt.Sprintf("cn%d", i)
+		seed = append(seed,
+			&models.ComputeNode{ID: id, Name: name},
+			&models.JobComputeNode{ID: "jcn" + id, JobID: "j1", ComputeNodeID: id})
+	}
+	for _, v := range seed {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	h := NewJobHandler(db, nil, &config.NexusDashboardConfig{}, nil)
+	r := gin.New()
+	r.GET("/jobs/:slurm_job_id/summary", h.GetJobSummary)
+	return r
+}
+
+func TestGetJobSummary_JSON(t *testing.T) {
+	r := newJobSummaryTestRouter(t)
+
+	w := doJSON(r, http.MethodGet, "/jobs/200/summary", "")
+	var summary map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); w.Code != http.StatusOK || err != nil {
+		t.Fatalf("code = %d (%s), want 200", w.Code, w.Body)
+	}
+	names, _ := json.Marshal(summary["node_names"])
+	if string(names) != `["node01","node02","node03"]` || summary["node_count"] != float64(3) {
+		t.Errorf("nodes = %s (count %v), want node01-node03", names, summary["node_count"])
+	}
+	if summary["security_group_id"] != float64(12345) || summary["completed_at"] != nil || summary["vrf"] != "compute" {
+		t.Errorf("summary = %v", summary)
+	}
+
+	if w := doJSON(r, http.MethodGet, "/jobs/999/summary", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown job: code = %d, want 404", w.Code)
+	}
+	if w := doJSON(r, http.MethodGet, "/jobs/200/summary?format=xml", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("format=xml: code = %d, want 400", w.Code)
+	}
+}
+
+func TestGetJobSummary_Text(t *testing.T) {
+	r := newJobSummaryTestRouter(t)
+
+	w := doJSON(r, http.MethodGet, "/jobs/200/summary?format=text", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("code = %d, content type %q, want 200 text/plain", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	// Parse the way a shell script would: one key=value per line, split at the first =
+	var keys []string
+	fields := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			t.Fatalf("line %q is not key=value", line)
+		}
+		keys = append(keys, key)
+		fields[key] = value
+	}
+
+	wantKeys := "slurm_job_id,name,status,submitted_at,provisioned_at,completed_at,node_count,node_names,fabric,vrf,contract,security_group_id,exit_code,signal,failed_reason"
+	if strings.Join(keys, ",") != wantKeys {
+		t.Errorf("keys = %v, want %s", keys, wantKeys)
+	}
+	want := map[string]string{
+		"slurm_job_id":      "200",
+		"name":              "train run",
+		"status":            "active",
+		"submitted_at":      "2026-03-01T12:00:00Z",
+		"provisioned_at":    "2026-03-01T12:01:30Z",
+		"completed_at":      "",
+		"node_count":        "3",
+		"node_names":        "node01,node02,node03",
+		"fabric":            "f1",
+		"contract":          "job-200",
+		"security_group_id": "12345",
+		"exit_code":         "",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %q, want %q", key, fields[key], value)
+		}
+	}
+}
+
+func TestBulkGetJobs_MissingJobsHaveError(t *testing.T) {
+	r, _ := newExpiredJobsTestRouter(t)
+
+	w := doJSON(r, http.MethodPost, "/jobs/bulk-get", `{"slurm_job_ids": ["100", "102", "999"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d (%s), want 200", w.Code, w.Body)
+	}
+	var resp map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp) != 3 || resp["100"]["slurm_job_id"] != "100" || resp["102"]["status"] != "completed" {
+		t.Errorf("response = %v, want jobs 100 and 102", resp)
+	}
+	if resp["999"]["error"] != "not found" {
+		t.Errorf("999 = %v, want not found error", resp["999"])
+	}
+
+	if w := doJSON(r, http.MethodPost, "/jobs/bulk-get", `{"slurm_job_ids": []}`); w.Code != http.StatusBadRequest {
+		t.Errorf("empty IDs: code = %d, want 400", w.Code)
+	}
+	ids, _ := json.Marshal(make([]string, 101))
+	if w := doJSON(r, http.MethodPost, "/jobs/bulk-get", `{"slurm_job_ids": `+string(ids)+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("101 IDs: code = %d, want 400", w.Code)
+	}
+}
+
+func TestRetentionPreview(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+
+	tests := []struct {
+		query    string
+		wantCode int
+		wantJobs int64
+	}{
+		{"", http.StatusOK, 1}, // Default retention of 365 days covers job 102
+		{"?max_age_days=400", http.StatusOK, 0},
+		{"?max_age_days=0", http.StatusBadRequest, 0},
+		{"?max_age_days=abc", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		w := doJSON(r, http.MethodGet, "/jobs/retention-preview"+tt.query, "")
+		if w.Code != tt.wantCode {
+			t.Errorf("%q: code = %d, want %d (%s)", tt.query, w.Code, tt.wantCode, w.Body)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var preview services.JobRetentionPreview
+		if err := json.Unmarshal(w.Body.Bytes(), &preview); err != nil {
+			t.Fatal(err)
+		}
+		if preview.Jobs != tt.wantJobs {
+			t.Errorf("%q: jobs = %d, want %d", tt.query, preview.Jobs, tt.wantJobs)
+		}
+	}
+
+	var n int64
+	if err := db.Model(&models.Job{}).Count(&n).Error; err != nil || n != 4 {
+		t.Errorf("preview changed jobs: %d left (%v), want 4", n, err)
+	}
+}
+
+func TestUpdateJobMetadata(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+	h := NewJobHandler(db, nil, &config.NexusDashboardConfig{}, nil)
+	r.PATCH("/jobs/:slurm_job_id", h.UpdateJobMetadata)
+	var before models.Job
+	if err := db.Where("slurm_job_id = ?", "100").First(&before).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	w := doJSON(r, http.MethodPatch, "/jobs/100", `{"name": "climate-run", "tags": {"project": "climate", "queue": "gpu", "tmp": "x"}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("first patch: %d %s", w.Code, w.Body)
+	}
+
+	// Tags are merged; "" and null remove a tag
+	w = doJSON(r, http.MethodPatch, "/jobs/100", `{"description": "rerun", "tags": {"queue": "cpu", "tmp": "", "project": null, "owner": "hpc-team"}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("second patch: %d %s", w.Code, w.Body)
+	}
+	var job models.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	var tags map[string]string
+	if err := json.Unmarshal(job.Tags, &tags); err != nil {
+		t.Fatalf("tags %s: %v", job.Tags, err)
+	}
+	if job.Name != "climate-run" || job.Description != "rerun" || len(tags) != 2 || tags["queue"] != "cpu" || tags["owner"] != "hpc-team" {
+		t.Errorf("job = %q %q %v, want name kept, description set and tags merged", job.Name, job.Description, tags)
+	}
+	if !job.UpdatedAt.After(before.UpdatedAt) || job.Version != before.Version {
+		t.Errorf("updated_at %v -> %v, version %d -> %d; want updated_at bumped and version kept",
+			before.UpdatedAt, job.UpdatedAt, before.Version, job.Version)
+	}
+}
+
+func TestUpdateJobMetadata_RejectsOtherFields(t *testing.T) {
+	r, db := newExpiredJobsTestRouter(t)
+	h := NewJobHandler(db, nil, &config.NexusDashboardConfig{}, nil)
+	r.PATCH("/jobs/:slurm_job_id", h.UpdateJobMetadata)
+
+	for _, body := range []string{
+		`{"status": "completed"}`,
+		`{"name": "renamed", "status": "completed"}`,
+		`{"compute_nodes": ["node-1"]}`,
+		`{"security_group_id": "sg-1"}`,
+		`{"tags": {"": "empty key"}}`,
+		`{"tags": {"team name": "not filterable"}}`,
+		`{"name": 42}`,
+	} {
+		if w := doJSON(r, http.MethodPatch, "/jobs/100", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: code = %d, want 400", body, w.Code)
+		}
+	}
+
+	var job models.Job
+	if err := db.Where("slurm_job_id = ?", "100").First(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != string(models.JobStatusActive) || job.Name != "" {
+		t.Errorf("job changed by rejected patches: status %q, name %q", job.Status, job.Name)
+	}
+
+	if w := doJSON(r, http.MethodPatch, "/jobs/999", `{"name": "x"}`); w.Code != http.StatusNotFound {
+		t.Errorf("unknown job: code = %d, want 404", w.Code)
+	}
+}
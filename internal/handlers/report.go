@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// reportDateLayout is the format of the from/to report query parameters
+const reportDateLayout = "2006-01-02"
+
+// ReportHandler handles HTTP requests for reporting endpoints
+type ReportHandler struct{}
+
+// NewReportHandler creates a new ReportHandler
+func NewReportHandler() *ReportHandler {
+	return &ReportHandler{}
+}
+
+// GetProvisioningSummary reports provisioning activity for capacity planning.
+// Query params: from and to (YYYY-MM-DD, inclusive, required), group_by (day|week|month|fabric, default month).
+func (h *ReportHandler) GetProvisioningSummary(c *gin.Context) {
+	from, err := time.Parse(reportDateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date in YYYY-MM-DD format"})
+		return
+	}
+	to, err := time.Parse(reportDateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date in YYYY-MM-DD format"})
+		return
+	}
+
+	// to is inclusive, so include the whole final day
+	rows, err := services.ProvisioningSummary(c.Request.Context(), database.DB, from, to.AddDate(0, 0, 1),
+		c.DefaultQuery("group_by", services.ReportGroupByMonth))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidReport) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
@@ -1,27 +1,37 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
-	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 type SecurityHandler struct {
-	ndClient *ndclient.Client
-	db       *gorm.DB
+	ndClient        *ndclient.Client
+	db              *gorm.DB
+	contractService *services.ContractService
+	groupService    *services.SecurityGroupService
+	storageService  *services.StorageService
 }
 
 func NewSecurityHandler(client *ndclient.Client) *SecurityHandler {
-	return &SecurityHandler{ndClient: client, db: database.DB}
+	return &SecurityHandler{
+		ndClient:        client,
+		db:              database.DB,
+		contractService: services.NewContractService(database.DB, client),
+		groupService:    services.NewSecurityGroupService(database.DB, client),
+	}
 }
 
 // Security Group handlers
@@ -82,18 +92,18 @@ func (h *SecurityHandler) CreateSecurityGroup(c *gin.Context) {
 		})
 	}
 
-	// Build network port selectors (normalize interface names to full format)
+	// Build network port selectors; the service normalizes and validates them
 	var networkPortSelectors []ndclient.NetworkPortSelector
 	for _, sel := range input.NetworkPortSelectors {
 		networkPortSelectors = append(networkPortSelectors, ndclient.NetworkPortSelector{
 			Network:       sel.Network,
 			SwitchID:      sel.SwitchID,
-			InterfaceName: lanfabric.NormalizeInterfaceName(sel.InterfaceName),
+			InterfaceName: sel.InterfaceName,
 		})
 	}
 
-	// Create in Nexus Dashboard
-	ndGroup := &ndclient.SecurityGroup{
+	// Create in Nexus Dashboard and upsert locally (idempotent: handles retries gracefully)
+	group, ndResp, err := h.groupService.Create(c.Request.Context(), &ndclient.SecurityGroup{
 		FabricName:           input.FabricName,
 		SourceFabric:         input.SourceFabric,
 		GroupName:            input.GroupName,
@@ -101,73 +111,250 @@ func (h *SecurityHandler) CreateSecurityGroup(c *gin.Context) {
 		IPSelectors:          ipSelectors,
 		NetworkSelectors:     networkSelectors,
 		NetworkPortSelectors: networkPortSelectors,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSecurityGroup) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"local":  group,
+		"remote": ndResp,
+	})
+}
+
+// SetStorageService enables reconciling all node storage SGs through BulkUpdateSelectors
+func (h *SecurityHandler) SetStorageService(storageService *services.StorageService) {
+	h.storageService = storageService
+}
+
+// BulkUpdateSelectorsInput replaces the network port selectors of existing groups. When
+// Updates is omitted, every node storage SG is reconciled against its port mappings.
+type BulkUpdateSelectorsInput struct {
+	FabricName string                  `json:"fabric_name"`
+	Updates    []SGSelectorUpdateInput `json:"updates"`
+}
+
+type SGSelectorUpdateInput struct {
+	GroupID   int                        `json:"group_id" binding:"required"`
+	GroupName string                     `json:"group_name" binding:"required"`
+	Selectors []NetworkPortSelectorInput `json:"selectors"` // Empty detaches the group
+}
+
+// BulkUpdateSelectors updates the selectors of many security groups in one NDFC request.
+// ?dry_run=true reports the updates without applying them. An empty body is rejected;
+// reconciling every node storage SG takes an explicit {}.
+func (h *SecurityHandler) BulkUpdateSelectors(c *gin.Context) {
+	var input BulkUpdateSelectorsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "request body is required"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	if len(input.Updates) == 0 {
+		if h.storageService == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "updates are required"})
+			return
+		}
+		result, err := h.storageService.ReconcileAllNodeStorageSGs(c.Request.Context(), dryRun)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	updates := make([]ndclient.SGSelectorUpdate, 0, len(input.Updates))
+	for _, u := range input.Updates {
+		selectors := make([]ndclient.NetworkPortSelector, 0, len(u.Selectors))
+		for _, sel := range u.Selectors {
+			selectors = append(selectors, ndclient.NetworkPortSelector{
+				Network:       sel.Network,
+				SwitchID:      sel.SwitchID,
+				InterfaceName: sel.InterfaceName,
+			})
+		}
+		updates = append(updates, ndclient.SGSelectorUpdate{GroupID: u.GroupID, GroupName: u.GroupName, Selectors: selectors})
 	}
 
-	ndResp, err := h.ndClient.CreateSecurityGroup(c.Request.Context(), input.FabricName, ndGroup)
+	errs, err := h.groupService.BulkUpdateSelectors(c.Request.Context(), input.FabricName, updates, dryRun)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidSecurityGroup) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Upsert to local database (idempotent: handles retries gracefully)
-	group := models.SecurityGroup{
-		ID:         uuid.New().String(),
-		Name:       input.GroupName,
-		NDObjectID: fmt.Sprintf("%d", ndResp.GroupID),
-		FabricName: input.FabricName,
+	failed := make(map[string]string)
+	for i, err := range errs {
+		if err != nil {
+			failed[updates[i].GroupName] = err.Error()
+		}
 	}
+	status := http.StatusOK
+	if len(failed) == len(updates) {
+		status = http.StatusBadGateway
+	}
+	c.JSON(status, gin.H{
+		"dry_run": dryRun,
+		"updates": updates,
+		"failed":  failed,
+	})
+}
 
-	if err := h.db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "fabric_name"}, {Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"nd_object_id", "updated_at"}),
-	}).Create(&group).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+// ExportSecurityGroups returns the security groups, contracts and associations NDFC has for
+// ?fabric= as a snapshot document that ImportSecurityGroups accepts
+func (h *SecurityHandler) ExportSecurityGroups(c *gin.Context) {
+	fabricName := c.Query("fabric")
+	if fabricName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fabric query parameter is required"})
+		return
+	}
+	if h.ndClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Nexus Dashboard client not configured"})
 		return
 	}
 
-	// Fetch the actual record to get the ID (may be existing or newly created)
-	if err := h.db.Where("fabric_name = ? AND name = ?", input.FabricName, input.GroupName).First(&group).Error; err != nil {
+	data, err := h.groupService.ExportSnapshot(c.Request.Context(), fabricName)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fabricName+"-security.json"))
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"local":  group,
-		"remote": ndResp,
-	})
+// maxSecuritySnapshotBytes bounds the snapshot document ImportSecurityGroups reads
+const maxSecuritySnapshotBytes = 16 << 20
+
+// ImportSecurityGroups creates the objects of an exported snapshot in NDFC for ?fabric= and
+// deploys the fabric. Objects that already exist are skipped, so an import can be retried.
+func (h *SecurityHandler) ImportSecurityGroups(c *gin.Context) {
+	fabricName := c.Query("fabric")
+	if fabricName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fabric query parameter is required"})
+		return
+	}
+	if h.ndClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Nexus Dashboard client not configured"})
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSecuritySnapshotBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("snapshot exceeds %d bytes", tooLarge.Limit)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	snapshot, err := ndclient.UnmarshalSecuritySnapshot(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.groupService.ImportSnapshot(c.Request.Context(), fabricName, snapshot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "result": result})
+		return
+	}
+	c.JSON(http.StatusOK, result)
 }
 
 func (h *SecurityHandler) GetSecurityGroups(c *gin.Context) {
 	fabricName := c.Query("fabric_name")
 
-	// If fabric name provided, fetch from NDFC
+	opts, err := securityGroupListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// If fabric name provided, fetch from NDFC and page locally
 	if fabricName != "" && h.ndClient != nil {
-		groups, err := h.ndClient.GetSecurityGroups(c.Request.Context(), fabricName)
+		groups, total, err := h.groupService.ListNDFC(c.Request.Context(), fabricName, opts)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeSecurityGroupListError(c, err)
 			return
 		}
+		c.Header("X-Total-Count", strconv.FormatInt(total, 10))
 		c.JSON(http.StatusOK, groups)
 		return
 	}
 
 	// Otherwise return from local database
-	var groups []models.SecurityGroup
-	if err := h.db.Preload("Selectors.SwitchPort").Find(&groups).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	groups, total, err := h.groupService.List(c.Request.Context(), opts)
+	if err != nil {
+		writeSecurityGroupListError(c, err)
 		return
 	}
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
 	c.JSON(http.StatusOK, groups)
 }
 
+// securityGroupListOptions reads name_contains, fabric, sort, page (1-based) and page_size
+func securityGroupListOptions(c *gin.Context) (services.SecurityGroupListOptions, error) {
+	opts := services.SecurityGroupListOptions{
+		NameContains: c.Query("name_contains"),
+		Fabric:       c.Query("fabric"),
+		Sort:         c.Query("sort"),
+		Limit:        services.DefaultSecurityGroupPageSize,
+	}
+
+	page := 1
+	if v := c.Query("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return opts, errors.New("page must be a positive integer")
+		}
+		page = n
+	}
+	if v := c.Query("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > services.MaxSecurityGroupPageSize {
+			return opts, fmt.Errorf("page_size must be between 1 and %d", services.MaxSecurityGroupPageSize)
+		}
+		opts.Limit = n
+	}
+	opts.Offset = (page - 1) * opts.Limit
+	return opts, nil
+}
+
+func writeSecurityGroupListError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidGroupListing) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
 func (h *SecurityHandler) GetSecurityGroup(c *gin.Context) {
 	id := c.Param("id")
 	fabricName := c.Query("fabric_name")
 
 	// If fabric name provided, fetch from NDFC by name
 	if fabricName != "" && h.ndClient != nil {
-		group, err := h.ndClient.GetSecurityGroupByName(c.Request.Context(), fabricName, id)
+		group, err := h.groupService.GetNDFC(c.Request.Context(), fabricName, id)
 		if err != nil {
+			if errors.Is(err, services.ErrSecurityGroupNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Security group not found"})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -176,8 +363,8 @@ func (h *SecurityHandler) GetSecurityGroup(c *gin.Context) {
 	}
 
 	// Otherwise return from local database
-	var group models.SecurityGroup
-	if err := h.db.Preload("Selectors.SwitchPort.Switch").First(&group, "id = ?", id).Error; err != nil {
+	group, err := h.groupService.Get(c.Request.Context(), id)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Security group not found"})
 		return
 	}
@@ -187,38 +374,16 @@ func (h *SecurityHandler) GetSecurityGroup(c *gin.Context) {
 func (h *SecurityHandler) DeleteSecurityGroup(c *gin.Context) {
 	id := c.Param("id")
 
-	var group models.SecurityGroup
-	if err := h.db.First(&group, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Security group not found"})
-		return
-	}
-
-	// Delete from Nexus Dashboard
-	if h.ndClient != nil && group.NDObjectID != "" {
-		groupID, err := strconv.Atoi(group.NDObjectID)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid NDObjectID: " + group.NDObjectID})
-			return
-		}
-		if groupID > 0 {
-			if err := h.ndClient.DeleteSecurityGroup(c.Request.Context(), group.FabricName, groupID); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-				return
-			}
-		}
-	}
-
-	// Delete selectors and group in transaction
-	if err := h.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("security_group_id = ?", id).Delete(&models.PortSelector{}).Error; err != nil {
-			return err
-		}
-		if err := tx.Delete(&group).Error; err != nil {
-			return err
+	// The group is deleted locally first, then its associations and the group from NDFC
+	if err := h.groupService.Delete(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, services.ErrSecurityGroupNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Security group not found"})
+		case errors.Is(err, services.ErrInvalidGroupObjectID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		return nil
-	}); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -277,12 +442,15 @@ func (h *SecurityHandler) CreateSecurityContract(c *gin.Context) {
 	}
 
 	rules := make([]models.ContractRule, 0, len(input.Rules))
-	for _, r := range input.Rules {
+	for i, r := range input.Rules {
 		rules = append(rules, models.ContractRule{
 			ID:                 uuid.New().String(),
 			SecurityContractID: contract.ID,
 			Name:               r.ProtocolName,
+			Direction:          r.Direction,
 			Action:             r.Action,
+			Protocol:           r.ProtocolName,
+			Sequence:           (i + 1) * 10,
 		})
 	}
 
@@ -309,7 +477,7 @@ func (h *SecurityHandler) CreateSecurityContract(c *gin.Context) {
 
 func (h *SecurityHandler) GetSecurityContracts(c *gin.Context) {
 	var contracts []models.SecurityContract
-	if err := h.db.Preload("Rules").Find(&contracts).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).Preload("Rules", services.OrderContractRules).Find(&contracts).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -319,7 +487,7 @@ func (h *SecurityHandler) GetSecurityContracts(c *gin.Context) {
 func (h *SecurityHandler) GetSecurityContract(c *gin.Context) {
 	id := c.Param("id")
 	var contract models.SecurityContract
-	if err := h.db.Preload("Rules").First(&contract, "id = ?", id).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).Preload("Rules", services.OrderContractRules).First(&contract, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Security contract not found"})
 		return
 	}
@@ -330,7 +498,7 @@ func (h *SecurityHandler) DeleteSecurityContract(c *gin.Context) {
 	id := c.Param("id")
 
 	var contract models.SecurityContract
-	if err := h.db.First(&contract, "id = ?", id).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).First(&contract, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Security contract not found"})
 		return
 	}
@@ -360,6 +528,110 @@ func (h *SecurityHandler) DeleteSecurityContract(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Security contract deleted"})
 }
 
+type CloneSecurityContractInput struct {
+	NewName    string `json:"new_name" binding:"required"`
+	FabricName string `json:"fabric_name"`
+}
+
+// CloneSecurityContract duplicates a contract's rules under a new name
+func (h *SecurityHandler) CloneSecurityContract(c *gin.Context) {
+	var input CloneSecurityContractInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract, err := h.contractService.CloneContract(c.Request.Context(), c.Param("id"), input.NewName, input.FabricName)
+	if err != nil {
+		writeContractError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contract)
+}
+
+// AddSecurityContractRule appends a rule to a contract and updates it in NDFC
+func (h *SecurityHandler) AddSecurityContractRule(c *gin.Context) {
+	var input ContractRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract, err := h.contractService.AddRule(c.Request.Context(), c.Param("id"), models.ContractRule{
+		Direction: input.Direction,
+		Action:    input.Action,
+		Protocol:  input.ProtocolName,
+	})
+	if err != nil {
+		writeContractError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, contract)
+}
+
+// DeleteSecurityContractRule removes a rule from a contract and updates it in NDFC
+func (h *SecurityHandler) DeleteSecurityContractRule(c *gin.Context) {
+	contract, err := h.contractService.DeleteRule(c.Request.Context(), c.Param("id"), c.Param("ruleId"))
+	if err != nil {
+		writeContractError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contract)
+}
+
+// ReorderSecurityContractRulesInput lists every rule ID of a contract in the new order
+type ReorderSecurityContractRulesInput struct {
+	RuleIDs []string `json:"rule_ids" binding:"required"`
+}
+
+// ReorderSecurityContractRules reorders a contract's rules and updates it in NDFC
+func (h *SecurityHandler) ReorderSecurityContractRules(c *gin.Context) {
+	var input ReorderSecurityContractRulesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract, err := h.contractService.ReorderRules(c.Request.Context(), c.Param("id"), input.RuleIDs)
+	if err != nil {
+		writeContractError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contract)
+}
+
+// writeContractError maps contract service errors to HTTP responses
+func writeContractError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrContractNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Security contract not found"})
+	case errors.Is(err, services.ErrRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contract rule not found"})
+	case errors.Is(err, ndclient.ErrContractExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidContract):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// ValidateSecurityContract checks a contract's rules against the protocols
+// available in NDFC. The fabric query parameter defaults to the contract's fabric.
+func (h *SecurityHandler) ValidateSecurityContract(c *gin.Context) {
+	problems, err := h.contractService.ValidateContract(c.Request.Context(), c.Query("fabric"), c.Param("id"))
+	if err != nil {
+		writeContractError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":  len(problems) == 0,
+		"errors": problems,
+	})
+}
+
 // Security Association (Contract Association) handlers
 
 type CreateSecurityAssociationInput struct {
@@ -407,7 +679,7 @@ func (h *SecurityHandler) CreateSecurityAssociation(c *gin.Context) {
 		DstGroupNDID: input.DstGroupID,
 	}
 
-	if err := h.db.Create(&association).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).Create(&association).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -420,7 +692,7 @@ func (h *SecurityHandler) CreateSecurityAssociation(c *gin.Context) {
 
 func (h *SecurityHandler) GetSecurityAssociations(c *gin.Context) {
 	var associations []models.SecurityAssociation
-	if err := h.db.
+	if err := h.db.WithContext(c.Request.Context()).
 		Preload("ProviderGroup").
 		Preload("ConsumerGroup").
 		Preload("SecurityContract").
@@ -434,7 +706,7 @@ func (h *SecurityHandler) GetSecurityAssociations(c *gin.Context) {
 func (h *SecurityHandler) GetSecurityAssociation(c *gin.Context) {
 	id := c.Param("id")
 	var association models.SecurityAssociation
-	if err := h.db.
+	if err := h.db.WithContext(c.Request.Context()).
 		Preload("ProviderGroup.Selectors.SwitchPort").
 		Preload("ConsumerGroup.Selectors.SwitchPort").
 		Preload("SecurityContract.Rules").
@@ -449,7 +721,7 @@ func (h *SecurityHandler) DeleteSecurityAssociation(c *gin.Context) {
 	id := c.Param("id")
 
 	var association models.SecurityAssociation
-	if err := h.db.First(&association, "id = ?", id).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).First(&association, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Security association not found"})
 		return
 	}
@@ -463,7 +735,7 @@ func (h *SecurityHandler) DeleteSecurityAssociation(c *gin.Context) {
 	}
 
 	// Delete from local database
-	if err := h.db.Delete(&association).Error; err != nil {
+	if err := h.db.WithContext(c.Request.Context()).Delete(&association).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -471,6 +743,166 @@ func (h *SecurityHandler) DeleteSecurityAssociation(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Security association deleted"})
 }
 
+// ValidateAssociations compares the contract associations of a fabric in NDFC with the local
+// security associations and active jobs, and reports the missing and NDFC-only ones. With
+// ?fix=true, the missing associations are created in NDFC.
+func (h *SecurityHandler) ValidateAssociations(c *gin.Context) {
+	fabricName := c.Query("fabric")
+	if fabricName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fabric is required"})
+		return
+	}
+	if h.ndClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Nexus Dashboard client not configured"})
+		return
+	}
+
+	result, err := h.groupService.ValidateAssociations(c.Request.Context(), fabricName, c.Query("fix") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Security Protocol handlers
+
+type CreateSecurityProtocolInput struct {
+	ProtocolName string           `json:"protocol_name" binding:"required"`
+	MatchType    string           `json:"match_type" binding:"required"`
+	FabricName   string           `json:"fabric_name" binding:"required"`
+	Description  string           `json:"description"`
+	Protocol     string           `json:"protocol"` // IP protocol of the port ranges: "TCP" (default), "UDP", ...
+	Ports        []PortRangeInput `json:"ports"`
+}
+
+type PortRangeInput struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// String formats the range as NDFC expects it: "80" or "8000-8080"
+func (p PortRangeInput) String() string {
+	if p.Min == p.Max {
+		return strconv.Itoa(p.Min)
+	}
+	return fmt.Sprintf("%d-%d", p.Min, p.Max)
+}
+
+// GetSecurityProtocols lists the security protocols NDFC has for ?fabric=
+func (h *SecurityHandler) GetSecurityProtocols(c *gin.Context) {
+	fabricName := c.Query("fabric")
+	if fabricName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fabric query parameter is required"})
+		return
+	}
+
+	protocols, err := h.ndClient.GetSecurityProtocols(c.Request.Context(), fabricName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, protocols)
+}
+
+// CreateSecurityProtocol creates a protocol in NDFC matching the given destination port
+// ranges, and records it locally
+func (h *SecurityHandler) CreateSecurityProtocol(c *gin.Context) {
+	var input CreateSecurityProtocolInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	protocol := input.Protocol
+	if protocol == "" {
+		protocol = "TCP"
+	}
+	var matchItems []ndclient.ProtocolMatchItem
+	for i, p := range input.Ports {
+		if p.Min < 1 || p.Max > 65535 || p.Min > p.Max {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ports[%d]: need 1 <= min <= max <= 65535", i)})
+			return
+		}
+		matchItems = append(matchItems, ndclient.ProtocolMatchItem{
+			Type:            "Default",
+			ProtocolOptions: protocol,
+			DstPortRange:    p.String(),
+		})
+	}
+
+	var existing int64
+	if err := h.db.WithContext(c.Request.Context()).Model(&models.SecurityProtocol{}).
+		Where("fabric_name = ? AND name = ?", input.FabricName, input.ProtocolName).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Security protocol already exists"})
+		return
+	}
+
+	// Create in Nexus Dashboard
+	ndResp, err := h.ndClient.CreateSecurityProtocol(c.Request.Context(), input.FabricName, &ndclient.SecurityProtocol{
+		ProtocolName: input.ProtocolName,
+		Description:  input.Description,
+		MatchType:    input.MatchType,
+		MatchItems:   matchItems,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ports, err := json.Marshal(input.Ports)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	local := models.SecurityProtocol{
+		ID:         uuid.New().String(),
+		Name:       input.ProtocolName,
+		FabricName: input.FabricName,
+		MatchType:  input.MatchType,
+		Ports:      ports,
+	}
+	if err := h.db.WithContext(c.Request.Context()).Create(&local).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"local":  local,
+		"remote": ndResp,
+	})
+}
+
+// DeleteSecurityProtocol deletes a protocol from NDFC and its local record, if any
+func (h *SecurityHandler) DeleteSecurityProtocol(c *gin.Context) {
+	protocolName := c.Param("protocolName")
+	fabricName := c.Query("fabric")
+	if fabricName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fabric query parameter is required"})
+		return
+	}
+
+	if err := h.ndClient.DeleteSecurityProtocol(c.Request.Context(), fabricName, protocolName); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.db.WithContext(c.Request.Context()).
+		Where("fabric_name = ? AND name = ?", fabricName, protocolName).
+		Delete(&models.SecurityProtocol{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Security protocol deleted"})
+}
+
 // ListNDFCSecurityGroups lists all security groups from NDFC
 func (h *SecurityHandler) ListNDFCSecurityGroups(c *gin.Context) {
 	fabricName := c.Query("fabric")
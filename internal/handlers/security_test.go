@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newSecurityGroupsTestHandler(t *testing.T, count int) *SecurityHandler {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.SecurityGroup{}, &models.PortSelector{}, &models.SwitchPort{})
+
+	for i := 1; i <= count; i++ {
+		g := models.SecurityGroup{ID: fmt.Sprintf("g%03d", i), Name: fmt.Sprintf("job-%03d", i), FabricName: "f1"}
+		if err := db.Create(&g).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &SecurityHandler{db: db, groupService: services.NewSecurityGroupService(db, nil)}
+}
+
+func TestGetSecurityGroups_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newSecurityGroupsTestHandler(t, 120)
+	r := gin.New()
+	r.GET("/security/groups", h.GetSecurityGroups)
+
+	tests := []struct {
+		query     string
+		wantCode  int
+		wantTotal string
+		wantLen   int
+		wantFirst string
+	}{
+		{"", http.StatusOK, "120", 50, "job-001"},
+		{"?page=3&page_size=50", http.StatusOK, "120", 20, "job-101"},
+		{"?page=2&page_size=10&sort=name_desc", http.StatusOK, "120", 10, "job-110"},
+		{"?name_contains=JOB-11&fabric=f1", http.StatusOK, "10", 10, "job-110"},
+		{"?fabric=f2", http.StatusOK, "0", 0, ""},
+		{"?page=0", http.StatusBadRequest, "", 0, ""},
+		{"?page_size=1000", http.StatusBadRequest, "", 0, ""},
+		{"?sort=size", http.StatusBadRequest, "", 0, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/security/groups"+tt.query, nil))
+		if w.Code != tt.wantCode {
+			t.Errorf("%q: code = %d, want %d", tt.query, w.Code, tt.wantCode)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		if got := w.Header().Get("X-Total-Count"); got != tt.wantTotal {
+			t.Errorf("%q: X-Total-Count = %q, want %q", tt.query, got, tt.wantTotal)
+		}
+		var groups []models.SecurityGroup
+		if err := json.Unmarshal(w.Body.Bytes(), &groups); err != nil {
+			t.Fatalf("%q: decode: %v", tt.query, err)
+		}
+		if len(groups) != tt.wantLen {
+			t.Errorf("%q: len = %d, want %d", tt.query, len(groups), tt.wantLen)
+		}
+		if tt.wantLen > 0 && groups[0].Name != tt.wantFirst {
+			t.Errorf("%q: first = %s, want %s", tt.query, groups[0].Name, tt.wantFirst)
+		}
+	}
+}
+
+func TestSecurityProtocols_CreateAndDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := dbtest.NewSQLiteDB(t, &models.SecurityProtocol{})
+
+	// Fake NDFC that keeps the protocols of fabric f1
+	var mu sync.Mutex
+	ndfc := map[string]ndclient.SecurityProtocol{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		const prefix = "/fabrics/f1/protocols"
+		i := strings.Index(r.URL.Path, prefix)
+		if i < 0 {
+			t.Errorf("unexpected NDFC request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch name := strings.TrimPrefix(r.URL.Path[i+len(prefix):], "/"); r.Method {
+		case http.MethodGet:
+			list := []ndclient.SecurityProtocol{}
+			for _, p := range ndfc {
+				list = append(list, p)
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			var created []ndclient.SecurityProtocol
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &created); err != nil {
+				t.Errorf("decode create body: %v", err)
+			}
+			for _, p := range created {
+				ndfc[p.ProtocolName] = p
+			}
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			delete(ndfc, name)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	h := &SecurityHandler{ndClient: client, db: db}
+	r := gin.New()
+	r.GET("/security/protocols", h.GetSecurityProtocols)
+	r.POST("/security/protocols", h.CreateSecurityProtocol)
+	r.DELETE("/security/protocols/:protocolName", h.DeleteSecurityProtocol)
+
+	body := `{"protocol_name":"custom-tcp","match_type":"MatchAll","fabric_name":"f1","ports":[{"min":80,"max":80},{"min":8000,"max":8080}]}`
+	if w := doJSON(r, http.MethodPost, "/security/protocols", body); w.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+	mu.Lock()
+	items := ndfc["custom-tcp"].MatchItems
+	mu.Unlock()
+	if len(items) != 2 || items[0].DstPortRange != "80" || items[1].DstPortRange != "8000-8080" || items[0].ProtocolOptions != "TCP" {
+		t.Errorf("NDFC match items = %+v", items)
+	}
+	var local models.SecurityProtocol
+	if err := db.First(&local, "fabric_name = ? AND name = ?", "f1", "custom-tcp").Error; err != nil {
+		t.Fatalf("local protocol: %v", err)
+	}
+	if local.MatchType != "MatchAll" || string(local.Ports) != `[{"min":80,"max":80},{"min":8000,"max":8080}]` {
+		t.Errorf("local protocol = %+v (ports %s)", local, local.Ports)
+	}
+	if w := doJSON(r, http.MethodPost, "/security/protocols", body); w.Code != http.StatusConflict {
+		t.Errorf("duplicate create: %d, want 409", w.Code)
+	}
+
+	w := doJSON(r, http.MethodGet, "/security/protocols?fabric=f1", "")
+	var listed []ndclient.SecurityProtocol
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || len(listed) != 1 || listed[0].ProtocolName != "custom-tcp" {
+		t.Errorf("list = %d %s", w.Code, w.Body)
+	}
+
+	if w := doJSON(r, http.MethodDelete, "/security/protocols/custom-tcp?fabric=f1", ""); w.Code != http.StatusOK {
+		t.Fatalf("delete: %d %s", w.Code, w.Body)
+	}
+	mu.Lock()
+	_, inNDFC := ndfc["custom-tcp"]
+	mu.Unlock()
+	if inNDFC {
+		t.Error("protocol still in NDFC after delete")
+	}
+	var count int64
+	db.Model(&models.SecurityProtocol{}).Count(&count)
+	if count != 0 {
+		t.Errorf("local protocols after delete = %d, want 0", count)
+	}
+}
+
+func TestCreateSecurityProtocol_InvalidPorts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &SecurityHandler{}
+	r := gin.New()
+	r.POST("/security/protocols", h.CreateSecurityProtocol)
+	r.GET("/security/protocols", h.GetSecurityProtocols)
+
+	for _, body := range []string{
+		`{"protocol_name":"p","match_type":"MatchAll","fabric_name":"f1","ports":[{"min":90,"max":80}]}`,
+		`{"protocol_name":"p","match_type":"MatchAll","fabric_name":"f1","ports":[{"min":0,"max":80}]}`,
+		`{"match_type":"MatchAll","fabric_name":"f1"}`,
+	} {
+		if w := doJSON(r, http.MethodPost, "/security/protocols", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: %d, want 400", body, w.Code)
+		}
+	}
+	if w := doJSON(r, http.MethodGet, "/security/protocols", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("list without fabric: %d, want 400", w.Code)
+	}
+}
+
+func TestBulkUpdateSelectors_ReconcileDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{},
+		&models.Switch{}, &models.SwitchPort{}, &models.Job{}, &models.StorageTenant{}, &models.JobStorageAccess{})
+
+	// Nodes n1..n3 each have a storage NIC on Ethernet1/<i> of switch FDO1
+	if err := db.Create(&models.Switch{ID: "sw1", Name: "leaf1", SerialNumber: "FDO1", FabricID: "fab"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("n%d", i)
+		ifaceID := id + "-storage"
+		rows := []interface{}{
+			&models.ComputeNode{ID: id, Name: id},
+			&models.ComputeNodeInterface{ID: ifaceID, ComputeNodeID: id, Role: models.InterfaceRoleStorage},
+			&models.SwitchPort{ID: "p" + id, Name: fmt.Sprintf("Ethernet1/%d", i), SwitchID: "sw1"},
+			&models.ComputeNodePortMapping{ID: "m" + id, ComputeNodeID: id, InterfaceID: &ifaceID, SwitchPortID: "p" + id},
+		}
+		for _, row := range rows {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// NDFC has an up-to-date SG for n1, a stale one for n2 and none for n3
+	var writes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes++
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"groupId":40001,"groupName":"storage-node-n1","attach":true,
+			 "networkPortSelectors":[{"network":"stor-net","switchId":"FDO1","interfaceName":"Ethernet1/1"}]},
+			{"groupId":40002,"groupName":"storage-node-n2","attach":true,
+			 "networkPortSelectors":[{"network":"stor-net","switchId":"FDO1","interfaceName":"Ethernet1/9"}]}]`))
+	}))
+	t.Cleanup(server.Close)
+	cfg := &config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test", StorageFabricName: "stor", StorageNetworkName: "stor-net"}
+	client, err := ndclient.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	h := &SecurityHandler{db: db, groupService: services.NewSecurityGroupService(db, client)}
+	h.SetStorageService(services.NewStorageService(db, client, cfg, nil))
+	r := gin.New()
+	r.POST("/security/groups/bulk-update-selectors", h.BulkUpdateSelectors)
+
+	if w := doJSON(r, http.MethodPost, "/security/groups/bulk-update-selectors?dry_run=true", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("empty body: %d, want 400", w.Code)
+	}
+	w := doJSON(r, http.MethodPost, "/security/groups/bulk-update-selectors?dry_run=true", "{}")
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d: %s", w.Code, w.Body)
+	}
+	var result services.StorageSGBulkResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.DryRun || result.Unchanged != 1 || len(result.Failed) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Updates) != 1 || result.Updates[0].GroupID != 40002 ||
+		len(result.Updates[0].Selectors) != 1 || result.Updates[0].Selectors[0].InterfaceName != "Ethernet1/2" {
+		t.Errorf("updates = %+v, want n2's SG moved to Ethernet1/2", result.Updates)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "n3" {
+		t.Errorf("missing = %v, want [n3]", result.Missing)
+	}
+	if writes != 0 {
+		t.Errorf("dry run sent %d writes to NDFC", writes)
+	}
+}
+
+func TestBulkUpdateSelectors_ExplicitUpdates(t *testing.T) {
+	h := newSecurityGroupsTestHandler(t, 0)
+	r := gin.New()
+	r.POST("/security/groups/bulk-update-selectors", h.BulkUpdateSelectors)
+
+	body := `{"fabric_name":"f1","updates":[{"group_id":7,"group_name":"sg-7",
+		"selectors":[{"network":"net","switch_id":"FDO1","interface_name":" Ethernet1/3 "}]}]}`
+	w := doJSON(r, http.MethodPost, "/security/groups/bulk-update-selectors?dry_run=true", body)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"interfaceName":"Ethernet1/3"`) {
+		t.Errorf("dry run: %d %s, want the normalized update", w.Code, w.Body)
+	}
+
+	bad := `{"fabric_name":"f1","updates":[{"group_id":7,"group_name":"sg-7",
+		"selectors":[{"network":"net","switch_id":"FDO1","interface_name":"eth1/3"}]}]}`
+	if w := doJSON(r, http.MethodPost, "/security/groups/bulk-update-selectors", bad); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid selector: %d %s, want 400", w.Code, w.Body)
+	}
+	if w := doJSON(r, http.MethodPost, "/security/groups/bulk-update-selectors", "{}"); w.Code != http.StatusBadRequest {
+		t.Errorf("no updates without storage service: %d, want 400", w.Code)
+	}
+}
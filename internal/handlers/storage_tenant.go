@@ -30,7 +30,7 @@ type StorageTenantInput struct {
 // GetStorageTenants returns all storage tenants
 func (h *StorageTenantHandler) GetStorageTenants(c *gin.Context) {
 	var tenants []models.StorageTenant
-	if err := database.DB.Find(&tenants).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Find(&tenants).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -42,7 +42,7 @@ func (h *StorageTenantHandler) GetStorageTenant(c *gin.Context) {
 	key := c.Param("key")
 
 	var tenant models.StorageTenant
-	if err := database.DB.Where("key = ?", key).First(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("key = ?", key).First(&tenant).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Storage tenant not found"})
 		return
 	}
@@ -59,7 +59,7 @@ func (h *StorageTenantHandler) CreateStorageTenant(c *gin.Context) {
 
 	// Check if tenant key already exists
 	var existing models.StorageTenant
-	if err := database.DB.Where("key = ?", input.Key).First(&existing).Error; err == nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("key = ?", input.Key).First(&existing).Error; err == nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "Storage tenant with this key already exists"})
 		return
 	}
@@ -74,7 +74,7 @@ func (h *StorageTenantHandler) CreateStorageTenant(c *gin.Context) {
 		StorageContractName:  input.StorageContractName,
 	}
 
-	if err := database.DB.Create(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Create(&tenant).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -87,7 +87,7 @@ func (h *StorageTenantHandler) UpdateStorageTenant(c *gin.Context) {
 	key := c.Param("key")
 
 	var tenant models.StorageTenant
-	if err := database.DB.Where("key = ?", key).First(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("key = ?", key).First(&tenant).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Storage tenant not found"})
 		return
 	}
@@ -101,7 +101,7 @@ func (h *StorageTenantHandler) UpdateStorageTenant(c *gin.Context) {
 	// If key is being changed, check for conflicts
 	if input.Key != key {
 		var existing models.StorageTenant
-		if err := database.DB.Where("key = ?", input.Key).First(&existing).Error; err == nil {
+		if err := database.DB.WithContext(c.Request.Context()).Where("key = ?", input.Key).First(&existing).Error; err == nil {
 			c.JSON(http.StatusConflict, gin.H{"error": "Storage tenant with this key already exists"})
 			return
 		}
@@ -114,7 +114,7 @@ func (h *StorageTenantHandler) UpdateStorageTenant(c *gin.Context) {
 	tenant.StorageDstGroupName = input.StorageDstGroupName
 	tenant.StorageContractName = input.StorageContractName
 
-	if err := database.DB.Save(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Save(&tenant).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -127,14 +127,14 @@ func (h *StorageTenantHandler) DeleteStorageTenant(c *gin.Context) {
 	key := c.Param("key")
 
 	var tenant models.StorageTenant
-	if err := database.DB.Where("key = ?", key).First(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Where("key = ?", key).First(&tenant).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Storage tenant not found"})
 		return
 	}
 
 	// Check if tenant is in use by any active jobs
 	var count int64
-	if err := database.DB.Model(&models.Job{}).
+	if err := database.DB.WithContext(c.Request.Context()).Model(&models.Job{}).
 		Where("tenant_key = ? AND status NOT IN ?", key, []string{"completed", "failed"}).
 		Count(&count).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -145,7 +145,7 @@ func (h *StorageTenantHandler) DeleteStorageTenant(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Delete(&tenant).Error; err != nil {
+	if err := database.DB.WithContext(c.Request.Context()).Delete(&tenant).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
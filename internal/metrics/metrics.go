@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"slices"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Security group recovery outcomes for SGRecoveryTotal
+const (
+	SGRecoveryExisting  = "existing"  // Group already existed in NDFC before create
+	SGRecoveryCreated   = "created"   // Group was freshly created
+	SGRecoveryRecovered = "recovered" // Create conflicted and the group was re-fetched
+)
+
+// SGRecoveryTotal counts security group lookups/creates during provisioning by outcome
+var SGRecoveryTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_sg_recovery_total",
+	Help: "Security group resolutions during provisioning by outcome (existing, created, recovered).",
+}, []string{"type"})
+
+// DeployCircuitOpenTotal counts batched deploys failed because the NDFC circuit breaker stayed open
+var DeployCircuitOpenTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "nd_deploy_circuit_open_total",
+	Help: "Batched deploys abandoned because the NDFC circuit breaker was open.",
+})
+
+// DeploysSkippedNoChangesTotal counts batched deploys skipped because NDFC's config preview showed every switch in sync
+var DeploysSkippedNoChangesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_deploys_skipped_no_changes_total",
+	Help: "Fabric deploys skipped because NDFC's config preview showed every switch in sync, per fabric.",
+}, []string{"fabric"})
+
+// UplinkPortsCached is the number of uplink ports in the Valkey uplink cache per fabric (0 after invalidation)
+var UplinkPortsCached = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "nd_uplink_ports_cached",
+	Help: "Uplink (inter-switch link) ports currently cached per fabric.",
+}, []string{"fabric"})
+
+// FabricLeafPortsAvailable is the number of present leaf switch ports without a compute node mapping, per fabric
+var FabricLeafPortsAvailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "nd_fabric_leaf_ports_available",
+	Help: "Present leaf switch ports not mapped to a compute node, per fabric. Updated on each sync.",
+}, []string{"fabric"})
+
+// ComputeNodesNotSeen7d is the number of active compute nodes no port sync has seen for 7 days
+var ComputeNodesNotSeen7d = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "nd_compute_nodes_not_seen_7d_total",
+	Help: "Active compute nodes whose mapped ports no port sync has found for 7 days. Nodes never seen are not counted.",
+})
+
+// JobsPrunedTotal counts jobs permanently deleted after the retention period
+var JobsPrunedTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "nd_jobs_pruned_total",
+	Help: "Finished or soft-deleted jobs permanently deleted after the retention period.",
+})
+
+// OrphanedAllocationsRecoveredTotal counts compute node allocations released because their job was missing or finished
+var OrphanedAllocationsRecoveredTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "nd_orphaned_allocations_recovered_total",
+	Help: "Compute node allocations released because their job was missing, completed or failed.",
+})
+
+// Manual deploy triggers for ManualDeploysTotal
+const (
+	ManualDeployTriggerGRPC = "grpc"
+	ManualDeployTriggerHTTP = "http"
+)
+
+// ManualDeploysTotal counts fabric deploys requested through the API rather than by provisioning
+var ManualDeploysTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_manual_deploys_total",
+	Help: "Fabric deploys requested through the API by trigger (grpc, http).",
+}, []string{"trigger"})
+
+// GRPCServiceHealth is the gRPC health status per service: 1 serving, 0 not serving
+var GRPCServiceHealth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "nd_grpc_service_health",
+	Help: "gRPC health status per service (1 = serving, 0 = not serving).",
+}, []string{"service"})
+
+// GRPCCompressedBytesTotal counts compressed gRPC message bytes written, per codec
+var GRPCCompressedBytesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_grpc_compressed_bytes_total",
+	Help: "Compressed gRPC message bytes written by codec (gzip, zstd).",
+}, []string{"codec"})
+
+// NDFCRetriesTotal counts NDFC requests retried after a transient error, by status code
+var NDFCRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_ndfc_retries_total",
+	Help: "NDFC requests retried after a 429 or 502-504 response, by status code.",
+}, []string{"status_code"})
+
+// InvalidGroupIDTotal counts security group IDs rejected before reaching NDFC for being out of range
+var InvalidGroupIDTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "nd_invalid_group_id_total",
+	Help: "Security group IDs outside NDFC's range [16, 65535] rejected before an NDFC call.",
+})
+
+// Outcomes of provisioning operations, see Result
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Result returns the outcome label of an operation that returned err
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
+
+// provisioningBuckets span provisioning times from a cached resubmission to the NDFC timeout
+var provisioningBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800}
+
+// JobProvisionsTotal counts JobService.Provision calls by result
+var JobProvisionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_job_provisions_total",
+	Help: "Job provisioning requests by result (success, failure).",
+}, []string{"result"})
+
+// JobProvisionDuration is the duration of JobService.Provision calls by result
+var JobProvisionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "nd_job_provision_duration_seconds",
+	Help:    "Job provisioning duration by result (success, failure), including the NDFC deploy.",
+	Buckets: provisioningBuckets,
+}, []string{"result"})
+
+// JobDeprovisionsTotal counts JobService.Deprovision calls by result
+var JobDeprovisionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_job_deprovisions_total",
+	Help: "Job deprovisioning requests by result (success, failure).",
+}, []string{"result"})
+
+// JobDeprovisionDuration is the duration of JobService.Deprovision calls by result
+var JobDeprovisionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "nd_job_deprovision_duration_seconds",
+	Help:    "Job deprovisioning duration by result (success, failure).",
+	Buckets: provisioningBuckets,
+}, []string{"result"})
+
+// NDFCRequestsTotal counts HTTP requests sent to NDFC (each retry included) by method and
+// status code, "error" when no response was received
+var NDFCRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nd_ndfc_requests_total",
+	Help: "HTTP requests sent to NDFC by method and status code (error = no response).",
+}, []string{"method", "status_code"})
+
+// NDFCRequestDuration is the latency of HTTP requests to NDFC by method
+var NDFCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "nd_ndfc_request_duration_seconds",
+	Help:    "Latency of HTTP requests to NDFC until the response headers, by method.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method"})
+
+// DeployBatchSize is the number of deploy requests each batched fabric deploy served
+var DeployBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "nd_deploy_batch_size",
+	Help:    "Deploy requests coalesced into each batched fabric deploy.",
+	Buckets: []float64{1, 2, 3, 5, 10, 20, 50, 100},
+})
+
+// DeployBatchWait is the time from the first request of a deploy batch until its deploy starts
+var DeployBatchWait = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "nd_deploy_batch_wait_seconds",
+	Help:    "Time from the first request of a deploy batch until the deploy starts.",
+	Buckets: []float64{0.5, 1, 2, 5, 10, 15, 20, 30, 60, 120},
+})
+
+// JobExitCodes is the distribution of Slurm exit codes reported on job completion
+var JobExitCodes = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "nd_job_exit_code",
+	Help:    "Slurm exit codes reported on job completion (128+N for jobs killed by signal N).",
+	Buckets: []float64{0, 1, 2, 125, 126, 127, 128, 130, 137, 143, 255},
+})
+
+// HTTPDurationBuckets returns the default Prometheus buckets plus one at slowThreshold, so
+// dashboards can count requests above the slow request threshold
+func HTTPDurationBuckets(slowThreshold time.Duration) []float64 {
+	buckets := append([]float64(nil), prometheus.DefBuckets...)
+	threshold := slowThreshold.Seconds()
+	if threshold <= 0 || slices.Contains(buckets, threshold) {
+		return buckets
+	}
+	buckets = append(buckets, threshold)
+	slices.Sort(buckets)
+	return buckets
+}
+
+// HTTPRequestDuration is the HTTP request duration by method, route and status code, with a
+// bucket at SLOW_REQUEST_THRESHOLD_MS
+var HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "nd_http_request_duration_seconds",
+	Help:    "HTTP request duration by method, route and status code.",
+	Buckets: HTTPDurationBuckets(time.Duration(config.SlowRequestThresholdMS()) * time.Millisecond),
+}, []string{"method", "route", "status_code"})
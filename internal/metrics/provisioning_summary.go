@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ProvisioningMonth is one month of the provisioning summary
+type ProvisioningMonth struct {
+	Period              string // YYYY-MM
+	Jobs                int64
+	AvgProvisionSeconds float64
+	Nodes               int64
+	MaxNodesSingleJob   int64
+}
+
+// provisioningSummaryScrapeTimeout bounds the summary query run on each scrape
+const provisioningSummaryScrapeTimeout = 10 * time.Second
+
+// ProvisioningSummary exposes the monthly provisioning summary as gauges labelled by period.
+// The source set with SetSource is queried on each scrape so values always reflect the
+// database; until it is set nothing is reported.
+var ProvisioningSummary = newProvisioningSummaryCollector()
+
+func init() {
+	prometheus.MustRegister(ProvisioningSummary)
+}
+
+// ProvisioningSummaryCollector is the prometheus.Collector behind ProvisioningSummary
+type ProvisioningSummaryCollector struct {
+	source atomic.Pointer[func(ctx context.Context) ([]ProvisioningMonth, error)]
+
+	jobs         *prometheus.Desc
+	avgProvision *prometheus.Desc
+	nodes        *prometheus.Desc
+	maxNodes     *prometheus.Desc
+}
+
+func newProvisioningSummaryCollector() *ProvisioningSummaryCollector {
+	labels := []string{"period"}
+	return &ProvisioningSummaryCollector{
+		jobs: prometheus.NewDesc("nd_provisioning_summary_jobs",
+			"Jobs submitted per month.", labels, nil),
+		avgProvision: prometheus.NewDesc("nd_provisioning_summary_avg_provision_seconds",
+			"Mean submit-to-provisioned time of jobs submitted per month.", labels, nil),
+		nodes: prometheus.NewDesc("nd_provisioning_summary_nodes",
+			"Sum of compute nodes across jobs submitted per month.", labels, nil),
+		maxNodes: prometheus.NewDesc("nd_provisioning_summary_max_nodes_single_job",
+			"Largest compute node count of a single job submitted per month.", labels, nil),
+	}
+}
+
+// SetSource sets the query run on each scrape
+func (c *ProvisioningSummaryCollector) SetSource(source func(ctx context.Context) ([]ProvisioningMonth, error)) {
+	c.source.Store(&source)
+}
+
+// Describe implements prometheus.Collector
+func (c *ProvisioningSummaryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.jobs
+	ch <- c.avgProvision
+	ch <- c.nodes
+	ch <- c.maxNodes
+}
+
+// Collect implements prometheus.Collector. Query failures are logged and the
+// batch is omitted so the rest of the scrape still succeeds.
+func (c *ProvisioningSummaryCollector) Collect(ch chan<- prometheus.Metric) {
+	source := c.source.Load()
+	if source == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provisioningSummaryScrapeTimeout)
+	defer cancel()
+
+	months, err := (*source)(ctx)
+	if err != nil {
+		logger.Warn("Failed to collect provisioning summary metrics", zap.Error(err))
+		return
+	}
+	for _, m := range months {
+		ch <- prometheus.MustNewConstMetric(c.jobs, prometheus.GaugeValue, float64(m.Jobs), m.Period)
+		ch <- prometheus.MustNewConstMetric(c.avgProvision, prometheus.GaugeValue, m.AvgProvisionSeconds, m.Period)
+		ch <- prometheus.MustNewConstMetric(c.nodes, prometheus.GaugeValue, float64(m.Nodes), m.Period)
+		ch <- prometheus.MustNewConstMetric(c.maxNodes, prometheus.GaugeValue, float64(m.MaxNodesSingleJob), m.Period)
+	}
+}
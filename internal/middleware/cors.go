@@ -0,0 +1,5 @@
+package middleware
+
+// CORSOrigins are the browser origins allowed by the HTTP API and the gRPC gateway
+// (frontend development)
+var CORSOrigins = []string{"http://localhost:3000", "http://127.0.0.1:3000"}
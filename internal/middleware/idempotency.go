@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// IdempotencyKeyHeader is the header clients set to make a retried request safe to repeat
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses replayed from an earlier request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header, which becomes part of a Valkey key
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBodyBytes bounds the request body hashed for an Idempotency-Key
+const maxIdempotentBodyBytes = 1 << 20
+
+// Idempotency lock handling
+const (
+	idempotencyOpTimeout    = 2 * time.Second        // Per Valkey operation
+	idempotencyPollInterval = 100 * time.Millisecond // How often a waiting request checks for the response
+)
+
+// idempotencyRefreshInterval is how often a running request extends its lock; a var so tests
+// can shorten it
+var idempotencyRefreshInterval = cache.TTLIdempotencyLock / 3
+
+// idempotentResponse is a response cached under cache.IdempotencyResponse
+type idempotentResponse struct {
+	RequestHash string `json:"request_hash"` // SHA-256 of the request body
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// Idempotency makes requests carrying an Idempotency-Key header run at most once per key.
+// The first request claims cache.IdempotencyRequest(key) with SET NX (refreshed every 10s
+// while the handler runs, expiring after cache.TTLIdempotencyLock if the instance dies) and
+// caches its response for cache.TTLIdempotency. A request with the same key arriving
+// meanwhile waits for that response and gets it replayed with Idempotent-Replayed: true;
+// later ones get it replayed at once. Reusing a key with a different body is rejected with
+// 422, and a body over 1 MiB with 413.
+//
+// If the lock cannot be refreshed (it expired or another request took it over), the
+// handler's request context is cancelled and its response is neither cached nor followed by
+// a release of the lock.
+//
+// 5xx responses are not cached, so the next request with the key runs the handler again.
+// Requests without the header, and all requests when store is nil, pass through. If Valkey
+// fails the request runs unguarded.
+func Idempotency(store cache.Store, log *zap.Logger) gin.HandlerFunc {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if store == nil || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodyBytes)); err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		sum := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(sum[:])
+
+		ctx := c.Request.Context()
+		ticker := time.NewTicker(idempotencyPollInterval)
+		defer ticker.Stop()
+		for {
+			cached, err := loadIdempotentResponse(ctx, store, key)
+			if err != nil {
+				log.Warn("Failed to read idempotent response, running request unguarded",
+					zap.String("idempotency_key", key), zap.Error(err))
+				c.Next()
+				return
+			}
+			if cached != nil {
+				replayIdempotentResponse(c, cached, requestHash)
+				return
+			}
+
+			owner := uuid.NewString()
+			claimed, err := setNX(ctx, store, cache.IdempotencyRequest(key), owner)
+			if err != nil {
+				log.Warn("Failed to claim idempotency key, running request unguarded",
+					zap.String("idempotency_key", key), zap.Error(err))
+				c.Next()
+				return
+			}
+			if claimed {
+				runIdempotent(c, store, log, key, owner, requestHash)
+				return
+			}
+
+			// Another request holds the key: wait for its response, or for its claim to be
+			// released without one (a 5xx) or to expire, then try again
+			select {
+			case <-ctx.Done():
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is still in progress"})
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// runIdempotent runs the handler while holding the idempotency key, then caches its response
+// unless it is a 5xx and releases the key. If the key is lost while the handler runs, the
+// handler's context is cancelled and nothing is cached or released.
+func runIdempotent(c *gin.Context, store cache.Store, log *zap.Logger, key, owner, requestHash string) {
+	lockKey := cache.IdempotencyRequest(key)
+	handlerCtx, cancelHandler := context.WithCancel(c.Request.Context())
+	defer cancelHandler()
+	c.Request = c.Request.WithContext(handlerCtx)
+
+	stopRefresh := make(chan struct{})
+	refreshed := make(chan bool, 1)
+	go func() {
+		held := refreshIdempotencyLock(store, lockKey, owner, stopRefresh)
+		if !held {
+			log.Warn("Lost idempotency key, cancelling request", zap.String("idempotency_key", key))
+			cancelHandler()
+		}
+		refreshed <- held
+	}()
+	var stopOnce sync.Once
+	held := true
+	stopRefreshing := func() {
+		stopOnce.Do(func() {
+			close(stopRefresh)
+			held = <-refreshed
+		})
+	}
+	defer func() {
+		stopRefreshing()
+		if !held {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), idempotencyOpTimeout)
+		defer cancel()
+		if err := store.ReleaseLock(ctx, lockKey, owner); err != nil {
+			log.Warn("Failed to release idempotency key", zap.String("idempotency_key", key), zap.Error(err))
+		}
+	}()
+
+	rw := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = rw
+	c.Next()
+	c.Writer = rw.ResponseWriter
+
+	// Once the key is lost another request may be running the handler, and its response is
+	// the one to cache
+	stopRefreshing()
+	status := c.Writer.Status()
+	if !held || status >= http.StatusInternalServerError {
+		return
+	}
+	data, err := json.Marshal(idempotentResponse{
+		RequestHash: requestHash,
+		Status:      status,
+		ContentType: c.Writer.Header().Get("Content-Type"),
+		Body:        rw.body.Bytes(),
+	})
+	if err != nil {
+		return
+	}
+	// Not the request context: the response should be cached even if the client has gone
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyOpTimeout)
+	defer cancel()
+	if err := store.SetString(ctx, cache.IdempotencyResponse(key), string(data), cache.TTLIdempotency); err != nil {
+		log.Warn("Failed to cache idempotent response", zap.String("idempotency_key", key), zap.Error(err))
+	}
+}
+
+// refreshIdempotencyLock extends the idempotency lock every third of its TTL until stop is
+// closed, so a waiting request does not take over from a handler running longer than the TTL.
+// It returns false as soon as the lock could not be extended for owner, true once stopped.
+func refreshIdempotencyLock(store cache.Store, lockKey, owner string, stop <-chan struct{}) bool {
+	ticker := time.NewTicker(idempotencyRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return true
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), idempotencyOpTimeout)
+		held, err := store.ExtendLockIfOwner(ctx, lockKey, owner, cache.TTLIdempotencyLock)
+		cancel()
+		if err != nil || !held {
+			return false
+		}
+	}
+}
+
+// loadIdempotentResponse returns the response cached for key, or nil if there is none
+func loadIdempotentResponse(ctx context.Context, store cache.Store, key string) (*idempotentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, idempotencyOpTimeout)
+	defer cancel()
+	data, err := store.GetString(ctx, cache.IdempotencyResponse(key))
+	if errors.Is(err, cache.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached idempotentResponse
+	if err := json.Unmarshal([]byte(data), &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// setNX claims key for cache.TTLIdempotencyLock
+func setNX(ctx context.Context, store cache.Store, key, value string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, idempotencyOpTimeout)
+	defer cancel()
+	return store.SetNX(ctx, key, value, cache.TTLIdempotencyLock)
+}
+
+// replayIdempotentResponse writes a cached response, or 422 if it was for another request body
+func replayIdempotentResponse(c *gin.Context, cached *idempotentResponse, requestHash string) {
+	if cached.RequestHash != requestHash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request body"})
+		return
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(cached.Status, cached.ContentType, cached.Body)
+	c.Abort()
+}
+
+// recordingWriter copies the response body as it is written
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
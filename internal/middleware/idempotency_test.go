@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/gin-gonic/gin"
+)
+
+// newIdempotencyRouter serves POST /jobs behind Idempotency, calling handler
+func newIdempotencyRouter(store cache.Store, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/jobs", Idempotency(store, nil), handler)
+	return r
+}
+
+func postJob(r http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ConcurrentRequestWaitsForFirstResponse(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := newIdempotencyRouter(cachetest.NewInMemoryCache(), func(c *gin.Context) {
+		n := calls.Add(1)
+		if n == 1 {
+			close(started)
+			<-release
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": n})
+	})
+
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 2)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responses[0] = postJob(r, "k1", `{"slurm_job_id": "1001"}`)
+	}()
+	<-started
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responses[1] = postJob(r, "k1", `{"slurm_job_id": "1001"}`)
+	}()
+	// Let the second request find the key claimed and start waiting
+	time.Sleep(3 * idempotencyPollInterval)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler ran %d times, want 1", n)
+	}
+	first, second := responses[0], responses[1]
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("codes = %d, %d, want 201 twice", first.Code, second.Code)
+	}
+	if first.Body.String() != `{"call":1}` || second.Body.String() != first.Body.String() {
+		t.Errorf("bodies = %s, %s, want the first response twice", first.Body, second.Body)
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" || second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("%s = %q, %q, want only the second replayed", IdempotentReplayedHeader,
+			first.Header().Get(IdempotentReplayedHeader), second.Header().Get(IdempotentReplayedHeader))
+	}
+	if ct := second.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("replayed Content-Type = %q, want the original", ct)
+	}
+}
+
+func TestIdempotency_ReplaysAndRejectsChangedBody(t *testing.T) {
+	var calls atomic.Int32
+	r := newIdempotencyRouter(cachetest.NewInMemoryCache(), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"call": calls.Add(1)})
+	})
+
+	postJob(r, "k1", `{"slurm_job_id": "1001"}`)
+	if w := postJob(r, "k1", `{"slurm_job_id": "1001"}`); w.Code != http.StatusCreated || w.Body.String() != `{"call":1}` {
+		t.Errorf("retry = %d %s, want the first response replayed", w.Code, w.Body)
+	}
+	if w := postJob(r, "k1", `{"slurm_job_id": "1002"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("changed body = %d, want 422", w.Code)
+	}
+	if w := postJob(r, "k2", `{"slurm_job_id": "1002"}`); w.Body.String() != `{"call":2}` {
+		t.Errorf("other key = %s, want a new call", w.Body)
+	}
+	if w := postJob(r, "", `{"slurm_job_id": "1002"}`); w.Body.String() != `{"call":3}` {
+		t.Errorf("no key = %s, want a new call", w.Body)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("handler ran %d times, want 3", n)
+	}
+}
+
+func TestIdempotency_ServerErrorsAreNotCached(t *testing.T) {
+	var calls atomic.Int32
+	r := newIdempotencyRouter(cachetest.NewInMemoryCache(), func(c *gin.Context) {
+		if calls.Add(1) == 1 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "NDFC unavailable"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	if w := postJob(r, "k1", `{}`); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("first = %d, want 503", w.Code)
+	}
+	if w := postJob(r, "k1", `{}`); w.Code != http.StatusCreated || w.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("retry = %d, want the handler to run again", w.Code)
+	}
+}
+
+func TestIdempotency_RejectsLongKey(t *testing.T) {
+	r := newIdempotencyRouter(cachetest.NewInMemoryCache(), func(c *gin.Context) {
+		t.Error("handler ran")
+	})
+	if w := postJob(r, strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("code = %d, want 400", w.Code)
+	}
+}
+
+func TestIdempotency_RejectsLargeBody(t *testing.T) {
+	r := newIdempotencyRouter(cachetest.NewInMemoryCache(), func(c *gin.Context) {
+		t.Error("handler ran")
+	})
+	if w := postJob(r, "k1", strings.Repeat("x", maxIdempotentBodyBytes+1)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("code = %d, want 413", w.Code)
+	}
+}
+
+func TestIdempotency_LostLockCancelsRequest(t *testing.T) {
+	prev := idempotencyRefreshInterval
+	idempotencyRefreshInterval = 10 * time.Millisecond
+	t.Cleanup(func() { idempotencyRefreshInterval = prev })
+
+	store := cachetest.NewInMemoryCache()
+	r := newIdempotencyRouter(store, func(c *gin.Context) {
+		// Another request takes the key over, as after the lock expired
+		if err := store.SetString(c.Request.Context(), cache.IdempotencyRequest("k1"), "other", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("request context was not cancelled")
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	postJob(r, "k1", `{}`)
+	ctx := context.Background()
+	if _, err := store.GetString(ctx, cache.IdempotencyResponse("k1")); !errors.Is(err, cache.ErrKeyNotFound) {
+		t.Errorf("response cached after the lock was lost: %v", err)
+	}
+	if owner, err := store.GetString(ctx, cache.IdempotencyRequest("k1")); err != nil || owner != "other" {
+		t.Errorf("lock = %q, %v; want it left to its new owner", owner, err)
+	}
+}
+
+// failingStore fails every operation, like an unreachable Valkey
+type failingStore struct{ cache.Store }
+
+func (failingStore) GetString(context.Context, string) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+func TestIdempotency_RunsUnguardedWhenValkeyFails(t *testing.T) {
+	var calls atomic.Int32
+	for _, store := range []cache.Store{nil, failingStore{}} {
+		r := newIdempotencyRouter(store, func(c *gin.Context) {
+			calls.Add(1)
+			c.Status(http.StatusCreated)
+		})
+		for i := 0; i < 2; i++ {
+			if w := postJob(r, "k1", `{}`); w.Code != http.StatusCreated {
+				t.Errorf("code = %d, want 201", w.Code)
+			}
+		}
+	}
+	if n := calls.Load(); n != 4 {
+		t.Errorf("handler ran %d times, want 4", n)
+	}
+}
@@ -0,0 +1,157 @@
+// Package middleware provides Gin middleware for the HTTP API.
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// maxLoggedBodyBytes is how much of a slow request's body is logged
+const maxLoggedBodyBytes = 1024
+
+// RequestIDHeader is the header a request ID is read from, e.g. as set by a load balancer
+const RequestIDHeader = "X-Request-ID"
+
+// SlowRequestConfig configures SlowRequests
+type SlowRequestConfig struct {
+	Threshold time.Duration // Requests taking longer are logged
+	LogBodies bool          // Log the first 1 KB of slow POST/PUT bodies, secrets masked
+}
+
+// SlowRequests observes every request's duration in duration (by method, route and status
+// code; may be nil) and logs requests slower than cfg.Threshold as warnings
+func SlowRequests(log *zap.Logger, cfg SlowRequestConfig, duration *prometheus.HistogramVec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// Capture the body as the handler reads it, so the request is not buffered twice
+		var body *bodyCapture
+		if cfg.LogBodies && c.Request.Body != nil &&
+			(c.Request.Method == http.MethodPost || c.Request.Method == http.MethodPut) {
+			body = &bodyCapture{ReadCloser: c.Request.Body}
+			c.Request.Body = body
+		}
+
+		c.Next()
+
+		elapsed := time.Since(start)
+		status := c.Writer.Status()
+		if duration != nil {
+			route := c.FullPath()
+			if route == "" {
+				route = "unmatched" // Keeps 404 scans from creating a series per path
+			}
+			duration.WithLabelValues(c.Request.Method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+		}
+		if elapsed <= cfg.Threshold || log == nil {
+			return
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int64("duration_ms", elapsed.Milliseconds()),
+			zap.Int("status_code", status),
+			zap.String("request_id", c.GetHeader(RequestIDHeader)),
+		}
+		if body != nil && len(body.data) > 0 {
+			fields = append(fields, zap.String("body", MaskSecrets(string(body.data))))
+		}
+		log.Warn("Slow HTTP request", fields...)
+	}
+}
+
+// bodyCapture keeps the first maxLoggedBodyBytes read from a request body
+type bodyCapture struct {
+	io.ReadCloser
+	data []byte
+}
+
+func (b *bodyCapture) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := maxLoggedBodyBytes - len(b.data); room > 0 && n > 0 {
+		b.data = append(b.data, p[:min(n, room)]...)
+	}
+	return n, err
+}
+
+// secretKeyPattern matches JSON keys whose values are masked
+var secretKeyPattern = regexp.MustCompile(`(?i)password|token|secret`)
+
+// secretFieldPattern matches a JSON field whose key contains password, token or secret, with
+// its string, flat array, flat object or scalar value. Used for bodies that are not valid
+// JSON, e.g. truncated ones.
+var secretFieldPattern = regexp.MustCompile(
+	`("[^"]*(?i:password|token|secret)[^"]*"\s*:\s*)("(?:[^"\\]|\\.)*"?|\[[^\[\]]*\]?|\{[^{}]*\}?|[^,}\]\s]+)`)
+
+// MaskSecrets replaces the values of JSON fields whose key contains password, token or
+// secret with "***", at any depth. Bodies that are not valid JSON, e.g. truncated ones, are
+// masked with secretFieldPattern.
+func MaskSecrets(body string) string {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var spans [][2]int64
+	if err := walkSecretValues(dec, &spans); err != nil {
+		return secretFieldPattern.ReplaceAllString(body, `$1"***"`)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return secretFieldPattern.ReplaceAllString(body, `$1"***"`)
+	}
+
+	// Each span runs from the end of a secret key to the end of its value
+	var b strings.Builder
+	last := int64(0)
+	for _, span := range spans {
+		field := body[span[0]:span[1]]
+		value := strings.TrimLeft(field[strings.IndexByte(field, ':')+1:], " \t\r\n")
+		b.WriteString(body[last:span[0]])
+		b.WriteString(field[:len(field)-len(value)]) // Colon and whitespace
+		b.WriteString(`"***"`)
+		last = span[1]
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
+
+// walkSecretValues reads one JSON value from dec, recursing into objects and arrays, and
+// appends the byte span of every secret key's value to spans (if not nil)
+func walkSecretValues(dec *json.Decoder, spans *[][2]int64) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+	for dec.More() {
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if k, _ := key.(string); spans != nil && secretKeyPattern.MatchString(k) {
+				start := dec.InputOffset()
+				if err := walkSecretValues(dec, nil); err != nil {
+					return err
+				}
+				*spans = append(*spans, [2]int64{start, dec.InputOffset()})
+				continue
+			}
+		}
+		if err := walkSecretValues(dec, spans); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // Closing delimiter
+	return err
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newSlowLogRouter serves /fast and /slow (sleeping 60ms, reading the body) behind
+// SlowRequests with a 30ms threshold
+func newSlowLogRouter(t *testing.T, logBodies bool) (*gin.Engine, *observer.ObservedLogs, *prometheus.HistogramVec) {
+	t.Helper()
+	core, logs := observer.New(zap.WarnLevel)
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "test_http_request_duration_seconds",
+		Buckets: metrics.HTTPDurationBuckets(30 * time.Millisecond),
+	}, []string{"method", "route", "status_code"})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(SlowRequests(zap.New(core), SlowRequestConfig{Threshold: 30 * time.Millisecond, LogBodies: logBodies}, duration))
+	r.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/slow", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		time.Sleep(60 * time.Millisecond)
+		c.Status(http.StatusAccepted)
+	})
+	return r, logs, duration
+}
+
+func serve(r *gin.Engine, method, path, body string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(RequestIDHeader, "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestSlowRequests_LogsSlowRequestsOnly(t *testing.T) {
+	r, logs, duration := newSlowLogRouter(t, false)
+
+	serve(r, http.MethodGet, "/fast", "")
+	if logs.Len() != 0 {
+		t.Fatalf("fast request logged: %v", logs.All())
+	}
+
+	serve(r, http.MethodPost, "/slow", `{"name":"x"}`)
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1 for the slow request", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["method"] != "POST" || fields["path"] != "/slow" || fields["status_code"] != int64(http.StatusAccepted) ||
+		fields["request_id"] != "req-1" {
+		t.Errorf("fields = %v", fields)
+	}
+	if ms, _ := fields["duration_ms"].(int64); ms < 60 {
+		t.Errorf("duration_ms = %v, want >= 60", fields["duration_ms"])
+	}
+	if _, ok := fields["body"]; ok {
+		t.Error("body logged with LogBodies off")
+	}
+
+	if n := testutil.CollectAndCount(duration); n != 2 {
+		t.Errorf("histogram series = %d, want 2 (fast and slow)", n)
+	}
+}
+
+func TestSlowRequests_LogsMaskedBody(t *testing.T) {
+	r, logs, _ := newSlowLogRouter(t, true)
+
+	serve(r, http.MethodPost, "/slow", `{"user":"admin","password":"hunter2","api_token":"abc","count":3}`)
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	want := `{"user":"admin","password":"***","api_token":"***","count":3}`
+	if body := entries[0].ContextMap()["body"]; body != want {
+		t.Errorf("body = %v, want %s", body, want)
+	}
+
+	// Only the first 1 KB is logged
+	logs.TakeAll()
+	serve(r, http.MethodPost, "/slow", `{"data":"`+strings.Repeat("a", 2000)+`"}`)
+	if body, _ := logs.All()[0].ContextMap()["body"].(string); len(body) != maxLoggedBodyBytes {
+		t.Errorf("logged body length = %d, want %d", len(body), maxLoggedBodyBytes)
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"Password": "p\"w", "name": "n"}`, `{"Password": "***", "name": "n"}`},
+		{`{"secrets": {"a": "b"}, "n": 1}`, `{"secrets": "***", "n": 1}`},
+		{`{"token_ttl": 300}`, `{"token_ttl": "***"}`},
+		{`{"tokens": ["abc", "def"], "n": 1}`, `{"tokens": "***", "n": 1}`},
+		{`{"auth": {"user": "u", "creds": {"password": "p", "keys": [{"secret": ["s"]}]}}}`,
+			`{"auth": {"user": "u", "creds": {"password": "***", "keys": [{"secret": "***"}]}}}`},
+		{`[{"api_token" :` + "\n" + `{"a": ["b", {"c": "}"}]}, "id": 1}]`, `[{"api_token" :` + "\n" + `"***", "id": 1}]`},
+		{`{"description": "token rotation"}`, `{"description": "token rotation"}`},
+		// Truncated bodies
+		{`{"client_secret":"abc`, `{"client_secret":"***"`},
+		{`{"tokens":["abc","def"],"name":"n`, `{"tokens":"***","name":"n`},
+		{`{"tokens":["abc","de`, `{"tokens":"***"`},
+	}
+	for _, tt := range tests {
+		if got := MaskSecrets(tt.in); got != tt.want {
+			t.Errorf("MaskSecrets(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPDurationBuckets_IncludesThreshold(t *testing.T) {
+	buckets := metrics.HTTPDurationBuckets(5 * time.Second)
+	if n := len(buckets); n != len(prometheus.DefBuckets) {
+		t.Errorf("5s is a default bucket: got %d buckets, want %d", n, len(prometheus.DefBuckets))
+	}
+	buckets = metrics.HTTPDurationBuckets(3 * time.Second)
+	found := false
+	for i, b := range buckets {
+		if i > 0 && buckets[i-1] >= b {
+			t.Errorf("buckets not sorted: %v", buckets)
+		}
+		found = found || b == 3
+	}
+	if !found {
+		t.Errorf("buckets %v lack the 3s threshold", buckets)
+	}
+}
@@ -0,0 +1,253 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultEndpointTimeout applies to endpoints without an entry in the timeout map
+const DefaultEndpointTimeout = 30 * time.Second
+
+// DefaultEndpointTimeouts are the built-in limits for endpoints that wait on NDFC for longer
+// than DefaultEndpointTimeout. Keys are route paths as registered ("/api/v1/fabrics/:id/deploy")
+// or request paths, optionally prefixed by a method ("POST /api/v1/jobs"). Zero disables the
+// timeout. Entries loaded from ENDPOINT_TIMEOUTS_FILE override these.
+var DefaultEndpointTimeouts = map[string]time.Duration{
+	"/api/v1/fabrics/sync":                              10 * time.Minute,
+	"/api/v1/fabrics/:id/switches/sync":                 5 * time.Minute,
+	"/api/v1/fabrics/:id/sync-stale-switches":           5 * time.Minute,
+	"/api/v1/fabrics/:id/ports/sync":                    5 * time.Minute,
+	"/api/v1/fabrics/:id/bulk-sync-ports":               5 * time.Minute,
+	"/api/v1/fabrics/:id/switches/:switchId/ports/sync": 5 * time.Minute,
+	"/api/v1/fabrics/:id/deploy":                        5 * time.Minute,
+	"/api/v1/compute-nodes/import":                      10 * time.Minute,
+	"/api/v1/security/groups/bulk-update-selectors":     5 * time.Minute,
+	"/api/v1/security/groups/import":                    10 * time.Minute,
+	"/api/v1/jobs/:slurm_job_id/complete":               10 * time.Minute,
+	"/api/v1/jobs/:slurm_job_id/reconcile-ports":        5 * time.Minute,
+	"/api/v1/jobs/cleanup":                              30 * time.Minute,
+	"/api/v1/jobs/cleanup-expired":                      30 * time.Minute,
+	"/api/v1/compute-nodes/imports/:importId/progress":  0,                // Server-sent events until the import ends
+	provisionEndpoint:                                   60 * time.Minute, // Replaced by MAX_PROVISION_TIMEOUT_MINUTES in LoadEndpointTimeouts
+}
+
+// provisionEndpoint waits for a job's provisioning, so its timeout follows the cap on
+// per-job provisioning timeouts
+const provisionEndpoint = "POST /api/v1/jobs"
+
+// EndpointTimeouts bounds each request with a per-endpoint deadline. The request context is
+// replaced by one that is cancelled at the deadline, and the handler's response is buffered.
+// If the handler has not returned by the deadline, the client gets 504 {"error": "request
+// timeout"} at once and anything the handler writes afterwards is discarded. Like
+// http.TimeoutHandler, streaming (Flush) is not supported, so streaming endpoints should have a
+// zero timeout.
+//
+// The handler runs on its own goroutine, and the middleware waits for it to return before
+// giving the gin.Context back, so handlers that ignore their context hold the connection
+// until they finish.
+func EndpointTimeouts(defaultTimeout time.Duration, timeouts map[string]time.Duration) gin.HandlerFunc {
+	if defaultTimeout <= 0 {
+		defaultTimeout = DefaultEndpointTimeout
+	}
+	return func(c *gin.Context) {
+		timeout := endpointTimeout(c, defaultTimeout, timeouts)
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		original := c.Writer
+		tw := &timeoutWriter{ResponseWriter: original, header: make(http.Header), status: http.StatusOK}
+		c.Writer = tw
+
+		done := make(chan struct{})
+		var panicked any
+		go func() {
+			defer close(done)
+			defer func() { panicked = recover() }()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+			c.Writer = original
+			if panicked != nil {
+				panic(panicked)
+			}
+			tw.flushTo(original)
+		case <-ctx.Done():
+			tw.timeOut()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				body, _ := json.Marshal(gin.H{"error": "request timeout"})
+				original.Header().Set("Content-Type", "application/json; charset=utf-8")
+				original.WriteHeader(http.StatusGatewayTimeout)
+				_, _ = original.Write(body)
+				original.Flush()
+			}
+			<-done
+			c.Writer = original
+			c.Abort()
+			if panicked != nil {
+				panic(panicked)
+			}
+		}
+	}
+}
+
+// endpointTimeout returns the timeout for the request, looking up the route, then the request
+// path, each first with the method
+func endpointTimeout(c *gin.Context, defaultTimeout time.Duration, timeouts map[string]time.Duration) time.Duration {
+	method := c.Request.Method
+	for _, path := range []string{c.FullPath(), c.Request.URL.Path} {
+		if path == "" {
+			continue
+		}
+		if d, ok := timeouts[method+" "+path]; ok {
+			return d
+		}
+		if d, ok := timeouts[path]; ok {
+			return d
+		}
+	}
+	return defaultTimeout
+}
+
+// LoadEndpointTimeouts reads a JSON object mapping endpoints to timeouts in seconds (e.g.
+// {"/api/v1/fabrics/sync": 600, "/api/v1/jobs": 10}) and merges it over
+// DefaultEndpointTimeouts, with job submission set to provisionTimeout
+// (MAX_PROVISION_TIMEOUT_MINUTES; <= 0 keeps the default). Zero disables the timeout. An
+// empty path returns the defaults.
+func LoadEndpointTimeouts(path string, provisionTimeout time.Duration) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(DefaultEndpointTimeouts))
+	for endpoint, d := range DefaultEndpointTimeouts {
+		timeouts[endpoint] = d
+	}
+	if provisionTimeout > 0 {
+		timeouts[provisionEndpoint] = provisionTimeout
+	}
+	if path == "" {
+		return timeouts, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read endpoint timeouts: %w", err)
+	}
+	var raw map[string]float64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse endpoint timeouts %s: %w", path, err)
+	}
+	for endpoint, seconds := range raw {
+		if seconds < 0 {
+			return nil, fmt.Errorf("endpoint timeout for %s must not be negative", endpoint)
+		}
+		timeouts[endpoint] = time.Duration(seconds * float64(time.Second))
+	}
+	return timeouts, nil
+}
+
+// timeoutWriter buffers a handler's response until it returns. After timeOut, writes are
+// discarded with http.ErrHandlerTimeout.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	written  bool
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader sets the status, which like gin's writer can change until the body is written
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.written || code <= 0 {
+		return
+	}
+	w.status = code
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written = true
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.written = true
+	return w.buf.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written
+}
+
+// Flush is a no-op: the response is only sent once the handler returns
+func (w *timeoutWriter) Flush() {}
+
+func (w *timeoutWriter) timeOut() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+}
+
+// flushTo sends the buffered response to dst
+func (w *timeoutWriter) flushTo(dst gin.ResponseWriter) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	header := dst.Header()
+	for k, v := range w.header {
+		header[k] = v
+	}
+	dst.WriteHeader(w.status)
+	if w.buf.Len() > 0 {
+		_, _ = dst.Write(w.buf.Bytes())
+	} else if w.written {
+		dst.WriteHeaderNow()
+	}
+}
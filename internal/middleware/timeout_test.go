@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTimeoutRouter serves /fast, /slow (sleeping 200ms without watching its context),
+// /watch (returning when its context ends) and /sync (sleeping 100ms) behind EndpointTimeouts
+// with a 50ms default and a 500ms override for /sync
+func newTimeoutRouter(watchCanceled chan<- error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(EndpointTimeouts(50*time.Millisecond, map[string]time.Duration{"/sync/:id": 500 * time.Millisecond}))
+	r.GET("/fast", func(c *gin.Context) {
+		c.Header("X-Handler", "fast")
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+	r.GET("/slow", func(c *gin.Context) {
+		time.Sleep(200 * time.Millisecond)
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	r.GET("/watch", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		watchCanceled <- c.Request.Context().Err()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "canceled"})
+	})
+	r.POST("/sync/:id", func(c *gin.Context) {
+		time.Sleep(100 * time.Millisecond)
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestEndpointTimeouts_SlowHandlerGets504(t *testing.T) {
+	r := newTimeoutRouter(nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("code = %d, want 504", w.Code)
+	}
+	if got := w.Body.String(); got != `{"error":"request timeout"}` {
+		t.Errorf("body = %s, want the timeout error only", got)
+	}
+}
+
+func TestEndpointTimeouts_CancelsContext(t *testing.T) {
+	canceled := make(chan error, 1)
+	r := newTimeoutRouter(canceled)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/watch", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("code = %d, want 504", w.Code)
+	}
+	select {
+	case err := <-canceled:
+		if err == nil {
+			t.Error("handler context ended without an error")
+		}
+	default:
+		t.Error("handler context was not cancelled")
+	}
+}
+
+func TestEndpointTimeouts_FastAndOverridden(t *testing.T) {
+	r := newTimeoutRouter(nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusCreated || w.Header().Get("X-Handler") != "fast" || w.Body.String() != `{"ok":true}` {
+		t.Errorf("fast: %d %v %s, want the handler's response", w.Code, w.Header(), w.Body)
+	}
+
+	// The route's 500ms override outlasts the 100ms handler
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sync/fab-1", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("sync: code = %d, want 204", w.Code)
+	}
+}
+
+func TestLoadEndpointTimeouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeouts.json")
+	if err := os.WriteFile(path, []byte(`{"/api/v1/fabrics/sync": 900, "/api/v1/jobs": 10, "/stream": 0}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	timeouts, err := LoadEndpointTimeouts(path, 90*time.Minute)
+	if err != nil {
+		t.Fatalf("LoadEndpointTimeouts: %v", err)
+	}
+	if timeouts["/api/v1/fabrics/sync"] != 900*time.Second || timeouts["/api/v1/jobs"] != 10*time.Second || timeouts["/stream"] != 0 {
+		t.Errorf("file entries not applied: %v", timeouts)
+	}
+	if timeouts["/api/v1/fabrics/:id/deploy"] != DefaultEndpointTimeouts["/api/v1/fabrics/:id/deploy"] {
+		t.Error("defaults not kept")
+	}
+	if timeouts["POST /api/v1/jobs"] != 90*time.Minute {
+		t.Errorf("job submission = %v, want the 90m provisioning cap", timeouts["POST /api/v1/jobs"])
+	}
+
+	if err := os.WriteFile(path, []byte(`{"/api/v1/jobs": -1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEndpointTimeouts(path, 0); err == nil {
+		t.Error("expected an error for a negative timeout")
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"gorm.io/gorm"
@@ -40,6 +41,17 @@ type Fabric struct {
 	Switches  []Switch       `gorm:"foreignKey:FabricID" json:"switches,omitempty"`
 }
 
+// Network records a fabric network's port attachments made by provisioning, keyed by fabric
+// and network name. Rows are created on first attachment.
+type Network struct {
+	ID                     string    `gorm:"primaryKey" json:"id"`
+	FabricName             string    `gorm:"uniqueIndex:idx_network_fabric_name;not null" json:"fabric_name"`
+	Name                   string    `gorm:"uniqueIndex:idx_network_fabric_name;not null" json:"name"`
+	NetworkAttachmentCount int       `gorm:"not null;default:0" json:"network_attachment_count"` // Ports attached by active jobs
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
 // Switch represents a network switch in the fabric
 type Switch struct {
 	ID           string         `gorm:"primaryKey" json:"id"`
@@ -49,12 +61,27 @@ type Switch struct {
 	IPAddress    string         `json:"ip_address"`
 	FabricID     string         `gorm:"index;not null" json:"fabric_id"`
 	Fabric       *Fabric        `gorm:"foreignKey:FabricID" json:"fabric,omitempty"`
+	LastSyncedAt *time.Time     `gorm:"index" json:"last_synced_at,omitempty"`       // Last successful port sync from NDFC
+	Role         string         `gorm:"index" json:"role,omitempty"`                 // leaf, spine, or border
+	RoleOverride bool           `gorm:"not null;default:false" json:"role_override"` // Role set manually; NDFC sync leaves it unchanged
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 	Ports        []SwitchPort   `gorm:"foreignKey:SwitchID" json:"ports,omitempty"`
 }
 
+// Switch roles, normalized from the NDFC inventory switch role
+const (
+	SwitchRoleLeaf   = "leaf"
+	SwitchRoleSpine  = "spine"
+	SwitchRoleBorder = "border"
+)
+
+// IsValidSwitchRole reports whether role is one of the normalized switch roles
+func IsValidSwitchRole(role string) bool {
+	return role == SwitchRoleLeaf || role == SwitchRoleSpine || role == SwitchRoleBorder
+}
+
 // SwitchPort represents a port on a switch
 type SwitchPort struct {
 	ID          string         `gorm:"primaryKey" json:"id"`
@@ -63,13 +90,21 @@ type SwitchPort struct {
 	Description string         `json:"description"`
 	AdminState  string         `json:"admin_state"` // NDFC admin state: "true"=enabled, "false"=disabled
 	Speed       string         `json:"speed"`
-	IsPresent   bool           `gorm:"default:true" json:"is_present"` // false if not seen in recent sync
+	IsPresent   bool           `gorm:"default:true" json:"is_present"`   // false if not seen in recent sync
+	IsBreakout  bool           `gorm:"default:false" json:"is_breakout"` // Breakout sub-port (Ethernetx/x/x)
 	SwitchID    string         `gorm:"index;not null;uniqueIndex:idx_switch_port" json:"switch_id"`
 	Switch      *Switch        `gorm:"foreignKey:SwitchID" json:"switch,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 	LastSeenAt  *time.Time     `json:"last_seen_at,omitempty"`
+
+	// CurrentJobSlurmID is the Slurm job currently allocated this port's compute node.
+	// Computed at query time (not stored); only set by endpoints that look it up.
+	CurrentJobSlurmID *string `gorm:"-" json:"current_job_slurm_id,omitempty"`
+	// DisplayName is Name in ND_PORT_NAME_FORMAT (util.DisplayPortName). Computed at query
+	// time (not stored); only set by endpoints that return ports.
+	DisplayName string `gorm:"-" json:"display_name,omitempty"`
 }
 
 // InterfaceRole represents the role of a compute node interface
@@ -82,17 +117,65 @@ const (
 
 // ComputeNode represents a server/compute node
 type ComputeNode struct {
-	ID           string                   `gorm:"primaryKey" json:"id"`
-	Name         string                   `gorm:"uniqueIndex;not null" json:"name"`
-	Hostname     string                   `json:"hostname"`
-	IPAddress    string                   `json:"ip_address"`
-	MACAddress   string                   `json:"mac_address"`
-	Description  string                   `json:"description"`
-	CreatedAt    time.Time                `json:"created_at"`
-	UpdatedAt    time.Time                `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt           `gorm:"index" json:"-"`
-	Interfaces   []ComputeNodeInterface   `gorm:"foreignKey:ComputeNodeID" json:"interfaces,omitempty"`
-	PortMappings []ComputeNodePortMapping `gorm:"foreignKey:ComputeNodeID" json:"port_mappings,omitempty"`
+	ID            string                   `gorm:"primaryKey" json:"id"`
+	Name          string                   `gorm:"uniqueIndex:idx_compute_nodes_active_name,where:deleted_at IS NULL;not null" json:"name"` // Unique among nodes that are not deleted
+	Hostname      string                   `json:"hostname"`
+	HostnameValid bool                     `gorm:"default:true" json:"hostname_valid"` // False for hostnames stored before RFC 1123 validation
+	IPAddress     string                   `json:"ip_address"`
+	MACAddress    string                   `json:"mac_address"`
+	Description   string                   `json:"description"`
+	BMCAddress    string                   `json:"bmc_address"`                                 // Out-of-band management (IPMI/Redfish) address
+	BMCUsername   string                   `json:"bmc_username"`                                // BMC login user (password is not stored)
+	BMCPort       int                      `gorm:"default:623" json:"bmc_port"`                 // IPMI port
+	Status        string                   `gorm:"index;not null;default:active" json:"status"` // active or decommissioned
+	LastSeenAt    *time.Time               `gorm:"index" json:"last_seen_at,omitempty"`         // Last port sync that found one of the node's mapped ports
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt           `gorm:"index" json:"-"`
+	Interfaces    []ComputeNodeInterface   `gorm:"foreignKey:ComputeNodeID" json:"interfaces,omitempty"`
+	PortMappings  []ComputeNodePortMapping `gorm:"foreignKey:ComputeNodeID" json:"port_mappings,omitempty"`
+	Labels        []ComputeNodeLabel       `gorm:"foreignKey:ComputeNodeID" json:"labels,omitempty"`
+}
+
+// Compute node statuses
+const (
+	ComputeNodeStatusActive         = "active"
+	ComputeNodeStatusDecommissioned = "decommissioned" // Not seen on any port sync for ND_AUTO_DECOMMISSION_DAYS
+)
+
+// Compute node import statuses
+const (
+	NodeImportStatusPending   = "pending"
+	NodeImportStatusRunning   = "running"
+	NodeImportStatusCompleted = "completed"
+	NodeImportStatusFailed    = "failed"
+)
+
+// NodeImport tracks a bulk compute node import from CSV or YAML
+type NodeImport struct {
+	ID            string          `gorm:"primaryKey" json:"id"`
+	Status        string          `gorm:"index;not null" json:"status"` // pending, running, completed, failed
+	TotalRows     int             `json:"total_rows"`
+	ImportedCount int             `json:"imported_count"`
+	SkippedCount  int             `json:"skipped_count"` // Rows named like an existing node, left unchanged
+	UpdatedCount  int             `json:"updated_count"` // Rows that updated an existing node
+	ErrorCount    int             `json:"error_count"`
+	Errors        json.RawMessage `gorm:"type:jsonb" json:"errors,omitempty"` // Per-row failures: [{"row": 3, "name": "node-3", "error": "..."}]
+	StartedAt     *time.Time      `json:"started_at,omitempty"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// ComputeNodeLabel is a key/value scheduling hint on a compute node (e.g. gpu=a100).
+// Each key appears at most once per node.
+type ComputeNodeLabel struct {
+	ID            string    `gorm:"primaryKey" json:"id"`
+	ComputeNodeID string    `gorm:"not null;uniqueIndex:idx_node_label_key" json:"compute_node_id"`
+	Key           string    `gorm:"not null;uniqueIndex:idx_node_label_key" json:"key"`
+	Value         string    `gorm:"not null" json:"value"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // ComputeNodeInterface represents a logical interface (compute or storage) on a node
@@ -104,6 +187,7 @@ type ComputeNodeInterface struct {
 	Hostname      string                   `json:"hostname"`                                       // Per-interface hostname (optional)
 	IPAddress     string                   `json:"ip_address"`                                     // Per-interface IP (optional)
 	MACAddress    string                   `json:"mac_address"`                                    // Per-interface MAC (optional)
+	Label         string                   `json:"label"`                                          // Operator annotation (e.g., "primary 100G NIC")
 	CreatedAt     time.Time                `json:"created_at"`
 	UpdatedAt     time.Time                `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt           `gorm:"index" json:"-"`
@@ -120,10 +204,27 @@ type ComputeNodePortMapping struct {
 	SwitchPortID  string                `gorm:"index;not null" json:"switch_port_id"`
 	SwitchPort    *SwitchPort           `gorm:"foreignKey:SwitchPortID" json:"switch_port,omitempty"`
 	NICName       string                `json:"nic_name"`
-	VLAN          int                   `json:"vlan"`
+	VLAN          int                   `gorm:"not null;default:0;check:chk_port_mapping_vlan,vlan >= 0 AND vlan <= 4094" json:"vlan"` // 0 = untagged/unset
 	CreatedAt     time.Time             `json:"created_at"`
 	UpdatedAt     time.Time             `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt        `gorm:"index" json:"-"`
+
+	previous *ComputeNodePortMapping `gorm:"-"` // Persisted state captured by BeforeUpdate
+}
+
+// ComputeNodePortMappingHistory records a change to a port mapping's node, interface, or switch port.
+// Rows are written by the ComputeNodePortMapping AfterUpdate hook; creates and deletes are not recorded.
+type ComputeNodePortMappingHistory struct {
+	ID                       string    `gorm:"primaryKey" json:"id"`
+	ComputeNodePortMappingID string    `gorm:"index;not null" json:"compute_node_port_mapping_id"`
+	PreviousNodeID           string    `gorm:"index" json:"previous_node_id"`
+	NewNodeID                string    `gorm:"index" json:"new_node_id"`
+	PreviousInterfaceID      *string   `json:"previous_interface_id,omitempty"`
+	NewInterfaceID           *string   `json:"new_interface_id,omitempty"`
+	PreviousSwitchPortID     string    `gorm:"index" json:"previous_switch_port_id"`
+	NewSwitchPortID          string    `gorm:"index" json:"new_switch_port_id"`
+	ChangedAt                time.Time `gorm:"index;not null" json:"changed_at"`
+	ChangedBy                string    `json:"changed_by"` // Actor ID, or "system" for background operations
 }
 
 // SecurityGroup represents a Nexus Dashboard Security Group
@@ -165,22 +266,39 @@ type SecurityContract struct {
 	Rules       []ContractRule `gorm:"foreignKey:SecurityContractID" json:"rules,omitempty"`
 }
 
+// DefaultContractRuleDirection is the direction of contract rules stored before Direction was
+const DefaultContractRuleDirection = "bidirectional"
+
 // ContractRule represents a rule within a security contract
 type ContractRule struct {
 	ID                 string            `gorm:"primaryKey" json:"id"`
 	SecurityContractID string            `gorm:"index;not null" json:"security_contract_id"`
 	SecurityContract   *SecurityContract `gorm:"foreignKey:SecurityContractID" json:"security_contract,omitempty"`
 	Name               string            `json:"name"`
+	Direction          string            `json:"direction"`
 	Action             string            `json:"action"`
 	Protocol           string            `json:"protocol"`
 	SrcPort            string            `json:"src_port"`
 	DstPort            string            `json:"dst_port"`
 	Priority           int               `json:"priority"`
+	Sequence           int               `gorm:"not null;default:0" json:"sequence"` // Rule order within the contract (10, 20, 30, ...)
 	CreatedAt          time.Time         `json:"created_at"`
 	UpdatedAt          time.Time         `json:"updated_at"`
 	DeletedAt          gorm.DeletedAt    `gorm:"index" json:"-"`
 }
 
+// SecurityProtocol represents a Nexus Dashboard Security Protocol created through the API.
+// Protocols are per fabric, so the name is unique within its fabric.
+type SecurityProtocol struct {
+	ID         string          `gorm:"primaryKey" json:"id"`
+	Name       string          `gorm:"uniqueIndex:idx_security_protocol_fabric_name;not null" json:"name"`
+	FabricName string          `gorm:"uniqueIndex:idx_security_protocol_fabric_name;not null" json:"fabric_name"`
+	MatchType  string          `json:"match_type"`
+	Ports      json.RawMessage `gorm:"type:jsonb" json:"ports,omitempty"` // [{"min": 80, "max": 80}]
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
 // SecurityAssociation represents a Nexus Dashboard Security Association
 type SecurityAssociation struct {
 	ID                 string            `gorm:"primaryKey" json:"id"`
@@ -204,25 +322,36 @@ type SecurityAssociation struct {
 
 // Job represents a Slurm job with associated security provisioning
 type Job struct {
-	ID              string           `gorm:"primaryKey" json:"id"`
-	SlurmJobID      string           `gorm:"uniqueIndex;not null" json:"slurm_job_id"`
-	Name            string           `json:"name"`
-	TenantKey       string           `gorm:"index" json:"tenant_key,omitempty"` // Storage tenant key for tenant-specific storage access
-	Status          string           `gorm:"index;not null" json:"status"`      // pending, provisioning, active, deprovisioning, completed, failed
-	ErrorMessage    *string          `json:"error_message,omitempty"`           // Error details if status is failed
-	FabricName      string           `gorm:"not null" json:"fabric_name"`
-	VRFName         string           `json:"vrf_name"`
-	ContractName    string           `json:"contract_name"`
-	SubmittedAt     time.Time        `json:"submitted_at"`
-	ProvisionedAt   *time.Time       `json:"provisioned_at,omitempty"`
-	CompletedAt     *time.Time       `json:"completed_at,omitempty"`
-	ExpiresAt       *time.Time       `json:"expires_at,omitempty"`
-	CreatedAt       time.Time        `json:"created_at"`
-	UpdatedAt       time.Time        `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt   `gorm:"index" json:"-"`
-	ComputeNodes    []JobComputeNode `gorm:"foreignKey:JobID" json:"compute_nodes,omitempty"`
-	SecurityGroupID *string          `gorm:"index" json:"security_group_id,omitempty"`
-	SecurityGroup   *SecurityGroup   `gorm:"foreignKey:SecurityGroupID" json:"security_group,omitempty"`
+	ID                      string           `gorm:"primaryKey" json:"id"`
+	SlurmJobID              string           `gorm:"uniqueIndex;not null" json:"slurm_job_id"`
+	Name                    string           `json:"name"`
+	Description             string           `json:"description,omitempty"`             // Free-form job description, used in port descriptions
+	Tags                    json.RawMessage  `gorm:"type:jsonb" json:"tags,omitempty"`  // Operator metadata: {"project": "climate", "queue": "gpu"}
+	TenantKey               string           `gorm:"index" json:"tenant_key,omitempty"` // Storage tenant key for tenant-specific storage access
+	Status                  string           `gorm:"index;not null" json:"status"`      // pending, provisioning, active, deprovisioning, completed, failed
+	ErrorMessage            *string          `json:"error_message,omitempty"`           // Error details if status is failed
+	ExitCode                *int             `gorm:"index" json:"exit_code,omitempty"`  // Slurm exit code reported on completion
+	Signal                  *int             `json:"signal,omitempty"`                  // Signal that killed the job, reported on completion
+	FailedReason            *string          `json:"failed_reason,omitempty"`           // Slurm failure reason reported on completion
+	Version                 int              `gorm:"not null;default:0" json:"version"` // Optimistic lock counter, bumped on guarded status transitions
+	FabricName              string           `gorm:"not null" json:"fabric_name"`
+	VRFName                 string           `json:"vrf_name"`
+	NetworkName             string           `json:"network_name,omitempty"` // NDFC network the job's ports are attached to
+	ContractName            string           `json:"contract_name"`
+	ContractNames           json.RawMessage  `gorm:"type:jsonb" json:"contract_names,omitempty"`   // All contracts of the job: ["hpc-123-0-intra", "hpc-123-1-mgmt"]
+	SharedContracts         json.RawMessage  `gorm:"type:jsonb" json:"shared_contracts,omitempty"` // Shared contract associations applied at provision: [{"dst_group_name": "SG_AD", "contract_name": "matchAD"}]
+	SubmittedAt             time.Time        `json:"submitted_at"`
+	ProvisionedAt           *time.Time       `json:"provisioned_at,omitempty"`
+	CompletedAt             *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt               *time.Time       `json:"expires_at,omitempty"`
+	ProvisionTimeoutMinutes int              `json:"provision_timeout_minutes"` // Effective NDFC provisioning timeout, recorded for auditing
+	AttachedPortCount       int              `json:"attached_port_count"`       // Ports this job added to its network's attachment count
+	CreatedAt               time.Time        `json:"created_at"`
+	UpdatedAt               time.Time        `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt   `gorm:"index" json:"-"`
+	ComputeNodes            []JobComputeNode `gorm:"foreignKey:JobID" json:"compute_nodes,omitempty"`
+	SecurityGroupID         *string          `gorm:"index" json:"security_group_id,omitempty"`
+	SecurityGroup           *SecurityGroup   `gorm:"foreignKey:SecurityGroupID" json:"security_group,omitempty"`
 }
 
 // JobComputeNode links a job to the compute nodes assigned by Slurm
@@ -236,6 +365,24 @@ type JobComputeNode struct {
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// Job event phases
+const (
+	JobEventPhaseProvision   = "provision"
+	JobEventPhaseDeprovision = "deprovision"
+)
+
+// JobEvent records one step of a job's NDFC provisioning, for debugging failed provisions.
+// Steps write a "<step>_started" event and then "<step>_completed" or "<step>_failed".
+type JobEvent struct {
+	ID         string    `gorm:"primaryKey" json:"id"`
+	JobID      string    `gorm:"index;not null" json:"job_id"`
+	EventType  string    `gorm:"not null" json:"event_type"` // e.g. ndfc.sg_create_started, ndfc.attach_ports_completed
+	Phase      string    `gorm:"not null" json:"phase"`      // provision or deprovision
+	DurationMs int64     `json:"duration_ms"`                // Step duration; 0 for started events
+	Error      *string   `json:"error,omitempty"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
 // ComputeNodeAllocation tracks exclusive allocation of compute nodes to jobs.
 // The unique constraint on compute_node_id ensures only one active allocation per node.
 // This prevents race conditions in concurrent job provisioning.
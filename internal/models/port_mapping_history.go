@@ -0,0 +1,99 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SystemActor is recorded as ChangedBy when no actor is attached to the context
+// (e.g., sync worker and other background operations)
+const SystemActor = "system"
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the ID of the actor making changes.
+// Pass it to gorm via DB.WithContext so history hooks can attribute the change.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor attached by WithActor, or SystemActor if none
+func ActorFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+			return actor
+		}
+	}
+	return SystemActor
+}
+
+// BeforeUpdate captures the persisted mapping so AfterUpdate can diff against it.
+// Batch updates without a primary key (e.g., unlinking all mappings from an interface) are not tracked.
+func (m *ComputeNodePortMapping) BeforeUpdate(tx *gorm.DB) error {
+	m.previous = nil
+	if m.ID == "" {
+		return nil
+	}
+
+	var prev ComputeNodePortMapping
+	result := tx.Session(&gorm.Session{NewDB: true}).
+		Select("id", "compute_node_id", "interface_id", "switch_port_id").
+		Where("id = ?", m.ID).
+		Limit(1).
+		Find(&prev)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 1 {
+		m.previous = &prev
+	}
+	return nil
+}
+
+// AfterUpdate writes a history row if the mapping's node, interface, or switch port changed
+func (m *ComputeNodePortMapping) AfterUpdate(tx *gorm.DB) error {
+	prev := m.previous
+	m.previous = nil
+	if prev == nil {
+		return nil // Not tracked, or Save is about to fall back to an insert
+	}
+
+	entry := portMappingHistoryEntry(prev, m)
+	if entry == nil {
+		return nil
+	}
+	entry.ID = uuid.New().String()
+	entry.ChangedAt = time.Now()
+	entry.ChangedBy = ActorFromContext(tx.Statement.Context)
+
+	return tx.Session(&gorm.Session{NewDB: true}).Create(entry).Error
+}
+
+// portMappingHistoryEntry returns the history row for a change from prev to cur,
+// or nil if none of the tracked fields changed
+func portMappingHistoryEntry(prev, cur *ComputeNodePortMapping) *ComputeNodePortMappingHistory {
+	if prev.ComputeNodeID == cur.ComputeNodeID &&
+		prev.SwitchPortID == cur.SwitchPortID &&
+		equalStringPtr(prev.InterfaceID, cur.InterfaceID) {
+		return nil
+	}
+	return &ComputeNodePortMappingHistory{
+		ComputeNodePortMappingID: cur.ID,
+		PreviousNodeID:           prev.ComputeNodeID,
+		NewNodeID:                cur.ComputeNodeID,
+		PreviousInterfaceID:      prev.InterfaceID,
+		NewInterfaceID:           cur.InterfaceID,
+		PreviousSwitchPortID:     prev.SwitchPortID,
+		NewSwitchPortID:          cur.SwitchPortID,
+	}
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
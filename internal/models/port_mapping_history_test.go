@@ -0,0 +1,155 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &ComputeNodePortMapping{}, &ComputeNodePortMappingHistory{})
+	return db
+}
+
+func historyFor(t *testing.T, db *gorm.DB, mappingID string) []ComputeNodePortMappingHistory {
+	t.Helper()
+	var history []ComputeNodePortMappingHistory
+	if err := db.Where("compute_node_port_mapping_id = ?", mappingID).Order("changed_at").Find(&history).Error; err != nil {
+		t.Fatalf("load history: %v", err)
+	}
+	return history
+}
+
+func TestPortMappingHistory_NotWrittenOnCreate(t *testing.T) {
+	db := newTestDB(t)
+
+	m := ComputeNodePortMapping{ID: "m1", ComputeNodeID: "node-a", SwitchPortID: "port-1"}
+	if err := db.Create(&m).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Save of a new row falls back to an insert after an update matching no rows
+	m2 := ComputeNodePortMapping{ID: "m2", ComputeNodeID: "node-a", SwitchPortID: "port-2"}
+	if err := db.Save(&m2).Error; err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	var count int64
+	db.Model(&ComputeNodePortMappingHistory{}).Count(&count)
+	if count != 0 {
+		t.Errorf("history rows = %d, want 0", count)
+	}
+}
+
+func TestPortMappingHistory_WrittenOnUpdate(t *testing.T) {
+	db := newTestDB(t)
+
+	iface := "iface-1"
+	m := ComputeNodePortMapping{ID: "m1", ComputeNodeID: "node-a", SwitchPortID: "port-1", InterfaceID: &iface}
+	if err := db.Create(&m).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var loaded ComputeNodePortMapping
+	if err := db.First(&loaded, "id = ?", "m1").Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	loaded.ComputeNodeID = "node-b"
+	loaded.InterfaceID = nil
+	ctx := WithActor(context.Background(), "alice")
+	if err := db.WithContext(ctx).Save(&loaded).Error; err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	history := historyFor(t, db, "m1")
+	if len(history) != 1 {
+		t.Fatalf("history rows = %d, want 1", len(history))
+	}
+	h := history[0]
+	if h.PreviousNodeID != "node-a" || h.NewNodeID != "node-b" {
+		t.Errorf("node change = %q -> %q, want node-a -> node-b", h.PreviousNodeID, h.NewNodeID)
+	}
+	if h.PreviousInterfaceID == nil || *h.PreviousInterfaceID != "iface-1" || h.NewInterfaceID != nil {
+		t.Errorf("interface change = %v -> %v, want iface-1 -> nil", h.PreviousInterfaceID, h.NewInterfaceID)
+	}
+	if h.PreviousSwitchPortID != "port-1" || h.NewSwitchPortID != "port-1" {
+		t.Errorf("switch port = %q -> %q, want port-1 unchanged", h.PreviousSwitchPortID, h.NewSwitchPortID)
+	}
+	if h.ChangedBy != "alice" {
+		t.Errorf("ChangedBy = %q, want alice", h.ChangedBy)
+	}
+	if h.ChangedAt.IsZero() || h.ID == "" {
+		t.Errorf("ID/ChangedAt not set: %+v", h)
+	}
+}
+
+func TestPortMappingHistory_SystemActorAndNoOpUpdate(t *testing.T) {
+	db := newTestDB(t)
+
+	m := ComputeNodePortMapping{ID: "m1", ComputeNodeID: "node-a", SwitchPortID: "port-1"}
+	if err := db.Create(&m).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Untracked field only: no history
+	m.NICName = "eth0"
+	if err := db.Save(&m).Error; err != nil {
+		t.Fatalf("save nic: %v", err)
+	}
+	if got := historyFor(t, db, "m1"); len(got) != 0 {
+		t.Fatalf("history rows after NIC rename = %d, want 0", len(got))
+	}
+
+	m.SwitchPortID = "port-2"
+	if err := db.Save(&m).Error; err != nil {
+		t.Fatalf("save port: %v", err)
+	}
+	history := historyFor(t, db, "m1")
+	if len(history) != 1 {
+		t.Fatalf("history rows = %d, want 1", len(history))
+	}
+	if history[0].ChangedBy != SystemActor {
+		t.Errorf("ChangedBy = %q, want %q", history[0].ChangedBy, SystemActor)
+	}
+	if history[0].PreviousSwitchPortID != "port-1" || history[0].NewSwitchPortID != "port-2" {
+		t.Errorf("switch port change = %q -> %q", history[0].PreviousSwitchPortID, history[0].NewSwitchPortID)
+	}
+}
+
+func TestPortMappingHistory_BatchUpdateNotTracked(t *testing.T) {
+	db := newTestDB(t)
+
+	iface := "iface-1"
+	m := ComputeNodePortMapping{ID: "m1", ComputeNodeID: "node-a", SwitchPortID: "port-1", InterfaceID: &iface}
+	if err := db.Create(&m).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := db.Model(&ComputeNodePortMapping{}).Where("interface_id = ?", iface).Update("interface_id", nil).Error; err != nil {
+		t.Fatalf("batch update: %v", err)
+	}
+	if got := historyFor(t, db, "m1"); len(got) != 0 {
+		t.Errorf("history rows = %d, want 0", len(got))
+	}
+}
+
+func TestComputeNodePortMapping_VLANCheckConstraint(t *testing.T) {
+	db := newTestDB(t)
+	for _, tt := range []struct {
+		vlan int
+		ok   bool
+	}{{0, true}, {1, true}, {4094, true}, {4095, false}, {-1, false}} {
+		m := ComputeNodePortMapping{ID: uuid.New().String(), ComputeNodeID: "n1", SwitchPortID: uuid.New().String(), VLAN: tt.vlan}
+		err := db.Create(&m).Error
+		if tt.ok && err != nil {
+			t.Errorf("vlan %d rejected: %v", tt.vlan, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("vlan %d accepted, want check constraint violation", tt.vlan)
+		}
+	}
+}
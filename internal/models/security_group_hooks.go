@@ -0,0 +1,15 @@
+package models
+
+import "gorm.io/gorm"
+
+// BeforeDelete soft-deletes associations that reference the group as provider or
+// consumer, since they are invalid once the group is gone.
+// Batch deletes without a primary key do not cascade.
+func (g *SecurityGroup) BeforeDelete(tx *gorm.DB) error {
+	if g.ID == "" {
+		return nil
+	}
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Where("provider_group_id = ? OR consumer_group_id = ?", g.ID, g.ID).
+		Delete(&SecurityAssociation{}).Error
+}
@@ -0,0 +1,46 @@
+package models
+
+import "testing"
+
+func TestSecurityGroupBeforeDelete_CascadesAssociations(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&SecurityGroup{}, &SecurityAssociation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	groups := []SecurityGroup{
+		{ID: "g1", Name: "job-1", FabricName: "f1"},
+		{ID: "g2", Name: "job-2", FabricName: "f1"},
+		{ID: "g3", Name: "job-3", FabricName: "f1"},
+	}
+	assocs := []SecurityAssociation{
+		{ID: "a1", Name: "g1-g2", ProviderGroupID: "g1", ConsumerGroupID: "g2"},
+		{ID: "a2", Name: "g3-g1", ProviderGroupID: "g3", ConsumerGroupID: "g1"},
+		{ID: "a3", Name: "g2-g3", ProviderGroupID: "g2", ConsumerGroupID: "g3"},
+	}
+	if err := db.Create(&groups).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&assocs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Delete(&groups[0]).Error; err != nil {
+		t.Fatalf("delete group: %v", err)
+	}
+
+	var remaining []SecurityAssociation
+	if err := db.Find(&remaining).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != "a3" {
+		t.Errorf("remaining associations = %+v, want only a3", remaining)
+	}
+
+	// Soft-deleted, not removed
+	var all int64
+	db.Unscoped().Model(&SecurityAssociation{}).Count(&all)
+	if all != 3 {
+		t.Errorf("unscoped count = %d, want 3 (soft delete)", all)
+	}
+}
@@ -0,0 +1,228 @@
+package ndclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when too many consecutive NDFC requests have failed
+// and the client is refusing new requests until the recovery timeout elapses
+var ErrCircuitOpen = errors.New("NDFC circuit breaker open")
+
+// IsCircuitOpenError checks if an error is (or wraps) ErrCircuitOpen
+func IsCircuitOpenError(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
+
+// Circuit breaker defaults
+const (
+	DefaultCircuitFailureThreshold = 5                // Consecutive failures before opening
+	DefaultCircuitRecoveryTimeout  = 30 * time.Second // Time the circuit stays open before a probe request
+)
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests pass; failures are counted
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // The recovery timeout elapsed; one probe request may pass
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// MarshalText encodes the state by name, e.g. "half_open"
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CircuitBreaker tracks consecutive NDFC failures (transport errors and 502/503/504 responses).
+// Plain 500s are not counted: NDFC uses them for application errors such as
+// "deploy already in progress", which say nothing about availability.
+// After FailureThreshold failures the circuit opens and requests fail fast with ErrCircuitOpen.
+// Once RecoveryTimeout elapses the circuit is half-open: a single probe request is let
+// through while the others keep failing fast. A successful probe closes the circuit and a
+// failed one re-opens it for another RecoveryTimeout.
+type CircuitBreaker struct {
+	FailureThreshold int
+	RecoveryTimeout  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time // Zero when closed
+	probing  bool      // A half-open probe is in flight
+	now      func() time.Time
+	onChange func(open bool) // Called under the lock when the circuit opens or closes
+}
+
+// CircuitBreakerStatus is a snapshot of a CircuitBreaker
+type CircuitBreakerStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	FailureThreshold    int          `json:"failure_threshold"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`    // When the circuit last opened (not closed since)
+	HalfOpenAt          *time.Time   `json:"half_open_at,omitempty"` // When a probe is let through
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(failureThreshold int, recoveryTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		RecoveryTimeout:  recoveryTimeout,
+		now:              time.Now,
+	}
+}
+
+// state returns the current state; cb.mu must be held
+func (cb *CircuitBreaker) state() CircuitState {
+	switch {
+	case cb.openedAt.IsZero():
+		return CircuitClosed
+	case cb.now().Sub(cb.openedAt) >= cb.RecoveryTimeout:
+		return CircuitHalfOpen
+	default:
+		return CircuitOpen
+	}
+}
+
+// State returns the current state of the circuit
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state()
+}
+
+// Status returns a snapshot of the circuit
+func (cb *CircuitBreaker) Status() CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	status := CircuitBreakerStatus{
+		State:               cb.state(),
+		ConsecutiveFailures: cb.failures,
+		FailureThreshold:    cb.FailureThreshold,
+	}
+	if !cb.openedAt.IsZero() {
+		openedAt, halfOpenAt := cb.openedAt, cb.openedAt.Add(cb.RecoveryTimeout)
+		status.OpenedAt, status.HalfOpenAt = &openedAt, &halfOpenAt
+	}
+	return status
+}
+
+// allow returns ErrCircuitOpen unless a request may be sent: the circuit is closed, or it is
+// half-open and no other probe is in flight. Every allowed request must be recorded.
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state() {
+	case CircuitClosed:
+		return nil
+	case CircuitHalfOpen:
+		if !cb.probing {
+			cb.probing = true
+			return nil
+		}
+	}
+	return ErrCircuitOpen
+}
+
+// record updates the breaker from the outcome of a request
+func (cb *CircuitBreaker) record(resp *http.Response, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+	// Caller-side cancellation says nothing about NDFC health
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	failed := err != nil || (resp != nil && isUnavailableStatus(resp.StatusCode))
+	wasOpen := !cb.openedAt.IsZero()
+	if !failed {
+		cb.failures = 0
+		cb.openedAt = time.Time{}
+	} else {
+		cb.failures++
+		if cb.failures >= cb.FailureThreshold {
+			// (Re)open: also restarts the recovery timeout after a failed probe
+			cb.openedAt = cb.now()
+		}
+	}
+	// Under the lock, so concurrent transitions reach onChange in the order they happened
+	if isOpen := !cb.openedAt.IsZero(); cb.onChange != nil && isOpen != wasOpen {
+		cb.onChange(isOpen)
+	}
+}
+
+// Transport wraps base so that requests fail fast with ErrCircuitOpen while the circuit is
+// open, and the outcome of every request sent is recorded
+func (cb *CircuitBreaker) Transport(base http.RoundTripper) http.RoundTripper {
+	return &circuitBreakerTransport{base: base, breaker: cb}
+}
+
+type circuitBreakerTransport struct {
+	base    http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	t.breaker.record(resp, err)
+	return resp, err
+}
+
+// isUnavailableStatus reports whether a status code indicates NDFC (or its proxy) is unavailable
+func isUnavailableStatus(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// OnCircuitStateChange registers fn to be called with true when the circuit breaker opens
+// and false when it closes again. The circuit only closes on a successful probe after the
+// recovery timeout. Replaces any previously registered function. fn is called with the
+// breaker locked, so it must be quick and must not use the client.
+func (c *Client) OnCircuitStateChange(fn func(open bool)) {
+	if c.breaker == nil {
+		return
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	c.breaker.onChange = fn
+}
+
+// CheckNDFCAvailable returns ErrCircuitOpen if the circuit breaker is currently open.
+// Callers can use it to skip work that would fail immediately. It does not take the probe
+// of a half-open circuit.
+func (c *Client) CheckNDFCAvailable() error {
+	if c.breaker == nil || c.breaker.State() != CircuitOpen {
+		return nil
+	}
+	return ErrCircuitOpen
+}
+
+// CircuitBreaker returns the breaker guarding the client's requests to NDFC, or nil if
+// ND_CIRCUIT_BREAKER_DISABLED turned it off
+func (c *Client) CircuitBreaker() *CircuitBreaker {
+	return c.breaker
+}
@@ -0,0 +1,222 @@
+package ndclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	const maxRetries = 2
+	var calls int32
+	client := newRetryTestClient(t, maxRetries, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	for i := 0; i < DefaultCircuitFailureThreshold; i++ {
+		if err := client.Get(context.Background(), "/x", nil); IsCircuitOpenError(err) {
+			t.Fatalf("request %d: circuit opened too early", i)
+		}
+	}
+
+	if err := client.CheckNDFCAvailable(); !IsCircuitOpenError(err) {
+		t.Fatalf("CheckNDFCAvailable() = %v, want ErrCircuitOpen", err)
+	}
+
+	err := client.Get(context.Background(), "/x", nil)
+	if !IsCircuitOpenError(err) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	// Each request is retried before counting as one failure; the open circuit must not hit the server
+	if want := int32(DefaultCircuitFailureThreshold * (maxRetries + 1)); atomic.LoadInt32(&calls) != want {
+		t.Errorf("expected %d server calls, got %d", want, atomic.LoadInt32(&calls))
+	}
+}
+
+func TestCircuitBreaker_ApplicationErrorsDoNotCount(t *testing.T) {
+	for _, code := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(code)
+		}))
+
+		for i := 0; i < DefaultCircuitFailureThreshold*2; i++ {
+			_ = client.Get(context.Background(), "/x", nil)
+		}
+		if err := client.CheckNDFCAvailable(); err != nil {
+			t.Errorf("status %d should not open the circuit, got %v", code, err)
+		}
+		server.Close()
+	}
+}
+
+func TestCircuitBreaker_States(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+	expectState := func(want CircuitState) {
+		t.Helper()
+		if got := cb.State(); got != want {
+			t.Fatalf("state = %s, want %s", got, want)
+		}
+	}
+
+	// Closed: failures below the threshold still pass
+	expectState(CircuitClosed)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("closed circuit refused a request: %v", err)
+	}
+	cb.record(nil, errors.New("connection refused"))
+	expectState(CircuitClosed)
+
+	// Open after FailureThreshold consecutive failures
+	if err := cb.allow(); err != nil {
+		t.Fatal(err)
+	}
+	cb.record(nil, errors.New("connection refused"))
+	expectState(CircuitOpen)
+	if err := cb.allow(); !IsCircuitOpenError(err) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+
+	// Half-open once RecoveryTimeout elapsed: a single probe is let through
+	now = now.Add(time.Minute)
+	expectState(CircuitHalfOpen)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected half-open circuit to allow a probe, got %v", err)
+	}
+	if err := cb.allow(); !IsCircuitOpenError(err) {
+		t.Fatalf("expected a second request during the probe to fail fast, got %v", err)
+	}
+
+	// Failed probe re-opens for another RecoveryTimeout
+	cb.record(&http.Response{StatusCode: http.StatusBadGateway}, nil)
+	expectState(CircuitOpen)
+	if err := cb.allow(); !IsCircuitOpenError(err) {
+		t.Fatalf("expected re-opened circuit, got %v", err)
+	}
+
+	// Successful probe closes it
+	now = now.Add(time.Minute)
+	if err := cb.allow(); err != nil {
+		t.Fatal(err)
+	}
+	cb.record(&http.Response{StatusCode: http.StatusOK}, nil)
+	expectState(CircuitClosed)
+	if err := cb.allow(); err != nil {
+		t.Errorf("expected closed circuit after success, got %v", err)
+	}
+	if status := cb.Status(); status.ConsecutiveFailures != 0 || status.OpenedAt != nil {
+		t.Errorf("status = %+v, want no failures", status)
+	}
+}
+
+func TestCircuitBreaker_CancelledProbeFreesSlot(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(1, time.Minute)
+	cb.now = func() time.Time { return now }
+	cb.record(nil, errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	if err := cb.allow(); err != nil {
+		t.Fatal(err)
+	}
+	cb.record(nil, fmt.Errorf("do request: %w", context.Canceled))
+	if err := cb.allow(); err != nil {
+		t.Errorf("expected another probe after a cancelled one, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_Transport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+	now := time.Now()
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+	client := &http.Client{Transport: cb.Transport(http.DefaultTransport)}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		if i < 2 && err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if i == 2 && !IsCircuitOpenError(err) {
+			t.Fatalf("request %d = %v, want ErrCircuitOpen", i, err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("%d requests reached the server, want 2", n)
+	}
+}
+
+func TestCircuitBreaker_IgnoresCancellation(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute)
+	cb.record(nil, fmt.Errorf("do request: %w", context.Canceled))
+	if err := cb.allow(); err != nil {
+		t.Errorf("context cancellation should not open the circuit, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_ReportsStateChanges(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+	var changes []bool
+	cb.onChange = func(open bool) { changes = append(changes, open) }
+
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	cb.record(unavailable, nil)
+	cb.record(unavailable, nil) // Opens
+	cb.record(unavailable, nil) // Stays open: no change
+
+	now = now.Add(time.Minute)
+	cb.record(&http.Response{StatusCode: http.StatusOK}, nil) // Trial succeeds: closes
+	cb.record(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	if fmt.Sprint(changes) != "[true false]" {
+		t.Errorf("state changes = %v, want [true false]", changes)
+	}
+}
+
+func TestCircuitBreaker_ReportsConcurrentChangesInOrder(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute)
+	var changes []bool
+	cb.onChange = func(open bool) { changes = append(changes, open) }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(fail bool) {
+			defer wg.Done()
+			if fail {
+				cb.record(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+			} else {
+				cb.record(&http.Response{StatusCode: http.StatusOK}, nil)
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+
+	// Changes alternate open/closed and the last one matches the final state
+	for i, open := range changes {
+		if open != (i%2 == 0) {
+			t.Fatalf("change %d = %v, want %v: %v", i, open, i%2 == 0, changes)
+		}
+	}
+	if len(changes) > 0 && changes[len(changes)-1] != (cb.State() != CircuitClosed) {
+		t.Errorf("last change = %v, state %s", changes[len(changes)-1], cb.State())
+	}
+}
@@ -3,7 +3,6 @@ package ndclient
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,10 +10,15 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // APIError represents an HTTP error from the Nexus Dashboard API
@@ -62,6 +66,20 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsMethodNotAllowed returns true if the error is a 405 Method Not Allowed
+func (e *APIError) IsMethodNotAllowed() bool {
+	return e.StatusCode == 405
+}
+
+// IsMethodNotAllowedError checks if an error is an APIError with 405 status
+func IsMethodNotAllowedError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsMethodNotAllowed()
+	}
+	return false
+}
+
 // IsConflictError checks if an error is an APIError with 409 status
 func IsConflictError(err error) bool {
 	var apiErr *APIError
@@ -104,6 +122,16 @@ type Client struct {
 	apiKey     string // API key for X-Nd-Apikey header
 	username   string // Username for X-Nd-Username header (required with API key)
 	endpoints  Endpoints
+	breaker    *CircuitBreaker // Fails fast after repeated NDFC errors (wraps the transport; nil if disabled)
+	tracer     trace.Tracer    // Starts a client span per request, see WithTracer
+
+	// supportsDeleteWithBody is cleared the first time NDFC rejects a DELETE body with 405,
+	// after which association deletes go straight to the query-parameter form
+	supportsDeleteWithBody atomic.Bool
+
+	// supportsBulkGroupUpdate is cleared the first time NDFC rejects a batch PUT of security
+	// groups with 405, after which bulk updates go straight to per-group PUTs
+	supportsBulkGroupUpdate atomic.Bool
 
 	// Service instances (lazy initialized)
 	lanFabricService *lanfabric.Service
@@ -124,21 +152,31 @@ func NewClient(cfg *config.NexusDashboardConfig) (*Client, error) {
 		return nil, err
 	}
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: cfg.Insecure, //nolint:gosec // User-configurable for self-signed certs
-		},
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
 	}
 
+	// The breaker sees the outcome of a request after its retries; metrics count each attempt
+	var breaker *CircuitBreaker
+	var roundTripper http.RoundTripper = NewRetryTransport(&metricsTransport{base: transport}, cfg)
+	if !cfg.CircuitBreakerDisabled {
+		breaker = NewCircuitBreaker(DefaultCircuitFailureThreshold, DefaultCircuitRecoveryTimeout)
+		roundTripper = breaker.Transport(roundTripper)
+	}
 	client := &Client{
 		baseURL: cfg.BaseURL,
 		httpClient: &http.Client{
-			Transport: transport,
+			Transport: roundTripper,
 			Jar:       jar,
 			Timeout:   120 * time.Second, // ConfigDeploy can take a long time
 		},
 		endpoints: DefaultEndpoints(),
+		breaker:   breaker,
+		tracer:    otel.Tracer(tracerName),
 	}
+	client.supportsDeleteWithBody.Store(true)
+	client.supportsBulkGroupUpdate.Store(true)
 
 	// API key takes priority over username/password
 	// API key auth uses X-Nd-Apikey and X-Nd-Username headers
@@ -164,6 +202,15 @@ func (c *Client) WithEndpoints(e Endpoints) *Client {
 	return c
 }
 
+// tracerName names the tracer of NDFC request spans
+const tracerName = "github.com/banglin/go-nd/internal/ndclient"
+
+// WithTracer replaces the tracer of NDFC request spans, by default the global provider's
+func (c *Client) WithTracer(t trace.Tracer) *Client {
+	c.tracer = t
+	return c
+}
+
 type loginRequest struct {
 	UserName string `json:"userName"`
 	UserPass string `json:"userPasswd"`
@@ -221,6 +268,13 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
+	// One span per call, covering its retries; the path leaves out any query parameters
+	urlPath, _, _ := strings.Cut(path, "?")
+	ctx, span := c.tracer.Start(ctx, "NDFC "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(method), semconv.URLPath(urlPath)))
+	defer span.End()
+
 	req, err := http.NewRequestWithContext(ctx, method, c.buildURL(path), reqBody)
 	if err != nil {
 		return nil, err
@@ -237,7 +291,17 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
 }
 
 func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
@@ -283,8 +347,9 @@ func (c *Client) Put(ctx context.Context, path string, body, result interface{})
 	return decodeJSON(resp, result)
 }
 
-func (c *Client) Delete(ctx context.Context, path string) error {
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+// Delete sends a DELETE request, with body as JSON unless it is nil
+func (c *Client) Delete(ctx context.Context, path string, body interface{}) error {
+	resp, err := c.doRequest(ctx, "DELETE", path, body)
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@ const (
 	APINDFCSecurityV1        APINamespace = "ndfc.security.v1"
 	APINDFCLANFabricV1       APINamespace = "ndfc.lan-fabric.v1"
 	APINDFCImageManagementV1 APINamespace = "ndfc.imagemanagement.v1"
+	APINDFCFMV1              APINamespace = "ndfc.fm.v1"
 
 	// New ND APIs (under /api/v1/)
 	APINDRootV1   APINamespace = "nd.root.v1"   // Base for all new ND APIs
@@ -34,6 +35,7 @@ func DefaultEndpoints() Endpoints {
 			APINDFCSecurityV1:        "/appcenter/cisco/ndfc/api/v1/security",
 			APINDFCLANFabricV1:       "/appcenter/cisco/ndfc/api/v1/lan-fabric",
 			APINDFCImageManagementV1: "/appcenter/cisco/ndfc/api/v1/imagemanagement",
+			APINDFCFMV1:              "/appcenter/cisco/ndfc/api/v1/fm",
 
 			// New ND APIs
 			APINDRootV1:   "/api/v1",
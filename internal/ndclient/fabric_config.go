@@ -0,0 +1,68 @@
+package ndclient
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/ndclient/common"
+)
+
+// configStatusInSync is the config-preview status of a switch with nothing left to deploy
+const configStatusInSync = "In-Sync"
+
+// SwitchConfigStatus is one switch's entry in NDFC's config preview
+type SwitchConfigStatus struct {
+	SerialNumber string `json:"switchId"`
+	HostName     string `json:"hostName,omitempty"`
+	Status       string `json:"status"` // "In-Sync", "Out-of-Sync", ...
+}
+
+// FabricConfigState is a fabric's config-deploy state in NDFC: the config-preview status of
+// each switch and the switches whose intended configuration is not yet deployed
+type FabricConfigState struct {
+	Switches          []SwitchConfigStatus `json:"switches"`
+	OutOfSyncSwitches []string             `json:"outOfSyncSwitches"` // Serial numbers
+}
+
+// Pending reports whether the fabric may have changes a config-deploy would push. A preview
+// without switches is treated as pending, since it does not show that anything is in sync.
+func (s *FabricConfigState) Pending() bool {
+	return len(s.Switches) == 0 || len(s.OutOfSyncSwitches) > 0
+}
+
+// GetFabricConfigState retrieves the fabric's config preview, the per-switch comparison of
+// intended and running configuration that config-deploy acts on. Any switch whose status is
+// not In-Sync, including a missing status, counts as out of sync, so callers never mistake
+// an unknown state for a clean one.
+func (c *Client) GetFabricConfigState(ctx context.Context, fabricName string) (*FabricConfigState, error) {
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		return nil, err
+	}
+
+	// Build path: /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/control/fabrics/{fabricName}/config-preview
+	basePath, err := c.ndfcLanFabricPath("rest", "control", "fabrics", fabricName, "config-preview")
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	q.Set("forShowRun", "false")
+	q.Set("showBrief", "true") // Status only, without the generated configuration
+	path := common.AddQuery(basePath, q)
+
+	var switches []SwitchConfigStatus
+	if err := c.Get(ctx, path, &switches); err != nil {
+		return nil, wrapOpErr(opConfigPreview, fabricName, err)
+	}
+
+	state := &FabricConfigState{Switches: switches, OutOfSyncSwitches: []string{}}
+	for _, sw := range switches {
+		if !strings.EqualFold(sw.Status, configStatusInSync) {
+			state.OutOfSyncSwitches = append(state.OutOfSyncSwitches, sw.SerialNumber)
+		}
+	}
+	if state.Switches == nil {
+		state.Switches = []SwitchConfigStatus{}
+	}
+	return state, nil
+}
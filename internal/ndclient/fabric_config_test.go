@@ -0,0 +1,66 @@
+package ndclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFabricConfigState(t *testing.T) {
+	body := `[{"switchId":"SN1","hostName":"leaf1","status":"In-Sync"},{"switchId":"SN2","hostName":"leaf2","status":"Out-of-Sync"},{"switchId":"SN3"}]`
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/lan-fabric/rest/control/fabrics/test-fabric/config-preview") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("showBrief") != "true" {
+			t.Errorf("query = %s, want showBrief=true", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	state, err := client.GetFabricConfigState(context.Background(), "test-fabric")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A switch without a status counts as out of sync
+	if len(state.Switches) != 3 || strings.Join(state.OutOfSyncSwitches, ",") != "SN2,SN3" || !state.Pending() {
+		t.Errorf("state = %+v, want SN2 and SN3 out of sync", state)
+	}
+}
+
+func TestGetFabricConfigState_Pending(t *testing.T) {
+	for _, tt := range []struct {
+		body string
+		want bool
+	}{
+		{`[{"switchId":"SN1","status":"In-Sync"},{"switchId":"SN2","status":"in-sync"}]`, false},
+		{`[]`, true}, // Nothing shown in sync
+	} {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(tt.body))
+		})
+		client, server := newTestClient(t, handler)
+		state, err := client.GetFabricConfigState(context.Background(), "test-fabric")
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.body, err)
+		}
+		if state.Pending() != tt.want {
+			t.Errorf("%s: Pending() = %v, want %v", tt.body, state.Pending(), tt.want)
+		}
+	}
+
+	client, server := newTestClient(t, http.NotFoundHandler())
+	defer server.Close()
+	if _, err := client.GetFabricConfigState(context.Background(), ""); err == nil {
+		t.Error("expected an error for an empty fabric name")
+	}
+}
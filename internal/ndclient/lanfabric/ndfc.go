@@ -3,18 +3,29 @@ package lanfabric
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/banglin/go-nd/internal/ndclient/common"
+	"github.com/banglin/go-nd/internal/util"
 )
 
+// ErrNetworkNotFound is returned by GetNetworkVLAN when the fabric has no network with that name
+var ErrNetworkNotFound = errors.New("network not found")
+
+// ErrFabricNotFound is returned by FindFabricByNameNDFC when NDFC has no fabric with that name
+var ErrFabricNotFound = errors.New("fabric not found")
+
 // Service provides LAN fabric operations
 type Service struct {
 	client ClientInterface
+
+	fabricNames sync.Map // Fabric ID or name -> fabricNameEntry, see ResolveFabricName
 }
 
 // ClientInterface defines the methods needed from the main client
@@ -79,7 +90,7 @@ func (s *Service) FindFabricByNameNDFC(ctx context.Context, name string) (*Fabri
 			return &fabrics[i], nil
 		}
 	}
-	return nil, fmt.Errorf("fabric not found (ndfc): %q", name)
+	return nil, fmt.Errorf("%w (ndfc): %q", ErrFabricNotFound, name)
 }
 
 // GetFabricLinksNDFC retrieves all inter-switch links for a fabric from NDFC
@@ -110,13 +121,11 @@ func (s *Service) GetUplinkPortsNDFC(ctx context.Context, fabricName string) (ma
 
 	uplinks := make(map[string]bool)
 	for _, link := range links {
-		if link.Sw1Info.SerialNumber != "" && link.Sw1Info.IfName != "" {
-			key := link.Sw1Info.SerialNumber + ":" + link.Sw1Info.IfName
-			uplinks[key] = true
-		}
-		if link.Sw2Info.SerialNumber != "" && link.Sw2Info.IfName != "" {
-			key := link.Sw2Info.SerialNumber + ":" + link.Sw2Info.IfName
-			uplinks[key] = true
+		for _, end := range []FabricLinkInfo{link.Sw1Info, link.Sw2Info} {
+			if end.SerialNumber != "" && end.IfName != "" {
+				key := util.SelectorExpression{SerialNumber: end.SerialNumber, InterfaceName: end.IfName}
+				uplinks[key.String()] = true
+			}
 		}
 	}
 	return uplinks, nil
@@ -141,6 +150,46 @@ func (s *Service) GetSwitchesNDFC(ctx context.Context, fabricName string) ([]Swi
 	return switches, nil
 }
 
+// GetLLDPNeighbors retrieves the LLDP neighbors of a switch from NDFC
+// Uses: /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/control/fabrics/{fabricName}/switches/{serialNumber}/lldpNeighbors
+func (s *Service) GetLLDPNeighbors(ctx context.Context, fabricName, serialNumber string) ([]LLDPNeighbor, error) {
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		return nil, err
+	}
+	if err := common.RequireNonEmpty("serialNumber", serialNumber); err != nil {
+		return nil, err
+	}
+
+	path, err := s.client.NDFCLanFabricPath("rest", "control", "fabrics", fabricName, "switches", serialNumber, "lldpNeighbors")
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []LLDPNeighbor
+	if err := s.client.Get(ctx, path, &neighbors); err != nil {
+		return nil, fmt.Errorf("get lldp neighbors (ndfc, serial=%s): %w", serialNumber, err)
+	}
+	return neighbors, nil
+}
+
+// FindLLDPNeighbor returns the neighbor on localPort whose system name is hostname.
+// Hostnames match case-insensitively, and a short name matches its FQDN.
+func FindLLDPNeighbor(neighbors []LLDPNeighbor, localPort, hostname string) (LLDPNeighbor, bool) {
+	short := func(name string) string {
+		name, _, _ = strings.Cut(strings.TrimSpace(name), ".")
+		return name
+	}
+	if short(hostname) == "" {
+		return LLDPNeighbor{}, false
+	}
+	for _, n := range neighbors {
+		if n.LocalPort == localPort && strings.EqualFold(short(n.RemoteHostname), short(hostname)) {
+			return n, true
+		}
+	}
+	return LLDPNeighbor{}, false
+}
+
 // GetSwitchPortsNDFC retrieves all interfaces for a switch from legacy NDFC API
 // Uses: /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/interface?serialNumber=XXX
 // Returns normalized SwitchPortData
@@ -179,16 +228,34 @@ func IsLeafOrBorder(role string) bool {
 	return strings.Contains(r, "leaf") || strings.Contains(r, "tor") || strings.Contains(r, "border")
 }
 
-// ethernetIfRE matches valid Ethernet interface names:
+// breakoutIfRE matches the module/port/subport segments of a breakout port
+var breakoutIfRE = regexp.MustCompile(`^\d+/\d+/\d+$`)
+
+// IsEthernetPort returns true if the port name is a valid Ethernet interface:
 //   - Ethernetx/x (standard port, e.g., Ethernet1/1, Ethernet1/49)
 //   - Ethernetx/x/x (breakout port, e.g., Ethernet1/1/1, Ethernet1/49/4)
-var ethernetIfRE = regexp.MustCompile(`^Ethernet\d+/\d+(/\d+)?$`)
-
-// IsEthernetPort returns true if the port name is a valid Ethernet interface.
+//
 // Only accepts full "Ethernet" prefix with slot/port pattern.
 // Short forms like "Eth1/1" are NOT valid.
 func IsEthernetPort(name string) bool {
-	return ethernetIfRE.MatchString(strings.TrimSpace(name))
+	return util.IsEthernetInterface(name)
+}
+
+// IsBreakoutPort returns true if the port name is an Ethernet breakout sub-port
+// (module/port/subport, e.g., Ethernet1/49/1).
+func IsBreakoutPort(name string) bool {
+	name = strings.TrimSpace(name)
+	return IsEthernetPort(name) && breakoutIfRE.MatchString(strings.TrimPrefix(name, "Ethernet"))
+}
+
+// BreakoutParent returns the parent port of a breakout sub-port
+// (Ethernet1/49/1 -> Ethernet1/49), or "" if name is not a breakout port.
+func BreakoutParent(name string) string {
+	if !IsBreakoutPort(name) {
+		return ""
+	}
+	name = strings.TrimSpace(name)
+	return name[:strings.LastIndex(name, "/")]
 }
 
 // GetNetworksNDFC returns all networks for a fabric
@@ -222,7 +289,7 @@ func (s *Service) NetworkExists(ctx context.Context, fabricName, networkName str
 }
 
 // GetVRFsNDFC returns all VRFs for a fabric
-func (s *Service) GetVRFsNDFC(ctx context.Context, fabricName string) ([]map[string]interface{}, error) {
+func (s *Service) GetVRFsNDFC(ctx context.Context, fabricName string) ([]VRFData, error) {
 	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
 		return nil, err
 	}
@@ -230,7 +297,7 @@ func (s *Service) GetVRFsNDFC(ctx context.Context, fabricName string) ([]map[str
 	if err != nil {
 		return nil, err
 	}
-	var vrfs []map[string]interface{}
+	var vrfs []VRFData
 	if err := s.client.Get(ctx, path, &vrfs); err != nil {
 		return nil, fmt.Errorf("get vrfs (ndfc, fabric=%s): %w", fabricName, err)
 	}
@@ -244,7 +311,7 @@ func (s *Service) VRFExists(ctx context.Context, fabricName, vrfName string) (bo
 		return false, err
 	}
 	for _, v := range vrfs {
-		if name, ok := v["vrfName"].(string); ok && name == vrfName {
+		if v.VRFName == vrfName {
 			return true, nil
 		}
 	}
@@ -358,7 +425,7 @@ func (s *Service) GetNetworkVLAN(ctx context.Context, fabricName, networkName st
 		}
 	}
 
-	return "", fmt.Errorf("network %s not found in fabric %s", networkName, fabricName)
+	return "", fmt.Errorf("network %s not found in fabric %s: %w", networkName, fabricName, ErrNetworkNotFound)
 }
 
 // extractVLANFromConfig extracts the vlanId from the networkTemplateConfig JSON string
@@ -436,6 +503,28 @@ func (s *Service) AttachPortsToNetwork(ctx context.Context, fabricName, networkN
 	return nil
 }
 
+// GetNetworkAttachments returns the network's attachment on each switch of the fabric
+// GET /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/top-down/fabrics/{fabricName}/networks/{networkName}/attachments
+func (s *Service) GetNetworkAttachments(ctx context.Context, fabricName, networkName string) ([]NetworkAttachmentState, error) {
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		return nil, err
+	}
+	if err := common.RequireNonEmpty("networkName", networkName); err != nil {
+		return nil, err
+	}
+
+	path, err := s.client.NDFCLanFabricPath("rest", "top-down", "fabrics", fabricName, "networks", networkName, "attachments")
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []NetworkAttachmentState
+	if err := s.client.Get(ctx, path, &attachments); err != nil {
+		return nil, common.WrapAPIErrorWithContext("get network attachments", fmt.Sprintf("fabric=%s, network=%s", fabricName, networkName), err)
+	}
+	return attachments, nil
+}
+
 // DetachPortsFromNetwork detaches switch ports from a network
 func (s *Service) DetachPortsFromNetwork(ctx context.Context, fabricName, networkName string, attachments []NetworkAttachment) error {
 	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
@@ -3,6 +3,7 @@ package lanfabric
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -95,8 +96,8 @@ func TestGetVRFsNDFC_Success(t *testing.T) {
 	if len(vrfs) != 2 {
 		t.Fatalf("expected 2 VRFs, got %d", len(vrfs))
 	}
-	if vrfs[0]["vrfName"] != "vrf1" {
-		t.Errorf("expected vrf1, got %v", vrfs[0]["vrfName"])
+	if vrfs[0].VRFName != "vrf1" || vrfs[0].Fabric != "test-fabric" {
+		t.Errorf("expected vrf1 in test-fabric, got %+v", vrfs[0])
 	}
 }
 
@@ -332,6 +333,7 @@ func TestGetSwitchPortsNDFC_Success(t *testing.T) {
 				Interfaces: []InterfaceData{
 					{SerialNumber: "ABC123", IfName: "Ethernet1/1", NvPairs: map[string]interface{}{"ADMIN_STATE": "true"}},
 					{SerialNumber: "ABC123", IfName: "Ethernet1/2", NvPairs: map[string]interface{}{"ADMIN_STATE": "false"}},
+					{SerialNumber: "ABC123", IfName: "Ethernet1/49/1", NvPairs: map[string]interface{}{"ADMIN_STATE": "true"}},
 				},
 			},
 		}
@@ -347,12 +349,15 @@ func TestGetSwitchPortsNDFC_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(ports) != 2 {
-		t.Fatalf("expected 2 ports, got %d", len(ports))
+	if len(ports) != 3 {
+		t.Fatalf("expected 3 ports, got %d", len(ports))
 	}
 	if ports[0].Name != "Ethernet1/1" {
 		t.Errorf("expected Ethernet1/1, got %s", ports[0].Name)
 	}
+	if ports[0].IsBreakout || !ports[2].IsBreakout {
+		t.Errorf("breakout flags = %v, %v; want only Ethernet1/49/1 tagged", ports[0].IsBreakout, ports[2].IsBreakout)
+	}
 }
 
 // TestGetFabricNDFC_Success tests successful single fabric retrieval
@@ -492,6 +497,8 @@ func TestIsEthernetPort(t *testing.T) {
 		{"Ethernet1/", false},
 		{"Ethernet1/1/", false},
 		{"Ethernet1/1/1/1", false},
+		{"Ethernetfoo", false},
+		{"Ethernet1/1foo", false},
 		{"", false},
 	}
 
@@ -505,6 +512,35 @@ func TestIsEthernetPort(t *testing.T) {
 	}
 }
 
+// TestIsBreakoutPort tests breakout sub-port detection and parent lookup
+func TestIsBreakoutPort(t *testing.T) {
+	tests := []struct {
+		name       string
+		isBreakout bool
+		parent     string
+	}{
+		{"Ethernet1/49/1", true, "Ethernet1/49"},
+		{"Ethernet1/49/4", true, "Ethernet1/49"},
+		{" Ethernet2/1/2 ", true, "Ethernet2/1"},
+		{"Ethernet1/49", false, ""},
+		{"Eth1/49/1", false, ""},
+		{"Ethernet1/49/1/1", false, ""},
+		{"Ethernetfoo/1/1", false, ""},
+		{"", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBreakoutPort(tt.name); got != tt.isBreakout {
+				t.Errorf("IsBreakoutPort(%q) = %v, want %v", tt.name, got, tt.isBreakout)
+			}
+			if got := BreakoutParent(tt.name); got != tt.parent {
+				t.Errorf("BreakoutParent(%q) = %q, want %q", tt.name, got, tt.parent)
+			}
+		})
+	}
+}
+
 // TestNormalizeInterfaces tests batch interface normalization
 func TestNormalizeInterfaces(t *testing.T) {
 	interfaces := []InterfaceData{
@@ -582,8 +618,8 @@ func TestGetNetworkVLAN_NotFound(t *testing.T) {
 
 	svc := NewService(client)
 	_, err := svc.GetNetworkVLAN(context.Background(), "test-fabric", "nonexistent")
-	if err == nil {
-		t.Fatal("expected error for not found network")
+	if !errors.Is(err, ErrNetworkNotFound) {
+		t.Fatalf("expected ErrNetworkNotFound, got %v", err)
 	}
 }
 
@@ -813,3 +849,58 @@ func TestFindFabricByNameNDFC_NotFound(t *testing.T) {
 		t.Errorf("expected 'not found' in error, got: %v", err)
 	}
 }
+
+// TestGetLLDPNeighbors tests LLDP neighbor retrieval for a switch
+func TestGetLLDPNeighbors(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/rest/control/fabrics/test-fabric/switches/SN1/lldpNeighbors") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"localInterface":"Ethernet1/1","systemName":"node1","portId":"ens1f0"}]`))
+	})
+
+	client := newMockClient(t, handler)
+	defer client.Close()
+
+	svc := NewService(client)
+	neighbors, err := svc.GetLLDPNeighbors(context.Background(), "test-fabric", "SN1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := LLDPNeighbor{LocalPort: "Ethernet1/1", RemoteHostname: "node1", RemotePort: "ens1f0"}
+	if len(neighbors) != 1 || neighbors[0] != want {
+		t.Errorf("neighbors = %+v, want [%+v]", neighbors, want)
+	}
+
+	if _, err := svc.GetLLDPNeighbors(context.Background(), "test-fabric", ""); err == nil {
+		t.Error("expected error for empty serial number")
+	}
+}
+
+// TestFindLLDPNeighbor tests matching a neighbor by local port and hostname
+func TestFindLLDPNeighbor(t *testing.T) {
+	neighbors := []LLDPNeighbor{
+		{LocalPort: "Ethernet1/1", RemoteHostname: "node1.example.com", RemotePort: "ens1f0"},
+		{LocalPort: "Ethernet1/2", RemoteHostname: "node2", RemotePort: "ens1f0"},
+		{LocalPort: "Ethernet1/3", RemoteHostname: "", RemotePort: "eth0"},
+	}
+	tests := []struct {
+		port, hostname string
+		want           string
+		found          bool
+	}{
+		{"Ethernet1/1", "node1", "ens1f0", true},
+		{"Ethernet1/1", "NODE1.example.com", "ens1f0", true},
+		{"Ethernet1/1", "node2", "", false},
+		{"Ethernet1/2", "node2.example.com", "ens1f0", true},
+		{"Ethernet1/3", "", "", false},
+		{"Ethernet1/4", "node1", "", false},
+	}
+	for _, tt := range tests {
+		n, ok := FindLLDPNeighbor(neighbors, tt.port, tt.hostname)
+		if ok != tt.found || n.RemotePort != tt.want {
+			t.Errorf("FindLLDPNeighbor(%s, %q) = %q, %v; want %q, %v", tt.port, tt.hostname, n.RemotePort, ok, tt.want, tt.found)
+		}
+	}
+}
@@ -20,13 +20,15 @@ func NormalizeInterfaceName(name string) string {
 
 // NormalizeInterface converts raw InterfaceData to normalized SwitchPortData
 func NormalizeInterface(iface InterfaceData) SwitchPortData {
+	name := NormalizeInterfaceName(iface.IfName)
 	return SwitchPortData{
 		SerialNumber: iface.SerialNumber,
-		Name:         NormalizeInterfaceName(iface.IfName),
+		Name:         name,
 		Description:  common.GetString(iface.NvPairs, "DESC"),
 		Speed:        common.GetString(iface.NvPairs, "SPEED"),
 		MTU:          common.GetString(iface.NvPairs, "MTU"),
 		AdminState:   common.GetString(iface.NvPairs, "ADMIN_STATE"),
+		IsBreakout:   IsBreakoutPort(name),
 	}
 }
 
@@ -0,0 +1,51 @@
+package lanfabric
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/banglin/go-nd/internal/ndclient/common"
+)
+
+// fabricNameTTL is how long ResolveFabricName caches a resolved fabric name
+const fabricNameTTL = 10 * time.Minute
+
+type fabricNameEntry struct {
+	name    string
+	expires time.Time
+}
+
+// ResolveFabricName returns the NDFC fabric name for a fabric ID or name. All-digit input is
+// an NDFC fabric ID, looked up with GetFabricNDFC; anything else is a name, checked with
+// FindFabricByNameNDFC. Results are cached for 10 minutes; failures are not cached.
+func (s *Service) ResolveFabricName(ctx context.Context, fabricIDOrName string) (string, error) {
+	if err := common.RequireNonEmpty("fabricIDOrName", fabricIDOrName); err != nil {
+		return "", err
+	}
+	if v, ok := s.fabricNames.Load(fabricIDOrName); ok {
+		if entry := v.(fabricNameEntry); time.Now().Before(entry.expires) {
+			return entry.name, nil
+		}
+		s.fabricNames.Delete(fabricIDOrName)
+	}
+
+	var fabric *FabricData
+	var err error
+	if isFabricID(fabricIDOrName) {
+		fabric, err = s.GetFabricNDFC(ctx, fabricIDOrName)
+	} else {
+		fabric, err = s.FindFabricByNameNDFC(ctx, fabricIDOrName)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s.fabricNames.Store(fabricIDOrName, fabricNameEntry{name: fabric.FabricName, expires: time.Now().Add(fabricNameTTL)})
+	return fabric.FabricName, nil
+}
+
+// isFabricID reports whether s is all digits, i.e. an NDFC fabric ID rather than a name
+func isFabricID(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
@@ -0,0 +1,55 @@
+package lanfabric
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestResolveFabricName tests that numeric IDs are looked up with GetFabricNDFC, names with
+// FindFabricByNameNDFC, and that results are cached
+func TestResolveFabricName(t *testing.T) {
+	var byID, list int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/lan-fabric/fabrics/42"):
+			atomic.AddInt32(&byID, 1)
+			_, _ = w.Write([]byte(`{"id":42,"fabricName":"fabric-42"}`))
+		case strings.HasSuffix(r.URL.Path, "/rest/control/fabrics"):
+			atomic.AddInt32(&list, 1)
+			_, _ = w.Write([]byte(`[{"id":1,"fabricName":"fabric1"},{"id":2,"fabricName":"fabric2"}]`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client := newMockClient(t, handler)
+	defer client.Close()
+	svc := NewService(client)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		name, err := svc.ResolveFabricName(ctx, "42")
+		if err != nil || name != "fabric-42" {
+			t.Fatalf("ResolveFabricName(42) = %q, %v, want fabric-42", name, err)
+		}
+		name, err = svc.ResolveFabricName(ctx, "fabric2")
+		if err != nil || name != "fabric2" {
+			t.Fatalf("ResolveFabricName(fabric2) = %q, %v, want fabric2", name, err)
+		}
+	}
+	if byID != 1 || list != 1 {
+		t.Errorf("NDFC calls: %d by ID, %d fabric lists, want 1 each (cached after)", byID, list)
+	}
+
+	if _, err := svc.ResolveFabricName(ctx, "unknown"); !errors.Is(err, ErrFabricNotFound) {
+		t.Errorf("unknown name: err = %v, want ErrFabricNotFound", err)
+	}
+	if _, err := svc.ResolveFabricName(ctx, ""); err == nil {
+		t.Error("expected an error for an empty fabric ID")
+	}
+}
@@ -1,5 +1,7 @@
 package lanfabric
 
+import "strings"
+
 // FabricResponse wraps the fabric list response
 type FabricResponse struct {
 	Fabrics []FabricData `json:"fabrics"`
@@ -51,6 +53,7 @@ type SwitchPortData struct {
 	AdminState   string
 	Speed        string
 	MTU          string
+	IsBreakout   bool // Breakout sub-port (Ethernetx/x/x)
 }
 
 // FabricLink represents a link between switches from NDFC
@@ -70,6 +73,15 @@ type FabricLinkInfo struct {
 	SysName      string `json:"sw-sys-name"`
 }
 
+// LLDPNeighbor is a device seen over LLDP on a switch port. For compute hosts the remote
+// port is the host's NIC name.
+// GET /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/control/fabrics/{fabricName}/switches/{serialNumber}/lldpNeighbors
+type LLDPNeighbor struct {
+	LocalPort      string `json:"localInterface"` // Switch port, e.g. "Ethernet1/1"
+	RemoteHostname string `json:"systemName"`
+	RemotePort     string `json:"portId"` // e.g. "ens1f0"
+}
+
 // InterfaceUpdateRequest is the payload for updating interfaces via NDFC API
 // PUT /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/interface
 type InterfaceUpdateRequest struct {
@@ -95,6 +107,18 @@ type InterfaceDeployItem struct {
 // The API expects an array of InterfaceDeployItem
 type InterfaceDeployRequest []InterfaceDeployItem
 
+// VRFData represents a VRF from NDFC
+// GET /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/top-down/fabrics/{fabricName}/vrfs
+type VRFData struct {
+	ID          int    `json:"id"`
+	Fabric      string `json:"fabric"`
+	VRFName     string `json:"vrfName"`
+	VRFID       int    `json:"vrfId"`
+	VRFTemplate string `json:"vrfTemplate"`
+	TenantName  string `json:"tenantName"`
+	VRFStatus   string `json:"vrfStatus"`
+}
+
 // NetworkData represents a network from NDFC
 // GET /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/top-down/fabrics/{fabricName}/networks
 type NetworkData struct {
@@ -130,3 +154,27 @@ type NetworkAttachment struct {
 	TorPorts          string  `json:"torPorts"`
 	Untagged          bool    `json:"untagged"`
 }
+
+// NetworkAttachmentState is one switch's attachment to a network as NDFC reports it
+// GET /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/top-down/fabrics/{fabricName}/networks/{networkName}/attachments
+type NetworkAttachmentState struct {
+	NetworkName    string `json:"networkName"`
+	FabricName     string `json:"fabricName"`
+	SwitchName     string `json:"switchName"`
+	SerialNumber   string `json:"switchSerialNo"`
+	PortNames      string `json:"portNames"` // Comma-separated, empty when no ports are attached
+	VlanID         int    `json:"vlanId"`
+	IsLanAttached  bool   `json:"isLanAttached"`
+	LanAttachState string `json:"lanAttachState"` // e.g. DEPLOYED, PENDING, NA
+}
+
+// Ports returns the attached port names
+func (a NetworkAttachmentState) Ports() []string {
+	var ports []string
+	for _, p := range strings.Split(a.PortNames, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
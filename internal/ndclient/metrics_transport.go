@@ -0,0 +1,28 @@
+package ndclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/banglin/go-nd/internal/metrics"
+)
+
+// metricsTransport counts every HTTP request sent to NDFC, retries included, and records its
+// latency until the response headers
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	metrics.NDFCRequestDuration.WithLabelValues(req.Method).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.NDFCRequestsTotal.WithLabelValues(req.Method, code).Inc()
+	return resp, err
+}
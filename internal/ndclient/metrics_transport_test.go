@@ -0,0 +1,36 @@
+package ndclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsTransport_CountsEachAttempt(t *testing.T) {
+	calls := 0
+	client := newRetryTestClient(t, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	unavailable := testutil.ToFloat64(metrics.NDFCRequestsTotal.WithLabelValues(http.MethodDelete, "503"))
+	noContent := testutil.ToFloat64(metrics.NDFCRequestsTotal.WithLabelValues(http.MethodDelete, "204"))
+
+	if err := client.Delete(context.Background(), "/x", nil); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if n := testutil.ToFloat64(metrics.NDFCRequestsTotal.WithLabelValues(http.MethodDelete, "503")) - unavailable; n != 1 {
+		t.Errorf(`nd_ndfc_requests_total{status_code="503"} grew by %.0f, want 1`, n)
+	}
+	if n := testutil.ToFloat64(metrics.NDFCRequestsTotal.WithLabelValues(http.MethodDelete, "204")) - noContent; n != 1 {
+		t.Errorf(`nd_ndfc_requests_total{status_code="204"} grew by %.0f, want 1`, n)
+	}
+	if n := testutil.CollectAndCount(metrics.NDFCRequestDuration, "nd_ndfc_request_duration_seconds"); n == 0 {
+		t.Error("nd_ndfc_request_duration_seconds has no series")
+	}
+}
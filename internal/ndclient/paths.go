@@ -23,6 +23,16 @@ func (c *Client) ndfcLanFabricPath(parts ...string) (string, error) {
 	return joinPath(base, parts...), nil
 }
 
+// ndfcFMPath builds a path for the legacy NDFC fabric manager API
+// Example: /appcenter/cisco/ndfc/api/v1/fm/about/version
+func (c *Client) ndfcFMPath(parts ...string) (string, error) {
+	base, err := c.endpoints.base(APINDFCFMV1)
+	if err != nil {
+		return "", err
+	}
+	return joinPath(base, parts...), nil
+}
+
 // New ND path builders
 
 // ndPath builds a path for the new ND API root
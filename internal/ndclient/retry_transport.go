@@ -0,0 +1,138 @@
+package ndclient
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+)
+
+// Retry defaults for NDFC requests, used when the configured delays are zero
+const (
+	DefaultRetryBaseDelay = 200 * time.Millisecond
+	DefaultRetryMaxDelay  = 5 * time.Second
+)
+
+// RetryTransport retries requests that fail with 429 Too Many Requests or 503 Service
+// Unavailable, which NDFC answers before acting on the request. GET, HEAD, OPTIONS and DELETE
+// requests are also retried on 502/504, since the request may have reached NDFC and only
+// those methods are safe to repeat. Retry n waits BaseDelay*2^n, capped at MaxDelay (or the Retry-After of the
+// response, within the same cap), with random jitter so clients that failed together do not
+// retry together. Waiting stops when the request's context is done. Requests whose body
+// cannot be replayed are not retried. Other errors, including plain 500s, are returned as is.
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int           // Retries after the first attempt (0 = none)
+	BaseDelay  time.Duration // Wait before the first retry, doubled for each further one
+	MaxDelay   time.Duration // Longest wait between attempts
+}
+
+// NewRetryTransport wraps base with the retry settings of cfg
+func NewRetryTransport(base http.RoundTripper, cfg *config.NexusDashboardConfig) *RetryTransport {
+	t := &RetryTransport{
+		Base:       base,
+		MaxRetries: cfg.RetryMaxRetries,
+		BaseDelay:  time.Duration(cfg.RetryBaseDelayMS) * time.Millisecond,
+		MaxDelay:   time.Duration(cfg.RetryMaxDelayMS) * time.Millisecond,
+	}
+	if t.BaseDelay <= 0 {
+		t.BaseDelay = DefaultRetryBaseDelay
+	}
+	if t.MaxDelay <= 0 {
+		t.MaxDelay = DefaultRetryMaxDelay
+	}
+	return t
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	for retry := 0; retry < t.MaxRetries && replayable && err == nil && isRetryableStatus(req.Method, resp.StatusCode); retry++ {
+		metrics.NDFCRetriesTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+		delay := t.delay(retry, resp)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if next.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		resp, err = t.Base.RoundTrip(next)
+	}
+	return resp, err
+}
+
+// delay returns the jittered wait before retry number retry (0-based)
+func (t *RetryTransport) delay(retry int, resp *http.Response) time.Duration {
+	d := t.BaseDelay
+	for i := 0; i < retry && d < t.MaxDelay; i++ {
+		d *= 2
+	}
+	if after := retryAfter(resp); after > d {
+		d = after
+	}
+	if d > t.MaxDelay {
+		d = t.MaxDelay
+	}
+	// Wait between half and all of d
+	return d/2 + randomDuration(d/2)
+}
+
+// retryAfter returns the Retry-After of a response given in seconds, or 0
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// randomDuration returns a duration in [0, max] from crypto/rand
+func randomDuration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)+1))
+	if err != nil {
+		return max
+	}
+	return time.Duration(n.Int64())
+}
+
+// isRetryableStatus reports whether a response status is worth retrying for a request method:
+// NDFC throttling or being briefly unavailable for any method, a gateway error only for
+// idempotent methods
+func isRetryableStatus(method string, code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotentMethod(method)
+	}
+	return false
+}
+
+// isIdempotentMethod reports whether repeating a request with method has the same effect as
+// sending it once
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	return false
+}
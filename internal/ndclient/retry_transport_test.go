@@ -0,0 +1,179 @@
+package ndclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newRetryTestClient creates a client that retries up to maxRetries times with short delays
+func newRetryTestClient(t *testing.T, maxRetries int, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(&config.NexusDashboardConfig{
+		BaseURL:          server.URL,
+		APIKey:           "test-api-key",
+		RetryMaxRetries:  maxRetries,
+		RetryBaseDelayMS: 1,
+		RetryMaxDelayMS:  5,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test client: %v", err)
+	}
+	return client
+}
+
+func TestRetryTransport_RetriesTransientErrors(t *testing.T) {
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		var calls atomic.Int32
+		client := newRetryTestClient(t, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= 2 {
+				w.WriteHeader(code)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		}))
+		before := testutil.ToFloat64(metrics.NDFCRetriesTotal.WithLabelValues(strconv.Itoa(code)))
+
+		var out map[string]bool
+		if err := client.Get(context.Background(), "/x", &out); err != nil || !out["ok"] {
+			t.Errorf("%d: Get = %v, %v; want success after retries", code, out, err)
+		}
+		if n := calls.Load(); n != 3 {
+			t.Errorf("%d: %d attempts, want 3", code, n)
+		}
+		if retried := testutil.ToFloat64(metrics.NDFCRetriesTotal.WithLabelValues(strconv.Itoa(code))) - before; retried != 2 {
+			t.Errorf("%d: nd_ndfc_retries_total grew by %.0f, want 2", code, retried)
+		}
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryTestClient(t, 2, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	err := client.Get(context.Background(), "/x", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Get = %v, want the last 503", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("%d attempts, want 3 (1 + 2 retries)", n)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryOtherErrors(t *testing.T) {
+	for _, code := range []int{http.StatusInternalServerError, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict} {
+		var calls atomic.Int32
+		client := newRetryTestClient(t, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(code)
+		}))
+		_ = client.Get(context.Background(), "/x", nil)
+		if n := calls.Load(); n != 1 {
+			t.Errorf("%d: %d attempts, want 1", code, n)
+		}
+	}
+}
+
+func TestRetryTransport_DoesNotRetryWritesOnGatewayErrors(t *testing.T) {
+	for _, code := range []int{http.StatusBadGateway, http.StatusGatewayTimeout} {
+		var calls atomic.Int32
+		client := newRetryTestClient(t, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(code)
+		}))
+		_ = client.Post(context.Background(), "/groups", map[string]string{"groupName": "g1"}, nil)
+		_ = client.Put(context.Background(), "/groups/g1", map[string]string{"groupName": "g1"}, nil)
+		if n := calls.Load(); n != 2 {
+			t.Errorf("%d: %d attempts for one POST and one PUT, want 2", code, n)
+		}
+	}
+}
+
+func TestRetryTransport_ReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	client := newRetryTestClient(t, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if err := client.Post(context.Background(), "/groups", map[string]string{"groupName": "g1"}, nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[0] == "" {
+		t.Errorf("bodies = %q, want the same body twice", bodies)
+	}
+}
+
+func TestRetryTransport_StopsWhenContextDone(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "k", RetryMaxRetries: 5, RetryMaxDelayMS: 60000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.Get(ctx, "/x", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get returned after %s, want soon after the context deadline", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestRetryTransport_Delay(t *testing.T) {
+	tr := &RetryTransport{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	noHeader := &http.Response{Header: http.Header{}}
+
+	tests := []struct {
+		retry    int
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{0, noHeader, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, noHeader, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, noHeader, 400 * time.Millisecond, 800 * time.Millisecond},
+		{10, noHeader, 500 * time.Millisecond, time.Second},                                                  // Capped
+		{0, &http.Response{Header: http.Header{"Retry-After": {"30"}}}, 500 * time.Millisecond, time.Second}, // Retry-After, capped
+	}
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			if d := tr.delay(tt.retry, tt.resp); d < tt.min || d > tt.max {
+				t.Fatalf("delay(%d) = %s, want between %s and %s", tt.retry, d, tt.min, tt.max)
+			}
+		}
+	}
+}
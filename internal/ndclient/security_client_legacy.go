@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/banglin/go-nd/internal/ndclient/common"
@@ -22,6 +24,9 @@ func wrapOpErr(op, fabric string, err error) error {
 
 // Security Group methods
 
+// securityGroupPageSize is the number of security groups GetSecurityGroups requests per page
+const securityGroupPageSize = 50
+
 func (c *Client) CreateSecurityGroups(ctx context.Context, fabricName string, groups []SecurityGroup) ([]SecurityGroup, error) {
 	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
 		return nil, err
@@ -36,6 +41,11 @@ func (c *Client) CreateSecurityGroups(ctx context.Context, fabricName string, gr
 		if err := validateSecurityGroup(g); err != nil {
 			return nil, fmt.Errorf("groups[%d]: %w", i, err)
 		}
+		if g.GroupID != nil {
+			if err := ValidateGroupID(*g.GroupID); err != nil {
+				return nil, fmt.Errorf("groups[%d]: %w", i, err)
+			}
+		}
 		sanitized[i] = sanitizeGroupForRequest(g)
 	}
 
@@ -78,13 +88,40 @@ func (c *Client) GetSecurityGroups(ctx context.Context, fabricName string) ([]Se
 		return nil, err
 	}
 
+	// Follow pages until a short one. A controller that ignores the paging parameters returns
+	// every group each time; the repeated groups end the loop.
 	var out []SecurityGroup
-	if err := c.Get(ctx, path, &out); err != nil {
-		return nil, wrapOpErr(opGetSecGroups, fabricName, err)
+	seen := make(map[int]bool)
+	for offset := 0; ; {
+		q := url.Values{}
+		q.Set("offset", strconv.Itoa(offset))
+		q.Set("max", strconv.Itoa(securityGroupPageSize))
+
+		var page []SecurityGroup
+		if err := c.Get(ctx, common.AddQuery(path, q), &page); err != nil {
+			return nil, wrapOpErr(opGetSecGroups, fabricName, err)
+		}
+		added := 0
+		for _, g := range page {
+			if g.GroupID != nil {
+				if seen[*g.GroupID] {
+					continue
+				}
+				seen[*g.GroupID] = true
+			}
+			out = append(out, g)
+			added++
+		}
+		if len(page) != securityGroupPageSize || added == 0 {
+			return out, nil
+		}
+		offset += len(page)
 	}
-	return out, nil
 }
 
+// ErrSecurityGroupNotFound is returned by GetSecurityGroupByName when no group has the given name
+var ErrSecurityGroupNotFound = errors.New("not found")
+
 // GetSecurityGroupByName retrieves a security group by its name (not ID)
 func (c *Client) GetSecurityGroupByName(ctx context.Context, fabricName, groupName string) (*SecurityGroup, error) {
 	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
@@ -104,7 +141,7 @@ func (c *Client) GetSecurityGroupByName(ctx context.Context, fabricName, groupNa
 			return &groups[i], nil
 		}
 	}
-	return nil, fmt.Errorf("%s (fabric=%s, name=%s): not found", opGetSecGroup, fabricName, groupName)
+	return nil, fmt.Errorf("%s (fabric=%s, name=%s): %w", opGetSecGroup, fabricName, groupName, ErrSecurityGroupNotFound)
 }
 
 func (c *Client) UpdateSecurityGroups(ctx context.Context, fabricName string, groups []SecurityGroup) ([]SecurityGroup, error) {
@@ -121,9 +158,12 @@ func (c *Client) UpdateSecurityGroups(ctx context.Context, fabricName string, gr
 		if err := validateSecurityGroup(g); err != nil {
 			return nil, fmt.Errorf("groups[%d]: %w", i, err)
 		}
-		if g.GroupID == nil || *g.GroupID <= 0 {
+		if g.GroupID == nil {
 			return nil, fmt.Errorf("groups[%d]: groupID is required for update", i)
 		}
+		if err := ValidateGroupID(*g.GroupID); err != nil {
+			return nil, fmt.Errorf("groups[%d]: %w", i, err)
+		}
 
 		sanitized := sanitizeGroupForRequest(g)
 		path, err := c.secFabricPath(fabricName, "groups", fmt.Sprintf("%d", *g.GroupID))
@@ -140,12 +180,108 @@ func (c *Client) UpdateSecurityGroups(ctx context.Context, fabricName string, gr
 	return results, nil
 }
 
+// bulkGroupUpdateConcurrency bounds the per-group PUTs of a bulk selector update when NDFC
+// does not accept a batch PUT
+const bulkGroupUpdateConcurrency = 8
+
+// BulkUpdateSecurityGroupSelectors replaces the port selectors of many security groups,
+// keeping their IP, network and VM selectors as NDFC has them. A group stays attached while it
+// has any selector. It sends one batch PUT of all groups when NDFC accepts it, and otherwise
+// one PUT per group, at most bulkGroupUpdateConcurrency at a time. The returned slice has an
+// entry per update: nil if it succeeded, otherwise its error.
+func (c *Client) BulkUpdateSecurityGroupSelectors(ctx context.Context, fabricName string, updates []SGSelectorUpdate) []error {
+	errs := make([]error, len(updates))
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	// PUT replaces the whole group, so start from each group's current selectors
+	existing, err := c.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	byID := make(map[int]SecurityGroup, len(existing))
+	for _, g := range existing {
+		if g.GroupID != nil {
+			byID[*g.GroupID] = g
+		}
+	}
+
+	groups := make([]SecurityGroup, 0, len(updates))
+	index := make([]int, 0, len(updates)) // groups[j] is updates[index[j]]
+	for i, u := range updates {
+		current, ok := byID[u.GroupID]
+		if !ok {
+			errs[i] = fmt.Errorf("updates[%d]: group %d: %w", i, u.GroupID, ErrSecurityGroupNotFound)
+			continue
+		}
+		g := current
+		g.GroupID = &u.GroupID
+		g.GroupName = u.GroupName
+		g.NetworkPortSelectors = u.Selectors
+		g.Attach = len(g.IPSelectors)+len(g.NetworkSelectors)+len(g.NetworkPortSelectors)+len(g.VMInstanceUUIDSelectors) > 0
+		if err := validateSecurityGroup(g); err != nil {
+			errs[i] = fmt.Errorf("updates[%d]: %w", i, err)
+			continue
+		}
+		if err := ValidateGroupID(u.GroupID); err != nil {
+			errs[i] = fmt.Errorf("updates[%d]: %w", i, err)
+			continue
+		}
+		groups = append(groups, sanitizeGroupForRequest(g))
+		index = append(index, i)
+	}
+	if len(groups) == 0 {
+		return errs
+	}
+
+	// Some NDFC versions accept the whole list on /groups; others reject it with 405, in
+	// which case fall back to per-group PUTs from now on
+	if c.supportsBulkGroupUpdate.Load() {
+		path, err := c.secFabricPath(fabricName, "groups")
+		if err == nil {
+			var out interface{}
+			err = c.Put(ctx, path, groups, &out)
+		}
+		if !IsMethodNotAllowedError(err) {
+			if err != nil {
+				err = wrapOpErr(opUpdateSecGroups, fabricName, err)
+			}
+			for _, i := range index {
+				errs[i] = err
+			}
+			return errs
+		}
+		c.supportsBulkGroupUpdate.Store(false)
+	}
+
+	sem := make(chan struct{}, bulkGroupUpdateConcurrency)
+	var wg sync.WaitGroup
+	for j, g := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			_, errs[index[j]] = c.UpdateSecurityGroups(ctx, fabricName, []SecurityGroup{g})
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
 func (c *Client) DeleteSecurityGroup(ctx context.Context, fabricName string, groupID int) error {
 	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
 		return err
 	}
-	if groupID <= 0 {
-		return fmt.Errorf("groupID must be > 0")
+	if err := ValidateGroupID(groupID); err != nil {
+		return err
 	}
 
 	// First, detach the security group by updating with attach=false.
@@ -169,7 +305,7 @@ func (c *Client) DeleteSecurityGroup(ctx context.Context, fabricName string, gro
 	q.Set("groupId", fmt.Sprintf("%d", groupID))
 	path := common.AddQuery(basePath, q)
 
-	if err := c.Delete(ctx, path); err != nil {
+	if err := c.Delete(ctx, path, nil); err != nil {
 		return wrapOpErr(opDeleteSecGroup, fabricName, err)
 	}
 	return nil
@@ -268,7 +404,7 @@ func (c *Client) DeleteSecurityProtocol(ctx context.Context, fabricName, protoco
 		return err
 	}
 
-	if err := c.Delete(ctx, path); err != nil {
+	if err := c.Delete(ctx, path, nil); err != nil {
 		return wrapOpErr(opDeleteSecProtocol, fabricName, err)
 	}
 	return nil
@@ -396,12 +532,47 @@ func (c *Client) DeleteSecurityContract(ctx context.Context, fabricName, contrac
 	q.Set("contractName", contractName)
 	path := common.AddQuery(basePath, q)
 
-	if err := c.Delete(ctx, path); err != nil {
+	if err := c.Delete(ctx, path, nil); err != nil {
 		return wrapOpErr(opDeleteSecContract, fabricName, err)
 	}
 	return nil
 }
 
+// ErrContractExists is returned when a clone target name is already in use in NDFC
+var ErrContractExists = errors.New("security contract already exists")
+
+// CloneSecurityContract copies an existing contract's rules into a new contract.
+// The source is fetched from NDFC so the clone reflects the most current rules.
+// Returns ErrContractExists if newName is already present in the fabric.
+func (c *Client) CloneSecurityContract(ctx context.Context, srcFabricName, srcContractName, newName string) (*SecurityContract, error) {
+	if err := common.RequireNonEmpty("newName", newName); err != nil {
+		return nil, err
+	}
+	if newName == srcContractName {
+		return nil, fmt.Errorf("new contract name must differ from source %q", srcContractName)
+	}
+
+	src, err := c.GetSecurityContract(ctx, srcFabricName, srcContractName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to overwrite an existing contract with the target name
+	existing, err := c.GetSecurityContract(ctx, srcFabricName, newName)
+	if err != nil && !IsNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil && existing.ContractName != "" {
+		return nil, wrapOpErr(opCloneSecContract, srcFabricName, fmt.Errorf("%w: %q", ErrContractExists, newName))
+	}
+
+	clone := SecurityContract{
+		ContractName: newName,
+		Rules:        append([]ContractRule(nil), src.Rules...),
+	}
+	return c.CreateSecurityContract(ctx, srcFabricName, &clone)
+}
+
 // Contract Association methods
 
 func (c *Client) CreateContractAssociations(ctx context.Context, fabricName string, associations []ContractAssociation) ([]ContractAssociation, error) {
@@ -489,6 +660,25 @@ func (c *Client) DeleteSecurityAssociation(ctx context.Context, fabricName strin
 		return err
 	}
 
+	// Some NDFC versions only accept the IDs in the request body; others reject a
+	// DELETE body with 405, in which case fall back to query parameters from now on
+	if c.supportsDeleteWithBody.Load() {
+		body := []ContractAssociation{{
+			VRFName:      vrfName,
+			SrcGroupID:   &srcGroupID,
+			DstGroupID:   &dstGroupID,
+			ContractName: contractName,
+		}}
+		err := c.Delete(ctx, basePath, body)
+		if !IsMethodNotAllowedError(err) {
+			if err != nil {
+				return wrapOpErr(opDeleteSecAssociation, fabricName, err)
+			}
+			return nil
+		}
+		c.supportsDeleteWithBody.Store(false)
+	}
+
 	q := url.Values{}
 	q.Set("vrfName", vrfName)
 	q.Set("srcGroupId", fmt.Sprintf("%d", srcGroupID))
@@ -496,7 +686,7 @@ func (c *Client) DeleteSecurityAssociation(ctx context.Context, fabricName strin
 	q.Set("contractName", contractName)
 	path := common.AddQuery(basePath, q)
 
-	if err := c.Delete(ctx, path); err != nil {
+	if err := c.Delete(ctx, path, nil); err != nil {
 		return wrapOpErr(opDeleteSecAssociation, fabricName, err)
 	}
 	return nil
@@ -528,25 +718,76 @@ func (c *Client) ConfigDeploy(ctx context.Context, fabricName string, opts *Conf
 		path = common.AddQuery(path, q)
 	}
 
-	// Retry with exponential backoff if deploy is already in progress
+	return c.postRetryInProgress(ctx, opConfigDeploy, fabricName, path, struct{}{}, isDeployInProgress)
+}
+
+// SwitchDeployTarget identifies a switch in a ConfigDeployForSwitches request
+type SwitchDeployTarget struct {
+	SerialNumber string `json:"serialNumber"`
+}
+
+// ConfigDeployForSwitches deploys the fabric configuration to the given switches only.
+// Retries like ConfigDeploy if a deploy is already in progress.
+func (c *Client) ConfigDeployForSwitches(ctx context.Context, fabricName string, serialNumbers []string) error {
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		return err
+	}
+	if len(serialNumbers) == 0 {
+		return fmt.Errorf("serialNumbers is required")
+	}
+
+	path, err := c.ndfcLanFabricPath("rest", "control", "fabrics", fabricName, "config-deploy")
+	if err != nil {
+		return err
+	}
+
+	targets := make([]SwitchDeployTarget, 0, len(serialNumbers))
+	for _, serial := range serialNumbers {
+		targets = append(targets, SwitchDeployTarget{SerialNumber: serial})
+	}
+	return c.postRetryInProgress(ctx, opConfigDeploy, fabricName, path, targets, isDeployInProgress)
+}
+
+// ConfigSave saves the fabric configuration. Some NDFC versions need this before ConfigDeploy,
+// otherwise the deploy finds no changes. Retries like ConfigDeploy if a save or deploy is
+// already in progress.
+func (c *Client) ConfigSave(ctx context.Context, fabricName string) error {
+	if err := common.RequireNonEmpty("fabricName", fabricName); err != nil {
+		return err
+	}
+
+	// Build path: /appcenter/cisco/ndfc/api/v1/lan-fabric/rest/control/fabrics/{fabricName}/config-save
+	path, err := c.ndfcLanFabricPath("rest", "control", "fabrics", fabricName, "config-save")
+	if err != nil {
+		return err
+	}
+
+	return c.postRetryInProgress(ctx, opConfigSave, fabricName, path, struct{}{}, func(err error) bool {
+		return isSaveInProgress(err) || isDeployInProgress(err)
+	})
+}
+
+// postRetryInProgress POSTs body to a fabric control endpoint, retrying with exponential
+// backoff while inProgress reports that another operation is still running.
+func (c *Client) postRetryInProgress(ctx context.Context, op, fabricName, path string, body interface{}, inProgress func(error) bool) error {
 	const maxRetries = 6
 	baseDelay := 2 * time.Second
 
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		err := c.Post(ctx, path, struct{}{}, nil)
+		err := c.Post(ctx, path, body, nil)
 		if err == nil {
 			return nil
 		}
 
-		if !isDeployInProgress(err) {
-			return wrapOpErr(opConfigDeploy, fabricName, err)
+		if !inProgress(err) {
+			return wrapOpErr(op, fabricName, err)
 		}
 
 		lastErr = err
 		if attempt == maxRetries {
-			return wrapOpErr(opConfigDeploy, fabricName,
-				fmt.Errorf("deploy still in progress after %d attempts: %w", attempt, err))
+			return wrapOpErr(op, fabricName,
+				fmt.Errorf("%s still in progress after %d attempts: %w", op, attempt, err))
 		}
 
 		// Exponential backoff with cap at 30s
@@ -559,20 +800,16 @@ func (c *Client) ConfigDeploy(ctx context.Context, fabricName string, opts *Conf
 		jitter := time.Duration(int64(delay) / 5)
 		delay = delay - jitter + time.Duration(time.Now().UnixNano()%int64(2*jitter+1))
 
-		// Debug log for retry visibility
-		// logger.Debug("config-deploy already in progress; retrying",
-		//   zap.String("fabric", fabricName), zap.Int("attempt", attempt), zap.Duration("delay", delay))
-
 		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
 			timer.Stop()
-			return wrapOpErr(opConfigDeploy, fabricName, ctx.Err())
+			return wrapOpErr(op, fabricName, ctx.Err())
 		case <-timer.C:
 			// retry
 		}
 	}
-	return wrapOpErr(opConfigDeploy, fabricName, lastErr)
+	return wrapOpErr(op, fabricName, lastErr)
 }
 
 // isDeployInProgress checks if the error indicates a deploy is already in progress.
@@ -604,6 +841,17 @@ func isDeployInProgress(err error) bool {
 	return false
 }
 
+// isSaveInProgress checks if the error indicates a config save is already in progress.
+func isSaveInProgress(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	body := strings.ToLower(apiErr.BodyString(1000))
+	return strings.Contains(body, "save") && strings.Contains(body, "in progress")
+}
+
 // ConfigDeployOptions contains optional parameters for config deploy
 type ConfigDeployOptions struct {
 	// ForceShowRun: If true, config compliance tries to fetch show run if anything changed.
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -111,6 +114,12 @@ func TestCreateSecurityGroups_BatchError(t *testing.T) {
 	if batchErr.Failed != 1 {
 		t.Errorf("expected 1 failure, got %d", batchErr.Failed)
 	}
+	if batchErr.IsPartial() {
+		t.Error("expected IsPartial() = false for all-failed batch")
+	}
+	if code := batchErr.FirstFailureCode(); code != "INVALID" {
+		t.Errorf("expected first failure code 'INVALID', got '%s'", code)
+	}
 }
 
 // TestCreateSecurityGroups_HTTPError tests HTTP error handling
@@ -167,6 +176,65 @@ func TestGetSecurityGroups_Success(t *testing.T) {
 	}
 }
 
+// TestGetSecurityGroups_FollowsPages tests that every page is fetched until a short one
+func TestGetSecurityGroups_FollowsPages(t *testing.T) {
+	all := make([]SecurityGroup, 2*securityGroupPageSize+7)
+	for i := range all {
+		all[i] = SecurityGroup{GroupName: fmt.Sprintf("group%d", i), GroupID: intPtr(100 + i)}
+	}
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("max"))
+		end := min(offset+limit, len(all))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(all[offset:end])
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	groups, err := client.GetSecurityGroups(context.Background(), "test-fabric")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != len(all) {
+		t.Fatalf("expected %d groups, got %d", len(all), len(groups))
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+// TestGetSecurityGroups_UnpagedController tests a controller that ignores the paging parameters
+func TestGetSecurityGroups_UnpagedController(t *testing.T) {
+	all := make([]SecurityGroup, securityGroupPageSize)
+	for i := range all {
+		all[i] = SecurityGroup{GroupName: fmt.Sprintf("group%d", i), GroupID: intPtr(100 + i)}
+	}
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(all)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	groups, err := client.GetSecurityGroups(context.Background(), "test-fabric")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != len(all) {
+		t.Fatalf("expected %d groups, got %d", len(all), len(groups))
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
 // TestGetSecurityGroupByName_Found tests finding a group by name
 func TestGetSecurityGroupByName_Found(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -237,6 +305,51 @@ func TestConfigDeploy_Success(t *testing.T) {
 	}
 }
 
+func TestConfigSave_Success(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/fabrics/test-fabric/config-save") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	if err := client.ConfigSave(context.Background(), "test-fabric"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.ConfigSave(context.Background(), ""); err == nil {
+		t.Error("expected error for empty fabric name")
+	}
+}
+
+func TestConfigDeployForSwitches_Payload(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/fabrics/test-fabric/config-deploy") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if got := strings.TrimSpace(string(body)); got != `[{"serialNumber":"SN1"},{"serialNumber":"SN2"}]` {
+			t.Errorf("body = %s", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	if err := client.ConfigDeployForSwitches(context.Background(), "test-fabric", []string{"SN1", "SN2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.ConfigDeployForSwitches(context.Background(), "test-fabric", nil); err == nil {
+		t.Error("expected error without serial numbers")
+	}
+}
+
 // TestConfigDeploy_RetryOnInProgress tests retry logic when deploy is in progress
 func TestConfigDeploy_RetryOnInProgress(t *testing.T) {
 	var attempts int32
@@ -567,8 +680,34 @@ func TestValidation_InvalidGroupID(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for zero group ID")
 	}
-	if !strings.Contains(err.Error(), "groupID") {
-		t.Errorf("expected error about groupID, got: %v", err)
+	if !strings.Contains(err.Error(), "groupId must be in range") {
+		t.Errorf("expected error about groupId range, got: %v", err)
+	}
+}
+
+// TestValidation_OutOfRangeGroupIDNotSent tests that out-of-range group IDs never reach NDFC
+func TestValidation_OutOfRangeGroupIDNotSent(t *testing.T) {
+	var calls atomic.Int32
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	for _, id := range []int{-1, 15, 65536} {
+		if _, err := client.CreateSecurityGroups(ctx, "test-fabric", []SecurityGroup{{GroupName: "g", GroupID: intPtr(id)}}); err == nil {
+			t.Errorf("create with groupId %d: expected an error", id)
+		}
+		if _, err := client.UpdateSecurityGroups(ctx, "test-fabric", []SecurityGroup{{GroupName: "g", GroupID: intPtr(id)}}); err == nil {
+			t.Errorf("update with groupId %d: expected an error", id)
+		}
+		if err := client.DeleteSecurityGroup(ctx, "test-fabric", id); err == nil {
+			t.Errorf("delete with groupId %d: expected an error", id)
+		}
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("NDFC called %d times, want 0", n)
 	}
 }
 
@@ -718,6 +857,109 @@ func TestDeleteSecurityAssociation_Success(t *testing.T) {
 	}
 }
 
+// TestDeleteSecurityAssociation_Body tests that IDs and contract name are sent in the DELETE body
+func TestDeleteSecurityAssociation_Body(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query parameters, got %q", r.URL.RawQuery)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+
+		var body []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body) != 1 {
+			t.Fatalf("expected 1 association in body, got %d", len(body))
+		}
+		got := body[0]
+		if got["vrfName"] != "test-vrf" || got["contractName"] != "test-contract" {
+			t.Errorf("unexpected body: %v", got)
+		}
+		if got["srcGroupId"] != float64(100) || got["dstGroupId"] != float64(200) {
+			t.Errorf("unexpected group IDs in body: %v", got)
+		}
+		if _, ok := got["fabricName"]; ok {
+			t.Errorf("fabricName should come from the path, not the body: %v", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	if err := client.DeleteSecurityAssociation(context.Background(), "test-fabric", "test-vrf", 100, 200, "test-contract"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestDeleteSecurityAssociation_FallbackOn405 tests the query-parameter fallback when NDFC
+// rejects a DELETE body, and that the fallback is remembered for later calls
+func TestDeleteSecurityAssociation_FallbackOn405(t *testing.T) {
+	var bodyCalls, queryCalls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery == "" {
+			bodyCalls++
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		queryCalls++
+		q := r.URL.Query()
+		if q.Get("vrfName") != "test-vrf" || q.Get("srcGroupId") != "100" ||
+			q.Get("dstGroupId") != "200" || q.Get("contractName") != "test-contract" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := client.DeleteSecurityAssociation(context.Background(), "test-fabric", "test-vrf", 100, 200, "test-contract"); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if bodyCalls != 1 {
+		t.Errorf("expected 1 body DELETE before fallback, got %d", bodyCalls)
+	}
+	if queryCalls != 2 {
+		t.Errorf("expected 2 query DELETEs, got %d", queryCalls)
+	}
+	if client.supportsDeleteWithBody.Load() {
+		t.Error("expected supportsDeleteWithBody to be cleared after 405")
+	}
+}
+
+// TestDeleteSecurityAssociation_BodyErrorNoFallback tests that non-405 errors are returned without retrying
+func TestDeleteSecurityAssociation_BodyErrorNoFallback(t *testing.T) {
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	err := client.DeleteSecurityAssociation(context.Background(), "test-fabric", "test-vrf", 100, 200, "test-contract")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+	if !client.supportsDeleteWithBody.Load() {
+		t.Error("supportsDeleteWithBody should stay set for non-405 errors")
+	}
+}
+
 // TestDeleteSecurityProtocol_Success tests successful protocol deletion
 func TestDeleteSecurityProtocol_Success(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -817,6 +1059,128 @@ func TestBatchError_FailureSummary(t *testing.T) {
 	}
 }
 
+// TestParseBatchResponseError tests item-level vs batch-level failure parsing
+func TestParseBatchResponseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        BatchResponse
+		wantNil     bool
+		wantPartial bool
+		wantCode    string
+		wantMessage string
+		wantItems   int
+	}{
+		{
+			name:    "success",
+			resp:    BatchResponse{TotalCount: 2, SuccessCount: 2, Code: "200"},
+			wantNil: true,
+		},
+		{
+			name: "all failures with items",
+			resp: BatchResponse{
+				TotalCount: 2, FailedCount: 2, Code: "207", Message: "batch failed",
+				FailureList: []BatchItem{
+					{Name: "g1", Code: "DUPLICATE", Message: "exists"},
+					{Name: "g2", Code: "INVALID", Message: "bad name"},
+				},
+			},
+			wantCode:    "DUPLICATE",
+			wantMessage: "batch failed",
+			wantItems:   2,
+		},
+		{
+			name: "partial success",
+			resp: BatchResponse{
+				TotalCount: 3, SuccessCount: 2, FailedCount: 1,
+				FailureList: []BatchItem{{Name: "g3", Code: "INVALID", Message: "bad name"}},
+			},
+			wantPartial: true,
+			wantCode:    "INVALID",
+			wantItems:   1,
+		},
+		{
+			name:        "failed count without failure list uses top-level message",
+			resp:        BatchResponse{TotalCount: 1, FailedCount: 1, Code: "500", Message: "internal error"},
+			wantCode:    "500",
+			wantMessage: "internal error",
+		},
+		{
+			name:        "batch-level error code only",
+			resp:        BatchResponse{TotalCount: 1, Code: "ERROR", Message: "fabric locked"},
+			wantCode:    "ERROR",
+			wantMessage: "fabric locked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ParseBatchResponseError("create groups", "test-fabric", tt.resp)
+			if tt.wantNil {
+				if e != nil {
+					t.Fatalf("expected nil, got %v", e)
+				}
+				if err := batchErr("create groups", "test-fabric", tt.resp); err != nil {
+					t.Fatalf("expected batchErr to return untyped nil, got %v", err)
+				}
+				return
+			}
+			if e == nil {
+				t.Fatal("expected BatchError, got nil")
+			}
+			if got := e.IsPartial(); got != tt.wantPartial {
+				t.Errorf("IsPartial() = %v, want %v", got, tt.wantPartial)
+			}
+			if got := e.FirstFailureCode(); got != tt.wantCode {
+				t.Errorf("FirstFailureCode() = %q, want %q", got, tt.wantCode)
+			}
+			if e.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", e.Message, tt.wantMessage)
+			}
+			if len(e.Failures) != tt.wantItems {
+				t.Errorf("len(Failures) = %d, want %d", len(e.Failures), tt.wantItems)
+			}
+		})
+	}
+}
+
+// TestCreateSecurityGroups_PartialSuccess tests that partial batch failures surface as BatchError
+func TestCreateSecurityGroups_PartialSuccess(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := BatchResponseGroups{
+			BatchResponse: BatchResponse{
+				TotalCount:   2,
+				SuccessCount: 1,
+				FailedCount:  1,
+				FailureList: []BatchItem{
+					{Name: "bad-group", Code: "DUPLICATE", Message: "group id in use"},
+				},
+			},
+			SuccessList: []SecurityGroup{{GroupName: "good-group", GroupID: intPtr(100)}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	_, err := client.CreateSecurityGroups(context.Background(), "test-fabric", []SecurityGroup{
+		{GroupName: "good-group", GroupID: intPtr(100)},
+		{GroupName: "bad-group", GroupID: intPtr(100)},
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %T: %v", err, err)
+	}
+	if !batchErr.IsPartial() {
+		t.Error("expected IsPartial() = true")
+	}
+	if code := batchErr.FirstFailureCode(); code != "DUPLICATE" {
+		t.Errorf("expected first failure code 'DUPLICATE', got '%s'", code)
+	}
+}
+
 // TestCreateSecurityProtocols_Success tests successful protocol creation
 func TestCreateSecurityProtocols_Success(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -842,3 +1206,200 @@ func TestCreateSecurityProtocols_Success(t *testing.T) {
 		t.Fatalf("expected 1 protocol, got %d", len(protocols))
 	}
 }
+
+// TestCloneSecurityContract_CopiesRules tests that a cloned contract carries all source rules
+func TestCloneSecurityContract_CopiesRules(t *testing.T) {
+	srcRules := []ContractRule{
+		{Direction: "bidirectional", Action: "permit", ProtocolName: "icmp"},
+		{Direction: "unidirectional", Action: "permit", ProtocolName: "ssh"},
+		{Direction: "bidirectional", Action: "deny", ProtocolName: "default"},
+	}
+
+	var created []SecurityContract
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/contracts/job-v1"):
+			_ = json.NewEncoder(w).Encode(SecurityContract{ContractName: "job-v1", Rules: srcRules})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/contracts/job-v2"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/contracts"):
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(BatchResponseContracts{
+				BatchResponse: BatchResponse{TotalCount: 1, SuccessCount: 1},
+				SuccessList:   created,
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	clone, err := client.CloneSecurityContract(context.Background(), "test-fabric", "job-v1", "job-v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.ContractName != "job-v2" {
+		t.Errorf("expected contract name 'job-v2', got '%s'", clone.ContractName)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected 1 contract in create request, got %d", len(created))
+	}
+	if len(created[0].Rules) != len(srcRules) {
+		t.Fatalf("expected %d rules, got %d", len(srcRules), len(created[0].Rules))
+	}
+	for i, r := range created[0].Rules {
+		if r != srcRules[i] {
+			t.Errorf("rule %d: expected %+v, got %+v", i, srcRules[i], r)
+		}
+	}
+}
+
+// TestCloneSecurityContract_TargetExists tests that cloning onto an existing name fails
+func TestCloneSecurityContract_TargetExists(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(SecurityContract{
+			ContractName: name,
+			Rules:        []ContractRule{{Direction: "bidirectional", Action: "permit"}},
+		})
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	_, err := client.CloneSecurityContract(context.Background(), "test-fabric", "job-v1", "job-v2")
+	if !errors.Is(err, ErrContractExists) {
+		t.Fatalf("expected ErrContractExists, got %v", err)
+	}
+}
+
+// TestCloneSecurityContract_SameName tests that the clone name must differ from the source
+func TestCloneSecurityContract_SameName(t *testing.T) {
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	if _, err := client.CloneSecurityContract(context.Background(), "test-fabric", "job-v1", "job-v1"); err == nil {
+		t.Fatal("expected error for identical names")
+	}
+}
+
+// TestBulkUpdateSecurityGroupSelectors_Batch tests that all groups go out in one PUT to /groups
+func TestBulkUpdateSecurityGroupSelectors_Batch(t *testing.T) {
+	var calls int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"groupId":101,"groupName":"sg-a","attach":true,
+				"ipSelectors":[{"type":"External Subnets","ip":"10.0.0.0/24","vrfName":"vrf1"}]},
+				{"groupId":102,"groupName":"sg-b","attach":true,
+				"networkPortSelectors":[{"network":"net","switchId":"FDO1","interfaceName":"Ethernet1/2"}]}]`))
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		if r.Method != "PUT" || !strings.HasSuffix(r.URL.Path, "/security/fabrics/test-fabric/groups") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var groups []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&groups); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 groups, got %d", len(groups))
+		}
+		if groups[0]["groupId"] != float64(101) || groups[0]["groupName"] != "sg-a" || groups[0]["attach"] != true {
+			t.Errorf("unexpected first group: %v", groups[0])
+		}
+		if sel, _ := groups[0]["networkPortSelectors"].([]interface{}); len(sel) != 1 {
+			t.Errorf("expected 1 port selector, got %v", groups[0]["networkPortSelectors"])
+		}
+		if sel, _ := groups[0]["ipSelectors"].([]interface{}); len(sel) != 1 {
+			t.Errorf("expected the IP selector kept, got %v", groups[0]["ipSelectors"])
+		}
+		if groups[1]["attach"] != false || groups[1]["networkPortSelectors"] != nil {
+			t.Errorf("group without selectors should be detached: %v", groups[1])
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	errs := client.BulkUpdateSecurityGroupSelectors(context.Background(), "test-fabric", []SGSelectorUpdate{
+		{GroupID: 101, GroupName: "sg-a", Selectors: []NetworkPortSelector{{Network: "net", SwitchID: "FDO1", InterfaceName: "Ethernet1/1"}}},
+		{GroupID: 102, GroupName: "sg-b"},
+		{GroupName: "sg-no-id"},
+		{GroupID: 103, GroupName: "sg-missing"},
+	})
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs[2] == nil {
+		t.Error("expected error for update without group ID")
+	}
+	if !errors.Is(errs[3], ErrSecurityGroupNotFound) {
+		t.Errorf("unknown group: %v, want ErrSecurityGroupNotFound", errs[3])
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}
+
+// TestBulkUpdateSecurityGroupSelectors_FallbackOn405 tests per-group PUTs when the batch PUT is rejected
+func TestBulkUpdateSecurityGroupSelectors_FallbackOn405(t *testing.T) {
+	var batchCalls, groupCalls int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"groupId":101,"groupName":"sg-a"},{"groupId":102,"groupName":"sg-b"}]`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/groups") {
+			atomic.AddInt32(&batchCalls, 1)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		atomic.AddInt32(&groupCalls, 1)
+		if strings.HasSuffix(r.URL.Path, "/groups/102") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	updates := []SGSelectorUpdate{
+		{GroupID: 101, GroupName: "sg-a"},
+		{GroupID: 102, GroupName: "sg-b"},
+	}
+	for round := 0; round < 2; round++ {
+		errs := client.BulkUpdateSecurityGroupSelectors(context.Background(), "test-fabric", updates)
+		if errs[0] != nil || errs[1] == nil {
+			t.Errorf("round %d: errs = %v, want only sg-b to fail", round, errs)
+		}
+	}
+	if batchCalls != 1 {
+		t.Errorf("expected 1 batch PUT, got %d", batchCalls)
+	}
+	if groupCalls != 4 {
+		t.Errorf("expected 4 per-group PUTs, got %d", groupCalls)
+	}
+	if client.supportsBulkGroupUpdate.Load() {
+		t.Error("expected supportsBulkGroupUpdate to be cleared after 405")
+	}
+}
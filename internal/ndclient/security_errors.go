@@ -26,6 +26,7 @@ const (
 	opGetSecContract     = "get security contract"
 	opUpdateSecContract  = "update security contract"
 	opDeleteSecContract  = "delete security contract"
+	opCloneSecContract   = "clone security contract"
 
 	// Contract Associations
 	opCreateSecAssociations = "create contract associations"
@@ -33,7 +34,9 @@ const (
 	opDeleteSecAssociation  = "delete contract association"
 
 	// Fabric Operations
-	opConfigDeploy = "config deploy"
+	opConfigDeploy  = "config deploy"
+	opConfigSave    = "config save"
+	opConfigPreview = "get config preview"
 )
 
 // BatchError represents a batch operation failure with full details
@@ -86,6 +89,17 @@ func (e *BatchError) IsPartial() bool {
 	return e.Failed > 0 && e.Failed < e.Total
 }
 
+// FirstFailureCode returns the code of the first item-level failure,
+// falling back to the batch-level code when there are no item failures
+func (e *BatchError) FirstFailureCode() string {
+	for _, f := range e.Failures {
+		if f.Code != "" {
+			return f.Code
+		}
+	}
+	return e.Code
+}
+
 // IsAllFailed returns true if all items in the batch failed
 func (e *BatchError) IsAllFailed() bool {
 	return e.Failed == e.Total && e.Total > 0
@@ -142,18 +156,32 @@ func isBatchError(out BatchResponse) bool {
 	return false
 }
 
-// batchErr returns a BatchError if the batch operation had failures
-func batchErr(op, fabric string, out BatchResponse) error {
-	if !isBatchError(out) {
+// ParseBatchResponseError builds a BatchError from an NDFC batch response.
+// Returns nil if the response has no failures.
+//
+// Item-level failures populate Failures. The top-level Code and Message are kept
+// alongside them, and describe the failure alone when the batch failed without a
+// FailureList (or reported a non-success code).
+func ParseBatchResponseError(op, fabric string, resp BatchResponse) *BatchError {
+	if !isBatchError(resp) {
 		return nil
 	}
+
 	return &BatchError{
 		Op:       op,
 		Fabric:   fabric,
-		Failed:   out.FailedCount,
-		Total:    out.TotalCount,
-		Code:     out.Code,
-		Message:  out.Message,
-		Failures: out.FailureList,
+		Failed:   resp.FailedCount,
+		Total:    resp.TotalCount,
+		Code:     resp.Code,
+		Message:  resp.Message,
+		Failures: resp.FailureList,
+	}
+}
+
+// batchErr returns a BatchError if the batch operation had failures
+func batchErr(op, fabric string, out BatchResponse) error {
+	if e := ParseBatchResponseError(op, fabric, out); e != nil {
+		return e
 	}
+	return nil
 }
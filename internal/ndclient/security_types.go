@@ -39,6 +39,14 @@ type NetworkPortSelector struct {
 	InterfaceName string `json:"interfaceName"` // Must be full format (e.g., "Ethernet1/5", not "Eth1/5")
 }
 
+// SGSelectorUpdate replaces the network port selectors of an existing security group.
+// The group is attached if it has selectors and detached if the list is empty.
+type SGSelectorUpdate struct {
+	GroupID   int                   `json:"groupId"`
+	GroupName string                `json:"groupName"`
+	Selectors []NetworkPortSelector `json:"networkPortSelectors"`
+}
+
 type VMInstanceUUIDSelector struct {
 	VCenter        string `json:"vCenter,omitempty"`
 	VMUUID         string `json:"vmUUID,omitempty"`
@@ -3,8 +3,26 @@ package ndclient
 import (
 	"fmt"
 	"strings"
+
+	"github.com/banglin/go-nd/internal/metrics"
+)
+
+// Range of security group IDs NDFC accepts
+const (
+	MinGroupID = 16
+	MaxGroupID = 65535
 )
 
+// ValidateGroupID checks that id is in the range NDFC accepts for groupId, which otherwise
+// rejects it with an unhelpful error. Rejected IDs are counted in nd_invalid_group_id_total.
+func ValidateGroupID(id int) error {
+	if id < MinGroupID || id > MaxGroupID {
+		metrics.InvalidGroupIDTotal.Inc()
+		return fmt.Errorf("groupId must be in range [%d, %d], got %d", MinGroupID, MaxGroupID, id)
+	}
+	return nil
+}
+
 // validateSecurityGroup validates required fields on a SecurityGroup before sending to NDFC
 func validateSecurityGroup(g SecurityGroup) error {
 	if strings.TrimSpace(g.GroupName) == "" {
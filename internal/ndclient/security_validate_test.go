@@ -1,7 +1,11 @@
 package ndclient
 
 import (
+	"fmt"
 	"testing"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestValidateContractAssociation_Valid_WithIDs(t *testing.T) {
@@ -206,3 +210,33 @@ func TestSanitizeAssociationForRequest(t *testing.T) {
 		t.Errorf("expected vrfName preserved, got %s", sanitized.VRFName)
 	}
 }
+
+func TestValidateGroupID(t *testing.T) {
+	tests := []struct {
+		id    int
+		valid bool
+	}{
+		{-1, false},
+		{-65536, false},
+		{0, false},
+		{15, false},
+		{16, true},
+		{65535, true},
+		{65536, false},
+	}
+	for _, tt := range tests {
+		before := testutil.ToFloat64(metrics.InvalidGroupIDTotal)
+		err := ValidateGroupID(tt.id)
+		counted := testutil.ToFloat64(metrics.InvalidGroupIDTotal) - before
+		if tt.valid {
+			if err != nil || counted != 0 {
+				t.Errorf("ValidateGroupID(%d) = %v (counted %.0f), want valid", tt.id, err, counted)
+			}
+			continue
+		}
+		want := fmt.Sprintf("groupId must be in range [16, 65535], got %d", tt.id)
+		if err == nil || err.Error() != want || counted != 1 {
+			t.Errorf("ValidateGroupID(%d) = %v (counted %.0f), want %q counted once", tt.id, err, counted, want)
+		}
+	}
+}
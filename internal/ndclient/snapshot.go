@@ -0,0 +1,89 @@
+package ndclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SecuritySnapshotVersion is the format version written by MarshalSecuritySnapshot
+const SecuritySnapshotVersion = 1
+
+// SecuritySnapshot is the security configuration of a fabric in the NDFC batch create format:
+// each list is a valid request body for the groups, contracts and contractAssociations
+// endpoints of another fabric.
+type SecuritySnapshot struct {
+	Version      int                   `json:"version"`
+	SourceFabric string                `json:"sourceFabric,omitempty"`
+	Groups       []SecurityGroup       `json:"groups"`
+	Contracts    []SecurityContract    `json:"contracts"`
+	Associations []ContractAssociation `json:"contractAssociations"`
+}
+
+// MarshalSecuritySnapshot formats the security groups, contracts and associations of
+// sourceFabric as an indented SecuritySnapshot document. Fabric names are cleared from the
+// objects so they can be created in any fabric, and each list is sorted for stable output.
+func MarshalSecuritySnapshot(sourceFabric string, groups []SecurityGroup, contracts []SecurityContract, associations []ContractAssociation) ([]byte, error) {
+	snapshot := SecuritySnapshot{
+		Version:      SecuritySnapshotVersion,
+		SourceFabric: sourceFabric,
+		Groups:       make([]SecurityGroup, len(groups)),
+		Contracts:    make([]SecurityContract, len(contracts)),
+		Associations: make([]ContractAssociation, len(associations)),
+	}
+	for i, g := range groups {
+		snapshot.Groups[i] = sanitizeGroupForRequest(g)
+	}
+	for i, c := range contracts {
+		snapshot.Contracts[i] = sanitizeContractForRequest(c)
+	}
+	for i, a := range associations {
+		snapshot.Associations[i] = sanitizeAssociationForRequest(a)
+	}
+
+	sort.Slice(snapshot.Groups, func(i, j int) bool { return snapshot.Groups[i].GroupName < snapshot.Groups[j].GroupName })
+	sort.Slice(snapshot.Contracts, func(i, j int) bool {
+		return snapshot.Contracts[i].ContractName < snapshot.Contracts[j].ContractName
+	})
+	sort.Slice(snapshot.Associations, func(i, j int) bool {
+		a, b := snapshot.Associations[i], snapshot.Associations[j]
+		if a.SrcGroupName != b.SrcGroupName {
+			return a.SrcGroupName < b.SrcGroupName
+		}
+		if a.DstGroupName != b.DstGroupName {
+			return a.DstGroupName < b.DstGroupName
+		}
+		return a.ContractName < b.ContractName
+	})
+
+	return json.MarshalIndent(snapshot, "", "  ")
+}
+
+// UnmarshalSecuritySnapshot parses a document written by MarshalSecuritySnapshot and
+// validates every object in it as CreateSecurityGroups, CreateSecurityContracts and
+// CreateContractAssociations would
+func UnmarshalSecuritySnapshot(data []byte) (*SecuritySnapshot, error) {
+	var snapshot SecuritySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parse security snapshot: %w", err)
+	}
+	if snapshot.Version != SecuritySnapshotVersion {
+		return nil, fmt.Errorf("unsupported security snapshot version %d (expected %d)", snapshot.Version, SecuritySnapshotVersion)
+	}
+	for i, g := range snapshot.Groups {
+		if err := validateSecurityGroup(g); err != nil {
+			return nil, fmt.Errorf("groups[%d]: %w", i, err)
+		}
+	}
+	for i, c := range snapshot.Contracts {
+		if err := validateSecurityContract(c); err != nil {
+			return nil, fmt.Errorf("contracts[%d]: %w", i, err)
+		}
+	}
+	for i, a := range snapshot.Associations {
+		if err := validateContractAssociation(a); err != nil {
+			return nil, fmt.Errorf("contractAssociations[%d]: %w", i, err)
+		}
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,46 @@
+package ndclient
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecuritySnapshot_MarshalUnmarshal(t *testing.T) {
+	id := 7
+	data, err := MarshalSecuritySnapshot("fab1",
+		[]SecurityGroup{{FabricName: "fab1", GroupID: &id, GroupName: "web"}, {FabricName: "fab1", GroupName: "app"}},
+		[]SecurityContract{{ContractName: "permit-all"}},
+		[]ContractAssociation{{FabricName: "fab1", VRFName: "vrf1", SrcGroupName: "web", DstGroupName: "app", ContractName: "permit-all"}},
+	)
+	if err != nil {
+		t.Fatalf("MarshalSecuritySnapshot: %v", err)
+	}
+	if strings.Contains(string(data), `"fabricName"`) {
+		t.Errorf("fabric names not cleared:\n%s", data)
+	}
+
+	snapshot, err := UnmarshalSecuritySnapshot(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSecuritySnapshot: %v", err)
+	}
+	if snapshot.SourceFabric != "fab1" || len(snapshot.Groups) != 2 || len(snapshot.Contracts) != 1 || len(snapshot.Associations) != 1 {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+	if snapshot.Groups[0].GroupName != "app" || snapshot.Groups[1].GroupID == nil || *snapshot.Groups[1].GroupID != 7 {
+		t.Errorf("groups = %+v, want sorted by name with IDs kept", snapshot.Groups)
+	}
+}
+
+func TestUnmarshalSecuritySnapshot_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":                     `{`,
+		"unknown version":              `{"version": 2}`,
+		"group without name":           `{"version": 1, "groups": [{"groupName": ""}]}`,
+		"association without contract": `{"version": 1, "contractAssociations": [{"vrfName": "v", "srcGroupName": "a", "dstGroupName": "b"}]}`,
+	}
+	for name, data := range tests {
+		if _, err := UnmarshalSecuritySnapshot([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ndclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestClient_RequestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter)))
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	client.WithTracer(provider.Tracer("test"))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_ = client.Get(ctx, "/fabrics/f1?detail=true", nil)
+	_ = client.Post(ctx, "/fabrics/f1/groups", map[string]string{"groupName": "g1"}, nil)
+	_ = client.Put(ctx, "/fabrics/f1/groups/1", map[string]string{"groupName": "g1"}, nil)
+	_ = client.Delete(ctx, "/fabrics/f1/groups/1", nil)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	want := []struct {
+		name, method, path string
+		status             int64
+	}{
+		{"NDFC GET", "GET", "/fabrics/f1", 200},
+		{"NDFC POST", "POST", "/fabrics/f1/groups", 200},
+		{"NDFC PUT", "PUT", "/fabrics/f1/groups/1", 200},
+		{"NDFC DELETE", "DELETE", "/fabrics/f1/groups/1", 404},
+	}
+	if len(spans) != len(want)+1 {
+		t.Fatalf("%d spans, want %d requests and the parent", len(spans), len(want))
+	}
+	for i, w := range want {
+		span := spans[i]
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes {
+			attrs[kv.Key] = kv.Value
+		}
+		if span.Name != w.name || span.SpanKind != trace.SpanKindClient ||
+			attrs["http.request.method"].AsString() != w.method ||
+			attrs["url.path"].AsString() != w.path ||
+			attrs["http.response.status_code"].AsInt64() != w.status {
+			t.Errorf("span %d = %s %v %v, want %s %s %s %d", i, span.Name, span.SpanKind, span.Attributes, w.name, w.method, w.path, w.status)
+		}
+		if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the caller's span", span.Name)
+		}
+		if wantError := w.status >= 400; (span.Status.Code == codes.Error) != wantError {
+			t.Errorf("span %s status = %v, want error %v", span.Name, span.Status, wantError)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package ndclient
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+)
+
+// tlsVersions maps ND_TLS_MIN_VERSION values to TLS versions
+var tlsVersions = map[string]uint16{
+	"TLS12": tls.VersionTLS12,
+	"TLS13": tls.VersionTLS13,
+}
+
+// parseTLSMinVersion parses "TLS12" or "TLS13" (case-insensitive). Empty returns 0, leaving
+// the Go default (TLS 1.2).
+func parseTLSMinVersion(s string) (uint16, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, ok := tlsVersions[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS min version %q (want TLS12 or TLS13)", s)
+	}
+	return v, nil
+}
+
+// NewTransport builds the HTTP transport for NDFC requests from the proxy, TLS and
+// connection pool settings of cfg. Without HTTPProxy, the HTTPS_PROXY/NO_PROXY environment
+// is used.
+func NewTransport(cfg *config.NexusDashboardConfig) (*http.Transport, error) {
+	minVersion, err := parseTLSMinVersion(cfg.TLSMinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.HTTPProxy != "" {
+		proxyURL, err := url.Parse(cfg.HTTPProxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid HTTP proxy URL %q", cfg.HTTPProxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         minVersion,
+		InsecureSkipVerify: cfg.Insecure, //nolint:gosec // User-configurable for self-signed certs
+	}
+	if cfg.IdleConnTimeoutSeconds > 0 {
+		transport.IdleConnTimeout = time.Duration(cfg.IdleConnTimeoutSeconds) * time.Second
+	}
+	if cfg.ResponseHeaderTimeoutSeconds > 0 {
+		transport.ResponseHeaderTimeout = time.Duration(cfg.ResponseHeaderTimeoutSeconds) * time.Second
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	return transport, nil
+}
+
+// ValidateTransportConfig checks the NDFC HTTP client settings at startup. Skipping TLS
+// verification is only allowed in debug mode.
+func ValidateTransportConfig(cfg *config.NexusDashboardConfig, serverMode string) error {
+	if cfg.Insecure && serverMode != "debug" {
+		return errors.New("ND_INSECURE=true is only allowed with GIN_MODE=debug")
+	}
+	_, err := NewTransport(cfg)
+	return err
+}
@@ -0,0 +1,84 @@
+package ndclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+)
+
+func TestNewTransport_AppliesSettings(t *testing.T) {
+	transport, err := NewTransport(&config.NexusDashboardConfig{
+		HTTPProxy:                    "http://proxy.example.com:3128",
+		TLSMinVersion:                "tls13",
+		Insecure:                     true,
+		IdleConnTimeoutSeconds:       45,
+		ResponseHeaderTimeoutSeconds: 20,
+		MaxIdleConnsPerHost:          7,
+	})
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://nd.example.com/login", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.String() != "http://proxy.example.com:3128" {
+		t.Errorf("proxy = %v (%v), want http://proxy.example.com:3128", proxyURL, err)
+	}
+	if v := transport.TLSClientConfig.MinVersion; v != tls.VersionTLS13 {
+		t.Errorf("MinVersion = %x, want TLS 1.3", v)
+	}
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = false with Insecure set")
+	}
+	if transport.IdleConnTimeout != 45*time.Second || transport.ResponseHeaderTimeout != 20*time.Second ||
+		transport.MaxIdleConnsPerHost != 7 {
+		t.Errorf("pool settings = %v/%v/%d, want 45s/20s/7",
+			transport.IdleConnTimeout, transport.ResponseHeaderTimeout, transport.MaxIdleConnsPerHost)
+	}
+}
+
+func TestNewTransport_ProxyFromEnvironment(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:8080")
+	transport, err := NewTransport(&config.NexusDashboardConfig{TLSMinVersion: "TLS12"})
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+	if v := transport.TLSClientConfig.MinVersion; v != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %x, want TLS 1.2", v)
+	}
+	if transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = true without Insecure")
+	}
+	// http.ProxyFromEnvironment caches the environment, so only check the function is used
+	if transport.Proxy == nil {
+		t.Error("Proxy = nil, want http.ProxyFromEnvironment")
+	}
+}
+
+func TestNewTransport_InvalidSettings(t *testing.T) {
+	for _, cfg := range []config.NexusDashboardConfig{
+		{TLSMinVersion: "TLS10"},
+		{HTTPProxy: "proxy.example.com"},
+	} {
+		if _, err := NewTransport(&cfg); err == nil {
+			t.Errorf("NewTransport(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestValidateTransportConfig_InsecureOnlyInDebug(t *testing.T) {
+	cfg := &config.NexusDashboardConfig{Insecure: true}
+	if err := ValidateTransportConfig(cfg, "debug"); err != nil {
+		t.Errorf("debug mode: %v", err)
+	}
+	if err := ValidateTransportConfig(cfg, "release"); err == nil {
+		t.Error("Insecure accepted in release mode")
+	}
+	cfg.Insecure = false
+	if err := ValidateTransportConfig(cfg, "release"); err != nil {
+		t.Errorf("secure release config: %v", err)
+	}
+}
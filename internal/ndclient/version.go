@@ -0,0 +1,35 @@
+package ndclient
+
+import (
+	"context"
+	"errors"
+)
+
+// ContractProtocolMatchAll is the contract rule protocol name matching all traffic
+const ContractProtocolMatchAll = "default"
+
+// NDFCVersion is the NDFC "about" version response
+type NDFCVersion struct {
+	Version       string `json:"version"`
+	Mode          string `json:"mode,omitempty"`
+	IsMediaDevice string `json:"isMediaDevice,omitempty"`
+	IsHaEnabled   string `json:"isHaEnabled,omitempty"`
+}
+
+// DetectAPIVersion returns the NDFC software version (e.g. "12.2.2").
+// A successful call also confirms NDFC is reachable and the credentials are accepted.
+func (c *Client) DetectAPIVersion(ctx context.Context) (string, error) {
+	path, err := c.ndfcFMPath("about", "version")
+	if err != nil {
+		return "", err
+	}
+
+	var v NDFCVersion
+	if err := c.Get(ctx, path, &v); err != nil {
+		return "", err
+	}
+	if v.Version == "" {
+		return "", errors.New("NDFC version response has no version")
+	}
+	return v.Version, nil
+}
@@ -1,64 +1,134 @@
 package router
 
 import (
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/handlers"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/middleware"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/services"
+	"github.com/banglin/go-nd/internal/sync"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 )
 
-func Setup(ndClient *ndclient.Client, cfg *config.Config) *gin.Engine {
+func Setup(ndClient *ndclient.Client, cfg *config.Config, registry *services.Registry) *gin.Engine {
 	r := gin.Default()
 
+	// Warn about slow requests (typically waiting on NDFC) and record request durations
+	slowThreshold := time.Duration(cfg.Server.SlowRequestThresholdMS) * time.Millisecond
+	r.Use(middleware.SlowRequests(logger.L(), middleware.SlowRequestConfig{
+		Threshold: slowThreshold,
+		LogBodies: cfg.Server.LogSlowRequestBodies,
+	}, metrics.HTTPRequestDuration))
+
+	// Bound request durations per endpoint (sync and provisioning endpoints get longer)
+	endpointTimeouts, err := middleware.LoadEndpointTimeouts(cfg.Server.EndpointTimeoutsFile,
+		time.Duration(cfg.Server.MaxProvisionTimeoutMinutes)*time.Minute)
+	if err != nil {
+		logger.Fatal("Invalid ENDPOINT_TIMEOUTS_FILE", zap.Error(err))
+	}
+	r.Use(middleware.EndpointTimeouts(time.Duration(cfg.Server.EndpointTimeoutDefaultSec)*time.Second, endpointTimeouts))
+
 	// CORS middleware for frontend development
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"},
+		AllowOrigins:     middleware.CORSOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", middleware.IdempotencyKeyHeader},
 		AllowCredentials: true,
 	}))
 
 	// Initialize services
-	storageService := services.NewStorageService(database.DB, ndClient, &cfg.NexusDashboard)
+	storageService := services.NewStorageService(database.DB, ndClient, &cfg.NexusDashboard, registry)
 
 	// Initialize handlers
-	fabricHandler := handlers.NewFabricHandler(ndClient)
-	computeHandler := handlers.NewComputeHandler(storageService)
+	uplinkCache := sync.NewUplinkCache(cache.Default(), time.Duration(cfg.NexusDashboard.UplinkCacheTTLMinutes)*time.Minute)
+	fabricHandler := handlers.NewFabricHandler(ndClient, uplinkCache)
+	fabricHandler.SetMaxPortsPerNetwork(cfg.NexusDashboard.MaxPortsPerNetwork)
+	fabricHandler.SetPortNameFormat(cfg.NexusDashboard.PortNameFormat)
+	bmcService := services.NewBMCService(cfg.NexusDashboard.BMCPowerCycleCmd,
+		time.Duration(cfg.NexusDashboard.BMCPowerCycleTimeoutSec)*time.Second)
+	computeHandler := handlers.NewComputeHandler(storageService, bmcService)
+	computeHandler.SetNDClient(ndClient)
 	interfaceHandler := handlers.NewInterfaceHandler(storageService)
 	securityHandler := handlers.NewSecurityHandler(ndClient)
-	jobHandler := handlers.NewJobHandler(database.DB, ndClient, &cfg.NexusDashboard)
+	securityHandler.SetStorageService(storageService)
+	jobHandler := handlers.NewJobHandler(database.DB, ndClient, &cfg.NexusDashboard, registry)
+	jobHandler.SetMaxProvisionTimeout(time.Duration(cfg.Server.MaxProvisionTimeoutMinutes) * time.Minute)
+	jobHandler.SetInstanceID(cfg.Server.InstanceID)
+	jobHandler.SetRetentionDays(cfg.Server.RetentionDays)
+	if ndClient != nil {
+		fabricHandler.SetDeployer(jobHandler.DeployBatcher())
+		interfaceHandler.SetDeployer(jobHandler.DeployBatcher())
+	}
 	storageTenantHandler := handlers.NewStorageTenantHandler()
+	reportHandler := handlers.NewReportHandler()
+	adminHandler := handlers.NewAdminHandler(cfg.NexusDashboard.ComputeFabricName)
 
 	// Health check
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	r.GET("/health", handlers.Health)
+
+	// Prometheus metrics. With METRICS_PORT they are served by a separate listener instead
+	// (metrics.NewServer).
+	if cfg.Server.MetricsPort == "" {
+		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+
+	// Admin routes
+	admin := r.Group("/admin")
+	{
+		admin.GET("/sync-leader", adminHandler.GetSyncLeader)
+	}
 
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
+		// Configured NDFC objects (fabrics, compute VRF, networks) exist
+		v1.GET("/health/ndfc-config", jobHandler.NDFCConfigHealth)
+		// NDFC circuit breaker state
+		v1.GET("/health/ndfc", handlers.NDFCHealth(ndClient))
+
 		// Fabric routes (new API for querying)
 		fabrics := v1.Group("/fabrics")
 		{
 			fabrics.GET("", fabricHandler.GetFabrics)
 			fabrics.GET("/:id", fabricHandler.GetFabric)
 			fabrics.POST("", fabricHandler.CreateFabric)
+			fabrics.DELETE("/:id", fabricHandler.DeleteFabric)
 			fabrics.POST("/sync", fabricHandler.SyncFabrics)
+			fabrics.POST("/:id/deploy", fabricHandler.DeployFabric)
+			fabrics.GET("/:id/config-state", fabricHandler.GetConfigState)
 
 			// Switch routes
 			fabrics.GET("/:id/switches", fabricHandler.GetSwitches)
 			fabrics.POST("/:id/switches", fabricHandler.CreateSwitch)
 			fabrics.GET("/:id/switches/:switchId", fabricHandler.GetSwitch)
+			fabrics.PUT("/:id/switches/:switchId", fabricHandler.UpdateSwitch)
+			fabrics.PUT("/:id/switches/:switchId/role", fabricHandler.SetSwitchRole)
+			fabrics.GET("/:id/switch-roles", fabricHandler.GetSwitchRoleSummary)
 			fabrics.POST("/:id/switches/sync", fabricHandler.SyncSwitches)
+			fabrics.POST("/:id/sync-stale-switches", fabricHandler.SyncStaleSwitches)
 
 			// Network routes
 			fabrics.GET("/:id/networks", fabricHandler.GetNetworks)
+			fabrics.GET("/:id/networks/:networkName/attachment-count", fabricHandler.GetNetworkAttachmentCount)
+			fabrics.GET("/:id/networks/:networkName/vlan", fabricHandler.GetNetworkVLAN)
+			fabrics.GET("/:id/port-attachments", fabricHandler.GetPortAttachments)
+			fabrics.GET("/:id/vrfs", fabricHandler.GetVRFs)
+			fabrics.GET("/:id/vrfs/:vrfName/exists", fabricHandler.GetVRFExists)
 
 			// Switch port routes
+			fabrics.GET("/:id/ports", fabricHandler.SearchFabricPorts)  // Search ports across all switches
 			fabrics.POST("/:id/ports/sync", fabricHandler.SyncAllPorts) // Sync all ports in fabric
+			fabrics.POST("/:id/bulk-sync-ports", fabricHandler.BulkSyncPorts)
+			fabrics.GET("/:id/port-history", fabricHandler.GetPortHistory)
 			fabrics.GET("/:id/switches/:switchId/ports", fabricHandler.GetSwitchPorts)
 			fabrics.GET("/:id/switches/:switchId/ports/:portId", fabricHandler.GetSwitchPort)
 			fabrics.POST("/:id/switches/:switchId/ports", fabricHandler.CreateSwitchPort)
@@ -72,19 +142,43 @@ func Setup(ndClient *ndclient.Client, cfg *config.Config) *gin.Engine {
 			compute.GET("", computeHandler.GetComputeNodes)
 			compute.GET("/:id", computeHandler.GetComputeNode)
 			compute.POST("", computeHandler.CreateComputeNode)
+			compute.POST("/connectivity-check", computeHandler.BulkCheckConnectivity)
+
+			// Bulk import from CSV or YAML (?async=true runs in the background)
+			compute.POST("/import", computeHandler.ImportComputeNodes)
+			compute.GET("/imports/:importId", computeHandler.GetImport)
+			compute.GET("/imports/:importId/progress", computeHandler.StreamImportProgress)
+
 			compute.PUT("/:id", computeHandler.UpdateComputeNode)
 			compute.DELETE("/:id", computeHandler.DeleteComputeNode)
+			compute.POST("/:id/restore", computeHandler.RestoreComputeNode)
+
+			// Connectivity check routes (TCP handshake to SSH port)
+			compute.GET("/:id/connectivity-check", computeHandler.GetConnectivityCheck)
+			compute.POST("/:id/connectivity-check", computeHandler.CheckConnectivity)
+
+			// BMC routes (power-cycle runs ND_BMC_POWER_CYCLE_CMD)
+			compute.GET("/:id/bmc", computeHandler.GetBMC)
+			compute.POST("/:id/bmc/power-cycle", computeHandler.PowerCycleBMC)
 
 			// Port mapping routes
 			compute.GET("/:id/port-mappings", computeHandler.GetPortMappings)
 			compute.POST("/:id/port-mappings", computeHandler.AddPortMapping)
 			compute.PUT("/:id/port-mappings/:mappingId", computeHandler.UpdatePortMapping)
 			compute.DELETE("/:id/port-mappings/:mappingId", computeHandler.DeletePortMapping)
+			compute.GET("/:id/port-history", computeHandler.GetPortHistory)
+
+			// Label routes (scheduling hints, e.g. gpu=a100)
+			compute.GET("/:id/labels", computeHandler.GetLabels)
+			compute.POST("/:id/labels", computeHandler.CreateLabel)
+			compute.PUT("/:id/labels/:key", computeHandler.SetLabel)
+			compute.DELETE("/:id/labels/:key", computeHandler.DeleteLabel)
 
 			// Interface routes (compute/storage NICs)
 			compute.GET("/:id/interfaces", interfaceHandler.GetInterfaces)
 			compute.POST("/:id/interfaces", interfaceHandler.CreateInterface)
 			compute.PUT("/:id/interfaces/:interfaceId", interfaceHandler.UpdateInterface)
+			compute.PUT("/:id/interfaces/:interfaceId/role", interfaceHandler.UpdateInterfaceRole)
 			compute.DELETE("/:id/interfaces/:interfaceId", interfaceHandler.DeleteInterface)
 			compute.PUT("/:id/port-mappings/:mappingId/interface", interfaceHandler.AssignPortMapping)
 		}
@@ -105,6 +199,9 @@ func Setup(ndClient *ndclient.Client, cfg *config.Config) *gin.Engine {
 				groups.GET("", securityHandler.GetSecurityGroups)
 				groups.GET("/ndfc", securityHandler.ListNDFCSecurityGroups)
 				groups.DELETE("/ndfc/:groupId", securityHandler.DeleteNDFCSecurityGroup)
+				groups.POST("/bulk-update-selectors", securityHandler.BulkUpdateSelectors)
+				groups.GET("/export", securityHandler.ExportSecurityGroups)
+				groups.POST("/import", securityHandler.ImportSecurityGroups)
 				groups.GET("/:id", securityHandler.GetSecurityGroup)
 				groups.POST("", securityHandler.CreateSecurityGroup)
 				groups.DELETE("/:id", securityHandler.DeleteSecurityGroup)
@@ -116,27 +213,54 @@ func Setup(ndClient *ndclient.Client, cfg *config.Config) *gin.Engine {
 				contracts.GET("", securityHandler.GetSecurityContracts)
 				contracts.GET("/:id", securityHandler.GetSecurityContract)
 				contracts.POST("", securityHandler.CreateSecurityContract)
+				contracts.POST("/:id/clone", securityHandler.CloneSecurityContract)
+				contracts.GET("/:id/validate", securityHandler.ValidateSecurityContract)
+				contracts.POST("/:id/rules", securityHandler.AddSecurityContractRule)
+				contracts.PUT("/:id/rules/reorder", securityHandler.ReorderSecurityContractRules)
+				contracts.DELETE("/:id/rules/:ruleId", securityHandler.DeleteSecurityContractRule)
 				contracts.DELETE("/:id", securityHandler.DeleteSecurityContract)
 			}
 
+			// Security Protocols (listed from NDFC; created ones are also recorded locally)
+			protocols := security.Group("/protocols")
+			{
+				protocols.GET("", securityHandler.GetSecurityProtocols)
+				protocols.POST("", securityHandler.CreateSecurityProtocol)
+				protocols.DELETE("/:protocolName", securityHandler.DeleteSecurityProtocol)
+			}
+
 			// Security Associations
 			associations := security.Group("/associations")
 			{
 				associations.GET("", securityHandler.GetSecurityAssociations)
 				associations.GET("/:id", securityHandler.GetSecurityAssociation)
 				associations.POST("", securityHandler.CreateSecurityAssociation)
+				associations.POST("/validate", securityHandler.ValidateAssociations)
 				associations.DELETE("/:id", securityHandler.DeleteSecurityAssociation)
 			}
 		}
 
-		// Job routes (Slurm integration)
+		// Job routes (Slurm integration). Submissions with an Idempotency-Key header run once
+		// per key; without Valkey the header is ignored.
+		var idempotencyStore cache.Store
+		if cache.Client != nil {
+			idempotencyStore = cache.Client
+		}
 		jobs := v1.Group("/jobs")
 		{
 			jobs.GET("", jobHandler.ListJobs)
-			jobs.POST("", jobHandler.SubmitJob)
+			jobs.POST("", middleware.Idempotency(idempotencyStore, logger.L()), jobHandler.SubmitJob)
+			jobs.POST("/validate", jobHandler.ValidateJob)
+			jobs.POST("/bulk-get", jobHandler.BulkGetJobs)
+			jobs.GET("/retention-preview", jobHandler.RetentionPreview)
 			jobs.GET("/:slurm_job_id", jobHandler.GetJob)
+			jobs.PATCH("/:slurm_job_id", jobHandler.UpdateJobMetadata)
+			jobs.GET("/:slurm_job_id/events", jobHandler.GetJobEvents)
+			jobs.GET("/:slurm_job_id/summary", jobHandler.GetJobSummary)
 			jobs.POST("/:slurm_job_id/complete", jobHandler.CompleteJob)
+			jobs.POST("/:slurm_job_id/reconcile-ports", jobHandler.ReconcileJobPorts)
 			jobs.POST("/cleanup", jobHandler.CleanupExpiredJobs)
+			jobs.POST("/cleanup-expired", jobHandler.CleanupExpired) // ?dry_run=true previews
 		}
 
 		// Storage tenant routes (admin configuration for tenant storage access)
@@ -148,6 +272,12 @@ func Setup(ndClient *ndclient.Client, cfg *config.Config) *gin.Engine {
 			storageTenants.PUT("/:key", storageTenantHandler.UpdateStorageTenant)
 			storageTenants.DELETE("/:key", storageTenantHandler.DeleteStorageTenant)
 		}
+
+		// Report routes (capacity planning)
+		reports := v1.Group("/reports")
+		{
+			reports.GET("/provisioning-summary", reportHandler.GetProvisioningSummary)
+		}
 	}
 
 	return r
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long Serve waits for in-flight requests when none is configured
+const DefaultShutdownTimeout = 30 * time.Second
+
+// NewServer returns the HTTP server for handler on port. Unlike gin's Engine.Run, it can be
+// shut down without dropping in-flight requests (see Serve).
+func NewServer(handler http.Handler, port string) *http.Server {
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// Serve serves srv on ln until ctx is done, then stops accepting connections and waits up to
+// drainTimeout for in-flight requests to finish. Returns nil once they have, or the error that
+// stopped the server early or the drain.
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, drainTimeout time.Duration) error {
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultShutdownTimeout
+	}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"go.uber.org/zap"
+)
+
+// AssociationValidationInterval is how often the sync worker runs ValidateAllAssociations
+const AssociationValidationInterval = 7 * 24 * time.Hour
+
+// AssociationValidation is the result of comparing the contract associations a fabric
+// should have in NDFC, from local security associations and active jobs, with those it has
+type AssociationValidation struct {
+	FabricName    string                         `json:"fabric"`
+	MissingInNDFC int                            `json:"missing_in_ndfc"`
+	NDFCOnly      int                            `json:"ndfc_only"`
+	Matched       int                            `json:"matched"`
+	Missing       []ndclient.ContractAssociation `json:"missing_associations"`   // Local or job associations NDFC does not have
+	NDFCOnlyList  []ndclient.ContractAssociation `json:"ndfc_only_associations"` // NDFC associations neither local nor of a job
+	Fixed         bool                           `json:"fixed"`
+	Created       int                            `json:"created,omitempty"` // Missing associations created in NDFC by fix
+	Errors        []string                       `json:"errors,omitempty"`
+}
+
+// associationKey identifies a contract association the way NDFC does (VRF, groups, contract)
+func associationKey(vrfName string, srcGroupID, dstGroupID int, contractName string) string {
+	return fmt.Sprintf("%s|%d|%d|%s", vrfName, srcGroupID, dstGroupID, contractName)
+}
+
+// ValidateAssociations compares a fabric's contract associations in NDFC with those it
+// should have: the local security associations pushed to NDFC (local rows without group IDs
+// are ignored) and the self-associations of active jobs. Other NDFC associations of the
+// security group of a job that still holds NDFC resources, such as shared contract
+// associations, are managed by the job and count as matched. With fix, the missing
+// associations are created in NDFC; NDFC-only associations are reported but left alone.
+func (s *SecurityGroupService) ValidateAssociations(ctx context.Context, fabricName string, fix bool) (*AssociationValidation, error) {
+	if fabricName == "" {
+		return nil, fmt.Errorf("%w: fabric name is required", ErrInvalidSecurityGroup)
+	}
+	if s.ndClient == nil {
+		return nil, errors.New("Nexus Dashboard client not configured")
+	}
+
+	expected, jobGroups, err := s.expectedAssociations(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := s.ndClient.GetSecurityAssociations(ctx, fabricName)
+	if err != nil {
+		return nil, fmt.Errorf("get NDFC associations: %w", err)
+	}
+
+	result := &AssociationValidation{
+		FabricName:   fabricName,
+		Missing:      []ndclient.ContractAssociation{},
+		NDFCOnlyList: []ndclient.ContractAssociation{},
+		Fixed:        fix,
+	}
+	inNDFC := make(map[string]bool, len(remote))
+	for _, a := range remote {
+		if a.SrcGroupID == nil || a.DstGroupID == nil {
+			continue
+		}
+		inNDFC[associationKey(a.VRFName, *a.SrcGroupID, *a.DstGroupID, a.ContractName)] = true
+	}
+	isExpected := make(map[string]bool, len(expected))
+	for _, a := range expected {
+		key := associationKey(a.VRFName, *a.SrcGroupID, *a.DstGroupID, a.ContractName)
+		isExpected[key] = true
+		if inNDFC[key] {
+			result.Matched++
+		} else {
+			result.Missing = append(result.Missing, a)
+		}
+	}
+	for _, a := range remote {
+		switch {
+		case a.SrcGroupID == nil || a.DstGroupID == nil:
+			result.NDFCOnlyList = append(result.NDFCOnlyList, a)
+		case isExpected[associationKey(a.VRFName, *a.SrcGroupID, *a.DstGroupID, a.ContractName)]:
+		case *a.SrcGroupID != *a.DstGroupID && (jobGroups[*a.SrcGroupID] || jobGroups[*a.DstGroupID]):
+			result.Matched++ // Shared contract association of a job
+		default:
+			result.NDFCOnlyList = append(result.NDFCOnlyList, a)
+		}
+	}
+	result.MissingInNDFC = len(result.Missing)
+	result.NDFCOnly = len(result.NDFCOnlyList)
+
+	if fix {
+		s.createMissingAssociations(ctx, result)
+	}
+	return result, nil
+}
+
+// expectedAssociations returns the contract associations fabricName should have in NDFC,
+// sorted by contract and group IDs, and the NDFC IDs of the security groups of the fabric's
+// jobs that may still hold NDFC resources
+func (s *SecurityGroupService) expectedAssociations(ctx context.Context, fabricName string) ([]ndclient.ContractAssociation, map[int]bool, error) {
+	db := s.db.WithContext(ctx)
+
+	var local []models.SecurityAssociation
+	if err := db.Where("fabric_name = ? AND src_group_nd_id > 0 AND dst_group_nd_id > 0", fabricName).
+		Order("name").Find(&local).Error; err != nil {
+		return nil, nil, fmt.Errorf("list local associations: %w", err)
+	}
+	var jobs []models.Job
+	if err := db.Preload("SecurityGroup").
+		Where("fabric_name = ? AND security_group_id IS NOT NULL AND status IN ?", fabricName, []string{
+			string(models.JobStatusProvisioning), string(models.JobStatusActive),
+			string(models.JobStatusDeprovisioning), string(models.JobStatusCleanupFailed),
+		}).
+		Order("slurm_job_id").Find(&jobs).Error; err != nil {
+		return nil, nil, fmt.Errorf("list jobs: %w", err)
+	}
+
+	seen := make(map[string]bool, len(local))
+	var expected []ndclient.ContractAssociation
+	add := func(vrfName string, srcGroupID, dstGroupID int, contractName string) {
+		key := associationKey(vrfName, srcGroupID, dstGroupID, contractName)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		expected = append(expected, ndclient.ContractAssociation{
+			FabricName:   fabricName,
+			VRFName:      vrfName,
+			SrcGroupID:   &srcGroupID,
+			DstGroupID:   &dstGroupID,
+			ContractName: contractName,
+			Attach:       true,
+		})
+	}
+	for _, a := range local {
+		add(a.VRFName, a.SrcGroupNDID, a.DstGroupNDID, a.ContractName)
+	}
+
+	jobGroups := make(map[int]bool, len(jobs))
+	for i := range jobs {
+		job := &jobs[i]
+		if job.SecurityGroup == nil {
+			continue
+		}
+		groupID, err := strconv.Atoi(job.SecurityGroup.NDObjectID)
+		if err != nil || groupID <= 0 {
+			continue
+		}
+		jobGroups[groupID] = true
+		// Jobs still provisioning or being torn down may not have every association
+		if job.Status != string(models.JobStatusActive) {
+			continue
+		}
+		for _, name := range jobContractNames(job) {
+			add(job.VRFName, groupID, groupID, name)
+		}
+	}
+
+	sort.SliceStable(expected, func(i, j int) bool {
+		a, b := expected[i], expected[j]
+		if a.ContractName != b.ContractName {
+			return a.ContractName < b.ContractName
+		}
+		if *a.SrcGroupID != *b.SrcGroupID {
+			return *a.SrcGroupID < *b.SrcGroupID
+		}
+		return *a.DstGroupID < *b.DstGroupID
+	})
+	return expected, jobGroups, nil
+}
+
+// createMissingAssociations creates the missing associations of v in NDFC. An association
+// that already exists counts as created; other failures are collected in v.Errors.
+func (s *SecurityGroupService) createMissingAssociations(ctx context.Context, v *AssociationValidation) {
+	for _, a := range v.Missing {
+		if _, err := s.ndClient.CreateContractAssociations(ctx, v.FabricName, []ndclient.ContractAssociation{a}); err != nil &&
+			!ndclient.IsConflictError(err) {
+			v.Errors = append(v.Errors, fmt.Sprintf("create %s %d->%d: %v", a.ContractName, *a.SrcGroupID, *a.DstGroupID, err))
+			continue
+		}
+		v.Created++
+	}
+}
+
+// ValidateAllAssociations runs ValidateAssociations (without fix) for every known fabric
+// and logs the fabrics whose associations differ from NDFC
+func (s *SecurityGroupService) ValidateAllAssociations(ctx context.Context) error {
+	var fabricNames []string
+	if err := s.db.WithContext(ctx).Model(&models.Fabric{}).Pluck("name", &fabricNames).Error; err != nil {
+		return fmt.Errorf("list fabrics: %w", err)
+	}
+	sort.Strings(fabricNames)
+
+	for _, name := range fabricNames {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result, err := s.ValidateAssociations(ctx, name, false)
+		if err != nil {
+			logger.Warn("Failed to validate security associations", zap.String("fabric", name), zap.Error(err))
+			continue
+		}
+		if result.MissingInNDFC > 0 || result.NDFCOnly > 0 {
+			logger.Warn("Security associations differ from NDFC",
+				zap.String("fabric", name),
+				zap.Int("missing_in_ndfc", result.MissingInNDFC),
+				zap.Int("ndfc_only", result.NDFCOnly),
+				zap.Int("matched", result.Matched))
+		}
+	}
+	return nil
+}
+
+// AssociationValidationTask returns ValidateAllAssociations as a sync worker task
+func (s *SecurityGroupService) AssociationValidationTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "association-validation",
+		Interval: AssociationValidationInterval,
+		Run:      s.ValidateAllAssociations,
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// newAssociationValidationTest seeds fabric f1 with local associations a1-a4 (a4 never pushed
+// to NDFC), active job 7 with contract hpc-7 on group 103 and completed job 8 on group 104,
+// and serves ndfcAssociations as NDFC's contract associations of f1. Associations created
+// in NDFC are returned through the recorded slice.
+func newAssociationValidationTest(t *testing.T, ndfcAssociations string) (*SecurityGroupService, *[]ndclient.ContractAssociation) {
+	t.Helper()
+	var mu sync.Mutex
+	created := []ndclient.ContractAssociation{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/contractAssociations"):
+			_, _ = w.Write([]byte(ndfcAssociations))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/contractAssociations"):
+			var batch []ndclient.ContractAssociation
+			_ = json.NewDecoder(r.Body).Decode(&batch)
+			mu.Lock()
+			created = append(created, batch...)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(ndclient.BatchResponseAssociations{SuccessList: batch})
+		default:
+			t.Errorf("unexpected NDFC request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	db := dbtest.NewSQLiteDB(t, &models.SecurityGroup{}, &models.SecurityAssociation{}, &models.Job{})
+	activeGroup, completedGroup := "g3", "g4"
+	for _, v := range []interface{}{
+		&models.SecurityGroup{ID: "g1", Name: "storage", NDObjectID: "101", FabricName: "f1"},
+		&models.SecurityGroup{ID: "g2", Name: "mgmt", NDObjectID: "102", FabricName: "f1"},
+		&models.SecurityGroup{ID: "g3", Name: "job-7", NDObjectID: "103", FabricName: "f1"},
+		&models.SecurityGroup{ID: "g4", Name: "job-8", NDObjectID: "104", FabricName: "f1"},
+		&models.Job{ID: "j1", SlurmJobID: "7", Status: string(models.JobStatusActive), FabricName: "f1",
+			VRFName: "vrf", ContractName: "hpc-7", SecurityGroupID: &activeGroup},
+		&models.Job{ID: "j2", SlurmJobID: "8", Status: string(models.JobStatusCompleted), FabricName: "f1",
+			VRFName: "vrf", ContractName: "hpc-8", SecurityGroupID: &completedGroup},
+		&models.SecurityAssociation{ID: "a1", Name: "a1", FabricName: "f1", VRFName: "vrf", ContractName: "c",
+			SrcGroupNDID: 101, DstGroupNDID: 102},
+		&models.SecurityAssociation{ID: "a2", Name: "a2", FabricName: "f1", VRFName: "vrf", ContractName: "c",
+			SrcGroupNDID: 102, DstGroupNDID: 101},
+		&models.SecurityAssociation{ID: "a3", Name: "a3", FabricName: "f1", VRFName: "vrf", ContractName: "other",
+			SrcGroupNDID: 101, DstGroupNDID: 102},
+		&models.SecurityAssociation{ID: "a4", Name: "a4", FabricName: "f1", VRFName: "vrf", ContractName: "c"},
+		&models.SecurityAssociation{ID: "b1", Name: "b1", FabricName: "f2", VRFName: "vrf", ContractName: "c",
+			SrcGroupNDID: 201, DstGroupNDID: 202},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+	return NewSecurityGroupService(db, client), &created
+}
+
+// ndfcAssociation formats an NDFC contract association as JSON
+func ndfcAssociation(src, dst int, contract string) string {
+	return fmt.Sprintf(`{"vrfName":"vrf","srcGroupId":%d,"dstGroupId":%d,"contractName":%q,"attach":true}`, src, dst, contract)
+}
+
+// associationList formats associations as "contract:src->dst"
+func associationList(associations []ndclient.ContractAssociation) string {
+	parts := make([]string, 0, len(associations))
+	for _, a := range associations {
+		parts = append(parts, fmt.Sprintf("%s:%d->%d", a.ContractName, *a.SrcGroupID, *a.DstGroupID))
+	}
+	return strings.Join(parts, ",")
+}
+
+// validationNDFC has a1, job 7's self and shared associations, an association left by
+// completed job 8 and one between f1's groups, but neither a2 nor a3
+var validationNDFC = "[" + strings.Join([]string{
+	ndfcAssociation(101, 102, "c"),
+	ndfcAssociation(103, 103, "hpc-7"),
+	ndfcAssociation(103, 101, "shared"),
+	ndfcAssociation(104, 104, "hpc-8"),
+	ndfcAssociation(102, 102, "c"),
+}, ",") + "]"
+
+func TestValidateAssociations_Diff(t *testing.T) {
+	svc, created := newAssociationValidationTest(t, validationNDFC)
+
+	result, err := svc.ValidateAssociations(context.Background(), "f1", false)
+	if err != nil {
+		t.Fatalf("ValidateAssociations: %v", err)
+	}
+	if result.Matched != 3 || result.MissingInNDFC != 2 || result.NDFCOnly != 2 {
+		t.Errorf("matched/missing_in_ndfc/ndfc_only = %d/%d/%d, want 3/2/2",
+			result.Matched, result.MissingInNDFC, result.NDFCOnly)
+	}
+	if got := associationList(result.Missing); got != "c:102->101,other:101->102" {
+		t.Errorf("missing = %s, want a2 and a3", got)
+	}
+	if got := associationList(result.NDFCOnlyList); got != "hpc-8:104->104,c:102->102" {
+		t.Errorf("NDFC-only = %s, want completed job 8's and 102->102", got)
+	}
+	if len(*created) != 0 {
+		t.Errorf("created = %d, want 0 (validation without fix changes nothing)", len(*created))
+	}
+}
+
+func TestValidateAssociations_MissingJobAssociation(t *testing.T) {
+	svc, _ := newAssociationValidationTest(t, "["+ndfcAssociation(101, 102, "c")+"]")
+
+	result, err := svc.ValidateAssociations(context.Background(), "f1", false)
+	if err != nil {
+		t.Fatalf("ValidateAssociations: %v", err)
+	}
+	if got := associationList(result.Missing); got != "c:102->101,hpc-7:103->103,other:101->102" {
+		t.Errorf("missing = %s, want a2, job 7's self-association and a3", got)
+	}
+}
+
+func TestValidateAssociations_Fix(t *testing.T) {
+	svc, created := newAssociationValidationTest(t, validationNDFC)
+
+	result, err := svc.ValidateAssociations(context.Background(), "f1", true)
+	if err != nil {
+		t.Fatalf("ValidateAssociations: %v", err)
+	}
+	if result.Created != 2 || len(result.Errors) != 0 {
+		t.Errorf("created = %d (errors %v), want 2", result.Created, result.Errors)
+	}
+	if got := associationList(*created); got != "c:102->101,other:101->102" {
+		t.Errorf("created in NDFC = %s, want a2 and a3", got)
+	}
+	for _, a := range *created {
+		if a.VRFName != "vrf" || !a.Attach {
+			t.Errorf("created association = %+v", a)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/util"
+	"go.uber.org/zap"
+)
+
+// DefaultBMCPort is the standard IPMI (RMCP) port
+const DefaultBMCPort = 623
+
+// defaultBMCCommandTimeout bounds the external power-cycle command when no timeout is configured
+const defaultBMCCommandTimeout = 60 * time.Second
+
+var (
+	// ErrBMCNotConfigured is returned when no power-cycle command is configured
+	ErrBMCNotConfigured = errors.New("BMC power-cycle command not configured (set ND_BMC_POWER_CYCLE_CMD)")
+	// ErrNoBMCAddress is returned when a compute node has no BMC address
+	ErrNoBMCAddress = errors.New("compute node has no BMC address")
+	// ErrBMCCommandFailed is returned when the power-cycle command exits non-zero or times out
+	ErrBMCCommandFailed = errors.New("BMC power-cycle command failed")
+)
+
+// BMCInfo is the out-of-band management details of a compute node
+type BMCInfo struct {
+	NodeID      string `json:"node_id"`
+	NodeName    string `json:"node_name"`
+	BMCAddress  string `json:"bmc_address"`
+	BMCUsername string `json:"bmc_username"`
+	BMCPort     int    `json:"bmc_port"`
+}
+
+// BMCInfoFromNode extracts the BMC fields from a compute node
+func BMCInfoFromNode(node *models.ComputeNode) BMCInfo {
+	return BMCInfo{
+		NodeID:      node.ID,
+		NodeName:    node.Name,
+		BMCAddress:  node.BMCAddress,
+		BMCUsername: node.BMCUsername,
+		BMCPort:     node.BMCPort,
+	}
+}
+
+// PowerCycleResult is the outcome of running the external power-cycle command
+type PowerCycleResult struct {
+	NodeID     string `json:"node_id"`
+	NodeName   string `json:"node_name"`
+	BMCAddress string `json:"bmc_address"`
+	Output     string `json:"output"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// commandRunner runs an external command and returns its combined stdout/stderr.
+// Replaced in tests to avoid executing real commands.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func execCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// BMCService power-cycles compute nodes through a configurable external command.
+// IPMI is not implemented here; the command (e.g., a wrapper around ipmitool) receives
+// the BMC address as its last argument, so any out-of-band tool can be plugged in. The
+// address must pass util.ValidateBMCAddress, so it can never be read as an option.
+type BMCService struct {
+	command []string // Command and fixed leading args
+	timeout time.Duration
+	run     commandRunner
+}
+
+// NewBMCService creates a new BMCService.
+// command is split on whitespace (e.g., "/usr/local/bin/bmc-cycle --hard"); empty disables power-cycle.
+// Non-positive timeout uses the default.
+func NewBMCService(command string, timeout time.Duration) *BMCService {
+	if timeout <= 0 {
+		timeout = defaultBMCCommandTimeout
+	}
+	return &BMCService{
+		command: strings.Fields(command),
+		timeout: timeout,
+		run:     execCommand,
+	}
+}
+
+// PowerCycle runs the power-cycle command for node and waits for it to exit.
+// On failure the returned result still carries the command output and exit code.
+func (s *BMCService) PowerCycle(ctx context.Context, node *models.ComputeNode) (*PowerCycleResult, error) {
+	if len(s.command) == 0 {
+		return nil, ErrBMCNotConfigured
+	}
+	if node.BMCAddress == "" {
+		return nil, ErrNoBMCAddress
+	}
+	// Addresses stored before validation must not reach the command line either
+	if err := util.ValidateBMCAddress(node.BMCAddress); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	args := append(append([]string{}, s.command[1:]...), node.BMCAddress)
+
+	start := time.Now()
+	output, err := s.run(ctx, s.command[0], args...)
+	result := &PowerCycleResult{
+		NodeID:     node.ID,
+		NodeName:   node.Name,
+		BMCAddress: node.BMCAddress,
+		Output:     string(output),
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		logger.Warn("BMC power-cycle command failed",
+			zap.String("node", node.Name),
+			zap.String("bmc", node.BMCAddress),
+			zap.Int("exit_code", result.ExitCode),
+			zap.Error(err))
+		return result, fmt.Errorf("%w: %v", ErrBMCCommandFailed, err)
+	}
+
+	logger.Info("BMC power-cycle command completed",
+		zap.String("node", node.Name),
+		zap.String("bmc", node.BMCAddress),
+		zap.Int64("duration_ms", result.DurationMS))
+	return result, nil
+}
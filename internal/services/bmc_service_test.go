@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/util"
+)
+
+// fakeRunner records the command it was asked to run and returns canned output
+type fakeRunner struct {
+	name   string
+	args   []string
+	output []byte
+	err    error
+	calls  int
+}
+
+func (f *fakeRunner) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	f.calls++
+	f.name = name
+	f.args = args
+	return f.output, f.err
+}
+
+func newTestBMCService(command string, runner *fakeRunner) *BMCService {
+	s := NewBMCService(command, time.Second)
+	s.run = runner.run
+	return s
+}
+
+func TestBMCService_PowerCycle(t *testing.T) {
+	runner := &fakeRunner{output: []byte("Chassis Power Control: Cycle\n")}
+	s := newTestBMCService("/usr/local/bin/bmc-cycle --hard", runner)
+	node := &models.ComputeNode{ID: "n1", Name: "node01", BMCAddress: "10.0.0.50"}
+
+	result, err := s.PowerCycle(context.Background(), node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.name != "/usr/local/bin/bmc-cycle" {
+		t.Errorf("command = %q", runner.name)
+	}
+	// Configured args are kept and the BMC address is appended last
+	if want := []string{"--hard", "10.0.0.50"}; !reflect.DeepEqual(runner.args, want) {
+		t.Errorf("args = %v, want %v", runner.args, want)
+	}
+	if result.Output != "Chassis Power Control: Cycle\n" || result.ExitCode != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.NodeName != "node01" || result.BMCAddress != "10.0.0.50" {
+		t.Errorf("result missing node details: %+v", result)
+	}
+}
+
+func TestBMCService_PowerCycle_CommandFails(t *testing.T) {
+	// Produce a real *exec.ExitError so the exit code is extracted
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	runner := &fakeRunner{output: []byte("Error: Unable to establish IPMI session"), err: exitErr}
+	s := newTestBMCService("ipmi-wrapper", runner)
+
+	result, err := s.PowerCycle(context.Background(), &models.ComputeNode{Name: "node01", BMCAddress: "10.0.0.50"})
+	if !errors.Is(err, ErrBMCCommandFailed) {
+		t.Fatalf("expected ErrBMCCommandFailed, got %v", err)
+	}
+	if result == nil || result.ExitCode != 3 {
+		t.Fatalf("expected exit code 3 in result, got %+v", result)
+	}
+	if result.Output != "Error: Unable to establish IPMI session" {
+		t.Errorf("output not preserved: %q", result.Output)
+	}
+}
+
+func TestBMCService_PowerCycle_Preconditions(t *testing.T) {
+	runner := &fakeRunner{}
+
+	s := newTestBMCService("", runner)
+	if _, err := s.PowerCycle(context.Background(), &models.ComputeNode{BMCAddress: "10.0.0.50"}); !errors.Is(err, ErrBMCNotConfigured) {
+		t.Errorf("expected ErrBMCNotConfigured, got %v", err)
+	}
+
+	s = newTestBMCService("ipmi-wrapper", runner)
+	if _, err := s.PowerCycle(context.Background(), &models.ComputeNode{Name: "node01"}); !errors.Is(err, ErrNoBMCAddress) {
+		t.Errorf("expected ErrNoBMCAddress, got %v", err)
+	}
+
+	// An address that would be read as an option never reaches the command
+	if _, err := s.PowerCycle(context.Background(), &models.ComputeNode{Name: "node01", BMCAddress: "-Psecret"}); !errors.Is(err, util.ErrInvalidBMCAddress) {
+		t.Errorf("expected ErrInvalidBMCAddress, got %v", err)
+	}
+
+	if runner.calls != 0 {
+		t.Errorf("command should not run when preconditions fail, ran %d times", runner.calls)
+	}
+}
+
+func TestBMCService_PowerCycle_Timeout(t *testing.T) {
+	s := NewBMCService("ipmi-wrapper", 20*time.Millisecond)
+	s.run = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	result, err := s.PowerCycle(context.Background(), &models.ComputeNode{BMCAddress: "10.0.0.50"})
+	if !errors.Is(err, ErrBMCCommandFailed) {
+		t.Fatalf("expected ErrBMCCommandFailed, got %v", err)
+	}
+	if result.ExitCode != -1 {
+		t.Errorf("exit code = %d, want -1 for timeout", result.ExitCode)
+	}
+}
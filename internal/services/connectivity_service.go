@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"go.uber.org/zap"
+)
+
+// Connectivity check defaults
+const (
+	connectivityCheckPort        = 22
+	connectivityCheckTimeout     = 5 * time.Second
+	connectivityCheckConcurrency = 20
+)
+
+// ErrNoIPAddress is returned when a compute node has no IP address to check
+var ErrNoIPAddress = errors.New("compute node has no IP address")
+
+// ConnectivityResult is the outcome of a TCP reachability check against a compute node
+type ConnectivityResult struct {
+	NodeID    string    `json:"node_id,omitempty"`
+	NodeName  string    `json:"node_name,omitempty"`
+	Address   string    `json:"address,omitempty"`
+	Reachable bool      `json:"reachable"`
+	LatencyMS int64     `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ConnectivityService checks whether compute nodes accept TCP connections on the SSH port.
+// Only the TCP handshake is performed; no SSH authentication is attempted.
+type ConnectivityService struct {
+	cache       cache.CacheClient
+	port        int
+	timeout     time.Duration
+	concurrency int
+}
+
+// NewConnectivityService creates a new ConnectivityService.
+// cacheClient may be nil, in which case results are not persisted.
+func NewConnectivityService(cacheClient cache.CacheClient) *ConnectivityService {
+	return &ConnectivityService{
+		cache:       cacheClient,
+		port:        connectivityCheckPort,
+		timeout:     connectivityCheckTimeout,
+		concurrency: connectivityCheckConcurrency,
+	}
+}
+
+// CheckAddress dials host on the configured port and reports reachability and latency.
+// The dial runs in its own goroutine bounded by the check timeout.
+func (s *ConnectivityService) CheckAddress(ctx context.Context, host string) ConnectivityResult {
+	addr := net.JoinHostPort(host, strconv.Itoa(s.port))
+	result := ConnectivityResult{Address: addr}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	type dialResult struct {
+		latency time.Duration
+		err     error
+	}
+	done := make(chan dialResult, 1)
+
+	go func() {
+		var d net.Dialer
+		start := time.Now()
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		latency := time.Since(start)
+		if err == nil {
+			_ = conn.Close()
+		}
+		done <- dialResult{latency: latency, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		result.LatencyMS = r.latency.Milliseconds()
+		if r.err != nil {
+			result.Error = r.err.Error()
+		} else {
+			result.Reachable = true
+		}
+	case <-ctx.Done():
+		result.LatencyMS = s.timeout.Milliseconds()
+		result.Error = fmt.Sprintf("dial %s: %v", addr, ctx.Err())
+	}
+
+	result.CheckedAt = time.Now().UTC()
+	return result
+}
+
+// CheckNode runs a connectivity check against a compute node and stores the result.
+// Returns ErrNoIPAddress if the node has no IP address configured.
+func (s *ConnectivityService) CheckNode(ctx context.Context, node *models.ComputeNode) (*ConnectivityResult, error) {
+	if node.IPAddress == "" {
+		return nil, ErrNoIPAddress
+	}
+
+	result := s.CheckAddress(ctx, node.IPAddress)
+	result.NodeID = node.ID
+	result.NodeName = node.Name
+
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, cache.ConnectivityCheck(node.ID), result, cache.TTLConnectivityCheck); err != nil {
+			logger.Warn("Failed to cache connectivity check result",
+				zap.String("node", node.Name),
+				zap.Error(err))
+		}
+	}
+
+	return &result, nil
+}
+
+// CheckNodes fans out connectivity checks across nodes, bounded by the concurrency limit.
+// Results are returned in the same order as the input nodes. Nodes without an IP address
+// produce an unreachable result carrying the error instead of failing the whole batch.
+func (s *ConnectivityService) CheckNodes(ctx context.Context, nodes []models.ComputeNode) []ConnectivityResult {
+	results := make([]ConnectivityResult, len(nodes))
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+
+	for i := range nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			node := &nodes[i]
+			result, err := s.CheckNode(ctx, node)
+			if err != nil {
+				results[i] = ConnectivityResult{
+					NodeID:    node.ID,
+					NodeName:  node.Name,
+					CheckedAt: time.Now().UTC(),
+					Error:     err.Error(),
+				}
+				return
+			}
+			results[i] = *result
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// GetLastResult returns the most recent stored check result for a node.
+// Returns cache.ErrCacheMiss if no result is stored or the cache is unavailable.
+func (s *ConnectivityService) GetLastResult(ctx context.Context, nodeID string) (*ConnectivityResult, error) {
+	if s.cache == nil {
+		return nil, cache.ErrCacheMiss
+	}
+
+	var result ConnectivityResult
+	if err := s.cache.Get(ctx, cache.ConnectivityCheck(nodeID), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+)
+
+// startAcceptingListener starts a TCP listener that accepts and immediately closes
+// connections, simulating a reachable host. Returns the listener port.
+func startAcceptingListener(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// closedPort returns a port with nothing listening on it
+func closedPort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+	return port
+}
+
+func newTestConnectivityService(port int) *ConnectivityService {
+	svc := NewConnectivityService(nil)
+	svc.port = port
+	svc.timeout = time.Second
+	return svc
+}
+
+func TestConnectivityService_CheckAddress_Reachable(t *testing.T) {
+	svc := newTestConnectivityService(startAcceptingListener(t))
+
+	result := svc.CheckAddress(context.Background(), "127.0.0.1")
+	if !result.Reachable {
+		t.Fatalf("expected reachable, got error %q", result.Error)
+	}
+	if result.CheckedAt.IsZero() {
+		t.Error("expected CheckedAt to be set")
+	}
+	if result.Address != net.JoinHostPort("127.0.0.1", strconv.Itoa(svc.port)) {
+		t.Errorf("unexpected address %q", result.Address)
+	}
+}
+
+func TestConnectivityService_CheckAddress_Unreachable(t *testing.T) {
+	svc := newTestConnectivityService(closedPort(t))
+
+	result := svc.CheckAddress(context.Background(), "127.0.0.1")
+	if result.Reachable {
+		t.Fatal("expected unreachable")
+	}
+	if result.Error == "" {
+		t.Error("expected error message for unreachable host")
+	}
+}
+
+func TestConnectivityService_CheckNode_NoIPAddress(t *testing.T) {
+	svc := newTestConnectivityService(closedPort(t))
+
+	_, err := svc.CheckNode(context.Background(), &models.ComputeNode{ID: "n1", Name: "node1"})
+	if !errors.Is(err, ErrNoIPAddress) {
+		t.Fatalf("expected ErrNoIPAddress, got %v", err)
+	}
+}
+
+func TestConnectivityService_CheckNodes(t *testing.T) {
+	svc := newTestConnectivityService(startAcceptingListener(t))
+	svc.concurrency = 2
+
+	nodes := []models.ComputeNode{
+		{ID: "n1", Name: "node1", IPAddress: "127.0.0.1"},
+		{ID: "n2", Name: "node2"},
+		{ID: "n3", Name: "node3", IPAddress: "127.0.0.1"},
+		{ID: "n4", Name: "node4", IPAddress: "127.0.0.1"},
+	}
+
+	results := svc.CheckNodes(context.Background(), nodes)
+	if len(results) != len(nodes) {
+		t.Fatalf("expected %d results, got %d", len(nodes), len(results))
+	}
+
+	for i, r := range results {
+		if r.NodeID != nodes[i].ID {
+			t.Errorf("result %d: expected node %s, got %s", i, nodes[i].ID, r.NodeID)
+		}
+		wantReachable := nodes[i].IPAddress != ""
+		if r.Reachable != wantReachable {
+			t.Errorf("result %d: reachable = %v, want %v (error %q)", i, r.Reachable, wantReachable, r.Error)
+		}
+	}
+	if results[1].Error != ErrNoIPAddress.Error() {
+		t.Errorf("expected no-IP error for node2, got %q", results[1].Error)
+	}
+}
+
+func TestConnectivityService_GetLastResult_NoCache(t *testing.T) {
+	svc := NewConnectivityService(nil)
+	if _, err := svc.GetLastResult(context.Background(), "n1"); err == nil {
+		t.Fatal("expected error without cache")
+	}
+}
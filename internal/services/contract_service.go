@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Contract service errors. A clone target name already in use, locally or in NDFC, is
+// reported as ndclient.ErrContractExists.
+var (
+	ErrContractNotFound = errors.New("security contract not found")
+	ErrInvalidContract  = errors.New("invalid security contract")
+	ErrRuleNotFound     = errors.New("contract rule not found")
+)
+
+// ContractService handles security contract operations spanning NDFC and the local DB
+type ContractService struct {
+	db       *gorm.DB
+	ndClient *ndclient.Client
+}
+
+// NewContractService creates a new ContractService
+func NewContractService(db *gorm.DB, ndClient *ndclient.Client) *ContractService {
+	return &ContractService{
+		db:       db,
+		ndClient: ndClient,
+	}
+}
+
+// CloneContract duplicates a contract and its rules under a new name.
+// The source is looked up locally by ID or name; the NDFC copy is built from the
+// source's current NDFC rules. fabricName defaults to the source contract's fabric.
+func (s *ContractService) CloneContract(ctx context.Context, srcIDOrName, newName, fabricName string) (*models.SecurityContract, error) {
+	if newName == "" {
+		return nil, fmt.Errorf("%w: new_name is required", ErrInvalidContract)
+	}
+
+	var src models.SecurityContract
+	if err := s.db.WithContext(ctx).Preload("Rules", OrderContractRules).
+		Where("id = ? OR name = ?", srcIDOrName, srcIDOrName).
+		First(&src).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContractNotFound
+		}
+		return nil, err
+	}
+
+	if newName == src.Name {
+		return nil, fmt.Errorf("%w: new name must differ from source contract name", ErrInvalidContract)
+	}
+
+	if fabricName == "" {
+		fabricName = src.FabricName
+	}
+	if fabricName == "" {
+		return nil, fmt.Errorf("%w: fabric_name is required", ErrInvalidContract)
+	}
+	if src.FabricName != "" && fabricName != src.FabricName {
+		return nil, fmt.Errorf("%w: fabric_name must match source contract fabric %q", ErrInvalidContract, src.FabricName)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.SecurityContract{}).
+		Where("name = ?", newName).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: %q", ndclient.ErrContractExists, newName)
+	}
+
+	ndObjectID := newName
+	if s.ndClient != nil {
+		ndContract, err := s.ndClient.CloneSecurityContract(ctx, fabricName, src.Name, newName)
+		if err != nil {
+			return nil, err
+		}
+		ndObjectID = ndContract.ContractName
+	}
+
+	clone := models.SecurityContract{
+		ID:          uuid.New().String(),
+		Name:        newName,
+		Description: src.Description,
+		NDObjectID:  ndObjectID,
+		FabricName:  fabricName,
+	}
+
+	rules := make([]models.ContractRule, 0, len(src.Rules))
+	for _, r := range src.Rules {
+		rules = append(rules, models.ContractRule{
+			ID:                 uuid.New().String(),
+			SecurityContractID: clone.ID,
+			Name:               r.Name,
+			Direction:          r.Direction,
+			Action:             r.Action,
+			Protocol:           r.Protocol,
+			SrcPort:            r.SrcPort,
+			DstPort:            r.DstPort,
+			Priority:           r.Priority,
+			Sequence:           r.Sequence,
+		})
+	}
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&clone).Error; err != nil {
+			return err
+		}
+		if len(rules) > 0 {
+			if err := tx.Create(&rules).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	clone.Rules = rules
+	return &clone, nil
+}
+
+// Valid contract rule directions and actions accepted by NDFC
+var (
+	validRuleDirections = map[string]bool{"bidirectional": true, "unidirectional": true}
+	validRuleActions    = map[string]bool{"permit": true, "deny": true}
+)
+
+// ValidateContract checks a locally stored contract's rules against the protocols
+// defined in NDFC for fabricName. contractName may be the contract's ID or name;
+// fabricName defaults to the contract's fabric. It returns one message per problem
+// found, numbering rules from 1; an empty slice means the contract is valid.
+func (s *ContractService) ValidateContract(ctx context.Context, fabricName, contractName string) ([]string, error) {
+	var contract models.SecurityContract
+	if err := s.db.WithContext(ctx).
+		Preload("Rules", OrderContractRules).
+		Where("id = ? OR name = ?", contractName, contractName).
+		First(&contract).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContractNotFound
+		}
+		return nil, err
+	}
+
+	if fabricName == "" {
+		fabricName = contract.FabricName
+	}
+	if fabricName == "" {
+		return nil, fmt.Errorf("%w: fabric is required", ErrInvalidContract)
+	}
+	if s.ndClient == nil {
+		return nil, fmt.Errorf("NDFC client not configured")
+	}
+
+	protocols, err := s.ndClient.GetSecurityProtocols(ctx, fabricName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security protocols: %w", err)
+	}
+	known := make(map[string]bool, len(protocols))
+	for _, p := range protocols {
+		known[p.ProtocolName] = true
+	}
+
+	problems := []string{}
+	for i, r := range contract.Rules {
+		n := i + 1
+		protocol := ruleProtocol(r)
+		switch {
+		case protocol == "":
+			problems = append(problems, fmt.Sprintf("rule %d: protocol is required", n))
+		case !known[protocol]:
+			problems = append(problems, fmt.Sprintf("rule %d: protocol '%s' not found in NDFC", n, protocol))
+		}
+		if direction := ruleDirection(r); !validRuleDirections[direction] {
+			problems = append(problems, fmt.Sprintf("rule %d: invalid direction '%s' (must be bidirectional or unidirectional)", n, direction))
+		}
+		if !validRuleActions[r.Action] {
+			problems = append(problems, fmt.Sprintf("rule %d: invalid action '%s' (must be permit or deny)", n, r.Action))
+		}
+	}
+	return problems, nil
+}
+
+// ruleSequenceStep is the gap between consecutive rule sequence numbers
+const ruleSequenceStep = 10
+
+// OrderContractRules orders preloaded contract rules by sequence
+func OrderContractRules(db *gorm.DB) *gorm.DB {
+	return db.Order("sequence, created_at")
+}
+
+// ruleProtocol returns the NDFC protocol name of a rule. Rules created before
+// Protocol was populated keep the protocol in Name.
+func ruleProtocol(r models.ContractRule) string {
+	if r.Protocol != "" {
+		return r.Protocol
+	}
+	return r.Name
+}
+
+// ruleDirection returns the NDFC direction of a rule. Rules stored before Direction was
+// populated are bidirectional.
+func ruleDirection(r models.ContractRule) string {
+	if r.Direction != "" {
+		return r.Direction
+	}
+	return models.DefaultContractRuleDirection
+}
+
+// AddRule appends a rule to the end of a contract and pushes the full rule list to NDFC.
+// Only Direction, Action and Protocol are read from rule.
+func (s *ContractService) AddRule(ctx context.Context, contractID string, rule models.ContractRule) (*models.SecurityContract, error) {
+	if !validRuleDirections[rule.Direction] {
+		return nil, fmt.Errorf("%w: direction %q must be bidirectional or unidirectional", ErrInvalidContract, rule.Direction)
+	}
+	if !validRuleActions[rule.Action] {
+		return nil, fmt.Errorf("%w: action %q must be permit or deny", ErrInvalidContract, rule.Action)
+	}
+
+	return s.updateRules(ctx, contractID, func(tx *gorm.DB, contract *models.SecurityContract) error {
+		next := ruleSequenceStep
+		if n := len(contract.Rules); n > 0 {
+			next = contract.Rules[n-1].Sequence + ruleSequenceStep
+		}
+		added := models.ContractRule{
+			ID:                 uuid.New().String(),
+			SecurityContractID: contract.ID,
+			Name:               rule.Protocol,
+			Direction:          rule.Direction,
+			Action:             rule.Action,
+			Protocol:           rule.Protocol,
+			Sequence:           next,
+		}
+		if err := tx.Create(&added).Error; err != nil {
+			return err
+		}
+		contract.Rules = append(contract.Rules, added)
+		return nil
+	})
+}
+
+// DeleteRule removes a rule from a contract and pushes the remaining rules to NDFC
+func (s *ContractService) DeleteRule(ctx context.Context, contractID, ruleID string) (*models.SecurityContract, error) {
+	return s.updateRules(ctx, contractID, func(tx *gorm.DB, contract *models.SecurityContract) error {
+		for i, r := range contract.Rules {
+			if r.ID != ruleID {
+				continue
+			}
+			if err := tx.Delete(&models.ContractRule{}, "id = ?", ruleID).Error; err != nil {
+				return err
+			}
+			contract.Rules = append(contract.Rules[:i], contract.Rules[i+1:]...)
+			return nil
+		}
+		return ErrRuleNotFound
+	})
+}
+
+// ReorderRules puts a contract's rules in the given order, renumbering sequences
+// 10, 20, 30, ... and pushing the reordered list to NDFC. orderedRuleIDs must list
+// every rule of the contract exactly once.
+func (s *ContractService) ReorderRules(ctx context.Context, contractID string, orderedRuleIDs []string) (*models.SecurityContract, error) {
+	return s.updateRules(ctx, contractID, func(tx *gorm.DB, contract *models.SecurityContract) error {
+		byID := make(map[string]models.ContractRule, len(contract.Rules))
+		for _, r := range contract.Rules {
+			byID[r.ID] = r
+		}
+		if len(orderedRuleIDs) != len(byID) {
+			return fmt.Errorf("%w: rule_ids must list each of the contract's %d rules exactly once", ErrInvalidContract, len(byID))
+		}
+
+		reordered := make([]models.ContractRule, 0, len(orderedRuleIDs))
+		for i, id := range orderedRuleIDs {
+			r, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%w: rule %q is not in the contract or is listed twice", ErrInvalidContract, id)
+			}
+			delete(byID, id)
+
+			r.Sequence = (i + 1) * ruleSequenceStep
+			if err := tx.Model(&models.ContractRule{}).Where("id = ?", r.ID).
+				Update("sequence", r.Sequence).Error; err != nil {
+				return err
+			}
+			reordered = append(reordered, r)
+		}
+		contract.Rules = reordered
+		return nil
+	})
+}
+
+// updateRules loads a contract with its ordered rules, applies change in a
+// transaction and replaces the contract's NDFC rule list with the result.
+// An NDFC failure rolls back the local change.
+func (s *ContractService) updateRules(ctx context.Context, contractID string, change func(tx *gorm.DB, contract *models.SecurityContract) error) (*models.SecurityContract, error) {
+	var contract models.SecurityContract
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Rules", OrderContractRules).
+			Where("id = ? OR name = ?", contractID, contractID).
+			First(&contract).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrContractNotFound
+			}
+			return err
+		}
+
+		if err := change(tx, &contract); err != nil {
+			return err
+		}
+		return s.pushRules(ctx, &contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+// pushRules replaces the contract's rules in NDFC with contract.Rules, in order
+func (s *ContractService) pushRules(ctx context.Context, contract *models.SecurityContract) error {
+	if s.ndClient == nil {
+		return nil
+	}
+	if contract.FabricName == "" {
+		return fmt.Errorf("%w: contract has no fabric", ErrInvalidContract)
+	}
+
+	ndRules := make([]ndclient.ContractRule, 0, len(contract.Rules))
+	for _, r := range contract.Rules {
+		ndRules = append(ndRules, ndclient.ContractRule{
+			Direction:    ruleDirection(r),
+			Action:       r.Action,
+			ProtocolName: ruleProtocol(r),
+		})
+	}
+
+	if _, err := s.ndClient.UpdateSecurityContract(ctx, contract.FabricName, contract.Name, &ndclient.SecurityContract{
+		ContractName: contract.Name,
+		Rules:        ndRules,
+	}); err != nil {
+		return fmt.Errorf("failed to update contract in NDFC: %w", err)
+	}
+	return nil
+}
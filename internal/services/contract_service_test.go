@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"gorm.io/gorm"
+)
+
+func newContractValidationTest(t *testing.T, rules ...models.ContractRule) *ContractService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/protocols") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"protocolName": "https", "matchType": "any"}, {"protocolName": "dns", "matchType": "any"}]`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	db := dbtest.NewSQLiteDB(t, &models.SecurityContract{}, &models.ContractRule{})
+	if err := db.Create(&models.SecurityContract{ID: "c1", Name: "web", FabricName: "f1"}).Error; err != nil {
+		t.Fatalf("seed contract: %v", err)
+	}
+	for i := range rules {
+		rules[i].SecurityContractID = "c1"
+		rules[i].Priority = i
+		if err := db.Create(&rules[i]).Error; err != nil {
+			t.Fatalf("seed rule: %v", err)
+		}
+	}
+	return NewContractService(db, client)
+}
+
+func TestValidateContract_Valid(t *testing.T) {
+	svc := newContractValidationTest(t,
+		models.ContractRule{ID: "r1", Protocol: "https", Direction: "bidirectional", Action: "permit"},
+		models.ContractRule{ID: "r2", Name: "dns", Direction: "unidirectional", Action: "deny"},
+		models.ContractRule{ID: "r3", Protocol: "dns", Action: "permit"}, // Stored before Direction, bidirectional
+	)
+
+	problems, err := svc.ValidateContract(context.Background(), "", "web")
+	if err != nil {
+		t.Fatalf("ValidateContract: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateContract_InvalidRules(t *testing.T) {
+	svc := newContractValidationTest(t,
+		models.ContractRule{ID: "r1", Protocol: "https", Direction: "bidirectional", Action: "permit"},
+		models.ContractRule{ID: "r2", Protocol: "ssh", Direction: "bidirectional", Action: "permit"},
+		models.ContractRule{ID: "r3", Protocol: "dns", Direction: "sideways", Action: "allow"},
+	)
+
+	problems, err := svc.ValidateContract(context.Background(), "f1", "c1")
+	if err != nil {
+		t.Fatalf("ValidateContract: %v", err)
+	}
+	want := []string{
+		"rule 2: protocol 'ssh' not found in NDFC",
+		"rule 3: invalid direction 'sideways' (must be bidirectional or unidirectional)",
+		"rule 3: invalid action 'allow' (must be permit or deny)",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+}
+
+func TestValidateContract_NotFound(t *testing.T) {
+	svc := newContractValidationTest(t)
+	if _, err := svc.ValidateContract(context.Background(), "f1", "missing"); !errors.Is(err, ErrContractNotFound) {
+		t.Errorf("expected ErrContractNotFound, got %v", err)
+	}
+}
+
+// contractPutRecorder records contract bodies PUT to NDFC, failing with status if set
+type contractPutRecorder struct {
+	puts   []ndclient.SecurityContract
+	status int
+}
+
+func (f *contractPutRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodPut {
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(`{"message": "update failed"}`))
+		return
+	}
+	var body ndclient.SecurityContract
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.puts = append(f.puts, body)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newContractRulesTest(t *testing.T, fake *contractPutRecorder) (*ContractService, *gorm.DB) {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	db := dbtest.NewSQLiteDB(t, &models.SecurityContract{}, &models.ContractRule{})
+	for _, v := range []interface{}{
+		&models.SecurityContract{ID: "c1", Name: "web", FabricName: "f1"},
+		&models.ContractRule{ID: "r1", SecurityContractID: "c1", Protocol: "https", Direction: "bidirectional", Action: "permit", Sequence: 10},
+		&models.ContractRule{ID: "r2", SecurityContractID: "c1", Protocol: "dns", Direction: "bidirectional", Action: "permit", Sequence: 20},
+		&models.ContractRule{ID: "r3", SecurityContractID: "c1", Protocol: "ssh", Direction: "unidirectional", Action: "deny", Sequence: 30},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+	return NewContractService(db, client), db
+}
+
+func ndProtocols(c ndclient.SecurityContract) []string {
+	out := make([]string, len(c.Rules))
+	for i, r := range c.Rules {
+		out[i] = r.ProtocolName
+	}
+	return out
+}
+
+func storedSequences(t *testing.T, db *gorm.DB) map[string]int {
+	t.Helper()
+	var rules []models.ContractRule
+	if err := db.Find(&rules).Error; err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	out := make(map[string]int, len(rules))
+	for _, r := range rules {
+		out[r.ID] = r.Sequence
+	}
+	return out
+}
+
+func TestReorderRules_PutsRulesInNewOrder(t *testing.T) {
+	fake := &contractPutRecorder{}
+	svc, db := newContractRulesTest(t, fake)
+
+	contract, err := svc.ReorderRules(context.Background(), "c1", []string{"r3", "r1", "r2"})
+	if err != nil {
+		t.Fatalf("ReorderRules: %v", err)
+	}
+
+	if len(fake.puts) != 1 {
+		t.Fatalf("NDFC PUTs = %d, want 1", len(fake.puts))
+	}
+	if got, want := ndProtocols(fake.puts[0]), []string{"ssh", "https", "dns"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NDFC rule order = %v, want %v", got, want)
+	}
+	if fake.puts[0].ContractName != "web" {
+		t.Errorf("NDFC contract name = %q, want web", fake.puts[0].ContractName)
+	}
+
+	want := map[string]int{"r3": 10, "r1": 20, "r2": 30}
+	if got := storedSequences(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("sequences = %v, want %v", got, want)
+	}
+	if contract.Rules[0].ID != "r3" || contract.Rules[2].ID != "r2" {
+		t.Errorf("returned rules not in new order: %+v", contract.Rules)
+	}
+}
+
+func TestReorderRules_RejectsIncompleteList(t *testing.T) {
+	fake := &contractPutRecorder{}
+	svc, _ := newContractRulesTest(t, fake)
+
+	for _, ids := range [][]string{{"r1", "r2"}, {"r1", "r1", "r2"}, {"r1", "r2", "r9"}} {
+		if _, err := svc.ReorderRules(context.Background(), "c1", ids); !errors.Is(err, ErrInvalidContract) {
+			t.Errorf("ReorderRules(%v) error = %v, want ErrInvalidContract", ids, err)
+		}
+	}
+	if len(fake.puts) != 0 {
+		t.Errorf("NDFC PUTs = %d, want 0", len(fake.puts))
+	}
+}
+
+func TestReorderRules_NDFCFailureRollsBack(t *testing.T) {
+	fake := &contractPutRecorder{status: http.StatusInternalServerError}
+	svc, db := newContractRulesTest(t, fake)
+
+	if _, err := svc.ReorderRules(context.Background(), "c1", []string{"r3", "r2", "r1"}); err == nil {
+		t.Fatal("expected NDFC error")
+	}
+	want := map[string]int{"r1": 10, "r2": 20, "r3": 30}
+	if got := storedSequences(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("sequences = %v after failed update, want unchanged %v", got, want)
+	}
+}
+
+func TestAddRule_AppendsAfterLastSequence(t *testing.T) {
+	fake := &contractPutRecorder{}
+	svc, _ := newContractRulesTest(t, fake)
+
+	contract, err := svc.AddRule(context.Background(), "web", models.ContractRule{
+		Direction: "bidirectional", Action: "permit", Protocol: "ntp",
+	})
+	if err != nil {
+		t.Fatalf("AddRule: %v", err)
+	}
+	last := contract.Rules[len(contract.Rules)-1]
+	if last.Protocol != "ntp" || last.Sequence != 40 {
+		t.Errorf("appended rule = %+v, want ntp at sequence 40", last)
+	}
+	if got, want := ndProtocols(fake.puts[0]), []string{"https", "dns", "ssh", "ntp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NDFC rules = %v, want %v", got, want)
+	}
+
+	if _, err := svc.AddRule(context.Background(), "c1", models.ContractRule{Direction: "both", Action: "permit"}); !errors.Is(err, ErrInvalidContract) {
+		t.Errorf("invalid direction error = %v, want ErrInvalidContract", err)
+	}
+}
+
+func TestDeleteRule(t *testing.T) {
+	fake := &contractPutRecorder{}
+	svc, db := newContractRulesTest(t, fake)
+
+	if _, err := svc.DeleteRule(context.Background(), "c1", "r2"); err != nil {
+		t.Fatalf("DeleteRule: %v", err)
+	}
+	if got, want := ndProtocols(fake.puts[0]), []string{"https", "ssh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NDFC rules = %v, want %v", got, want)
+	}
+	if _, ok := storedSequences(t, db)["r2"]; ok {
+		t.Error("rule r2 still stored")
+	}
+
+	if _, err := svc.DeleteRule(context.Background(), "c1", "missing"); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("error = %v, want ErrRuleNotFound", err)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"go.uber.org/zap"
 )
@@ -24,18 +26,38 @@ import (
 //   - All waiters poll for completion and receive the same result
 //   - If new requests arrive during deploy, they form a NEW batch that waits for the lock
 //   - This ensures sequential deploys: batch1 deploys -> batch2 deploys -> etc.
+//   - Requests may target one switch; a batch of only such requests deploys the union of
+//     their switches, unless that covers every switch in the fabric
 //
 // Valkey keys used (per fabric):
 //   - deploy:batch:{fabric}:start    - Unix timestamp of first request in batch (also serves as batch ID)
 //   - deploy:batch:{fabric}:last     - Unix timestamp of last request in batch
 //   - deploy:batch:{fabric}:lock     - Lock for executing deploy (only one instance)
 //   - deploy:batch:{fabric}:result:{batchID} - Result of deploy ("ok" or error message)
+//   - deploy:batch:{fabric}:serials:{batchID} - Set of switch serial numbers to deploy ("*" = whole fabric)
+//   - deploy:batch:{fabric}:requests:{batchID} - Number of requests in the batch (for metrics)
 type DeployBatcher struct {
 	ndClient     *ndclient.Client
-	cache        *cache.ValkeyClient
+	cache        cache.CacheClient // Nil deploys immediately without batching
 	debounceTime time.Duration
 	maxWaitTime  time.Duration
 
+	// pollInterval is how often the coordinator checks Valkey for debounce/max-wait conditions.
+	// resultWatchPollInterval is how often joiner instances poll for the batch result.
+	pollInterval            time.Duration
+	resultWatchPollInterval time.Duration
+
+	// circuitPollInterval is how often the coordinator re-checks an open NDFC circuit breaker
+	circuitPollInterval time.Duration
+
+	// Deploy behavior: defaultDeploy applies to fabrics without an entry in fabricDeploy
+	defaultDeploy FabricDeployConfig
+	fabricDeploy  map[string]FabricDeployConfig
+
+	// switchCounter returns the number of switches in a fabric, to decide whether a targeted
+	// deploy is worth it. Nil means targeted deploys are always sent.
+	switchCounter SwitchCounter
+
 	// Local waiters for this instance (to notify when deploy completes)
 	mu      sync.Mutex
 	waiters map[string][]chan error // fabricName -> local waiters
@@ -45,46 +67,182 @@ type DeployBatcher struct {
 	watchers  map[string]bool // fabricName -> has active watcher
 }
 
+// Default poll intervals for deploy coordination
+const (
+	DefaultDeployPollInterval            = 500 * time.Millisecond
+	DefaultDeployResultWatchPollInterval = 2 * time.Second
+	DefaultDeployCircuitPollInterval     = 10 * time.Second
+)
+
+// FabricDeployConfig controls how a fabric's configuration is deployed
+type FabricDeployConfig struct {
+	// ConfigSaveBeforeDeploy runs config-save before config-deploy, for NDFC versions that
+	// otherwise report no changes to deploy
+	ConfigSaveBeforeDeploy bool
+}
+
+// SwitchCounter returns the number of switches in a fabric
+type SwitchCounter func(ctx context.Context, fabricName string) (int, error)
+
+// deployAllSwitches is the batch target recorded by requests for a whole-fabric deploy
+const deployAllSwitches = "*"
+
+// DeployBatcherOption configures optional DeployBatcher settings
+type DeployBatcherOption func(*DeployBatcher)
+
+// WithPollInterval sets how often the coordinator checks whether a batch is ready to deploy.
+// Non-positive values are ignored.
+func WithPollInterval(d time.Duration) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		if d > 0 {
+			b.pollInterval = d
+		}
+	}
+}
+
+// WithResultWatchPollInterval sets how often joiners poll for a batch result.
+// Non-positive values are ignored.
+func WithResultWatchPollInterval(d time.Duration) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		if d > 0 {
+			b.resultWatchPollInterval = d
+		}
+	}
+}
+
+// WithCircuitPollInterval sets how often the coordinator re-checks an open circuit breaker.
+// Non-positive values are ignored.
+func WithCircuitPollInterval(d time.Duration) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		if d > 0 {
+			b.circuitPollInterval = d
+		}
+	}
+}
+
+// WithDefaultFabricDeployConfig sets the deploy behavior for fabrics without their own config.
+func WithDefaultFabricDeployConfig(cfg FabricDeployConfig) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		b.defaultDeploy = cfg
+	}
+}
+
+// WithFabricDeployConfig sets the deploy behavior for one fabric, overriding the default.
+func WithFabricDeployConfig(fabricName string, cfg FabricDeployConfig) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		b.fabricDeploy[fabricName] = cfg
+	}
+}
+
+// WithSwitchCounter sets how the batcher counts a fabric's switches. A batch whose switches
+// cover the whole fabric is sent as a full fabric deploy.
+func WithSwitchCounter(counter SwitchCounter) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		b.switchCounter = counter
+	}
+}
+
+// WithCacheClient sets the cache that coordinates batches instead of the global Valkey client
+func WithCacheClient(client cache.CacheClient) DeployBatcherOption {
+	return func(b *DeployBatcher) {
+		b.cache = client
+	}
+}
+
 // NewDeployBatcher creates a new deploy batcher.
 // debounceTime: how long to wait after the last request before deploying (e.g., 5s)
 // maxWaitTime: maximum time to wait before forcing deploy regardless of new requests (e.g., 20s)
-func NewDeployBatcher(ndClient *ndclient.Client, debounceTime, maxWaitTime time.Duration) *DeployBatcher {
-	return &DeployBatcher{
-		ndClient:     ndClient,
-		cache:        cache.Client,
-		debounceTime: debounceTime,
-		maxWaitTime:  maxWaitTime,
-		waiters:      make(map[string][]chan error),
-		watchers:     make(map[string]bool),
+// options: optional overrides (poll intervals); unset values use the defaults above
+func NewDeployBatcher(ndClient *ndclient.Client, debounceTime, maxWaitTime time.Duration, options ...DeployBatcherOption) *DeployBatcher {
+	b := &DeployBatcher{
+		ndClient:                ndClient,
+		debounceTime:            debounceTime,
+		maxWaitTime:             maxWaitTime,
+		pollInterval:            DefaultDeployPollInterval,
+		resultWatchPollInterval: DefaultDeployResultWatchPollInterval,
+		circuitPollInterval:     DefaultDeployCircuitPollInterval,
+		fabricDeploy:            make(map[string]FabricDeployConfig),
+		waiters:                 make(map[string][]chan error),
+		watchers:                make(map[string]bool),
 	}
+	if cache.Client != nil {
+		b.cache = cache.Client
+	}
+	for _, opt := range options {
+		opt(b)
+	}
+	return b
 }
 
 // Valkey key helpers
 // keyStart stores the batch ID (timestamp of first request) - used to identify the batch
 func (b *DeployBatcher) keyStart(fabric string) string {
-	return fmt.Sprintf("deploy:batch:%s:start", fabric)
+	return cache.DeployBatchStart(fabric)
 }
 func (b *DeployBatcher) keyLast(fabric string) string {
-	return fmt.Sprintf("deploy:batch:%s:last", fabric)
+	return cache.DeployBatchLast(fabric)
 }
 func (b *DeployBatcher) keyLock(fabric string) string {
-	return fmt.Sprintf("deploy:batch:%s:lock", fabric)
+	return cache.DeployBatchLock(fabric)
+}
+
+// PendingDeploys counts deploy batches waiting to run, for one fabric or all fabrics if
+// fabricName is empty. A batch is pending while its start key exists.
+func PendingDeploys(ctx context.Context, client *cache.ValkeyClient, fabricName string) (int64, error) {
+	if fabricName == "" {
+		return client.CountPattern(ctx, cache.DeployBatchStartPattern())
+	}
+	exists, err := client.Exists(ctx, cache.DeployBatchStart(fabricName))
+	if err != nil || !exists {
+		return 0, err
+	}
+	return 1, nil
 }
 
 // keyResult includes the batch ID to prevent cross-batch result confusion
 func (b *DeployBatcher) keyResult(fabric, batchID string) string {
-	return fmt.Sprintf("deploy:batch:%s:result:%s", fabric, batchID)
+	return cache.DeployBatchResult(fabric, batchID)
+}
+
+// keySerials holds the switches requested in a batch
+func (b *DeployBatcher) keySerials(fabric, batchID string) string {
+	return cache.DeployBatchSerials(fabric, batchID)
+}
+
+// keyRequests counts the requests of a batch
+func (b *DeployBatcher) keyRequests(fabric, batchID string) string {
+	return cache.DeployBatchRequests(fabric, batchID)
 }
 
 // RequestDeploy queues a deploy request for the given fabric.
 // Uses Valkey for distributed coordination - works across multiple instances.
 // Returns when the deploy completes (or fails).
 func (b *DeployBatcher) RequestDeploy(ctx context.Context, fabricName string) error {
+	return b.requestDeploy(ctx, fabricName, deployAllSwitches)
+}
+
+// RequestDeployForSwitch queues a deploy request for one switch in the fabric. It is batched
+// with other requests like RequestDeploy; if the batch also holds a whole-fabric request, the
+// whole fabric is deployed.
+func (b *DeployBatcher) RequestDeployForSwitch(ctx context.Context, fabricName, serialNumber string) error {
+	if serialNumber == "" {
+		return b.RequestDeploy(ctx, fabricName)
+	}
+	return b.requestDeploy(ctx, fabricName, serialNumber)
+}
+
+// requestDeploy queues a deploy of target, a switch serial number or deployAllSwitches
+func (b *DeployBatcher) requestDeploy(ctx context.Context, fabricName, target string) error {
+	if b.ndClient == nil {
+		return errors.New("Nexus Dashboard client not configured")
+	}
 	if b.cache == nil {
 		// Fallback: no Valkey, deploy immediately
 		logger.Warn("DeployBatcher: Valkey not available, deploying immediately",
 			zap.String("fabric", fabricName))
-		return b.ndClient.ConfigDeploy(ctx, fabricName, nil)
+		metrics.DeployBatchSize.Observe(1)
+		metrics.DeployBatchWait.Observe(0)
+		return b.deployTargets(ctx, fabricName, batchTargets([]string{target}))
 	}
 
 	resultCh := make(chan error, 1)
@@ -118,6 +276,23 @@ func (b *DeployBatcher) RequestDeploy(ctx context.Context, fabricName string) er
 		}
 	}
 
+	// Record the target before updating the last request time, so the coordinator sees it
+	// once the debounce elapses
+	keySerials := b.keySerials(fabricName, batchID)
+	err = b.cache.SAdd(ctx, keySerials, target)
+	if err == nil {
+		err = b.cache.Expire(ctx, keySerials, ttl)
+	}
+	if err != nil {
+		if isFirst {
+			_ = b.cache.Delete(ctx, keyStart)
+		}
+		b.removeWaiter(fabricName, resultCh)
+		return fmt.Errorf("deploy batch: add target: %w", err)
+	}
+	// Best-effort: the count only feeds the batch size metric
+	_, _, _ = b.cache.IncrByEx(ctx, b.keyRequests(fabricName, batchID), ttl)
+
 	// Update last request time (raw string, not JSON)
 	if err := b.cache.SetString(ctx, keyLast, nowStr, ttl); err != nil {
 		if isFirst {
@@ -164,7 +339,7 @@ func (b *DeployBatcher) coordinateDeploy(fabricName, batchID string) {
 	keyLock := b.keyLock(fabricName)
 	keyResult := b.keyResult(fabricName, batchID)
 
-	ticker := time.NewTicker(500 * time.Millisecond)
+	ticker := time.NewTicker(b.pollInterval)
 	defer ticker.Stop()
 
 	for {
@@ -226,8 +401,13 @@ func (b *DeployBatcher) coordinateDeploy(fabricName, batchID string) {
 		logger.Info("Executing batched deploy",
 			zap.String("fabric", fabricName),
 			zap.String("batchID", batchID))
+		b.observeBatch(ctx, fabricName, batchID)
 
-		deployErr := b.ndClient.ConfigDeploy(ctx, fabricName, nil)
+		// Don't send a deploy that the circuit breaker would reject immediately
+		deployErr := b.waitForNDFC(ctx, fabricName)
+		if deployErr == nil && !b.noPendingChanges(ctx, fabricName) {
+			deployErr = b.deployTargets(ctx, fabricName, b.pendingTargets(ctx, fabricName, batchID))
+		}
 
 		// Store result (raw string, not JSON)
 		result := "ok"
@@ -269,6 +449,135 @@ func (b *DeployBatcher) coordinateDeploy(fabricName, batchID string) {
 	}
 }
 
+// observeBatch records the size of a batch about to be deployed and how long its first
+// request has waited
+func (b *DeployBatcher) observeBatch(ctx context.Context, fabricName, batchID string) {
+	size := 1
+	if count, err := b.cache.GetString(ctx, b.keyRequests(fabricName, batchID)); err == nil {
+		if n, err := strconv.Atoi(count); err == nil && n > 0 {
+			size = n
+		}
+	}
+	metrics.DeployBatchSize.Observe(float64(size))
+
+	if start, err := strconv.ParseInt(batchID, 10, 64); err == nil {
+		metrics.DeployBatchWait.Observe(time.Since(time.UnixMilli(start)).Seconds())
+	}
+}
+
+// pendingTargets returns the switches recorded for a batch, or nil for a whole-fabric deploy
+func (b *DeployBatcher) pendingTargets(ctx context.Context, fabricName, batchID string) []string {
+	members, err := b.cache.SMembers(ctx, b.keySerials(fabricName, batchID))
+	if err != nil {
+		logger.Warn("Deploy batch: failed to read targets, deploying whole fabric",
+			zap.String("fabric", fabricName),
+			zap.String("batchID", batchID),
+			zap.Error(err))
+		members = nil
+	}
+	return batchTargets(members)
+}
+
+// batchTargets returns the sorted union of switch serial numbers requested in a batch, or nil
+// if the batch needs a whole-fabric deploy
+func batchTargets(members []string) []string {
+	if len(members) == 0 || slices.Contains(members, deployAllSwitches) {
+		return nil
+	}
+	serials := slices.Clone(members)
+	slices.Sort(serials)
+	return slices.Compact(serials)
+}
+
+// noPendingChanges reports whether NDFC's config preview shows every switch of the fabric in
+// sync, so the deploy can be skipped. An unknown state (the check failing) counts as pending.
+func (b *DeployBatcher) noPendingChanges(ctx context.Context, fabricName string) bool {
+	state, err := b.ndClient.GetFabricConfigState(ctx, fabricName)
+	if err != nil {
+		logger.Warn("Deploy batch: failed to get fabric config state, deploying anyway",
+			zap.String("fabric", fabricName),
+			zap.Error(err))
+		return false
+	}
+	if state.Pending() {
+		return false
+	}
+	logger.Info("Deploy batch: all switches in sync in NDFC, skipping deploy",
+		zap.String("fabric", fabricName))
+	metrics.DeploysSkippedNoChangesTotal.WithLabelValues(fabricName).Inc()
+	return true
+}
+
+// deployTargets runs config-deploy for the given switches, or the whole fabric if serials is
+// empty or covers all of its switches. Config-save runs first if the fabric needs it.
+func (b *DeployBatcher) deployTargets(ctx context.Context, fabricName string, serials []string) error {
+	if len(serials) > 0 && b.switchCounter != nil {
+		total, err := b.switchCounter(ctx, fabricName)
+		if err != nil {
+			logger.Warn("Deploy batch: failed to count fabric switches, deploying whole fabric",
+				zap.String("fabric", fabricName),
+				zap.Error(err))
+			serials = nil
+		} else if len(serials) >= total {
+			serials = nil
+		}
+	}
+
+	cfg, ok := b.fabricDeploy[fabricName]
+	if !ok {
+		cfg = b.defaultDeploy
+	}
+	if cfg.ConfigSaveBeforeDeploy {
+		if err := b.ndClient.ConfigSave(ctx, fabricName); err != nil {
+			return err
+		}
+	}
+
+	if len(serials) == 0 {
+		return b.ndClient.ConfigDeploy(ctx, fabricName, nil)
+	}
+	logger.Debug("Deploying targeted switches",
+		zap.String("fabric", fabricName),
+		zap.Strings("switches", serials))
+	return b.ndClient.ConfigDeployForSwitches(ctx, fabricName, serials)
+}
+
+// waitForNDFC waits up to maxWaitTime for the NDFC circuit breaker to close.
+// Returns ErrCircuitOpen if it is still open after that.
+func (b *DeployBatcher) waitForNDFC(ctx context.Context, fabricName string) error {
+	if b.ndClient.CheckNDFCAvailable() == nil {
+		return nil
+	}
+
+	logger.Warn("Deploy batch: NDFC circuit breaker open, waiting before deploy",
+		zap.String("fabric", fabricName),
+		zap.Duration("maxWait", b.maxWaitTime))
+
+	deadline := time.Now().Add(b.maxWaitTime)
+	for {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			break
+		}
+		if wait > b.circuitPollInterval {
+			wait = b.circuitPollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if b.ndClient.CheckNDFCAvailable() == nil {
+			return nil
+		}
+	}
+
+	metrics.DeployCircuitOpenTotal.Inc()
+	return ndclient.ErrCircuitOpen
+}
+
 // shouldDeploy checks if debounce or max wait conditions are met
 func (b *DeployBatcher) shouldDeploy(ctx context.Context, keyStart, keyLast string) (bool, error) {
 	now := time.Now().UnixMilli()
@@ -329,7 +638,12 @@ func (b *DeployBatcher) notifyWaiters(fabricName, result string) {
 	b.mu.Unlock()
 
 	var err error
-	if result != "ok" {
+	switch result {
+	case "ok":
+	case ndclient.ErrCircuitOpen.Error():
+		// Keep the sentinel so callers can use ndclient.IsCircuitOpenError
+		err = ndclient.ErrCircuitOpen
+	default:
 		err = errors.New(result)
 	}
 
@@ -381,7 +695,7 @@ func (b *DeployBatcher) watchForResult(fabricName, batchID string) {
 	}()
 
 	keyResult := b.keyResult(fabricName, batchID)
-	ticker := time.NewTicker(500 * time.Millisecond)
+	ticker := time.NewTicker(b.resultWatchPollInterval)
 	defer ticker.Stop()
 
 	for {
@@ -2,10 +2,22 @@ package services
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // mockDeployClient implements the minimal interface needed for testing
@@ -148,3 +160,487 @@ func TestDeployBatcher_ConcurrentRequests(t *testing.T) {
 		t.Errorf("expected %d deploys (mock doesn't batch), got %d", numRequests, mock.getDeployCount())
 	}
 }
+
+// newTestBatcherEnv starts a fake NDFC that records config-deploy calls. Batchers under test
+// coordinate through cachetest.NewInMemoryCache instead of Valkey.
+func newTestBatcherEnv(t *testing.T) (*ndclient.Client, *int32, chan time.Time) {
+	t.Helper()
+
+	var deployCount int32
+	deployed := make(chan time.Time, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/config-deploy") {
+			atomic.AddInt32(&deployCount, 1)
+			deployed <- time.Now()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client, &deployCount, deployed
+}
+
+// TestNewDeployBatcher_Defaults tests that unset options fall back to defaults
+func TestNewDeployBatcher_Defaults(t *testing.T) {
+	b := NewDeployBatcher(nil, time.Second, 5*time.Second)
+	if b.pollInterval != DefaultDeployPollInterval {
+		t.Errorf("pollInterval = %v, want %v", b.pollInterval, DefaultDeployPollInterval)
+	}
+	if b.resultWatchPollInterval != DefaultDeployResultWatchPollInterval {
+		t.Errorf("resultWatchPollInterval = %v, want %v", b.resultWatchPollInterval, DefaultDeployResultWatchPollInterval)
+	}
+
+	// Non-positive overrides are ignored
+	b = NewDeployBatcher(nil, time.Second, 5*time.Second, WithPollInterval(0), WithResultWatchPollInterval(-1))
+	if b.pollInterval != DefaultDeployPollInterval || b.resultWatchPollInterval != DefaultDeployResultWatchPollInterval {
+		t.Errorf("expected defaults for non-positive overrides, got %v / %v", b.pollInterval, b.resultWatchPollInterval)
+	}
+
+	b = NewDeployBatcher(nil, time.Second, 5*time.Second, WithPollInterval(10*time.Millisecond))
+	if b.pollInterval != 10*time.Millisecond {
+		t.Errorf("pollInterval = %v, want 10ms", b.pollInterval)
+	}
+	if b.resultWatchPollInterval != DefaultDeployResultWatchPollInterval {
+		t.Errorf("resultWatchPollInterval should keep default, got %v", b.resultWatchPollInterval)
+	}
+}
+
+func TestDeployBatcher_WithoutNDClient(t *testing.T) {
+	b := NewDeployBatcher(nil, 10*time.Millisecond, 50*time.Millisecond, WithCacheClient(cachetest.NewInMemoryCache()))
+	if err := b.RequestDeploy(context.Background(), "test-fabric"); err == nil {
+		t.Error("RequestDeploy without a Nexus Dashboard client succeeded, want an error")
+	}
+}
+
+// TestDeployBatcher_DeployFiresAfterDebounce tests that a single request deploys once the
+// debounce elapses, using a short poll interval to keep the test fast
+func TestDeployBatcher_DeployFiresAfterDebounce(t *testing.T) {
+	client, deployCount, deployed := newTestBatcherEnv(t)
+
+	debounce := 100 * time.Millisecond
+	b := NewDeployBatcher(client, debounce, time.Second,
+		WithCacheClient(cachetest.NewInMemoryCache()),
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := b.RequestDeploy(ctx, "test-fabric"); err != nil {
+		t.Fatalf("RequestDeploy: %v", err)
+	}
+
+	deployAt := <-deployed
+	elapsed := deployAt.Sub(start)
+	if elapsed < debounce {
+		t.Errorf("deployed too early: %v < %v", elapsed, debounce)
+	}
+	if elapsed > debounce+200*time.Millisecond {
+		t.Errorf("deployed too late: %v (debounce %v, poll 10ms)", elapsed, debounce)
+	}
+	if n := atomic.LoadInt32(deployCount); n != 1 {
+		t.Errorf("expected 1 deploy, got %d", n)
+	}
+}
+
+// TestDeployBatcher_ConcurrentRequestsCoalesce tests that concurrent requests share one deploy
+func TestDeployBatcher_ConcurrentRequestsCoalesce(t *testing.T) {
+	client, deployCount, _ := newTestBatcherEnv(t)
+
+	b := NewDeployBatcher(client, 50*time.Millisecond, time.Second,
+		WithCacheClient(cachetest.NewInMemoryCache()),
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- b.RequestDeploy(ctx, "test-fabric")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("RequestDeploy: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(deployCount); n != 1 {
+		t.Errorf("expected 1 coalesced deploy, got %d", n)
+	}
+}
+
+// TestDeployBatcher_CircuitOpenFailsWaiters tests that waiters get ErrCircuitOpen once the
+// breaker stays open past maxWaitTime, without a deploy being sent
+func TestDeployBatcher_CircuitOpenFailsWaiters(t *testing.T) {
+	// NDFC is down: every request fails with 503
+	var deployCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/config-deploy") {
+			atomic.AddInt32(&deployCount, 1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	for i := 0; i < ndclient.DefaultCircuitFailureThreshold; i++ {
+		_ = client.Get(context.Background(), "/health", nil)
+	}
+	if !ndclient.IsCircuitOpenError(client.CheckNDFCAvailable()) {
+		t.Fatal("expected circuit breaker to be open")
+	}
+
+	debounce := 20 * time.Millisecond
+	maxWait := 200 * time.Millisecond
+	b := NewDeployBatcher(client, debounce, maxWait,
+		WithCacheClient(cachetest.NewInMemoryCache()),
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+		WithCircuitPollInterval(20*time.Millisecond),
+	)
+	before := testutil.ToFloat64(metrics.DeployCircuitOpenTotal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const numWaiters = 3
+	errs := make(chan error, numWaiters)
+	start := time.Now()
+	for i := 0; i < numWaiters; i++ {
+		go func() { errs <- b.RequestDeploy(ctx, "test-fabric") }()
+	}
+	for i := 0; i < numWaiters; i++ {
+		if err := <-errs; !ndclient.IsCircuitOpenError(err) {
+			t.Errorf("waiter %d: expected ErrCircuitOpen, got %v", i, err)
+		}
+	}
+
+	// Waiters should be released after roughly debounce + maxWait, not the coordinator timeout
+	if elapsed := time.Since(start); elapsed > debounce+maxWait+time.Second {
+		t.Errorf("waiters released too late: %v", elapsed)
+	}
+	if n := atomic.LoadInt32(&deployCount); n != 0 {
+		t.Errorf("expected no deploy calls while circuit open, got %d", n)
+	}
+	if d := testutil.ToFloat64(metrics.DeployCircuitOpenTotal) - before; d != 1 {
+		t.Errorf("expected circuit open counter +1, got %+v", d)
+	}
+}
+
+// newConfigSaveTestClient starts a fake NDFC that records config-save and config-deploy calls
+// in order as "save:{fabric}" and "deploy:{fabric}"
+func newConfigSaveTestClient(t *testing.T) (*ndclient.Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) >= 2 {
+			fabric, op := parts[len(parts)-2], parts[len(parts)-1]
+			mu.Lock()
+			switch op {
+			case "config-save":
+				calls = append(calls, "save:"+fabric)
+			case "config-deploy":
+				calls = append(calls, "deploy:"+fabric)
+			}
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+// TestDeployBatcher_ConfigSaveBeforeDeploy tests that config-save precedes config-deploy only
+// for fabrics configured to need it
+func TestDeployBatcher_ConfigSaveBeforeDeploy(t *testing.T) {
+	client, calls := newConfigSaveTestClient(t)
+
+	b := NewDeployBatcher(client, 10*time.Millisecond, time.Second,
+		WithCacheClient(cachetest.NewInMemoryCache()),
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+		WithFabricDeployConfig("fabric-a", FabricDeployConfig{ConfigSaveBeforeDeploy: true}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for _, fabric := range []string{"fabric-a", "fabric-b"} {
+		if err := b.RequestDeploy(ctx, fabric); err != nil {
+			t.Fatalf("RequestDeploy %s: %v", fabric, err)
+		}
+	}
+
+	want := []string{"save:fabric-a", "deploy:fabric-a", "deploy:fabric-b"}
+	if got := calls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("NDFC calls = %v, want %v", got, want)
+	}
+}
+
+// TestDeployBatcher_ConfigSaveDefault tests the default deploy config, its per-fabric
+// override, and the immediate deploy used without Valkey
+func TestDeployBatcher_ConfigSaveDefault(t *testing.T) {
+	prev := cache.Client
+	cache.Client = nil
+	t.Cleanup(func() { cache.Client = prev })
+	client, calls := newConfigSaveTestClient(t)
+
+	b := NewDeployBatcher(client, time.Second, 5*time.Second,
+		WithDefaultFabricDeployConfig(FabricDeployConfig{ConfigSaveBeforeDeploy: true}),
+		WithFabricDeployConfig("fabric-b", FabricDeployConfig{}),
+	)
+
+	for _, fabric := range []string{"fabric-a", "fabric-b"} {
+		if err := b.RequestDeploy(context.Background(), fabric); err != nil {
+			t.Fatalf("RequestDeploy %s: %v", fabric, err)
+		}
+	}
+
+	want := []string{"save:fabric-a", "deploy:fabric-a", "deploy:fabric-b"}
+	if got := calls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("NDFC calls = %v, want %v", got, want)
+	}
+}
+
+// TestDeployBatcher_TargetedDeploy tests that batched per-switch requests deploy the union of
+// their switches, falling back to a full fabric deploy when they cover every switch or the
+// batch also holds a whole-fabric request
+func TestDeployBatcher_TargetedDeploy(t *testing.T) {
+
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/config-deploy") {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, strings.TrimSpace(string(body)))
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	const fabricSwitches = 3
+
+	tests := []struct {
+		name     string
+		switches []string // "" = whole-fabric request
+		want     string
+	}{
+		{"targeted", []string{"SN2", "SN1", "SN2"}, `[{"serialNumber":"SN1"},{"serialNumber":"SN2"}]`},
+		{"all switches", []string{"SN1", "SN2", "SN3"}, `{}`},
+		{"mixed with fabric request", []string{"SN1", ""}, `{}`},
+	}
+	for _, tt := range tests {
+		mu.Lock()
+		bodies = nil
+		mu.Unlock()
+
+		// A fresh cache per case, so the next batch does not see this one's keys
+		b := NewDeployBatcher(client, 50*time.Millisecond, time.Second,
+			WithPollInterval(10*time.Millisecond),
+			WithResultWatchPollInterval(10*time.Millisecond),
+			WithSwitchCounter(func(context.Context, string) (int, error) { return fabricSwitches, nil }),
+			WithCacheClient(cachetest.NewInMemoryCache()),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		var wg sync.WaitGroup
+		for _, serial := range tt.switches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := b.RequestDeployForSwitch(ctx, "test-fabric", serial); err != nil {
+					t.Errorf("%s: RequestDeployForSwitch(%q): %v", tt.name, serial, err)
+				}
+			}()
+		}
+		wg.Wait()
+		cancel()
+
+		mu.Lock()
+		got := slices.Clone(bodies)
+		mu.Unlock()
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: config-deploy bodies = %v, want [%s]", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestConfigureInterfaces_DeploysOnlyJobSwitches tests that configureInterfaces waits for a
+// deploy targeted at the switches it configured
+func TestConfigureInterfaces_DeploysOnlyJobSwitches(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/config-deploy"):
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, strings.TrimSpace(string(body)))
+			mu.Unlock()
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/networks"):
+			_, _ = w.Write([]byte(`[{"networkName":"net1","networkTemplateConfig":"{\"vlanId\":\"2301\"}"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	svc := NewJobService(nil, client, &config.NexusDashboardConfig{}, nil)
+	svc.deployBatcher = NewDeployBatcher(client, 20*time.Millisecond, time.Second,
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+		WithSwitchCounter(func(context.Context, string) (int, error) { return 3, nil }),
+		WithCacheClient(cachetest.NewInMemoryCache()),
+	)
+
+	ports := []portInfo{
+		{serialNumber: "SN2", interfaceName: "Ethernet1/1"},
+		{serialNumber: "SN1", interfaceName: "Ethernet1/1"},
+		{serialNumber: "SN2", interfaceName: "Ethernet1/2"},
+	}
+	n, err := svc.configureInterfaces(context.Background(), ports, "test-fabric", "net1", "1001", "HPC Job 1001")
+	if err != nil {
+		t.Fatalf("configureInterfaces: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("attached %d ports, want 3", n)
+	}
+
+	// The deploy has completed by the time configureInterfaces returns
+	mu.Lock()
+	got := slices.Clone(bodies)
+	mu.Unlock()
+	if want := `[{"serialNumber":"SN1"},{"serialNumber":"SN2"}]`; len(got) != 1 || got[0] != want {
+		t.Errorf("config-deploy bodies = %v, want [%s]", got, want)
+	}
+}
+
+// TestDeployBatcher_TargetedDeployWithoutValkey tests that per-switch requests deploy their
+// switch immediately when Valkey is unavailable
+func TestDeployBatcher_TargetedDeployWithoutValkey(t *testing.T) {
+	prev := cache.Client
+	cache.Client = nil
+	t.Cleanup(func() { cache.Client = prev })
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	b := NewDeployBatcher(client, time.Second, 5*time.Second)
+	if err := b.RequestDeployForSwitch(context.Background(), "test-fabric", "SN1"); err != nil {
+		t.Fatalf("RequestDeployForSwitch: %v", err)
+	}
+	if got := strings.TrimSpace(string(body)); got != `[{"serialNumber":"SN1"}]` {
+		t.Errorf("config-deploy body = %s", got)
+	}
+}
+
+// TestDeployBatcher_SkipsDeployWithoutPendingChanges tests that waiters succeed without a
+// config-deploy when NDFC's config preview shows every switch in sync, and that an
+// out-of-sync switch still deploys
+func TestDeployBatcher_SkipsDeployWithoutPendingChanges(t *testing.T) {
+	var deployCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/clean-fabric/config-preview"):
+			_, _ = w.Write([]byte(`[{"switchId":"SN1","status":"In-Sync"},{"switchId":"SN2","status":"In-Sync"}]`))
+		case strings.HasSuffix(r.URL.Path, "/dirty-fabric/config-preview"):
+			_, _ = w.Write([]byte(`[{"switchId":"SN1","status":"Out-of-Sync"},{"switchId":"SN2","status":"In-Sync"}]`))
+		case strings.HasSuffix(r.URL.Path, "/config-deploy"):
+			atomic.AddInt32(&deployCount, 1)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	b := NewDeployBatcher(client, 10*time.Millisecond, time.Second,
+		WithCacheClient(cachetest.NewInMemoryCache()),
+		WithPollInterval(10*time.Millisecond),
+		WithResultWatchPollInterval(10*time.Millisecond),
+	)
+	skipped := metrics.DeploysSkippedNoChangesTotal.WithLabelValues("clean-fabric")
+	before := testutil.ToFloat64(skipped)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	const numWaiters = 3
+	errs := make(chan error, numWaiters)
+	for i := 0; i < numWaiters; i++ {
+		go func() { errs <- b.RequestDeploy(ctx, "clean-fabric") }()
+	}
+	for i := 0; i < numWaiters; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("waiter %d: %v", i, err)
+		}
+	}
+	if n := atomic.LoadInt32(&deployCount); n != 0 {
+		t.Errorf("expected no deploy without pending changes, got %d", n)
+	}
+	if d := testutil.ToFloat64(skipped) - before; d != 1 {
+		t.Errorf("expected skipped counter +1, got %+v", d)
+	}
+
+	if err := b.RequestDeploy(ctx, "dirty-fabric"); err != nil {
+		t.Fatalf("RequestDeploy dirty-fabric: %v", err)
+	}
+	if n := atomic.LoadInt32(&deployCount); n != 1 {
+		t.Errorf("expected 1 deploy with a switch pending, got %d", n)
+	}
+}
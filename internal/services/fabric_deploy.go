@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FabricDeployer queues NDFC config deploys for a fabric. DeployBatcher implements it.
+type FabricDeployer interface {
+	RequestDeploy(ctx context.Context, fabricName string) error
+	RequestDeployForSwitch(ctx context.Context, fabricName, serialNumber string) error
+}
+
+var _ FabricDeployer = (*DeployBatcher)(nil)
+
+// DeployFabric deploys the fabric's configuration, or only the given switches if serialNumbers
+// is not empty, and returns how long the deploy took. Deploys are batched with provisioning
+// deploys, so the time includes waiting for the batch.
+func DeployFabric(ctx context.Context, deployer FabricDeployer, fabricName string, serialNumbers []string) (time.Duration, error) {
+	start := time.Now()
+	if len(serialNumbers) == 0 {
+		if err := deployer.RequestDeploy(ctx, fabricName); err != nil {
+			return time.Since(start), fmt.Errorf("deploy fabric %s: %w", fabricName, err)
+		}
+		return time.Since(start), nil
+	}
+
+	// Switch requests join the same batch, so wait for them together
+	errs := make([]error, len(serialNumbers))
+	var wg sync.WaitGroup
+	for i, serialNumber := range serialNumbers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := deployer.RequestDeployForSwitch(ctx, fabricName, serialNumber); err != nil {
+				errs[i] = fmt.Errorf("deploy switch %s: %w", serialNumber, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return time.Since(start), errors.Join(errs...)
+}
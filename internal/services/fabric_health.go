@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// fabricHealthNDFCTimeout bounds the NDFC reachability probe so an unreachable
+// NDFC does not hold the health check for the full client timeout
+const fabricHealthNDFCTimeout = 5 * time.Second
+
+// orphanedSGMinAge is how old a security group without a job must be to count as orphaned,
+// leaving time for a job being provisioned to link its group
+const orphanedSGMinAge = time.Hour
+
+// FabricHealth summarizes NDFC connectivity and local sync state
+type FabricHealth struct {
+	NDFCReachable  bool
+	NDFCVersion    string
+	LastFabricSync *time.Time // Most recent fabric upsert from NDFC
+	LastSwitchSync *time.Time // Most recent successful switch port sync
+	StalePorts     int64      // Ports not seen by sync within backgroundsync.StalePortThreshold
+	OrphanedSGs    int64      // Security groups older than an hour that no job references
+	PendingDeploys int64      // Deploy batches waiting in the DeployBatcher
+}
+
+// Health reports NDFC connectivity and sync status. fabricIDOrName scopes the local
+// counts to one fabric; empty covers all fabrics. Each check degrades independently:
+// a failed check is logged and leaves its field zero. ndClient and cacheClient may be nil.
+func (s *FabricService) Health(ctx context.Context, ndClient *ndclient.Client, cacheClient *cache.ValkeyClient, fabricIDOrName string) (*FabricHealth, error) {
+	health := &FabricHealth{}
+
+	var fabric *models.Fabric
+	if fabricIDOrName != "" {
+		f, err := s.findFabric(ctx, fabricIDOrName)
+		if err != nil {
+			return nil, err
+		}
+		fabric = f
+	}
+
+	logFailure := func(check string, err error) {
+		logger.Warn("Fabric health check failed", zap.String("check", check), zap.Error(err))
+	}
+
+	if ndClient != nil {
+		probeCtx, cancel := context.WithTimeout(ctx, fabricHealthNDFCTimeout)
+		version, err := ndClient.DetectAPIVersion(probeCtx)
+		cancel()
+		if err != nil {
+			logFailure("ndfc", err)
+		} else {
+			health.NDFCReachable = true
+			health.NDFCVersion = version
+		}
+	} else {
+		logFailure("ndfc", errors.New("Nexus Dashboard client not configured"))
+	}
+
+	db := s.db.WithContext(ctx)
+
+	fabricQuery := db.Model(&models.Fabric{})
+	if fabric != nil {
+		fabricQuery = fabricQuery.Where("id = ?", fabric.ID)
+	}
+	var lastFabric models.Fabric
+	if err := fabricQuery.Select("updated_at").Order("updated_at DESC").Take(&lastFabric).Error; err == nil {
+		health.LastFabricSync = &lastFabric.UpdatedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logFailure("last_fabric_sync", err)
+	}
+
+	switchQuery := db.Model(&models.Switch{}).Where("last_synced_at IS NOT NULL")
+	if fabric != nil {
+		switchQuery = switchQuery.Where("fabric_id = ?", fabric.ID)
+	}
+	var lastSwitch models.Switch
+	if err := switchQuery.Select("last_synced_at").Order("last_synced_at DESC").Take(&lastSwitch).Error; err == nil {
+		health.LastSwitchSync = lastSwitch.LastSyncedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logFailure("last_switch_sync", err)
+	}
+
+	portQuery := db.Model(&models.SwitchPort{}).
+		Where("switch_ports.last_seen_at < ?", time.Now().Add(-backgroundsync.StalePortThreshold))
+	if fabric != nil {
+		portQuery = portQuery.
+			Joins("JOIN switches ON switches.id = switch_ports.switch_id AND switches.deleted_at IS NULL").
+			Where("switches.fabric_id = ?", fabric.ID)
+	}
+	if err := portQuery.Count(&health.StalePorts).Error; err != nil {
+		logFailure("stale_ports", err)
+	}
+
+	sgQuery := db.Model(&models.SecurityGroup{}).
+		Where("security_groups.created_at < ?", time.Now().Add(-orphanedSGMinAge)).
+		Where("NOT EXISTS (SELECT 1 FROM jobs WHERE jobs.security_group_id = security_groups.id AND jobs.deleted_at IS NULL)")
+	if fabric != nil {
+		sgQuery = sgQuery.Where("security_groups.fabric_name = ?", fabric.Name)
+	}
+	if err := sgQuery.Count(&health.OrphanedSGs).Error; err != nil {
+		logFailure("orphaned_sgs", err)
+	}
+
+	if cacheClient != nil {
+		fabricName := ""
+		if fabric != nil {
+			fabricName = fabric.Name
+		}
+		pending, err := PendingDeploys(ctx, cacheClient, fabricName)
+		if err != nil {
+			logFailure("pending_deploys", err)
+		}
+		health.PendingDeploys = pending
+	}
+
+	return health, nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"gorm.io/gorm"
+)
+
+// seedFabricHealth creates fabric f1 with one synced switch, one fresh and one stale
+// port, and security groups that are linked, orphaned and too new to count
+func seedFabricHealth(t *testing.T) (*gorm.DB, time.Time) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Fabric{}, &models.Switch{}, &models.SwitchPort{},
+		&models.SecurityGroup{}, &models.Job{})
+
+	now := time.Now()
+	synced := now.Add(-10 * time.Minute)
+	fresh := now.Add(-time.Hour)
+	stale := now.Add(-48 * time.Hour)
+	old := now.Add(-2 * time.Hour)
+	sgLinked := "sg-linked"
+	records := []interface{}{
+		&models.Fabric{ID: "f1", Name: "fab1"},
+		&models.Switch{ID: "sw1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", LastSyncedAt: &synced},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "sw1", IsPresent: true, LastSeenAt: &fresh},
+		&models.SwitchPort{ID: "p2", Name: "Ethernet1/2", SwitchID: "sw1", LastSeenAt: &stale},
+		&models.SecurityGroup{ID: sgLinked, Name: "linked", FabricName: "fab1", CreatedAt: old},
+		&models.SecurityGroup{ID: "sg-orphan", Name: "orphan", FabricName: "fab1", CreatedAt: old},
+		&models.SecurityGroup{ID: "sg-new", Name: "new", FabricName: "fab1", CreatedAt: now},
+		&models.Job{ID: "j1", SlurmJobID: "100", Status: string(models.JobStatusActive), FabricName: "fab1", SecurityGroupID: &sgLinked},
+	}
+	for _, r := range records {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+	return db, synced
+}
+
+func TestFabricHealth_NDFCUnreachable(t *testing.T) {
+	db, synced := seedFabricHealth(t)
+
+	// A closed server refuses connections like an NDFC that is down
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	health, err := NewFabricService(db).Health(context.Background(), client, nil, "fab1")
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.NDFCReachable || health.NDFCVersion != "" {
+		t.Errorf("ndfc reachable = %v, version = %q; want unreachable", health.NDFCReachable, health.NDFCVersion)
+	}
+
+	// Local checks still report
+	if health.LastFabricSync == nil {
+		t.Error("expected last fabric sync")
+	}
+	if health.LastSwitchSync == nil || !health.LastSwitchSync.Equal(synced) {
+		t.Errorf("last switch sync = %v, want %v", health.LastSwitchSync, synced)
+	}
+	if health.StalePorts != 1 {
+		t.Errorf("stale ports = %d, want 1", health.StalePorts)
+	}
+	if health.OrphanedSGs != 1 {
+		t.Errorf("orphaned SGs = %d, want 1", health.OrphanedSGs)
+	}
+	if health.PendingDeploys != 0 {
+		t.Errorf("pending deploys = %d, want 0 without a cache", health.PendingDeploys)
+	}
+}
+
+func TestFabricHealth_Reachable(t *testing.T) {
+	db, _ := seedFabricHealth(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/appcenter/cisco/ndfc/api/v1/fm/about/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "12.2.2", "mode": "LAN"}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	mr, _ := cachetest.NewMiniredis(t)
+	_ = mr.Set(cache.DeployBatchStart("fab1"), "1700000000")
+	_ = mr.Set(cache.DeployBatchStart("fab2"), "1700000000")
+	_ = mr.Set("deploy:batch:fab1:last", "1700000001")
+
+	svc := NewFabricService(db)
+	health, err := svc.Health(context.Background(), client, cache.Client, "")
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if !health.NDFCReachable || health.NDFCVersion != "12.2.2" {
+		t.Errorf("ndfc reachable = %v, version = %q; want 12.2.2", health.NDFCReachable, health.NDFCVersion)
+	}
+	if health.PendingDeploys != 2 {
+		t.Errorf("pending deploys (all fabrics) = %d, want 2", health.PendingDeploys)
+	}
+
+	scoped, err := svc.Health(context.Background(), client, cache.Client, "f1")
+	if err != nil {
+		t.Fatalf("Health(f1): %v", err)
+	}
+	if scoped.PendingDeploys != 1 {
+		t.Errorf("pending deploys (fab1) = %d, want 1", scoped.PendingDeploys)
+	}
+
+	if _, err := svc.Health(context.Background(), client, cache.Client, "missing"); !errors.Is(err, ErrFabricNotFound) {
+		t.Errorf("unknown fabric err = %v, want ErrFabricNotFound", err)
+	}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/models"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"gorm.io/gorm"
+)
+
+// Fabric service errors
+var (
+	ErrFabricNotFound = errors.New("fabric not found")
+	ErrFabricInUse    = errors.New("fabric has active jobs or allocations")
+)
+
+// FabricDeleteResult describes the outcome of DeleteFabric
+type FabricDeleteResult struct {
+	FabricID         string   `json:"fabric_id"`
+	FabricName       string   `json:"fabric_name"`
+	Forced           bool     `json:"forced"`
+	BlockingReasons  []string `json:"blocking_reasons,omitempty"` // Set when deletion was refused
+	DeletedSwitches  int64    `json:"deleted_switches"`
+	DeletedPorts     int64    `json:"deleted_ports"`
+	UnlinkedMappings int64    `json:"unlinked_mappings"`
+}
+
+// FabricService handles local fabric inventory operations
+type FabricService struct {
+	db *gorm.DB
+}
+
+// NewFabricService creates a new FabricService
+func NewFabricService(db *gorm.DB) *FabricService {
+	return &FabricService{db: db}
+}
+
+// findFabric looks up a fabric by ID first, then by name
+func (s *FabricService) findFabric(ctx context.Context, idOrName string) (*models.Fabric, error) {
+	var fabric models.Fabric
+	db := s.db.WithContext(ctx)
+	if err := db.Where("id = ?", idOrName).First(&fabric).Error; err == nil {
+		return &fabric, nil
+	}
+	if err := db.Where("name = ?", idOrName).First(&fabric).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFabricNotFound
+		}
+		return nil, err
+	}
+	return &fabric, nil
+}
+
+// GetFabric looks up a fabric by ID or name. Returns ErrFabricNotFound if neither matches.
+func (s *FabricService) GetFabric(ctx context.Context, idOrName string) (*models.Fabric, error) {
+	return s.findFabric(ctx, idOrName)
+}
+
+// FabricRoleSummary counts a fabric's switches by role, plus its leaf port capacity
+type FabricRoleSummary struct {
+	Leaf               int64 `json:"leaf"`
+	Spine              int64 `json:"spine"`
+	Border             int64 `json:"border"`
+	Unknown            int64 `json:"unknown"` // Switches without a recognized role
+	Total              int64 `json:"total"`
+	LeafPortsTotal     int64 `json:"leaf_ports_total"`     // Present ports on leaf switches
+	LeafPortsAvailable int64 `json:"leaf_ports_available"` // Of those, ports no compute node is mapped to
+}
+
+// GetSwitchRoleSummary counts the switches of a fabric (by ID or name) per role and the
+// present and unmapped ports on its leaf switches. Returns ErrFabricNotFound if neither matches.
+func (s *FabricService) GetSwitchRoleSummary(ctx context.Context, fabricID string) (*FabricRoleSummary, error) {
+	fabric, err := s.findFabric(ctx, fabricID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Role  string
+		Count int64
+	}
+	if err := s.db.WithContext(ctx).Model(&models.Switch{}).
+		Select("role, COUNT(*) AS count").
+		Where("fabric_id = ?", fabric.ID).
+		Group("role").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("count switches by role: %w", err)
+	}
+
+	summary := &FabricRoleSummary{}
+	for _, row := range rows {
+		switch row.Role {
+		case models.SwitchRoleLeaf:
+			summary.Leaf += row.Count
+		case models.SwitchRoleSpine:
+			summary.Spine += row.Count
+		case models.SwitchRoleBorder:
+			summary.Border += row.Count
+		default:
+			summary.Unknown += row.Count
+		}
+		summary.Total += row.Count
+	}
+
+	summary.LeafPortsTotal, summary.LeafPortsAvailable, err = backgroundsync.CountLeafPorts(ctx, s.db, fabric.ID)
+	if err != nil {
+		return nil, fmt.Errorf("count leaf ports: %w", err)
+	}
+	return summary, nil
+}
+
+// ValidateFabricDeletion returns the reasons a fabric cannot be safely deleted:
+// active or provisioning jobs on the fabric, and compute node allocations for nodes
+// whose port mappings reference the fabric's switches. An empty list means deletion is safe.
+func (s *FabricService) ValidateFabricDeletion(ctx context.Context, fabricID string) ([]string, error) {
+	fabric, err := s.findFabric(ctx, fabricID)
+	if err != nil {
+		return nil, err
+	}
+	return s.blockingReasons(ctx, fabric)
+}
+
+func (s *FabricService) blockingReasons(ctx context.Context, fabric *models.Fabric) ([]string, error) {
+	db := s.db.WithContext(ctx)
+	var reasons []string
+
+	var jobs []models.Job
+	if err := db.Select("slurm_job_id", "status").
+		Where("fabric_name = ? AND status IN ?", fabric.Name,
+			[]models.JobStatus{models.JobStatusActive, models.JobStatusProvisioning}).
+		Order("slurm_job_id").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		reasons = append(reasons, fmt.Sprintf("job %s is %s on fabric %s", job.SlurmJobID, job.Status, fabric.Name))
+	}
+
+	var allocations []struct {
+		NodeName   string
+		SlurmJobID string
+	}
+	if err := fabricAllocationsQuery(db, fabric.ID).Scan(&allocations).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range allocations {
+		reasons = append(reasons, fmt.Sprintf("compute node %s on fabric %s is allocated to job %s", a.NodeName, fabric.Name, a.SlurmJobID))
+	}
+
+	return reasons, nil
+}
+
+// fabricAllocationsQuery finds allocated compute nodes with port mappings on the fabric's switches
+func fabricAllocationsQuery(db *gorm.DB, fabricID string) *gorm.DB {
+	return db.Table("compute_node_allocations").
+		Distinct("compute_nodes.name AS node_name, jobs.slurm_job_id").
+		Joins("JOIN compute_nodes ON compute_nodes.id = compute_node_allocations.compute_node_id").
+		Joins("JOIN jobs ON jobs.id = compute_node_allocations.job_id").
+		Joins("JOIN compute_node_port_mappings ON compute_node_port_mappings.compute_node_id = compute_node_allocations.compute_node_id AND compute_node_port_mappings.deleted_at IS NULL").
+		Joins("JOIN switch_ports ON switch_ports.id = compute_node_port_mappings.switch_port_id AND switch_ports.deleted_at IS NULL").
+		Joins("JOIN switches ON switches.id = switch_ports.switch_id AND switches.deleted_at IS NULL").
+		Where("switches.fabric_id = ?", fabricID).
+		Order("compute_nodes.name")
+}
+
+// DeleteFabric soft-deletes a fabric by ID or name.
+//
+// Without force, deletion is refused with ErrFabricInUse (and the reasons in the result)
+// if ValidateFabricDeletion reports blocking resources; only the fabric row is deleted.
+// With force, the checks are skipped and the fabric's switches and ports, the port mappings
+// unlinking compute nodes from those ports (switch_port_id is not nullable) and the security
+// group port selectors on them are permanently deleted in the same transaction. Only the
+// fabric itself is soft-deleted, so no soft-deleted row is left referencing a deleted one and
+// switches and ports synced again later do not collide with old rows.
+func (s *FabricService) DeleteFabric(ctx context.Context, idOrName string, force bool) (*FabricDeleteResult, error) {
+	fabric, err := s.findFabric(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FabricDeleteResult{
+		FabricID:   fabric.ID,
+		FabricName: fabric.Name,
+		Forced:     force,
+	}
+
+	if !force {
+		reasons, err := s.blockingReasons(ctx, fabric)
+		if err != nil {
+			return nil, err
+		}
+		if len(reasons) > 0 {
+			result.BlockingReasons = reasons
+			return result, ErrFabricInUse
+		}
+		if err := s.db.WithContext(ctx).Delete(fabric).Error; err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Unscoped, so rows soft-deleted earlier go too
+		switchIDs := tx.Unscoped().Model(&models.Switch{}).Select("id").Where("fabric_id = ?", fabric.ID)
+		portIDs := tx.Unscoped().Model(&models.SwitchPort{}).Select("id").Where("switch_id IN (?)", switchIDs)
+
+		// Children first, so no remaining row references a deleted one
+		res := tx.Unscoped().Where("switch_port_id IN (?)", portIDs).Delete(&models.ComputeNodePortMapping{})
+		if res.Error != nil {
+			return fmt.Errorf("unlink port mappings: %w", res.Error)
+		}
+		result.UnlinkedMappings = res.RowsAffected
+
+		if err := tx.Unscoped().Where("switch_port_id IN (?)", portIDs).Delete(&models.PortSelector{}).Error; err != nil {
+			return fmt.Errorf("delete port selectors: %w", err)
+		}
+
+		res = tx.Unscoped().Where("switch_id IN (?)", switchIDs).Delete(&models.SwitchPort{})
+		if res.Error != nil {
+			return fmt.Errorf("delete ports: %w", res.Error)
+		}
+		result.DeletedPorts = res.RowsAffected
+
+		res = tx.Unscoped().Where("fabric_id = ?", fabric.ID).Delete(&models.Switch{})
+		if res.Error != nil {
+			return fmt.Errorf("delete switches: %w", res.Error)
+		}
+		result.DeletedSwitches = res.RowsAffected
+
+		return tx.Delete(fabric).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+func seedFabric(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t,
+		&models.Fabric{}, &models.Switch{}, &models.SwitchPort{},
+		&models.ComputeNode{}, &models.ComputeNodePortMapping{}, &models.ComputeNodePortMappingHistory{},
+		&models.Job{}, &models.ComputeNodeAllocation{}, &models.SecurityGroup{}, &models.PortSelector{},
+	)
+	for _, v := range []interface{}{
+		&models.Fabric{ID: "f1", Name: "fabric-one"},
+		&models.Fabric{ID: "f2", Name: "fabric-two"},
+		&models.Switch{ID: "sw1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1"},
+		&models.Switch{ID: "sw2", Name: "leaf2", SerialNumber: "SN2", FabricID: "f2"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "sw1"},
+		&models.SwitchPort{ID: "p2", Name: "Ethernet1/1", SwitchID: "sw2"},
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNode{ID: "n2", Name: "node2"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1"},
+		&models.ComputeNodePortMapping{ID: "m2", ComputeNodeID: "n2", SwitchPortID: "p2"},
+		&models.PortSelector{ID: "ps1", SecurityGroupID: "sg1", SwitchPortID: "p1"},
+		&models.PortSelector{ID: "ps2", SecurityGroupID: "sg1", SwitchPortID: "p2"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+	return db
+}
+
+func TestValidateFabricDeletion_Blocked(t *testing.T) {
+	db := seedFabric(t)
+	for _, v := range []interface{}{
+		&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "fabric-one"},
+		&models.Job{ID: "j2", SlurmJobID: "1002", Status: string(models.JobStatusCompleted), FabricName: "fabric-one"},
+		&models.Job{ID: "j3", SlurmJobID: "1003", Status: string(models.JobStatusActive), FabricName: "fabric-two"},
+		&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j3"},
+		&models.ComputeNodeAllocation{ID: "a2", ComputeNodeID: "n2", JobID: "j3"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+
+	reasons, err := NewFabricService(db).ValidateFabricDeletion(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reasons) != 2 {
+		t.Fatalf("reasons = %v, want 2 (active job 1001, node1 allocation)", reasons)
+	}
+	if !strings.Contains(reasons[0], "job 1001") {
+		t.Errorf("reasons[0] = %q, want active job 1001", reasons[0])
+	}
+	if !strings.Contains(reasons[1], "node1") || !strings.Contains(reasons[1], "job 1003") {
+		t.Errorf("reasons[1] = %q, want node1 allocated to 1003", reasons[1])
+	}
+}
+
+func TestDeleteFabric_SafeDeleteFailsWhenBlocked(t *testing.T) {
+	db := seedFabric(t)
+	if err := db.Create(&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "fabric-one"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := NewFabricService(db).DeleteFabric(context.Background(), "fabric-one", false)
+	if !errors.Is(err, ErrFabricInUse) {
+		t.Fatalf("err = %v, want ErrFabricInUse", err)
+	}
+	if len(result.BlockingReasons) != 1 {
+		t.Errorf("blocking reasons = %v, want 1", result.BlockingReasons)
+	}
+
+	var count int64
+	db.Model(&models.Fabric{}).Where("id = ?", "f1").Count(&count)
+	if count != 1 {
+		t.Error("fabric should not be deleted when blocked")
+	}
+}
+
+func TestDeleteFabric_SafeDelete(t *testing.T) {
+	db := seedFabric(t)
+
+	result, err := NewFabricService(db).DeleteFabric(context.Background(), "f1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DeletedSwitches != 0 || result.DeletedPorts != 0 || result.UnlinkedMappings != 0 {
+		t.Errorf("safe delete should not cascade: %+v", result)
+	}
+
+	var fabric models.Fabric
+	if err := db.Unscoped().First(&fabric, "id = ?", "f1").Error; err != nil {
+		t.Fatalf("load fabric: %v", err)
+	}
+	if !fabric.DeletedAt.Valid {
+		t.Error("fabric should be soft-deleted")
+	}
+}
+
+func TestDeleteFabric_ForceCascades(t *testing.T) {
+	db := seedFabric(t)
+	for _, v := range []interface{}{
+		&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "fabric-one"},
+		&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j1"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+
+	result, err := NewFabricService(db).DeleteFabric(context.Background(), "f1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DeletedSwitches != 1 || result.DeletedPorts != 1 || result.UnlinkedMappings != 1 {
+		t.Errorf("result = %+v, want 1 switch, 1 port, 1 mapping", result)
+	}
+
+	// Only fabric-two's rows remain: fabric-one is soft-deleted and its children are gone,
+	// not soft-deleted
+	counts := map[string]int64{"fabrics": countRows(t, db, &models.Fabric{})}
+	for name, model := range map[string]interface{}{
+		"switches":  &models.Switch{},
+		"ports":     &models.SwitchPort{},
+		"mappings":  &models.ComputeNodePortMapping{},
+		"selectors": &models.PortSelector{},
+	} {
+		counts[name] = countRows(t, db.Unscoped(), model)
+	}
+	for name, n := range counts {
+		if n != 1 {
+			t.Errorf("%s remaining = %d, want 1", name, n)
+		}
+	}
+
+	var m2 models.ComputeNodePortMapping
+	if err := db.First(&m2, "id = ?", "m2").Error; err != nil {
+		t.Errorf("other fabric's mapping should remain: %v", err)
+	}
+}
+
+func TestDeleteFabric_NotFound(t *testing.T) {
+	db := seedFabric(t)
+	if _, err := NewFabricService(db).DeleteFabric(context.Background(), "missing", true); !errors.Is(err, ErrFabricNotFound) {
+		t.Errorf("err = %v, want ErrFabricNotFound", err)
+	}
+}
+
+func TestGetSwitchRoleSummary(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Fabric{}, &models.Switch{}, &models.SwitchPort{}, &models.ComputeNodePortMapping{})
+	for _, v := range []interface{}{
+		&models.Fabric{ID: "f1", Name: "fabric-one"},
+		&models.Fabric{ID: "f2", Name: "fabric-two"},
+		&models.Switch{ID: "leaf1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", Role: models.SwitchRoleLeaf},
+		&models.Switch{ID: "leaf2", Name: "leaf2", SerialNumber: "SN2", FabricID: "f1", Role: models.SwitchRoleLeaf},
+		&models.Switch{ID: "spine1", Name: "spine1", SerialNumber: "SN3", FabricID: "f1", Role: models.SwitchRoleSpine},
+		&models.Switch{ID: "border1", Name: "border1", SerialNumber: "SN4", FabricID: "f1", Role: models.SwitchRoleBorder},
+		&models.Switch{ID: "new1", Name: "new1", SerialNumber: "SN5", FabricID: "f1"},
+		&models.Switch{ID: "gone1", Name: "gone1", SerialNumber: "SN6", FabricID: "f1", Role: models.SwitchRoleLeaf},
+		&models.Switch{ID: "other1", Name: "other1", SerialNumber: "SN7", FabricID: "f2", Role: models.SwitchRoleLeaf},
+
+		// leaf1: 4 present ports, 2 of them mapped (p2 by two nodes), plus one absent port
+		&models.SwitchPort{ID: "l1p1", Name: "Ethernet1/1", SwitchID: "leaf1"},
+		&models.SwitchPort{ID: "l1p2", Name: "Ethernet1/2", SwitchID: "leaf1"},
+		&models.SwitchPort{ID: "l1p3", Name: "Ethernet1/3", SwitchID: "leaf1"},
+		&models.SwitchPort{ID: "l1p4", Name: "Ethernet1/4", SwitchID: "leaf1"},
+		&models.SwitchPort{ID: "l1p5", Name: "Ethernet1/5", SwitchID: "leaf1"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "l1p1"},
+		&models.ComputeNodePortMapping{ID: "m2", ComputeNodeID: "n2", SwitchPortID: "l1p2"},
+		&models.ComputeNodePortMapping{ID: "m3", ComputeNodeID: "n3", SwitchPortID: "l1p2"},
+		&models.ComputeNodePortMapping{ID: "m4", ComputeNodeID: "n4", SwitchPortID: "l1p3"}, // Soft-deleted below
+
+		// leaf2: 2 present ports, 1 mapped
+		&models.SwitchPort{ID: "l2p1", Name: "Ethernet1/1", SwitchID: "leaf2"},
+		&models.SwitchPort{ID: "l2p2", Name: "Ethernet1/2", SwitchID: "leaf2"},
+		&models.ComputeNodePortMapping{ID: "m5", ComputeNodeID: "n5", SwitchPortID: "l2p1"},
+
+		// Not leaf capacity: spine and border ports, a deleted leaf, another fabric
+		&models.SwitchPort{ID: "s1p1", Name: "Ethernet1/1", SwitchID: "spine1"},
+		&models.SwitchPort{ID: "b1p1", Name: "Ethernet1/1", SwitchID: "border1"},
+		&models.SwitchPort{ID: "g1p1", Name: "Ethernet1/1", SwitchID: "gone1"},
+		&models.SwitchPort{ID: "o1p1", Name: "Ethernet1/1", SwitchID: "other1"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+	if err := db.Model(&models.SwitchPort{}).Where("id = ?", "l1p5").Update("is_present", false).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&models.ComputeNodePortMapping{}, "id = ?", "m4").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&models.Switch{}, "id = ?", "gone1").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewFabricService(db)
+	for _, id := range []string{"f1", "fabric-one"} {
+		summary, err := svc.GetSwitchRoleSummary(context.Background(), id)
+		if err != nil {
+			t.Fatalf("%s: %v", id, err)
+		}
+		want := FabricRoleSummary{Leaf: 2, Spine: 1, Border: 1, Unknown: 1, Total: 5, LeafPortsTotal: 6, LeafPortsAvailable: 3}
+		if *summary != want {
+			t.Errorf("%s: summary = %+v, want %+v", id, *summary, want)
+		}
+	}
+
+	if _, err := svc.GetSwitchRoleSummary(context.Background(), "missing"); !errors.Is(err, ErrFabricNotFound) {
+		t.Errorf("unknown fabric err = %v, want ErrFabricNotFound", err)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+// jobEventSlurmCompleted is the audit event recording a job's Slurm exit status
+const jobEventSlurmCompleted = "slurm.completed"
+
+// JobCompletion is the Slurm exit status reported when a job completes
+type JobCompletion struct {
+	ExitCode     *int    `json:"exit_code"`
+	Signal       *int    `json:"signal"`
+	FailedReason *string `json:"failed_reason"`
+}
+
+// errorMessage describes a failed exit, e.g. "Slurm exit code 1: OOM; killed by signal 9".
+// Returns "" for a successful exit.
+func (c JobCompletion) errorMessage() string {
+	var parts []string
+	if c.ExitCode != nil && *c.ExitCode != 0 {
+		msg := fmt.Sprintf("Slurm exit code %d", *c.ExitCode)
+		if c.FailedReason != nil && *c.FailedReason != "" {
+			msg += ": " + *c.FailedReason
+		}
+		parts = append(parts, msg)
+	}
+	if c.Signal != nil && *c.Signal != 0 {
+		parts = append(parts, fmt.Sprintf("killed by signal %d", *c.Signal))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Complete records the Slurm exit status on the job and deprovisions it. The exit status is
+// stored with the completed job; a failed exit also sets the job's error message unless
+// deprovisioning fails, whose error takes precedence.
+func (s *JobService) Complete(ctx context.Context, job *models.Job, completion JobCompletion) error {
+	if job == nil {
+		return errors.New("job is nil")
+	}
+	job.ExitCode = completion.ExitCode
+	job.Signal = completion.Signal
+	job.FailedReason = completion.FailedReason
+	msg := completion.errorMessage()
+	if msg != "" {
+		job.ErrorMessage = &msg
+	}
+
+	if err := s.Deprovision(ctx, job); err != nil {
+		return err
+	}
+
+	if completion.ExitCode != nil {
+		metrics.JobExitCodes.Observe(float64(*completion.ExitCode))
+	}
+	var exitErr error
+	if msg != "" {
+		exitErr = errors.New(msg)
+	}
+	s.recordJobEvent(job.ID, models.JobEventPhaseDeprovision, jobEventSlurmCompleted, 0, exitErr)
+	return nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func newCompletionTestService(t *testing.T) (*JobService, func(slurmJobID string) *models.Job) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.ComputeNodeAllocation{}, &models.SecurityGroup{},
+		&models.PortSelector{}, &models.SecurityAssociation{}, &models.JobEvent{},
+		&models.JobComputeNode{}, &models.ComputeNode{}, &models.SwitchPort{})
+	for _, id := range []string{"1001", "1002", "1003"} {
+		if err := db.Create(&models.Job{ID: "j" + id, SlurmJobID: id, Status: string(models.JobStatusActive), FabricName: "f1"}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	t.Cleanup(svc.jobEvents.Wait) // Before the database is closed
+	load := func(slurmJobID string) *models.Job {
+		t.Helper()
+		var job models.Job
+		if err := db.First(&job, "slurm_job_id = ?", slurmJobID).Error; err != nil {
+			t.Fatal(err)
+		}
+		return &job
+	}
+	return svc, load
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestComplete_StoresExitStatus(t *testing.T) {
+	svc, load := newCompletionTestService(t)
+	ctx := context.Background()
+	reason := "OutOfMemory"
+
+	if err := svc.Complete(ctx, load("1001"), JobCompletion{ExitCode: intPtr(137), Signal: intPtr(9), FailedReason: &reason}); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	svc.jobEvents.Wait()
+
+	job := load("1001")
+	if job.Status != string(models.JobStatusCompleted) {
+		t.Errorf("status = %s, want completed", job.Status)
+	}
+	if job.ExitCode == nil || *job.ExitCode != 137 || job.Signal == nil || *job.Signal != 9 ||
+		job.FailedReason == nil || *job.FailedReason != reason {
+		t.Errorf("exit status = %v/%v/%v, want 137/9/%s", job.ExitCode, job.Signal, job.FailedReason, reason)
+	}
+	want := "Slurm exit code 137: OutOfMemory; killed by signal 9"
+	if job.ErrorMessage == nil || *job.ErrorMessage != want {
+		t.Errorf("error message = %v, want %q", job.ErrorMessage, want)
+	}
+
+	events, err := svc.ListJobEvents(ctx, "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == jobEventSlurmCompleted {
+			found = e.Error != nil && *e.Error == want
+		}
+	}
+	if !found {
+		t.Errorf("no %s event with the exit status in %+v", jobEventSlurmCompleted, events)
+	}
+}
+
+func TestComplete_SignalOnly(t *testing.T) {
+	svc, load := newCompletionTestService(t)
+
+	if err := svc.Complete(context.Background(), load("1001"), JobCompletion{ExitCode: intPtr(0), Signal: intPtr(9)}); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if job := load("1001"); job.ErrorMessage == nil || *job.ErrorMessage != "killed by signal 9" {
+		t.Errorf("error message = %v, want %q", job.ErrorMessage, "killed by signal 9")
+	}
+}
+
+func TestComplete_SuccessfulExitAndFilter(t *testing.T) {
+	svc, load := newCompletionTestService(t)
+	ctx := context.Background()
+
+	if err := svc.Complete(ctx, load("1001"), JobCompletion{ExitCode: intPtr(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Complete(ctx, load("1002"), JobCompletion{ExitCode: intPtr(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Complete(ctx, load("1003"), JobCompletion{}); err != nil { // No body
+		t.Fatal(err)
+	}
+	if job := load("1001"); job.ErrorMessage != nil {
+		t.Errorf("exit code 0 set error message %q", *job.ErrorMessage)
+	}
+	if job := load("1002"); job.ErrorMessage == nil || *job.ErrorMessage != "Slurm exit code 1" {
+		t.Errorf("error message = %v, want %q", job.ErrorMessage, "Slurm exit code 1")
+	}
+
+	page, err := svc.ListJobs(ctx, JobListOptions{ExitCode: intPtr(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Jobs) != 1 || page.Jobs[0].SlurmJobID != "1002" {
+		t.Errorf("exit_code=1 jobs = %v, want only 1002", page.Jobs)
+	}
+}
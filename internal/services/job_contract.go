@@ -0,0 +1,140 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"go.uber.org/zap"
+)
+
+// DefaultJobContractProtocol matches all traffic between a job's compute nodes
+const DefaultJobContractProtocol = ndclient.ContractProtocolMatchAll
+
+// ValidateJobContractProtocol checks ND_JOB_CONTRACT_PROTOCOL. Valid values are "default"
+// (all traffic) or the name of an NDFC protocol definition such as "icmp" or "SSH".
+func ValidateJobContractProtocol(protocol string) error {
+	if strings.TrimSpace(protocol) == "" {
+		return errors.New("job contract protocol must not be empty")
+	}
+	return nil
+}
+
+// jobContractProtocol returns the configured protocol for the job self-association rule
+func (s *JobService) jobContractProtocol() string {
+	if p := strings.TrimSpace(s.cfg.JobContractProtocol); p != "" {
+		return p
+	}
+	return DefaultJobContractProtocol
+}
+
+// jobContractRules returns the rules of a job's contract. The first rule carries the configured
+// protocol.
+func (s *JobService) jobContractRules() []ndclient.ContractRule {
+	protocol := s.jobContractProtocol()
+	return []ndclient.ContractRule{
+		{Direction: "bidirectional", Action: "permit", ProtocolName: protocol},
+		{Direction: "bidirectional", Action: "permit", ProtocolName: "SSH"},
+	}
+}
+
+// ContractRuleSet is one of a job's contracts, e.g. full permit for intra-job traffic and
+// ICMP only for management traffic
+type ContractRuleSet struct {
+	Name  string                  `json:"name"`
+	Rules []ndclient.ContractRule `json:"rules"`
+}
+
+// jobContract is a contract created for a job, with a self-association of the job's group.
+// Nil rules are the default job contract rules.
+type jobContract struct {
+	name  string
+	rules []ndclient.ContractRule
+}
+
+// contractRuleSetNamePattern is the form of rule set names, which become part of NDFC
+// contract names
+var contractRuleSetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// validateContractRuleSets checks that each rule set has a unique, well-formed name and
+// rules with a valid direction and action
+func validateContractRuleSets(sets []ContractRuleSet) error {
+	seen := make(map[string]bool, len(sets))
+	for i, set := range sets {
+		name := strings.TrimSpace(set.Name)
+		if name == "" {
+			return fmt.Errorf("%w: contract_rules[%d]: name is required", ErrInvalidContract, i)
+		}
+		if !contractRuleSetNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: contract_rules[%d]: name %q must be 1-32 letters, digits, '_' or '-'", ErrInvalidContract, i, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: contract_rules[%d]: duplicate name %q", ErrInvalidContract, i, name)
+		}
+		seen[name] = true
+		if len(set.Rules) == 0 {
+			return fmt.Errorf("%w: contract_rules[%d]: at least one rule is required", ErrInvalidContract, i)
+		}
+		for j, r := range set.Rules {
+			if !validRuleDirections[r.Direction] {
+				return fmt.Errorf("%w: contract_rules[%d].rules[%d]: direction %q must be bidirectional or unidirectional", ErrInvalidContract, i, j, r.Direction)
+			}
+			if !validRuleActions[r.Action] {
+				return fmt.Errorf("%w: contract_rules[%d].rules[%d]: action %q must be permit or deny", ErrInvalidContract, i, j, r.Action)
+			}
+		}
+	}
+	return nil
+}
+
+// jobContracts returns the contracts of a job whose base contract name is contractName: one
+// default contract named contractName without rule sets, otherwise one per rule set named
+// "<contractName>-<index>-<set name>"
+func jobContracts(contractName string, sets []ContractRuleSet) []jobContract {
+	if len(sets) == 0 {
+		return []jobContract{{name: contractName}}
+	}
+	contracts := make([]jobContract, 0, len(sets))
+	for i, set := range sets {
+		contracts = append(contracts, jobContract{
+			name:  contractName + "-" + strconv.Itoa(i) + "-" + strings.TrimSpace(set.Name),
+			rules: set.Rules,
+		})
+	}
+	return contracts
+}
+
+// jobSharedContracts returns the shared contract associations applied to a job. Jobs
+// provisioned before they were recorded get the current list.
+func (s *JobService) jobSharedContracts(job *models.Job) []SharedContractAssociation {
+	if len(job.SharedContracts) > 0 {
+		var contracts []SharedContractAssociation
+		err := json.Unmarshal(job.SharedContracts, &contracts)
+		if err == nil {
+			return contracts
+		}
+		logger.Warn("Invalid job shared contracts", zap.String("job", job.SlurmJobID), zap.Error(err))
+	}
+	return s.registry.SharedContracts.Load()
+}
+
+// jobContractNames returns the names of a job's contracts. Jobs submitted before multiple
+// contracts were supported only have ContractName.
+func jobContractNames(job *models.Job) []string {
+	var names []string
+	if len(job.ContractNames) > 0 {
+		if err := json.Unmarshal(job.ContractNames, &names); err != nil {
+			logger.Warn("Invalid job contract names", zap.String("job", job.SlurmJobID), zap.Error(err))
+		}
+	}
+	if len(names) == 0 && job.ContractName != "" {
+		names = []string{job.ContractName}
+	}
+	return names
+}
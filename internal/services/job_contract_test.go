@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// contractRecorder records created contracts and associations, and the contracts of deleted
+// contracts and associations
+type contractRecorder struct {
+	mu                 sync.Mutex
+	contracts          []ndclient.SecurityContract
+	associations       []ndclient.ContractAssociation
+	deletedContracts   []string
+	deletedAssociation []string
+}
+
+func (f *contractRecorder) serve(t *testing.T) *ndclient.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/contracts"):
+			var contracts []ndclient.SecurityContract
+			if err := json.NewDecoder(r.Body).Decode(&contracts); err != nil {
+				t.Errorf("decode contracts: %v", err)
+			}
+			f.mu.Lock()
+			f.contracts = append(f.contracts, contracts...)
+			f.mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/contractAssociations"):
+			var associations []ndclient.ContractAssociation
+			if err := json.NewDecoder(r.Body).Decode(&associations); err != nil {
+				t.Errorf("decode associations: %v", err)
+			}
+			f.mu.Lock()
+			f.associations = append(f.associations, associations...)
+			f.mu.Unlock()
+			_ = json.NewEncoder(w).Encode(ndclient.BatchResponseAssociations{SuccessList: associations})
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/contractAssociations"):
+			// Deletes carry the association in the body, or in the query once the body is rejected
+			var body []ndclient.ContractAssociation
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			name := r.URL.Query().Get("contractName")
+			if len(body) > 0 {
+				name = body[0].ContractName
+			}
+			f.mu.Lock()
+			f.deletedAssociation = append(f.deletedAssociation, name)
+			f.mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/contracts"):
+			f.mu.Lock()
+			f.deletedContracts = append(f.deletedContracts, r.URL.Query().Get("contractName"))
+			f.mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client
+}
+
+func TestCreateContractAndAssociations_Protocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		want     string
+	}{
+		{name: "explicit protocol", protocol: "tcp", want: "tcp"},
+		{name: "default", protocol: "default", want: "default"},
+		{name: "unset uses default", protocol: "", want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &contractRecorder{}
+			svc := NewJobService(nil, fake.serve(t), &config.NexusDashboardConfig{JobContractProtocol: tt.protocol}, nil)
+
+			svc.createContractAndAssociations(context.Background(), "f1", "vrf1", "job-1", jobContracts("job-contract", nil), nil, "job-group", 42)
+
+			if len(fake.contracts) != 1 {
+				t.Fatalf("contracts created = %d, want 1", len(fake.contracts))
+			}
+			rules := fake.contracts[0].Rules
+			if len(rules) != 2 || rules[0].ProtocolName != tt.want || rules[1].ProtocolName != "SSH" {
+				t.Errorf("rules = %+v, want first protocol %q then SSH", rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateJobContractProtocol(t *testing.T) {
+	for _, p := range []string{"default", "tcp", "icmp"} {
+		if err := ValidateJobContractProtocol(p); err != nil {
+			t.Errorf("ValidateJobContractProtocol(%q) = %v", p, err)
+		}
+	}
+	for _, p := range []string{"", "  "} {
+		if err := ValidateJobContractProtocol(p); err == nil {
+			t.Errorf("ValidateJobContractProtocol(%q) = nil, want error", p)
+		}
+	}
+}
+
+func TestCreateContractAndAssociations_MultipleContracts(t *testing.T) {
+	fake := &contractRecorder{}
+	svc := NewJobService(nil, fake.serve(t), &config.NexusDashboardConfig{}, nil)
+
+	sets := []ContractRuleSet{
+		{Name: "intra", Rules: []ndclient.ContractRule{{Direction: "bidirectional", Action: "permit", ProtocolName: "default"}}},
+		{Name: "mgmt", Rules: []ndclient.ContractRule{{Direction: "bidirectional", Action: "permit", ProtocolName: "icmp"}}},
+	}
+	svc.createContractAndAssociations(context.Background(), "f1", "vrf1", "job-1", jobContracts("hpc-123", sets), nil, "job-123", 42)
+
+	var contracts []string
+	for _, c := range fake.contracts {
+		contracts = append(contracts, c.ContractName+":"+c.Rules[0].ProtocolName)
+	}
+	if got := strings.Join(contracts, ","); got != "hpc-123-0-intra:default,hpc-123-1-mgmt:icmp" {
+		t.Errorf("contracts = %s, want hpc-123-0-intra (default) and hpc-123-1-mgmt (icmp)", got)
+	}
+	var associations []string
+	for _, a := range fake.associations {
+		if *a.SrcGroupID != 42 || *a.DstGroupID != 42 {
+			t.Errorf("association %s is not self-referential: %d -> %d", a.ContractName, *a.SrcGroupID, *a.DstGroupID)
+		}
+		associations = append(associations, a.ContractName)
+	}
+	if got := strings.Join(associations, ","); got != "hpc-123-0-intra,hpc-123-1-mgmt" {
+		t.Errorf("associations = %s, want one per contract", got)
+	}
+}
+
+func TestDeprovisionNDFC_DeletesAllContracts(t *testing.T) {
+	tests := []struct {
+		name          string
+		contractName  string
+		contractNames string
+		want          string
+	}{
+		{name: "multiple contracts", contractName: "hpc-123-0-intra", contractNames: `["hpc-123-0-intra","hpc-123-1-mgmt"]`,
+			want: "hpc-123-0-intra,hpc-123-1-mgmt"},
+		{name: "job without contract list", contractName: "hpc-123", want: "hpc-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &contractRecorder{}
+			svc := NewJobService(nil, fake.serve(t), &config.NexusDashboardConfig{}, nil)
+			job := &models.Job{
+				SlurmJobID:    "123",
+				FabricName:    "f1",
+				VRFName:       "vrf1",
+				ContractName:  tt.contractName,
+				ContractNames: json.RawMessage(tt.contractNames),
+				SecurityGroup: &models.SecurityGroup{NDObjectID: "42"},
+			}
+
+			if err := svc.deprovisionNDFC(context.Background(), job); err != nil {
+				t.Fatalf("deprovisionNDFC: %v", err)
+			}
+			if got := strings.Join(fake.deletedAssociation, ","); got != tt.want {
+				t.Errorf("deleted associations = %s, want %s", got, tt.want)
+			}
+			if got := strings.Join(fake.deletedContracts, ","); got != tt.want {
+				t.Errorf("deleted contracts = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprovisionNDFC_DeletesRecordedSharedContracts(t *testing.T) {
+	tests := []struct {
+		name            string
+		sharedContracts string
+		want            string
+	}{
+		{name: "recorded at provision", sharedContracts: `[{"dst_group_name":"SG_OLD","contract_name":"oldAD"}]`, want: "hpc-123,oldAD"},
+		{name: "job without recorded list", want: "hpc-123,newAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &contractRecorder{}
+			// The list was reloaded since the job was provisioned
+			registry := NewRegistry(nil)
+			registry.SharedContracts.Store([]SharedContractAssociation{{DstGroupName: "SG_NEW", ContractName: "newAD"}})
+			svc := NewJobService(nil, fake.serve(t), &config.NexusDashboardConfig{}, registry)
+			svc.sharedGroupCache = map[string]int{"SG_OLD": 7, "SG_NEW": 8}
+			svc.sharedGroupCacheTime = time.Now()
+			job := &models.Job{
+				SlurmJobID:      "123",
+				FabricName:      "f1",
+				VRFName:         "vrf1",
+				ContractName:    "hpc-123",
+				SharedContracts: json.RawMessage(tt.sharedContracts),
+				SecurityGroup:   &models.SecurityGroup{NDObjectID: "42"},
+			}
+
+			if err := svc.deprovisionNDFC(context.Background(), job); err != nil {
+				t.Fatalf("deprovisionNDFC: %v", err)
+			}
+			if got := strings.Join(fake.deletedAssociation, ","); got != tt.want {
+				t.Errorf("deleted associations = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateContractRuleSets(t *testing.T) {
+	permit := []ndclient.ContractRule{{Direction: "bidirectional", Action: "permit"}}
+	tests := []struct {
+		name    string
+		sets    []ContractRuleSet
+		wantErr bool
+	}{
+		{name: "none", sets: nil},
+		{name: "valid", sets: []ContractRuleSet{{Name: "intra", Rules: permit}, {Name: "mgmt", Rules: permit}}},
+		{name: "missing name", sets: []ContractRuleSet{{Rules: permit}}, wantErr: true},
+		{name: "duplicate name", sets: []ContractRuleSet{{Name: "a", Rules: permit}, {Name: "a", Rules: permit}}, wantErr: true},
+		{name: "no rules", sets: []ContractRuleSet{{Name: "a"}}, wantErr: true},
+		{name: "incomplete rule", sets: []ContractRuleSet{{Name: "a", Rules: []ndclient.ContractRule{{Action: "permit"}}}}, wantErr: true},
+		{name: "invalid name", sets: []ContractRuleSet{{Name: "a/b", Rules: permit}}, wantErr: true},
+		{name: "long name", sets: []ContractRuleSet{{Name: strings.Repeat("a", 33), Rules: permit}}, wantErr: true},
+		{name: "invalid direction", sets: []ContractRuleSet{{Name: "a", Rules: []ndclient.ContractRule{{Direction: "both", Action: "permit"}}}}, wantErr: true},
+		{name: "invalid action", sets: []ContractRuleSet{{Name: "a", Rules: []ndclient.ContractRule{{Direction: "bidirectional", Action: "allow"}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateContractRuleSets(tt.sets)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidContract) {
+			t.Errorf("%s: err = %v, want ErrInvalidContract", tt.name, err)
+		}
+	}
+}
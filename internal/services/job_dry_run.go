@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// Dry run conflict kinds
+const (
+	ConflictJobExists          = "job_exists"
+	ConflictNodeNotFound       = "node_not_found"
+	ConflictNodeAllocated      = "node_allocated"
+	ConflictNodeDecommissioned = "node_decommissioned"
+	ConflictInsufficientNodes  = "insufficient_nodes"
+	ConflictMissingLabels      = "missing_labels"
+	ConflictNoPortMapping      = "no_port_mapping"
+	ConflictVRFNotFound        = "vrf_not_found"
+	ConflictNetworkNotFound    = "network_not_found"
+	ConflictVLANUnavailable    = "vlan_unavailable"
+	ConflictNDFCCheckFailed    = "ndfc_check_failed"
+)
+
+// ProvisionDryRunResult is what Provision found for a DryRun input: the nodes and switch
+// ports the job would use, and every conflict that would make the real submission fail
+type ProvisionDryRunResult struct {
+	Valid        bool                `json:"valid"` // No conflicts: submitting now is expected to succeed
+	ComputeNodes []DryRunComputeNode `json:"compute_nodes"`
+	SkippedNodes []string            `json:"skipped_nodes,omitempty"` // Allocated nodes left out (MinNodeFraction)
+	NetworkVLAN  string              `json:"network_vlan,omitempty"`  // VLAN of the compute network in NDFC
+	NDFCChecked  bool                `json:"ndfc_checked"`            // False without a Nexus Dashboard client
+	Conflicts    []ProvisionConflict `json:"conflicts,omitempty"`
+}
+
+// DryRunComputeNode is a requested compute node resolved to its switch ports
+type DryRunComputeNode struct {
+	Requested   string             `json:"requested"` // Name or hostname as requested
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	SwitchPorts []DryRunSwitchPort `json:"switch_ports"`
+}
+
+// DryRunSwitchPort is a switch port a job would attach to the compute network
+type DryRunSwitchPort struct {
+	SwitchPortID  string `json:"switch_port_id"`
+	SerialNumber  string `json:"serial_number"`
+	InterfaceName string `json:"interface_name"`
+}
+
+// ProvisionConflict is one reason a submission would fail
+type ProvisionConflict struct {
+	Kind    string `json:"kind"`
+	Node    string `json:"node,omitempty"`
+	Message string `json:"message"`
+}
+
+// provisionDryRun runs the lookups of provision (planJob, without locking) and the NDFC
+// pre-checks for validated input, creating nothing and leaving NDFC unchanged. Conflicts are
+// collected rather than returned as errors; only lookup failures are errors.
+func (s *JobService) provisionDryRun(ctx context.Context, input ProvisionInput) (*ProvisionDryRunResult, error) {
+	result := &ProvisionDryRunResult{ComputeNodes: []DryRunComputeNode{}}
+	conflict := func(kind, node, format string, args ...interface{}) {
+		result.Conflicts = append(result.Conflicts, ProvisionConflict{Kind: kind, Node: node, Message: fmt.Sprintf(format, args...)})
+	}
+	db := s.db.WithContext(ctx)
+
+	var existing models.Job
+	err := db.Select("slurm_job_id", "status").Where("slurm_job_id = ?", input.SlurmJobID).First(&existing).Error
+	switch {
+	case err == nil:
+		conflict(ConflictJobExists, "", "job %s already exists with status %s", input.SlurmJobID, existing.Status)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("lookup job %s: %w", input.SlurmJobID, err)
+	}
+
+	plan, err := s.planJob(ctx, db, input, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, requested := range plan.missing {
+		conflict(ConflictNodeNotFound, requested, "compute node %s not found", requested)
+	}
+	for _, name := range plan.decommissioned {
+		conflict(ConflictNodeDecommissioned, name, "compute node %s is decommissioned", name)
+	}
+	if plan.insufficient != nil {
+		conflict(ConflictInsufficientNodes, "", "%s", plan.insufficient.Error())
+	}
+	for _, a := range plan.allocated {
+		conflict(ConflictNodeAllocated, a.NodeName, "compute node %s is allocated to job %s", a.NodeName, a.SlurmJobID)
+	}
+	result.SkippedNodes = plan.skipped
+
+	for _, node := range plan.nodes {
+		if missing := plan.unlabeled[node.Name]; len(missing) > 0 {
+			conflict(ConflictMissingLabels, node.Name, "compute node %s lacks required labels %s", node.Name, strings.Join(missing, ", "))
+		}
+	}
+	for _, node := range plan.nodes {
+		resolved := DryRunComputeNode{Requested: plan.requestedAs[node.ID], ID: node.ID, Name: node.Name, SwitchPorts: []DryRunSwitchPort{}}
+		for _, pi := range plan.ports[node.ID] {
+			resolved.SwitchPorts = append(resolved.SwitchPorts, DryRunSwitchPort{
+				SwitchPortID:  pi.switchPortID,
+				SerialNumber:  pi.serialNumber,
+				InterfaceName: pi.interfaceName,
+			})
+		}
+		if len(resolved.SwitchPorts) == 0 {
+			conflict(ConflictNoPortMapping, node.Name, "compute node %s has no port mapped to a switch", node.Name)
+		}
+		result.ComputeNodes = append(result.ComputeNodes, resolved)
+	}
+
+	s.dryRunNDFC(ctx, result, conflict)
+
+	result.Valid = len(result.Conflicts) == 0
+	return result, nil
+}
+
+// dryRunNDFC checks that the compute VRF and network exist in NDFC and that the network's
+// VLAN can be read. Nothing is checked without a Nexus Dashboard client.
+func (s *JobService) dryRunNDFC(ctx context.Context, result *ProvisionDryRunResult, conflict func(kind, node, format string, args ...interface{})) {
+	if s.ndClient == nil {
+		return
+	}
+	result.NDFCChecked = true
+	fabricName := s.cfg.ComputeFabricName
+	vrfName := s.cfg.ComputeVRFName
+	networkName := s.cfg.ComputeNetworkName
+	lanFabric := s.ndClient.LANFabric()
+
+	if vrfName != "" {
+		exists, err := s.checkVRFExistsWithCache(ctx, lanFabric, fabricName, vrfName)
+		switch {
+		case err != nil:
+			conflict(ConflictNDFCCheckFailed, "", "failed to check VRF %q: %v", vrfName, err)
+		case !exists:
+			conflict(ConflictVRFNotFound, "", "VRF %q does not exist in fabric %q", vrfName, fabricName)
+		}
+	}
+	if networkName == "" {
+		return
+	}
+	exists, err := s.checkNetworkExistsWithCache(ctx, lanFabric, fabricName, networkName)
+	switch {
+	case err != nil:
+		conflict(ConflictNDFCCheckFailed, "", "failed to check network %q: %v", networkName, err)
+		return
+	case !exists:
+		conflict(ConflictNetworkNotFound, "", "network %q does not exist in fabric %q", networkName, fabricName)
+		return
+	}
+	vlan, err := s.getNetworkVLANWithCache(ctx, fabricName, networkName)
+	switch {
+	case err != nil:
+		conflict(ConflictVLANUnavailable, "", "cannot read the VLAN of network %q: %v", networkName, err)
+	case vlan == "":
+		conflict(ConflictVLANUnavailable, "", "network %q has no VLAN", networkName)
+	default:
+		result.NetworkVLAN = vlan
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestProvision_DryRun(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if result.Job != nil || result.DryRun == nil {
+		t.Fatalf("result = %+v, want only a dry run result", result)
+	}
+	dryRun := result.DryRun
+	if !dryRun.Valid || len(dryRun.Conflicts) != 0 || dryRun.NDFCChecked {
+		t.Errorf("dry run = %+v, want valid without NDFC checks", dryRun)
+	}
+	want := []DryRunComputeNode{{Requested: "node1", ID: "n1", Name: "node1", SwitchPorts: []DryRunSwitchPort{
+		{SwitchPortID: "p1", SerialNumber: "SN1", InterfaceName: "Ethernet1/1"},
+	}}}
+	if !reflect.DeepEqual(dryRun.ComputeNodes, want) {
+		t.Errorf("compute nodes = %+v, want %+v", dryRun.ComputeNodes, want)
+	}
+
+	var jobs, allocations int64
+	db.Model(&models.Job{}).Count(&jobs)
+	db.Model(&models.ComputeNodeAllocation{}).Count(&allocations)
+	if jobs != 0 || allocations != 0 {
+		t.Errorf("dry run created %d jobs and %d allocations, want none", jobs, allocations)
+	}
+}
+
+func TestProvision_DryRunConflicts(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+	for _, r := range []interface{}{
+		&models.ComputeNode{ID: "n2", Name: "node2"}, // No port mapping
+		&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive)},
+		&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n1", JobID: "j1"},
+	} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+	svc.ndClient = (&fakeNDFCObjects{
+		fabrics:  []string{"f1"},
+		vrfs:     map[string][]string{"f1": {"other-vrf"}},
+		networks: map[string]map[string]string{"f1": {"compute-net": "2300"}},
+	}).serve(t)
+	svc.cfg.ComputeVRFName = "compute-vrf"
+	svc.cfg.ComputeNetworkName = "compute-net"
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{
+		SlurmJobID:   "1001",
+		ComputeNodes: []string{"node1", "node2", "node9"},
+		DryRun:       true,
+	})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	dryRun := result.DryRun
+	if dryRun.Valid || !dryRun.NDFCChecked || dryRun.NetworkVLAN != "2300" {
+		t.Errorf("dry run = %+v, want invalid with NDFC checked and VLAN 2300", dryRun)
+	}
+	var got [][2]string
+	for _, c := range dryRun.Conflicts {
+		got = append(got, [2]string{c.Kind, c.Node})
+	}
+	want := [][2]string{
+		{ConflictJobExists, ""},
+		{ConflictNodeNotFound, "node9"},
+		{ConflictNodeAllocated, "node1"},
+		{ConflictNoPortMapping, "node2"},
+		{ConflictVRFNotFound, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("conflicts = %v, want %v", got, want)
+	}
+	if len(dryRun.ComputeNodes) != 2 {
+		t.Errorf("compute nodes = %+v, want node1 and node2 resolved", dryRun.ComputeNodes)
+	}
+}
+
+func TestProvision_DryRunInvalidInput(t *testing.T) {
+	svc, _ := newSubmissionTestService(t, newMemStore())
+	_, err := svc.Provision(context.Background(), ProvisionInput{
+		SlurmJobID:   "1001",
+		ComputeNodes: []string{"node1"},
+		Tags:         map[string]string{"bad key": "x"},
+		DryRun:       true,
+	})
+	if err == nil {
+		t.Error("Provision accepted an invalid tag key in a dry run")
+	}
+}
+
+func TestProvision_DryRunDecommissionedNode(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+	if err := db.Model(&models.ComputeNode{}).Where("id = ?", "n1").
+		Update("status", models.ComputeNodeStatusDecommissioned).Error; err != nil {
+		t.Fatalf("decommission node: %v", err)
+	}
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	conflicts := result.DryRun.Conflicts
+	if result.DryRun.Valid || len(conflicts) != 1 || conflicts[0].Kind != ConflictNodeDecommissioned || conflicts[0].Node != "node1" {
+		t.Errorf("conflicts = %+v, want node1 decommissioned, as provision rejects it", conflicts)
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Job event retention
+const (
+	JobEventRetention       = 30 * 24 * time.Hour // Events older than this are removed by JobEventCleanupTask
+	JobEventCleanupInterval = 6 * time.Hour       // How often the sync worker runs the cleanup
+	jobEventWriteTimeout    = 5 * time.Second
+)
+
+// startJobStep records "<step>_started" for the job and returns a function that records
+// "<step>_completed", or "<step>_failed" with the error, along with the step's duration
+func (s *JobService) startJobStep(job *models.Job, phase, step string) func(error) {
+	start := time.Now()
+	s.recordJobEvent(job.ID, phase, step+"_started", 0, nil)
+	return func(err error) {
+		eventType := step + "_completed"
+		if err != nil {
+			eventType = step + "_failed"
+		}
+		s.recordJobEvent(job.ID, phase, eventType, time.Since(start), err)
+	}
+}
+
+// recordJobEvent stores a job event without blocking the caller. Failures are only logged:
+// events are diagnostics and must not fail provisioning.
+func (s *JobService) recordJobEvent(jobID, phase, eventType string, duration time.Duration, err error) {
+	if s.db == nil {
+		return
+	}
+	event := models.JobEvent{
+		ID:         uuid.New().String(),
+		JobID:      jobID,
+		EventType:  eventType,
+		Phase:      phase,
+		DurationMs: duration.Milliseconds(),
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		msg := err.Error()
+		event.Error = &msg
+	}
+
+	s.jobEvents.Add(1)
+	go func() {
+		defer s.jobEvents.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), jobEventWriteTimeout)
+		defer cancel()
+		if err := s.db.WithContext(ctx).Create(&event).Error; err != nil {
+			logger.Warn("Failed to record job event",
+				zap.String("job_id", jobID),
+				zap.String("event", eventType),
+				zap.Error(err))
+		}
+	}()
+}
+
+// ListJobEvents returns the events of a job in the order they happened
+func (s *JobService) ListJobEvents(ctx context.Context, slurmJobID string) ([]models.JobEvent, error) {
+	var job models.Job
+	if err := s.db.WithContext(ctx).Select("id").Where("slurm_job_id = ?", slurmJobID).First(&job).Error; err != nil {
+		return nil, err
+	}
+
+	events := []models.JobEvent{}
+	if err := s.db.WithContext(ctx).Where("job_id = ?", job.ID).Order("created_at").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("list job events: %w", err)
+	}
+	return events, nil
+}
+
+// CleanupJobEvents deletes job events older than olderThan and returns how many were removed
+func (s *JobService) CleanupJobEvents(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("created_at < ?", time.Now().Add(-olderThan)).
+		Delete(&models.JobEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("cleanup job events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// JobEventCleanupTask returns CleanupJobEvents with JobEventRetention as a sync worker task
+func (s *JobService) JobEventCleanupTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "job-event-cleanup",
+		Interval: JobEventCleanupInterval,
+		Run: func(ctx context.Context) error {
+			removed, err := s.CleanupJobEvents(ctx, JobEventRetention)
+			if removed > 0 {
+				logger.Info("Removed old job events", zap.Int64("count", removed))
+			}
+			return err
+		},
+	}
+}
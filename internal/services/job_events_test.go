@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"gorm.io/gorm"
+)
+
+func TestProvisionNDFC_RecordsJobEvents(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobEvent{})
+	job := &models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusProvisioning), FabricName: "f1"}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// NDFC knows no VRFs, so provisioning stops at pre-flight validation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc := NewJobService(db, client, &config.NexusDashboardConfig{ComputeFabricName: "f1"}, nil)
+
+	err = svc.provisionNDFC(context.Background(), job, nil, nil, nil, false, "f1", "vrf1", "net1", "1001", "", time.Minute)
+	if err == nil {
+		t.Fatal("expected pre-flight validation to fail")
+	}
+	svc.jobEvents.Wait()
+
+	events, err := svc.ListJobEvents(context.Background(), "1001")
+	if err != nil {
+		t.Fatalf("ListJobEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want started and failed", events)
+	}
+	if events[0].EventType != "ndfc.validate_started" || events[1].EventType != "ndfc.validate_failed" {
+		t.Errorf("event types = %s, %s", events[0].EventType, events[1].EventType)
+	}
+	for _, e := range events {
+		if e.JobID != "j1" || e.Phase != models.JobEventPhaseProvision {
+			t.Errorf("event %s: job %s phase %s", e.EventType, e.JobID, e.Phase)
+		}
+	}
+	if events[1].Error == nil || *events[1].Error == "" {
+		t.Error("failed event has no error")
+	}
+
+	if _, err := svc.ListJobEvents(context.Background(), "missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("missing job err = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestStartJobStep(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobEvent{})
+	job := &models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "f1"}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	done := svc.startJobStep(job, models.JobEventPhaseProvision, "ndfc.sg_create")
+	time.Sleep(5 * time.Millisecond)
+	done(nil)
+	svc.startJobStep(job, models.JobEventPhaseProvision, "ndfc.deploy")(errors.New("deploy failed"))
+	svc.jobEvents.Wait()
+
+	events, err := svc.ListJobEvents(context.Background(), "1001")
+	if err != nil {
+		t.Fatalf("ListJobEvents: %v", err)
+	}
+	want := []string{"ndfc.sg_create_started", "ndfc.sg_create_completed", "ndfc.deploy_started", "ndfc.deploy_failed"}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.EventType != want[i] {
+			t.Errorf("event %d = %s, want %s", i, e.EventType, want[i])
+		}
+	}
+	if events[1].DurationMs < 5 || events[1].Error != nil {
+		t.Errorf("completed event = %dms, error %v; want >= 5ms and no error", events[1].DurationMs, events[1].Error)
+	}
+	if events[3].Error == nil || *events[3].Error != "deploy failed" {
+		t.Errorf("failed event error = %v", events[3].Error)
+	}
+}
+
+func TestCleanupJobEvents(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.JobEvent{})
+	now := time.Now()
+	for i, age := range []time.Duration{time.Hour, 29 * 24 * time.Hour, 31 * 24 * time.Hour, 90 * 24 * time.Hour} {
+		event := models.JobEvent{ID: string(rune('a' + i)), JobID: "j1", EventType: "ndfc.deploy_started",
+			Phase: models.JobEventPhaseProvision, CreatedAt: now.Add(-age)}
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	removed, err := svc.CleanupJobEvents(context.Background(), JobEventRetention)
+	if err != nil {
+		t.Fatalf("CleanupJobEvents: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+	var left int64
+	db.Model(&models.JobEvent{}).Count(&left)
+	if left != 2 {
+		t.Errorf("events left = %d, want 2", left)
+	}
+}
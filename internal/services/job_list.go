@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidJobListing is returned by ListJobs for invalid paging options
+var ErrInvalidJobListing = errors.New("invalid job list options")
+
+// Job page sizes
+const (
+	DefaultJobPageSize = 100
+	MaxJobPageSize     = 1000
+)
+
+// JobListOptions filters and pages a job listing. Jobs are listed newest first, by
+// submitted_at and then ID. A page starts after Cursor, a NextCursor of the previous page,
+// or else after skipping Offset jobs; cursors stay stable while jobs are being submitted.
+type JobListOptions struct {
+	Status     string
+	ExitCode   *int
+	FabricName string
+	Tags       map[string]string // Jobs must carry every one of these tags
+	Limit      int               // Defaults to DefaultJobPageSize, capped at MaxJobPageSize
+	Offset     int
+	Cursor     string
+}
+
+// JobPage is one page of a job listing
+type JobPage struct {
+	Jobs       []models.Job `json:"jobs"`
+	TotalCount int64        `json:"total_count"`           // Jobs matching the filters, across all pages
+	NextCursor string       `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// normalize applies defaults and validates the options
+func (o *JobListOptions) normalize() error {
+	if o.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidJobListing)
+	}
+	if o.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidJobListing)
+	}
+	for key := range o.Tags {
+		if !jobTagKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: invalid tag key %q", ErrInvalidJobListing, key)
+		}
+	}
+	if o.Cursor != "" && o.Offset > 0 {
+		return fmt.Errorf("%w: cursor and offset cannot be combined", ErrInvalidJobListing)
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultJobPageSize
+	}
+	if o.Limit > MaxJobPageSize {
+		o.Limit = MaxJobPageSize
+	}
+	return nil
+}
+
+// ListJobs returns one page of jobs matching the options' filters
+func (s *JobService) ListJobs(ctx context.Context, opts JobListOptions) (*JobPage, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Job{})
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+	if opts.ExitCode != nil {
+		query = query.Where("exit_code = ?", *opts.ExitCode)
+	}
+	if opts.FabricName != "" {
+		query = query.Where("fabric_name = ?", opts.FabricName)
+	}
+	for key, value := range opts.Tags {
+		query = whereJobTag(query, key, value)
+	}
+
+	page := &JobPage{Jobs: []models.Job{}}
+	if err := query.Count(&page.TotalCount).Error; err != nil {
+		return nil, err
+	}
+
+	if opts.Cursor != "" {
+		submittedAt, id, err := decodeJobCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("submitted_at < ? OR (submitted_at = ? AND id < ?)", submittedAt, submittedAt, id)
+	}
+
+	// One extra row tells whether there is a next page
+	if err := query.
+		Preload("ComputeNodes.ComputeNode").
+		Preload("SecurityGroup.Selectors.SwitchPort").
+		Order("submitted_at DESC, id DESC").
+		Offset(opts.Offset).
+		Limit(opts.Limit + 1).
+		Find(&page.Jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(page.Jobs) > opts.Limit {
+		page.Jobs = page.Jobs[:opts.Limit]
+		last := page.Jobs[len(page.Jobs)-1]
+		page.NextCursor = encodeJobCursor(last.SubmittedAt, last.ID)
+	}
+	return page, nil
+}
+
+// whereJobTag restricts query to jobs whose tag key is value. key must match jobTagKeyPattern.
+func whereJobTag(query *gorm.DB, key, value string) *gorm.DB {
+	if query.Dialector.Name() == "postgres" {
+		return query.Where("tags->>? = ?", key, value)
+	}
+	return query.Where("JSON_EXTRACT(tags, ?) = ?", `$."`+key+`"`, value)
+}
+
+// encodeJobCursor returns the opaque cursor of the page after the job with this position
+func encodeJobCursor(submittedAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(submittedAt.Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeJobCursor(cursor string) (time.Time, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: malformed cursor", ErrInvalidJobListing)
+	}
+	at, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", fmt.Errorf("%w: malformed cursor", ErrInvalidJobListing)
+	}
+	submittedAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: malformed cursor", ErrInvalidJobListing)
+	}
+	return submittedAt, id, nil
+}
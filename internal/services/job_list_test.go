@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+// newJobListService seeds jobs 1-7, submitted a minute apart with job 7 newest. Jobs 4 and
+// 5 share a submission time, job 1 is completed and job 2 is in fabric f2. Jobs 3 and 6 are
+// tagged account=phys101 (job 3 also comment=calib) and job 4 account=chem.
+func newJobListService(t *testing.T) *JobService {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobComputeNode{}, &models.ComputeNode{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SwitchPort{})
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i <= 7; i++ {
+		job := models.Job{ID: fmt.Sprintf("j%d", i), SlurmJobID: fmt.Sprint(i), Status: string(models.JobStatusActive),
+			FabricName: "f1", SubmittedAt: base.Add(time.Duration(i) * time.Minute)}
+		switch i {
+		case 1:
+			job.Status = string(models.JobStatusCompleted)
+		case 2:
+			job.FabricName = "f2"
+		case 3:
+			job.Tags = []byte(`{"account": "phys101", "comment": "calib"}`)
+		case 4:
+			job.Tags = []byte(`{"account": "chem"}`)
+		case 5:
+			job.SubmittedAt = base.Add(4 * time.Minute)
+		case 6:
+			job.Tags = []byte(`{"account": "phys101"}`)
+		}
+		if err := db.Create(&job).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+}
+
+func slurmJobIDs(jobs []models.Job) []string {
+	ids := make([]string, len(jobs))
+	for i, j := range jobs {
+		ids[i] = j.SlurmJobID
+	}
+	return ids
+}
+
+func TestListJobs_Pages(t *testing.T) {
+	svc := newJobListService(t)
+
+	tests := []struct {
+		name      string
+		opts      JobListOptions
+		wantIDs   []string
+		wantTotal int64
+		wantNext  bool
+	}{
+		{"default page", JobListOptions{}, []string{"7", "6", "5", "4", "3", "2", "1"}, 7, false},
+		{"limit", JobListOptions{Limit: 3}, []string{"7", "6", "5"}, 7, true},
+		{"exact last page", JobListOptions{Limit: 7}, []string{"7", "6", "5", "4", "3", "2", "1"}, 7, false},
+		{"offset", JobListOptions{Limit: 2, Offset: 3}, []string{"4", "3"}, 7, true},
+		{"offset past end", JobListOptions{Offset: 10}, []string{}, 7, false},
+		{"status filter", JobListOptions{Status: string(models.JobStatusCompleted)}, []string{"1"}, 1, false},
+		{"fabric filter", JobListOptions{FabricName: "f1", Limit: 5}, []string{"7", "6", "5", "4", "3"}, 6, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := svc.ListJobs(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("ListJobs: %v", err)
+			}
+			if got := slurmJobIDs(page.Jobs); !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("jobs = %v, want %v", got, tt.wantIDs)
+			}
+			if page.TotalCount != tt.wantTotal || (page.NextCursor != "") != tt.wantNext {
+				t.Errorf("total = %d, next cursor %q; want %d, next page %v", page.TotalCount, page.NextCursor, tt.wantTotal, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestListJobs_CursorWalk(t *testing.T) {
+	svc := newJobListService(t)
+
+	// Pages of 2 split jobs 4 and 5, which share a submission time
+	var got []string
+	opts := JobListOptions{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 4 {
+			t.Fatalf("cursor walk did not end: %v", got)
+		}
+		page, err := svc.ListJobs(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("ListJobs: %v", err)
+		}
+		got = append(got, slurmJobIDs(page.Jobs)...)
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	if want := []string{"7", "6", "5", "4", "3", "2", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("walked jobs = %v, want %v", got, want)
+	}
+}
+
+func TestListJobs_InvalidOptions(t *testing.T) {
+	svc := newJobListService(t)
+	page, err := svc.ListJobs(context.Background(), JobListOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]JobListOptions{
+		"negative offset":   {Offset: -1},
+		"negative limit":    {Limit: -1},
+		"malformed cursor":  {Cursor: "not-a-cursor!"},
+		"cursor without id": {Cursor: encodeJobCursor(time.Now(), "")},
+		"cursor and offset": {Cursor: page.NextCursor, Offset: 1},
+	}
+	for name, opts := range tests {
+		if _, err := svc.ListJobs(context.Background(), opts); !errors.Is(err, ErrInvalidJobListing) {
+			t.Errorf("%s: err = %v, want ErrInvalidJobListing", name, err)
+		}
+	}
+}
+
+func TestListJobs_Tags(t *testing.T) {
+	svc := newJobListService(t)
+
+	tests := []struct {
+		tags    map[string]string
+		wantIDs []string
+	}{
+		{map[string]string{"account": "phys101"}, []string{"6", "3"}},
+		{map[string]string{"account": "phys101", "comment": "calib"}, []string{"3"}},
+		{map[string]string{"account": "bio"}, []string{}},
+		{map[string]string{"queue": "gpu"}, []string{}},
+	}
+	for _, tt := range tests {
+		page, err := svc.ListJobs(context.Background(), JobListOptions{Tags: tt.tags})
+		if err != nil {
+			t.Fatalf("%v: %v", tt.tags, err)
+		}
+		if got := slurmJobIDs(page.Jobs); !reflect.DeepEqual(got, tt.wantIDs) || page.TotalCount != int64(len(tt.wantIDs)) {
+			t.Errorf("%v: jobs = %v (total %d), want %v", tt.tags, got, page.TotalCount, tt.wantIDs)
+		}
+	}
+
+	if _, err := svc.ListJobs(context.Background(), JobListOptions{Tags: map[string]string{`a"b`: "x"}}); !errors.Is(err, ErrInvalidJobListing) {
+		t.Errorf("invalid tag key: err = %v, want ErrInvalidJobListing", err)
+	}
+}
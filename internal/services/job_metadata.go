@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidJobMetadata is returned by UpdateJobMetadata for an invalid tag
+var ErrInvalidJobMetadata = errors.New("invalid job metadata")
+
+// jobTagKeyPattern is the form of the tag keys jobs can be submitted and filtered with
+var jobTagKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// jobMetadataColumns are the only job columns UpdateJobMetadata writes
+var jobMetadataColumns = []string{"name", "description", "tags", "updated_at"}
+
+// JobMetadataUpdate is a partial update of a job's metadata. Nil fields are left unchanged.
+type JobMetadataUpdate struct {
+	Name        *string
+	Description *string
+	Tags        map[string]*string // Merged into the job's tags; a nil or "" value deletes the key
+}
+
+// UpdateJobMetadata changes the name, description and tags of a job without touching its
+// provisioning. Tags are merged: keys not in the update are kept. The job row is locked while
+// tags are merged, so concurrent updates do not lose each other's keys; its version is left
+// alone so a running status transition is not aborted. Returns the updated job.
+func (s *JobService) UpdateJobMetadata(ctx context.Context, slurmJobID string, update JobMetadataUpdate) (*models.Job, error) {
+	for key := range update.Tags {
+		if !jobTagKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("%w: tag key %q must be 1-64 letters, digits, '_', '.' or '-'", ErrInvalidJobMetadata, key)
+		}
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job models.Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "tags").
+			Where("slurm_job_id = ?", slurmJobID).First(&job).Error; err != nil {
+			return err
+		}
+
+		changes := make(map[string]interface{})
+		if update.Name != nil {
+			changes["name"] = *update.Name
+		}
+		if update.Description != nil {
+			changes["description"] = *update.Description
+		}
+		if update.Tags != nil {
+			tags, err := mergeJobTags(job.Tags, update.Tags)
+			if err != nil {
+				return err
+			}
+			changes["tags"] = tags
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		changes["updated_at"] = time.Now()
+		return tx.Model(&models.Job{}).Where("id = ?", job.ID).Select(jobMetadataColumns).Updates(changes).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetJob(ctx, slurmJobID)
+}
+
+// encodeJobTags validates the tags of a new job and returns them as stored. Returns nil
+// (NULL) for no tags.
+func encodeJobTags(tags map[string]string) (json.RawMessage, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	for key, value := range tags {
+		if !jobTagKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("%w: tag key %q must be 1-64 letters, digits, '_', '.' or '-'", ErrInvalidJobMetadata, key)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%w: tag %q has an empty value", ErrInvalidJobMetadata, key)
+		}
+	}
+	return json.Marshal(tags)
+}
+
+// mergeJobTags applies updates to the stored tags. Returns nil (NULL) when no tag is left.
+func mergeJobTags(stored json.RawMessage, updates map[string]*string) (interface{}, error) {
+	tags := make(map[string]string)
+	if len(stored) > 0 {
+		if err := json.Unmarshal(stored, &tags); err != nil {
+			return nil, fmt.Errorf("decode stored job tags: %w", err)
+		}
+	}
+	for key, value := range updates {
+		if value == nil || *value == "" {
+			delete(tags, key)
+			continue
+		}
+		tags[key] = *value
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientNodes is returned when fewer compute nodes are available than
+// ProvisionInput.MinNodeFraction requires
+var ErrInsufficientNodes = errors.New("not enough available compute nodes")
+
+// ErrInvalidMinNodeFraction is returned for a ProvisionInput.MinNodeFraction outside 0-1
+var ErrInvalidMinNodeFraction = errors.New("min node fraction must be between 0 and 1")
+
+// validateMinNodeFraction checks that a minimum node fraction is within 0-1. NaN fails every
+// comparison, so it is rejected explicitly.
+func validateMinNodeFraction(fraction float64) error {
+	if math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		return fmt.Errorf("%w, got %v", ErrInvalidMinNodeFraction, fraction)
+	}
+	return nil
+}
+
+// availableJobNodes splits the requested compute nodes into those not allocated to a job
+// and the names of the allocated ones. It fails with ErrInsufficientNodes if the available
+// nodes are fewer than minFraction of all requested nodes.
+func availableJobNodes(tx *gorm.DB, nodes []models.ComputeNode, minFraction float64) ([]models.ComputeNode, []string, error) {
+	var allocated []string
+	if err := tx.Model(&models.ComputeNodeAllocation{}).
+		Where("compute_node_id IN ?", nodeIDs(nodes)).
+		Pluck("compute_node_id", &allocated).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to check node allocations: %w", err)
+	}
+	isAllocated := make(map[string]bool, len(allocated))
+	for _, id := range allocated {
+		isAllocated[id] = true
+	}
+
+	available := make([]models.ComputeNode, 0, len(nodes))
+	var skipped []string
+	for _, node := range nodes {
+		if isAllocated[node.ID] {
+			skipped = append(skipped, node.Name)
+		} else {
+			available = append(available, node)
+		}
+	}
+
+	// Tolerate float error, e.g. 10 * 0.9 must require 9 nodes, not 10
+	required := int(math.Ceil(float64(len(nodes))*minFraction - 1e-9))
+	if required < 1 {
+		required = 1
+	}
+	if len(available) < required {
+		return nil, nil, fmt.Errorf("%w: %d of %d requested nodes available, %d required",
+			ErrInsufficientNodes, len(available), len(nodes), required)
+	}
+	return available, skipped, nil
+}
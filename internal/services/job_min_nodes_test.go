@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// seedNodeGroup adds node1-node10 (node1 from newSubmissionTestService) with port mappings
+// and allocates node10 to another job
+func seedNodeGroup(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	names := []string{"node1"}
+	for i := 2; i <= 10; i++ {
+		id := fmt.Sprintf("n%d", i)
+		for _, r := range []interface{}{
+			&models.SwitchPort{ID: fmt.Sprintf("p%d", i), Name: fmt.Sprintf("Ethernet1/%d", i), SwitchID: "s1"},
+			&models.ComputeNode{ID: id, Name: fmt.Sprintf("node%d", i)},
+			&models.ComputeNodePortMapping{ID: fmt.Sprintf("m%d", i), ComputeNodeID: id, SwitchPortID: fmt.Sprintf("p%d", i)},
+		} {
+			if err := db.Create(r).Error; err != nil {
+				t.Fatalf("seed %T: %v", r, err)
+			}
+		}
+		names = append(names, fmt.Sprintf("node%d", i))
+	}
+	if err := db.Create(&models.ComputeNodeAllocation{ID: "a10", ComputeNodeID: "n10", JobID: "other"}).Error; err != nil {
+		t.Fatalf("seed allocation: %v", err)
+	}
+	return names
+}
+
+func TestProvision_MinNodeFractionSkipsAllocatedNodes(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+	nodes := seedNodeGroup(t, db)
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{
+		SlurmJobID: "1001", ComputeNodes: nodes, MinNodeFraction: 0.9,
+	})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if len(result.SkippedNodes) != 1 || result.SkippedNodes[0] != "node10" {
+		t.Errorf("SkippedNodes = %v, want [node10]", result.SkippedNodes)
+	}
+	if n := countRows(t, db.Where("job_id = ?", result.Job.ID), &models.JobComputeNode{}); n != 9 {
+		t.Errorf("job has %d nodes, want 9", n)
+	}
+}
+
+func TestProvision_MinNodeFractionNotMet(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+	nodes := seedNodeGroup(t, db)
+
+	_, err := svc.Provision(context.Background(), ProvisionInput{
+		SlurmJobID: "1001", ComputeNodes: nodes, MinNodeFraction: 0.95,
+	})
+	if !errors.Is(err, ErrInsufficientNodes) {
+		t.Fatalf("err = %v, want ErrInsufficientNodes", err)
+	}
+	if n := countRows(t, db, &models.Job{}); n != 0 {
+		t.Errorf("%d jobs created, want 0", n)
+	}
+}
+
+func TestProvision_InvalidMinNodeFraction(t *testing.T) {
+	svc, _ := newSubmissionTestService(t, newMemStore())
+	for _, f := range []float64{-0.1, 1.5, math.NaN()} {
+		if _, err := svc.Provision(context.Background(), ProvisionInput{
+			SlurmJobID: "1001", ComputeNodes: []string{"node1"}, MinNodeFraction: f,
+		}); !errors.Is(err, ErrInvalidMinNodeFraction) {
+			t.Errorf("MinNodeFraction %v: err = %v, want ErrInvalidMinNodeFraction", f, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// jobPlan is what a submission resolves to before anything is written: the compute nodes the
+// job gets, their switch ports, and every reason the submission would be rejected. provision
+// turns the first reason into its error; a dry run reports them all.
+type jobPlan struct {
+	found       int                   // Compute nodes matching the request
+	nodes       []models.ComputeNode  // Nodes the job gets, in ID order
+	requestedAs map[string]string     // Node ID -> name or hostname as requested
+	ports       map[string][]portInfo // Node ID -> switch ports the job attaches
+	skipped     []string              // Allocated nodes left out (MinNodeFraction)
+
+	missing        []string            // Requested names or hostnames matching no node
+	decommissioned []string            // Names of decommissioned nodes
+	insufficient   error               // ErrInsufficientNodes under MinNodeFraction
+	allocated      []nodeAllocation    // Nodes allocated to other jobs (without MinNodeFraction)
+	unlabeled      map[string][]string // Node name -> missing required labels
+	withoutPorts   []string            // Names of nodes without a mapped switch port
+}
+
+// nodeAllocation is a compute node allocated to a job
+type nodeAllocation struct {
+	NodeID     string
+	NodeName   string
+	SlurmJobID string
+}
+
+// planJob resolves the compute nodes of a submission, locking them when lock is set.
+// Rejection reasons are recorded in the plan; only lookup failures are errors. With
+// MinNodeFraction too few available nodes leaves every requested node in the plan, so
+// their other problems are still found.
+func (s *JobService) planJob(ctx context.Context, db *gorm.DB, input ProvisionInput, lock bool) (*jobPlan, error) {
+	// Order by ID to prevent deadlocks when multiple transactions lock the same nodes
+	query := db
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var computeNodes []models.ComputeNode
+	if err := query.Where("name IN ? OR hostname IN ?", input.ComputeNodes, input.ComputeNodes).
+		Order("id").
+		Find(&computeNodes).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up compute nodes: %w", err)
+	}
+
+	plan := &jobPlan{
+		found:       len(computeNodes),
+		nodes:       computeNodes,
+		requestedAs: make(map[string]string, len(computeNodes)),
+		ports:       make(map[string][]portInfo, len(computeNodes)),
+	}
+
+	// Input can hold both the name and hostname of a node (e.g. ["node1", "node1.hpc"]);
+	// names win over hostnames
+	byName := make(map[string]string, 2*len(computeNodes))
+	for _, cn := range computeNodes {
+		byName[cn.Hostname] = cn.ID
+	}
+	for _, cn := range computeNodes {
+		byName[cn.Name] = cn.ID
+	}
+	for _, requested := range input.ComputeNodes {
+		id, ok := byName[requested]
+		if !ok {
+			plan.missing = append(plan.missing, requested)
+			continue
+		}
+		if _, seen := plan.requestedAs[id]; !seen {
+			plan.requestedAs[id] = requested
+		}
+	}
+
+	// Decommissioned nodes have not been seen on the fabric for a long time
+	for _, cn := range computeNodes {
+		if cn.Status == models.ComputeNodeStatusDecommissioned {
+			plan.decommissioned = append(plan.decommissioned, cn.Name)
+		}
+	}
+
+	// With a minimum node fraction, provision only the nodes other jobs have not allocated
+	if err := plan.resolveAllocations(db, input.MinNodeFraction); err != nil {
+		return nil, err
+	}
+
+	// Every node must carry the scheduler's required labels
+	unlabeled, err := MissingRequiredLabels(ctx, db, plan.nodes, input.RequiredLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check node labels: %w", err)
+	}
+	plan.unlabeled = unlabeled
+
+	// Nodes need at least one port mapping with a switch assignment
+	for _, node := range plan.nodes {
+		nodePorts, err := jobNodePorts(db, node.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get port mappings for %s: %w", node.Name, err)
+		}
+		plan.ports[node.ID] = nodePorts
+		if len(nodePorts) == 0 {
+			plan.withoutPorts = append(plan.withoutPorts, node.Name)
+		}
+	}
+	return plan, nil
+}
+
+// resolveAllocations records the plan's nodes allocated to other jobs. With minFraction the
+// allocated nodes are left out of the plan instead, unless too few would remain.
+func (p *jobPlan) resolveAllocations(db *gorm.DB, minFraction float64) error {
+	if len(p.nodes) == 0 {
+		return nil
+	}
+	if minFraction > 0 {
+		available, skipped, err := availableJobNodes(db, p.nodes, minFraction)
+		if errors.Is(err, ErrInsufficientNodes) {
+			p.insufficient = err
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p.nodes, p.skipped = available, skipped
+		return nil
+	}
+
+	if err := db.Raw(`
+		SELECT cn.id as node_id, cn.name as node_name, j.slurm_job_id as slurm_job_id
+		FROM compute_node_allocations a
+		JOIN compute_nodes cn ON cn.id = a.compute_node_id
+		JOIN jobs j ON j.id = a.job_id
+		WHERE a.compute_node_id IN ?
+		ORDER BY cn.id
+	`, nodeIDs(p.nodes)).Scan(&p.allocated).Error; err != nil {
+		return fmt.Errorf("failed to check node allocations: %w", err)
+	}
+	return nil
+}
+
+// err returns the error provision fails with for the plan, or nil if the job can be created
+func (p *jobPlan) err(requested []string) error {
+	switch {
+	case p.found == 0:
+		return fmt.Errorf("%w: none matches %v", ErrComputeNodesNotFound, requested)
+	case len(p.missing) > 0:
+		return fmt.Errorf("%w: %v", ErrComputeNodesNotFound, p.missing)
+	case len(p.decommissioned) > 0:
+		return fmt.Errorf("%w: %v", ErrComputeNodesDecommissioned, p.decommissioned)
+	case p.insufficient != nil:
+		return p.insufficient
+	case len(p.allocated) > 0:
+		return allocationConflictError(p.allocated)
+	case len(p.unlabeled) > 0:
+		return fmt.Errorf("%w: %v", ErrMissingRequiredLabels, p.unlabeled)
+	case len(p.withoutPorts) > 0:
+		return fmt.Errorf("compute nodes missing port/switch assignments (cannot schedule): %v", p.withoutPorts)
+	}
+	return nil
+}
+
+// allocationConflictError returns the ErrComputeNodesAllocated error naming the allocated
+// nodes and their jobs
+func allocationConflictError(allocated []nodeAllocation) error {
+	msgs := make([]string, 0, len(allocated))
+	for _, a := range allocated {
+		msgs = append(msgs, fmt.Sprintf("%s [%s] (job %s)", a.NodeName, a.NodeID, a.SlurmJobID))
+	}
+	return fmt.Errorf("%w: %v", ErrComputeNodesAllocated, msgs)
+}
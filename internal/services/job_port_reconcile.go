@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobPortReconcileInterval is how often the sync worker runs ReconcileActiveJobPorts
+const JobPortReconcileInterval = 24 * time.Hour
+
+// StalePort is a difference between a job's security group selectors and the current port
+// mappings of its compute nodes, e.g. after a node was moved to another switch port.
+// OldExpression is empty for a port the group lacks; NewExpression is empty for a selector
+// whose port no node of the job is mapped to anymore.
+type StalePort struct {
+	NodeName      string `json:"node_name,omitempty"` // Empty if no node of the job was ever mapped to the old port
+	OldExpression string `json:"old_expression,omitempty"`
+	NewExpression string `json:"new_expression,omitempty"`
+
+	selectorID string   // Local PortSelector holding OldExpression
+	newPort    portInfo // Port of NewExpression
+}
+
+// jobPortState is an active job with its current ports and the selectors that differ from them
+type jobPortState struct {
+	job     *models.Job
+	current []portInfo
+	stale   []StalePort
+}
+
+// DetectStaleNodePortMappings compares the port selectors of an active job's security group
+// with the ports its compute nodes are currently mapped to (node -> port mapping -> switch)
+// and returns the differences. Returns gorm.ErrRecordNotFound for an unknown job and
+// ErrInvalidJobState if the job is not active.
+func (s *JobService) DetectStaleNodePortMappings(ctx context.Context, jobID string) ([]StalePort, error) {
+	state, err := s.jobPortState(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return state.stale, nil
+}
+
+func (s *JobService) jobPortState(ctx context.Context, jobID string) (*jobPortState, error) {
+	db := s.db.WithContext(ctx)
+	var job models.Job
+	if err := db.Preload("ComputeNodes.ComputeNode").Preload("SecurityGroup.Selectors").
+		First(&job, "id = ?", jobID).Error; err != nil {
+		return nil, err
+	}
+	if job.Status != string(models.JobStatusActive) {
+		return nil, fmt.Errorf("%w: job %s is %s", ErrInvalidJobState, job.SlurmJobID, job.Status)
+	}
+	state := &jobPortState{job: &job}
+	if job.SecurityGroup == nil {
+		return state, nil
+	}
+
+	type nodePort struct {
+		nodeName string
+		port     portInfo
+	}
+	current := make(map[string]nodePort)  // Expression -> node and port it is mapped to now
+	nodeByPort := make(map[string]string) // Switch port ID -> node ever mapped to it
+	for _, jn := range job.ComputeNodes {
+		if jn.ComputeNode == nil {
+			continue
+		}
+		ports, err := jobNodePorts(db, jn.ComputeNodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get port mappings for %s: %w", jn.ComputeNode.Name, err)
+		}
+		for _, pi := range ports {
+			current[pi.selectorExpression().String()] = nodePort{nodeName: jn.ComputeNode.Name, port: pi}
+			state.current = append(state.current, pi)
+		}
+
+		// Include removed mappings so an outdated selector can be traced to its node
+		var mappings []models.ComputeNodePortMapping
+		if err := db.Unscoped().Where("compute_node_id = ?", jn.ComputeNodeID).Find(&mappings).Error; err != nil {
+			return nil, fmt.Errorf("failed to get port mapping history for %s: %w", jn.ComputeNode.Name, err)
+		}
+		for _, m := range mappings {
+			nodeByPort[m.SwitchPortID] = jn.ComputeNode.Name
+		}
+	}
+
+	selected := make(map[string]bool, len(job.SecurityGroup.Selectors))
+	var removed, added []StalePort
+	for _, sel := range job.SecurityGroup.Selectors {
+		selected[sel.Expression] = true
+		if _, ok := current[sel.Expression]; !ok {
+			removed = append(removed, StalePort{
+				NodeName:      nodeByPort[sel.SwitchPortID],
+				OldExpression: sel.Expression,
+				selectorID:    sel.ID,
+			})
+		}
+	}
+	for expr, np := range current {
+		if !selected[expr] {
+			added = append(added, StalePort{NodeName: np.nodeName, NewExpression: expr, newPort: np.port})
+		}
+	}
+	state.stale = pairStalePorts(removed, added)
+	return state, nil
+}
+
+// pairStalePorts merges each removed selector with a new port of the same node, so a node
+// moved to another port is reported as one change. Unpaired entries are kept as they are.
+func pairStalePorts(removed, added []StalePort) []StalePort {
+	sort.Slice(removed, func(i, j int) bool { return removed[i].OldExpression < removed[j].OldExpression })
+	sort.Slice(added, func(i, j int) bool { return added[i].NewExpression < added[j].NewExpression })
+
+	paired := make([]bool, len(added))
+	result := make([]StalePort, 0, len(removed)+len(added))
+	for _, r := range removed {
+		for i, a := range added {
+			if !paired[i] && r.NodeName != "" && a.NodeName == r.NodeName {
+				paired[i] = true
+				r.NewExpression = a.NewExpression
+				r.newPort = a.newPort
+				break
+			}
+		}
+		result = append(result, r)
+	}
+	for i, a := range added {
+		if !paired[i] {
+			result = append(result, a)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].NodeName < result[j].NodeName })
+	return result
+}
+
+// ReconcileJobPorts brings an active job's security group in line with its nodes' current
+// port mappings: the NDFC group's network port selectors are replaced, the local selectors
+// updated and a fabric deploy requested. Returns the ports that changed (none if the group
+// was up to date).
+func (s *JobService) ReconcileJobPorts(ctx context.Context, jobID string) ([]StalePort, error) {
+	state, err := s.jobPortState(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.stale) == 0 {
+		return nil, nil
+	}
+	job, localGroup := state.job, state.job.SecurityGroup
+
+	if s.ndClient != nil {
+		groupID, err := strconv.Atoi(localGroup.NDObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("security group %s has no NDFC group ID", localGroup.Name)
+		}
+		group, err := s.ndClient.GetSecurityGroupByName(ctx, localGroup.FabricName, localGroup.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security group %s: %w", localGroup.Name, err)
+		}
+		selectors := make([]ndclient.NetworkPortSelector, 0, len(state.current))
+		for _, pi := range state.current {
+			selectors = append(selectors, ndclient.NetworkPortSelector{
+				Network:       s.cfg.ComputeNetworkName,
+				SwitchID:      pi.serialNumber,
+				InterfaceName: pi.interfaceName,
+			})
+		}
+		group.GroupID = &groupID
+		group.Attach = len(selectors) > 0
+		group.NetworkPortSelectors = dedupePortSelectors(selectors)
+		if _, err := s.ndClient.UpdateSecurityGroups(ctx, localGroup.FabricName, []ndclient.SecurityGroup{*group}); err != nil {
+			return nil, fmt.Errorf("failed to update security group %s: %w", localGroup.Name, err)
+		}
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var removedIDs []string
+		var added []models.PortSelector
+		for _, p := range state.stale {
+			if p.selectorID != "" {
+				removedIDs = append(removedIDs, p.selectorID)
+			}
+			if p.NewExpression != "" {
+				added = append(added, models.PortSelector{
+					ID:              uuid.New().String(),
+					SecurityGroupID: localGroup.ID,
+					SwitchPortID:    p.newPort.switchPortID,
+					Expression:      p.NewExpression,
+				})
+			}
+		}
+		// Hard delete: a soft-deleted row would still hold its (group, port) unique key
+		if len(removedIDs) > 0 {
+			if err := tx.Unscoped().Where("id IN ?", removedIDs).Delete(&models.PortSelector{}).Error; err != nil {
+				return fmt.Errorf("failed to delete stale port selectors: %w", err)
+			}
+		}
+		if len(added) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "security_group_id"}, {Name: "switch_port_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"expression", "updated_at"}),
+			}).CreateInBatches(added, 100).Error; err != nil {
+				return fmt.Errorf("failed to upsert port selectors: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.deployBatcher != nil {
+		if err := s.deployBatcher.RequestDeploy(ctx, localGroup.FabricName); err != nil {
+			// Non-fatal: the selectors are updated, deploy can be retried
+			logger.Warn("Failed to deploy fabric config after port reconcile",
+				zap.String("fabric", localGroup.FabricName),
+				zap.String("job", job.SlurmJobID),
+				zap.Error(err))
+		}
+	}
+
+	logger.Info("Reconciled job port selectors",
+		zap.String("job", job.SlurmJobID),
+		zap.Int("changed", len(state.stale)))
+	return state.stale, nil
+}
+
+// ReconcileActiveJobPorts runs ReconcileJobPorts for every active job, logging the jobs
+// that fail. Returns the number of ports changed.
+func (s *JobService) ReconcileActiveJobPorts(ctx context.Context) (int, error) {
+	var jobIDs []string
+	if err := s.db.WithContext(ctx).Model(&models.Job{}).
+		Where("status = ?", string(models.JobStatusActive)).
+		Pluck("id", &jobIDs).Error; err != nil {
+		return 0, fmt.Errorf("list active jobs: %w", err)
+	}
+
+	changed := 0
+	for _, id := range jobIDs {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+		stale, err := s.ReconcileJobPorts(ctx, id)
+		if err != nil {
+			logger.Warn("Failed to reconcile job port selectors", zap.String("job_id", id), zap.Error(err))
+			continue
+		}
+		changed += len(stale)
+	}
+	return changed, nil
+}
+
+// JobPortReconcileTask returns ReconcileActiveJobPorts as a sync worker task
+func (s *JobService) JobPortReconcileTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "job-port-reconcile",
+		Interval: JobPortReconcileInterval,
+		Run: func(ctx context.Context) error {
+			_, err := s.ReconcileActiveJobPorts(ctx)
+			return err
+		},
+	}
+}
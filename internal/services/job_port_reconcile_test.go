@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// seedReconcileJob creates active job 100 on node1 (SN1 Ethernet1/1) and node2 (SN1
+// Ethernet1/3) with matching selectors in security group job-100, then moves node1 to Ethernet1/2
+func seedReconcileJob(t *testing.T) *JobService {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t,
+		&models.Switch{}, &models.SwitchPort{},
+		&models.ComputeNode{}, &models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{},
+		&models.Job{}, &models.JobComputeNode{}, &models.SecurityGroup{}, &models.PortSelector{},
+	)
+	sgID := "sg1"
+	for _, v := range []interface{}{
+		&models.Switch{ID: "sw1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "sw1"},
+		&models.SwitchPort{ID: "p2", Name: "Ethernet1/2", SwitchID: "sw1"},
+		&models.SwitchPort{ID: "p3", Name: "Ethernet1/3", SwitchID: "sw1"},
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNode{ID: "n2", Name: "node2"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1"},
+		&models.ComputeNodePortMapping{ID: "m2", ComputeNodeID: "n2", SwitchPortID: "p3"},
+		&models.SecurityGroup{ID: sgID, Name: "job-100", FabricName: "fab", NDObjectID: "500"},
+		&models.PortSelector{ID: "ps1", SecurityGroupID: sgID, SwitchPortID: "p1", Expression: "SN1:Ethernet1/1"},
+		&models.PortSelector{ID: "ps3", SecurityGroupID: sgID, SwitchPortID: "p3", Expression: "SN1:Ethernet1/3"},
+		&models.Job{ID: "j1", SlurmJobID: "100", Status: string(models.JobStatusActive), FabricName: "fab", SecurityGroupID: &sgID},
+		&models.JobComputeNode{ID: "jn1", JobID: "j1", ComputeNodeID: "n1"},
+		&models.JobComputeNode{ID: "jn2", JobID: "j1", ComputeNodeID: "n2"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+
+	// Hardware swap: node1 is recabled to Ethernet1/2
+	if err := db.Delete(&models.ComputeNodePortMapping{}, "id = ?", "m1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.ComputeNodePortMapping{ID: "m1b", ComputeNodeID: "n1", SwitchPortID: "p2"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &JobService{db: db, cfg: &config.NexusDashboardConfig{ComputeNetworkName: "compute"}}
+}
+
+func TestDetectStaleNodePortMappings(t *testing.T) {
+	svc := seedReconcileJob(t)
+
+	stale, err := svc.DetectStaleNodePortMappings(context.Background(), "j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 {
+		t.Fatalf("stale = %+v, want one moved port", stale)
+	}
+	if got := stale[0]; got.NodeName != "node1" || got.OldExpression != "SN1:Ethernet1/1" || got.NewExpression != "SN1:Ethernet1/2" {
+		t.Errorf("stale[0] = %+v, want node1 moved from Ethernet1/1 to Ethernet1/2", got)
+	}
+
+	if err := svc.db.Model(&models.Job{}).Where("id = ?", "j1").Update("status", models.JobStatusCompleted).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.DetectStaleNodePortMappings(context.Background(), "j1"); !errors.Is(err, ErrInvalidJobState) {
+		t.Errorf("completed job err = %v, want ErrInvalidJobState", err)
+	}
+}
+
+func TestReconcileJobPorts_UpdatesNDFCSelectors(t *testing.T) {
+	svc := seedReconcileJob(t)
+
+	var mu sync.Mutex
+	var updated []ndclient.SecurityGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/fabrics/fab/groups"):
+			_, _ = w.Write([]byte(`[{"groupId":500,"groupName":"job-100","attach":true,"networkPortSelectors":[
+				{"network":"compute","switchId":"SN1","interfaceName":"Ethernet1/1"},
+				{"network":"compute","switchId":"SN1","interfaceName":"Ethernet1/3"}]}]`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/fabrics/fab/groups/500"):
+			var g ndclient.SecurityGroup
+			if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+				t.Errorf("decode PUT body: %v", err)
+			}
+			updated = append(updated, g)
+			_ = json.NewEncoder(w).Encode(g)
+		default:
+			t.Errorf("unexpected NDFC request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc.ndClient = client
+
+	stale, err := svc.ReconcileJobPorts(context.Background(), "j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 || stale[0].NewExpression != "SN1:Ethernet1/2" {
+		t.Fatalf("stale = %+v", stale)
+	}
+
+	if len(updated) != 1 {
+		t.Fatalf("NDFC group updated %d times, want 1", len(updated))
+	}
+	var interfaces []string
+	for _, sel := range updated[0].NetworkPortSelectors {
+		if sel.Network != "compute" || sel.SwitchID != "SN1" {
+			t.Errorf("unexpected selector %+v", sel)
+		}
+		interfaces = append(interfaces, sel.InterfaceName)
+	}
+	slices.Sort(interfaces)
+	if !updated[0].Attach || !slices.Equal(interfaces, []string{"Ethernet1/2", "Ethernet1/3"}) {
+		t.Errorf("NDFC selectors = %v (attach %v), want Ethernet1/2 and Ethernet1/3", interfaces, updated[0].Attach)
+	}
+
+	var selectors []models.PortSelector
+	if err := svc.db.Order("expression").Find(&selectors).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(selectors) != 2 || selectors[0].SwitchPortID != "p2" || selectors[0].Expression != "SN1:Ethernet1/2" || selectors[1].SwitchPortID != "p3" {
+		t.Errorf("local selectors = %+v, want p2 and p3", selectors)
+	}
+
+	// Up to date now: nothing changes and NDFC is not called again
+	if stale, err := svc.ReconcileJobPorts(context.Background(), "j1"); err != nil || len(stale) != 0 {
+		t.Errorf("second reconcile = %+v, %v; want no changes", stale, err)
+	}
+	if len(updated) != 1 {
+		t.Errorf("NDFC group updated %d times after second reconcile, want 1", len(updated))
+	}
+}
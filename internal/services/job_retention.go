@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Job retention
+const (
+	DefaultJobRetentionDays = 365                 // Finished jobs older than this are pruned by JobPruneTask
+	JobPruneInterval        = 30 * 24 * time.Hour // How often the sync worker prunes jobs
+	JobPruneCheckInterval   = time.Hour           // How often the sync worker checks whether a prune is due
+	jobPruneBatchSize       = 500                 // Job IDs per IN clause, below database parameter limits
+)
+
+// SetRetentionDays sets how many days completed and failed jobs are kept before
+// PruneOldJobs removes them. Non-positive values keep the current retention.
+func (s *JobService) SetRetentionDays(days int) {
+	if days > 0 {
+		s.retentionDays = days
+	}
+}
+
+// RetentionDays returns how many days finished jobs are kept
+func (s *JobService) RetentionDays() int {
+	return s.retentionDays
+}
+
+// JobRetentionPreview counts the records PruneOldJobs would remove for a given retention
+type JobRetentionPreview struct {
+	MaxAgeDays         int       `json:"max_age_days"`
+	Cutoff             time.Time `json:"cutoff"` // Jobs completed before this are pruned
+	Jobs               int64     `json:"jobs"`
+	JobComputeNodes    int64     `json:"job_compute_nodes"`
+	JobStorageAccesses int64     `json:"job_storage_accesses"`
+	JobEvents          int64     `json:"job_events"`
+}
+
+// prunableJobs scopes a query to jobs past retention: soft-deleted jobs, and completed or
+// failed jobs that finished before cutoff. Jobs that failed during provisioning have no
+// completed_at, so their last update counts as the finish time.
+func prunableJobs(db *gorm.DB, cutoff time.Time) *gorm.DB {
+	return db.Unscoped().Model(&models.Job{}).
+		Where("deleted_at IS NOT NULL OR (status IN ? AND COALESCE(completed_at, updated_at) < ?)",
+			[]string{string(models.JobStatusCompleted), string(models.JobStatusFailed)}, cutoff)
+}
+
+// PreviewJobRetention counts the jobs and related records that pruning with a retention of
+// maxAgeDays would remove, without removing them
+func (s *JobService) PreviewJobRetention(ctx context.Context, maxAgeDays int) (*JobRetentionPreview, error) {
+	preview := &JobRetentionPreview{
+		MaxAgeDays: maxAgeDays,
+		Cutoff:     time.Now().AddDate(0, 0, -maxAgeDays),
+	}
+	db := s.db.WithContext(ctx)
+	jobIDs := prunableJobs(db, preview.Cutoff).Select("id")
+
+	if err := prunableJobs(db, preview.Cutoff).Count(&preview.Jobs).Error; err != nil {
+		return nil, fmt.Errorf("count prunable jobs: %w", err)
+	}
+	for _, c := range []struct {
+		model interface{}
+		count *int64
+	}{
+		{&models.JobComputeNode{}, &preview.JobComputeNodes},
+		{&models.JobStorageAccess{}, &preview.JobStorageAccesses},
+		{&models.JobEvent{}, &preview.JobEvents},
+	} {
+		if err := db.Unscoped().Model(c.model).Where("job_id IN (?)", jobIDs).Count(c.count).Error; err != nil {
+			return nil, fmt.Errorf("count prunable %T: %w", c.model, err)
+		}
+	}
+	return preview, nil
+}
+
+// PruneOldJobs permanently deletes soft-deleted jobs and completed or failed jobs older than
+// the retention period, with their compute node links, storage accesses, events and any
+// leftover allocations, in one transaction. Each pruned job is logged first as an audit
+// record, since its events go with it. Returns how many jobs were deleted.
+func (s *JobService) PruneOldJobs(ctx context.Context) (int64, error) {
+	cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+
+	var pruned int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var jobs []models.Job
+		if err := prunableJobs(tx, cutoff).
+			Select("id", "slurm_job_id", "status", "completed_at", "deleted_at").
+			Find(&jobs).Error; err != nil {
+			return fmt.Errorf("find prunable jobs: %w", err)
+		}
+
+		for start := 0; start < len(jobs); start += jobPruneBatchSize {
+			batch := jobs[start:min(start+jobPruneBatchSize, len(jobs))]
+			ids := make([]string, 0, len(batch))
+			for _, job := range batch {
+				logger.Info("Pruning job past retention",
+					zap.String("job_id", job.ID),
+					zap.String("slurm_job_id", job.SlurmJobID),
+					zap.String("status", job.Status),
+					zap.Timep("completed_at", job.CompletedAt),
+					zap.Bool("soft_deleted", job.DeletedAt.Valid),
+					zap.Int("retention_days", s.retentionDays))
+				ids = append(ids, job.ID)
+			}
+
+			for _, model := range []interface{}{
+				&models.JobComputeNode{}, &models.JobStorageAccess{}, &models.JobEvent{}, &models.ComputeNodeAllocation{},
+			} {
+				if err := tx.Unscoped().Where("job_id IN ?", ids).Delete(model).Error; err != nil {
+					return fmt.Errorf("prune %T: %w", model, err)
+				}
+			}
+			result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Job{})
+			if result.Error != nil {
+				return fmt.Errorf("prune jobs: %w", result.Error)
+			}
+			pruned += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	metrics.JobsPrunedTotal.Add(float64(pruned))
+	return pruned, nil
+}
+
+// JobPruneTask returns a sync worker task that runs PruneOldJobs once every JobPruneInterval.
+// The task checks every JobPruneCheckInterval against the last prune time, which is kept in
+// Valkey so that restarts and leader changes neither skip nor repeat a prune. Without Valkey
+// each instance prunes once after start and then every JobPruneInterval.
+func (s *JobService) JobPruneTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "job-prune",
+		Interval: JobPruneCheckInterval,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			due, err := s.jobPruneDue(ctx, now)
+			if err != nil || !due {
+				return err
+			}
+			pruned, err := s.PruneOldJobs(ctx)
+			if pruned > 0 {
+				logger.Info("Pruned jobs past retention", zap.Int64("count", pruned))
+			}
+			if err != nil {
+				return err
+			}
+			return s.recordJobPrune(ctx, now)
+		},
+	}
+}
+
+// jobPruneDue reports whether JobPruneInterval has passed since the last prune
+func (s *JobService) jobPruneDue(ctx context.Context, now time.Time) (bool, error) {
+	last := s.lastPrune
+	if s.pruneState != nil {
+		value, err := s.pruneState.GetString(ctx, cache.JobPruneLastRun())
+		switch {
+		case err == nil:
+			if last, err = time.Parse(time.RFC3339Nano, value); err != nil {
+				return false, fmt.Errorf("parse last job prune time %q: %w", value, err)
+			}
+		case !errors.Is(err, cache.ErrKeyNotFound):
+			return false, fmt.Errorf("get last job prune time: %w", err)
+		}
+	}
+	return last.IsZero() || now.Sub(last) >= JobPruneInterval, nil
+}
+
+// recordJobPrune stores at as the last prune time
+func (s *JobService) recordJobPrune(ctx context.Context, at time.Time) error {
+	s.lastPrune = at
+	if s.pruneState == nil {
+		return nil
+	}
+	if err := s.pruneState.SetString(ctx, cache.JobPruneLastRun(), at.Format(time.RFC3339Nano), cache.TTLJobPruneLastRun); err != nil {
+		return fmt.Errorf("store last job prune time: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/gorm"
+)
+
+// newRetentionTestService seeds jobs on each side of a 30-day retention, each with a compute
+// node link, a storage access and an event:
+//
+//	old-completed, old-failed: finished 40 days ago (pruned)
+//	old-provision-failed:      failed provisioning 40 days ago, no completed_at (pruned)
+//	soft-deleted:              finished yesterday but soft-deleted (pruned)
+//	recent-completed:          finished 10 days ago (kept)
+//	old-active:                created 40 days ago, still active (kept)
+func newRetentionTestService(t *testing.T) (*JobService, *gorm.DB) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobComputeNode{}, &models.JobStorageAccess{},
+		&models.JobEvent{}, &models.ComputeNodeAllocation{})
+
+	now := time.Now()
+	daysAgo := func(d int) *time.Time {
+		ts := now.AddDate(0, 0, -d)
+		return &ts
+	}
+	jobs := []models.Job{
+		{ID: "old-completed", SlurmJobID: "1", Status: string(models.JobStatusCompleted), CompletedAt: daysAgo(40)},
+		{ID: "old-failed", SlurmJobID: "2", Status: string(models.JobStatusFailed), CompletedAt: daysAgo(40)},
+		{ID: "old-provision-failed", SlurmJobID: "6", Status: string(models.JobStatusFailed), UpdatedAt: *daysAgo(40)},
+		{ID: "soft-deleted", SlurmJobID: "3", Status: string(models.JobStatusCompleted), CompletedAt: daysAgo(1)},
+		{ID: "recent-completed", SlurmJobID: "4", Status: string(models.JobStatusCompleted), CompletedAt: daysAgo(10)},
+		{ID: "old-active", SlurmJobID: "5", Status: string(models.JobStatusActive), CreatedAt: *daysAgo(40)},
+	}
+	for _, job := range jobs {
+		for _, r := range []interface{}{
+			&job,
+			&models.JobComputeNode{ID: "jcn-" + job.ID, JobID: job.ID, ComputeNodeID: "n1"},
+			&models.JobStorageAccess{ID: "jsa-" + job.ID, JobID: job.ID, ComputeNodeID: "n1", StorageTenantID: "t1"},
+			&models.JobEvent{ID: "ev-" + job.ID, JobID: job.ID, EventType: "test", Phase: models.JobEventPhaseProvision, CreatedAt: now},
+		} {
+			if err := db.Create(r).Error; err != nil {
+				t.Fatalf("seed %T: %v", r, err)
+			}
+		}
+	}
+	if err := db.Delete(&models.Job{}, "id = ?", "soft-deleted").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetRetentionDays(30)
+	return svc, db
+}
+
+func TestPruneOldJobs_KeepsRecentJobs(t *testing.T) {
+	svc, db := newRetentionTestService(t)
+	before := testutil.ToFloat64(metrics.JobsPrunedTotal)
+
+	pruned, err := svc.PruneOldJobs(context.Background())
+	if err != nil {
+		t.Fatalf("PruneOldJobs: %v", err)
+	}
+	if pruned != 4 {
+		t.Errorf("pruned = %d, want 4", pruned)
+	}
+	if d := testutil.ToFloat64(metrics.JobsPrunedTotal) - before; d != 4 {
+		t.Errorf("nd_jobs_pruned_total +%v, want +4", d)
+	}
+
+	var kept []string
+	if err := db.Unscoped().Model(&models.Job{}).Order("id").Pluck("id", &kept).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 2 || kept[0] != "old-active" || kept[1] != "recent-completed" {
+		t.Errorf("remaining jobs = %v, want [old-active recent-completed]", kept)
+	}
+	for _, model := range []interface{}{&models.JobComputeNode{}, &models.JobStorageAccess{}, &models.JobEvent{}} {
+		var jobIDs []string
+		if err := db.Unscoped().Model(model).Order("job_id").Pluck("job_id", &jobIDs).Error; err != nil {
+			t.Fatal(err)
+		}
+		if len(jobIDs) != 2 || jobIDs[0] != "old-active" || jobIDs[1] != "recent-completed" {
+			t.Errorf("%T left for jobs %v, want only the kept jobs", model, jobIDs)
+		}
+	}
+
+	if pruned, err := svc.PruneOldJobs(context.Background()); err != nil || pruned != 0 {
+		t.Errorf("second prune = %d, %v, want nothing left to prune", pruned, err)
+	}
+}
+
+func TestPreviewJobRetention(t *testing.T) {
+	svc, db := newRetentionTestService(t)
+
+	preview, err := svc.PreviewJobRetention(context.Background(), 30)
+	if err != nil {
+		t.Fatalf("PreviewJobRetention: %v", err)
+	}
+	if preview.Jobs != 4 || preview.JobComputeNodes != 4 || preview.JobStorageAccesses != 4 || preview.JobEvents != 4 {
+		t.Errorf("preview = %+v, want 4 of each", preview)
+	}
+
+	// A shorter max age also covers the job finished 10 days ago
+	if preview, err := svc.PreviewJobRetention(context.Background(), 5); err != nil || preview.Jobs != 5 {
+		t.Errorf("5-day preview = %+v, %v, want 5 jobs", preview, err)
+	}
+	if n := countRows(t, db.Unscoped(), &models.Job{}); n != 6 {
+		t.Errorf("preview removed jobs: %d left, want 6", n)
+	}
+}
+
+func TestJobPruneTask_RunsOncePerInterval(t *testing.T) {
+	svc, db := newRetentionTestService(t)
+	store := cachetest.NewInMemoryCache()
+	svc.pruneState = store
+	task := svc.JobPruneTask()
+	ctx := context.Background()
+
+	if err := task.Run(ctx); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if n := countRows(t, db.Unscoped(), &models.Job{}); n != 2 {
+		t.Fatalf("%d jobs left after first run, want 2", n)
+	}
+
+	// Within the interval, even on a new instance, nothing is pruned
+	old := time.Now().AddDate(0, 0, -40)
+	if err := db.Create(&models.Job{ID: "later-failed", SlurmJobID: "7", Status: string(models.JobStatusFailed), CompletedAt: &old}).Error; err != nil {
+		t.Fatal(err)
+	}
+	restarted := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	restarted.SetRetentionDays(30)
+	restarted.pruneState = store
+	if err := restarted.JobPruneTask().Run(ctx); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if n := countRows(t, db.Unscoped(), &models.Job{}); n != 3 {
+		t.Errorf("%d jobs left after second run, want 3 (prune not due)", n)
+	}
+
+	// Once the interval has passed since the recorded prune, the next check prunes
+	last := time.Now().Add(-JobPruneInterval - time.Hour).Format(time.RFC3339Nano)
+	if err := store.SetString(ctx, cache.JobPruneLastRun(), last, cache.TTLJobPruneLastRun); err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.JobPruneTask().Run(ctx); err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	if n := countRows(t, db.Unscoped(), &models.Job{}); n != 2 {
+		t.Errorf("%d jobs left after third run, want 2", n)
+	}
+}
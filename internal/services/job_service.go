@@ -6,34 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"sort"
 	"strconv"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/banglin/go-nd/internal/cache"
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/util"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-// SharedContractAssociation defines a common contract association that every job should have
-type SharedContractAssociation struct {
-	DstGroupName string // Destination security group name (e.g., "ActiveDirectory")
-	ContractName string // Contract name to use (e.g., "matchAD")
-}
-
-// SharedContracts is the list of common contract associations applied to every job
-// Add or remove entries here to manage shared service access
-var SharedContracts = []SharedContractAssociation{
-	{DstGroupName: "SG_AD", ContractName: "matchAD"},
-}
-
 // JobService handles job provisioning and deprovisioning
 type JobService struct {
 	db            *gorm.DB
@@ -41,6 +35,27 @@ type JobService struct {
 	cfg           *config.NexusDashboardConfig
 	deployBatcher *DeployBatcher
 	storageSvc    *StorageService
+	registry      *Registry          // Hot-reloadable shared contract lists
+	portDescTmpl  *template.Template // Parsed PortDescriptionTemplate (nil = built-in format)
+
+	maxProvisionTimeout time.Duration // Upper bound for ProvisionInput.TimeoutMinutes
+	retentionDays       int           // Days finished jobs are kept before PruneOldJobs removes them
+
+	pruneState cache.Store // Last job prune time, shared by sync leaders (nil = lastPrune only)
+	lastPrune  time.Time   // Last job prune by this instance
+
+	submissions    cache.Store   // Job submission idempotency keys (nil = database constraint only)
+	instanceID     string        // Owner value of submission keys claimed by this instance
+	submissionWait time.Duration // How long a duplicate submission waits for the in-flight one
+
+	associations      cache.Store   // Shared associations created per job (nil = not tracked across retries)
+	assocRetryBackoff time.Duration // Base wait between shared association retries
+
+	statusEvents cache.Store // Pub/sub for job status changes (nil = not published)
+
+	tracer trace.Tracer // Parent spans of Provision and Deprovision (nil = global provider's)
+
+	jobEvents sync.WaitGroup // In-flight job event writes
 
 	// Cache for shared group IDs (refreshed periodically)
 	sharedGroupCache     map[string]int // groupName -> groupID
@@ -65,20 +80,79 @@ const (
 	negativeCacheTTL     = 2 * time.Minute // Shorter TTL for "not found" results
 	cacheOpTimeout       = 2 * time.Second
 	refreshLockTTL       = 10 * time.Second
-	switchDeployCooldown = 15 * time.Second // Throttle per-switch deploys
-	cacheJitterPct       = 0.15             // ±15% TTL jitter to prevent synchronized expiry
+	cacheJitterPct       = 0.15 // ±15% TTL jitter to prevent synchronized expiry
 )
 
-// NewJobService creates a new JobService
-func NewJobService(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig) *JobService {
-	return &JobService{
+// fabricSwitchCounter counts a fabric's switches in the database, by fabric name
+func fabricSwitchCounter(db *gorm.DB) SwitchCounter {
+	return func(ctx context.Context, fabricName string) (int, error) {
+		var count int64
+		err := db.WithContext(ctx).Model(&models.Switch{}).
+			Where("fabric_id IN (?)", db.Model(&models.Fabric{}).Select("id").Where("name = ?", fabricName)).
+			Count(&count).Error
+		return int(count), err
+	}
+}
+
+// NewJobService creates a new JobService.
+// registry may be nil, in which case the default shared contracts are used.
+func NewJobService(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig, registry *Registry) *JobService {
+	if registry == nil {
+		registry = NewRegistry(nil)
+	}
+
+	batcherOpts := []DeployBatcherOption{
+		WithPollInterval(time.Duration(cfg.DeployPollMS) * time.Millisecond),
+		WithResultWatchPollInterval(time.Duration(cfg.DeployResultPollMS) * time.Millisecond),
+		WithDefaultFabricDeployConfig(FabricDeployConfig{ConfigSaveBeforeDeploy: cfg.ConfigSaveBeforeDeploy}),
+		WithSwitchCounter(fabricSwitchCounter(db)),
+	}
+	for _, fabric := range cfg.ConfigSaveFabrics {
+		batcherOpts = append(batcherOpts, WithFabricDeployConfig(fabric, FabricDeployConfig{ConfigSaveBeforeDeploy: true}))
+	}
+	deployBatcher := NewDeployBatcher(ndClient, deployDebounceTime, deployMaxWaitTime, batcherOpts...)
+
+	// Templates are validated at startup; fall back to the built-in format if this one is bad
+	portDescTmpl, err := ParsePortDescriptionTemplate(cfg.PortDescriptionTemplate)
+	if err != nil {
+		logger.Warn("Ignoring invalid port description template", zap.Error(err))
+	}
+
+	svc := &JobService{
 		db:                  db,
 		ndClient:            ndClient,
 		cfg:                 cfg,
-		deployBatcher:       NewDeployBatcher(ndClient, deployDebounceTime, deployMaxWaitTime),
-		storageSvc:          NewStorageService(db, ndClient, cfg),
+		deployBatcher:       deployBatcher,
+		storageSvc:          NewStorageService(db, ndClient, cfg, registry),
+		registry:            registry,
+		portDescTmpl:        portDescTmpl,
 		sharedGroupCache:    make(map[string]int),
 		sharedGroupCacheTTL: 5 * time.Minute,
+		maxProvisionTimeout: DefaultMaxProvisionTimeout,
+		retentionDays:       DefaultJobRetentionDays,
+		instanceID:          uuid.New().String(),
+		submissionWait:      submissionWaitTimeout,
+		assocRetryBackoff:   sharedAssocRetryBackoff,
+	}
+	if cache.Client != nil {
+		svc.submissions = cache.Client
+		svc.associations = cache.Client
+		svc.statusEvents = cache.Client
+		svc.pruneState = cache.Client
+	}
+	return svc
+}
+
+// DeployBatcher returns the batcher that deploys this service's fabric configuration
+func (s *JobService) DeployBatcher() *DeployBatcher {
+	return s.deployBatcher
+}
+
+// SetMaxProvisionTimeout sets the cap for per-job provisioning timeouts.
+// Non-positive values keep the current cap.
+func (s *JobService) SetMaxProvisionTimeout(d time.Duration) {
+	if d > 0 {
+		s.maxProvisionTimeout = d
 	}
 }
 
@@ -86,14 +160,43 @@ func NewJobService(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDash
 type ProvisionInput struct {
 	SlurmJobID   string
 	Name         string
+	Description  string // Optional; replaces the templated port description with "HPC:<slurm id>/<description>"
 	Tenant       string // Storage tenant key for tenant-specific storage access
 	ComputeNodes []string
+	// RequiredLabels must all be present (with equal values) on every compute node
+	RequiredLabels map[string]string
+	// TimeoutMinutes overrides the default NDFC provisioning timeout for large jobs (0 = default).
+	// Values above the configured maximum are capped.
+	TimeoutMinutes int
+	// ContractRules creates one contract per rule set instead of the default job contract
+	ContractRules []ContractRuleSet
+	// MinNodeFraction (0-1) provisions only the requested nodes not allocated to other jobs,
+	// as long as at least this fraction of them is available. 0 requires all nodes.
+	MinNodeFraction float64
+	// SkipSelectorValidation accepts the job's security group without checking that NDFC holds
+	// all of the job's port selectors
+	SkipSelectorValidation bool
+	// Tags are stored on the job for grouping and filtering, e.g. the Slurm account and comment
+	Tags map[string]string
+	// DryRun only validates the submission: nodes, allocations, port mappings and the NDFC
+	// VRF, network and VLAN are checked, and nothing is created
+	DryRun bool
 }
 
+// Provisioning errors about the requested compute nodes
+var (
+	ErrComputeNodesNotFound       = errors.New("compute nodes not found")
+	ErrComputeNodesAllocated      = errors.New("compute nodes already allocated")
+	ErrComputeNodesDecommissioned = errors.New("compute nodes decommissioned")
+)
+
 // ProvisionResult represents the result of job provisioning
 type ProvisionResult struct {
-	Job     *models.Job
-	Created bool // true if new job was created, false if existing job returned
+	Job          *models.Job
+	Created      bool     // true if new job was created, false if existing job returned
+	SkippedNodes []string // Requested nodes left out because they were allocated (MinNodeFraction)
+	// DryRun is the outcome of a DryRun input, which sets neither Job nor Created
+	DryRun *ProvisionDryRunResult
 }
 
 // portInfo holds information about a port for provisioning
@@ -103,80 +206,150 @@ type portInfo struct {
 	interfaceName string
 }
 
+// jobNodePorts returns the switch ports a job reaches a compute node through: the ports mapped
+// to its compute interface, or all its mapped ports if it has none. Mappings to ports without
+// a switch are skipped.
+func jobNodePorts(db *gorm.DB, nodeID string) ([]portInfo, error) {
+	var computeInterface models.ComputeNodeInterface
+	hasComputeInterface := db.Where("compute_node_id = ? AND role = ?", nodeID, models.InterfaceRoleCompute).
+		First(&computeInterface).Error == nil
+
+	var mappings []models.ComputeNodePortMapping
+	query := db.Preload("SwitchPort.Switch").Where("compute_node_id = ?", nodeID)
+	if hasComputeInterface {
+		query = query.Where("interface_id = ?", computeInterface.ID)
+	}
+	if err := query.Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+
+	ports := make([]portInfo, 0, len(mappings))
+	for _, mapping := range mappings {
+		if mapping.SwitchPort != nil && mapping.SwitchPort.Switch != nil {
+			ports = append(ports, portInfo{
+				switchPortID:  mapping.SwitchPortID,
+				serialNumber:  mapping.SwitchPort.Switch.SerialNumber,
+				interfaceName: mapping.SwitchPort.Name,
+			})
+		}
+	}
+	return ports, nil
+}
+
+// selectorExpression returns the port's "serial:interface" selector expression
+func (pi portInfo) selectorExpression() util.SelectorExpression {
+	return util.SelectorExpression{SerialNumber: pi.serialNumber, InterfaceName: pi.interfaceName}
+}
+
 // Provision creates and provisions a new job, or returns existing job if idempotent
-func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*ProvisionResult, error) {
-	// Check if job already exists (idempotent)
-	var existingJob models.Job
-	err := s.db.WithContext(ctx).Where("slurm_job_id = ?", input.SlurmJobID).First(&existingJob).Error
-	if err == nil {
-		// Job exists - return it if still active/provisioning
-		if existingJob.Status == string(models.JobStatusActive) ||
-			existingJob.Status == string(models.JobStatusProvisioning) {
-			s.db.WithContext(ctx).Preload("ComputeNodes.ComputeNode").
-				Preload("SecurityGroup.Selectors.SwitchPort").
-				First(&existingJob, "id = ?", existingJob.ID)
-			return &ProvisionResult{Job: &existingJob, Created: false}, nil
+func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (result *ProvisionResult, err error) {
+	ctx, span := s.startSpan(ctx, "JobService.Provision",
+		attribute.String("slurm_job_id", input.SlurmJobID),
+		attribute.Int("compute_nodes", len(input.ComputeNodes)),
+		attribute.Bool("dry_run", input.DryRun))
+	defer func() { endSpan(span, err) }()
+
+	if input.DryRun {
+		return s.provision(ctx, input)
+	}
+
+	start := time.Now()
+	result, err = s.provision(ctx, input)
+	metrics.JobProvisionsTotal.WithLabelValues(metrics.Result(err)).Inc()
+	metrics.JobProvisionDuration.WithLabelValues(metrics.Result(err)).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+func (s *JobService) provision(ctx context.Context, input ProvisionInput) (*ProvisionResult, error) {
+	if err := validateContractRuleSets(input.ContractRules); err != nil {
+		return nil, err
+	}
+	if err := validateMinNodeFraction(input.MinNodeFraction); err != nil {
+		return nil, err
+	}
+	tags, err := encodeJobTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// A dry run stops at the lookups and pre-checks, writing nothing
+	if input.DryRun {
+		dryRun, err := s.provisionDryRun(ctx, input)
+		if err != nil {
+			return nil, err
 		}
-		// Job exists but completed/failed - conflict
-		return nil, fmt.Errorf("job %s already exists with status %s", input.SlurmJobID, existingJob.Status)
+		return &ProvisionResult{DryRun: dryRun}, nil
 	}
-	// Distinguish "not found" from other DB errors (connection issues, context canceled, etc.)
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("lookup job %s: %w", input.SlurmJobID, err)
+
+	// Claim the submission first; a concurrent request for the same job waits for ours to land
+	release, err := s.claimSubmission(ctx, input.SlurmJobID)
+	if errors.Is(err, ErrJobSubmissionInProgress) {
+		return s.awaitSubmission(ctx, input.SlurmJobID)
+	}
+	defer release()
+
+	// Check if job already exists (idempotent)
+	if result, err := s.existingSubmission(ctx, input.SlurmJobID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, err
 	}
 
 	// Use config values
 	fabricName := s.cfg.ComputeFabricName
 	vrfName := s.cfg.ComputeVRFName
 	networkName := s.cfg.ComputeNetworkName
+	provisionTimeout := s.provisionTimeout(input.TimeoutMinutes)
 
-	// Generate contract name
+	// Generate contract names
 	contractName := input.SlurmJobID
 	if s.cfg.ComputeContractPrefix != "" {
 		contractName = s.cfg.ComputeContractPrefix + "-" + input.SlurmJobID
 	}
+	contracts := jobContracts(contractName, input.ContractRules)
+	contractNames := make([]string, 0, len(contracts))
+	for _, c := range contracts {
+		contractNames = append(contractNames, c.name)
+	}
+	contractNamesJSON, err := json.Marshal(contractNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode contract names: %w", err)
+	}
+	// Record the shared contracts applied now, so deprovisioning removes these associations
+	// even if the list is reloaded in between
+	sharedContractsJSON, err := json.Marshal(s.registry.SharedContracts.Load())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode shared contracts: %w", err)
+	}
 
 	// Start transaction for local DB operations
 	var job models.Job
 	var portInfos []portInfo
 	var portSelectors []ndclient.NetworkPortSelector
+	var skippedNodes []string
 
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Lock compute nodes to prevent race conditions
-		// Order by ID to prevent deadlocks when multiple transactions lock the same nodes
-		var computeNodes []models.ComputeNode
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("name IN ? OR hostname IN ?", input.ComputeNodes, input.ComputeNodes).
-			Order("id").
-			Find(&computeNodes).Error; err != nil {
-			return fmt.Errorf("failed to lock compute nodes: %w", err)
-		}
-
-		// Verify all requested compute nodes were found
-		// Handle case where input contains both name and hostname for same node (e.g., ["node1", "node1.hpc"])
-		if len(computeNodes) == 0 {
-			return fmt.Errorf("no compute nodes found matching: %v", input.ComputeNodes)
+		// Lock the compute nodes and check the job can be created on them
+		plan, err := s.planJob(ctx, tx, input, true)
+		if err != nil {
+			return err
 		}
-
-		// Build lookup maps from found nodes (O(n) instead of O(n*m))
-		nodeByName := make(map[string]string)     // name -> node ID
-		nodeByHostname := make(map[string]string) // hostname -> node ID
-		for _, cn := range computeNodes {
-			nodeByName[cn.Name] = cn.ID
-			nodeByHostname[cn.Hostname] = cn.ID
+		if err := plan.err(input.ComputeNodes); err != nil {
+			return err
 		}
-
-		// Check which inputs resolved
-		var missing []string
-		for _, requested := range input.ComputeNodes {
-			if _, ok := nodeByName[requested]; !ok {
-				if _, ok := nodeByHostname[requested]; !ok {
-					missing = append(missing, requested)
-				}
+		computeNodes := plan.nodes
+		skippedNodes = plan.skipped
+
+		// Reserve the job's ports on the network before any NDFC call when ports are capped;
+		// otherwise they are only counted once attached
+		reserved := 0
+		if s.cfg.MaxPortsPerNetwork > 0 {
+			var ports []portInfo
+			for _, node := range computeNodes {
+				ports = append(ports, plan.ports[node.ID]...)
+			}
+			reserved = len(dedupePortInfos(ports))
+			if err := s.reserveNetworkCapacity(ctx, tx, fabricName, networkName, reserved); err != nil {
+				return err
 			}
-		}
-		if len(missing) > 0 {
-			return fmt.Errorf("compute nodes not found: %v", missing)
 		}
 
 		// Create job record first (needed for allocation foreign key)
@@ -185,12 +358,20 @@ func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*Prov
 			ID:           uuid.New().String(),
 			SlurmJobID:   input.SlurmJobID,
 			Name:         input.Name,
+			Description:  input.Description,
 			TenantKey:    input.Tenant,
 			Status:       string(models.JobStatusPending),
 			FabricName:   fabricName,
 			VRFName:      vrfName,
-			ContractName: contractName,
+			ContractName: contracts[0].name,
+			NetworkName:  networkName,
 			SubmittedAt:  now,
+
+			Tags:                    tags,
+			ContractNames:           contractNamesJSON,
+			SharedContracts:         sharedContractsJSON,
+			ProvisionTimeoutMinutes: int(provisionTimeout / time.Minute),
+			AttachedPortCount:       reserved,
 		}
 
 		if err := tx.Create(&job).Error; err != nil {
@@ -198,61 +379,23 @@ func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*Prov
 		}
 
 		// Collect job-compute node links and port info
-		// Validate that all nodes have port mappings with switch assignments
 		jobNodes := make([]models.JobComputeNode, 0, len(computeNodes))
-		var nodesWithoutPorts []string
-
 		for _, node := range computeNodes {
 			jobNodes = append(jobNodes, models.JobComputeNode{
 				ID:            uuid.New().String(),
 				JobID:         job.ID,
 				ComputeNodeID: node.ID,
 			})
-
-			// Get compute interface for this node (if any)
-			var computeInterface models.ComputeNodeInterface
-			hasComputeInterface := tx.Where("compute_node_id = ? AND role = ?", node.ID, models.InterfaceRoleCompute).
-				First(&computeInterface).Error == nil
-
-			// Get port mappings - only those assigned to compute interface if one exists
-			var mappings []models.ComputeNodePortMapping
-			query := tx.Preload("SwitchPort.Switch").Where("compute_node_id = ?", node.ID)
-			if hasComputeInterface {
-				// Only use mappings assigned to the compute interface
-				query = query.Where("interface_id = ?", computeInterface.ID)
-			}
-			if err := query.Find(&mappings).Error; err != nil {
-				return fmt.Errorf("failed to get port mappings for %s: %w", node.Name, err)
-			}
-
-			// Check if node has valid port mappings (with switch assignment)
-			hasValidMapping := false
-			for _, mapping := range mappings {
-				if mapping.SwitchPort != nil && mapping.SwitchPort.Switch != nil {
-					hasValidMapping = true
-					portSelectors = append(portSelectors, ndclient.NetworkPortSelector{
-						Network:       networkName,
-						SwitchID:      mapping.SwitchPort.Switch.SerialNumber,
-						InterfaceName: mapping.SwitchPort.Name,
-					})
-					portInfos = append(portInfos, portInfo{
-						switchPortID:  mapping.SwitchPortID,
-						serialNumber:  mapping.SwitchPort.Switch.SerialNumber,
-						interfaceName: mapping.SwitchPort.Name,
-					})
-				}
-			}
-
-			if !hasValidMapping {
-				nodesWithoutPorts = append(nodesWithoutPorts, node.Name)
+			for _, pi := range plan.ports[node.ID] {
+				portSelectors = append(portSelectors, ndclient.NetworkPortSelector{
+					Network:       networkName,
+					SwitchID:      pi.serialNumber,
+					InterfaceName: pi.interfaceName,
+				})
+				portInfos = append(portInfos, pi)
 			}
 		}
 
-		// Reject job if any nodes lack port+switch assignments
-		if len(nodesWithoutPorts) > 0 {
-			return fmt.Errorf("compute nodes missing port/switch assignments (cannot schedule): %v", nodesWithoutPorts)
-		}
-
 		// Bulk insert job-compute node links
 		if len(jobNodes) > 0 {
 			if err := tx.CreateInBatches(jobNodes, 100).Error; err != nil {
@@ -274,15 +417,11 @@ func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*Prov
 		}
 		if len(allocations) > 0 {
 			if err := tx.Create(&allocations).Error; err != nil {
-				// Check if this is a unique constraint violation (node already allocated)
-				// Query which nodes are already allocated by OTHER jobs
-				var conflicts []struct {
-					NodeName   string
-					NodeID     string
-					JobSlurmID string
-				}
+				// A unique constraint violation means a job allocated a node after the plan
+				// checked it; query which nodes OTHER jobs now hold
+				var conflicts []nodeAllocation
 				q := tx.Raw(`
-					SELECT cn.name as node_name, cn.id as node_id, j.slurm_job_id as job_slurm_id
+					SELECT cn.name as node_name, cn.id as node_id, j.slurm_job_id as slurm_job_id
 					FROM compute_node_allocations a
 					JOIN compute_nodes cn ON cn.id = a.compute_node_id
 					JOIN jobs j ON j.id = a.job_id
@@ -292,39 +431,38 @@ func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*Prov
 				if q.Error != nil {
 					return fmt.Errorf("failed to determine allocation conflicts: %w", q.Error)
 				}
-
 				if len(conflicts) > 0 {
-					var conflictMsgs []string
-					for _, c := range conflicts {
-						conflictMsgs = append(conflictMsgs, fmt.Sprintf("%s [%s] (job %s)", c.NodeName, c.NodeID, c.JobSlurmID))
-					}
-					return fmt.Errorf("compute nodes already allocated: %v", conflictMsgs)
+					return allocationConflictError(conflicts)
 				}
 				// If no conflicts found, it's a different DB error
 				return fmt.Errorf("failed to allocate compute nodes: %w", err)
 			}
 		}
 
-		// Update status to provisioning
-		job.Status = string(models.JobStatusProvisioning)
-		if err := tx.Save(&job).Error; err != nil {
-			return fmt.Errorf("failed to update job status: %w", err)
+		// Update status to provisioning (guarded so a concurrent writer can't be overwritten)
+		if err := transitionJob(ctx, tx, &job, models.JobStatusProvisioning, string(models.JobStatusPending)); err != nil {
+			return err
 		}
 
 		return nil
 	})
 
+	// The job is committed out of pending (or rolled back); later submissions find it in the database
+	release()
 	if err != nil {
 		return nil, err
 	}
+	s.publishJobStatus(&job, string(models.JobStatusPending))
 
 	// Now do NDFC provisioning (outside transaction)
-	if err := s.provisionNDFC(ctx, &job, portInfos, portSelectors, fabricName, vrfName, networkName, input.SlurmJobID); err != nil {
+	if err := s.provisionNDFC(ctx, &job, portInfos, portSelectors, contracts, !input.SkipSelectorValidation, fabricName, vrfName, networkName, input.SlurmJobID, s.portDescription(input), provisionTimeout); err != nil {
 		// Mark job as failed and release allocations to allow retry with same nodes
+		previous := job.Status
 		job.Status = string(models.JobStatusFailed)
 		errMsg := err.Error()
 		job.ErrorMessage = &errMsg
 		s.db.WithContext(ctx).Save(&job)
+		s.publishJobStatus(&job, previous)
 
 		// Release allocations so nodes can be used by retry or other jobs
 		s.db.WithContext(ctx).Where("job_id = ?", job.ID).Delete(&models.ComputeNodeAllocation{})
@@ -345,43 +483,79 @@ func (s *JobService) Provision(ctx context.Context, input ProvisionInput) (*Prov
 		zap.String("slurm_job_id", input.SlurmJobID),
 	)
 
-	return &ProvisionResult{Job: &job, Created: true}, nil
+	if len(skippedNodes) > 0 {
+		logger.Warn("Provisioned job without allocated nodes",
+			zap.String("slurm_job_id", input.SlurmJobID),
+			zap.Strings("skipped_nodes", skippedNodes))
+	}
+	return &ProvisionResult{Job: &job, Created: true, SkippedNodes: skippedNodes}, nil
 }
 
 // NDFC timeout constants
 const (
-	ndfcProvisionTimeout   = 10 * time.Minute // Overall provisioning timeout
+	ndfcProvisionTimeout   = 10 * time.Minute // Overall provisioning timeout, unless the job overrides it
 	ndfcInterfaceTimeout   = 3 * time.Minute  // Per-step: interface config + deploy + attach
 	ndfcSecurityTimeout    = 30 * time.Second // Per-step: SG/contract/association operations
 	ndfcDeprovisionTimeout = 5 * time.Minute  // Overall deprovisioning timeout
 )
 
-// provisionNDFC handles all NDFC provisioning steps
-func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInfos []portInfo, portSelectors []ndclient.NetworkPortSelector, fabricName, vrfName, networkName, slurmJobID string) error {
+// DefaultMaxProvisionTimeout caps per-job provisioning timeouts unless SetMaxProvisionTimeout is called
+const DefaultMaxProvisionTimeout = 60 * time.Minute
+
+// provisionTimeout returns the overall NDFC provisioning timeout for a job requesting
+// timeoutMinutes: the global default when unset, otherwise capped at the configured maximum
+func (s *JobService) provisionTimeout(timeoutMinutes int) time.Duration {
+	if timeoutMinutes <= 0 {
+		return ndfcProvisionTimeout
+	}
+	timeout := time.Duration(timeoutMinutes) * time.Minute
+	if timeout > s.maxProvisionTimeout {
+		return s.maxProvisionTimeout
+	}
+	return timeout
+}
+
+// provisionNDFC handles all NDFC provisioning steps within the overall timeout
+func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInfos []portInfo, portSelectors []ndclient.NetworkPortSelector, contracts []jobContract, validateSelectors bool, fabricName, vrfName, networkName, slurmJobID, portDescription string, timeout time.Duration) error {
 	if s.ndClient == nil {
 		return nil
 	}
 
 	// Apply overall timeout for provisioning
-	ctx, cancel := context.WithTimeout(ctx, ndfcProvisionTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// 0. Pre-flight validation: verify VRF and Network exist in NDFC
-	if err := s.validateNDFCResources(ctx, fabricName, vrfName, networkName); err != nil {
+	// 0. Pre-flight validation: NDFC is reachable (the deploy at the end would be abandoned
+	// with the circuit breaker open), and VRF and Network exist in NDFC
+	done := s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.validate")
+	err := s.ndClient.CheckNDFCAvailable()
+	if err == nil {
+		err = s.validateNDFCResources(ctx, fabricName, vrfName, networkName)
+	}
+	done(err)
+	if err != nil {
+		// Nothing attached yet: release the ports reserved at allocation
+		s.recordNetworkAttachment(ctx, job, fabricName, networkName, 0)
 		return fmt.Errorf("pre-flight validation failed: %w", err)
 	}
 
 	// 1. Configure and attach ports to network (with dedicated timeout)
+	done = s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.attach_ports")
 	ifCtx, ifCancel := context.WithTimeout(ctx, ndfcInterfaceTimeout)
-	err := s.configureInterfaces(ifCtx, portInfos, fabricName, networkName, slurmJobID)
+	attached, err := s.configureInterfaces(ifCtx, portInfos, fabricName, networkName, slurmJobID, portDescription)
 	ifCancel()
+	done(err)
+	s.recordNetworkAttachment(ctx, job, fabricName, networkName, attached)
 	if err != nil {
 		return fmt.Errorf("interface configuration failed: %w", err)
 	}
 
 	// 2. Create security group (idempotent: treat "already exists" as success)
-	groupName := fmt.Sprintf("job-%s", slurmJobID)
-	groupID := s.generateGroupID(slurmJobID)
+	groupName := jobSecurityGroupPrefix + slurmJobID
+	groupID, err := s.generateGroupID(slurmJobID)
+	if err != nil {
+		return err
+	}
 
 	// Dedupe port selectors before sending to NDFC
 	portSelectors = dedupePortSelectors(portSelectors)
@@ -394,25 +568,29 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 		NetworkPortSelectors: portSelectors,
 	}
 
-	// Create security group with dedicated timeout
+	// Find or create the security group with dedicated timeout. Recovering an existing
+	// group avoids duplicates when a previous attempt was interrupted after the NDFC create.
+	done = s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.sg_create")
 	sgCtx, sgCancel := context.WithTimeout(ctx, ndfcSecurityTimeout)
-	_, err = s.ndClient.CreateSecurityGroup(sgCtx, fabricName, securityGroup)
-	if err != nil && !ndclient.IsConflictError(err) {
-		sgCancel()
-		return fmt.Errorf("failed to create security group: %w", err)
-	}
-
-	// Always fetch the group after create (success or conflict) to get the real NDFC-assigned ID
-	// This handles cases where NDFC returns success but with nil GroupID, or assigns a different ID
-	fetchedGroup, fetchErr := s.ndClient.GetSecurityGroupByName(sgCtx, fabricName, groupName)
+	fetchedGroup, recovered, err := s.RecoverSecurityGroupByName(sgCtx, fabricName, securityGroup)
 	sgCancel()
-	if fetchErr != nil {
-		return fmt.Errorf("failed to fetch security group after create: %w", fetchErr)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("failed to create security group: %w", err)
 	}
 	if fetchedGroup.GroupID != nil {
 		groupID = *fetchedGroup.GroupID
 	}
-	logger.Info("Security group ready in NDFC", zap.String("group", groupName), zap.Int("groupId", groupID))
+	// NDFC must hold every port the job asked for, whether the group is new or recovered
+	if validateSelectors {
+		if err := validatePortSelectors(fetchedGroup, portSelectors); err != nil {
+			return fmt.Errorf("port selector validation failed: %w", err)
+		}
+	}
+	logger.Info("Security group ready in NDFC",
+		zap.String("group", groupName),
+		zap.Int("groupId", groupID),
+		zap.Bool("recovered", recovered))
 
 	// 3. Save local security group, selectors, and update job in a transaction (idempotent)
 	// Use OnConflict upsert - single query, no race window
@@ -424,7 +602,8 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 	}
 
 	// Wrap local DB writes in transaction for consistency
-	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	done = s.startJobStep(job, models.JobEventPhaseProvision, "db.save_state")
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Upsert: insert or update NDObjectID on conflict (fabric_name, name)
 		if err := tx.Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "fabric_name"}, {Name: "name"}},
@@ -446,7 +625,7 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 					ID:              uuid.New().String(),
 					SecurityGroupID: localGroup.ID,
 					SwitchPortID:    pi.switchPortID,
-					Expression:      fmt.Sprintf("%s:%s", pi.serialNumber, pi.interfaceName),
+					Expression:      pi.selectorExpression().String(),
 				})
 			}
 			// OnConflict: if (security_group_id, switch_port_id) exists, update expression
@@ -464,18 +643,26 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 		job.ProvisionedAt = &provisionedAt
 		job.ErrorMessage = nil // Clear any previous error
 		return tx.Save(job).Error
-	}); err != nil {
+	})
+	done(err)
+	if err != nil {
 		return fmt.Errorf("failed to save local state: %w", err)
 	}
+	s.publishJobStatus(job, string(models.JobStatusProvisioning))
 
-	// 6. Create contract and associations (best-effort, with dedicated timeout)
+	// 6. Create contracts and associations (best-effort, with dedicated timeout)
+	done = s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.contract_create")
 	secCtx, secCancel := context.WithTimeout(ctx, ndfcSecurityTimeout)
-	s.createContractAndAssociations(secCtx, fabricName, vrfName, job.ContractName, groupName, groupID)
+	s.createContractAndAssociations(secCtx, fabricName, vrfName, job.SlurmJobID, contracts, s.jobSharedContracts(job), groupName, groupID)
 	secCancel()
+	done(nil)
 
 	// 7. Provision storage access if tenant is specified
 	if job.TenantKey != "" {
-		if err := s.provisionStorageAccess(ctx, job); err != nil {
+		done = s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.storage_provision")
+		err := s.provisionStorageAccess(ctx, job)
+		done(err)
+		if err != nil {
 			logger.Error("Failed to provision storage access, rolling back",
 				zap.String("job", job.SlurmJobID),
 				zap.String("tenant", job.TenantKey),
@@ -493,7 +680,22 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 	// 8. Deploy fabric configuration to apply security changes (batched)
 	// Uses DeployBatcher to coalesce multiple rapid job requests into a single deploy.
 	// This prevents "deploy already in progress" errors when jobs arrive quickly.
-	if err := s.deployBatcher.RequestDeploy(ctx, fabricName); err != nil {
+	done = s.startJobStep(job, models.JobEventPhaseProvision, "ndfc.deploy")
+	err = s.deployBatcher.RequestDeploy(ctx, fabricName)
+	done(err)
+	if ndclient.IsCircuitOpenError(err) {
+		// NDFC became unavailable mid-provision: fail the job now rather than leaving it for a
+		// later timeout, and roll back what was created like a storage failure does
+		logger.Error("NDFC circuit breaker open, deploy abandoned, rolling back",
+			zap.String("fabric", fabricName),
+			zap.String("job", job.SlurmJobID))
+		if deprovErr := s.Deprovision(ctx, job); deprovErr != nil {
+			logger.Warn("Failed to rollback job provisioning",
+				zap.String("job", job.SlurmJobID),
+				zap.Error(deprovErr))
+		}
+		return err
+	} else if err != nil {
 		logger.Warn("Failed to deploy fabric config after security setup",
 			zap.String("fabric", fabricName),
 			zap.String("job", job.SlurmJobID),
@@ -508,6 +710,54 @@ func (s *JobService) provisionNDFC(ctx context.Context, job *models.Job, portInf
 	return nil
 }
 
+// RecoverSecurityGroupByName returns the NDFC security group named group.GroupName,
+// creating it from group if it does not exist yet.
+//
+// The lookup happens first so retries after an interrupted provision reuse the group
+// instead of creating a duplicate. If the create conflicts (another attempt won the race),
+// the group is fetched again. The bool is true when an existing group was returned
+// rather than a freshly created one. The returned group always carries the
+// NDFC-assigned ID, which may differ from the requested one.
+func (s *JobService) RecoverSecurityGroupByName(ctx context.Context, fabricName string, group *ndclient.SecurityGroup) (*ndclient.SecurityGroup, bool, error) {
+	groupName := group.GroupName
+
+	existing, err := s.ndClient.GetSecurityGroupByName(ctx, fabricName, groupName)
+	if err == nil {
+		metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryExisting).Inc()
+		logger.Info("Recovered existing security group from NDFC",
+			zap.String("group", groupName),
+			zap.String("fabric", fabricName))
+		return existing, true, nil
+	}
+	if !errors.Is(err, ndclient.ErrSecurityGroupNotFound) {
+		return nil, false, fmt.Errorf("lookup security group: %w", err)
+	}
+
+	_, err = s.ndClient.CreateSecurityGroup(ctx, fabricName, group)
+	if err != nil && !ndclient.IsConflictError(err) {
+		return nil, false, err
+	}
+	conflict := err != nil
+
+	// Always fetch after create (success or conflict) to get the real NDFC-assigned ID.
+	// This handles cases where NDFC returns success but with nil GroupID, or assigns a different ID
+	fetched, err := s.ndClient.GetSecurityGroupByName(ctx, fabricName, groupName)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetch security group after create: %w", err)
+	}
+
+	if conflict {
+		metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryRecovered).Inc()
+		logger.Info("Security group create conflicted, recovered existing group",
+			zap.String("group", groupName),
+			zap.String("fabric", fabricName))
+		return fetched, true, nil
+	}
+
+	metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryCreated).Inc()
+	return fetched, false, nil
+}
+
 // validateNDFCResources validates that required NDFC resources (VRF, Network) exist before provisioning
 // Uses Valkey caching to reduce NDFC calls (positive cache longer, negative cache shorter)
 func (s *JobService) validateNDFCResources(ctx context.Context, fabricName, vrfName, networkName string) error {
@@ -652,9 +902,10 @@ func (s *JobService) getNetworkVLANWithCache(ctx context.Context, fabricName, ne
 // 2. Configure interface settings (access mode, VLAN, PFC, QoS, etc.) via int_access_host policy
 // 3. Deploy interface configurations
 // 4. Attach ports to network
-func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portInfo, fabricName, networkName, slurmJobID string) error {
+// Returns the number of ports attached
+func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portInfo, fabricName, networkName, slurmJobID, description string) (int, error) {
 	if len(portInfos) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	// Dedupe ports by (serialNumber, interfaceName) to avoid duplicate NDFC calls
@@ -669,7 +920,7 @@ func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portIn
 	// Query the network's VLAN (cached in Valkey)
 	accessVlan, err := s.getNetworkVLANWithCache(ctx, fabricName, networkName)
 	if err != nil {
-		return fmt.Errorf("failed to get VLAN for network %s: %w", networkName, err)
+		return 0, fmt.Errorf("failed to get VLAN for network %s: %w", networkName, err)
 	}
 
 	logger.Info("Retrieved network VLAN",
@@ -686,7 +937,7 @@ func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portIn
 			pi.serialNumber,
 			pi.interfaceName,
 			accessVlan,
-			fmt.Sprintf("HPC Job %s", slurmJobID),
+			description,
 		)
 		if err != nil {
 			logger.Warn("Failed to configure interface",
@@ -698,18 +949,18 @@ func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portIn
 		}
 	}
 
-	// 2. Deploy interface configurations per switch (throttled to prevent hammering NDFC)
-	for serialNumber, ifNames := range interfacesBySwitch {
-		if !s.shouldDeploySwitch(ctx, fabricName, serialNumber) {
-			logger.Debug("Skipping interface deploy (throttled)",
-				zap.String("switch", serialNumber),
-				zap.Strings("interfaces", ifNames))
-			continue
+	// 2. Deploy only the configured switches and wait for the batch, so it completes as a
+	// targeted deploy before the job's fabric deploy queues a whole-fabric request
+	if len(interfacesBySwitch) > 0 {
+		serials := make([]string, 0, len(interfacesBySwitch))
+		for serialNumber := range interfacesBySwitch {
+			serials = append(serials, serialNumber)
 		}
-		if err := s.ndClient.LANFabric().DeployInterfacesNDFC(ctx, serialNumber, ifNames); err != nil {
+		sort.Strings(serials)
+		if _, err := DeployFabric(ctx, s.deployBatcher, fabricName, serials); err != nil {
 			logger.Warn("Failed to deploy interfaces",
-				zap.String("switch", serialNumber),
-				zap.Strings("interfaces", ifNames),
+				zap.String("fabric", fabricName),
+				zap.Strings("switches", serials),
 				zap.Error(err))
 		}
 	}
@@ -729,7 +980,7 @@ func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portIn
 	}
 
 	if err := s.ndClient.LANFabric().AttachPortsToNetwork(ctx, fabricName, networkName, attachments); err != nil {
-		return fmt.Errorf("failed to attach ports to network %s: %w", networkName, err)
+		return 0, fmt.Errorf("failed to attach ports to network %s: %w", networkName, err)
 	}
 
 	logger.Info("Configured and attached ports to network",
@@ -737,55 +988,62 @@ func (s *JobService) configureInterfaces(ctx context.Context, portInfos []portIn
 		zap.String("job", slurmJobID),
 		zap.Int("port_count", len(attachments)))
 
-	return nil
+	return len(attachments), nil
 }
 
-// createContractAndAssociations creates the security contract and associations (idempotent)
-func (s *JobService) createContractAndAssociations(ctx context.Context, fabricName, vrfName, contractName, groupName string, groupID int) {
-	// Create contract (idempotent: conflict = already exists = success)
-	contract := &ndclient.SecurityContract{
-		ContractName: contractName,
-		Rules: []ndclient.ContractRule{
-			{Direction: "bidirectional", Action: "permit", ProtocolName: "icmp"},
-			{Direction: "bidirectional", Action: "permit", ProtocolName: "SSH"},
-		},
-	}
-	if _, err := s.ndClient.CreateSecurityContract(ctx, fabricName, contract); err != nil {
-		if !ndclient.IsConflictError(err) {
-			logger.Warn("Failed to create security contract", zap.Error(err))
+// createContractAndAssociations creates the job's security contracts, a self-referential
+// association per contract and the shared contract associations (idempotent)
+func (s *JobService) createContractAndAssociations(ctx context.Context, fabricName, vrfName, slurmJobID string, contracts []jobContract, sharedContracts []SharedContractAssociation, groupName string, groupID int) {
+	for _, c := range contracts {
+		rules := c.rules
+		if rules == nil {
+			rules = s.jobContractRules()
+		}
+
+		// Create contract (idempotent: conflict = already exists = success)
+		contract := &ndclient.SecurityContract{
+			ContractName: c.name,
+			Rules:        rules,
+		}
+		if _, err := s.ndClient.CreateSecurityContract(ctx, fabricName, contract); err != nil {
+			if !ndclient.IsConflictError(err) {
+				logger.Warn("Failed to create security contract", zap.String("contract", c.name), zap.Error(err))
+			}
 		}
-	}
 
-	// Create self-referential association (idempotent: conflict = already exists = success)
-	association := &ndclient.ContractAssociation{
-		FabricName:   fabricName,
-		VRFName:      vrfName,
-		SrcGroupID:   &groupID,
-		DstGroupID:   &groupID,
-		SrcGroupName: groupName,
-		DstGroupName: groupName,
-		ContractName: contractName,
-		Attach:       true,
-	}
-	if _, err := s.ndClient.CreateSecurityAssociation(ctx, fabricName, association); err != nil {
-		if !ndclient.IsConflictError(err) {
-			logger.Warn("Failed to create contract association", zap.Error(err))
+		// Create self-referential association (idempotent: conflict = already exists = success)
+		association := &ndclient.ContractAssociation{
+			FabricName:   fabricName,
+			VRFName:      vrfName,
+			SrcGroupID:   &groupID,
+			DstGroupID:   &groupID,
+			SrcGroupName: groupName,
+			DstGroupName: groupName,
+			ContractName: c.name,
+			Attach:       true,
+		}
+		if _, err := s.ndClient.CreateSecurityAssociation(ctx, fabricName, association); err != nil {
+			if !ndclient.IsConflictError(err) {
+				logger.Warn("Failed to create contract association", zap.String("contract", c.name), zap.Error(err))
+			}
 		}
 	}
 
 	// Create shared contract associations
-	s.createSharedAssociations(ctx, fabricName, vrfName, groupName, groupID)
+	s.createSharedAssociations(ctx, fabricName, vrfName, slurmJobID, sharedContracts, groupName, groupID)
 }
 
-// createSharedAssociations creates associations for shared services
-func (s *JobService) createSharedAssociations(ctx context.Context, fabricName, vrfName, groupName string, groupID int) {
-	if len(SharedContracts) == 0 {
+// createSharedAssociations creates associations for shared services, retrying the ones
+// that fail (see retryableAssociationCreate)
+func (s *JobService) createSharedAssociations(ctx context.Context, fabricName, vrfName, slurmJobID string, sharedContracts []SharedContractAssociation, groupName string, groupID int) {
+	if len(sharedContracts) == 0 {
 		return
 	}
 
 	groupIDMap := s.getSharedGroupIDs(ctx, fabricName)
 
-	for _, shared := range SharedContracts {
+	associations := make([]ndclient.ContractAssociation, 0, len(sharedContracts))
+	for _, shared := range sharedContracts {
 		dstGroupID, found := groupIDMap[shared.DstGroupName]
 		if !found {
 			logger.Warn("Shared service security group not found",
@@ -793,7 +1051,7 @@ func (s *JobService) createSharedAssociations(ctx context.Context, fabricName, v
 			continue
 		}
 
-		association := &ndclient.ContractAssociation{
+		associations = append(associations, ndclient.ContractAssociation{
 			FabricName:   fabricName,
 			VRFName:      vrfName,
 			SrcGroupID:   &groupID,
@@ -802,22 +1060,13 @@ func (s *JobService) createSharedAssociations(ctx context.Context, fabricName, v
 			DstGroupName: shared.DstGroupName,
 			ContractName: shared.ContractName,
 			Attach:       true,
-		}
+		})
+	}
 
-		if _, err := s.ndClient.CreateSecurityAssociation(ctx, fabricName, association); err != nil {
-			// Conflict = already exists = success (idempotent)
-			if !ndclient.IsConflictError(err) {
-				logger.Warn("Failed to create shared contract association",
-					zap.String("dst_group", shared.DstGroupName),
-					zap.String("contract", shared.ContractName),
-					zap.Error(err))
-			}
-		} else {
-			logger.Info("Created shared contract association",
-				zap.String("src_group", groupName),
-				zap.String("dst_group", shared.DstGroupName),
-				zap.String("contract", shared.ContractName))
-		}
+	if err := s.retryableAssociationCreate(ctx, fabricName, slurmJobID, associations); err != nil {
+		logger.Warn("Failed to create shared contract associations",
+			zap.String("slurm_job_id", slurmJobID),
+			zap.Error(err))
 	}
 }
 
@@ -957,36 +1206,25 @@ func jitterTTL(ttl time.Duration, jitterPct float64) time.Duration {
 	return time.Duration(float64(ttl) * (1 + jitter))
 }
 
-// shouldDeploySwitch checks if we should deploy to a switch (throttle using Valkey)
-// Returns true if deploy should proceed, false if recently deployed
-func (s *JobService) shouldDeploySwitch(ctx context.Context, fabricName, serialNumber string) bool {
-	valkeyClient := cache.Client
-	if valkeyClient == nil {
-		return true // No Valkey = always deploy
-	}
-
-	cooldownKey := cacheKeyPrefix + fabricName + ":ifdeploy:" + serialNumber + ":cooldown"
-
-	// Try to set cooldown key (SETNX style)
-	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
-	acquired, err := valkeyClient.SetNX(cacheCtx, cooldownKey, "1", switchDeployCooldown)
-	cancel()
-
-	if err != nil {
-		// Valkey error - allow deploy to be safe
-		return true
-	}
-
-	return acquired // true = we set it (deploy), false = already set (skip)
-}
-
 // Deprovision cleans up NDFC resources for a job
 // This is the unified cleanup function used by CompleteJob and CleanupExpiredJobs
-func (s *JobService) Deprovision(ctx context.Context, job *models.Job) error {
+func (s *JobService) Deprovision(ctx context.Context, job *models.Job) (err error) {
 	if job == nil {
 		return fmt.Errorf("job is nil")
 	}
+	ctx, span := s.startSpan(ctx, "JobService.Deprovision",
+		attribute.String("slurm_job_id", job.SlurmJobID),
+		attribute.String("job_id", job.ID))
+	defer func() { endSpan(span, err) }()
 
+	start := time.Now()
+	err = s.deprovision(ctx, job)
+	metrics.JobDeprovisionsTotal.WithLabelValues(metrics.Result(err)).Inc()
+	metrics.JobDeprovisionDuration.WithLabelValues(metrics.Result(err)).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (s *JobService) deprovision(ctx context.Context, job *models.Job) error {
 	// Ensure SecurityGroup is loaded (don't depend on caller preloading)
 	if job.SecurityGroupID != nil && job.SecurityGroup == nil {
 		var sg models.SecurityGroup
@@ -995,12 +1233,19 @@ func (s *JobService) Deprovision(ctx context.Context, job *models.Job) error {
 		}
 	}
 
-	// Update status to deprovisioning
-	job.Status = string(models.JobStatusDeprovisioning)
-	if err := s.db.WithContext(ctx).Save(job).Error; err != nil {
-		return fmt.Errorf("failed to update job status: %w", err)
+	from := deprovisionableFrom(job)
+	if from == nil {
+		return fmt.Errorf("%w: job %s is %s", ErrInvalidJobState, job.SlurmJobID, job.Status)
 	}
 
+	// Claim the job for deprovisioning. Only one concurrent caller can win this
+	// update; the others get ErrConcurrentModification instead of double-deleting in NDFC.
+	previous := job.Status
+	if err := transitionJob(ctx, s.db, job, models.JobStatusDeprovisioning, from...); err != nil {
+		return err
+	}
+	s.publishJobStatus(job, previous)
+
 	// Cleanup storage access first (if any)
 	if err := s.storageSvc.DeprovisionStorageForJob(ctx, job); err != nil {
 		logger.Warn("Failed to deprovision storage access",
@@ -1034,6 +1279,18 @@ func (s *JobService) Deprovision(ctx context.Context, job *models.Job) error {
 			return fmt.Errorf("failed to release allocations: %w", err)
 		}
 
+		// Release the job's ports from its network's attachment count
+		if job.AttachedPortCount > 0 {
+			networkName := job.NetworkName
+			if networkName == "" {
+				networkName = s.cfg.ComputeNetworkName // Jobs created before NetworkName was recorded
+			}
+			if err := adjustNetworkAttachmentCount(ctx, tx, job.FabricName, networkName, -job.AttachedPortCount); err != nil {
+				return fmt.Errorf("failed to release network attachments: %w", err)
+			}
+			job.AttachedPortCount = 0
+		}
+
 		// Update job status based on NDFC cleanup result
 		if ndfcError != nil {
 			job.Status = string(models.JobStatusCleanupFailed)
@@ -1048,6 +1305,7 @@ func (s *JobService) Deprovision(ctx context.Context, job *models.Job) error {
 	}); err != nil {
 		return fmt.Errorf("failed to complete local cleanup: %w", err)
 	}
+	s.publishJobStatus(job, string(models.JobStatusDeprovisioning))
 
 	// If NDFC cleanup failed, log and return error after local cleanup succeeded
 	if ndfcError != nil {
@@ -1076,19 +1334,21 @@ func (s *JobService) deprovisionNDFC(ctx context.Context, job *models.Job) error
 		return nil
 	}
 
-	// 1. Delete self-referential contract association (404 = already deleted = success)
-	if job.ContractName != "" {
-		if err := s.ndClient.DeleteSecurityAssociation(ctx, job.FabricName, job.VRFName, groupID, groupID, job.ContractName); err != nil {
+	contractNames := jobContractNames(job)
+
+	// 1. Delete self-referential contract associations (404 = already deleted = success)
+	for _, name := range contractNames {
+		if err := s.ndClient.DeleteSecurityAssociation(ctx, job.FabricName, job.VRFName, groupID, groupID, name); err != nil {
 			if !ndclient.IsNotFoundError(err) {
-				logger.Warn("Failed to delete contract association", zap.Error(err))
+				logger.Warn("Failed to delete contract association", zap.String("contract", name), zap.Error(err))
 			}
 		}
 	}
 
-	// 2. Delete shared contract associations (404 = already deleted = success)
-	if len(SharedContracts) > 0 {
+	// 2. Delete the shared contract associations applied at provision (404 = already deleted = success)
+	if sharedContracts := s.jobSharedContracts(job); len(sharedContracts) > 0 {
 		groupIDMap := s.getSharedGroupIDs(ctx, job.FabricName)
-		for _, shared := range SharedContracts {
+		for _, shared := range sharedContracts {
 			dstGroupID, found := groupIDMap[shared.DstGroupName]
 			if !found {
 				continue
@@ -1099,16 +1359,22 @@ func (s *JobService) deprovisionNDFC(ctx context.Context, job *models.Job) error
 						zap.String("dst_group", shared.DstGroupName),
 						zap.String("contract", shared.ContractName),
 						zap.Error(err))
+					continue
 				}
 			}
+			s.forgetSharedAssociation(ctx, job.FabricName, job.SlurmJobID, &ndclient.ContractAssociation{
+				SrcGroupName: job.SecurityGroup.Name,
+				DstGroupName: shared.DstGroupName,
+				ContractName: shared.ContractName,
+			})
 		}
 	}
 
-	// 3. Delete security contract (404 = already deleted = success)
-	if job.ContractName != "" {
-		if err := s.ndClient.DeleteSecurityContract(ctx, job.FabricName, job.ContractName); err != nil {
+	// 3. Delete security contracts (404 = already deleted = success)
+	for _, name := range contractNames {
+		if err := s.ndClient.DeleteSecurityContract(ctx, job.FabricName, name); err != nil {
 			if !ndclient.IsNotFoundError(err) {
-				logger.Warn("Failed to delete security contract", zap.Error(err))
+				logger.Warn("Failed to delete security contract", zap.String("contract", name), zap.Error(err))
 			}
 		}
 	}
@@ -1124,12 +1390,16 @@ func (s *JobService) deprovisionNDFC(ctx context.Context, job *models.Job) error
 }
 
 // generateGroupID generates a group ID in valid range (16-65535) from job ID
-func (s *JobService) generateGroupID(slurmJobID string) int {
+func (s *JobService) generateGroupID(slurmJobID string) (int, error) {
 	var groupID int
 	for _, c := range slurmJobID {
-		groupID = (groupID*31 + int(c)) % (65535 - 16)
+		groupID = (groupID*31 + int(c)) % (ndclient.MaxGroupID - ndclient.MinGroupID)
+	}
+	groupID += ndclient.MinGroupID
+	if err := ndclient.ValidateGroupID(groupID); err != nil {
+		return 0, fmt.Errorf("generated group ID for job %s: %w", slurmJobID, err)
 	}
-	return groupID + 16
+	return groupID, nil
 }
 
 // provisionStorageAccess provisions storage access for a job based on tenant configuration
@@ -1177,47 +1447,78 @@ func (s *JobService) GetJob(ctx context.Context, slurmJobID string) (*models.Job
 	return &job, nil
 }
 
-// ListJobs lists jobs with optional status filter
-func (s *JobService) ListJobs(ctx context.Context, status string) ([]models.Job, error) {
-	query := s.db.WithContext(ctx).
-		Preload("ComputeNodes.ComputeNode").
-		Preload("SecurityGroup.Selectors.SwitchPort")
+// MaxBulkGetJobs is the most Slurm job IDs BulkGetJobs accepts per call
+const MaxBulkGetJobs = 100
 
-	if status != "" {
-		query = query.Where("status = ?", status)
+// ErrTooManyJobIDs is returned when BulkGetJobs is asked for more than MaxBulkGetJobs jobs
+var ErrTooManyJobIDs = fmt.Errorf("too many Slurm job IDs (max %d)", MaxBulkGetJobs)
+
+// BulkGetJobs retrieves jobs by Slurm job ID with one query (plus one per preload), keyed by
+// Slurm job ID. Unknown IDs are missing from the map.
+func (s *JobService) BulkGetJobs(ctx context.Context, slurmJobIDs []string) (map[string]*models.Job, error) {
+	if len(slurmJobIDs) > MaxBulkGetJobs {
+		return nil, ErrTooManyJobIDs
+	}
+	jobs := make(map[string]*models.Job, len(slurmJobIDs))
+	if len(slurmJobIDs) == 0 {
+		return jobs, nil
 	}
 
-	var jobs []models.Job
-	if err := query.Order("submitted_at DESC").Find(&jobs).Error; err != nil {
+	var found []models.Job
+	if err := s.db.WithContext(ctx).
+		Preload("ComputeNodes.ComputeNode").
+		Preload("SecurityGroup.Selectors.SwitchPort").
+		Where("slurm_job_id IN ?", slurmJobIDs).
+		Find(&found).Error; err != nil {
 		return nil, err
 	}
+	for i := range found {
+		jobs[found[i].SlurmJobID] = &found[i]
+	}
 	return jobs, nil
 }
 
-// CleanupExpiredJobs finds and deprovisions expired jobs
-func (s *JobService) CleanupExpiredJobs(ctx context.Context) ([]string, error) {
-	var expiredJobs []models.Job
-	now := time.Now()
+// ExpiredJobCleanup reports the outcome of CleanupExpiredJobs by Slurm job ID
+type ExpiredJobCleanup struct {
+	Cleaned []string          `json:"cleaned"`
+	Errors  map[string]string `json:"errors"` // Slurm job ID -> deprovisioning error
+}
 
+// ListExpiredJobs returns active jobs whose expiry is before the given time,
+// i.e. the jobs CleanupExpiredJobs would deprovision at that time
+func (s *JobService) ListExpiredJobs(ctx context.Context, before time.Time) ([]models.Job, error) {
+	var jobs []models.Job
 	if err := s.db.WithContext(ctx).
 		Preload("SecurityGroup.Selectors").
-		Where("status = ? AND expires_at < ?", models.JobStatusActive, now).
-		Find(&expiredJobs).Error; err != nil {
+		Where("status = ? AND expires_at < ?", models.JobStatusActive, before).
+		Order("expires_at").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CleanupExpiredJobs finds and deprovisions expired jobs. A job that fails to
+// deprovision is recorded in Errors and does not stop the others.
+func (s *JobService) CleanupExpiredJobs(ctx context.Context) (*ExpiredJobCleanup, error) {
+	expiredJobs, err := s.ListExpiredJobs(ctx, time.Now())
+	if err != nil {
 		return nil, err
 	}
 
-	var cleaned []string
+	result := &ExpiredJobCleanup{Cleaned: []string{}, Errors: map[string]string{}}
 	for _, job := range expiredJobs {
 		if err := s.Deprovision(ctx, &job); err != nil {
 			logger.Warn("Failed to cleanup expired job",
 				zap.String("slurm_job_id", job.SlurmJobID),
 				zap.Error(err))
+			result.Errors[job.SlurmJobID] = err.Error()
 			continue
 		}
-		cleaned = append(cleaned, job.SlurmJobID)
+		result.Cleaned = append(result.Cleaned, job.SlurmJobID)
 	}
 
-	return cleaned, nil
+	return result, nil
 }
 
 // Helper to extract node IDs
@@ -1234,7 +1535,7 @@ func dedupePortInfos(portInfos []portInfo) []portInfo {
 	seen := make(map[string]bool)
 	result := make([]portInfo, 0, len(portInfos))
 	for _, pi := range portInfos {
-		key := pi.serialNumber + ":" + pi.interfaceName
+		key := pi.selectorExpression().String()
 		if !seen[key] {
 			seen[key] = true
 			result = append(result, pi)
@@ -1248,7 +1549,7 @@ func dedupePortSelectors(selectors []ndclient.NetworkPortSelector) []ndclient.Ne
 	seen := make(map[string]bool)
 	result := make([]ndclient.NetworkPortSelector, 0, len(selectors))
 	for _, s := range selectors {
-		key := s.SwitchID + ":" + s.InterfaceName
+		key := util.SelectorExpression{SerialNumber: s.SwitchID, InterfaceName: s.InterfaceName}.String()
 		if !seen[key] {
 			seen[key] = true
 			result = append(result, s)
@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/gorm"
+)
+
+// fakeSGServer simulates the NDFC security group list/create endpoints.
+// listResponses is consumed in order for each GET; the last entry repeats.
+type fakeSGServer struct {
+	listResponses [][]ndclient.SecurityGroup
+	createStatus  int
+	gets          int32
+	creates       int32
+}
+
+func (f *fakeSGServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		n := int(atomic.AddInt32(&f.gets, 1)) - 1
+		if n >= len(f.listResponses) {
+			n = len(f.listResponses) - 1
+		}
+		_ = json.NewEncoder(w).Encode(f.listResponses[n])
+	case http.MethodPost:
+		atomic.AddInt32(&f.creates, 1)
+		if f.createStatus != 0 && f.createStatus != http.StatusOK {
+			w.WriteHeader(f.createStatus)
+			_, _ = w.Write([]byte(`{"message": "group already exists"}`))
+			return
+		}
+		var groups []ndclient.SecurityGroup
+		_ = json.NewDecoder(r.Body).Decode(&groups)
+		_ = json.NewEncoder(w).Encode(ndclient.BatchResponseGroups{
+			BatchResponse: ndclient.BatchResponse{TotalCount: len(groups), SuccessCount: len(groups)},
+			SuccessList:   groups,
+		})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newRecoveryTestService(t *testing.T, fake *fakeSGServer) *JobService {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return &JobService{ndClient: client}
+}
+
+func testGroup(name string, id int) ndclient.SecurityGroup {
+	return ndclient.SecurityGroup{GroupName: name, GroupID: &id}
+}
+
+// TestRecoverSecurityGroupByName_Existing tests that an existing group is reused without creating
+func TestRecoverSecurityGroupByName_Existing(t *testing.T) {
+	fake := &fakeSGServer{listResponses: [][]ndclient.SecurityGroup{{testGroup("job-1", 500)}}}
+	svc := newRecoveryTestService(t, fake)
+	before := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryExisting))
+
+	want := testGroup("job-1", 100)
+	got, recovered, err := svc.RecoverSecurityGroupByName(context.Background(), "f1", &want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !recovered {
+		t.Error("expected recovered = true for existing group")
+	}
+	if *got.GroupID != 500 {
+		t.Errorf("expected NDFC group ID 500, got %d", *got.GroupID)
+	}
+	if c := atomic.LoadInt32(&fake.creates); c != 0 {
+		t.Errorf("expected no create calls, got %d", c)
+	}
+	if d := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryExisting)) - before; d != 1 {
+		t.Errorf("expected existing counter +1, got %+v", d)
+	}
+}
+
+// TestRecoverSecurityGroupByName_Created tests a fresh create where NDFC reassigns the group ID
+func TestRecoverSecurityGroupByName_Created(t *testing.T) {
+	fake := &fakeSGServer{listResponses: [][]ndclient.SecurityGroup{
+		{},
+		{testGroup("job-2", 777)},
+	}}
+	svc := newRecoveryTestService(t, fake)
+	before := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryCreated))
+
+	want := testGroup("job-2", 100)
+	got, recovered, err := svc.RecoverSecurityGroupByName(context.Background(), "f1", &want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recovered {
+		t.Error("expected recovered = false for fresh creation")
+	}
+	if *got.GroupID != 777 {
+		t.Errorf("expected reassigned group ID 777, got %d", *got.GroupID)
+	}
+	if c := atomic.LoadInt32(&fake.creates); c != 1 {
+		t.Errorf("expected 1 create call, got %d", c)
+	}
+	if d := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryCreated)) - before; d != 1 {
+		t.Errorf("expected created counter +1, got %+v", d)
+	}
+}
+
+// TestRecoverSecurityGroupByName_ConflictThenRecover simulates an interrupted earlier attempt:
+// the group is not visible on the first lookup, the create conflicts, and the re-fetch finds it
+func TestRecoverSecurityGroupByName_ConflictThenRecover(t *testing.T) {
+	fake := &fakeSGServer{
+		listResponses: [][]ndclient.SecurityGroup{
+			{},
+			{testGroup("job-3", 900)},
+		},
+		createStatus: http.StatusConflict,
+	}
+	svc := newRecoveryTestService(t, fake)
+	before := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryRecovered))
+
+	want := testGroup("job-3", 100)
+	got, recovered, err := svc.RecoverSecurityGroupByName(context.Background(), "f1", &want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !recovered {
+		t.Error("expected recovered = true after conflict")
+	}
+	if *got.GroupID != 900 {
+		t.Errorf("expected recovered group ID 900, got %d", *got.GroupID)
+	}
+	if d := testutil.ToFloat64(metrics.SGRecoveryTotal.WithLabelValues(metrics.SGRecoveryRecovered)) - before; d != 1 {
+		t.Errorf("expected recovered counter +1, got %+v", d)
+	}
+}
+
+// TestRecoverSecurityGroupByName_CreateFails tests that non-conflict create errors are returned
+func TestRecoverSecurityGroupByName_CreateFails(t *testing.T) {
+	fake := &fakeSGServer{
+		listResponses: [][]ndclient.SecurityGroup{{}},
+		createStatus:  http.StatusBadRequest,
+	}
+	svc := newRecoveryTestService(t, fake)
+
+	want := testGroup("job-4", 100)
+	if _, _, err := svc.RecoverSecurityGroupByName(context.Background(), "f1", &want); err == nil {
+		t.Fatal("expected error for failed create")
+	}
+}
+
+func TestProvisionTimeout(t *testing.T) {
+	svc := NewJobService(nil, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetMaxProvisionTimeout(30 * time.Minute)
+
+	tests := []struct {
+		minutes int
+		want    time.Duration
+	}{
+		{0, ndfcProvisionTimeout},
+		{-5, ndfcProvisionTimeout},
+		{20, 20 * time.Minute},
+		{120, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := svc.provisionTimeout(tt.minutes); got != tt.want {
+			t.Errorf("provisionTimeout(%d) = %s, want %s", tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestProvisionNDFC_JobTimeoutExceeded(t *testing.T) {
+	// NDFC answers every request after 2s, so a 1s job timeout expires during pre-flight validation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[]`))
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc := NewJobService(nil, client, &config.NexusDashboardConfig{}, nil)
+
+	start := time.Now()
+	err = svc.provisionNDFC(context.Background(), &models.Job{SlurmJobID: "1001"}, nil, nil, nil, false,
+		"fabric1", "vrf1", "net1", "1001", "HPC Job 1001", time.Second)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 2*time.Second {
+		t.Errorf("provisioning returned after %s, want it cut off by the 1s job timeout", elapsed)
+	}
+}
+
+func TestProvisionNDFC_CircuitOpenCreatesNothing(t *testing.T) {
+	// NDFC is down: every request fails with 503 until the breaker opens
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	for i := 0; i < ndclient.DefaultCircuitFailureThreshold; i++ {
+		_ = client.Get(context.Background(), "/health", nil)
+	}
+	atomic.StoreInt32(&requests, 0)
+	svc := NewJobService(nil, client, &config.NexusDashboardConfig{}, nil)
+
+	err = svc.provisionNDFC(context.Background(), &models.Job{SlurmJobID: "1001"}, nil, nil, nil, false,
+		"fabric1", "vrf1", "net1", "1001", "HPC Job 1001", time.Minute)
+	if !ndclient.IsCircuitOpenError(err) {
+		t.Fatalf("err = %v, want a circuit open error", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("NDFC got %d requests with the breaker open, want 0", n)
+	}
+}
+
+func TestBulkGetJobs_SingleJobsQuery(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.JobComputeNode{}, &models.ComputeNode{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SwitchPort{})
+	for _, id := range []string{"1001", "1002", "1003"} {
+		if err := db.Create(&models.Job{ID: "j" + id, SlurmJobID: id, Status: string(models.JobStatusActive), FabricName: "f1"}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Create(&models.JobComputeNode{ID: "jcn1", JobID: "j1001", ComputeNodeID: "n1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the queries against the jobs table, and all queries
+	var jobQueries, queries int
+	if err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(tx *gorm.DB) {
+		queries++
+		if tx.Statement.Table == "jobs" {
+			jobQueries++
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	jobs, err := svc.BulkGetJobs(context.Background(), []string{"1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oneJobQueries := queries
+	if len(jobs) != 1 || jobs["1001"] == nil || len(jobs["1001"].ComputeNodes) != 1 {
+		t.Fatalf("jobs = %v, want 1001 with its compute node", jobs)
+	}
+
+	jobQueries, queries = 0, 0
+	jobs, err = svc.BulkGetJobs(context.Background(), []string{"1001", "1002", "1003", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 3 || jobs["missing"] != nil {
+		t.Errorf("jobs = %v, want 1001-1003 without missing", jobs)
+	}
+	if jobQueries != 1 {
+		t.Errorf("jobs table queried %d times, want 1", jobQueries)
+	}
+	if queries != oneJobQueries {
+		t.Errorf("%d queries for 4 IDs, %d for 1: query count must not grow with IDs", queries, oneJobQueries)
+	}
+
+	ids := make([]string, MaxBulkGetJobs+1)
+	if _, err := svc.BulkGetJobs(context.Background(), ids); !errors.Is(err, ErrTooManyJobIDs) {
+		t.Errorf("err = %v, want ErrTooManyJobIDs", err)
+	}
+}
+
+func TestGenerateGroupIDs_InRange(t *testing.T) {
+	jobs := &JobService{}
+	storage := &StorageService{}
+	for i := 0; i < 20000; i++ {
+		// Long node names overflow the storage hash
+		for _, name := range []string{fmt.Sprint(i), fmt.Sprintf("%d_%d", i, i*7919), fmt.Sprintf("compute-node-%05d", i), fmt.Sprintf("gpu-rack%02d-node-%04d.hpc.example.com", i%40, i)} {
+			id, err := jobs.generateGroupID(name)
+			if err != nil || id < ndclient.MinGroupID || id > ndclient.MaxGroupID {
+				t.Fatalf("generateGroupID(%q) = %d, %v; outside [%d, %d]", name, id, err, ndclient.MinGroupID, ndclient.MaxGroupID)
+			}
+			id, err = storage.generateStorageGroupID(name)
+			if err != nil || id < 32768 || id > ndclient.MaxGroupID {
+				t.Fatalf("generateStorageGroupID(%q) = %d, %v; outside [32768, %d]", name, id, err, ndclient.MaxGroupID)
+			}
+		}
+	}
+}
+
+func TestProvision_Metrics(t *testing.T) {
+	svc, db := newSubmissionTestService(t, newMemStore())
+	if err := db.AutoMigrate(&models.SecurityAssociation{}); err != nil {
+		t.Fatal(err)
+	}
+	before := testutil.ToFloat64(metrics.JobProvisionsTotal.WithLabelValues(metrics.ResultSuccess))
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	// Without NDFC the job stays provisioning
+	job := result.Job
+	job.Status = string(models.JobStatusActive)
+	if err := db.Model(job).Update("status", job.Status).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Deprovision(context.Background(), job); err != nil {
+		t.Fatalf("Deprovision: %v", err)
+	}
+	if n := testutil.ToFloat64(metrics.JobProvisionsTotal.WithLabelValues(metrics.ResultSuccess)) - before; n != 1 {
+		t.Errorf("nd_job_provisions_total{result=success} grew by %.0f, want 1", n)
+	}
+
+	server := httptest.NewServer(metrics.NewServer("0").Handler)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, name := range []string{
+		`nd_job_provisions_total{result="success"}`,
+		`nd_job_provision_duration_seconds_count{result="success"}`,
+		`nd_job_deprovisions_total{result="success"}`,
+		`nd_job_deprovision_duration_seconds_count{result="success"}`,
+	} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("/metrics has no %s", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"go.uber.org/zap"
+)
+
+// JobStatusEvent is published as JSON on a job's events channel (cache.JobEventsChannel)
+// each time Provision or Deprovision changes the job's status
+type JobStatusEvent struct {
+	SlurmJobID     string    `json:"slurm_job_id"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// SetStatusEventStore replaces Valkey as the pub/sub carrying job status changes. nil stops
+// publishing them.
+func (s *JobService) SetStatusEventStore(store cache.Store) {
+	s.statusEvents = store
+}
+
+// publishJobStatus announces that job moved from previous to its current status. Publishing
+// is best-effort: watchers are informational, so a failure is only logged.
+func (s *JobService) publishJobStatus(job *models.Job, previous string) {
+	if s.statusEvents == nil {
+		return
+	}
+	event := JobStatusEvent{
+		SlurmJobID:     job.SlurmJobID,
+		Status:         job.Status,
+		PreviousStatus: previous,
+		Timestamp:      time.Now().UTC(),
+	}
+	if job.ErrorMessage != nil {
+		event.ErrorMessage = *job.ErrorMessage
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	// Not the request context: the status changed even if the caller has gone
+	ctx, cancel := context.WithTimeout(context.Background(), cacheOpTimeout)
+	defer cancel()
+	if err := s.statusEvents.Publish(ctx, cache.JobEventsChannel(job.SlurmJobID), string(data)); err != nil {
+		logger.Warn("Failed to publish job status change",
+			zap.String("slurm_job_id", job.SlurmJobID),
+			zap.String("status", job.Status),
+			zap.Error(err))
+	}
+}
+
+// WatchJobStatus calls handler with each status change of the Slurm job until ctx is
+// cancelled or the subscription fails. Without Valkey it blocks until ctx is cancelled
+// without delivering anything. Malformed messages are skipped. Wrap ctx with
+// cache.WithSubscribed to learn when changes start being delivered.
+func (s *JobService) WatchJobStatus(ctx context.Context, slurmJobID string, handler func(JobStatusEvent)) error {
+	store := s.statusEvents
+	if store == nil {
+		store = cache.NoOpCacheClient{}
+	}
+	return store.Subscribe(ctx, []string{cache.JobEventsChannel(slurmJobID)}, func(_, message string) {
+		var event JobStatusEvent
+		if err := json.Unmarshal([]byte(message), &event); err != nil {
+			logger.Warn("Ignoring malformed job status event",
+				zap.String("slurm_job_id", slurmJobID),
+				zap.Error(err))
+			return
+		}
+		handler(event)
+	})
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestDeprovision_PublishesStatusChanges(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SecurityAssociation{})
+	job := models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "f1"}
+	if err := db.Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	store := cachetest.NewInMemoryCache()
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetStatusEventStore(store)
+
+	var mu sync.Mutex
+	var events []JobStatusEvent
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = svc.WatchJobStatus(ctx, "1001", func(event JobStatusEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		})
+	}()
+	// The subscription starts asynchronously: publish a probe until it arrives
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		_ = store.Publish(ctx, cache.JobEventsChannel("1001"), `{"status": "probe"}`)
+		mu.Lock()
+		subscribed := len(events) > 0
+		events = nil
+		mu.Unlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not started")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := svc.Deprovision(context.Background(), &job); err != nil {
+		t.Fatalf("Deprovision: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := [][2]string{{"active", "deprovisioning"}, {"deprovisioning", "completed"}}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %d status changes", events, len(want))
+	}
+	for i, event := range events {
+		if event.SlurmJobID != "1001" || event.PreviousStatus != want[i][0] || event.Status != want[i][1] || event.Timestamp.IsZero() {
+			t.Errorf("event %d = %+v, want %s -> %s", i, event, want[i][0], want[i][1])
+		}
+	}
+}
+
+func TestWatchJobStatus_WithoutStore(t *testing.T) {
+	svc := NewJobService(nil, nil, &config.NexusDashboardConfig{}, nil)
+	svc.SetStatusEventStore(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := svc.WatchJobStatus(ctx, "1001", func(JobStatusEvent) { t.Error("unexpected event") })
+	if err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want context.DeadlineExceeded once ctx is done", err)
+	}
+}
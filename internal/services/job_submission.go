@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrJobSubmissionInProgress is returned when another request is still submitting the same Slurm job
+var ErrJobSubmissionInProgress = errors.New("job submission already in progress")
+
+// Concurrent submission handling
+const (
+	submissionWaitTimeout  = 2 * time.Second        // How long a duplicate submission waits for the job to appear
+	submissionPollInterval = 100 * time.Millisecond // How often it checks the database meanwhile
+)
+
+// SetInstanceID sets the value stored in job submission idempotency keys, identifying the
+// instance holding a claim. Empty values keep the generated ID.
+func (s *JobService) SetInstanceID(id string) {
+	if id != "" {
+		s.instanceID = id
+	}
+}
+
+// claimSubmission claims the Slurm job ID in Valkey with SET NX so concurrent submissions of the
+// same job do not race between the existence check and the insert. It returns
+// ErrJobSubmissionInProgress if another request holds the claim. Without Valkey, or if Valkey
+// fails, the claim is skipped and the jobs unique constraint remains the only guard. The returned
+// release function is idempotent; unreleased claims expire after cache.TTLJobSubmission.
+func (s *JobService) claimSubmission(ctx context.Context, slurmJobID string) (func(), error) {
+	if s.submissions == nil {
+		return func() {}, nil
+	}
+
+	key := cache.JobSubmission(slurmJobID)
+	claimCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	claimed, err := s.submissions.SetNX(claimCtx, key, s.instanceID, cache.TTLJobSubmission)
+	cancel()
+	if err != nil {
+		logger.Warn("Failed to claim job submission key, continuing without it",
+			zap.String("slurm_job_id", slurmJobID),
+			zap.Error(err))
+		return func() {}, nil
+	}
+	if !claimed {
+		return nil, ErrJobSubmissionInProgress
+	}
+
+	return sync.OnceFunc(func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), cacheOpTimeout)
+		defer cancel()
+		if err := s.submissions.ReleaseLock(releaseCtx, key, s.instanceID); err != nil {
+			logger.Warn("Failed to release job submission key",
+				zap.String("slurm_job_id", slurmJobID),
+				zap.Error(err))
+		}
+	}), nil
+}
+
+// awaitSubmission waits briefly for a concurrent submission of the same Slurm job to create it,
+// then returns the job as an existing submission. If the job has not appeared by then it returns
+// ErrJobSubmissionInProgress.
+func (s *JobService) awaitSubmission(ctx context.Context, slurmJobID string) (*ProvisionResult, error) {
+	timer := time.NewTimer(s.submissionWait)
+	defer timer.Stop()
+	ticker := time.NewTicker(submissionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.existingSubmission(ctx, slurmJobID)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, fmt.Errorf("%w: job %s", ErrJobSubmissionInProgress, slurmJobID)
+		case <-ticker.C:
+		}
+	}
+}
+
+// existingSubmission returns an already submitted job for a repeated submission: active and
+// provisioning jobs are returned as-is, finished ones are a conflict. It returns
+// gorm.ErrRecordNotFound if the job does not exist.
+func (s *JobService) existingSubmission(ctx context.Context, slurmJobID string) (*ProvisionResult, error) {
+	var job models.Job
+	err := s.db.WithContext(ctx).Where("slurm_job_id = ?", slurmJobID).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	// Distinguish "not found" from other DB errors (connection issues, context canceled, etc.)
+	if err != nil {
+		return nil, fmt.Errorf("lookup job %s: %w", slurmJobID, err)
+	}
+
+	if job.Status == string(models.JobStatusActive) ||
+		job.Status == string(models.JobStatusProvisioning) {
+		s.db.WithContext(ctx).Preload("ComputeNodes.ComputeNode").
+			Preload("SecurityGroup.Selectors.SwitchPort").
+			First(&job, "id = ?", job.ID)
+		return &ProvisionResult{Job: &job, Created: false}, nil
+	}
+	// Job exists but completed/failed - conflict
+	return nil, fmt.Errorf("job %s already exists with status %s", slurmJobID, job.Status)
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// memStore is an in-memory cache.Store; TTLs are ignored
+type memStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+var _ cache.Store = (*memStore)(nil)
+
+func newMemStore() *memStore { return &memStore{values: make(map[string]string)} }
+
+func (m *memStore) SetNX(_ context.Context, key, value string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; ok {
+		return false, nil
+	}
+	m.values[key] = value
+	return true, nil
+}
+
+func (m *memStore) ReleaseLock(_ context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values[key] == value {
+		delete(m.values, key)
+	}
+	return nil
+}
+
+func (m *memStore) ExtendLockIfOwner(_ context.Context, key, value string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[key] == value, nil
+}
+
+func (m *memStore) SetString(_ context.Context, key, value string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func (m *memStore) GetString(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	if !ok {
+		return "", cache.ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (m *memStore) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.values, key)
+	}
+	return nil
+}
+
+func (m *memStore) Publish(context.Context, string, string) error { return nil }
+
+func (m *memStore) Subscribe(context.Context, []string, func(string, string)) error { return nil }
+
+// newSubmissionTestService seeds node1 mapped to a switch port and returns a JobService
+// without NDFC that claims submissions in store
+func newSubmissionTestService(t *testing.T, store cache.Store) (*JobService, *gorm.DB) {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Switch{}, &models.SwitchPort{}, &models.ComputeNode{},
+		&models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{}, &models.ComputeNodePortMappingHistory{},
+		&models.ComputeNodeLabel{}, &models.Job{}, &models.JobComputeNode{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.Network{})
+	for _, r := range []interface{}{
+		&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "s1"},
+		&models.ComputeNode{ID: "n1", Name: "node1"},
+		&models.ComputeNodePortMapping{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "p1"},
+	} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{ComputeFabricName: "f1"}, nil)
+	svc.submissions = store
+	svc.SetInstanceID("instance-a")
+	return svc, db
+}
+
+func TestProvision_ConcurrentSubmissions(t *testing.T) {
+	store := newMemStore()
+	svc, db := newSubmissionTestService(t, store)
+	input := ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}}
+
+	const submissions = 2
+	results := make([]*ProvisionResult, submissions)
+	errs := make([]error, submissions)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range submissions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = svc.Provision(context.Background(), input)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	created := 0
+	for i := range submissions {
+		if errs[i] != nil {
+			t.Fatalf("submission %d: %v", i, errs[i])
+		}
+		if results[i].Created {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Errorf("created = %d, want exactly 1", created)
+	}
+	if results[0].Job.ID != results[1].Job.ID {
+		t.Errorf("submissions returned jobs %s and %s, want the same job", results[0].Job.ID, results[1].Job.ID)
+	}
+
+	var count int64
+	if err := db.Model(&models.Job{}).Where("slurm_job_id = ?", "1001").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("job rows = %d, want 1", count)
+	}
+	if _, err := store.GetString(context.Background(), cache.JobSubmission("1001")); !errors.Is(err, cache.ErrKeyNotFound) {
+		t.Errorf("submission key still held after provisioning (err = %v)", err)
+	}
+}
+
+func TestProvision_SubmissionInProgress(t *testing.T) {
+	store := newMemStore()
+	svc, db := newSubmissionTestService(t, store)
+	svc.submissionWait = 300 * time.Millisecond
+	ctx := context.Background()
+
+	// Another instance holds the claim and never creates the job
+	if _, err := store.SetNX(ctx, cache.JobSubmission("1001"), "instance-b", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	input := ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}}
+	if _, err := svc.Provision(ctx, input); !errors.Is(err, ErrJobSubmissionInProgress) {
+		t.Fatalf("err = %v, want ErrJobSubmissionInProgress", err)
+	}
+	if owner, _ := store.GetString(ctx, cache.JobSubmission("1001")); owner != "instance-b" {
+		t.Errorf("claim owner = %q, want instance-b's claim left alone", owner)
+	}
+
+	// The other instance's job lands while this submission waits
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = db.Create(&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusProvisioning), FabricName: "f1"}).Error
+	}()
+	result, err := svc.Provision(ctx, input)
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if result.Created || result.Job.ID != "j1" {
+		t.Errorf("result = created %v job %s, want existing job j1", result.Created, result.Job.ID)
+	}
+}
+
+func TestProvision_WithoutSubmissionStore(t *testing.T) {
+	svc, _ := newSubmissionTestService(t, nil)
+	input := ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}}
+
+	first, err := svc.Provision(context.Background(), input)
+	if err != nil || !first.Created {
+		t.Fatalf("first submission = %+v, %v; want created", first, err)
+	}
+	second, err := svc.Provision(context.Background(), input)
+	if err != nil || second.Created || second.Job.ID != first.Job.ID {
+		t.Fatalf("second submission = %+v, %v; want existing job %s", second, err, first.Job.ID)
+	}
+}
+
+func TestProvision_Tags(t *testing.T) {
+	svc, _ := newSubmissionTestService(t, nil)
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"},
+		Tags: map[string]string{"account": "phys101", "comment": "calibration run"}})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	page, err := svc.ListJobs(context.Background(), JobListOptions{Tags: map[string]string{"account": "phys101"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Jobs) != 1 || page.Jobs[0].ID != result.Job.ID || string(page.Jobs[0].Tags) != `{"account":"phys101","comment":"calibration run"}` {
+		t.Errorf("jobs tagged account=phys101 = %+v, want the submitted job with its tags", page.Jobs)
+	}
+
+	for _, tags := range []map[string]string{{"": "x"}, {"bad key": "x"}, {"account": ""}} {
+		_, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1002", ComputeNodes: []string{"node1"}, Tags: tags})
+		if !errors.Is(err, ErrInvalidJobMetadata) {
+			t.Errorf("tags %v: err = %v, want ErrInvalidJobMetadata", tags, err)
+		}
+	}
+}
+
+func TestProvision_RecordsSharedContracts(t *testing.T) {
+	svc, _ := newSubmissionTestService(t, nil)
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	// Reloading the list later does not change what the job recorded
+	svc.registry.SharedContracts.Store(nil)
+	job, err := svc.GetJob(context.Background(), "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"dst_group_name":"SG_AD","contract_name":"matchAD"}]`
+	if job.ID != result.Job.ID || string(job.SharedContracts) != want {
+		t.Errorf("shared contracts = %s, want %s", job.SharedContracts, want)
+	}
+}
+
+func TestProvision_NetworkOversubscribed(t *testing.T) {
+	svc, db := newSubmissionTestService(t, nil)
+	svc.cfg.ComputeNetworkName = "net1"
+	svc.cfg.MaxPortsPerNetwork = 1
+	ctx := context.Background()
+
+	// The node's port is reserved on the network when the job is allocated
+	result, err := svc.Provision(ctx, ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if result.Job.NetworkName != "net1" || result.Job.AttachedPortCount != 1 {
+		t.Errorf("job network %q with %d ports, want net1 with 1", result.Job.NetworkName, result.Job.AttachedPortCount)
+	}
+
+	// Another job cannot oversubscribe the full network, and leaves nothing behind
+	if err := db.Where("job_id = ?", result.Job.ID).Delete(&models.ComputeNodeAllocation{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Provision(ctx, ProvisionInput{SlurmJobID: "1002", ComputeNodes: []string{"node1"}})
+	if !errors.Is(err, ErrNetworkOversubscribed) {
+		t.Fatalf("err = %v, want ErrNetworkOversubscribed", err)
+	}
+	var jobs int64
+	db.Model(&models.Job{}).Where("slurm_job_id = ?", "1002").Count(&jobs)
+	if jobs != 0 {
+		t.Errorf("%d jobs created for the rejected submission, want 0", jobs)
+	}
+	if got, _ := NetworkAttachmentCount(ctx, db, "f1", "net1"); got != 1 {
+		t.Errorf("count = %d, want 1", got)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+)
+
+// JobSummary is a compact view of a job for Slurm accounting (sacct)
+type JobSummary struct {
+	SlurmJobID      string     `json:"slurm_job_id"`
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	SubmittedAt     time.Time  `json:"submitted_at"`
+	ProvisionedAt   *time.Time `json:"provisioned_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	NodeCount       int        `json:"node_count"`
+	NodeNames       []string   `json:"node_names"` // Sorted
+	Fabric          string     `json:"fabric"`
+	VRF             string     `json:"vrf"`
+	Contract        string     `json:"contract"`
+	SecurityGroupID *int       `json:"security_group_id"` // NDFC group ID, nil until provisioned
+	ExitCode        *int       `json:"exit_code"`         // Slurm exit status, nil until completed with one
+	Signal          *int       `json:"signal"`
+	FailedReason    *string    `json:"failed_reason"`
+}
+
+// GetJobSummary returns the summary of a job from the local database only. Only the node
+// names and the security group are read, not the job's full associations.
+// Returns gorm.ErrRecordNotFound for an unknown job.
+func (s *JobService) GetJobSummary(ctx context.Context, slurmJobID string) (*JobSummary, error) {
+	db := s.db.WithContext(ctx)
+	var job models.Job
+	if err := db.Joins("SecurityGroup").
+		Where("jobs.slurm_job_id = ?", slurmJobID).
+		First(&job).Error; err != nil {
+		return nil, err
+	}
+	var nodeNames []string
+	if err := db.Model(&models.JobComputeNode{}).
+		Joins("JOIN compute_nodes ON compute_nodes.id = job_compute_nodes.compute_node_id AND compute_nodes.deleted_at IS NULL").
+		Where("job_compute_nodes.job_id = ?", job.ID).
+		Order("compute_nodes.name").
+		Pluck("compute_nodes.name", &nodeNames).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &JobSummary{
+		SlurmJobID:    job.SlurmJobID,
+		Name:          job.Name,
+		Status:        job.Status,
+		SubmittedAt:   job.SubmittedAt,
+		ProvisionedAt: job.ProvisionedAt,
+		CompletedAt:   job.CompletedAt,
+		NodeCount:     len(nodeNames),
+		NodeNames:     append([]string{}, nodeNames...),
+		Fabric:        job.FabricName,
+		VRF:           job.VRFName,
+		Contract:      job.ContractName,
+		ExitCode:      job.ExitCode,
+		Signal:        job.Signal,
+		FailedReason:  job.FailedReason,
+	}
+	if job.SecurityGroup != nil {
+		if id, err := strconv.Atoi(job.SecurityGroup.NDObjectID); err == nil {
+			summary.SecurityGroupID = &id
+		}
+	}
+	return summary, nil
+}
+
+// Text formats the summary as one key=value line per field, in JSON field order, for shell
+// scripts. Times are RFC 3339, node names comma-separated, and unset values empty.
+func (s *JobSummary) Text() string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	formatInt := func(i *int) string {
+		if i == nil {
+			return ""
+		}
+		return strconv.Itoa(*i)
+	}
+	failedReason := ""
+	if s.FailedReason != nil {
+		failedReason = *s.FailedReason
+	}
+
+	var b strings.Builder
+	for _, kv := range [][2]string{
+		{"slurm_job_id", s.SlurmJobID},
+		{"name", s.Name},
+		{"status", s.Status},
+		{"submitted_at", formatTime(&s.SubmittedAt)},
+		{"provisioned_at", formatTime(s.ProvisionedAt)},
+		{"completed_at", formatTime(s.CompletedAt)},
+		{"node_count", strconv.Itoa(s.NodeCount)},
+		{"node_names", strings.Join(s.NodeNames, ",")},
+		{"fabric", s.Fabric},
+		{"vrf", s.VRF},
+		{"contract", s.Contract},
+		{"security_group_id", formatInt(s.SecurityGroupID)},
+		{"exit_code", formatInt(s.ExitCode)},
+		{"signal", formatInt(s.Signal)},
+		{"failed_reason", failedReason},
+	} {
+		// Keep every field on one line, whatever the job name contains
+		fmt.Fprintf(&b, "%s=%s\n", kv[0], strings.NewReplacer("\r", " ", "\n", " ").Replace(kv[1]))
+	}
+	return b.String()
+}
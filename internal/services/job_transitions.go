@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// Job transition errors
+var (
+	// ErrConcurrentModification is returned when a guarded status transition finds
+	// that another caller changed the job first (version or status no longer match)
+	ErrConcurrentModification = errors.New("job was modified concurrently")
+	// ErrInvalidJobState is returned when a job's status does not allow the transition
+	ErrInvalidJobState = errors.New("invalid job state for transition")
+)
+
+// deprovisionableStatuses are the statuses Deprovision may start from, besides a stale
+// deprovisioning (staleDeprovisioningAfter).
+// cleanup_failed allows retrying a failed cleanup; failed lets Slurm complete
+// jobs whose provisioning failed.
+var deprovisionableStatuses = []string{
+	string(models.JobStatusActive),
+	string(models.JobStatusCleanupFailed),
+	string(models.JobStatusFailed),
+}
+
+// staleDeprovisioningAfter is how long a job may stay deprovisioning before Deprovision may
+// start over, e.g. after its local cleanup failed or the process died partway through. It
+// outlasts the NDFC deprovisioning timeout, so a running deprovision is never taken over.
+const staleDeprovisioningAfter = ndfcDeprovisionTimeout + time.Minute
+
+// deprovisionableFrom returns the statuses job may be claimed for deprovisioning from, or nil
+// if its status does not allow it
+func deprovisionableFrom(job *models.Job) []string {
+	if slices.Contains(deprovisionableStatuses, job.Status) {
+		return deprovisionableStatuses
+	}
+	if job.Status == string(models.JobStatusDeprovisioning) && time.Since(job.UpdatedAt) > staleDeprovisioningAfter {
+		return []string{string(models.JobStatusDeprovisioning)}
+	}
+	return nil
+}
+
+// transitionJob moves job to status "to" only if its row still has the caller's
+// version and one of the "from" statuses, bumping the version. On success the
+// in-memory job is updated to match; otherwise ErrConcurrentModification is returned.
+func transitionJob(ctx context.Context, db *gorm.DB, job *models.Job, to models.JobStatus, from ...string) error {
+	now := time.Now()
+	result := db.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND version = ? AND status IN ?", job.ID, job.Version, from).
+		Updates(map[string]interface{}{
+			"status":     string(to),
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job status: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("%w: job %s (version %d) is no longer %v", ErrConcurrentModification, job.SlurmJobID, job.Version, from)
+	}
+	job.Status = string(to)
+	job.Version++
+	job.UpdatedAt = now
+	return nil
+}
+
+// RetryOnConflict calls fn up to maxRetries+1 times while it returns
+// ErrConcurrentModification. fn should reload any state it depends on.
+// Other errors (and success) are returned immediately.
+func RetryOnConflict(maxRetries int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if err = fn(); !errors.Is(err, ErrConcurrentModification) {
+			return err
+		}
+	}
+	return err
+}
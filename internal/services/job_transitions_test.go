@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestDeprovision_ConcurrentCallsOnlyOneSucceeds(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SecurityAssociation{})
+	if err := db.Create(&models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusActive), FabricName: "f1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	// Both callers read the job (status=active, version=0) before either updates it
+	var loaded [2]models.Job
+	for i := range loaded {
+		if err := db.First(&loaded[i], "id = ?", "j1").Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	start := make(chan struct{})
+	for i := range loaded {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = svc.Deprovision(context.Background(), &loaded[i])
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded, conflicted := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, ErrConcurrentModification):
+			conflicted++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 || conflicted != 1 {
+		t.Fatalf("succeeded = %d, conflicted = %d; want exactly one of each (errs %v)", succeeded, conflicted, errs)
+	}
+
+	var job models.Job
+	if err := db.First(&job, "id = ?", "j1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != string(models.JobStatusCompleted) || job.Version != 1 {
+		t.Errorf("job status = %s version = %d, want completed version 1", job.Status, job.Version)
+	}
+}
+
+func TestDeprovision_RejectsInvalidState(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{})
+	job := models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusDeprovisioning), FabricName: "f1"}
+	if err := db.Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	if err := svc.Deprovision(context.Background(), &job); !errors.Is(err, ErrInvalidJobState) {
+		t.Errorf("err = %v, want ErrInvalidJobState", err)
+	}
+	if err := svc.Deprovision(context.Background(), nil); err == nil {
+		t.Error("nil job: want an error")
+	}
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	calls := 0
+	err := RetryOnConflict(3, func() error {
+		calls++
+		if calls < 3 {
+			return ErrConcurrentModification
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("err = %v, calls = %d; want success on third call", err, calls)
+	}
+
+	calls = 0
+	err = RetryOnConflict(2, func() error {
+		calls++
+		return ErrConcurrentModification
+	})
+	if !errors.Is(err, ErrConcurrentModification) || calls != 3 {
+		t.Errorf("err = %v, calls = %d; want conflict after 3 calls", err, calls)
+	}
+
+	calls = 0
+	other := errors.New("boom")
+	if err := RetryOnConflict(5, func() error { calls++; return other }); !errors.Is(err, other) || calls != 1 {
+		t.Errorf("err = %v, calls = %d; want other error without retry", err, calls)
+	}
+}
+
+func TestDeprovision_RetriesStaleDeprovisioning(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{}, &models.SecurityAssociation{})
+	job := models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusDeprovisioning), FabricName: "f1"}
+	if err := db.Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	// The deprovision that set the status died long ago
+	stale := time.Now().Add(-staleDeprovisioningAfter - time.Minute)
+	if err := db.Model(&models.Job{}).Where("id = ?", "j1").UpdateColumn("updated_at", stale).Error; err != nil {
+		t.Fatal(err)
+	}
+	job.UpdatedAt = stale
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	if err := svc.Deprovision(context.Background(), &job); err != nil {
+		t.Fatalf("Deprovision: %v", err)
+	}
+	var stored models.Job
+	if err := db.First(&stored, "id = ?", "j1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != string(models.JobStatusCompleted) {
+		t.Errorf("status = %s, want completed", stored.Status)
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// NDFCConfigStatus reports whether the NDFC objects named in configuration exist.
+// Checks for unconfigured names, or whose fabric is missing, are skipped and report false.
+type NDFCConfigStatus struct {
+	ComputeFabricExists  bool              `json:"compute_fabric_exists"`
+	ComputeVRFExists     bool              `json:"compute_vrf_exists"`
+	ComputeNetworkExists bool              `json:"compute_network_exists"`
+	ComputeNetworkVLAN   string            `json:"compute_network_vlan"`
+	StorageFabricExists  bool              `json:"storage_fabric_exists"`
+	StorageNetworkExists bool              `json:"storage_network_exists"`
+	Errors               map[string]string `json:"errors,omitempty"` // Check name -> NDFC error
+}
+
+// Missing returns the configured NDFC objects that were not found, as "kind name" strings
+func (s *NDFCConfigStatus) Missing(cfg NDFCConfigNames) []string {
+	var missing []string
+	add := func(ok bool, kind, name string) {
+		if name != "" && !ok {
+			missing = append(missing, kind+" "+name)
+		}
+	}
+	add(s.ComputeFabricExists, "compute fabric", cfg.ComputeFabric)
+	add(s.ComputeVRFExists, "compute VRF", cfg.ComputeVRF)
+	add(s.ComputeNetworkExists, "compute network", cfg.ComputeNetwork)
+	add(s.StorageFabricExists, "storage fabric", cfg.StorageFabric)
+	add(s.StorageNetworkExists, "storage network", cfg.StorageNetwork)
+	return missing
+}
+
+// NDFCConfigNames are the configured NDFC object names checked by CheckNDFCConfig
+type NDFCConfigNames struct {
+	ComputeFabric  string
+	ComputeVRF     string
+	ComputeNetwork string
+	StorageFabric  string
+	StorageNetwork string
+}
+
+// NDFCConfigNames returns the NDFC object names this service provisions against
+func (s *JobService) NDFCConfigNames() NDFCConfigNames {
+	return NDFCConfigNames{
+		ComputeFabric:  s.cfg.ComputeFabricName,
+		ComputeVRF:     s.cfg.ComputeVRFName,
+		ComputeNetwork: s.cfg.ComputeNetworkName,
+		StorageFabric:  s.cfg.StorageFabricName,
+		StorageNetwork: s.cfg.StorageNetworkName,
+	}
+}
+
+// CheckNDFCConfig verifies that the configured compute and storage fabrics, the compute VRF
+// and the compute and storage networks exist in NDFC. VRF and network lookups go through the
+// same Valkey cache used during provisioning. A failed lookup is recorded in Errors and the
+// remaining checks still run.
+func (s *JobService) CheckNDFCConfig(ctx context.Context) (*NDFCConfigStatus, error) {
+	if s.ndClient == nil {
+		return nil, errors.New("Nexus Dashboard client not configured")
+	}
+	lanFabric := s.ndClient.LANFabric()
+	names := s.NDFCConfigNames()
+	status := &NDFCConfigStatus{}
+
+	recordErr := func(check string, err error) {
+		if status.Errors == nil {
+			status.Errors = make(map[string]string)
+		}
+		status.Errors[check] = err.Error()
+	}
+
+	// One fabric listing answers both fabric checks
+	if names.ComputeFabric != "" || names.StorageFabric != "" {
+		fabrics, err := lanFabric.GetFabricsNDFC(ctx)
+		if err != nil {
+			recordErr("fabrics", err)
+		}
+		for _, f := range fabrics {
+			if names.ComputeFabric != "" && f.FabricName == names.ComputeFabric {
+				status.ComputeFabricExists = true
+			}
+			if names.StorageFabric != "" && f.FabricName == names.StorageFabric {
+				status.StorageFabricExists = true
+			}
+		}
+	}
+
+	if status.ComputeFabricExists {
+		if names.ComputeVRF != "" {
+			exists, err := s.checkVRFExistsWithCache(ctx, lanFabric, names.ComputeFabric, names.ComputeVRF)
+			if err != nil {
+				recordErr("compute_vrf", err)
+			}
+			status.ComputeVRFExists = exists
+		}
+		if names.ComputeNetwork != "" {
+			exists, err := s.checkNetworkExistsWithCache(ctx, lanFabric, names.ComputeFabric, names.ComputeNetwork)
+			if err != nil {
+				recordErr("compute_network", err)
+			}
+			status.ComputeNetworkExists = exists
+		}
+		if status.ComputeNetworkExists {
+			vlan, err := s.getNetworkVLANWithCache(ctx, names.ComputeFabric, names.ComputeNetwork)
+			if err != nil {
+				recordErr("compute_network_vlan", fmt.Errorf("get VLAN for network %q: %w", names.ComputeNetwork, err))
+			}
+			status.ComputeNetworkVLAN = vlan
+		}
+	}
+
+	if status.StorageFabricExists && names.StorageNetwork != "" {
+		exists, err := s.checkNetworkExistsWithCache(ctx, lanFabric, names.StorageFabric, names.StorageNetwork)
+		if err != nil {
+			recordErr("storage_network", err)
+		}
+		status.StorageNetworkExists = exists
+	}
+
+	return status, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// fakeNDFCObjects serves the fabric, VRF and network listings used by CheckNDFCConfig.
+// networks maps fabric -> network name -> VLAN; a fabric in failing answers 500.
+type fakeNDFCObjects struct {
+	fabrics  []string
+	vrfs     map[string][]string
+	networks map[string]map[string]string
+	failing  map[string]bool
+}
+
+func (f *fakeNDFCObjects) serve(t *testing.T) *ndclient.Client {
+	t.Helper()
+	const prefix = "/appcenter/cisco/ndfc/api/v1/lan-fabric/rest/"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		var body interface{}
+		switch {
+		case path == "control/fabrics":
+			fabrics := []map[string]interface{}{}
+			for i, name := range f.fabrics {
+				fabrics = append(fabrics, map[string]interface{}{"id": i + 1, "fabricName": name})
+			}
+			body = fabrics
+		case strings.HasPrefix(path, "top-down/fabrics/"):
+			parts := strings.Split(strings.TrimPrefix(path, "top-down/fabrics/"), "/")
+			if f.failing[parts[0]] {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"message":"internal error"}`))
+				return
+			}
+			items := []map[string]interface{}{}
+			switch parts[1] {
+			case "vrfs":
+				for _, name := range f.vrfs[parts[0]] {
+					items = append(items, map[string]interface{}{"vrfName": name})
+				}
+			case "networks":
+				for name, vlan := range f.networks[parts[0]] {
+					items = append(items, map[string]interface{}{
+						"networkName":           name,
+						"networkTemplateConfig": `{"vlanId":"` + vlan + `"}`,
+					})
+				}
+			}
+			body = items
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client
+}
+
+func TestCheckNDFCConfig(t *testing.T) {
+	cfg := &config.NexusDashboardConfig{
+		ComputeFabricName:  "compute",
+		ComputeVRFName:     "vrf-compute",
+		ComputeNetworkName: "net-compute",
+		StorageFabricName:  "storage",
+		StorageNetworkName: "net-storage",
+	}
+	complete := func() *fakeNDFCObjects {
+		return &fakeNDFCObjects{
+			fabrics:  []string{"compute", "storage"},
+			vrfs:     map[string][]string{"compute": {"vrf-compute"}},
+			networks: map[string]map[string]string{"compute": {"net-compute": "2301"}, "storage": {"net-storage": "3001"}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(*fakeNDFCObjects)
+		want        NDFCConfigStatus
+		wantMissing []string
+		wantErrors  []string
+	}{
+		{
+			name: "all present",
+			want: NDFCConfigStatus{ComputeFabricExists: true, ComputeVRFExists: true, ComputeNetworkExists: true,
+				ComputeNetworkVLAN: "2301", StorageFabricExists: true, StorageNetworkExists: true},
+		},
+		{
+			name:   "storage network missing",
+			mutate: func(f *fakeNDFCObjects) { delete(f.networks, "storage") },
+			want: NDFCConfigStatus{ComputeFabricExists: true, ComputeVRFExists: true, ComputeNetworkExists: true,
+				ComputeNetworkVLAN: "2301", StorageFabricExists: true},
+			wantMissing: []string{"storage network net-storage"},
+		},
+		{
+			name:        "compute VRF missing",
+			mutate:      func(f *fakeNDFCObjects) { f.vrfs = nil },
+			want:        NDFCConfigStatus{ComputeFabricExists: true, ComputeNetworkExists: true, ComputeNetworkVLAN: "2301", StorageFabricExists: true, StorageNetworkExists: true},
+			wantMissing: []string{"compute VRF vrf-compute"},
+		},
+		{
+			name:        "compute network missing",
+			mutate:      func(f *fakeNDFCObjects) { delete(f.networks, "compute") },
+			want:        NDFCConfigStatus{ComputeFabricExists: true, ComputeVRFExists: true, StorageFabricExists: true, StorageNetworkExists: true},
+			wantMissing: []string{"compute network net-compute"},
+		},
+		{
+			name:        "compute fabric missing skips its VRF and network",
+			mutate:      func(f *fakeNDFCObjects) { f.fabrics = []string{"storage"} },
+			want:        NDFCConfigStatus{StorageFabricExists: true, StorageNetworkExists: true},
+			wantMissing: []string{"compute fabric compute", "compute VRF vrf-compute", "compute network net-compute"},
+		},
+		{
+			name:        "storage fabric missing",
+			mutate:      func(f *fakeNDFCObjects) { f.fabrics = []string{"compute"} },
+			want:        NDFCConfigStatus{ComputeFabricExists: true, ComputeVRFExists: true, ComputeNetworkExists: true, ComputeNetworkVLAN: "2301"},
+			wantMissing: []string{"storage fabric storage", "storage network net-storage"},
+		},
+		{
+			name:        "compute fabric lookups fail",
+			mutate:      func(f *fakeNDFCObjects) { f.failing = map[string]bool{"compute": true} },
+			want:        NDFCConfigStatus{ComputeFabricExists: true, StorageFabricExists: true, StorageNetworkExists: true},
+			wantMissing: []string{"compute VRF vrf-compute", "compute network net-compute"},
+			wantErrors:  []string{"compute_network", "compute_vrf"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := complete()
+			if tt.mutate != nil {
+				tt.mutate(fake)
+			}
+			svc := NewJobService(nil, fake.serve(t), cfg, nil)
+
+			status, err := svc.CheckNDFCConfig(context.Background())
+			if err != nil {
+				t.Fatalf("CheckNDFCConfig: %v", err)
+			}
+
+			var gotErrors []string
+			for check := range status.Errors {
+				gotErrors = append(gotErrors, check)
+			}
+			sort.Strings(gotErrors)
+			if !reflect.DeepEqual(gotErrors, tt.wantErrors) {
+				t.Errorf("errors = %v, want checks %v", status.Errors, tt.wantErrors)
+			}
+
+			status.Errors = nil
+			if !reflect.DeepEqual(*status, tt.want) {
+				t.Errorf("status = %+v, want %+v", *status, tt.want)
+			}
+			if got := status.Missing(svc.NDFCConfigNames()); !reflect.DeepEqual(got, tt.wantMissing) {
+				t.Errorf("missing = %v, want %v", got, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCheckNDFCConfig_NoClient(t *testing.T) {
+	svc := NewJobService(nil, nil, &config.NexusDashboardConfig{}, nil)
+	if _, err := svc.CheckNDFCConfig(context.Background()); err == nil {
+		t.Error("expected error without an NDFC client")
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNetworkOversubscribed is returned when attaching ports would exceed MaxPortsPerNetwork
+var ErrNetworkOversubscribed = errors.New("network port attachment limit exceeded")
+
+// NetworkAttachmentCount returns the number of ports provisioning has attached to a network
+// (0 if none were recorded)
+func NetworkAttachmentCount(ctx context.Context, db *gorm.DB, fabricName, networkName string) (int, error) {
+	var network models.Network
+	err := db.WithContext(ctx).Where("fabric_name = ? AND name = ?", fabricName, networkName).First(&network).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return network.NetworkAttachmentCount, nil
+}
+
+// adjustNetworkAttachmentCount adds delta to a network's attachment count, creating the row on
+// first use. The count never drops below zero.
+func adjustNetworkAttachmentCount(ctx context.Context, db *gorm.DB, fabricName, networkName string, delta int) error {
+	network := models.Network{
+		ID:                     uuid.New().String(),
+		FabricName:             fabricName,
+		Name:                   networkName,
+		NetworkAttachmentCount: max(delta, 0),
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "fabric_name"}, {Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"network_attachment_count": gorm.Expr(
+				"CASE WHEN networks.network_attachment_count + ? < 0 THEN 0 ELSE networks.network_attachment_count + ? END", delta, delta),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(&network).Error
+}
+
+// recordNetworkAttachment adds the ports attached for the job to the network's attachment count
+// and remembers them on the job so deprovisioning can release them. A retried provision only
+// counts the difference from its previous attempt, and a provision that attached nothing
+// releases its reservation. Failures are only logged: NDFC already holds the ports attached.
+func (s *JobService) recordNetworkAttachment(ctx context.Context, job *models.Job, fabricName, networkName string, ports int) {
+	// Settle the count even when the provisioning timeout has expired
+	ctx = context.WithoutCancel(ctx)
+	delta := ports - job.AttachedPortCount
+	if delta == 0 {
+		return
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := adjustNetworkAttachmentCount(ctx, tx, fabricName, networkName, delta); err != nil {
+			return err
+		}
+		job.AttachedPortCount = ports
+		return tx.Model(job).Update("attached_port_count", ports).Error
+	})
+	if err != nil {
+		logger.Warn("Failed to record network attachment count",
+			zap.String("job", job.SlurmJobID),
+			zap.String("network", networkName),
+			zap.Int("ports", ports),
+			zap.Error(err))
+	}
+}
+
+// reserveNetworkCapacity adds ports to the network's attachment count within the allocation
+// transaction tx, returning ErrNetworkOversubscribed if that would exceed MaxPortsPerNetwork.
+// The network row stays locked until tx ends, so concurrent allocations cannot both pass the
+// check. recordNetworkAttachment later settles the reservation against the ports attached.
+func (s *JobService) reserveNetworkCapacity(ctx context.Context, tx *gorm.DB, fabricName, networkName string, ports int) error {
+	if err := adjustNetworkAttachmentCount(ctx, tx, fabricName, networkName, 0); err != nil {
+		return fmt.Errorf("create attachment count for network %s: %w", networkName, err)
+	}
+	var network models.Network
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("fabric_name = ? AND name = ?", fabricName, networkName).First(&network).Error; err != nil {
+		return fmt.Errorf("lock network %s: %w", networkName, err)
+	}
+	if limit := s.cfg.MaxPortsPerNetwork; limit > 0 && network.NetworkAttachmentCount+ports > limit {
+		return fmt.Errorf("%w: network %s has %d of %d ports attached, cannot attach %d more",
+			ErrNetworkOversubscribed, networkName, network.NetworkAttachmentCount, limit, ports)
+	}
+	return adjustNetworkAttachmentCount(ctx, tx, fabricName, networkName, ports)
+}
+
+// AttachedPort is a switch port attached to a network in NDFC
+type AttachedPort struct {
+	Switch        string `json:"switch"`
+	Serial        string `json:"serial"`
+	Port          string `json:"port"`
+	IsLanAttached bool   `json:"is_lan_attached"`
+}
+
+// NetworkPortAttachments lists the ports NDFC has attached to one network
+type NetworkPortAttachments struct {
+	Network       string         `json:"network"`
+	VLAN          int            `json:"vlan"`
+	AttachedPorts []AttachedPort `json:"attached_ports"`
+}
+
+// PortAttachmentReport lists, for each network of the fabric in the networks table, the
+// switch ports NDFC reports attached to it. Each network's NDFC attachments are cached for
+// cache.TTLAttachments; cacheClient may be a NoOpCacheClient. Any NDFC error fails the report.
+func PortAttachmentReport(ctx context.Context, db *gorm.DB, lanFabric *lanfabric.Service, cacheClient cache.CacheClient, fabricName string) ([]NetworkPortAttachments, error) {
+	var networks []models.Network
+	if err := db.WithContext(ctx).Where("fabric_name = ?", fabricName).Order("name").Find(&networks).Error; err != nil {
+		return nil, err
+	}
+
+	report := make([]NetworkPortAttachments, 0, len(networks))
+	for _, network := range networks {
+		attachments, err := networkAttachmentsWithCache(ctx, lanFabric, cacheClient, fabricName, network.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := NetworkPortAttachments{Network: network.Name, AttachedPorts: []AttachedPort{}}
+		for _, a := range attachments {
+			if entry.VLAN == 0 {
+				entry.VLAN = a.VlanID
+			}
+			for _, port := range a.Ports() {
+				entry.AttachedPorts = append(entry.AttachedPorts, AttachedPort{
+					Switch:        a.SwitchName,
+					Serial:        a.SerialNumber,
+					Port:          port,
+					IsLanAttached: a.IsLanAttached,
+				})
+			}
+		}
+		report = append(report, entry)
+	}
+	return report, nil
+}
+
+// networkAttachmentsWithCache returns a network's NDFC attachments, from the cache when present
+func networkAttachmentsWithCache(ctx context.Context, lanFabric *lanfabric.Service, cacheClient cache.CacheClient, fabricName, networkName string) ([]lanfabric.NetworkAttachmentState, error) {
+	key := cache.Attachments(fabricName, networkName)
+	var attachments []lanfabric.NetworkAttachmentState
+	if err := cacheClient.Get(ctx, key, &attachments); err == nil {
+		return attachments, nil
+	}
+
+	attachments, err := lanFabric.GetNetworkAttachments(ctx, fabricName, networkName)
+	if err != nil {
+		return nil, err
+	}
+	if err := cacheClient.Set(ctx, key, attachments, cache.TTLAttachments); err != nil {
+		logger.Warn("Failed to cache network attachments",
+			zap.String("fabric", fabricName), zap.String("network", networkName), zap.Error(err))
+	}
+	return attachments, nil
+}
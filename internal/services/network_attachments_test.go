@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"gorm.io/gorm"
+)
+
+func TestAdjustNetworkAttachmentCount(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Network{})
+	ctx := context.Background()
+
+	for _, delta := range []int{4, 3, -5} {
+		if err := adjustNetworkAttachmentCount(ctx, db, "f1", "net1", delta); err != nil {
+			t.Fatalf("adjust %d: %v", delta, err)
+		}
+	}
+	if got, err := NetworkAttachmentCount(ctx, db, "f1", "net1"); err != nil || got != 2 {
+		t.Errorf("count = %d, %v; want 2", got, err)
+	}
+
+	// The count never goes negative, including for a network seen for the first time
+	if err := adjustNetworkAttachmentCount(ctx, db, "f1", "net1", -10); err != nil {
+		t.Fatal(err)
+	}
+	if err := adjustNetworkAttachmentCount(ctx, db, "f1", "net2", -1); err != nil {
+		t.Fatal(err)
+	}
+	for _, network := range []string{"net1", "net2", "unknown"} {
+		if got, err := NetworkAttachmentCount(ctx, db, "f1", network); err != nil || got != 0 {
+			t.Errorf("%s count = %d, %v; want 0", network, got, err)
+		}
+	}
+}
+
+func TestReserveNetworkCapacity(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Network{})
+	ctx := context.Background()
+	if err := adjustNetworkAttachmentCount(ctx, db, "f1", "net1", 3); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{MaxPortsPerNetwork: 4}, nil)
+	reserve := func(network string, ports int) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			return svc.reserveNetworkCapacity(ctx, tx, "f1", network, ports)
+		})
+	}
+
+	err := reserve("net1", 2)
+	if !errors.Is(err, ErrNetworkOversubscribed) {
+		t.Fatalf("err = %v, want ErrNetworkOversubscribed", err)
+	}
+	if !strings.Contains(err.Error(), "3 of 4") {
+		t.Errorf("err = %q, want current and limit", err)
+	}
+
+	// The last free port is reserved, after which the network is full
+	if err := reserve("net1", 1); err != nil {
+		t.Fatalf("one more port: %v", err)
+	}
+	if got, _ := NetworkAttachmentCount(ctx, db, "f1", "net1"); got != 4 {
+		t.Errorf("count = %d, want 4", got)
+	}
+	if err := reserve("net1", 1); !errors.Is(err, ErrNetworkOversubscribed) {
+		t.Errorf("full network: %v, want ErrNetworkOversubscribed", err)
+	}
+
+	// A network seen for the first time starts empty
+	if err := reserve("net2", 4); err != nil {
+		t.Errorf("new network: %v", err)
+	}
+	svc.cfg.MaxPortsPerNetwork = 0
+	if err := reserve("net1", 100); err != nil {
+		t.Errorf("unlimited: %v", err)
+	}
+}
+
+func TestNetworkAttachment_ReleasedOnDeprovision(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Network{}, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.SecurityGroup{}, &models.PortSelector{})
+	job := &models.Job{ID: "j1", SlurmJobID: "1001", Status: string(models.JobStatusProvisioning), FabricName: "f1", NetworkName: "net1"}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatal(err)
+	}
+	// The job's own network is released, not the currently configured one
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{ComputeNetworkName: "net2"}, nil)
+	ctx := context.Background()
+
+	// A provision that attached nothing releases its reservation, and a retried provision only
+	// counts its ports once
+	if err := adjustNetworkAttachmentCount(ctx, db, "f1", "net1", 3); err != nil {
+		t.Fatal(err)
+	}
+	job.AttachedPortCount = 3
+	svc.recordNetworkAttachment(ctx, job, "f1", "net1", 0)
+	if got, _ := NetworkAttachmentCount(ctx, db, "f1", "net1"); got != 0 {
+		t.Fatalf("count after failed attach = %d, want 0", got)
+	}
+	svc.recordNetworkAttachment(ctx, job, "f1", "net1", 2)
+	svc.recordNetworkAttachment(ctx, job, "f1", "net1", 2)
+	if got, _ := NetworkAttachmentCount(ctx, db, "f1", "net1"); got != 2 {
+		t.Fatalf("count after provision = %d, want 2", got)
+	}
+
+	if err := db.Model(job).Update("status", string(models.JobStatusActive)).Error; err != nil {
+		t.Fatal(err)
+	}
+	job.Status = string(models.JobStatusActive)
+	if err := svc.Deprovision(ctx, job); err != nil {
+		t.Fatalf("Deprovision: %v", err)
+	}
+	if got, _ := NetworkAttachmentCount(ctx, db, "f1", "net1"); got != 0 {
+		t.Errorf("count after deprovision = %d, want 0", got)
+	}
+	var saved models.Job
+	if err := db.First(&saved, "id = ?", "j1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if saved.AttachedPortCount != 0 {
+		t.Errorf("job attached_port_count = %d, want 0", saved.AttachedPortCount)
+	}
+}
+
+func TestPortAttachmentReport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/top-down/fabrics/f1/networks/hpcnet/attachments"):
+			_, _ = w.Write([]byte(`[
+				{"networkName":"hpcnet","switchName":"leaf1","switchSerialNo":"SN1","portNames":"Ethernet1/1,Ethernet1/2","vlanId":2301,"isLanAttached":true},
+				{"networkName":"hpcnet","switchName":"leaf2","switchSerialNo":"SN2","portNames":"","vlanId":2301,"isLanAttached":false}
+			]`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/top-down/fabrics/f1/networks/storage/attachments"):
+			_, _ = w.Write([]byte(`[{"networkName":"storage","switchName":"leaf2","switchSerialNo":"SN2","portNames":"Ethernet1/5","vlanId":2400,"isLanAttached":false}]`))
+		default:
+			t.Errorf("unexpected NDFC request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	db := dbtest.NewSQLiteDB(t, &models.Network{})
+	for _, n := range []models.Network{
+		{ID: "n1", FabricName: "f1", Name: "storage"},
+		{ID: "n2", FabricName: "f1", Name: "hpcnet"},
+		{ID: "n3", FabricName: "f2", Name: "other"},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	cacheClient := cachetest.NewInMemoryCache()
+	ctx := context.Background()
+
+	report, err := PortAttachmentReport(ctx, db, client.LANFabric(), cacheClient, "f1")
+	if err != nil {
+		t.Fatalf("PortAttachmentReport: %v", err)
+	}
+	want := []NetworkPortAttachments{
+		{Network: "hpcnet", VLAN: 2301, AttachedPorts: []AttachedPort{
+			{Switch: "leaf1", Serial: "SN1", Port: "Ethernet1/1", IsLanAttached: true},
+			{Switch: "leaf1", Serial: "SN1", Port: "Ethernet1/2", IsLanAttached: true},
+		}},
+		{Network: "storage", VLAN: 2400, AttachedPorts: []AttachedPort{
+			{Switch: "leaf2", Serial: "SN2", Port: "Ethernet1/5", IsLanAttached: false},
+		}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+
+	// Served from the cache the second time
+	before := requests.Load()
+	if again, err := PortAttachmentReport(ctx, db, client.LANFabric(), cacheClient, "f1"); err != nil || !reflect.DeepEqual(again, want) {
+		t.Errorf("cached report = %+v, %v", again, err)
+	}
+	if n := requests.Load() - before; n != 0 {
+		t.Errorf("%d NDFC requests for the cached report, want 0", n)
+	}
+}
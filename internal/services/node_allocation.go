@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NodeAllocation is the job a compute node is currently allocated to
+type NodeAllocation struct {
+	JobSlurmID  string    `json:"job_slurm_id"`
+	JobStatus   string    `json:"job_status"`
+	AllocatedAt time.Time `json:"allocated_at"`
+}
+
+// nodeAllocationRow is one compute node LEFT JOINed with its allocation and job. The
+// allocation columns are nil for unallocated nodes.
+type nodeAllocationRow struct {
+	AllocationID *string
+	JobSlurmID   *string
+	JobStatus    *string
+	AllocatedAt  *time.Time
+}
+
+// GetNodeAllocation returns the job compute node nodeID is allocated to, or nil if it is not
+// allocated, in a single LEFT JOIN of compute_nodes to compute_node_allocations and jobs.
+// An allocation whose job row is gone is reported with empty job fields.
+func GetNodeAllocation(ctx context.Context, db *gorm.DB, nodeID string) (*NodeAllocation, error) {
+	var row nodeAllocationRow
+	err := db.WithContext(ctx).
+		Table("compute_nodes").
+		Select("compute_node_allocations.id AS allocation_id, jobs.slurm_job_id AS job_slurm_id, "+
+			"jobs.status AS job_status, compute_node_allocations.allocated_at").
+		Joins("LEFT JOIN compute_node_allocations ON compute_node_allocations.compute_node_id = compute_nodes.id").
+		Joins("LEFT JOIN jobs ON jobs.id = compute_node_allocations.job_id AND jobs.deleted_at IS NULL").
+		Where("compute_nodes.id = ?", nodeID).
+		Take(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	if row.AllocationID == nil {
+		return nil, nil
+	}
+
+	alloc := &NodeAllocation{}
+	if row.JobSlurmID != nil {
+		alloc.JobSlurmID = *row.JobSlurmID
+	}
+	if row.JobStatus != nil {
+		alloc.JobStatus = *row.JobStatus
+	}
+	if row.AllocatedAt != nil {
+		alloc.AllocatedAt = *row.AllocatedAt
+	}
+	return alloc, nil
+}
+
+// FilterNodesByAllocation limits a compute node query to nodes that are (allocated=true)
+// or are not (allocated=false) allocated to a job
+func FilterNodesByAllocation(q *gorm.DB, allocated bool) *gorm.DB {
+	exists := "EXISTS (SELECT 1 FROM compute_node_allocations WHERE compute_node_allocations.compute_node_id = compute_nodes.id)"
+	if !allocated {
+		exists = "NOT " + exists
+	}
+	return q.Where(exists)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Compute node auto-decommission
+const (
+	NodeDecommissionInterval = time.Hour          // How often the sync worker checks for nodes not seen
+	nodeNotSeenMetricWindow  = 7 * 24 * time.Hour // Window of the nd_compute_nodes_not_seen_7d_total gauge
+)
+
+// nodesNotSeenSince scopes a query to active compute nodes whose mapped ports no port sync has
+// found since cutoff. Nodes never seen (last_seen_at unset) are left out: their ports may not
+// have been synced since they were added.
+func nodesNotSeenSince(db *gorm.DB, cutoff time.Time) *gorm.DB {
+	return db.Model(&models.ComputeNode{}).
+		Where("status = ? AND last_seen_at < ?", models.ComputeNodeStatusActive, cutoff)
+}
+
+// CountNodesNotSeenSince counts active compute nodes last seen by a port sync before cutoff
+func (s *JobService) CountNodesNotSeenSince(ctx context.Context, cutoff time.Time) (int64, error) {
+	var count int64
+	if err := nodesNotSeenSince(s.db.WithContext(ctx), cutoff).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count nodes not seen: %w", err)
+	}
+	return count, nil
+}
+
+// AutoDecommissionNodes marks active compute nodes decommissioned when no port sync has seen
+// them for ND_AUTO_DECOMMISSION_DAYS before now. Allocated nodes are skipped until their job
+// releases them. With ND_AUTO_DECOMMISSION_DRY_RUN the candidates are only logged. Returns the
+// candidate nodes; nothing is done when auto-decommission is disabled.
+func (s *JobService) AutoDecommissionNodes(ctx context.Context, now time.Time) ([]models.ComputeNode, error) {
+	days := s.cfg.AutoDecommissionDays
+	if days <= 0 {
+		return nil, nil
+	}
+	cutoff := now.AddDate(0, 0, -days)
+
+	var candidates []models.ComputeNode
+	query := FilterNodesByAllocation(nodesNotSeenSince(s.db.WithContext(ctx), cutoff), false)
+	if err := query.Select("id", "name", "last_seen_at").Order("name").Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("find nodes to decommission: %w", err)
+	}
+
+	for _, node := range candidates {
+		if s.cfg.AutoDecommissionDryRun {
+			logger.Info("Compute node would be auto-decommissioned (dry run)",
+				zap.String("compute_node_id", node.ID),
+				zap.String("name", node.Name),
+				zap.Timep("last_seen_at", node.LastSeenAt),
+				zap.Int("threshold_days", days))
+			continue
+		}
+		res := s.db.WithContext(ctx).Model(&models.ComputeNode{}).
+			Where("id = ? AND status = ?", node.ID, models.ComputeNodeStatusActive).
+			Update("status", models.ComputeNodeStatusDecommissioned)
+		if res.Error != nil {
+			return candidates, fmt.Errorf("decommission compute node %s: %w", node.Name, res.Error)
+		}
+		if res.RowsAffected > 0 {
+			logger.Warn("Auto-decommissioned compute node not seen on any port sync",
+				zap.String("compute_node_id", node.ID),
+				zap.String("name", node.Name),
+				zap.Timep("last_seen_at", node.LastSeenAt),
+				zap.Int("threshold_days", days))
+		}
+	}
+	return candidates, nil
+}
+
+// NodeDecommissionTask returns a sync worker task that updates the not-seen gauge and runs
+// AutoDecommissionNodes
+func (s *JobService) NodeDecommissionTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "node-auto-decommission",
+		Interval: NodeDecommissionInterval,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			notSeen, err := s.CountNodesNotSeenSince(ctx, now.Add(-nodeNotSeenMetricWindow))
+			if err != nil {
+				return err
+			}
+			metrics.ComputeNodesNotSeen7d.Set(float64(notSeen))
+
+			_, err = s.AutoDecommissionNodes(ctx, now)
+			return err
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// newDecommissionTestDB stores nodes last seen 40 days ago (stale, and stale-allocated),
+// 10 days ago (recent) and never (new), relative to now
+func newDecommissionTestDB(t *testing.T, now time.Time) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeAllocation{})
+	old := now.AddDate(0, 0, -40)
+	recent := now.AddDate(0, 0, -10)
+	for _, n := range []models.ComputeNode{
+		{ID: "n1", Name: "stale", LastSeenAt: &old},
+		{ID: "n2", Name: "stale-allocated", LastSeenAt: &old},
+		{ID: "n3", Name: "recent", LastSeenAt: &recent},
+		{ID: "n4", Name: "new"},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Create(&models.ComputeNodeAllocation{ID: "a1", ComputeNodeID: "n2", JobID: "job-1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func nodeStatuses(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	var nodes []models.ComputeNode
+	if err := db.Find(&nodes).Error; err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		statuses[n.Name] = n.Status
+	}
+	return statuses
+}
+
+func TestAutoDecommissionNodes_Threshold(t *testing.T) {
+	now := time.Now()
+	db := newDecommissionTestDB(t, now)
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{AutoDecommissionDays: 30}, nil)
+
+	candidates, err := svc.AutoDecommissionNodes(context.Background(), now)
+	if err != nil {
+		t.Fatalf("AutoDecommissionNodes: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Name != "stale" {
+		t.Fatalf("candidates = %+v, want only the unallocated stale node", candidates)
+	}
+	want := map[string]string{
+		"stale":           models.ComputeNodeStatusDecommissioned,
+		"stale-allocated": models.ComputeNodeStatusActive,
+		"recent":          models.ComputeNodeStatusActive,
+		"new":             models.ComputeNodeStatusActive,
+	}
+	if got := nodeStatuses(t, db); len(got) != len(want) || got["stale"] != want["stale"] ||
+		got["stale-allocated"] != want["stale-allocated"] || got["recent"] != want["recent"] || got["new"] != want["new"] {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+
+	// A shorter threshold catches the recent node too; decommissioned nodes are not counted again
+	svc.cfg.AutoDecommissionDays = 7
+	candidates, err = svc.AutoDecommissionNodes(context.Background(), now)
+	if err != nil {
+		t.Fatalf("AutoDecommissionNodes: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Name != "recent" {
+		t.Errorf("candidates = %+v, want only the recent node", candidates)
+	}
+
+	notSeen, err := svc.CountNodesNotSeenSince(context.Background(), now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notSeen != 1 {
+		t.Errorf("not seen for 7 days = %d, want 1 (the allocated node)", notSeen)
+	}
+}
+
+func TestAutoDecommissionNodes_DryRunAndDisabled(t *testing.T) {
+	now := time.Now()
+	db := newDecommissionTestDB(t, now)
+
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{AutoDecommissionDays: 30, AutoDecommissionDryRun: true}, nil)
+	candidates, err := svc.AutoDecommissionNodes(context.Background(), now)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Name != "stale" {
+		t.Errorf("dry-run candidates = %+v, want the stale node", candidates)
+	}
+	if got := nodeStatuses(t, db)["stale"]; got != models.ComputeNodeStatusActive {
+		t.Errorf("dry run changed status to %q", got)
+	}
+
+	svc = NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	if candidates, err := svc.AutoDecommissionNodes(context.Background(), now); err != nil || candidates != nil {
+		t.Errorf("disabled: %v, %v, want nothing", candidates, err)
+	}
+}
+
+func TestProvision_RejectsDecommissionedNodes(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{}, &models.SwitchPort{}, &models.Switch{})
+	for _, n := range []models.ComputeNode{
+		{ID: "n1", Name: "node1"},
+		{ID: "n2", Name: "node2", Status: models.ComputeNodeStatusDecommissioned},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	_, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1", "node2"}})
+	if !errors.Is(err, ErrComputeNodesDecommissioned) || !strings.Contains(err.Error(), "node2") {
+		t.Fatalf("err = %v, want ErrComputeNodesDecommissioned naming node2", err)
+	}
+	var jobs int64
+	db.Model(&models.Job{}).Count(&jobs)
+	if jobs != 0 {
+		t.Errorf("jobs = %d, want none created", jobs)
+	}
+}
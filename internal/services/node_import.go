@@ -0,0 +1,536 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/util"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidImport is returned when an import file cannot be parsed
+	ErrInvalidImport = errors.New("invalid compute node import")
+	// ErrImportInProgress is returned when this instance is already running an import
+	ErrImportInProgress = errors.New("a compute node import is already running")
+	// ErrImportNotFound is returned when no import has the requested ID
+	ErrImportNotFound = errors.New("compute node import not found")
+)
+
+// Supported import file formats
+const (
+	NodeImportFormatCSV  = "csv"
+	NodeImportFormatYAML = "yaml"
+)
+
+// nodeImportProgressInterval is how many rows are processed between progress updates
+const nodeImportProgressInterval = 100
+
+// ImportDeduplicationMode decides what an import does with a row named like an existing node
+type ImportDeduplicationMode string
+
+// Deduplication modes for the deduplication_strategy import parameter
+const (
+	ImportDeduplicationError  ImportDeduplicationMode = "error"  // Fail the row (default)
+	ImportDeduplicationSkip   ImportDeduplicationMode = "skip"   // Keep the existing node and count the row as skipped
+	ImportDeduplicationUpdate ImportDeduplicationMode = "update" // Update the existing node's hostname, IP and MAC address
+)
+
+// ParseImportDeduplicationMode parses a deduplication strategy; empty means error
+func ParseImportDeduplicationMode(s string) (ImportDeduplicationMode, error) {
+	switch mode := ImportDeduplicationMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return ImportDeduplicationError, nil
+	case ImportDeduplicationError, ImportDeduplicationSkip, ImportDeduplicationUpdate:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: deduplication_strategy %q must be error, skip or update", ErrInvalidImport, s)
+	}
+}
+
+// nodeImportOutcome is what happened to an imported row
+type nodeImportOutcome int
+
+const (
+	nodeImportCreated nodeImportOutcome = iota
+	nodeImportSkipped
+	nodeImportUpdated
+)
+
+// NodeImportRow is one compute node in an import file. CSV headers use the same names.
+type NodeImportRow struct {
+	Name        string `json:"name" yaml:"name"`
+	Hostname    string `json:"hostname" yaml:"hostname"`
+	IPAddress   string `json:"ip_address" yaml:"ip_address"`
+	MACAddress  string `json:"mac_address" yaml:"mac_address"`
+	Description string `json:"description" yaml:"description"`
+	BMCAddress  string `json:"bmc_address" yaml:"bmc_address"`
+	BMCUsername string `json:"bmc_username" yaml:"bmc_username"`
+	BMCPort     int    `json:"bmc_port" yaml:"bmc_port"`
+}
+
+// NodeImportError records why a row was not imported. Row is 1-based, excluding any CSV header.
+type NodeImportError struct {
+	Row   int    `json:"row"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// NodeImportProgress is a progress snapshot of a running import
+type NodeImportProgress struct {
+	ImportID      string `json:"import_id"`
+	Status        string `json:"status"`
+	TotalRows     int64  `json:"total_rows"`
+	Processed     int64  `json:"processed"`
+	ImportedCount int64  `json:"imported_count"`
+	SkippedCount  int64  `json:"skipped_count"`
+	UpdatedCount  int64  `json:"updated_count"`
+	ErrorCount    int64  `json:"error_count"`
+}
+
+// Done reports whether the import has finished
+func (p NodeImportProgress) Done() bool {
+	return p.Status == models.NodeImportStatusCompleted || p.Status == models.NodeImportStatusFailed
+}
+
+// NodeImportProgressFromRecord builds a progress snapshot from a stored import
+func NodeImportProgressFromRecord(imp *models.NodeImport) NodeImportProgress {
+	return NodeImportProgress{
+		ImportID:      imp.ID,
+		Status:        imp.Status,
+		TotalRows:     int64(imp.TotalRows),
+		Processed:     int64(imp.ImportedCount + imp.SkippedCount + imp.UpdatedCount + imp.ErrorCount),
+		ImportedCount: int64(imp.ImportedCount),
+		SkippedCount:  int64(imp.SkippedCount),
+		UpdatedCount:  int64(imp.UpdatedCount),
+		ErrorCount:    int64(imp.ErrorCount),
+	}
+}
+
+// ParseNodeImport reads compute node rows from a CSV file with a header row,
+// or a YAML list of nodes
+func ParseNodeImport(format string, r io.Reader) ([]NodeImportRow, error) {
+	switch format {
+	case NodeImportFormatCSV:
+		return parseNodeImportCSV(r)
+	case NodeImportFormatYAML:
+		var rows []NodeImportRow
+		if err := yaml.NewDecoder(r).Decode(&rows); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("%w: format %q must be csv or yaml", ErrInvalidImport, format)
+	}
+}
+
+func parseNodeImportCSV(r io.Reader) ([]NodeImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "name", "hostname", "ip_address", "mac_address", "description", "bmc_address", "bmc_username", "bmc_port":
+			columns[name] = i
+		default:
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImport, name)
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("%w: missing name column", ErrInvalidImport)
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []NodeImportRow
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+
+		row := NodeImportRow{
+			Name:        field(record, "name"),
+			Hostname:    field(record, "hostname"),
+			IPAddress:   field(record, "ip_address"),
+			MACAddress:  field(record, "mac_address"),
+			Description: field(record, "description"),
+			BMCAddress:  field(record, "bmc_address"),
+			BMCUsername: field(record, "bmc_username"),
+		}
+		if port := field(record, "bmc_port"); port != "" {
+			if row.BMCPort, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("%w: row %d: bmc_port %q is not a number", ErrInvalidImport, line, port)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// nodeImportRun holds the live counters of the import in progress
+type nodeImportRun struct {
+	id       string
+	total    int64
+	imported atomic.Int64
+	skipped  atomic.Int64
+	updated  atomic.Int64
+	failed   atomic.Int64
+
+	mu          sync.Mutex
+	status      string
+	subscribers map[chan NodeImportProgress]struct{}
+}
+
+func (r *nodeImportRun) snapshot() NodeImportProgress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.progressLocked()
+}
+
+// progressLocked builds a snapshot; r.mu must be held
+func (r *nodeImportRun) progressLocked() NodeImportProgress {
+	status := r.status
+	imported, skipped, updated, failed := r.imported.Load(), r.skipped.Load(), r.updated.Load(), r.failed.Load()
+	return NodeImportProgress{
+		ImportID:      r.id,
+		Status:        status,
+		TotalRows:     r.total,
+		Processed:     imported + skipped + updated + failed,
+		ImportedCount: imported,
+		SkippedCount:  skipped,
+		UpdatedCount:  updated,
+		ErrorCount:    failed,
+	}
+}
+
+// publish sends a snapshot to every subscriber, dropping it for subscribers
+// that are behind. The final snapshot closes the subscriber channels. Sends and
+// closes happen under r.mu, like Subscribe's initial send and unsubscribe, so a
+// channel is never sent on after it is closed.
+func (r *nodeImportRun) publish(status string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+	p := r.progressLocked()
+	for ch := range r.subscribers {
+		select {
+		case ch <- p:
+		default:
+		}
+		if p.Done() {
+			close(ch)
+			delete(r.subscribers, ch)
+		}
+	}
+}
+
+// NodeImportService creates compute nodes in bulk and tracks import progress.
+// Only one import runs per instance at a time.
+type NodeImportService struct {
+	db             *gorm.DB
+	storageService *StorageService
+
+	active atomic.Bool
+	mu     sync.Mutex
+	run    *nodeImportRun
+}
+
+// NewNodeImportService creates an import service. storageService may be nil,
+// in which case no storage security groups are created for imported nodes.
+func NewNodeImportService(db *gorm.DB, storageService *StorageService) *NodeImportService {
+	return &NodeImportService{db: db, storageService: storageService}
+}
+
+// begin claims the import slot and records a pending import
+func (s *NodeImportService) begin(ctx context.Context, rows []NodeImportRow) (*models.NodeImport, *nodeImportRun, error) {
+	if !s.active.CompareAndSwap(false, true) {
+		return nil, nil, ErrImportInProgress
+	}
+
+	imp := &models.NodeImport{
+		ID:        uuid.New().String(),
+		Status:    models.NodeImportStatusPending,
+		TotalRows: len(rows),
+	}
+	if err := s.db.WithContext(ctx).Create(imp).Error; err != nil {
+		s.active.Store(false)
+		return nil, nil, err
+	}
+
+	run := &nodeImportRun{
+		id:          imp.ID,
+		total:       int64(len(rows)),
+		status:      imp.Status,
+		subscribers: make(map[chan NodeImportProgress]struct{}),
+	}
+	s.mu.Lock()
+	s.run = run
+	s.mu.Unlock()
+	return imp, run, nil
+}
+
+// Import creates the nodes in rows, handling rows named like existing nodes as mode says,
+// and returns the finished import
+func (s *NodeImportService) Import(ctx context.Context, rows []NodeImportRow, mode ImportDeduplicationMode) (*models.NodeImport, error) {
+	imp, run, err := s.begin(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	s.execute(ctx, run, rows, mode)
+	return s.Get(ctx, imp.ID)
+}
+
+// Start records a pending import and creates the nodes in the background.
+// Progress is available from Get and Subscribe.
+func (s *NodeImportService) Start(ctx context.Context, rows []NodeImportRow, mode ImportDeduplicationMode) (*models.NodeImport, error) {
+	imp, run, err := s.begin(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	go s.execute(context.Background(), run, rows, mode)
+	return imp, nil
+}
+
+// Get returns an import by ID
+func (s *NodeImportService) Get(ctx context.Context, id string) (*models.NodeImport, error) {
+	var imp models.NodeImport
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&imp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportNotFound
+		}
+		return nil, err
+	}
+	return &imp, nil
+}
+
+// Subscribe returns a channel of progress snapshots for a running import,
+// starting with the current state. The channel is closed after the final
+// snapshot. ok is false if the import is not running on this instance.
+func (s *NodeImportService) Subscribe(id string) (events <-chan NodeImportProgress, unsubscribe func(), ok bool) {
+	s.mu.Lock()
+	run := s.run
+	s.mu.Unlock()
+	if run == nil || run.id != id {
+		return nil, nil, false
+	}
+
+	ch := make(chan NodeImportProgress, 16)
+	run.mu.Lock()
+	if run.status != models.NodeImportStatusPending && run.status != models.NodeImportStatusRunning {
+		run.mu.Unlock()
+		return nil, nil, false
+	}
+	// The buffer is empty, so this never blocks while holding the lock
+	ch <- run.progressLocked()
+	run.subscribers[ch] = struct{}{}
+	run.mu.Unlock()
+
+	return ch, func() {
+		run.mu.Lock()
+		if _, ok := run.subscribers[ch]; ok {
+			delete(run.subscribers, ch)
+			close(ch)
+		}
+		run.mu.Unlock()
+	}, true
+}
+
+// execute imports rows one by one, saving and publishing progress every
+// nodeImportProgressInterval rows. The import fails only if every row failed.
+func (s *NodeImportService) execute(ctx context.Context, run *nodeImportRun, rows []NodeImportRow, mode ImportDeduplicationMode) {
+	defer s.active.Store(false)
+
+	started := time.Now()
+	s.saveProgress(ctx, run, map[string]interface{}{
+		"status":     models.NodeImportStatusRunning,
+		"started_at": started,
+	})
+	run.publish(models.NodeImportStatusRunning)
+
+	var rowErrors []NodeImportError
+	for i, row := range rows {
+		outcome, err := s.importRow(ctx, row, mode)
+		switch {
+		case err != nil:
+			run.failed.Add(1)
+			rowErrors = append(rowErrors, NodeImportError{Row: i + 1, Name: row.Name, Error: err.Error()})
+		case outcome == nodeImportSkipped:
+			run.skipped.Add(1)
+		case outcome == nodeImportUpdated:
+			run.updated.Add(1)
+		default:
+			run.imported.Add(1)
+		}
+
+		if (i+1)%nodeImportProgressInterval == 0 && i+1 < len(rows) {
+			s.saveProgress(ctx, run, map[string]interface{}{})
+			run.publish(models.NodeImportStatusRunning)
+		}
+	}
+
+	status := models.NodeImportStatusCompleted
+	if run.failed.Load() > 0 && run.failed.Load() == int64(len(rows)) {
+		status = models.NodeImportStatusFailed
+	}
+	updates := map[string]interface{}{
+		"status":       status,
+		"completed_at": time.Now(),
+	}
+	if len(rowErrors) > 0 {
+		if encoded, err := json.Marshal(rowErrors); err == nil {
+			updates["errors"] = json.RawMessage(encoded)
+		}
+	}
+	s.saveProgress(ctx, run, updates)
+	run.publish(status)
+
+	logger.Info("Compute node import finished",
+		zap.String("import_id", run.id),
+		zap.String("status", status),
+		zap.String("deduplication", string(mode)),
+		zap.Int64("imported", run.imported.Load()),
+		zap.Int64("skipped", run.skipped.Load()),
+		zap.Int64("updated", run.updated.Load()),
+		zap.Int64("errors", run.failed.Load()),
+		zap.Duration("duration", time.Since(started)))
+}
+
+// saveProgress writes the live counters plus any extra columns to the import record
+func (s *NodeImportService) saveProgress(ctx context.Context, run *nodeImportRun, updates map[string]interface{}) {
+	updates["imported_count"] = run.imported.Load()
+	updates["skipped_count"] = run.skipped.Load()
+	updates["updated_count"] = run.updated.Load()
+	updates["error_count"] = run.failed.Load()
+	if err := s.db.WithContext(ctx).Model(&models.NodeImport{}).Where("id = ?", run.id).Updates(updates).Error; err != nil {
+		logger.Warn("Failed to save compute node import progress", zap.String("import_id", run.id), zap.Error(err))
+	}
+}
+
+// importRow validates and creates one compute node. A node with the same name is an error,
+// left alone, or updated with the row's hostname, IP and MAC address, depending on mode.
+func (s *NodeImportService) importRow(ctx context.Context, row NodeImportRow, mode ImportDeduplicationMode) (nodeImportOutcome, error) {
+	if strings.TrimSpace(row.Name) == "" {
+		return 0, errors.New("name is required")
+	}
+	if row.BMCPort < 0 || row.BMCPort > 65535 {
+		return 0, fmt.Errorf("bmc_port %d must be between 1 and 65535", row.BMCPort)
+	}
+	if err := util.ValidateHostname(row.Hostname); err != nil {
+		return 0, err
+	}
+	if err := util.ValidateIPAddress(row.IPAddress); err != nil {
+		return 0, err
+	}
+	if err := util.ValidateMACAddress(row.MACAddress); err != nil {
+		return 0, err
+	}
+	if err := util.ValidateBMCAddress(row.BMCAddress); err != nil {
+		return 0, err
+	}
+
+	node := models.ComputeNode{
+		ID:          uuid.New().String(),
+		Name:        row.Name,
+		Hostname:    row.Hostname,
+		IPAddress:   row.IPAddress,
+		MACAddress:  row.MACAddress,
+		Description: row.Description,
+		BMCAddress:  row.BMCAddress,
+		BMCUsername: row.BMCUsername,
+		BMCPort:     row.BMCPort,
+	}
+	if node.BMCPort == 0 {
+		node.BMCPort = DefaultBMCPort
+	}
+
+	db := s.db.WithContext(ctx)
+	var existing int64
+	if err := db.Model(&models.ComputeNode{}).Where("name = ?", node.Name).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+
+	// Names are unique among nodes that are not deleted (idx_compute_nodes_active_name)
+	conflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "name"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+	}
+	switch mode {
+	case ImportDeduplicationSkip:
+		conflict.DoNothing = true
+		result := db.Clauses(conflict).Create(&node)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nodeImportSkipped, nil
+		}
+	case ImportDeduplicationUpdate:
+		// Columns the row leaves empty keep their stored value
+		columns := []string{"updated_at"}
+		for _, c := range []struct{ name, value string }{
+			{"hostname", node.Hostname}, {"ip_address", node.IPAddress}, {"mac_address", node.MACAddress},
+		} {
+			if c.value != "" {
+				columns = append(columns, c.name)
+			}
+		}
+		conflict.DoUpdates = clause.AssignmentColumns(columns)
+		if err := db.Clauses(conflict).Create(&node).Error; err != nil {
+			return 0, err
+		}
+		if existing > 0 {
+			return nodeImportUpdated, nil
+		}
+	default:
+		if existing > 0 {
+			return 0, fmt.Errorf("compute node %q already exists", node.Name)
+		}
+		if err := db.Create(&node).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	// Best-effort, as for nodes created individually
+	if s.storageService != nil {
+		if _, err := s.storageService.EnsureNodeStorageSG(ctx, &node, nil, ""); err != nil {
+			logger.Warn("Failed to create storage SG for imported compute node",
+				zap.String("node", node.Name),
+				zap.Error(err))
+		}
+	}
+	return nodeImportCreated, nil
+}
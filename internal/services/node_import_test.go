@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestParseNodeImport_CSV(t *testing.T) {
+	input := "name,ip_address,bmc_port\nnode-1,10.0.0.1,\nnode-2, 10.0.0.2 ,6230\n"
+	rows, err := ParseNodeImport(NodeImportFormatCSV, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseNodeImport: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	if rows[1].Name != "node-2" || rows[1].IPAddress != "10.0.0.2" || rows[1].BMCPort != 6230 {
+		t.Errorf("row 2 = %+v", rows[1])
+	}
+
+	if _, err := ParseNodeImport(NodeImportFormatCSV, strings.NewReader("hostname,rack\nh1,r1\n")); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("unknown column err = %v, want ErrInvalidImport", err)
+	}
+}
+
+func TestParseNodeImport_YAML(t *testing.T) {
+	input := "- name: node-1\n  hostname: n1.example.com\n- name: node-2\n  bmc_address: 10.1.0.2\n"
+	rows, err := ParseNodeImport(NodeImportFormatYAML, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseNodeImport: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Hostname != "n1.example.com" || rows[1].BMCAddress != "10.1.0.2" {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+// waitForImport polls until the import finishes
+func waitForImport(t *testing.T, svc *NodeImportService, id string) *models.NodeImport {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		imp, err := svc.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if imp.Status == models.NodeImportStatusCompleted || imp.Status == models.NodeImportStatusFailed {
+			return imp
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("import %s did not finish", id)
+	return nil
+}
+
+func TestNodeImportService_AsyncImportCompletes(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.NodeImport{})
+	if err := db.Create(&models.ComputeNode{ID: "existing", Name: "node-3"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := NewNodeImportService(db, nil)
+
+	// 250 rows spans several progress intervals; node-3 already exists and one row has no name
+	var rows []NodeImportRow
+	for i := 1; i <= 249; i++ {
+		rows = append(rows, NodeImportRow{Name: fmt.Sprintf("node-%d", i)})
+	}
+	rows = append(rows, NodeImportRow{Hostname: "unnamed"})
+
+	imp, err := svc.Start(context.Background(), rows, ImportDeduplicationError)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if imp.Status != models.NodeImportStatusPending || imp.TotalRows != 250 {
+		t.Errorf("started import = %+v", imp)
+	}
+
+	done := waitForImport(t, svc, imp.ID)
+	if done.Status != models.NodeImportStatusCompleted {
+		t.Errorf("status = %s, want completed", done.Status)
+	}
+	if done.ImportedCount != 248 || done.ErrorCount != 2 {
+		t.Errorf("imported = %d, errors = %d; want 248, 2", done.ImportedCount, done.ErrorCount)
+	}
+	if done.StartedAt == nil || done.CompletedAt == nil {
+		t.Error("expected started_at and completed_at to be set")
+	}
+
+	var rowErrors []NodeImportError
+	if err := json.Unmarshal(done.Errors, &rowErrors); err != nil {
+		t.Fatalf("decode errors: %v", err)
+	}
+	if len(rowErrors) != 2 || rowErrors[0].Row != 3 || rowErrors[1].Row != 250 {
+		t.Errorf("row errors = %+v", rowErrors)
+	}
+
+	var count int64
+	db.Model(&models.ComputeNode{}).Count(&count)
+	if count != 249 {
+		t.Errorf("compute nodes = %d, want 249", count)
+	}
+}
+
+func TestNodeImportService_OneActiveImport(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.NodeImport{})
+	svc := NewNodeImportService(db, nil)
+
+	svc.active.Store(true)
+	if _, err := svc.Start(context.Background(), []NodeImportRow{{Name: "n1"}}, ImportDeduplicationError); !errors.Is(err, ErrImportInProgress) {
+		t.Errorf("Start err = %v, want ErrImportInProgress", err)
+	}
+
+	svc.active.Store(false)
+	imp, err := svc.Import(context.Background(), []NodeImportRow{{Name: "n1"}}, ImportDeduplicationError)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if imp.Status != models.NodeImportStatusCompleted || imp.ImportedCount != 1 {
+		t.Errorf("import = %+v", imp)
+	}
+	if svc.active.Load() {
+		t.Error("import slot not released after Import")
+	}
+}
+
+func TestNodeImportService_AllRowsFail(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.NodeImport{})
+	svc := NewNodeImportService(db, nil)
+
+	imp, err := svc.Import(context.Background(), []NodeImportRow{{Name: ""}, {Name: "n1", BMCPort: 70000}}, ImportDeduplicationError)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if imp.Status != models.NodeImportStatusFailed || imp.ErrorCount != 2 {
+		t.Errorf("import = %+v, want failed with 2 errors", imp)
+	}
+}
+
+func TestNodeImportService_Deduplication(t *testing.T) {
+	// node-1 and node-2 exist; the file adds node-3 and node-4. node-2's row has no MAC address.
+	input := "name,hostname,ip_address,mac_address\n" +
+		"node-1,node-1.new,10.0.0.101,aa:aa:aa:aa:aa:01\n" +
+		"node-2,node-2.new,10.0.0.102,\n" +
+		"node-3,node-3,10.0.0.3,aa:aa:aa:aa:aa:03\n" +
+		"node-4,node-4,10.0.0.4,aa:aa:aa:aa:aa:04\n"
+
+	tests := []struct {
+		mode                              ImportDeduplicationMode
+		imported, skipped, updated, fails int
+		node1IP, node2Hostname, node2MAC  string
+	}{
+		{ImportDeduplicationError, 2, 0, 0, 2, "10.0.0.1", "node-2", "bb:bb:bb:bb:bb:02"},
+		{ImportDeduplicationSkip, 2, 2, 0, 0, "10.0.0.1", "node-2", "bb:bb:bb:bb:bb:02"},
+		{ImportDeduplicationUpdate, 2, 0, 2, 0, "10.0.0.101", "node-2.new", "bb:bb:bb:bb:bb:02"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.NodeImport{})
+			for i := 1; i <= 2; i++ {
+				node := models.ComputeNode{ID: fmt.Sprintf("existing-%d", i), Name: fmt.Sprintf("node-%d", i), Hostname: fmt.Sprintf("node-%d", i),
+					IPAddress: fmt.Sprintf("10.0.0.%d", i), MACAddress: fmt.Sprintf("bb:bb:bb:bb:bb:%02d", i)}
+				if err := db.Create(&node).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+			rows, err := ParseNodeImport(NodeImportFormatCSV, strings.NewReader(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			imp, err := NewNodeImportService(db, nil).Import(context.Background(), rows, tt.mode)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if imp.Status != models.NodeImportStatusCompleted || imp.ImportedCount != tt.imported || imp.SkippedCount != tt.skipped ||
+				imp.UpdatedCount != tt.updated || imp.ErrorCount != tt.fails {
+				t.Errorf("import = %s, imported %d, skipped %d, updated %d, errors %d; want completed, %d, %d, %d, %d",
+					imp.Status, imp.ImportedCount, imp.SkippedCount, imp.UpdatedCount, imp.ErrorCount, tt.imported, tt.skipped, tt.updated, tt.fails)
+			}
+
+			var nodes []models.ComputeNode
+			if err := db.Order("name").Find(&nodes).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(nodes) != 4 {
+				t.Fatalf("compute nodes = %d, want 4", len(nodes))
+			}
+			if nodes[0].ID != "existing-1" || nodes[0].IPAddress != tt.node1IP {
+				t.Errorf("node-1 = %s %s, want existing node with IP %s", nodes[0].ID, nodes[0].IPAddress, tt.node1IP)
+			}
+			if nodes[1].Hostname != tt.node2Hostname || nodes[1].MACAddress != tt.node2MAC {
+				t.Errorf("node-2 = %s %s, want %s %s", nodes[1].Hostname, nodes[1].MACAddress, tt.node2Hostname, tt.node2MAC)
+			}
+		})
+	}
+}
+
+func TestParseImportDeduplicationMode(t *testing.T) {
+	if mode, err := ParseImportDeduplicationMode(""); err != nil || mode != ImportDeduplicationError {
+		t.Errorf("empty = %q, %v; want error mode", mode, err)
+	}
+	if mode, err := ParseImportDeduplicationMode("Skip"); err != nil || mode != ImportDeduplicationSkip {
+		t.Errorf("Skip = %q, %v", mode, err)
+	}
+	if _, err := ParseImportDeduplicationMode("merge"); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("merge err = %v, want ErrInvalidImport", err)
+	}
+}
+
+func TestNodeImportService_SubscribeRacesFinalPublish(t *testing.T) {
+	// Before sends and closes shared the run lock, a subscriber registered just before the
+	// final publish could be sent its first snapshot after its channel was closed
+	for i := 0; i < 200; i++ {
+		run := &nodeImportRun{
+			id:          "imp",
+			status:      models.NodeImportStatusRunning,
+			subscribers: make(map[chan NodeImportProgress]struct{}),
+		}
+		svc := &NodeImportService{run: run}
+
+		published := make(chan struct{})
+		go func() {
+			defer close(published)
+			run.publish(models.NodeImportStatusCompleted)
+		}()
+		if events, unsubscribe, ok := svc.Subscribe("imp"); ok {
+			var last NodeImportProgress
+			for p := range events {
+				last = p
+			}
+			if last.Status != models.NodeImportStatusCompleted {
+				t.Fatalf("last snapshot = %+v, want completed", last)
+			}
+			unsubscribe()
+		}
+		<-published
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Node label errors
+var (
+	ErrInvalidLabel          = errors.New("invalid label")
+	ErrLabelNotFound         = errors.New("label not found")
+	ErrLabelExists           = errors.New("label already exists")
+	ErrMissingRequiredLabels = errors.New("compute nodes missing required labels")
+)
+
+// labelKeyPattern allows keys like "gpu", "infiniband", "topology.rack"
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]{0,61}[A-Za-z0-9])?$`)
+
+// LabelQueryPrefix marks list query parameters that filter by label (?label.gpu=a100)
+const LabelQueryPrefix = "label."
+
+// ValidateLabel checks a label key and value
+func ValidateLabel(key, value string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: key %q must be 1-63 alphanumerics, '.', '_' or '-'", ErrInvalidLabel, key)
+	}
+	if len(value) > 255 {
+		return fmt.Errorf("%w: value for %q exceeds 255 characters", ErrInvalidLabel, key)
+	}
+	return nil
+}
+
+// ListNodeLabels returns a compute node's labels ordered by key
+func ListNodeLabels(ctx context.Context, db *gorm.DB, nodeID string) ([]models.ComputeNodeLabel, error) {
+	var labels []models.ComputeNodeLabel
+	err := db.WithContext(ctx).Where("compute_node_id = ?", nodeID).Order("key").Find(&labels).Error
+	return labels, err
+}
+
+// CreateNodeLabel adds a label, failing with ErrLabelExists if the key is already set
+func CreateNodeLabel(ctx context.Context, db *gorm.DB, nodeID, key, value string) (*models.ComputeNodeLabel, error) {
+	if err := ValidateLabel(key, value); err != nil {
+		return nil, err
+	}
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.ComputeNodeLabel{}).
+		Where("compute_node_id = ? AND key = ?", nodeID, key).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLabelExists, key)
+	}
+
+	label := models.ComputeNodeLabel{ID: uuid.New().String(), ComputeNodeID: nodeID, Key: key, Value: value}
+	if err := db.WithContext(ctx).Create(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// SetNodeLabel creates or replaces the value of a label
+func SetNodeLabel(ctx context.Context, db *gorm.DB, nodeID, key, value string) (*models.ComputeNodeLabel, error) {
+	if err := ValidateLabel(key, value); err != nil {
+		return nil, err
+	}
+	label := models.ComputeNodeLabel{ID: uuid.New().String(), ComputeNodeID: nodeID, Key: key, Value: value}
+	if err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "compute_node_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&label).Error; err != nil {
+		return nil, err
+	}
+
+	// Reload to return the persisted ID when an existing label was updated
+	var saved models.ComputeNodeLabel
+	if err := db.WithContext(ctx).Where("compute_node_id = ? AND key = ?", nodeID, key).First(&saved).Error; err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// DeleteNodeLabel removes a label from a compute node
+func DeleteNodeLabel(ctx context.Context, db *gorm.DB, nodeID, key string) error {
+	result := db.WithContext(ctx).Where("compute_node_id = ? AND key = ?", nodeID, key).Delete(&models.ComputeNodeLabel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
+
+// LabelSelectorFromQuery extracts label.<key>=<value> parameters from a query string
+func LabelSelectorFromQuery(query map[string][]string) map[string]string {
+	selector := make(map[string]string)
+	for param, values := range query {
+		if key, ok := strings.CutPrefix(param, LabelQueryPrefix); ok && key != "" && len(values) > 0 {
+			selector[key] = values[0]
+		}
+	}
+	return selector
+}
+
+// FilterNodesByLabels restricts a compute_nodes query to nodes carrying every
+// key=value in selector. Each label adds its own JOIN to compute_node_labels.
+func FilterNodesByLabels(query *gorm.DB, selector map[string]string) *gorm.DB {
+	keys := make([]string, 0, len(selector))
+	for k := range selector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // Stable SQL for the same selector
+
+	for i, key := range keys {
+		alias := fmt.Sprintf("lbl%d", i)
+		query = query.Joins(
+			fmt.Sprintf("JOIN compute_node_labels %[1]s ON %[1]s.compute_node_id = compute_nodes.id AND %[1]s.key = ? AND %[1]s.value = ?", alias),
+			key, selector[key])
+	}
+	return query
+}
+
+// MissingRequiredLabels returns, per node name, the required labels each node
+// lacks (absent or with a different value). Nodes satisfying all labels are omitted.
+func MissingRequiredLabels(ctx context.Context, db *gorm.DB, nodes []models.ComputeNode, required map[string]string) (map[string][]string, error) {
+	if len(required) == 0 || len(nodes) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	keys := make([]string, 0, len(required))
+	for k := range required {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var labels []models.ComputeNodeLabel
+	if err := db.WithContext(ctx).Where("compute_node_id IN ? AND key IN ?", ids, keys).Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	have := make(map[string]map[string]string, len(nodes))
+	for _, l := range labels {
+		if have[l.ComputeNodeID] == nil {
+			have[l.ComputeNodeID] = make(map[string]string)
+		}
+		have[l.ComputeNodeID][l.Key] = l.Value
+	}
+
+	missing := make(map[string][]string)
+	for _, n := range nodes {
+		for _, k := range keys {
+			if v, ok := have[n.ID][k]; !ok || v != required[k] {
+				missing[n.Name] = append(missing[n.Name], k+"="+required[k])
+			}
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// seedLabeledNodes creates n1 (gpu=a100, infiniband=hdr), n2 (gpu=a100) and n3 (no labels)
+func seedLabeledNodes(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.ComputeNode{}, &models.ComputeNodeLabel{}, &models.Job{}, &models.ComputeNodeAllocation{},
+		&models.ComputeNodeInterface{}, &models.ComputeNodePortMapping{}, &models.SwitchPort{}, &models.Switch{})
+	ctx := context.Background()
+	for _, n := range []models.ComputeNode{{ID: "n1", Name: "node1"}, {ID: "n2", Name: "node2"}, {ID: "n3", Name: "node3"}} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, l := range []struct{ node, key, value string }{
+		{"n1", "gpu", "a100"}, {"n1", "infiniband", "hdr"}, {"n2", "gpu", "a100"},
+	} {
+		if _, err := CreateNodeLabel(ctx, db, l.node, l.key, l.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func nodeNames(t *testing.T, db *gorm.DB, selector map[string]string) []string {
+	t.Helper()
+	var nodes []models.ComputeNode
+	if err := FilterNodesByLabels(db, selector).Order("compute_nodes.name").Find(&nodes).Error; err != nil {
+		t.Fatalf("filter %v: %v", selector, err)
+	}
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n.Name
+	}
+	return names
+}
+
+func TestFilterNodesByLabels(t *testing.T) {
+	db := seedLabeledNodes(t)
+
+	tests := []struct {
+		selector map[string]string
+		want     []string
+	}{
+		{nil, []string{"node1", "node2", "node3"}},
+		{map[string]string{"gpu": "a100"}, []string{"node1", "node2"}},
+		{map[string]string{"gpu": "a100", "infiniband": "hdr"}, []string{"node1"}},
+		{map[string]string{"gpu": "h100"}, []string{}},
+	}
+	for _, tt := range tests {
+		got := nodeNames(t, db, tt.selector)
+		if len(got) != len(tt.want) {
+			t.Errorf("selector %v: got %v, want %v", tt.selector, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("selector %v: got %v, want %v", tt.selector, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestLabelSelectorFromQuery(t *testing.T) {
+	got := LabelSelectorFromQuery(map[string][]string{
+		"label.gpu":        {"a100"},
+		"label.infiniband": {"hdr"},
+		"label.":           {"ignored"},
+		"page":             {"2"},
+	})
+	if len(got) != 2 || got["gpu"] != "a100" || got["infiniband"] != "hdr" {
+		t.Errorf("selector = %v", got)
+	}
+}
+
+func TestNodeLabelCRUD(t *testing.T) {
+	db := seedLabeledNodes(t)
+	ctx := context.Background()
+
+	if _, err := CreateNodeLabel(ctx, db, "n1", "gpu", "h100"); !errors.Is(err, ErrLabelExists) {
+		t.Errorf("duplicate create err = %v, want ErrLabelExists", err)
+	}
+	if _, err := CreateNodeLabel(ctx, db, "n1", "bad key", "x"); !errors.Is(err, ErrInvalidLabel) {
+		t.Errorf("invalid key err = %v, want ErrInvalidLabel", err)
+	}
+
+	label, err := SetNodeLabel(ctx, db, "n1", "gpu", "h100")
+	if err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if label.Value != "h100" {
+		t.Errorf("value = %q, want h100", label.Value)
+	}
+	labels, err := ListNodeLabels(ctx, db, "n1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 || labels[0].Key != "gpu" || labels[0].Value != "h100" {
+		t.Errorf("labels = %+v, want gpu=h100 replaced in place", labels)
+	}
+
+	if err := DeleteNodeLabel(ctx, db, "n1", "gpu"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := DeleteNodeLabel(ctx, db, "n1", "gpu"); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("second delete err = %v, want ErrLabelNotFound", err)
+	}
+}
+
+func TestProvision_RejectsNodesMissingRequiredLabels(t *testing.T) {
+	db := seedLabeledNodes(t)
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+
+	_, err := svc.Provision(context.Background(), ProvisionInput{
+		SlurmJobID:     "1001",
+		ComputeNodes:   []string{"node1", "node2"},
+		RequiredLabels: map[string]string{"gpu": "a100", "infiniband": "hdr"},
+	})
+	if !errors.Is(err, ErrMissingRequiredLabels) {
+		t.Fatalf("err = %v, want ErrMissingRequiredLabels", err)
+	}
+
+	var jobs int64
+	db.Model(&models.Job{}).Count(&jobs)
+	if jobs != 0 {
+		t.Errorf("jobs = %d, want none created", jobs)
+	}
+}
+
+func TestMissingRequiredLabels(t *testing.T) {
+	db := seedLabeledNodes(t)
+	nodes := []models.ComputeNode{{ID: "n1", Name: "node1"}, {ID: "n2", Name: "node2"}, {ID: "n3", Name: "node3"}}
+
+	missing, err := MissingRequiredLabels(context.Background(), db, nodes, map[string]string{"gpu": "a100", "infiniband": "hdr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := missing["node1"]; ok {
+		t.Errorf("node1 has all labels, got missing %v", missing["node1"])
+	}
+	if got := missing["node2"]; len(got) != 1 || got[0] != "infiniband=hdr" {
+		t.Errorf("node2 missing = %v, want [infiniband=hdr]", got)
+	}
+	if got := missing["node3"]; len(got) != 2 {
+		t.Errorf("node3 missing = %v, want both labels", got)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	backgroundsync "github.com/banglin/go-nd/internal/sync"
+	"go.uber.org/zap"
+)
+
+// OrphanedAllocationRecoveryInterval is how often the sync worker runs RecoverOrphanedAllocations
+const OrphanedAllocationRecoveryInterval = 10 * time.Minute
+
+// orphanedAllocation is an allocation whose job is missing or already finished
+type orphanedAllocation struct {
+	ID            string
+	ComputeNodeID string
+	JobID         string
+	JobStatus     *string // Nil when the job row is missing
+}
+
+// RecoverOrphanedAllocations releases compute node allocations that would otherwise block
+// their node forever: allocations whose job row is missing (or soft-deleted), and allocations
+// of completed or failed jobs that deprovisioning should already have released. Allocations of
+// cleanup_failed jobs are kept until cleanup is retried. Returns the released compute node IDs.
+func (s *JobService) RecoverOrphanedAllocations(ctx context.Context) ([]string, error) {
+	var orphans []orphanedAllocation
+	err := s.db.WithContext(ctx).
+		Table("compute_node_allocations AS cna").
+		Select("cna.id, cna.compute_node_id, cna.job_id, j.status AS job_status").
+		Joins("LEFT JOIN jobs j ON j.id = cna.job_id").
+		Where("j.id IS NULL OR j.deleted_at IS NOT NULL OR j.status IN ?",
+			[]string{string(models.JobStatusCompleted), string(models.JobStatusFailed)}).
+		Scan(&orphans).Error
+	if err != nil {
+		return nil, fmt.Errorf("find orphaned allocations: %w", err)
+	}
+
+	recovered := make([]string, 0, len(orphans))
+	for _, o := range orphans {
+		res := s.db.WithContext(ctx).Delete(&models.ComputeNodeAllocation{}, "id = ?", o.ID)
+		if res.Error != nil {
+			return recovered, fmt.Errorf("release allocation for compute node %s: %w", o.ComputeNodeID, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			continue // Released concurrently
+		}
+
+		reason := "job missing"
+		if o.JobStatus != nil {
+			reason = "job " + *o.JobStatus
+		}
+		logger.Warn("Released orphaned compute node allocation",
+			zap.String("compute_node_id", o.ComputeNodeID),
+			zap.String("job_id", o.JobID),
+			zap.String("reason", reason),
+		)
+		metrics.OrphanedAllocationsRecoveredTotal.Inc()
+		recovered = append(recovered, o.ComputeNodeID)
+	}
+	return recovered, nil
+}
+
+// OrphanedAllocationTask returns RecoverOrphanedAllocations as a sync worker task
+func (s *JobService) OrphanedAllocationTask() backgroundsync.PeriodicTask {
+	return backgroundsync.PeriodicTask{
+		Name:     "orphaned-allocations",
+		Interval: OrphanedAllocationRecoveryInterval,
+		Run: func(ctx context.Context) error {
+			_, err := s.RecoverOrphanedAllocations(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestRecoverOrphanedAllocations(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.Job{}, &models.ComputeNodeAllocation{})
+	for _, j := range []models.Job{
+		{ID: "active", SlurmJobID: "1", Status: string(models.JobStatusActive)},
+		{ID: "completed", SlurmJobID: "2", Status: string(models.JobStatusCompleted)},
+		{ID: "failed", SlurmJobID: "3", Status: string(models.JobStatusFailed)},
+		{ID: "cleanup", SlurmJobID: "4", Status: string(models.JobStatusCleanupFailed)},
+		{ID: "deleted", SlurmJobID: "5", Status: string(models.JobStatusActive)},
+	} {
+		if err := db.Create(&j).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range []models.ComputeNodeAllocation{
+		{ID: "a1", ComputeNodeID: "node-active", JobID: "active"},
+		{ID: "a2", ComputeNodeID: "node-completed", JobID: "completed"},
+		{ID: "a3", ComputeNodeID: "node-failed", JobID: "failed"},
+		{ID: "a4", ComputeNodeID: "node-cleanup", JobID: "cleanup"},
+		{ID: "a5", ComputeNodeID: "node-missing", JobID: "deleted"},
+	} {
+		if err := db.Create(&a).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Simulate a job row removed manually from the database
+	if err := db.Unscoped().Delete(&models.Job{}, "id = ?", "deleted").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewJobService(db, nil, &config.NexusDashboardConfig{}, nil)
+	recovered, err := svc.RecoverOrphanedAllocations(context.Background())
+	if err != nil {
+		t.Fatalf("RecoverOrphanedAllocations: %v", err)
+	}
+	sort.Strings(recovered)
+	want := []string{"node-completed", "node-failed", "node-missing"}
+	if len(recovered) != len(want) {
+		t.Fatalf("recovered = %v, want %v", recovered, want)
+	}
+	for i := range want {
+		if recovered[i] != want[i] {
+			t.Errorf("recovered = %v, want %v", recovered, want)
+			break
+		}
+	}
+
+	var remaining []models.ComputeNodeAllocation
+	if err := db.Order("compute_node_id").Find(&remaining).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 || remaining[0].ComputeNodeID != "node-active" || remaining[1].ComputeNodeID != "node-cleanup" {
+		t.Errorf("remaining allocations = %+v, want node-active and node-cleanup", remaining)
+	}
+
+	// A second run finds nothing
+	if again, err := svc.RecoverOrphanedAllocations(context.Background()); err != nil || len(again) != 0 {
+		t.Errorf("second run = %v, %v; want none", again, err)
+	}
+}
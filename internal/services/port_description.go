@@ -0,0 +1,43 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/banglin/go-nd/internal/util"
+)
+
+// DefaultPortDescriptionTemplate is used when ND_PORT_DESCRIPTION_TEMPLATE is unset
+const DefaultPortDescriptionTemplate = "HPC Job {{.SlurmJobID}}"
+
+// ParsePortDescriptionTemplate parses a port description template and dry-runs it
+// against an empty ProvisionInput so unknown fields fail at startup, not mid-provision.
+func ParsePortDescriptionTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultPortDescriptionTemplate
+	}
+	tmpl, err := template.New("port-description").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port description template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, ProvisionInput{}); err != nil {
+		return nil, fmt.Errorf("invalid port description template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// portDescription returns the NDFC interface description for a job's access ports.
+// An explicit input.Description takes precedence over the configured template.
+func (s *JobService) portDescription(input ProvisionInput) string {
+	if input.Description != "" {
+		return util.TruncateDescription(fmt.Sprintf("HPC:%s/%s", input.SlurmJobID, input.Description), util.NDFCDescriptionMaxLen)
+	}
+
+	var b strings.Builder
+	if s.portDescTmpl == nil || s.portDescTmpl.Execute(&b, input) != nil {
+		return util.TruncateDescription("HPC Job "+input.SlurmJobID, util.NDFCDescriptionMaxLen)
+	}
+	return util.TruncateDescription(b.String(), util.NDFCDescriptionMaxLen)
+}
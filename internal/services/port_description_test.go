@@ -0,0 +1,56 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/util"
+)
+
+func TestPortDescription_DefaultTemplate(t *testing.T) {
+	s := NewJobService(nil, nil, &config.NexusDashboardConfig{}, nil)
+	if got := s.portDescription(ProvisionInput{SlurmJobID: "4242"}); got != "HPC Job 4242" {
+		t.Errorf("description = %q, want %q", got, "HPC Job 4242")
+	}
+}
+
+func TestPortDescription_SiteTemplate(t *testing.T) {
+	s := NewJobService(nil, nil, &config.NexusDashboardConfig{
+		PortDescriptionTemplate: "{{.Tenant}}:{{.SlurmJobID}} {{.Name}}",
+	}, nil)
+	got := s.portDescription(ProvisionInput{SlurmJobID: "4242", Name: "cfd", Tenant: "proj-7"})
+	if got != "proj-7:4242 cfd" {
+		t.Errorf("description = %q, want %q", got, "proj-7:4242 cfd")
+	}
+}
+
+func TestPortDescription_ExplicitDescriptionWins(t *testing.T) {
+	s := NewJobService(nil, nil, &config.NexusDashboardConfig{PortDescriptionTemplate: "{{.Name}}"}, nil)
+	got := s.portDescription(ProvisionInput{SlurmJobID: "4242", Name: "cfd", Description: "wind tunnel"})
+	if got != "HPC:4242/wind tunnel" {
+		t.Errorf("description = %q, want %q", got, "HPC:4242/wind tunnel")
+	}
+}
+
+func TestPortDescription_TruncatedTo64(t *testing.T) {
+	s := NewJobService(nil, nil, &config.NexusDashboardConfig{}, nil)
+	got := s.portDescription(ProvisionInput{SlurmJobID: "4242", Description: strings.Repeat("x", 100)})
+	if len(got) != util.NDFCDescriptionMaxLen {
+		t.Fatalf("len = %d, want %d", len(got), util.NDFCDescriptionMaxLen)
+	}
+	if !strings.HasPrefix(got, "HPC:4242/xxx") {
+		t.Errorf("description = %q, want HPC:4242/ prefix", got)
+	}
+}
+
+func TestParsePortDescriptionTemplate_Invalid(t *testing.T) {
+	for _, text := range []string{
+		"HPC Job {{.SlurmJobID",    // syntax error
+		"HPC Job {{.ProjectCode}}", // unknown field, only caught by executing
+	} {
+		if _, err := ParsePortDescriptionTemplate(text); err == nil {
+			t.Errorf("ParsePortDescriptionTemplate(%q) = nil error, want startup failure", text)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// nodePortHistoryQuery builds the history query for changes that moved a mapping to or from nodeID
+func nodePortHistoryQuery(db *gorm.DB, nodeID string, since time.Time) *gorm.DB {
+	q := db.Model(&models.ComputeNodePortMappingHistory{}).
+		Where("previous_node_id = ? OR new_node_id = ?", nodeID, nodeID)
+	if !since.IsZero() {
+		q = q.Where("changed_at >= ?", since)
+	}
+	return q.Order("changed_at DESC")
+}
+
+// NodePortHistory returns port mapping changes involving a compute node, newest first.
+// A zero since returns the full retained history.
+func NodePortHistory(ctx context.Context, db *gorm.DB, nodeID string, since time.Time) ([]models.ComputeNodePortMappingHistory, error) {
+	var history []models.ComputeNodePortMappingHistory
+	if err := nodePortHistoryQuery(db.WithContext(ctx), nodeID, since).Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// fabricPortHistoryQuery builds the history query for changes involving ports on a fabric's switches.
+// Soft-deleted ports and switches are included so history outlives inventory changes.
+func fabricPortHistoryQuery(db *gorm.DB, fabricID, portID string) *gorm.DB {
+	fabricPorts := db.Session(&gorm.Session{NewDB: true}).
+		Table("switch_ports").
+		Select("switch_ports.id").
+		Joins("JOIN switches ON switches.id = switch_ports.switch_id").
+		Where("switches.fabric_id = ?", fabricID)
+
+	q := db.Model(&models.ComputeNodePortMappingHistory{}).
+		Where("previous_switch_port_id IN (?) OR new_switch_port_id IN (?)", fabricPorts, fabricPorts)
+	if portID != "" {
+		q = q.Where("previous_switch_port_id = ? OR new_switch_port_id = ?", portID, portID)
+	}
+	return q.Order("changed_at DESC")
+}
+
+// FabricPortHistory returns port mapping changes involving ports in a fabric, newest first.
+// If portID is set, only changes to or from that switch port are returned.
+func FabricPortHistory(ctx context.Context, db *gorm.DB, fabricID, portID string) ([]models.ComputeNodePortMappingHistory, error) {
+	var history []models.ComputeNodePortMappingHistory
+	if err := fabricPortHistoryQuery(db.WithContext(ctx), fabricID, portID).Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+)
+
+func TestNodePortHistoryQuery_Since(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var history []models.ComputeNodePortMappingHistory
+	stmt := nodePortHistoryQuery(newDryRunDB(t), "node-1", since).Find(&history).Statement
+	sql := stmt.SQL.String()
+
+	for _, want := range []string{
+		"(previous_node_id = $1 OR new_node_id = $2)",
+		"changed_at >= $3",
+		"ORDER BY changed_at DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query missing %q:\n%s", want, sql)
+		}
+	}
+	if len(stmt.Vars) != 3 || stmt.Vars[2] != since {
+		t.Errorf("vars = %v, want [node-1 node-1 %v]", stmt.Vars, since)
+	}
+}
+
+func TestNodePortHistoryQuery_NoSince(t *testing.T) {
+	var history []models.ComputeNodePortMappingHistory
+	stmt := nodePortHistoryQuery(newDryRunDB(t), "node-1", time.Time{}).Find(&history).Statement
+
+	if strings.Contains(stmt.SQL.String(), "changed_at >=") {
+		t.Errorf("zero since should not filter by date:\n%s", stmt.SQL.String())
+	}
+}
+
+// TestFabricPortHistoryQuery_PortFilter tests that history is scoped to the fabric's ports
+// (including soft-deleted ones) and optionally to one port
+func TestFabricPortHistoryQuery_PortFilter(t *testing.T) {
+	var history []models.ComputeNodePortMappingHistory
+	stmt := fabricPortHistoryQuery(newDryRunDB(t), "fabric-1", "port-1").Find(&history).Statement
+	sql := stmt.SQL.String()
+
+	for _, want := range []string{
+		"previous_switch_port_id IN (SELECT switch_ports.id FROM",
+		"JOIN switches ON switches.id = switch_ports.switch_id",
+		"(previous_switch_port_id = $3 OR new_switch_port_id = $4)",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query missing %q:\n%s", want, sql)
+		}
+	}
+	if strings.Contains(sql, "deleted_at") {
+		t.Errorf("history should include ports on deleted switches:\n%s", sql)
+	}
+	if len(stmt.Vars) != 4 || stmt.Vars[0] != "fabric-1" || stmt.Vars[3] != "port-1" {
+		t.Errorf("vars = %v", stmt.Vars)
+	}
+}
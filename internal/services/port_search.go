@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPortFilter is returned when a port search filter value is malformed
+var ErrInvalidPortFilter = errors.New("invalid port filter")
+
+// PortSearchFilter holds the optional filters for SearchFabricPorts.
+// Empty fields are not applied.
+type PortSearchFilter struct {
+	DescriptionContains string // Case-insensitive substring match on the NDFC description
+	AdminState          string // "true" or "false"
+	Speed               string // Exact match (e.g., "100G")
+}
+
+// PortSearchResult is a switch port with its switch's name and serial number
+type PortSearchResult struct {
+	models.SwitchPort  `gorm:"embedded"`
+	SwitchName         string `json:"switch_name"`
+	SwitchSerialNumber string `json:"switch_serial_number"`
+}
+
+// portSearchQuery builds the port search query for all switches in a fabric.
+// Switch name and serial are selected via JOIN rather than preloaded per port.
+func portSearchQuery(db *gorm.DB, fabricID string, filter PortSearchFilter) (*gorm.DB, error) {
+	q := db.Model(&models.SwitchPort{}).
+		Select("switch_ports.*, switches.name AS switch_name, switches.serial_number AS switch_serial_number").
+		Joins("JOIN switches ON switches.id = switch_ports.switch_id AND switches.deleted_at IS NULL").
+		Where("switches.fabric_id = ?", fabricID)
+
+	if filter.DescriptionContains != "" {
+		q = q.Where("LOWER(switch_ports.description) LIKE ? ESCAPE '\\'",
+			"%"+escapeLike(strings.ToLower(filter.DescriptionContains))+"%")
+	}
+	if filter.AdminState != "" {
+		enabled, err := strconv.ParseBool(filter.AdminState)
+		if err != nil {
+			return nil, fmt.Errorf("%w: admin_state %q must be true or false", ErrInvalidPortFilter, filter.AdminState)
+		}
+		q = q.Where("switch_ports.admin_state = ?", strconv.FormatBool(enabled))
+	}
+	if filter.Speed != "" {
+		q = q.Where("switch_ports.speed = ?", filter.Speed)
+	}
+
+	return q.Order("switches.name, switch_ports.name"), nil
+}
+
+// SearchFabricPorts returns ports across all switches in a fabric matching filter
+func SearchFabricPorts(ctx context.Context, db *gorm.DB, fabricID string, filter PortSearchFilter) ([]PortSearchResult, error) {
+	q, err := portSearchQuery(db.WithContext(ctx), fabricID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []PortSearchResult
+	if err := q.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// portJobOwnersQuery builds the lookup of owning Slurm job IDs for a set of ports
+func portJobOwnersQuery(db *gorm.DB, portIDs []string) *gorm.DB {
+	return db.Table("compute_node_port_mappings").
+		Select("compute_node_port_mappings.switch_port_id, jobs.slurm_job_id").
+		Joins("JOIN compute_node_allocations ON compute_node_allocations.compute_node_id = compute_node_port_mappings.compute_node_id").
+		Joins("JOIN jobs ON jobs.id = compute_node_allocations.job_id AND jobs.deleted_at IS NULL").
+		Where("compute_node_port_mappings.switch_port_id IN ?", portIDs).
+		Where("compute_node_port_mappings.deleted_at IS NULL")
+}
+
+// PortJobOwners returns switch port ID -> Slurm job ID for ports whose compute node
+// is currently allocated to a job. Ports without an owning job are omitted.
+func PortJobOwners(ctx context.Context, db *gorm.DB, portIDs []string) (map[string]string, error) {
+	owners := make(map[string]string)
+	if len(portIDs) == 0 {
+		return owners, nil
+	}
+
+	var rows []struct {
+		SwitchPortID string
+		SlurmJobID   string
+	}
+	if err := portJobOwnersQuery(db.WithContext(ctx), portIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		owners[r.SwitchPortID] = r.SlurmJobID
+	}
+	return owners, nil
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
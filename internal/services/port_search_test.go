@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newDryRunDB returns a Postgres-dialect gorm DB that builds SQL without connecting
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dryrun"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	return db
+}
+
+// TestPortSearchQuery_Join tests that switch fields come from a single JOIN, not a preload
+func TestPortSearchQuery_Join(t *testing.T) {
+	q, err := portSearchQuery(newDryRunDB(t), "fabric-1", PortSearchFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var results []PortSearchResult
+	stmt := q.Scan(&results).Statement
+	sql := stmt.SQL.String()
+
+	for _, want := range []string{
+		"switches.name AS switch_name",
+		"switches.serial_number AS switch_serial_number",
+		"JOIN switches ON switches.id = switch_ports.switch_id",
+		"switches.fabric_id = $1",
+		`"switch_ports"."deleted_at" IS NULL`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query missing %q:\n%s", want, sql)
+		}
+	}
+	if len(stmt.Vars) != 1 || stmt.Vars[0] != "fabric-1" {
+		t.Errorf("vars = %v, want [fabric-1]", stmt.Vars)
+	}
+}
+
+// TestPortSearchQuery_DescriptionCaseInsensitive tests that both column and pattern are lowercased
+func TestPortSearchQuery_DescriptionCaseInsensitive(t *testing.T) {
+	q, err := portSearchQuery(newDryRunDB(t), "fabric-1", PortSearchFilter{DescriptionContains: "HPC Job_1%"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var results []PortSearchResult
+	stmt := q.Scan(&results).Statement
+
+	if !strings.Contains(stmt.SQL.String(), "LOWER(switch_ports.description) LIKE $2") {
+		t.Errorf("expected lowercased LIKE on description:\n%s", stmt.SQL.String())
+	}
+	// Wildcards in user input are escaped so they match literally
+	if got, want := stmt.Vars[1], `%hpc job\_1\%%`; got != want {
+		t.Errorf("pattern = %q, want %q", got, want)
+	}
+}
+
+func TestPortSearchQuery_CombinedFilters(t *testing.T) {
+	q, err := portSearchQuery(newDryRunDB(t), "fabric-1", PortSearchFilter{AdminState: "TRUE", Speed: "100G"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var results []PortSearchResult
+	stmt := q.Scan(&results).Statement
+	sql := stmt.SQL.String()
+
+	if !strings.Contains(sql, "switch_ports.admin_state = $2") || !strings.Contains(sql, "switch_ports.speed = $3") {
+		t.Errorf("expected admin_state and speed filters:\n%s", sql)
+	}
+	// admin_state is normalized to NDFC's "true"/"false"
+	if stmt.Vars[1] != "true" || stmt.Vars[2] != "100G" {
+		t.Errorf("vars = %v", stmt.Vars)
+	}
+}
+
+func TestPortSearchQuery_InvalidAdminState(t *testing.T) {
+	_, err := portSearchQuery(newDryRunDB(t), "fabric-1", PortSearchFilter{AdminState: "up"})
+	if !errors.Is(err, ErrInvalidPortFilter) {
+		t.Errorf("expected ErrInvalidPortFilter, got %v", err)
+	}
+}
+
+func TestPortJobOwnersQuery(t *testing.T) {
+	var rows []struct {
+		SwitchPortID string
+		SlurmJobID   string
+	}
+	stmt := portJobOwnersQuery(newDryRunDB(t), []string{"p1", "p2"}).Scan(&rows).Statement
+	sql := stmt.SQL.String()
+
+	for _, want := range []string{
+		"JOIN compute_node_allocations ON compute_node_allocations.compute_node_id = compute_node_port_mappings.compute_node_id",
+		"JOIN jobs ON jobs.id = compute_node_allocations.job_id",
+		"compute_node_port_mappings.switch_port_id IN ($1,$2)",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query missing %q:\n%s", want, sql)
+		}
+	}
+}
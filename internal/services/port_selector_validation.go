@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/util"
+)
+
+// ErrInvalidPortSelector is returned when the job's security group in NDFC lacks port
+// selectors the job requested, e.g. because NDFC dropped a selector for an unknown switch or
+// a recovered group was created with other ports
+var ErrInvalidPortSelector = errors.New("invalid port selector")
+
+// validatePortSelectors checks that each requested selector is among the selectors of the
+// security group as NDFC reports it. Returns one joined error listing every missing selector.
+func validatePortSelectors(group *ndclient.SecurityGroup, selectors []ndclient.NetworkPortSelector) error {
+	selectorKey := func(sel ndclient.NetworkPortSelector) string {
+		return util.SelectorExpression{
+			SerialNumber:  sel.SwitchID,
+			InterfaceName: lanfabric.NormalizeInterfaceName(sel.InterfaceName),
+		}.String()
+	}
+	actual := make(map[string]bool, len(group.NetworkPortSelectors))
+	for _, sel := range group.NetworkPortSelectors {
+		actual[selectorKey(sel)] = true
+	}
+
+	var errs []error
+	for _, sel := range selectors {
+		if !actual[selectorKey(sel)] {
+			errs = append(errs, fmt.Errorf("%w: interface %s on switch %s not in NDFC security group %s",
+				ErrInvalidPortSelector, sel.InterfaceName, sel.SwitchID, group.GroupName))
+		}
+	}
+	return errors.Join(errs...)
+}
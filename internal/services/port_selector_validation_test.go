@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+func TestValidatePortSelectors_Valid(t *testing.T) {
+	group := &ndclient.SecurityGroup{GroupName: "hpc-1001", NetworkPortSelectors: []ndclient.NetworkPortSelector{
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/1"},
+		{Network: "net1", SwitchID: "SN1", InterfaceName: " Ethernet1/2"},
+	}}
+
+	err := validatePortSelectors(group, []ndclient.NetworkPortSelector{
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/1"},
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/2"},
+	})
+	if err != nil {
+		t.Errorf("selectors in the group rejected: %v", err)
+	}
+	if err := validatePortSelectors(group, nil); err != nil {
+		t.Errorf("no selectors rejected: %v", err)
+	}
+}
+
+func TestValidatePortSelectors_ListsEveryMissingSelector(t *testing.T) {
+	group := &ndclient.SecurityGroup{GroupName: "hpc-1001", NetworkPortSelectors: []ndclient.NetworkPortSelector{
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/1"},
+	}}
+
+	err := validatePortSelectors(group, []ndclient.NetworkPortSelector{
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/1"},
+		{Network: "net1", SwitchID: "SN-MISSING", InterfaceName: "Ethernet1/1"},
+		{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/9"},
+	})
+	if !errors.Is(err, ErrInvalidPortSelector) {
+		t.Fatalf("err = %v, want ErrInvalidPortSelector", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "interface Ethernet1/1 on switch SN-MISSING") || !strings.Contains(msg, "interface Ethernet1/9 on switch SN1") {
+		t.Errorf("err = %q, want both missing selectors listed", msg)
+	}
+	if strings.Count(msg, "not in NDFC security group hpc-1001") != 2 {
+		t.Errorf("err = %q, want only the missing selectors", msg)
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidReport is returned when provisioning report parameters are malformed
+var ErrInvalidReport = errors.New("invalid report parameters")
+
+// Provisioning summary groupings
+const (
+	ReportGroupByDay    = "day"
+	ReportGroupByWeek   = "week"
+	ReportGroupByMonth  = "month"
+	ReportGroupByFabric = "fabric"
+)
+
+// reportPeriodExprs maps each grouping to the SQL expression producing the period label.
+// Time periods are formatted in SQL so every grouping yields a sortable string.
+var reportPeriodExprs = map[string]string{
+	ReportGroupByDay:    `TO_CHAR(DATE_TRUNC('day', jobs.submitted_at), 'YYYY-MM-DD')`,
+	ReportGroupByWeek:   `TO_CHAR(DATE_TRUNC('week', jobs.submitted_at), 'IYYY-"W"IW')`,
+	ReportGroupByMonth:  `TO_CHAR(DATE_TRUNC('month', jobs.submitted_at), 'YYYY-MM')`,
+	ReportGroupByFabric: `jobs.fabric_name`,
+}
+
+// ProvisioningSummaryRow is one period (or fabric) of the provisioning summary
+type ProvisioningSummaryRow struct {
+	Period              string  `json:"period"`                // "2024-01", "2024-01-15", "2024-W03" or fabric name
+	JobCount            int64   `json:"job_count"`             // Jobs submitted in the period
+	AvgProvisionSeconds float64 `json:"avg_provision_seconds"` // Mean submit-to-provisioned time of provisioned jobs
+	TotalNodeDays       int64   `json:"total_node_days"`       // Sum of compute nodes across the period's jobs
+	MaxNodesSingleJob   int64   `json:"max_nodes_single_job"`  // Largest node count of any one job
+}
+
+// provisioningSummaryQuery builds the summary of jobs submitted in [from, to) grouped by groupBy
+func provisioningSummaryQuery(db *gorm.DB, from, to time.Time, groupBy string) (*gorm.DB, error) {
+	periodExpr, ok := reportPeriodExprs[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: group_by %q must be day, week, month or fabric", ErrInvalidReport, groupBy)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidReport)
+	}
+
+	return db.Model(&models.Job{}).
+		Select(periodExpr+" AS period, "+
+			"COUNT(*) AS job_count, "+
+			"COALESCE(AVG(EXTRACT(EPOCH FROM jobs.provisioned_at - jobs.submitted_at)), 0) AS avg_provision_seconds, "+
+			"COALESCE(SUM(nc.node_count), 0) AS total_node_days, "+
+			"COALESCE(MAX(nc.node_count), 0) AS max_nodes_single_job").
+		Joins("LEFT JOIN (SELECT job_id, COUNT(*) AS node_count FROM job_compute_nodes "+
+			"WHERE deleted_at IS NULL GROUP BY job_id) nc ON nc.job_id = jobs.id").
+		Where("jobs.submitted_at >= ? AND jobs.submitted_at < ?", from, to).
+		Clauses(clause.GroupBy{Columns: []clause.Column{{Name: "1", Raw: true}}}).
+		Order("1"), nil
+}
+
+// ProvisioningSummary reports job counts, provisioning latency and node usage for
+// jobs submitted in [from, to), grouped by day, week, month or fabric
+func ProvisioningSummary(ctx context.Context, db *gorm.DB, from, to time.Time, groupBy string) ([]ProvisioningSummaryRow, error) {
+	q, err := provisioningSummaryQuery(db.WithContext(ctx), from, to, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []ProvisioningSummaryRow{}
+	if err := q.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// provisioningSummaryWindow is how far back metrics.ProvisioningSummary reports
+const provisioningSummaryWindow = 12 // months
+
+// MonthlyProvisioningSummary returns the source of metrics.ProvisioningSummary: the monthly
+// provisioning summary of db for the trailing twelve months
+func MonthlyProvisioningSummary(db *gorm.DB) func(ctx context.Context) ([]metrics.ProvisioningMonth, error) {
+	return func(ctx context.Context) ([]metrics.ProvisioningMonth, error) {
+		now := time.Now().UTC()
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(provisioningSummaryWindow - 1), 0)
+		rows, err := ProvisioningSummary(ctx, db, from, now.Add(time.Second), ReportGroupByMonth)
+		if err != nil {
+			return nil, err
+		}
+		months := make([]metrics.ProvisioningMonth, 0, len(rows))
+		for _, r := range rows {
+			months = append(months, metrics.ProvisioningMonth{
+				Period:              r.Period,
+				Jobs:                r.JobCount,
+				AvgProvisionSeconds: r.AvgProvisionSeconds,
+				Nodes:               r.TotalNodeDays,
+				MaxNodesSingleJob:   r.MaxNodesSingleJob,
+			})
+		}
+		return months, nil
+	}
+}
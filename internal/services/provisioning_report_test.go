@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestProvisioningSummaryQuery_Grouping tests the period expression used for each grouping
+func TestProvisioningSummaryQuery_Grouping(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		groupBy string
+		period  string
+	}{
+		{ReportGroupByDay, `TO_CHAR(DATE_TRUNC('day', jobs.submitted_at), 'YYYY-MM-DD') AS period`},
+		{ReportGroupByWeek, `TO_CHAR(DATE_TRUNC('week', jobs.submitted_at), 'IYYY-"W"IW') AS period`},
+		{ReportGroupByMonth, `TO_CHAR(DATE_TRUNC('month', jobs.submitted_at), 'YYYY-MM') AS period`},
+		{ReportGroupByFabric, `jobs.fabric_name AS period`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			q, err := provisioningSummaryQuery(newDryRunDB(t), from, to, tt.groupBy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var rows []ProvisioningSummaryRow
+			stmt := q.Scan(&rows).Statement
+			sql := stmt.SQL.String()
+
+			for _, want := range []string{
+				tt.period,
+				"COUNT(*) AS job_count",
+				"AVG(EXTRACT(EPOCH FROM jobs.provisioned_at - jobs.submitted_at))",
+				"SUM(nc.node_count)",
+				"MAX(nc.node_count)",
+				"LEFT JOIN (SELECT job_id, COUNT(*) AS node_count FROM job_compute_nodes",
+				"jobs.submitted_at >= $1 AND jobs.submitted_at < $2",
+				`"jobs"."deleted_at" IS NULL`,
+				"GROUP BY 1 ORDER BY 1",
+			} {
+				if !strings.Contains(sql, want) {
+					t.Errorf("query missing %q:\n%s", want, sql)
+				}
+			}
+			if len(stmt.Vars) != 2 || stmt.Vars[0] != from || stmt.Vars[1] != to {
+				t.Errorf("vars = %v, want [%v %v]", stmt.Vars, from, to)
+			}
+		})
+	}
+}
+
+func TestProvisioningSummaryQuery_Invalid(t *testing.T) {
+	now := time.Now()
+	if _, err := provisioningSummaryQuery(newDryRunDB(t), now, now.Add(time.Hour), "year"); !errors.Is(err, ErrInvalidReport) {
+		t.Errorf("expected ErrInvalidReport for unknown group_by, got %v", err)
+	}
+	if _, err := provisioningSummaryQuery(newDryRunDB(t), now, now, ReportGroupByMonth); !errors.Is(err, ErrInvalidReport) {
+		t.Errorf("expected ErrInvalidReport for empty range, got %v", err)
+	}
+}
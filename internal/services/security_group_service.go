@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/util"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Security group service errors
+var (
+	ErrSecurityGroupNotFound = errors.New("security group not found")
+	ErrInvalidGroupObjectID  = errors.New("invalid security group NDObjectID")
+	ErrInvalidGroupListing   = errors.New("invalid security group list options")
+	ErrInvalidSecurityGroup  = errors.New("invalid security group")
+)
+
+// Security group list sort orders
+const (
+	SecurityGroupSortNameAsc  = "name_asc"
+	SecurityGroupSortNameDesc = "name_desc"
+	SecurityGroupSortIDAsc    = "id_asc"
+)
+
+// Security group page sizes
+const (
+	DefaultSecurityGroupPageSize = 50
+	MaxSecurityGroupPageSize     = 500
+)
+
+// SecurityGroupListOptions filters, sorts and pages a security group listing
+type SecurityGroupListOptions struct {
+	NameContains string // Case-insensitive substring of the group name
+	Fabric       string // Local listings only: groups in this fabric
+	Sort         string // name_asc (default), name_desc or id_asc
+	Offset       int
+	Limit        int // Defaults to DefaultSecurityGroupPageSize, capped at MaxSecurityGroupPageSize
+}
+
+// normalize applies defaults and validates the options
+func (o *SecurityGroupListOptions) normalize() error {
+	switch o.Sort {
+	case "":
+		o.Sort = SecurityGroupSortNameAsc
+	case SecurityGroupSortNameAsc, SecurityGroupSortNameDesc, SecurityGroupSortIDAsc:
+	default:
+		return fmt.Errorf("%w: sort %q must be name_asc, name_desc or id_asc", ErrInvalidGroupListing, o.Sort)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidGroupListing)
+	}
+	if o.Limit < 0 {
+		return fmt.Errorf("%w: page size must not be negative", ErrInvalidGroupListing)
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultSecurityGroupPageSize
+	}
+	if o.Limit > MaxSecurityGroupPageSize {
+		o.Limit = MaxSecurityGroupPageSize
+	}
+	return nil
+}
+
+// List returns one page of local security groups and the total number matching the filters
+func (s *SecurityGroupService) List(ctx context.Context, opts SecurityGroupListOptions) ([]models.SecurityGroup, int64, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.SecurityGroup{})
+	if opts.NameContains != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(opts.NameContains))+"%")
+	}
+	if opts.Fabric != "" {
+		query = query.Where("fabric_name = ?", opts.Fabric)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := map[string]string{
+		SecurityGroupSortNameAsc:  "name ASC, id ASC",
+		SecurityGroupSortNameDesc: "name DESC, id ASC",
+		SecurityGroupSortIDAsc:    "id ASC",
+	}[opts.Sort]
+	groups := []models.SecurityGroup{}
+	if err := query.Preload("Selectors.SwitchPort").Order(order).
+		Offset(opts.Offset).Limit(opts.Limit).Find(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// ListNDFC fetches every security group of a fabric from NDFC, following its pages, then
+// filters, sorts and pages them locally since NDFC has no server-side filtering. Returns the page and the total
+// number matching the filters. opts.Fabric is ignored.
+func (s *SecurityGroupService) ListNDFC(ctx context.Context, fabricName string, opts SecurityGroupListOptions) ([]ndclient.SecurityGroup, int64, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, 0, err
+	}
+	if s.ndClient == nil {
+		return nil, 0, errors.New("Nexus Dashboard client not configured")
+	}
+
+	groups, err := s.ndClient.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, total := pageNDFCSecurityGroups(groups, opts)
+	return page, total, nil
+}
+
+// pageNDFCSecurityGroups applies normalized list options to groups fetched from NDFC
+func pageNDFCSecurityGroups(groups []ndclient.SecurityGroup, opts SecurityGroupListOptions) ([]ndclient.SecurityGroup, int64) {
+	needle := strings.ToLower(opts.NameContains)
+	matched := make([]ndclient.SecurityGroup, 0, len(groups))
+	for _, g := range groups {
+		if strings.Contains(strings.ToLower(g.GroupName), needle) {
+			matched = append(matched, g)
+		}
+	}
+
+	groupID := func(g ndclient.SecurityGroup) int {
+		if g.GroupID == nil {
+			return 0
+		}
+		return *g.GroupID
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		switch opts.Sort {
+		case SecurityGroupSortNameDesc:
+			return matched[i].GroupName > matched[j].GroupName
+		case SecurityGroupSortIDAsc:
+			return groupID(matched[i]) < groupID(matched[j])
+		default:
+			return matched[i].GroupName < matched[j].GroupName
+		}
+	})
+
+	total := int64(len(matched))
+	if opts.Offset >= len(matched) {
+		return []ndclient.SecurityGroup{}, total
+	}
+	end := opts.Offset + opts.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[opts.Offset:end], total
+}
+
+// SecurityGroupService handles security group operations spanning NDFC and the local DB
+type SecurityGroupService struct {
+	db       *gorm.DB
+	ndClient *ndclient.Client
+}
+
+// NewSecurityGroupService creates a new SecurityGroupService.
+// ndClient may be nil, in which case only the local DB is changed.
+func NewSecurityGroupService(db *gorm.DB, ndClient *ndclient.Client) *SecurityGroupService {
+	return &SecurityGroupService{db: db, ndClient: ndClient}
+}
+
+// Get returns a local security group with its port selectors, switch ports and switches
+func (s *SecurityGroupService) Get(ctx context.Context, id string) (*models.SecurityGroup, error) {
+	var group models.SecurityGroup
+	if err := s.db.WithContext(ctx).Preload("Selectors.SwitchPort.Switch").First(&group, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSecurityGroupNotFound
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetNDFC returns a security group read from NDFC by fabric and group name
+func (s *SecurityGroupService) GetNDFC(ctx context.Context, fabricName, groupName string) (*ndclient.SecurityGroup, error) {
+	if s.ndClient == nil {
+		return nil, errors.New("Nexus Dashboard client not configured")
+	}
+	group, err := s.ndClient.GetSecurityGroupByName(ctx, fabricName, groupName)
+	if err != nil {
+		if errors.Is(err, ndclient.ErrSecurityGroupNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSecurityGroupNotFound, groupName)
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+// normalizePortSelectors normalizes selector interface names in place and checks that each
+// selector is a valid serial:interface pair
+func normalizePortSelectors(selectors []ndclient.NetworkPortSelector) error {
+	for i, sel := range selectors {
+		expr := util.SelectorExpression{SerialNumber: sel.SwitchID, InterfaceName: lanfabric.NormalizeInterfaceName(sel.InterfaceName)}
+		if err := util.ValidateSelectorExpression(expr.String()); err != nil {
+			return fmt.Errorf("%w: network port selector %d: %v", ErrInvalidSecurityGroup, i, err)
+		}
+		selectors[i].SwitchID = expr.SerialNumber
+		selectors[i].InterfaceName = expr.InterfaceName
+	}
+	return nil
+}
+
+// BulkUpdateSelectors replaces the network port selectors of several existing groups in one
+// NDFC request, normalizing selectors like Create. Returns one error per update, in order;
+// the error return is set only when the request is invalid as a whole. With dryRun the
+// updates are validated and normalized but not sent.
+func (s *SecurityGroupService) BulkUpdateSelectors(ctx context.Context, fabricName string, updates []ndclient.SGSelectorUpdate, dryRun bool) ([]error, error) {
+	if fabricName == "" || len(updates) == 0 {
+		return nil, fmt.Errorf("%w: fabric name and at least one update are required", ErrInvalidSecurityGroup)
+	}
+	for i, u := range updates {
+		if u.GroupID <= 0 || u.GroupName == "" {
+			return nil, fmt.Errorf("%w: update %d: group ID and group name are required", ErrInvalidSecurityGroup, i)
+		}
+		if err := normalizePortSelectors(u.Selectors); err != nil {
+			return nil, fmt.Errorf("update %d (%s): %w", i, u.GroupName, err)
+		}
+	}
+	if dryRun {
+		return make([]error, len(updates)), nil
+	}
+	if s.ndClient == nil {
+		return nil, errors.New("Nexus Dashboard client not configured")
+	}
+	return s.ndClient.BulkUpdateSecurityGroupSelectors(ctx, fabricName, updates), nil
+}
+
+// Create creates a security group in NDFC and upserts it locally by fabric and name, so a
+// retried create returns the existing record. Network port selector interface names are
+// normalized to full Ethernet form and each selector must be a valid serial:interface pair.
+// Returns the local record and the group as created in NDFC.
+func (s *SecurityGroupService) Create(ctx context.Context, group *ndclient.SecurityGroup) (*models.SecurityGroup, *ndclient.SecurityGroup, error) {
+	if group.GroupName == "" || group.FabricName == "" {
+		return nil, nil, fmt.Errorf("%w: group name and fabric name are required", ErrInvalidSecurityGroup)
+	}
+	if err := normalizePortSelectors(group.NetworkPortSelectors); err != nil {
+		return nil, nil, err
+	}
+	if s.ndClient == nil {
+		return nil, nil, errors.New("Nexus Dashboard client not configured")
+	}
+
+	ndResp, err := s.ndClient.CreateSecurityGroup(ctx, group.FabricName, group)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	local := models.SecurityGroup{
+		ID:         uuid.New().String(),
+		Name:       group.GroupName,
+		FabricName: group.FabricName,
+	}
+	if ndResp.GroupID != nil {
+		local.NDObjectID = strconv.Itoa(*ndResp.GroupID)
+	}
+	db := s.db.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "fabric_name"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nd_object_id", "updated_at"}),
+	}).Create(&local).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// Re-read to get the ID, which belongs to the existing record on retries
+	if err := db.Where("fabric_name = ? AND name = ?", group.FabricName, group.GroupName).First(&local).Error; err != nil {
+		return nil, nil, err
+	}
+	return &local, ndResp, nil
+}
+
+// Delete removes a security group and everything that references it. The group's NDObjectID
+// is validated and the local associations (via the SecurityGroup BeforeDelete hook),
+// selectors and group are deleted in one transaction before NDFC is touched, so a failed
+// commit leaves NDFC unchanged. NDFC is then cleaned up in order:
+//  1. delete each association using the group (404 counts as already gone)
+//  2. delete the group
+//
+// NDFC may reject deleting a group that still has associations, so any non-404
+// association failure stops before the group. The returned error then names what was
+// left in NDFC; association validation reports it as ndfc_only.
+func (s *SecurityGroupService) Delete(ctx context.Context, id string) error {
+	var group models.SecurityGroup
+	var associations []models.SecurityAssociation
+	var groupID int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&group, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSecurityGroupNotFound
+			}
+			return err
+		}
+		if group.NDObjectID != "" {
+			n, err := strconv.Atoi(group.NDObjectID)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrInvalidGroupObjectID, group.NDObjectID)
+			}
+			groupID = n
+		}
+
+		if err := tx.Where("provider_group_id = ? OR consumer_group_id = ?", group.ID, group.ID).
+			Order("name").
+			Find(&associations).Error; err != nil {
+			return fmt.Errorf("list associations: %w", err)
+		}
+		if err := tx.Where("security_group_id = ?", group.ID).Delete(&models.PortSelector{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&group).Error
+	})
+	if err != nil || s.ndClient == nil {
+		return err
+	}
+
+	if err := s.deleteNDFC(ctx, &group, groupID, associations); err != nil {
+		return fmt.Errorf("security group %s deleted locally: %w", group.Name, err)
+	}
+	return nil
+}
+
+// deleteNDFC deletes the group's associations and then the group itself from NDFC.
+// groupID is the group's NDFC ID, or zero if it was never pushed.
+func (s *SecurityGroupService) deleteNDFC(ctx context.Context, group *models.SecurityGroup, groupID int, associations []models.SecurityAssociation) error {
+	for _, a := range associations {
+		if a.FabricName == "" || a.SrcGroupNDID <= 0 || a.DstGroupNDID <= 0 {
+			continue // Never pushed to NDFC
+		}
+		err := s.ndClient.DeleteSecurityAssociation(ctx, a.FabricName, a.VRFName, a.SrcGroupNDID, a.DstGroupNDID, a.ContractName)
+		if err != nil && !ndclient.IsNotFoundError(err) {
+			return fmt.Errorf("delete association %s from NDFC: %w", a.Name, err)
+		}
+	}
+
+	if groupID <= 0 {
+		return nil
+	}
+	if err := s.ndClient.DeleteSecurityGroup(ctx, group.FabricName, groupID); err != nil {
+		return fmt.Errorf("delete group from NDFC: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"gorm.io/gorm"
+)
+
+// recordingNDFC records NDFC DELETE calls in order and answers association
+// deletes with assocStatus (200 if zero)
+type recordingNDFC struct {
+	mu          sync.Mutex
+	deletes     []string
+	assocStatus int
+}
+
+func (f *recordingNDFC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodDelete {
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+
+	kind := "group"
+	if strings.Contains(r.URL.Path, "contractAssociations") {
+		kind = "association"
+	}
+	f.mu.Lock()
+	f.deletes = append(f.deletes, kind)
+	f.mu.Unlock()
+
+	if kind == "association" && f.assocStatus != 0 {
+		w.WriteHeader(f.assocStatus)
+		_, _ = w.Write([]byte(`{"message": "association error"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func newSecurityGroupTest(t *testing.T, fake *recordingNDFC) (*SecurityGroupService, *gorm.DB) {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	db := dbtest.NewSQLiteDB(t, &models.SecurityGroup{}, &models.PortSelector{}, &models.SecurityAssociation{})
+	for _, v := range []interface{}{
+		&models.SecurityGroup{ID: "g1", Name: "job-1", NDObjectID: "101", FabricName: "f1"},
+		&models.SecurityGroup{ID: "g2", Name: "job-2", NDObjectID: "102", FabricName: "f1"},
+		&models.PortSelector{ID: "ps1", SecurityGroupID: "g1", SwitchPortID: "p1"},
+		&models.SecurityAssociation{ID: "a1", Name: "a1", FabricName: "f1", VRFName: "vrf", ContractName: "c",
+			SrcGroupNDID: 101, DstGroupNDID: 102, ProviderGroupID: "g1", ConsumerGroupID: "g2"},
+		&models.SecurityAssociation{ID: "a2", Name: "a2", FabricName: "f1", VRFName: "vrf", ContractName: "c",
+			SrcGroupNDID: 102, DstGroupNDID: 101, ProviderGroupID: "g2", ConsumerGroupID: "g1"},
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed %T: %v", v, err)
+		}
+	}
+	return NewSecurityGroupService(db, client), db
+}
+
+func countRows(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(model).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestSecurityGroupDelete_CascadeOrder(t *testing.T) {
+	fake := &recordingNDFC{}
+	svc, db := newSecurityGroupTest(t, fake)
+
+	if err := svc.Delete(context.Background(), "g1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	want := []string{"association", "association", "group"}
+	if strings.Join(fake.deletes, ",") != strings.Join(want, ",") {
+		t.Errorf("NDFC deletes = %v, want %v", fake.deletes, want)
+	}
+	if n := countRows(t, db, &models.SecurityAssociation{}); n != 0 {
+		t.Errorf("associations = %d, want 0", n)
+	}
+	if n := countRows(t, db, &models.PortSelector{}); n != 0 {
+		t.Errorf("selectors = %d, want 0", n)
+	}
+	if n := countRows(t, db, &models.SecurityGroup{}); n != 1 {
+		t.Errorf("groups = %d, want 1 (g2 kept)", n)
+	}
+}
+
+func TestSecurityGroupDelete_AssociationNotFoundIsSuccess(t *testing.T) {
+	fake := &recordingNDFC{assocStatus: http.StatusNotFound}
+	svc, db := newSecurityGroupTest(t, fake)
+
+	if err := svc.Delete(context.Background(), "g1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if last := fake.deletes[len(fake.deletes)-1]; last != "group" {
+		t.Errorf("last NDFC delete = %s, want group", last)
+	}
+	if n := countRows(t, db, &models.SecurityAssociation{}); n != 0 {
+		t.Errorf("associations = %d, want 0", n)
+	}
+}
+
+func TestSecurityGroupDelete_AssociationFailureKeepsNDFCGroup(t *testing.T) {
+	fake := &recordingNDFC{assocStatus: http.StatusBadRequest}
+	svc, db := newSecurityGroupTest(t, fake)
+
+	if err := svc.Delete(context.Background(), "g1"); err == nil {
+		t.Fatal("expected error")
+	}
+	for _, d := range fake.deletes {
+		if d == "group" {
+			t.Error("group must not be deleted from NDFC after an association failure")
+		}
+	}
+	// The local delete is committed before NDFC is called
+	if n := countRows(t, db, &models.SecurityAssociation{}); n != 0 {
+		t.Errorf("associations = %d, want 0", n)
+	}
+	if n := countRows(t, db, &models.SecurityGroup{}); n != 1 {
+		t.Errorf("groups = %d, want 1 (g2 kept)", n)
+	}
+}
+
+func TestSecurityGroupDelete_InvalidObjectIDSkipsNDFC(t *testing.T) {
+	fake := &recordingNDFC{}
+	svc, db := newSecurityGroupTest(t, fake)
+	if err := db.Model(&models.SecurityGroup{}).Where("id = ?", "g1").Update("nd_object_id", "abc").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.Delete(context.Background(), "g1"); !errors.Is(err, ErrInvalidGroupObjectID) {
+		t.Fatalf("err = %v, want ErrInvalidGroupObjectID", err)
+	}
+	if len(fake.deletes) != 0 {
+		t.Errorf("NDFC deletes = %v, want none", fake.deletes)
+	}
+	if n := countRows(t, db, &models.SecurityGroup{}); n != 2 {
+		t.Errorf("groups = %d, want 2 (rolled back)", n)
+	}
+	if n := countRows(t, db, &models.SecurityAssociation{}); n != 2 {
+		t.Errorf("associations = %d, want 2 (rolled back)", n)
+	}
+}
+
+func TestSecurityGroupDelete_NotFound(t *testing.T) {
+	svc, _ := newSecurityGroupTest(t, &recordingNDFC{})
+	if err := svc.Delete(context.Background(), "missing"); !errors.Is(err, ErrSecurityGroupNotFound) {
+		t.Errorf("err = %v, want ErrSecurityGroupNotFound", err)
+	}
+}
+
+func TestPageNDFCSecurityGroups(t *testing.T) {
+	id := func(n int) *int { return &n }
+	var groups []ndclient.SecurityGroup
+	for i := 1; i <= 120; i++ {
+		name := fmt.Sprintf("job-%03d", i)
+		if i%4 == 0 {
+			name = fmt.Sprintf("infra-%03d", i)
+		}
+		groups = append(groups, ndclient.SecurityGroup{GroupName: name, GroupID: id(1000 - i)})
+	}
+
+	// 90 job groups: page 2 of 50 holds the remaining 40
+	opts := SecurityGroupListOptions{NameContains: "JOB-", Offset: 50, Limit: 50}
+	if err := opts.normalize(); err != nil {
+		t.Fatal(err)
+	}
+	page, total := pageNDFCSecurityGroups(groups, opts)
+	if total != 90 || len(page) != 40 {
+		t.Fatalf("total = %d, page = %d; want 90, 40", total, len(page))
+	}
+	if page[0].GroupName != "job-067" {
+		t.Errorf("first on page 2 = %s, want job-067", page[0].GroupName)
+	}
+
+	// Past the end
+	page, total = pageNDFCSecurityGroups(groups, SecurityGroupListOptions{NameContains: "job-", Sort: SecurityGroupSortNameAsc, Offset: 100, Limit: 50})
+	if total != 90 || len(page) != 0 {
+		t.Errorf("past end: total = %d, page = %d; want 90, 0", total, len(page))
+	}
+
+	page, _ = pageNDFCSecurityGroups(groups, SecurityGroupListOptions{Sort: SecurityGroupSortNameDesc, Limit: 1})
+	if page[0].GroupName != "job-119" {
+		t.Errorf("name_desc first = %s, want job-119", page[0].GroupName)
+	}
+	page, _ = pageNDFCSecurityGroups(groups, SecurityGroupListOptions{Sort: SecurityGroupSortIDAsc, Limit: 1})
+	if *page[0].GroupID != 880 {
+		t.Errorf("id_asc first = %d, want 880", *page[0].GroupID)
+	}
+}
+
+func TestSecurityGroupService_List(t *testing.T) {
+	db := dbtest.NewSQLiteDB(t, &models.SecurityGroup{}, &models.PortSelector{}, &models.SwitchPort{})
+	for i := 1; i <= 7; i++ {
+		fabric := "f1"
+		if i > 5 {
+			fabric = "f2"
+		}
+		if err := db.Create(&models.SecurityGroup{ID: fmt.Sprintf("g%d", i), Name: fmt.Sprintf("job-%d", i), FabricName: fabric}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for id, name := range map[string]string{"g9": "infra_1", "g10": "infrab1"} {
+		if err := db.Create(&models.SecurityGroup{ID: id, Name: name, FabricName: "f1"}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := NewSecurityGroupService(db, nil)
+	ctx := context.Background()
+
+	groups, total, err := svc.List(ctx, SecurityGroupListOptions{NameContains: "job-", Fabric: "f1", Sort: SecurityGroupSortNameDesc, Offset: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 5 || len(groups) != 2 || groups[0].Name != "job-3" || groups[1].Name != "job-2" {
+		t.Errorf("total = %d, groups = %v; want 5, [job-3 job-2]", total, groups)
+	}
+
+	// "_" matches literally, not as a LIKE wildcard
+	if _, total, _ := svc.List(ctx, SecurityGroupListOptions{NameContains: "a_1"}); total != 1 {
+		t.Errorf("literal underscore total = %d, want 1", total)
+	}
+
+	if _, _, err := svc.List(ctx, SecurityGroupListOptions{Sort: "size"}); !errors.Is(err, ErrInvalidGroupListing) {
+		t.Errorf("bad sort err = %v, want ErrInvalidGroupListing", err)
+	}
+}
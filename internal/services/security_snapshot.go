@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"go.uber.org/zap"
+)
+
+// SecuritySnapshotImport reports what ImportSnapshot did in the target fabric. Objects that
+// already existed there are counted as skipped.
+type SecuritySnapshotImport struct {
+	Fabric              string `json:"fabric"`
+	GroupsCreated       int    `json:"groups_created"`
+	GroupsSkipped       int    `json:"groups_skipped"`
+	ContractsCreated    int    `json:"contracts_created"`
+	ContractsSkipped    int    `json:"contracts_skipped"`
+	AssociationsCreated int    `json:"associations_created"`
+	AssociationsSkipped int    `json:"associations_skipped"`
+	Deployed            bool   `json:"deployed"`
+}
+
+// jobSecurityGroupPrefix starts the name of the security group provisioned for each job
+const jobSecurityGroupPrefix = "job-"
+
+// ExportSnapshot fetches the security groups, contracts and associations of a fabric from
+// NDFC and returns them as an ndclient.SecuritySnapshot document. The groups provisioned for
+// jobs, and their associations, belong to running jobs and are left out.
+func (s *SecurityGroupService) ExportSnapshot(ctx context.Context, fabricName string) ([]byte, error) {
+	allGroups, err := s.ndClient.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := s.ndClient.GetSecurityContracts(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+	allAssociations, err := s.ndClient.GetSecurityAssociations(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]ndclient.SecurityGroup, 0, len(allGroups))
+	jobGroupIDs := make(map[int]bool)
+	for _, g := range allGroups {
+		if strings.HasPrefix(g.GroupName, jobSecurityGroupPrefix) {
+			if g.GroupID != nil {
+				jobGroupIDs[*g.GroupID] = true
+			}
+			continue
+		}
+		groups = append(groups, g)
+	}
+	isJobGroup := func(name string, id *int) bool {
+		return strings.HasPrefix(name, jobSecurityGroupPrefix) || (id != nil && jobGroupIDs[*id])
+	}
+	associations := make([]ndclient.ContractAssociation, 0, len(allAssociations))
+	for _, a := range allAssociations {
+		if !isJobGroup(a.SrcGroupName, a.SrcGroupID) && !isJobGroup(a.DstGroupName, a.DstGroupID) {
+			associations = append(associations, a)
+		}
+	}
+	return ndclient.MarshalSecuritySnapshot(fabricName, groups, contracts, associations)
+}
+
+// ImportSnapshot creates the groups, contracts and associations of a snapshot in fabricName,
+// in that order, then deploys the fabric. Objects the fabric already has (by group name,
+// contract name, or VRF, groups and contract of an association) are skipped, as are those
+// NDFC reports a conflict for, so importing the same snapshot again is a no-op apart from
+// the deploy. Group IDs of the source fabric are dropped so NDFC allocates new ones, and
+// association group IDs are resolved by group name in the target fabric.
+func (s *SecurityGroupService) ImportSnapshot(ctx context.Context, fabricName string, snapshot *ndclient.SecuritySnapshot) (*SecuritySnapshotImport, error) {
+	result := &SecuritySnapshotImport{Fabric: fabricName}
+
+	existingGroups, err := s.ndClient.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+	haveGroup := make(map[string]bool, len(existingGroups))
+	for _, g := range existingGroups {
+		haveGroup[g.GroupName] = true
+	}
+	var groups []ndclient.SecurityGroup
+	for _, g := range snapshot.Groups {
+		if haveGroup[g.GroupName] {
+			result.GroupsSkipped++
+			continue
+		}
+		g.GroupID = nil // May be taken in the target fabric
+		groups = append(groups, g)
+	}
+	created, skipped, err := createSnapshotObjects(ctx, groups, func(ctx context.Context, groups []ndclient.SecurityGroup) error {
+		_, err := s.ndClient.CreateSecurityGroups(ctx, fabricName, groups)
+		return err
+	})
+	result.GroupsCreated, result.GroupsSkipped = created, result.GroupsSkipped+skipped
+	if err != nil {
+		return result, fmt.Errorf("import security groups: %w", err)
+	}
+
+	existingContracts, err := s.ndClient.GetSecurityContracts(ctx, fabricName)
+	if err != nil {
+		return result, err
+	}
+	haveContract := make(map[string]bool, len(existingContracts))
+	for _, c := range existingContracts {
+		haveContract[c.ContractName] = true
+	}
+	var contracts []ndclient.SecurityContract
+	for _, c := range snapshot.Contracts {
+		if haveContract[c.ContractName] {
+			result.ContractsSkipped++
+			continue
+		}
+		contracts = append(contracts, c)
+	}
+	created, skipped, err = createSnapshotObjects(ctx, contracts, func(ctx context.Context, contracts []ndclient.SecurityContract) error {
+		_, err := s.ndClient.CreateSecurityContracts(ctx, fabricName, contracts)
+		return err
+	})
+	result.ContractsCreated, result.ContractsSkipped = created, result.ContractsSkipped+skipped
+	if err != nil {
+		return result, fmt.Errorf("import security contracts: %w", err)
+	}
+
+	// Group IDs in the snapshot are those of the source fabric
+	targetGroups, err := s.ndClient.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		return result, err
+	}
+	groupIDs := make(map[string]int, len(targetGroups))
+	for _, g := range targetGroups {
+		if g.GroupID != nil {
+			groupIDs[g.GroupName] = *g.GroupID
+		}
+	}
+	existingAssociations, err := s.ndClient.GetSecurityAssociations(ctx, fabricName)
+	if err != nil {
+		return result, err
+	}
+	haveAssociation := make(map[string]bool, len(existingAssociations))
+	for _, a := range existingAssociations {
+		haveAssociation[snapshotAssociationKey(a)] = true
+	}
+	var associations []ndclient.ContractAssociation
+	for _, a := range snapshot.Associations {
+		if id, ok := groupIDs[a.SrcGroupName]; ok {
+			a.SrcGroupID = &id
+		}
+		if id, ok := groupIDs[a.DstGroupName]; ok {
+			a.DstGroupID = &id
+		}
+		if haveAssociation[snapshotAssociationKey(a)] {
+			result.AssociationsSkipped++
+			continue
+		}
+		associations = append(associations, a)
+	}
+	created, skipped, err = createSnapshotObjects(ctx, associations, func(ctx context.Context, associations []ndclient.ContractAssociation) error {
+		_, err := s.ndClient.CreateContractAssociations(ctx, fabricName, associations)
+		return err
+	})
+	result.AssociationsCreated, result.AssociationsSkipped = created, result.AssociationsSkipped+skipped
+	if err != nil {
+		return result, fmt.Errorf("import contract associations: %w", err)
+	}
+
+	if err := s.ndClient.ConfigDeploy(ctx, fabricName, nil); err != nil {
+		return result, fmt.Errorf("deploy imported security configuration: %w", err)
+	}
+	result.Deployed = true
+
+	logger.Info("Imported security snapshot",
+		zap.String("fabric", fabricName),
+		zap.String("source_fabric", snapshot.SourceFabric),
+		zap.Int("groups", result.GroupsCreated),
+		zap.Int("contracts", result.ContractsCreated),
+		zap.Int("associations", result.AssociationsCreated))
+	return result, nil
+}
+
+// createSnapshotObjects creates objects in one batch request. A conflict from NDFC means some
+// of them exist after all (e.g. created since the fabric was listed), so each object is then
+// created on its own and those NDFC reports a conflict for are counted as skipped.
+func createSnapshotObjects[T any](ctx context.Context, objects []T, create func(context.Context, []T) error) (created, skipped int, err error) {
+	if len(objects) == 0 {
+		return 0, 0, nil
+	}
+	err = create(ctx, objects)
+	if err == nil {
+		return len(objects), 0, nil
+	}
+	if !ndclient.IsConflictError(err) {
+		return 0, 0, err
+	}
+	for _, object := range objects {
+		err := create(ctx, []T{object})
+		switch {
+		case err == nil:
+			created++
+		case ndclient.IsConflictError(err):
+			skipped++
+		default:
+			return created, skipped, err
+		}
+	}
+	return created, skipped, nil
+}
+
+// snapshotAssociationKey identifies an association by VRF, source and destination group, and
+// contract. Groups are compared by name, or by ID when the name is missing.
+func snapshotAssociationKey(a ndclient.ContractAssociation) string {
+	group := func(name string, id *int) string {
+		if name != "" || id == nil {
+			return name
+		}
+		return "#" + strconv.Itoa(*id)
+	}
+	return a.VRFName + "|" + group(a.SrcGroupName, a.SrcGroupID) + "|" + group(a.DstGroupName, a.DstGroupID) + "|" + a.ContractName
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// securityStoreNDFC keeps security groups, contracts and associations per fabric, answering
+// the list and batch create endpoints, and counts config-deploys per fabric
+type securityStoreNDFC struct {
+	mu           sync.Mutex
+	groups       map[string][]ndclient.SecurityGroup
+	contracts    map[string][]ndclient.SecurityContract
+	associations map[string][]ndclient.ContractAssociation
+	deploys      map[string]int
+}
+
+func newSecurityStoreNDFC() *securityStoreNDFC {
+	return &securityStoreNDFC{
+		groups:       make(map[string][]ndclient.SecurityGroup),
+		contracts:    make(map[string][]ndclient.SecurityContract),
+		associations: make(map[string][]ndclient.ContractAssociation),
+		deploys:      make(map[string]int),
+	}
+}
+
+func (f *securityStoreNDFC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[len(parts)-1] == "config-deploy" {
+		f.deploys[parts[len(parts)-2]]++
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+	fabric, kind := parts[len(parts)-2], parts[len(parts)-1]
+
+	var out any
+	switch {
+	case r.Method == http.MethodGet && kind == "groups":
+		out = f.groups[fabric]
+	case r.Method == http.MethodGet && kind == "contracts":
+		out = f.contracts[fabric]
+	case r.Method == http.MethodGet && kind == "contractAssociations":
+		out = f.associations[fabric]
+	case r.Method == http.MethodPost && kind == "groups":
+		var groups []ndclient.SecurityGroup
+		_ = json.NewDecoder(r.Body).Decode(&groups)
+		for i := range groups {
+			if groups[i].GroupID == nil {
+				id := 1000 + len(f.groups[fabric]) + i
+				groups[i].GroupID = &id
+			}
+		}
+		f.groups[fabric] = append(f.groups[fabric], groups...)
+		out = ndclient.BatchResponseGroups{SuccessList: groups}
+	case r.Method == http.MethodPost && kind == "contracts":
+		var contracts []ndclient.SecurityContract
+		_ = json.NewDecoder(r.Body).Decode(&contracts)
+		f.contracts[fabric] = append(f.contracts[fabric], contracts...)
+		out = ndclient.BatchResponseContracts{SuccessList: contracts}
+	case r.Method == http.MethodPost && kind == "contractAssociations":
+		var associations []ndclient.ContractAssociation
+		_ = json.NewDecoder(r.Body).Decode(&associations)
+		f.associations[fabric] = append(f.associations[fabric], associations...)
+		out = ndclient.BatchResponseAssociations{SuccessList: associations}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		out = map[string]string{"message": "not found"}
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func TestSecuritySnapshot_RoundTrip(t *testing.T) {
+	fake := newSecurityStoreNDFC()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc := NewSecurityGroupService(nil, client)
+
+	web, db := 100, 101
+	fake.groups["src"] = []ndclient.SecurityGroup{
+		{FabricName: "src", GroupID: &web, GroupName: "web", Attach: true,
+			NetworkPortSelectors: []ndclient.NetworkPortSelector{{Network: "net1", SwitchID: "SN1", InterfaceName: "Ethernet1/1"}}},
+		{FabricName: "src", GroupID: &db, GroupName: "db", Attach: true,
+			IPSelectors: []ndclient.IPSelector{{Type: ndclient.IPSelectorTypeConnectedEndpoints, IP: "10.0.0.5", VRFName: "vrf1"}}},
+	}
+	fake.contracts["src"] = []ndclient.SecurityContract{
+		{ContractName: "web-to-db", Rules: []ndclient.ContractRule{{Direction: "bidirectional", Action: "permit", ProtocolName: "default"}}},
+	}
+	fake.associations["src"] = []ndclient.ContractAssociation{
+		{FabricName: "src", VRFName: "vrf1", SrcGroupID: &web, DstGroupID: &db, SrcGroupName: "web", DstGroupName: "db", ContractName: "web-to-db", Attach: true},
+	}
+
+	data, err := svc.ExportSnapshot(context.Background(), "src")
+	if err != nil {
+		t.Fatalf("ExportSnapshot: %v", err)
+	}
+	snapshot, err := ndclient.UnmarshalSecuritySnapshot(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSecuritySnapshot: %v\n%s", err, data)
+	}
+	result, err := svc.ImportSnapshot(context.Background(), "dst", snapshot)
+	if err != nil {
+		t.Fatalf("ImportSnapshot: %v", err)
+	}
+	if result.GroupsCreated != 2 || result.ContractsCreated != 1 || result.AssociationsCreated != 1 || !result.Deployed {
+		t.Errorf("result = %+v, want everything created and deployed", result)
+	}
+
+	// The target fabric ends up with the source's objects, apart from the fabric name and
+	// group IDs
+	withoutFabric := func(groups []ndclient.SecurityGroup, associations []ndclient.ContractAssociation) ([]ndclient.SecurityGroup, []ndclient.ContractAssociation) {
+		g := make(map[string]ndclient.SecurityGroup)
+		for _, group := range groups {
+			group.FabricName = ""
+			group.GroupID = nil // Allocated by the target fabric
+			g[group.GroupName] = group
+		}
+		a := make([]ndclient.ContractAssociation, len(associations))
+		for i, association := range associations {
+			association.FabricName = ""
+			association.SrcGroupID, association.DstGroupID = nil, nil
+			a[i] = association
+		}
+		return []ndclient.SecurityGroup{g["db"], g["web"]}, a
+	}
+	srcGroups, srcAssociations := withoutFabric(fake.groups["src"], fake.associations["src"])
+	dstGroups, dstAssociations := withoutFabric(fake.groups["dst"], fake.associations["dst"])
+	if !reflect.DeepEqual(srcGroups, dstGroups) {
+		t.Errorf("groups differ:\nsrc %+v\ndst %+v", srcGroups, dstGroups)
+	}
+	if !reflect.DeepEqual(fake.contracts["src"], fake.contracts["dst"]) {
+		t.Errorf("contracts differ:\nsrc %+v\ndst %+v", fake.contracts["src"], fake.contracts["dst"])
+	}
+	if !reflect.DeepEqual(srcAssociations, dstAssociations) {
+		t.Errorf("associations differ:\nsrc %+v\ndst %+v", srcAssociations, dstAssociations)
+	}
+
+	// Importing again creates nothing new
+	result, err = svc.ImportSnapshot(context.Background(), "dst", snapshot)
+	if err != nil {
+		t.Fatalf("second ImportSnapshot: %v", err)
+	}
+	if result.GroupsCreated+result.ContractsCreated+result.AssociationsCreated != 0 ||
+		result.GroupsSkipped != 2 || result.ContractsSkipped != 1 || result.AssociationsSkipped != 1 {
+		t.Errorf("second import = %+v, want everything skipped", result)
+	}
+	if len(fake.groups["dst"]) != 2 || len(fake.associations["dst"]) != 1 || fake.deploys["dst"] != 2 {
+		t.Errorf("dst after second import: %d groups, %d associations, %d deploys",
+			len(fake.groups["dst"]), len(fake.associations["dst"]), fake.deploys["dst"])
+	}
+}
+
+func TestImportSnapshot_ResolvesGroupIDsByName(t *testing.T) {
+	fake := newSecurityStoreNDFC()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc := NewSecurityGroupService(nil, client)
+
+	// The target already has "db" under another ID
+	srcWeb, srcDB, dstDB := 100, 101, 555
+	fake.groups["dst"] = []ndclient.SecurityGroup{{GroupID: &dstDB, GroupName: "db", Attach: true}}
+	snapshot := &ndclient.SecuritySnapshot{
+		Version: ndclient.SecuritySnapshotVersion,
+		Groups: []ndclient.SecurityGroup{
+			{GroupID: &srcWeb, GroupName: "web", Attach: true},
+			{GroupID: &srcDB, GroupName: "db", Attach: true},
+		},
+		Associations: []ndclient.ContractAssociation{
+			{VRFName: "vrf1", SrcGroupID: &srcWeb, DstGroupID: &srcDB, SrcGroupName: "web", DstGroupName: "db", ContractName: "c1", Attach: true},
+		},
+	}
+
+	result, err := svc.ImportSnapshot(context.Background(), "dst", snapshot)
+	if err != nil {
+		t.Fatalf("ImportSnapshot: %v", err)
+	}
+	if result.GroupsCreated != 1 || result.GroupsSkipped != 1 {
+		t.Errorf("result = %+v, want web created and db skipped", result)
+	}
+	var webID *int
+	for _, g := range fake.groups["dst"] {
+		if g.GroupName == "web" {
+			webID = g.GroupID
+		}
+	}
+	if webID == nil || *webID == srcWeb {
+		t.Fatalf("web group ID = %v, want one allocated by the target fabric", webID)
+	}
+	a := fake.associations["dst"]
+	if len(a) != 1 || *a[0].SrcGroupID != *webID || *a[0].DstGroupID != dstDB {
+		t.Errorf("associations = %+v, want web resolved to %d and db to %d", a, *webID, dstDB)
+	}
+}
+
+func TestExportSnapshot_SkipsJobGroups(t *testing.T) {
+	fake := newSecurityStoreNDFC()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	svc := NewSecurityGroupService(nil, client)
+
+	web, job := 100, 200
+	fake.groups["src"] = []ndclient.SecurityGroup{
+		{GroupID: &web, GroupName: "web", Attach: true},
+		{GroupID: &job, GroupName: "job-1001", Attach: true},
+	}
+	fake.associations["src"] = []ndclient.ContractAssociation{
+		{VRFName: "vrf1", SrcGroupID: &job, DstGroupID: &web, SrcGroupName: "job-1001", DstGroupName: "web", ContractName: "c1", Attach: true},
+	}
+
+	data, err := svc.ExportSnapshot(context.Background(), "src")
+	if err != nil {
+		t.Fatalf("ExportSnapshot: %v", err)
+	}
+	snapshot, err := ndclient.UnmarshalSecuritySnapshot(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSecuritySnapshot: %v", err)
+	}
+	if len(snapshot.Groups) != 1 || snapshot.Groups[0].GroupName != "web" || len(snapshot.Associations) != 0 {
+		t.Errorf("snapshot = %+v, want only the web group", snapshot)
+	}
+}
+
+func TestCreateSnapshotObjects_CountsConflictsPerObject(t *testing.T) {
+	exists := map[string]bool{"b": true}
+	var calls int
+	create := func(_ context.Context, names []string) error {
+		calls++
+		for _, name := range names {
+			if exists[name] {
+				return &ndclient.APIError{StatusCode: http.StatusConflict}
+			}
+		}
+		return nil
+	}
+
+	created, skipped, err := createSnapshotObjects(context.Background(), []string{"a", "b", "c"}, create)
+	if err != nil {
+		t.Fatalf("createSnapshotObjects: %v", err)
+	}
+	if created != 2 || skipped != 1 || calls != 4 {
+		t.Errorf("created %d, skipped %d in %d calls; want 2, 1 in 4 (batch, then one per object)", created, skipped, calls)
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Shared contract association creation
+const (
+	sharedAssocMaxConcurrent = 3           // Concurrent CreateSecurityAssociation calls
+	sharedAssocMaxRetries    = 3           // Retries of the associations that failed the first attempt
+	sharedAssocRetryBackoff  = time.Second // Wait before retry n is n * backoff
+)
+
+// retryableAssociationCreate creates the shared contract associations of a job, up to
+// sharedAssocMaxConcurrent at a time. Associations that fail are retried up to
+// sharedAssocMaxRetries times; ones that succeeded are never sent again. A conflict means
+// the association already exists and counts as created.
+//
+// Created associations are recorded in Valkey (cache.SharedAssociation) so a retried
+// provisioning of the same Slurm job skips them; deprovisioning forgets them. The returned
+// error joins the last error of each association that still failed.
+func (s *JobService) retryableAssociationCreate(ctx context.Context, fabricName, slurmJobID string, associations []ndclient.ContractAssociation) error {
+	pending := make([]int, 0, len(associations))
+	for i := range associations {
+		if s.sharedAssociationCreated(ctx, fabricName, slurmJobID, &associations[i]) {
+			logger.Debug("Shared contract association already created, skipping",
+				zap.String("slurm_job_id", slurmJobID),
+				zap.String("dst_group", associations[i].DstGroupName))
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	var created sync.Map // association index -> struct{}
+	var mu sync.Mutex
+	lastErr := make(map[int]error)
+
+	for attempt := 0; len(pending) > 0 && attempt <= sharedAssocMaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * s.assocRetryBackoff):
+			}
+			logger.Info("Retrying failed shared contract associations",
+				zap.String("slurm_job_id", slurmJobID),
+				zap.Int("attempt", attempt),
+				zap.Int("count", len(pending)))
+		}
+
+		var g errgroup.Group
+		g.SetLimit(sharedAssocMaxConcurrent)
+		for _, i := range pending {
+			g.Go(func() error {
+				association := associations[i]
+				_, err := s.ndClient.CreateSecurityAssociation(ctx, fabricName, &association)
+				if err != nil && !ndclient.IsConflictError(err) {
+					mu.Lock()
+					lastErr[i] = err
+					mu.Unlock()
+					return nil
+				}
+				created.Store(i, struct{}{})
+				s.markSharedAssociationCreated(ctx, fabricName, slurmJobID, &association)
+				if err == nil {
+					logger.Info("Created shared contract association",
+						zap.String("src_group", association.SrcGroupName),
+						zap.String("dst_group", association.DstGroupName),
+						zap.String("contract", association.ContractName))
+				}
+				return nil
+			})
+		}
+		_ = g.Wait()
+
+		failed := pending[:0]
+		for _, i := range pending {
+			if _, ok := created.Load(i); !ok {
+				failed = append(failed, i)
+			}
+		}
+		pending = failed
+	}
+
+	var errs []error
+	for _, i := range pending {
+		errs = append(errs, fmt.Errorf("shared association to %s (contract %s): %w",
+			associations[i].DstGroupName, associations[i].ContractName, lastErr[i]))
+	}
+	return errors.Join(errs...)
+}
+
+// sharedAssociationKey returns the Valkey key recording a Slurm job's shared association
+func sharedAssociationKey(fabricName, slurmJobID string, association *ndclient.ContractAssociation) string {
+	return cache.SharedAssociation(fabricName, slurmJobID, association.SrcGroupName, association.DstGroupName, association.ContractName)
+}
+
+// sharedAssociationCreated reports whether a previous attempt recorded the Slurm job's shared
+// association as created. Without Valkey, or if it fails, nothing is skipped.
+func (s *JobService) sharedAssociationCreated(ctx context.Context, fabricName, slurmJobID string, association *ndclient.ContractAssociation) bool {
+	if s.associations == nil {
+		return false
+	}
+	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	defer cancel()
+	v, err := s.associations.GetString(cacheCtx, sharedAssociationKey(fabricName, slurmJobID, association))
+	return err == nil && v != ""
+}
+
+// markSharedAssociationCreated records the Slurm job's shared association as created
+func (s *JobService) markSharedAssociationCreated(ctx context.Context, fabricName, slurmJobID string, association *ndclient.ContractAssociation) {
+	if s.associations == nil {
+		return
+	}
+	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	defer cancel()
+	if err := s.associations.SetString(cacheCtx, sharedAssociationKey(fabricName, slurmJobID, association), "1", cache.TTLSharedAssociation); err != nil {
+		logger.Warn("Failed to record shared contract association",
+			zap.String("slurm_job_id", slurmJobID),
+			zap.String("dst_group", association.DstGroupName),
+			zap.Error(err))
+	}
+}
+
+// forgetSharedAssociation removes the record of a Slurm job's shared association once it is
+// deleted, so provisioning the job again creates it
+func (s *JobService) forgetSharedAssociation(ctx context.Context, fabricName, slurmJobID string, association *ndclient.ContractAssociation) {
+	if s.associations == nil {
+		return
+	}
+	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	defer cancel()
+	if err := s.associations.Delete(cacheCtx, sharedAssociationKey(fabricName, slurmJobID, association)); err != nil {
+		logger.Warn("Failed to forget shared contract association",
+			zap.String("slurm_job_id", slurmJobID),
+			zap.String("dst_group", association.DstGroupName),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// flakyAssociationNDFC counts association creates per destination group. Creates to a group
+// in failures fail with HTTP 500 that many times before succeeding; groups in conflicts
+// always answer 409.
+type flakyAssociationNDFC struct {
+	failures  map[string]int
+	conflicts map[string]bool
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *flakyAssociationNDFC) serve(t *testing.T) *ndclient.Client {
+	t.Helper()
+	f.calls = make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/contractAssociations") {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		var associations []ndclient.ContractAssociation
+		if err := json.NewDecoder(r.Body).Decode(&associations); err != nil || len(associations) != 1 {
+			t.Errorf("decode associations: %v (%d)", err, len(associations))
+			return
+		}
+		dst := associations[0].DstGroupName
+
+		f.mu.Lock()
+		f.calls[dst]++
+		n := f.calls[dst]
+		f.mu.Unlock()
+
+		switch {
+		case f.conflicts[dst]:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"already exists"}`))
+		case n <= f.failures[dst]:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"transient"}`))
+		default:
+			_ = json.NewEncoder(w).Encode(ndclient.BatchResponseAssociations{SuccessList: associations})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client
+}
+
+func sharedAssociations(dstGroups ...string) []ndclient.ContractAssociation {
+	src := 42
+	out := make([]ndclient.ContractAssociation, len(dstGroups))
+	for i, dst := range dstGroups {
+		dstID := 100 + i
+		out[i] = ndclient.ContractAssociation{
+			FabricName:   "f1",
+			VRFName:      "vrf1",
+			SrcGroupID:   &src,
+			SrcGroupName: "job-group",
+			DstGroupID:   &dstID,
+			DstGroupName: dst,
+			ContractName: "contract-" + dst,
+			Attach:       true,
+		}
+	}
+	return out
+}
+
+func newAssociationTestService(t *testing.T, fake *flakyAssociationNDFC) (*JobService, cache.CacheClient) {
+	t.Helper()
+	store := cachetest.NewInMemoryCache()
+	svc := NewJobService(nil, fake.serve(t), &config.NexusDashboardConfig{}, nil)
+	svc.associations = store
+	svc.assocRetryBackoff = 0
+	return svc, store
+}
+
+func TestRetryableAssociationCreate_RetriesOnlyFailed(t *testing.T) {
+	fake := &flakyAssociationNDFC{
+		failures:  map[string]int{"SG_DNS": 2},
+		conflicts: map[string]bool{"SG_SIEM": true},
+	}
+	svc, store := newAssociationTestService(t, fake)
+
+	err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", sharedAssociations("SG_AD", "SG_DNS", "SG_SIEM"))
+	if err != nil {
+		t.Fatalf("retryableAssociationCreate: %v", err)
+	}
+
+	// SG_AD and the conflicting SG_SIEM are sent once; SG_DNS fails twice, then succeeds
+	want := map[string]int{"SG_AD": 1, "SG_DNS": 3, "SG_SIEM": 1}
+	for dst, n := range want {
+		if fake.calls[dst] != n {
+			t.Errorf("creates of %s = %d, want %d", dst, fake.calls[dst], n)
+		}
+	}
+	for dst := range want {
+		if v, err := store.GetString(context.Background(), cache.SharedAssociation("f1", "job-1", "job-group", dst, "contract-"+dst)); err != nil || v == "" {
+			t.Errorf("%s not recorded as created: %q, %v", dst, v, err)
+		}
+	}
+}
+
+func TestRetryableAssociationCreate_GivesUp(t *testing.T) {
+	fake := &flakyAssociationNDFC{failures: map[string]int{"SG_DNS": 100}}
+	svc, store := newAssociationTestService(t, fake)
+
+	err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", sharedAssociations("SG_AD", "SG_DNS"))
+	if err == nil || !strings.Contains(err.Error(), "SG_DNS") {
+		t.Fatalf("expected an error naming SG_DNS, got %v", err)
+	}
+	if fake.calls["SG_DNS"] != 1+sharedAssocMaxRetries {
+		t.Errorf("creates of SG_DNS = %d, want %d", fake.calls["SG_DNS"], 1+sharedAssocMaxRetries)
+	}
+	if fake.calls["SG_AD"] != 1 {
+		t.Errorf("creates of SG_AD = %d, want 1", fake.calls["SG_AD"])
+	}
+	if _, err := store.GetString(context.Background(), cache.SharedAssociation("f1", "job-1", "job-group", "SG_DNS", "contract-SG_DNS")); err == nil {
+		t.Error("failed association recorded as created")
+	}
+}
+
+func TestRetryableAssociationCreate_SkipsRecordedOnRetry(t *testing.T) {
+	fake := &flakyAssociationNDFC{failures: map[string]int{"SG_DNS": 100}}
+	svc, _ := newAssociationTestService(t, fake)
+	associations := sharedAssociations("SG_AD", "SG_DNS")
+
+	// First provisioning attempt: SG_AD is created, SG_DNS keeps failing
+	if err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", associations); err == nil {
+		t.Fatal("expected SG_DNS to fail")
+	}
+
+	// Retried provisioning: SG_DNS recovers, SG_AD is not sent again
+	fake.failures = nil
+	fake.calls = make(map[string]int)
+	if err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", associations); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if fake.calls["SG_AD"] != 0 || fake.calls["SG_DNS"] != 1 {
+		t.Errorf("creates on retry = %v, want only SG_DNS once", fake.calls)
+	}
+}
+
+func TestForgetSharedAssociation_RecreatesOnNextProvision(t *testing.T) {
+	fake := &flakyAssociationNDFC{}
+	svc, _ := newAssociationTestService(t, fake)
+	associations := sharedAssociations("SG_AD")
+
+	if err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", associations); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Deprovisioning forgets the association, so provisioning the job again recreates it
+	svc.forgetSharedAssociation(context.Background(), "f1", "job-1", &associations[0])
+	if err := svc.retryableAssociationCreate(context.Background(), "f1", "job-1", associations); err != nil {
+		t.Fatalf("recreate: %v", err)
+	}
+	if fake.calls["SG_AD"] != 2 {
+		t.Errorf("creates of SG_AD = %d, want 2", fake.calls["SG_AD"])
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/logger"
+	"go.uber.org/zap"
+)
+
+// SharedContractAssociation defines a common contract association that every job should have
+type SharedContractAssociation struct {
+	DstGroupName string `json:"dst_group_name"` // Destination security group name (e.g., "ActiveDirectory")
+	ContractName string `json:"contract_name"`  // Contract name to use (e.g., "matchAD")
+}
+
+// DefaultSharedContracts is the list of common contract associations applied to every job
+// when ND_SHARED_CONTRACTS is not set
+var DefaultSharedContracts = []SharedContractAssociation{
+	{DstGroupName: "SG_AD", ContractName: "matchAD"},
+}
+
+// DefaultStorageSharedContracts is the list of common contract associations applied to every
+// storage SG when ND_STORAGE_SHARED_CONTRACTS is not set.
+// These provide always-on access to shared services (DNS, AD, SIEM, etc.)
+var DefaultStorageSharedContracts = []SharedContractAssociation{
+	{DstGroupName: "SG_AD", ContractName: "AD"},
+	{DstGroupName: "SG_DNS", ContractName: "DNS"},
+}
+
+// SharedContractRegistry holds a list of shared contract associations that can be
+// replaced at runtime (e.g., on SIGHUP) while provisioning goroutines read it.
+//
+// Load and Store are atomic, but a provisioning run that calls Load more than once
+// may see the old list on one call and the new list on the next. Callers that need
+// a consistent view for a whole operation should Load once and reuse the slice.
+type SharedContractRegistry struct {
+	contracts atomic.Pointer[[]SharedContractAssociation]
+}
+
+// NewSharedContractRegistry creates a registry holding a copy of contracts
+func NewSharedContractRegistry(contracts []SharedContractAssociation) *SharedContractRegistry {
+	r := &SharedContractRegistry{}
+	r.Store(contracts)
+	return r
+}
+
+// Load returns the current list. The returned slice must not be modified.
+func (r *SharedContractRegistry) Load() []SharedContractAssociation {
+	if p := r.contracts.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Store atomically replaces the list with a copy of contracts
+func (r *SharedContractRegistry) Store(contracts []SharedContractAssociation) {
+	cp := make([]SharedContractAssociation, len(contracts))
+	copy(cp, contracts)
+	r.contracts.Store(&cp)
+}
+
+// Registry holds the hot-reloadable shared contract lists used by JobService and StorageService
+type Registry struct {
+	SharedContracts        *SharedContractRegistry // Applied to every job SG
+	StorageSharedContracts *SharedContractRegistry // Applied to every storage SG
+}
+
+// NewRegistry creates a Registry from configuration, falling back to the defaults
+// for lists that are unset or invalid
+func NewRegistry(cfg *config.NexusDashboardConfig) *Registry {
+	r := &Registry{
+		SharedContracts:        NewSharedContractRegistry(DefaultSharedContracts),
+		StorageSharedContracts: NewSharedContractRegistry(DefaultStorageSharedContracts),
+	}
+	if cfg != nil {
+		if err := r.Reload(cfg); err != nil {
+			logger.Warn("Invalid shared contract configuration, using defaults", zap.Error(err))
+		}
+	}
+	return r
+}
+
+// Reload replaces both lists from configuration. Unset values restore the defaults.
+// If either value fails to parse, neither list is changed.
+func (r *Registry) Reload(cfg *config.NexusDashboardConfig) error {
+	jobContracts, err := parseSharedContractsOrDefault(cfg.SharedContracts, DefaultSharedContracts)
+	if err != nil {
+		return fmt.Errorf("ND_SHARED_CONTRACTS: %w", err)
+	}
+	storageContracts, err := parseSharedContractsOrDefault(cfg.StorageSharedContracts, DefaultStorageSharedContracts)
+	if err != nil {
+		return fmt.Errorf("ND_STORAGE_SHARED_CONTRACTS: %w", err)
+	}
+
+	r.SharedContracts.Store(jobContracts)
+	r.StorageSharedContracts.Store(storageContracts)
+	return nil
+}
+
+// ReloadOnSIGHUP reloads the registry from configuration each time the process receives SIGHUP.
+// load is called to re-read configuration (e.g., config.Reload). Returns a function that stops watching.
+func (r *Registry) ReloadOnSIGHUP(load func() *config.Config) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-sigCh:
+			}
+
+			cfg := load()
+			if err := r.Reload(&cfg.NexusDashboard); err != nil {
+				logger.Error("Shared contract reload failed, keeping current lists", zap.Error(err))
+				continue
+			}
+			logger.Info("Shared contracts reloaded",
+				zap.Int("job_contracts", len(r.SharedContracts.Load())),
+				zap.Int("storage_contracts", len(r.StorageSharedContracts.Load())))
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// ParseSharedContracts parses a comma-separated list of "dstGroup:contract" pairs,
+// e.g. "SG_AD:matchAD,SG_DNS:DNS"
+func ParseSharedContracts(s string) ([]SharedContractAssociation, error) {
+	var out []SharedContractAssociation
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		group, contract, ok := strings.Cut(entry, ":")
+		group, contract = strings.TrimSpace(group), strings.TrimSpace(contract)
+		if !ok || group == "" || contract == "" {
+			return nil, fmt.Errorf("invalid shared contract %q (expected dstGroup:contract)", entry)
+		}
+		out = append(out, SharedContractAssociation{DstGroupName: group, ContractName: contract})
+	}
+	return out, nil
+}
+
+func parseSharedContractsOrDefault(s string, defaults []SharedContractAssociation) ([]SharedContractAssociation, error) {
+	if strings.TrimSpace(s) == "" {
+		return defaults, nil
+	}
+	return ParseSharedContracts(s)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/config"
+)
+
+func TestParseSharedContracts(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []SharedContractAssociation
+		wantErr bool
+	}{
+		{name: "single", in: "SG_AD:matchAD", want: []SharedContractAssociation{{DstGroupName: "SG_AD", ContractName: "matchAD"}}},
+		{name: "multiple with spaces", in: " SG_AD:AD , SG_DNS : DNS ", want: []SharedContractAssociation{
+			{DstGroupName: "SG_AD", ContractName: "AD"},
+			{DstGroupName: "SG_DNS", ContractName: "DNS"},
+		}},
+		{name: "empty entries skipped", in: ",", want: nil},
+		{name: "missing contract", in: "SG_AD:", wantErr: true},
+		{name: "missing separator", in: "SG_AD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSharedContracts(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSharedContracts(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSharedContracts(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Reload(t *testing.T) {
+	r := NewRegistry(&config.NexusDashboardConfig{})
+	if !reflect.DeepEqual(r.SharedContracts.Load(), DefaultSharedContracts) {
+		t.Errorf("expected default job contracts, got %+v", r.SharedContracts.Load())
+	}
+	if !reflect.DeepEqual(r.StorageSharedContracts.Load(), DefaultStorageSharedContracts) {
+		t.Errorf("expected default storage contracts, got %+v", r.StorageSharedContracts.Load())
+	}
+
+	if err := r.Reload(&config.NexusDashboardConfig{SharedContracts: "SG_SIEM:siem"}); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	want := []SharedContractAssociation{{DstGroupName: "SG_SIEM", ContractName: "siem"}}
+	if got := r.SharedContracts.Load(); !reflect.DeepEqual(got, want) {
+		t.Errorf("job contracts = %+v, want %+v", got, want)
+	}
+
+	// Invalid config leaves both lists unchanged
+	if err := r.Reload(&config.NexusDashboardConfig{SharedContracts: "SG_AD:AD", StorageSharedContracts: "bad"}); err == nil {
+		t.Fatal("expected error for invalid storage contracts")
+	}
+	if got := r.SharedContracts.Load(); !reflect.DeepEqual(got, want) {
+		t.Errorf("job contracts changed on failed reload: %+v", got)
+	}
+}
+
+func TestSharedContractRegistry_StoreCopies(t *testing.T) {
+	in := []SharedContractAssociation{{DstGroupName: "SG_AD", ContractName: "AD"}}
+	r := NewSharedContractRegistry(in)
+	in[0].ContractName = "changed"
+	if got := r.Load()[0].ContractName; got != "AD" {
+		t.Errorf("registry affected by caller mutation: %q", got)
+	}
+}
+
+// TestSharedContractRegistry_ConcurrentSwap exercises Load/Store under the race detector
+func TestSharedContractRegistry_ConcurrentSwap(t *testing.T) {
+	r := NewSharedContractRegistry(DefaultSharedContracts)
+	lists := [][]SharedContractAssociation{DefaultSharedContracts, DefaultStorageSharedContracts}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				r.Store(lists[(i+j)%2])
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				for _, c := range r.Load() {
+					if c.DstGroupName == "" || c.ContractName == "" {
+						t.Error("observed partially written contract")
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -17,26 +17,25 @@ import (
 	"gorm.io/gorm/clause"
 )
 
-// StorageSharedContracts is the list of common contract associations applied to every storage SG
-// These provide always-on access to shared services (DNS, AD, SIEM, etc.)
-var StorageSharedContracts = []SharedContractAssociation{
-	{DstGroupName: "SG_AD", ContractName: "AD"},
-	{DstGroupName: "SG_DNS", ContractName: "DNS"},
-}
-
 // StorageService handles storage NIC provisioning and per-node storage SG management
 type StorageService struct {
 	db       *gorm.DB
 	ndClient *ndclient.Client
 	cfg      *config.NexusDashboardConfig
+	registry *Registry // Hot-reloadable shared contract lists
 }
 
-// NewStorageService creates a new StorageService
-func NewStorageService(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig) *StorageService {
+// NewStorageService creates a new StorageService.
+// registry may be nil, in which case the default shared contracts are used.
+func NewStorageService(db *gorm.DB, ndClient *ndclient.Client, cfg *config.NexusDashboardConfig, registry *Registry) *StorageService {
+	if registry == nil {
+		registry = NewRegistry(nil)
+	}
 	return &StorageService{
 		db:       db,
 		ndClient: ndClient,
 		cfg:      cfg,
+		registry: registry,
 	}
 }
 
@@ -50,6 +49,11 @@ func (s *StorageService) GetStorageNetworkName() string {
 	return s.cfg.StorageNetworkName
 }
 
+// GetStorageFabricName returns the configured storage fabric name
+func (s *StorageService) GetStorageFabricName() string {
+	return s.cfg.StorageFabricName
+}
+
 // StoragePortInfo holds information about a storage port for provisioning
 type StoragePortInfo struct {
 	SwitchPortID  string
@@ -76,14 +80,7 @@ func (s *StorageService) EnsureNodeStorageSG(ctx context.Context, node *models.C
 	// Build port selectors (only if we have a network name - otherwise just create empty SG)
 	var portSelectors []ndclient.NetworkPortSelector
 	if networkName != "" && len(storagePorts) > 0 {
-		portSelectors = make([]ndclient.NetworkPortSelector, 0, len(storagePorts))
-		for _, p := range storagePorts {
-			portSelectors = append(portSelectors, ndclient.NetworkPortSelector{
-				Network:       networkName,
-				SwitchID:      p.SerialNumber,
-				InterfaceName: p.InterfaceName,
-			})
-		}
+		portSelectors = storagePortSelectors(storagePorts, networkName)
 	}
 
 	// Check if SG already exists
@@ -128,7 +125,10 @@ func (s *StorageService) EnsureNodeStorageSG(ctx context.Context, node *models.C
 	}
 
 	// Create new SG
-	groupID := s.generateStorageGroupID(node.Name)
+	groupID, err := s.generateStorageGroupID(node.Name)
+	if err != nil {
+		return 0, err
+	}
 	securityGroup := &ndclient.SecurityGroup{
 		FabricName:           fabricName,
 		GroupID:              &groupID,
@@ -161,14 +161,15 @@ func (s *StorageService) EnsureNodeStorageSG(ctx context.Context, node *models.C
 
 // EnsureStorageSharedAssociations ensures shared-services associations exist for a storage SG
 func (s *StorageService) EnsureStorageSharedAssociations(ctx context.Context, sgName string, sgID int, groupIDMap map[string]int) {
-	if len(StorageSharedContracts) == 0 {
+	sharedContracts := s.registry.StorageSharedContracts.Load()
+	if len(sharedContracts) == 0 {
 		return
 	}
 
 	fabricName := s.cfg.StorageFabricName
 	vrfName := s.cfg.StorageVRFName
 
-	for _, shared := range StorageSharedContracts {
+	for _, shared := range sharedContracts {
 		dstGroupID, found := groupIDMap[shared.DstGroupName]
 		if !found {
 			logger.Warn("Shared service security group not found for storage",
@@ -444,17 +445,7 @@ func (s *StorageService) updateStorageSGNetwork(ctx context.Context, node *model
 		return
 	}
 
-	// Build new selectors with updated network
-	portSelectors := make([]ndclient.NetworkPortSelector, 0, len(storagePorts))
-	for _, p := range storagePorts {
-		portSelectors = append(portSelectors, ndclient.NetworkPortSelector{
-			Network:       networkName,
-			SwitchID:      p.SerialNumber,
-			InterfaceName: p.InterfaceName,
-		})
-	}
-
-	existingGroup.NetworkPortSelectors = portSelectors
+	existingGroup.NetworkPortSelectors = storagePortSelectors(storagePorts, networkName)
 	existingGroup.Attach = true
 
 	if _, err := s.ndClient.UpdateSecurityGroups(ctx, fabricName, []ndclient.SecurityGroup{*existingGroup}); err != nil {
@@ -465,6 +456,36 @@ func (s *StorageService) updateStorageSGNetwork(ctx context.Context, node *model
 	}
 }
 
+// storagePortSelectors returns the SG port selectors placing storagePorts in networkName
+func storagePortSelectors(storagePorts []StoragePortInfo, networkName string) []ndclient.NetworkPortSelector {
+	selectors := make([]ndclient.NetworkPortSelector, 0, len(storagePorts))
+	for _, p := range storagePorts {
+		selectors = append(selectors, ndclient.NetworkPortSelector{
+			Network:       networkName,
+			SwitchID:      p.SerialNumber,
+			InterfaceName: p.InterfaceName,
+		})
+	}
+	return selectors
+}
+
+// storageNetworkForNode returns the storage network a node's ports belong in: its storage
+// tenant's network while it is in an active job with storage access, otherwise the base one
+func (s *StorageService) storageNetworkForNode(ctx context.Context, node *models.ComputeNode) string {
+	var activeAccess models.JobStorageAccess
+	err := s.db.WithContext(ctx).
+		Joins("JOIN jobs ON jobs.id = job_storage_accesses.job_id").
+		Where("job_storage_accesses.compute_node_id = ? AND jobs.status IN ?", node.ID, []string{"active", "provisioning"}).
+		First(&activeAccess).Error
+	if err == nil {
+		var tenant models.StorageTenant
+		if s.db.WithContext(ctx).First(&tenant, "id = ?", activeAccess.StorageTenantID).Error == nil {
+			return tenant.StorageNetworkName
+		}
+	}
+	return s.cfg.StorageNetworkName
+}
+
 // getStoragePortsForNode retrieves storage interface port mappings for a node
 func (s *StorageService) getStoragePortsForNode(ctx context.Context, node *models.ComputeNode) ([]StoragePortInfo, error) {
 	var mappings []models.ComputeNodePortMapping
@@ -500,26 +521,35 @@ func (s *StorageService) getStoragePortsForNode(ctx context.Context, node *model
 
 // generateStorageGroupID generates a group ID for storage SGs
 // Range: 32768-65535 (upper half of valid range, to avoid collision with job SGs)
-func (s *StorageService) generateStorageGroupID(nodeName string) int {
+func (s *StorageService) generateStorageGroupID(nodeName string) (int, error) {
 	var groupID int
 	for _, c := range nodeName {
 		groupID = (groupID*31 + int(c))
 	}
-	// Map to range 32768-65535 (32767 values)
-	return (groupID % 32767) + 32768
+	// Map to range 32768-65535 (32767 values). The hash overflows for names longer than
+	// about 13 characters, so the remainder may be negative.
+	offset := groupID % 32767
+	if offset < 0 {
+		offset += 32767
+	}
+	groupID = offset + 32768
+	if err := ndclient.ValidateGroupID(groupID); err != nil {
+		return 0, fmt.Errorf("generated storage group ID for node %s: %w", nodeName, err)
+	}
+	return groupID, nil
 }
 
 // ReconcileNodeStorageSG ensures a node's storage SG is properly configured
-// Called when node interfaces are updated or on startup
+// Called when node interfaces are updated or on startup. A node without storage ports has
+// the selectors of its existing SG cleared.
 func (s *StorageService) ReconcileNodeStorageSG(ctx context.Context, node *models.ComputeNode) error {
 	if s.ndClient == nil {
 		return nil
 	}
 
 	fabricName := s.cfg.StorageFabricName
-	baseNetworkName := s.cfg.StorageNetworkName
 
-	if fabricName == "" || baseNetworkName == "" {
+	if fabricName == "" || s.cfg.StorageNetworkName == "" {
 		return nil
 	}
 
@@ -529,27 +559,18 @@ func (s *StorageService) ReconcileNodeStorageSG(ctx context.Context, node *model
 	}
 
 	if len(storagePorts) == 0 {
-		// No storage interface - nothing to do
-		return nil
-	}
-
-	// Check if node is currently in a job with storage access
-	var activeAccess models.JobStorageAccess
-	err = s.db.WithContext(ctx).
-		Joins("JOIN jobs ON jobs.id = job_storage_accesses.job_id").
-		Where("job_storage_accesses.compute_node_id = ? AND jobs.status IN ?", node.ID, []string{"active", "provisioning"}).
-		First(&activeAccess).Error
-
-	networkName := baseNetworkName
-	if err == nil {
-		// Node is in an active job - use tenant network from the access record
-		var tenant models.StorageTenant
-		if s.db.WithContext(ctx).First(&tenant, "id = ?", activeAccess.StorageTenantID).Error == nil {
-			networkName = tenant.StorageNetworkName
+		// No storage ports: clear the selectors left on an existing SG (e.g. the storage
+		// interface changed role), otherwise nothing to do
+		existingGroup, err := s.ndClient.GetSecurityGroupByName(ctx, fabricName, storageNodeSGName(node.Name))
+		if err != nil || existingGroup == nil || len(existingGroup.NetworkPortSelectors) == 0 {
+			return nil
 		}
+		_, err = s.EnsureNodeStorageSG(ctx, node, nil, "")
+		return err
 	}
 
 	// Ensure SG exists with correct selectors
+	networkName := s.storageNetworkForNode(ctx, node)
 	sgID, err := s.EnsureNodeStorageSG(ctx, node, storagePorts, networkName)
 	if err != nil {
 		return err
@@ -581,3 +602,124 @@ func (s *StorageService) ReconcileNodeStorageSG(ctx context.Context, node *model
 
 	return nil
 }
+
+// StorageSGBulkResult reports a bulk update of storage SG port selectors
+type StorageSGBulkResult struct {
+	DryRun    bool                        `json:"dry_run"`
+	Updates   []ndclient.SGSelectorUpdate `json:"updates"`           // SGs whose selectors changed
+	Unchanged int                         `json:"unchanged"`         // SGs already up to date
+	Missing   []string                    `json:"missing,omitempty"` // Nodes without a storage SG yet
+	Failed    map[string]string           `json:"failed,omitempty"`  // SG or node name -> error
+}
+
+// ReconcileAllNodeStorageSGs brings the port selectors of every node's storage SG in line
+// with its storage port mappings, sending all changed SGs to NDFC in one bulk update.
+// Missing SGs are created individually; shared associations and local records are left to
+// ReconcileNodeStorageSG. With dryRun the changes are computed but not applied.
+func (s *StorageService) ReconcileAllNodeStorageSGs(ctx context.Context, dryRun bool) (*StorageSGBulkResult, error) {
+	result := &StorageSGBulkResult{DryRun: dryRun, Updates: []ndclient.SGSelectorUpdate{}}
+	if s.ndClient == nil {
+		return result, nil
+	}
+
+	fabricName := s.cfg.StorageFabricName
+	if fabricName == "" || s.cfg.StorageNetworkName == "" {
+		return result, nil
+	}
+
+	var nodes []models.ComputeNode
+	if err := s.db.WithContext(ctx).Find(&nodes).Error; err != nil {
+		return nil, fmt.Errorf("failed to list compute nodes: %w", err)
+	}
+
+	groups, err := s.ndClient.GetSecurityGroups(ctx, fabricName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security groups: %w", err)
+	}
+	groupsByName := make(map[string]ndclient.SecurityGroup, len(groups))
+	for _, g := range groups {
+		groupsByName[g.GroupName] = g
+	}
+
+	failed := func(name string, err error) {
+		if result.Failed == nil {
+			result.Failed = make(map[string]string)
+		}
+		result.Failed[name] = err.Error()
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		storagePorts, err := s.getStoragePortsForNode(ctx, node)
+		if err != nil {
+			failed(node.Name, err)
+			continue
+		}
+		if len(storagePorts) == 0 {
+			continue
+		}
+
+		networkName := s.storageNetworkForNode(ctx, node)
+		sgName := storageNodeSGName(node.Name)
+		existing, found := groupsByName[sgName]
+		if !found || existing.GroupID == nil {
+			result.Missing = append(result.Missing, node.Name)
+			if !dryRun {
+				if _, err := s.EnsureNodeStorageSG(ctx, node, storagePorts, networkName); err != nil {
+					failed(node.Name, err)
+				}
+			}
+			continue
+		}
+
+		selectors := storagePortSelectors(storagePorts, networkName)
+		if existing.Attach && sameSelectors(existing.NetworkPortSelectors, selectors) {
+			result.Unchanged++
+			continue
+		}
+		result.Updates = append(result.Updates, ndclient.SGSelectorUpdate{
+			GroupID:   *existing.GroupID,
+			GroupName: sgName,
+			Selectors: selectors,
+		})
+	}
+
+	if dryRun || len(result.Updates) == 0 {
+		return result, nil
+	}
+
+	errs := s.ndClient.BulkUpdateSecurityGroupSelectors(ctx, fabricName, result.Updates)
+	updateFailures := 0
+	for i, err := range errs {
+		if err != nil {
+			failed(result.Updates[i].GroupName, err)
+			updateFailures++
+		}
+	}
+	logger.Info("Bulk updated storage SG selectors",
+		zap.Int("updated", len(result.Updates)-updateFailures),
+		zap.Int("failed", updateFailures),
+		zap.Int("unchanged", result.Unchanged))
+	return result, nil
+}
+
+// sameSelectors reports whether a and b hold the same port selectors in any order
+func sameSelectors(a, b []ndclient.NetworkPortSelector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	key := func(p ndclient.NetworkPortSelector) string {
+		return p.Network + "|" + p.SwitchID + "|" + p.InterfaceName
+	}
+	seen := make(map[string]int, len(a))
+	for _, p := range a {
+		seen[key(p)]++
+	}
+	for _, p := range b {
+		if seen[key(p)] == 0 {
+			return false
+		}
+		seen[key(p)]--
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// Switch update errors
+var (
+	ErrSwitchNotFound       = errors.New("switch not found")
+	ErrImmutableSwitchField = errors.New("switch field is immutable")
+	ErrInvalidSwitchUpdate  = errors.New("invalid switch update")
+)
+
+// Locally overridable switch fields, named as in the API
+const (
+	SwitchFieldName      = "name"
+	SwitchFieldModel     = "model"
+	SwitchFieldIPAddress = "ip_address"
+)
+
+// immutableSwitchFields identify the switch in NDFC and can only change through sync
+var immutableSwitchFields = map[string]bool{"id": true, "serial_number": true, "fabric_id": true}
+
+// SwitchUpdate describes a partial update of a switch's local metadata
+type SwitchUpdate struct {
+	Name      string
+	Model     string
+	IPAddress string
+	// Fields is the field mask. Empty updates every non-empty field above;
+	// otherwise exactly the listed fields are written, allowing model and ip_address to be cleared.
+	Fields []string
+	// UpdatePortDescriptions replaces the old switch name in port descriptions across the fabric on rename
+	UpdatePortDescriptions bool
+}
+
+// SwitchUpdateResult describes the outcome of UpdateSwitch
+type SwitchUpdateResult struct {
+	Switch                  *models.Switch `json:"switch"`
+	UpdatedPortDescriptions int64          `json:"updated_port_descriptions"`
+}
+
+// fieldValues resolves the field mask into the column updates to apply
+func (u SwitchUpdate) fieldValues() (map[string]interface{}, error) {
+	values := map[string]string{
+		SwitchFieldName:      u.Name,
+		SwitchFieldModel:     u.Model,
+		SwitchFieldIPAddress: u.IPAddress,
+	}
+
+	updates := make(map[string]interface{})
+	if len(u.Fields) == 0 {
+		for field, value := range values {
+			if value != "" {
+				updates[field] = value
+			}
+		}
+	} else {
+		for _, field := range u.Fields {
+			if immutableSwitchFields[field] {
+				return nil, fmt.Errorf("%w: %s", ErrImmutableSwitchField, field)
+			}
+			value, ok := values[field]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSwitchUpdate, field)
+			}
+			updates[field] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidSwitchUpdate)
+	}
+	if name, ok := updates[SwitchFieldName]; ok && name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidSwitchUpdate)
+	}
+	return updates, nil
+}
+
+// UpdateSwitch updates a switch's name, model or IP address locally without touching NDFC.
+// Serial number and fabric are immutable. The next NDFC switch sync may overwrite these values.
+func (s *FabricService) UpdateSwitch(ctx context.Context, switchID string, update SwitchUpdate) (*SwitchUpdateResult, error) {
+	updates, err := update.fieldValues()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SwitchUpdateResult{}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sw models.Switch
+		if err := tx.First(&sw, "id = ?", switchID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSwitchNotFound
+			}
+			return err
+		}
+		oldName := sw.Name
+
+		if err := tx.Model(&sw).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update switch: %w", err)
+		}
+		result.Switch = &sw
+
+		newName, renamed := updates[SwitchFieldName].(string)
+		if !update.UpdatePortDescriptions || !renamed || newName == oldName || oldName == "" {
+			return nil
+		}
+		fabricSwitches := tx.Model(&models.Switch{}).Select("id").Where("fabric_id = ?", sw.FabricID)
+		res := tx.Model(&models.SwitchPort{}).
+			Where("switch_id IN (?)", fabricSwitches).
+			Where("description LIKE ? ESCAPE '\\'", "%"+escapeLike(oldName)+"%").
+			UpdateColumn("description", gorm.Expr("REPLACE(description, ?, ?)", oldName, newName))
+		if res.Error != nil {
+			return fmt.Errorf("update port descriptions: %w", res.Error)
+		}
+		result.UpdatedPortDescriptions = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// seedSwitchUpdate creates leaf1 and leaf2 in fabric f1, leaf1 in fabric f2, and ports
+// whose descriptions mention leaf1
+func seedSwitchUpdate(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Switch{}, &models.SwitchPort{})
+	records := []interface{}{
+		&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", Model: "N9K-C93180YC", IPAddress: "10.0.0.1", FabricID: "f1"},
+		&models.Switch{ID: "s2", Name: "leaf2", SerialNumber: "SN2", FabricID: "f1"},
+		&models.Switch{ID: "s3", Name: "leaf1", SerialNumber: "SN3", FabricID: "f2"},
+		&models.SwitchPort{ID: "p1", Name: "Ethernet1/1", SwitchID: "s1", Description: "leaf1 host port"},
+		&models.SwitchPort{ID: "p2", Name: "Ethernet1/1", SwitchID: "s2", Description: "peer of leaf1"},
+		&models.SwitchPort{ID: "p3", Name: "Ethernet1/2", SwitchID: "s2", Description: "host"},
+		&models.SwitchPort{ID: "p4", Name: "Ethernet1/1", SwitchID: "s3", Description: "leaf1 in other fabric"},
+	}
+	for _, r := range records {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatalf("seed %T: %v", r, err)
+		}
+	}
+	return db
+}
+
+func TestUpdateSwitch_RejectsImmutableFields(t *testing.T) {
+	db := seedSwitchUpdate(t)
+	svc := NewFabricService(db)
+
+	for _, field := range []string{"serial_number", "fabric_id", "id"} {
+		_, err := svc.UpdateSwitch(context.Background(), "s1", SwitchUpdate{Name: "x", Fields: []string{"name", field}})
+		if !errors.Is(err, ErrImmutableSwitchField) {
+			t.Errorf("mask with %s: err = %v, want ErrImmutableSwitchField", field, err)
+		}
+	}
+
+	if _, err := svc.UpdateSwitch(context.Background(), "s1", SwitchUpdate{Fields: []string{"role"}}); !errors.Is(err, ErrInvalidSwitchUpdate) {
+		t.Errorf("unknown field err = %v, want ErrInvalidSwitchUpdate", err)
+	}
+	if _, err := svc.UpdateSwitch(context.Background(), "s1", SwitchUpdate{Fields: []string{"name"}}); !errors.Is(err, ErrInvalidSwitchUpdate) {
+		t.Errorf("empty name err = %v, want ErrInvalidSwitchUpdate", err)
+	}
+	if _, err := svc.UpdateSwitch(context.Background(), "s1", SwitchUpdate{}); !errors.Is(err, ErrInvalidSwitchUpdate) {
+		t.Errorf("empty update err = %v, want ErrInvalidSwitchUpdate", err)
+	}
+	if _, err := svc.UpdateSwitch(context.Background(), "missing", SwitchUpdate{Name: "x"}); !errors.Is(err, ErrSwitchNotFound) {
+		t.Errorf("missing switch err = %v, want ErrSwitchNotFound", err)
+	}
+
+	var sw models.Switch
+	if err := db.First(&sw, "id = ?", "s1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if sw.Name != "leaf1" || sw.SerialNumber != "SN1" || sw.FabricID != "f1" {
+		t.Errorf("switch changed by rejected updates: %+v", sw)
+	}
+}
+
+func TestUpdateSwitch_FieldMask(t *testing.T) {
+	db := seedSwitchUpdate(t)
+	svc := NewFabricService(db)
+	ctx := context.Background()
+
+	// Without a mask only non-empty fields are written
+	result, err := svc.UpdateSwitch(ctx, "s1", SwitchUpdate{Model: "N9K-C93240YC"})
+	if err != nil {
+		t.Fatalf("UpdateSwitch: %v", err)
+	}
+	if result.Switch.Name != "leaf1" || result.Switch.Model != "N9K-C93240YC" || result.Switch.IPAddress != "10.0.0.1" {
+		t.Errorf("after model update = %+v", result.Switch)
+	}
+
+	// With a mask, listed fields are written even when empty and others are left alone
+	result, err = svc.UpdateSwitch(ctx, "s1", SwitchUpdate{Name: "ignored", IPAddress: "", Fields: []string{"ip_address"}})
+	if err != nil {
+		t.Fatalf("UpdateSwitch: %v", err)
+	}
+	var sw models.Switch
+	if err := db.First(&sw, "id = ?", "s1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if sw.Name != "leaf1" || sw.IPAddress != "" || sw.Model != "N9K-C93240YC" {
+		t.Errorf("after masked update = %+v", sw)
+	}
+	if result.UpdatedPortDescriptions != 0 {
+		t.Errorf("updated port descriptions = %d, want 0", result.UpdatedPortDescriptions)
+	}
+}
+
+func TestUpdateSwitch_RenameUpdatesPortDescriptions(t *testing.T) {
+	db := seedSwitchUpdate(t)
+	svc := NewFabricService(db)
+	ctx := context.Background()
+
+	descriptions := func() map[string]string {
+		var ports []models.SwitchPort
+		if err := db.Find(&ports).Error; err != nil {
+			t.Fatal(err)
+		}
+		m := make(map[string]string, len(ports))
+		for _, p := range ports {
+			m[p.ID] = p.Description
+		}
+		return m
+	}
+
+	// Renaming without the option leaves descriptions alone
+	if _, err := svc.UpdateSwitch(ctx, "s1", SwitchUpdate{Name: "leaf-01"}); err != nil {
+		t.Fatalf("UpdateSwitch: %v", err)
+	}
+	if got := descriptions()["p1"]; got != "leaf1 host port" {
+		t.Errorf("p1 description = %q, want unchanged", got)
+	}
+
+	if _, err := svc.UpdateSwitch(ctx, "s1", SwitchUpdate{Name: "leaf1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only ports in the switch's fabric that mention the old name are rewritten
+	result, err := svc.UpdateSwitch(ctx, "s1", SwitchUpdate{Name: "leaf-01", UpdatePortDescriptions: true})
+	if err != nil {
+		t.Fatalf("UpdateSwitch: %v", err)
+	}
+	if result.UpdatedPortDescriptions != 2 {
+		t.Errorf("updated = %d, want 2", result.UpdatedPortDescriptions)
+	}
+	want := map[string]string{
+		"p1": "leaf-01 host port",
+		"p2": "peer of leaf-01",
+		"p3": "host",
+		"p4": "leaf1 in other fabric",
+	}
+	got := descriptions()
+	for id, desc := range want {
+		if got[id] != desc {
+			t.Errorf("%s description = %q, want %q", id, got[id], desc)
+		}
+	}
+}
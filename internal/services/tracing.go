@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer of JobService spans
+const tracerName = "github.com/banglin/go-nd/internal/services"
+
+// SetTracer replaces the tracer of the Provision and Deprovision spans, by default the
+// global provider's
+func (s *JobService) SetTracer(tracer trace.Tracer) {
+	s.tracer = tracer
+}
+
+// startSpan starts a span; NDFC requests made with the returned context become its children
+func (s *JobService) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := s.tracer
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan marks span failed when err is set, then ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
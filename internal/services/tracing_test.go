@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banglin/go-nd/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestJobService_Spans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter)))
+	svc, db := newSubmissionTestService(t, newMemStore())
+	if err := db.AutoMigrate(&models.SecurityAssociation{}); err != nil {
+		t.Fatal(err)
+	}
+	svc.SetTracer(provider.Tracer("test"))
+
+	result, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1001", ComputeNodes: []string{"node1"}})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if _, err := svc.Provision(context.Background(), ProvisionInput{SlurmJobID: "1002", ComputeNodes: []string{"node9"}}); err == nil {
+		t.Fatal("Provision of an unknown node succeeded")
+	}
+	job := result.Job
+	job.Status = string(models.JobStatusActive)
+	if err := db.Model(job).Update("status", job.Status).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Deprovision(context.Background(), job); err != nil {
+		t.Fatalf("Deprovision: %v", err)
+	}
+
+	want := []struct {
+		name, slurmJobID string
+		failed           bool
+	}{
+		{"JobService.Provision", "1001", false},
+		{"JobService.Provision", "1002", true},
+		{"JobService.Deprovision", "1001", false},
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != len(want) {
+		t.Fatalf("%d spans, want %d", len(spans), len(want))
+	}
+	for i, w := range want {
+		span := spans[i]
+		var slurmJobID string
+		for _, kv := range span.Attributes {
+			if kv.Key == attribute.Key("slurm_job_id") {
+				slurmJobID = kv.Value.AsString()
+			}
+		}
+		if span.Name != w.name || slurmJobID != w.slurmJobID || (span.Status.Code == codes.Error) != w.failed {
+			t.Errorf("span %d = %s slurm_job_id=%s status=%v, want %s %s failed=%v",
+				i, span.Name, slurmJobID, span.Status, w.name, w.slurmJobID, w.failed)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	gosync "sync"
+
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// BulkSyncPortsConcurrency bounds how many switches BulkSyncSwitchPorts syncs at once
+const BulkSyncPortsConcurrency = 5
+
+// BulkSyncSwitchResult is one switch's outcome in BulkSyncSwitchPorts
+type BulkSyncSwitchResult struct {
+	Synced int    `json:"synced_count"`
+	Error  string `json:"error_message,omitempty"`
+}
+
+// BulkSyncSwitchPorts syncs the ports of several switches in parallel with SyncSwitchPorts,
+// at most BulkSyncPortsConcurrency at a time. switchIDs are local switch IDs; if empty, every
+// switch of fabricID is synced. A non-empty fabricID also limits switchIDs to that fabric.
+//
+// Returns a result per switch ID. Unknown switches and failed syncs get an error result
+// without stopping the others; the error return is only for failing to load the switches.
+func BulkSyncSwitchPorts(
+	ctx context.Context,
+	db *gorm.DB,
+	lanFabricSvc *lanfabric.Service,
+	fabricID string,
+	switchIDs []string,
+	uplinkCache *UplinkCache,
+) (map[string]BulkSyncSwitchResult, error) {
+	query := db.WithContext(ctx).Preload("Fabric")
+	if fabricID != "" {
+		query = query.Where("fabric_id = ?", fabricID)
+	}
+	if len(switchIDs) > 0 {
+		query = query.Where("id IN ?", switchIDs)
+	}
+	var switches []models.Switch
+	if err := query.Find(&switches).Error; err != nil {
+		return nil, fmt.Errorf("load switches: %w", err)
+	}
+
+	results := make(map[string]BulkSyncSwitchResult, max(len(switchIDs), len(switches)))
+	for _, id := range switchIDs {
+		if !slices.ContainsFunc(switches, func(sw models.Switch) bool { return sw.ID == id }) {
+			results[id] = BulkSyncSwitchResult{Error: "switch not found"}
+		}
+	}
+	// Fill in every result known up front before the syncs start writing the map
+	for _, sw := range switches {
+		if sw.SerialNumber == "" {
+			results[sw.ID] = BulkSyncSwitchResult{Error: "switch has no serial number"}
+		}
+	}
+
+	// Uplinks are per fabric; fetch them once rather than per switch
+	uplinks := make(map[string]map[string]bool)
+	for _, sw := range switches {
+		if sw.Fabric == nil {
+			continue
+		}
+		if _, ok := uplinks[sw.Fabric.Name]; !ok {
+			uplinks[sw.Fabric.Name] = GetUplinksWithCache(ctx, lanFabricSvc, sw.Fabric.Name, uplinkCache)
+		}
+	}
+
+	var mu gosync.Mutex
+	var g errgroup.Group
+	g.SetLimit(BulkSyncPortsConcurrency)
+	for _, sw := range switches {
+		if sw.SerialNumber == "" {
+			continue
+		}
+		var switchUplinks map[string]bool
+		if sw.Fabric != nil {
+			switchUplinks = uplinks[sw.Fabric.Name]
+		}
+		g.Go(func() error {
+			var res BulkSyncSwitchResult
+			synced, err := SyncSwitchPorts(ctx, db, lanFabricSvc, sw.ID, sw.SerialNumber, switchUplinks)
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Synced = synced.Synced
+			}
+			mu.Lock()
+			results[sw.ID] = res
+			mu.Unlock()
+			return nil // Failures are per-switch results, not a reason to stop the others
+		})
+	}
+	_ = g.Wait()
+	return results, nil
+}
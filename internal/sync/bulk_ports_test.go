@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/models"
+)
+
+// fakeSlowPortsNDFC serves one host port per switch after a delay, recording the most
+// interface requests in flight at once. Requests for failSerial get a 500.
+type fakeSlowPortsNDFC struct {
+	fakeInventoryNDFC
+	failSerial  string
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (f *fakeSlowPortsNDFC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/interface") {
+		f.fakeInventoryNDFC.ServeHTTP(w, r)
+		return
+	}
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		m := f.maxInFlight.Load()
+		if n <= m || f.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if r.URL.Query().Get("serialNumber") == f.failSerial {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message": "switch unreachable"}`))
+		return
+	}
+	f.fakeInventoryNDFC.ServeHTTP(w, r)
+}
+
+func TestBulkSyncSwitchPorts_LimitsConcurrency(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.Fabric{}, &models.SwitchPort{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.Fabric{ID: "f1", Name: "fab1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	const switches = 12
+	for i := 1; i <= switches; i++ {
+		sw := models.Switch{ID: fmt.Sprintf("s%d", i), Name: fmt.Sprintf("leaf%d", i),
+			SerialNumber: fmt.Sprintf("SN%d", i), FabricID: "f1", Role: models.SwitchRoleLeaf}
+		if err := db.Create(&sw).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake := &fakeSlowPortsNDFC{failSerial: "SN3"}
+	lan := newFakeLANFabric(t, fake)
+
+	results, err := BulkSyncSwitchPorts(context.Background(), db, lan, "f1", nil, nil)
+	if err != nil {
+		t.Fatalf("BulkSyncSwitchPorts: %v", err)
+	}
+
+	if peak := fake.maxInFlight.Load(); peak > BulkSyncPortsConcurrency || peak < 2 {
+		t.Errorf("max concurrent port fetches = %d, want 2..%d", peak, BulkSyncPortsConcurrency)
+	}
+	if len(results) != switches {
+		t.Fatalf("got %d results, want %d", len(results), switches)
+	}
+	// The failing switch doesn't stop the others
+	for id, res := range results {
+		if id == "s3" {
+			if res.Error == "" || res.Synced != 0 {
+				t.Errorf("s3 = %+v, want an error", res)
+			}
+			continue
+		}
+		if res.Error != "" || res.Synced != 1 {
+			t.Errorf("%s = %+v, want 1 port synced", id, res)
+		}
+	}
+}
+
+func TestBulkSyncSwitchPorts_SwitchIDs(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.Fabric{}, &models.SwitchPort{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []interface{}{
+		&models.Fabric{ID: "f1", Name: "fab1"},
+		&models.Fabric{ID: "f2", Name: "fab2"},
+		&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1"},
+		&models.Switch{ID: "s2", Name: "leaf2", SerialNumber: "SN2", FabricID: "f2"},
+		&models.Switch{ID: "s3", Name: "leaf3", FabricID: "f1"},
+	} {
+		if err := db.Create(r).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake := &fakeInventoryNDFC{}
+	lan := newFakeLANFabric(t, fake)
+
+	results, err := BulkSyncSwitchPorts(context.Background(), db, lan, "", []string{"s1", "s2", "s3", "missing"}, nil)
+	if err != nil {
+		t.Fatalf("BulkSyncSwitchPorts: %v", err)
+	}
+	if results["s1"].Synced != 1 || results["s2"].Synced != 1 {
+		t.Errorf("results = %+v, want s1 and s2 synced", results)
+	}
+	if results["s3"].Error == "" || results["missing"].Error != "switch not found" {
+		t.Errorf("results = %+v, want errors for s3 (no serial) and missing", results)
+	}
+
+	// With a fabric, switches of other fabrics are not found
+	results, err = BulkSyncSwitchPorts(context.Background(), db, lan, "f1", []string{"s1", "s2"}, nil)
+	if err != nil {
+		t.Fatalf("BulkSyncSwitchPorts: %v", err)
+	}
+	if results["s1"].Synced != 1 || results["s2"].Error != "switch not found" {
+		t.Errorf("fabric f1 results = %+v, want s1 synced and s2 not found", results)
+	}
+}
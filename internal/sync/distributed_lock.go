@@ -0,0 +1,165 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Leader election keys and timings. The leader key holds the leader's instance ID
+// and expires unless the leader refreshes it, so a stopped or crashed leader is
+// replaced within leaderTTL.
+const (
+	leaderKey             = "sync:leader"
+	leaderFabricKeyPrefix = "sync:leader:fabric:"
+	leaderTTL             = 30 * time.Second
+	leaderRefreshInterval = 10 * time.Second
+)
+
+// LeaderKey returns the leader election key for a fabric, or the global
+// key if fabricID is empty
+func LeaderKey(fabricID string) string {
+	if fabricID == "" {
+		return leaderKey
+	}
+	return leaderFabricKeyPrefix + fabricID
+}
+
+// LeaderElector elects a single sync leader among instances sharing a Valkey.
+// The leader holds the key with SET NX and refreshes its TTL every
+// leaderRefreshInterval; non-leaders retry on the same interval and take over
+// once the key expires.
+type LeaderElector struct {
+	client     *cache.ValkeyClient
+	key        string
+	instanceID string
+	ttl        time.Duration
+	refresh    time.Duration
+	now        func() time.Time // Overridden in tests
+
+	leader      atomic.Bool
+	leaderUntil atomic.Int64 // Unix nanos after which leadership is assumed lost without a refresh
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewLeaderElector creates an elector for key identifying this instance as instanceID
+func NewLeaderElector(client *cache.ValkeyClient, key, instanceID string) *LeaderElector {
+	return &LeaderElector{
+		client:     client,
+		key:        key,
+		instanceID: instanceID,
+		ttl:        leaderTTL,
+		refresh:    leaderRefreshInterval,
+		now:        time.Now,
+	}
+}
+
+// Start runs a first election round synchronously, then keeps campaigning
+// (or refreshing, once leader) in the background until Stop
+func (e *LeaderElector) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+
+	e.campaign(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.campaign(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the campaign and releases leadership so another instance can take over
+// without waiting for the TTL
+func (e *LeaderElector) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+
+	if e.leader.Swap(false) {
+		ctx, cancel := context.WithTimeout(context.Background(), cacheOpTimeout)
+		defer cancel()
+		if err := e.client.ReleaseLock(ctx, e.key, e.instanceID); err != nil {
+			logger.Warn("Failed to release sync leadership", zap.String("key", e.key), zap.Error(err))
+		}
+	}
+}
+
+// IsLeader reports whether this instance currently holds leadership. Leadership
+// lapses once the TTL passes without a successful refresh, even if Valkey is unreachable.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load() && e.now().UnixNano() < e.leaderUntil.Load()
+}
+
+// campaign runs one election round: the leader refreshes its TTL, others try to
+// claim the key. Returns whether this instance is leader afterwards.
+func (e *LeaderElector) campaign(ctx context.Context) bool {
+	opCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	defer cancel()
+
+	start := e.now()
+	var held bool
+	var err error
+	if e.leader.Load() {
+		held, err = e.client.ExtendLockIfOwner(opCtx, e.key, e.instanceID, e.ttl)
+	} else {
+		held, err = e.client.SetNX(opCtx, e.key, e.instanceID, e.ttl)
+	}
+	if err != nil && strings.Contains(err.Error(), "NOPERM") {
+		// Matches the sync lock: without SET permission assume single-instance mode
+		if !e.leader.Swap(true) {
+			logger.Warn("Leader election unavailable (NOPERM), proceeding in single-instance mode",
+				zap.String("key", e.key))
+		}
+		e.leaderUntil.Store(start.Add(e.ttl).UnixNano())
+		return true
+	}
+	if err != nil {
+		// Keep current state; IsLeader expires it if refreshes keep failing
+		logger.Warn("Sync leader election failed", zap.String("key", e.key), zap.Error(err))
+		return e.IsLeader()
+	}
+
+	if held {
+		e.leaderUntil.Store(start.Add(e.ttl).UnixNano())
+	}
+	if was := e.leader.Swap(held); was != held {
+		if held {
+			logger.Info("Acquired sync leadership", zap.String("key", e.key), zap.String("instance", e.instanceID))
+		} else {
+			logger.Warn("Lost sync leadership", zap.String("key", e.key), zap.String("instance", e.instanceID))
+		}
+	}
+	return held
+}
+
+// CurrentLeader returns the instance ID holding key, or "" if there is no leader
+func CurrentLeader(ctx context.Context, client *cache.ValkeyClient, key string) (string, error) {
+	leader, err := client.GetString(ctx, key)
+	if err != nil {
+		if errors.Is(err, cache.ErrKeyNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return leader, nil
+}
@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/banglin/go-nd/internal/cache"
+)
+
+// testClock is a manually advanced clock shared by electors and miniredis
+type testClock struct {
+	mr  *miniredis.Miniredis
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.mr.FastForward(d)
+}
+
+func newTestElector(client *cache.ValkeyClient, clock *testClock, instanceID string) *LeaderElector {
+	e := NewLeaderElector(client, LeaderKey("fab1"), instanceID)
+	e.now = clock.Now
+	return e
+}
+
+// runIfLeader stands in for Worker.syncAll's leadership check
+func runIfLeader(e *LeaderElector, runs map[string]int) {
+	if e.IsLeader() {
+		runs[e.instanceID]++
+	}
+}
+
+func TestLeaderElector_SingleLeader(t *testing.T) {
+	mr, client := newTestCache(t)
+	clock := &testClock{mr: mr, now: time.Now()}
+	a := newTestElector(client, clock, "instance-a")
+	b := newTestElector(client, clock, "instance-b")
+	ctx := context.Background()
+	runs := map[string]int{}
+
+	// Several refresh rounds; a wins first and keeps leadership by refreshing
+	for i := 0; i < 5; i++ {
+		a.campaign(ctx)
+		b.campaign(ctx)
+		if a.IsLeader() == b.IsLeader() {
+			t.Fatalf("round %d: a leader=%v, b leader=%v; want exactly one", i, a.IsLeader(), b.IsLeader())
+		}
+		runIfLeader(a, runs)
+		runIfLeader(b, runs)
+		clock.Advance(leaderRefreshInterval)
+	}
+
+	if runs["instance-a"] != 5 || runs["instance-b"] != 0 {
+		t.Errorf("runs = %v, want only instance-a to sync", runs)
+	}
+	if got, _ := mr.Get(LeaderKey("fab1")); got != "instance-a" {
+		t.Errorf("leader key = %q, want instance-a", got)
+	}
+	if ttl := mr.TTL(LeaderKey("fab1")); ttl <= 0 || ttl > leaderTTL {
+		t.Errorf("leader key TTL = %v, want within (0, %v]", ttl, leaderTTL)
+	}
+}
+
+func TestLeaderElector_TransfersWhenLeaderStopsRefreshing(t *testing.T) {
+	mr, client := newTestCache(t)
+	clock := &testClock{mr: mr, now: time.Now()}
+	a := newTestElector(client, clock, "instance-a")
+	b := newTestElector(client, clock, "instance-b")
+	ctx := context.Background()
+
+	if !a.campaign(ctx) || b.campaign(ctx) {
+		t.Fatal("expected instance-a to win the first election")
+	}
+
+	// a stops refreshing; b cannot take over until the TTL lapses
+	clock.Advance(leaderTTL - time.Second)
+	if b.campaign(ctx) {
+		t.Fatal("instance-b took leadership before the TTL expired")
+	}
+
+	clock.Advance(2 * time.Second)
+	if a.IsLeader() {
+		t.Error("instance-a still reports leadership after its TTL lapsed")
+	}
+	if !b.campaign(ctx) {
+		t.Fatal("instance-b did not take over after the TTL expired")
+	}
+
+	// a's late refresh must not reclaim the key
+	if a.campaign(ctx) {
+		t.Error("instance-a reclaimed leadership held by instance-b")
+	}
+	leader, err := CurrentLeader(ctx, client, LeaderKey("fab1"))
+	if err != nil || leader != "instance-b" {
+		t.Errorf("CurrentLeader = %q, %v; want instance-b", leader, err)
+	}
+}
+
+func TestLeaderElector_StopReleases(t *testing.T) {
+	mr, client := newTestCache(t)
+	clock := &testClock{mr: mr, now: time.Now()}
+	a := newTestElector(client, clock, "instance-a")
+	b := newTestElector(client, clock, "instance-b")
+	ctx := context.Background()
+
+	a.Start()
+	if !a.IsLeader() {
+		t.Fatal("expected instance-a to lead after Start")
+	}
+	a.Stop()
+	if a.IsLeader() {
+		t.Error("instance-a still leader after Stop")
+	}
+
+	// No TTL wait needed after a clean stop
+	if !b.campaign(ctx) {
+		t.Error("instance-b could not take over after instance-a stopped")
+	}
+}
+
+func TestCurrentLeader_None(t *testing.T) {
+	_, client := newTestCache(t)
+	leader, err := CurrentLeader(context.Background(), client, LeaderKey(""))
+	if err != nil || leader != "" {
+		t.Errorf("CurrentLeader = %q, %v; want empty", leader, err)
+	}
+}
+
+func TestLeaderKey(t *testing.T) {
+	if got := LeaderKey(""); got != "sync:leader" {
+		t.Errorf("LeaderKey(\"\") = %q", got)
+	}
+	if got := LeaderKey("fab1"); got != "sync:leader:fabric:fab1" {
+		t.Errorf("LeaderKey(fab1) = %q", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"github.com/banglin/go-nd/internal/util"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -25,7 +26,11 @@ type SyncSwitchPortsResult struct {
 //   - lanFabricSvc: LAN fabric service for NDFC calls
 //   - switchID: local database switch ID
 //   - serialNumber: switch serial number for NDFC API
-//   - uplinks: map of "serial:ifName" -> true for ports to exclude (inter-switch links)
+//   - uplinks: map of "serial:ifName" -> true for ports to exclude (inter-switch links);
+//     breakout sub-ports of an uplink parent port are excluded too
+//
+// Spine switches have no host-facing ports, so none are imported for them.
+// On leaf and border switches uplink ports are excluded.
 //
 // Returns the number of ports synced and any error.
 func SyncSwitchPorts(
@@ -36,6 +41,19 @@ func SyncSwitchPorts(
 	serialNumber string,
 	uplinks map[string]bool,
 ) (*SyncSwitchPortsResult, error) {
+	var roles []string
+	if err := db.WithContext(ctx).Model(&models.Switch{}).
+		Where("id = ?", switchID).
+		Pluck("role", &roles).Error; err != nil {
+		return nil, err
+	}
+	if len(roles) > 0 && roles[0] == models.SwitchRoleSpine {
+		if err := markSwitchSynced(ctx, db, switchID, time.Now()); err != nil {
+			return nil, err
+		}
+		return &SyncSwitchPortsResult{}, nil
+	}
+
 	// Fetch ports from NDFC
 	ports, err := lanFabricSvc.GetSwitchPortsNDFC(ctx, serialNumber)
 	if err != nil {
@@ -51,11 +69,17 @@ func SyncSwitchPorts(
 			continue
 		}
 
-		// Skip uplink ports (inter-switch links)
-		uplinkKey := serialNumber + ":" + p.Name
-		if uplinks[uplinkKey] {
+		// Skip uplink ports (inter-switch links), including sub-ports of a broken-out uplink
+		uplinkKey := util.SelectorExpression{SerialNumber: serialNumber, InterfaceName: p.Name}
+		if uplinks[uplinkKey.String()] {
 			continue
 		}
+		if p.IsBreakout {
+			uplinkKey.InterfaceName = lanfabric.BreakoutParent(p.Name)
+			if uplinks[uplinkKey.String()] {
+				continue
+			}
+		}
 
 		// Use deterministic ID (switch_id:port_name) for stable upserts
 		portID := switchID + ":" + p.Name
@@ -66,22 +90,73 @@ func SyncSwitchPorts(
 			Speed:       p.Speed,
 			AdminState:  p.AdminState,
 			IsPresent:   true,
+			IsBreakout:  p.IsBreakout,
 			SwitchID:    switchID,
 			LastSeenAt:  &now,
 		})
 	}
 
-	if len(portsToUpsert) == 0 {
-		return &SyncSwitchPortsResult{Synced: 0, Total: len(ports)}, nil
+	if len(portsToUpsert) > 0 {
+		// Bulk upsert with OnConflict - single query instead of N queries
+		if err := db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "switch_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"description", "speed", "admin_state", "is_present", "is_breakout", "last_seen_at", "updated_at"}),
+		}).CreateInBatches(portsToUpsert, 500).Error; err != nil {
+			return nil, err
+		}
+		if err := markMappedNodesSeen(ctx, db, switchID, portsToUpsert, now); err != nil {
+			return nil, err
+		}
 	}
 
-	// Bulk upsert with OnConflict - single query instead of N queries
-	if err := db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "switch_id"}, {Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"description", "speed", "admin_state", "is_present", "last_seen_at", "updated_at"}),
-	}).CreateInBatches(portsToUpsert, 500).Error; err != nil {
+	// Record the successful sync so SyncStaleSwitches can skip this switch
+	if err := markSwitchSynced(ctx, db, switchID, now); err != nil {
 		return nil, err
 	}
 
 	return &SyncSwitchPortsResult{Synced: len(portsToUpsert), Total: len(ports)}, nil
 }
+
+// markSwitchSynced sets the switch's last_synced_at
+func markSwitchSynced(ctx context.Context, db *gorm.DB, switchID string, at time.Time) error {
+	return db.WithContext(ctx).Model(&models.Switch{}).
+		Where("id = ?", switchID).
+		UpdateColumn("last_synced_at", at).Error
+}
+
+// markMappedNodesSeen sets last_seen_at on the compute nodes mapped to any of the switch's
+// ports found by a sync, reactivating any that were auto-decommissioned
+func markMappedNodesSeen(ctx context.Context, db *gorm.DB, switchID string, ports []models.SwitchPort, at time.Time) error {
+	names := make([]string, len(ports))
+	for i, p := range ports {
+		names[i] = p.Name
+	}
+	mapped := db.Model(&models.ComputeNodePortMapping{}).
+		Select("compute_node_port_mappings.compute_node_id").
+		Joins("JOIN switch_ports ON switch_ports.id = compute_node_port_mappings.switch_port_id").
+		Where("switch_ports.switch_id = ? AND switch_ports.name IN ?", switchID, names)
+	return db.WithContext(ctx).Model(&models.ComputeNode{}).
+		Where("id IN (?)", mapped).
+		UpdateColumns(map[string]interface{}{
+			"last_seen_at": at,
+			"status":       models.ComputeNodeStatusActive,
+		}).Error
+}
+
+// CountLeafPorts returns how many present ports the leaf switches of a fabric have in total
+// and how many of them no compute node is mapped to
+func CountLeafPorts(ctx context.Context, db *gorm.DB, fabricID string) (total, available int64, err error) {
+	var counts struct {
+		Total     int64
+		Available int64
+	}
+	err = db.WithContext(ctx).Model(&models.SwitchPort{}).
+		Select("COUNT(DISTINCT switch_ports.id) AS total, "+
+			"COUNT(DISTINCT CASE WHEN compute_node_port_mappings.id IS NULL THEN switch_ports.id END) AS available").
+		Joins("JOIN switches ON switches.id = switch_ports.switch_id AND switches.deleted_at IS NULL").
+		Joins("LEFT JOIN compute_node_port_mappings ON compute_node_port_mappings.switch_port_id = switch_ports.id AND compute_node_port_mappings.deleted_at IS NULL").
+		Where("switches.fabric_id = ? AND switches.role = ?", fabricID, models.SwitchRoleLeaf).
+		Where("switch_ports.is_present = ?", true).
+		Scan(&counts).Error
+	return counts.Total, counts.Available, err
+}
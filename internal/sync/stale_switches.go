@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DefaultStaleThreshold is used when SyncStaleSwitches is called without a threshold
+const DefaultStaleThreshold = 60 * time.Minute
+
+// SyncStaleSwitchesResult contains the result of a stale switch sync
+type SyncStaleSwitchesResult struct {
+	Synced         int           // Switches whose ports were synced
+	Skipped        int           // Switches synced within the threshold
+	Failed         int           // Stale switches whose port sync failed
+	StaleThreshold time.Duration // Threshold that was applied
+}
+
+// StaleSwitches partitions a fabric's switches into those never synced or last
+// synced before now-threshold (returned) and the number recently synced (skipped).
+func StaleSwitches(ctx context.Context, db *gorm.DB, fabricID string, threshold time.Duration, now time.Time) ([]models.Switch, int, error) {
+	var switches []models.Switch
+	if err := db.WithContext(ctx).Where("fabric_id = ?", fabricID).Order("name").Find(&switches).Error; err != nil {
+		return nil, 0, err
+	}
+
+	cutoff := now.Add(-threshold)
+	stale := make([]models.Switch, 0, len(switches))
+	for _, sw := range switches {
+		if sw.LastSyncedAt == nil || sw.LastSyncedAt.Before(cutoff) {
+			stale = append(stale, sw)
+		}
+	}
+	return stale, len(switches) - len(stale), nil
+}
+
+// SyncStaleSwitches syncs ports only for switches in the fabric that are stale
+// (see StaleSwitches), leaving recently synced switches alone. Non-positive
+// threshold uses DefaultStaleThreshold. Per-switch failures are logged and counted.
+func SyncStaleSwitches(
+	ctx context.Context,
+	db *gorm.DB,
+	lanFabricSvc *lanfabric.Service,
+	fabric *models.Fabric,
+	uplinks map[string]bool,
+	threshold time.Duration,
+) (*SyncStaleSwitchesResult, error) {
+	if threshold <= 0 {
+		threshold = DefaultStaleThreshold
+	}
+
+	stale, skipped, err := StaleSwitches(ctx, db, fabric.ID, threshold, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncStaleSwitchesResult{Skipped: skipped, StaleThreshold: threshold}
+	for _, sw := range stale {
+		if sw.SerialNumber == "" {
+			result.Skipped++
+			continue
+		}
+		if _, err := SyncSwitchPorts(ctx, db, lanFabricSvc, sw.ID, sw.SerialNumber, uplinks); err != nil {
+			logger.Warn("Stale switch port sync failed",
+				zap.String("fabric", fabric.Name),
+				zap.String("switch", sw.Name),
+				zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.Synced++
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/database/dbtest"
+	"github.com/banglin/go-nd/internal/models"
+	"gorm.io/gorm"
+)
+
+// newSwitchDB opens an in-memory SQLite DB with the switch table migrated
+func newSwitchDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.NewSQLiteDB(t, &models.Switch{}, &models.ComputeNode{}, &models.ComputeNodePortMapping{})
+	return db
+}
+
+func TestStaleSwitches_SkipsRecentlySynced(t *testing.T) {
+	db := newSwitchDB(t)
+	now := time.Now()
+	recent := now.Add(-10 * time.Minute)
+	old := now.Add(-2 * time.Hour)
+
+	for _, sw := range []models.Switch{
+		{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", LastSyncedAt: &recent},
+		{ID: "s2", Name: "leaf2", SerialNumber: "SN2", FabricID: "f1", LastSyncedAt: &old},
+		{ID: "s3", Name: "leaf3", SerialNumber: "SN3", FabricID: "f1"},
+		{ID: "s4", Name: "leaf4", SerialNumber: "SN4", FabricID: "f2"},
+	} {
+		if err := db.Create(&sw).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale, skipped, err := StaleSwitches(context.Background(), db, "f1", time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+	if len(stale) != 2 || stale[0].ID != "s2" || stale[1].ID != "s3" {
+		t.Errorf("stale = %+v, want leaf2 (old) and leaf3 (never synced)", stale)
+	}
+
+	// A wider threshold makes the 2h-old switch fresh
+	stale, skipped, err = StaleSwitches(context.Background(), db, "f1", 3*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 || stale[0].ID != "s3" || skipped != 2 {
+		t.Errorf("3h threshold: stale = %d (skipped %d), want only the never-synced switch", len(stale), skipped)
+	}
+}
+
+func TestMarkSwitchSynced(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.Create(&models.Switch{ID: "s1", Name: "leaf1", FabricID: "f1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Now().Truncate(time.Second)
+	if err := markSwitchSynced(context.Background(), db, "s1", at); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, skipped, err := StaleSwitches(context.Background(), db, "f1", DefaultStaleThreshold, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 0 || skipped != 1 {
+		t.Errorf("stale = %d, skipped = %d; want switch skipped right after sync", len(stale), skipped)
+	}
+}
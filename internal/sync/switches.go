@@ -2,9 +2,13 @@ package sync
 
 import (
 	"context"
+	"strings"
 
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -47,13 +51,20 @@ func SyncFabricSwitches(
 			Model:        s.Model,
 			IPAddress:    s.IPAddress,
 			FabricID:     fabric.ID,
+			Role:         NormalizeSwitchRole(s.SwitchRole),
 		}
 
 		// Upsert by serial_number (unique constraint)
-		// This handles cases where the same switch might have different IDs
+		// This handles cases where the same switch might have different IDs.
+		// A manually overridden role is kept.
+		updates := clause.AssignmentColumns([]string{"name", "model", "ip_address", "fabric_id", "updated_at"})
+		updates = append(updates, clause.Assignment{
+			Column: clause.Column{Name: "role"},
+			Value:  gorm.Expr("CASE WHEN switches.role_override THEN switches.role ELSE excluded.role END"),
+		})
 		if err := db.WithContext(ctx).Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "serial_number"}},
-			DoUpdates: clause.AssignmentColumns([]string{"name", "model", "ip_address", "fabric_id", "updated_at"}),
+			DoUpdates: updates,
 		}).Create(&sw).Error; err != nil {
 			// Log but continue - don't fail entire sync for one switch
 			continue
@@ -61,5 +72,29 @@ func SyncFabricSwitches(
 		synced++
 	}
 
+	// Switch inventory changed, so inter-switch links may have too; refetch uplinks on next use
+	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	err = cache.InvalidateUplinkCache(cacheCtx, fabric.Name)
+	cancel()
+	if err != nil {
+		logger.Warn("Failed to invalidate uplink cache", zap.String("fabric", fabric.Name), zap.Error(err))
+	}
+
 	return &SyncSwitchesResult{Synced: synced, Total: len(switches)}, nil
 }
+
+// NormalizeSwitchRole maps an NDFC switch role (e.g. "Leaf", "ToR", "Border Gateway",
+// "Border Spine") to leaf, border, or spine. Unrecognized roles return "".
+func NormalizeSwitchRole(ndfcRole string) string {
+	r := strings.ToLower(ndfcRole)
+	if !lanfabric.IsLeafOrBorder(ndfcRole) {
+		if strings.Contains(r, "spine") {
+			return models.SwitchRoleSpine
+		}
+		return ""
+	}
+	if strings.Contains(r, "border") {
+		return models.SwitchRoleBorder
+	}
+	return models.SwitchRoleLeaf
+}
@@ -0,0 +1,269 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"gorm.io/gorm"
+)
+
+// fakeInventoryNDFC serves a fixed switch inventory and the ports in ifNames
+// (default one host port) per switch, counting interface requests
+type fakeInventoryNDFC struct {
+	inventory      string
+	ifNames        []string
+	interfaceCalls atomic.Int32
+}
+
+func (f *fakeInventoryNDFC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/inventory"):
+		_, _ = w.Write([]byte(f.inventory))
+	case strings.HasSuffix(r.URL.Path, "/interface"):
+		f.interfaceCalls.Add(1)
+		serial := r.URL.Query().Get("serialNumber")
+		ifNames := f.ifNames
+		if len(ifNames) == 0 {
+			ifNames = []string{"Ethernet1/1"}
+		}
+		var ifaces []string
+		for _, name := range ifNames {
+			ifaces = append(ifaces, `{"serialNumber": "`+serial+`", "ifName": "`+name+
+				`", "nvPairs": {"DESC": "host", "ADMIN_STATE": "true", "SPEED": "100Gb"}}`)
+		}
+		_, _ = w.Write([]byte(`[{"policy": "int_access_host", "interfaces": [` + strings.Join(ifaces, ",") + `]}]`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "not found"}`))
+	}
+}
+
+func newFakeLANFabric(t *testing.T, fake http.Handler) *lanfabric.Service {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return client.LANFabric()
+}
+
+func TestNormalizeSwitchRole(t *testing.T) {
+	tests := map[string]string{
+		"Leaf":           models.SwitchRoleLeaf,
+		"tor":            models.SwitchRoleLeaf,
+		"Border Gateway": models.SwitchRoleBorder,
+		"border":         models.SwitchRoleBorder,
+		"Spine":          models.SwitchRoleSpine,
+		"Border Spine":   models.SwitchRoleSpine,
+		"super spine":    models.SwitchRoleSpine,
+		"":               "",
+		"access":         "",
+	}
+	for in, want := range tests {
+		if got := NormalizeSwitchRole(in); got != want {
+			t.Errorf("NormalizeSwitchRole(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSyncFabricSwitches_SetsRoleFromInventory(t *testing.T) {
+	db := newSwitchDB(t)
+	fake := &fakeInventoryNDFC{inventory: `[
+		{"serialNumber": "SN1", "logicalName": "leaf1", "switchRoleEnum": "Leaf"},
+		{"serialNumber": "SN2", "logicalName": "bgw1", "switchRoleEnum": "Border Gateway"},
+		{"serialNumber": "SN3", "logicalName": "spine1", "switchRoleEnum": "Spine"}
+	]`}
+	lan := newFakeLANFabric(t, fake)
+	fabric := &models.Fabric{ID: "f1", Name: "fab1"}
+
+	result, err := SyncFabricSwitches(context.Background(), db, lan, fabric)
+	if err != nil {
+		t.Fatalf("SyncFabricSwitches: %v", err)
+	}
+	if result.Synced != 2 || result.Total != 3 {
+		t.Errorf("result = %+v, want 2 of 3 synced", result)
+	}
+
+	roles := switchRoles(t, db)
+	want := map[string]string{"SN1": models.SwitchRoleLeaf, "SN2": models.SwitchRoleBorder}
+	if len(roles) != len(want) || roles["SN1"] != want["SN1"] || roles["SN2"] != want["SN2"] {
+		t.Errorf("roles = %v, want %v", roles, want)
+	}
+
+	// A manual override survives the next sync
+	if err := db.Model(&models.Switch{}).Where("serial_number = ?", "SN1").
+		Updates(map[string]interface{}{"role": models.SwitchRoleSpine, "role_override": true}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SyncFabricSwitches(context.Background(), db, lan, fabric); err != nil {
+		t.Fatalf("resync: %v", err)
+	}
+	if got := switchRoles(t, db)["SN1"]; got != models.SwitchRoleSpine {
+		t.Errorf("overridden role = %q after resync, want spine", got)
+	}
+}
+
+func TestSyncSwitchPorts_SpineHasNoHostPorts(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.SwitchPort{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, sw := range []models.Switch{
+		{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", Role: models.SwitchRoleLeaf},
+		{ID: "s2", Name: "spine1", SerialNumber: "SN2", FabricID: "f1", Role: models.SwitchRoleSpine},
+	} {
+		if err := db.Create(&sw).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake := &fakeInventoryNDFC{}
+	lan := newFakeLANFabric(t, fake)
+	ctx := context.Background()
+
+	leaf, err := SyncSwitchPorts(ctx, db, lan, "s1", "SN1", nil)
+	if err != nil {
+		t.Fatalf("leaf sync: %v", err)
+	}
+	spine, err := SyncSwitchPorts(ctx, db, lan, "s2", "SN2", nil)
+	if err != nil {
+		t.Fatalf("spine sync: %v", err)
+	}
+
+	if leaf.Synced != 1 || spine.Synced != 0 {
+		t.Errorf("synced leaf=%d spine=%d, want 1 and 0", leaf.Synced, spine.Synced)
+	}
+	if calls := fake.interfaceCalls.Load(); calls != 1 {
+		t.Errorf("NDFC interface calls = %d, want 1 (spine not fetched)", calls)
+	}
+	var spinePorts int64
+	if err := db.Model(&models.SwitchPort{}).Where("switch_id = ?", "s2").Count(&spinePorts).Error; err != nil {
+		t.Fatal(err)
+	}
+	if spinePorts != 0 {
+		t.Errorf("spine has %d ports, want 0", spinePorts)
+	}
+}
+
+func TestSyncSwitchPorts_BreakoutPorts(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.SwitchPort{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", Role: models.SwitchRoleLeaf}).Error; err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeInventoryNDFC{ifNames: []string{
+		"Ethernet1/1", "Ethernet1/2/1", "Ethernet1/2/2", "Ethernet1/49", "Ethernet1/50/1", "Ethernet1/50/2",
+	}}
+	lan := newFakeLANFabric(t, fake)
+
+	// Ethernet1/49 is an uplink; Ethernet1/50 is a broken-out uplink whose sub-ports must be skipped too
+	uplinks := map[string]bool{"SN1:Ethernet1/49": true, "SN1:Ethernet1/50": true}
+	result, err := SyncSwitchPorts(context.Background(), db, lan, "s1", "SN1", uplinks)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if result.Synced != 3 {
+		t.Errorf("synced = %d, want 3", result.Synced)
+	}
+
+	var ports []models.SwitchPort
+	if err := db.Order("name").Find(&ports).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"Ethernet1/1": false, "Ethernet1/2/1": true, "Ethernet1/2/2": true}
+	if len(ports) != len(want) {
+		t.Fatalf("stored %d ports, want %d", len(ports), len(want))
+	}
+	for _, p := range ports {
+		breakout, ok := want[p.Name]
+		if !ok {
+			t.Errorf("unexpected port %s stored", p.Name)
+			continue
+		}
+		if p.IsBreakout != breakout {
+			t.Errorf("%s is_breakout = %v, want %v", p.Name, p.IsBreakout, breakout)
+		}
+	}
+}
+
+func TestSyncSwitchPorts_MarksMappedNodesSeen(t *testing.T) {
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.SwitchPort{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.Switch{ID: "s1", Name: "leaf1", SerialNumber: "SN1", FabricID: "f1", Role: models.SwitchRoleLeaf}).Error; err != nil {
+		t.Fatal(err)
+	}
+	// node-1 (auto-decommissioned) is mapped to a port NDFC still reports, node-2 to one that is gone
+	for _, p := range []models.SwitchPort{
+		{ID: "s1:Ethernet1/1", Name: "Ethernet1/1", SwitchID: "s1"},
+		{ID: "s1:Ethernet1/2", Name: "Ethernet1/2", SwitchID: "s1"},
+	} {
+		if err := db.Create(&p).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, n := range []models.ComputeNode{
+		{ID: "n1", Name: "node-1", Status: models.ComputeNodeStatusDecommissioned},
+		{ID: "n2", Name: "node-2"},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []models.ComputeNodePortMapping{
+		{ID: "m1", ComputeNodeID: "n1", SwitchPortID: "s1:Ethernet1/1"},
+		{ID: "m2", ComputeNodeID: "n2", SwitchPortID: "s1:Ethernet1/2"},
+	} {
+		if err := db.Create(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	lan := newFakeLANFabric(t, &fakeInventoryNDFC{ifNames: []string{"Ethernet1/1"}})
+
+	before := time.Now()
+	if _, err := SyncSwitchPorts(context.Background(), db, lan, "s1", "SN1", nil); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	var nodes []models.ComputeNode
+	if err := db.Order("name").Find(&nodes).Error; err != nil {
+		t.Fatal(err)
+	}
+	if nodes[0].LastSeenAt == nil || nodes[0].LastSeenAt.Before(before.Add(-time.Second)) {
+		t.Errorf("node-1 last_seen_at = %v, want the sync time", nodes[0].LastSeenAt)
+	}
+	if nodes[0].Status != models.ComputeNodeStatusActive {
+		t.Errorf("node-1 status = %q, want reactivated", nodes[0].Status)
+	}
+	if nodes[1].LastSeenAt != nil {
+		t.Errorf("node-2 last_seen_at = %v, want unset (port not reported)", nodes[1].LastSeenAt)
+	}
+}
+
+// switchRoles returns serial number -> role for all stored switches
+func switchRoles(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	var switches []models.Switch
+	if err := db.Find(&switches).Error; err != nil {
+		t.Fatal(err)
+	}
+	roles := make(map[string]string, len(switches))
+	for _, sw := range switches {
+		roles[sw.SerialNumber] = sw.Role
+	}
+	return roles
+}
@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// DefaultUplinkCacheTTL is used when ND_UPLINK_CACHE_TTL_MINUTES is unset or not positive
+const DefaultUplinkCacheTTL = 60 * time.Minute
+
+// UplinkSource fetches the uplink (inter-switch link) ports of a fabric from NDFC.
+// Implemented by *lanfabric.Service.
+type UplinkSource interface {
+	GetUplinkPortsNDFC(ctx context.Context, fabricName string) (map[string]bool, error)
+}
+
+// UplinkCache caches per-fabric uplink ports in Valkey as a JSON object of
+// "serial:ifName" -> true. A nil client disables caching; every call fetches live.
+type UplinkCache struct {
+	client cache.CacheClient
+	ttl    time.Duration
+}
+
+// NewUplinkCache creates an UplinkCache. Non-positive ttl uses DefaultUplinkCacheTTL.
+func NewUplinkCache(client cache.CacheClient, ttl time.Duration) *UplinkCache {
+	if ttl <= 0 {
+		ttl = DefaultUplinkCacheTTL
+	}
+	return &UplinkCache{client: client, ttl: ttl}
+}
+
+// GetUplinks returns the uplink ports for fabricName, from Valkey if cached,
+// otherwise from NDFC (storing the result for the configured TTL).
+// Cache errors are treated as misses; NDFC errors are returned.
+func (u *UplinkCache) GetUplinks(ctx context.Context, lanFabric UplinkSource, fabricName string) (map[string]bool, error) {
+	key := cache.Uplinks(fabricName)
+
+	if u.client != nil {
+		cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+		var cached map[string]bool
+		err := u.client.Get(cacheCtx, key, &cached)
+		cancel()
+		if err == nil && cached != nil {
+			return cached, nil
+		}
+		if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+			logger.Debug("Uplink cache read failed, fetching from NDFC",
+				zap.String("fabric", fabricName), zap.Error(err))
+		}
+	}
+
+	uplinks, err := lanFabric.GetUplinkPortsNDFC(ctx, fabricName)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.client != nil {
+		cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+		err := u.client.Set(cacheCtx, key, uplinks, u.ttl)
+		cancel()
+		if err != nil {
+			logger.Debug("Uplink cache write failed", zap.String("fabric", fabricName), zap.Error(err))
+		} else {
+			metrics.UplinkPortsCached.WithLabelValues(fabricName).Set(float64(len(uplinks)))
+		}
+	}
+
+	return uplinks, nil
+}
+
+// Invalidate removes the cached uplink ports for fabricName so the next lookup fetches from NDFC
+func (u *UplinkCache) Invalidate(ctx context.Context, fabricName string) error {
+	if u.client == nil {
+		return nil
+	}
+	cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
+	defer cancel()
+	if err := u.client.Delete(cacheCtx, cache.Uplinks(fabricName)); err != nil {
+		return err
+	}
+	metrics.UplinkPortsCached.WithLabelValues(fabricName).Set(0)
+	return nil
+}
+
+// GetUplinks returns uplink ports for a fabric using cacheClient (nil to always fetch live)
+func GetUplinks(ctx context.Context, lanFabric UplinkSource, fabricName string, cacheClient cache.CacheClient, ttl time.Duration) (map[string]bool, error) {
+	return NewUplinkCache(cacheClient, ttl).GetUplinks(ctx, lanFabric, fabricName)
+}
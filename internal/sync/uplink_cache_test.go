@@ -0,0 +1,118 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/cache/cachetest"
+	"github.com/banglin/go-nd/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeUplinkSource counts NDFC fetches and returns a fixed uplink set
+type fakeUplinkSource struct {
+	calls   int
+	uplinks map[string]bool
+	err     error
+}
+
+func (f *fakeUplinkSource) GetUplinkPortsNDFC(ctx context.Context, fabricName string) (map[string]bool, error) {
+	f.calls++
+	return f.uplinks, f.err
+}
+
+// newTestCache starts an in-memory Valkey and installs it as the global cache client
+func newTestCache(t *testing.T) (*miniredis.Miniredis, *cache.ValkeyClient) {
+	t.Helper()
+	return cachetest.NewMiniredis(t)
+}
+
+func TestUplinkCache_MissThenHit(t *testing.T) {
+	mr, client := newTestCache(t)
+	src := &fakeUplinkSource{uplinks: map[string]bool{"SN1:Ethernet1/49": true, "SN2:Ethernet1/49": true}}
+	uc := NewUplinkCache(client, 15*time.Minute)
+	ctx := context.Background()
+
+	got, err := uc.GetUplinks(ctx, src, "fab1")
+	if err != nil {
+		t.Fatalf("miss: %v", err)
+	}
+	if len(got) != 2 || src.calls != 1 {
+		t.Fatalf("miss: got %v with %d fetches, want 2 uplinks and 1 fetch", got, src.calls)
+	}
+	if ttl := mr.TTL(cache.Uplinks("fab1")); ttl != 15*time.Minute {
+		t.Errorf("TTL = %v, want 15m", ttl)
+	}
+	if v := testutil.ToFloat64(metrics.UplinkPortsCached.WithLabelValues("fab1")); v != 2 {
+		t.Errorf("nd_uplink_ports_cached = %v, want 2", v)
+	}
+
+	got, err = uc.GetUplinks(ctx, src, "fab1")
+	if err != nil {
+		t.Fatalf("hit: %v", err)
+	}
+	if !got["SN1:Ethernet1/49"] || src.calls != 1 {
+		t.Errorf("hit: got %v with %d fetches, want cached result and no new fetch", got, src.calls)
+	}
+}
+
+func TestUplinkCache_Invalidate(t *testing.T) {
+	mr, client := newTestCache(t)
+	src := &fakeUplinkSource{uplinks: map[string]bool{"SN1:Ethernet1/49": true}}
+	uc := NewUplinkCache(client, time.Minute)
+	ctx := context.Background()
+
+	if _, err := uc.GetUplinks(ctx, src, "fab1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.InvalidateUplinkCache(ctx, "fab1"); err != nil {
+		t.Fatalf("invalidate: %v", err)
+	}
+	if mr.Exists(cache.Uplinks("fab1")) {
+		t.Error("uplink key should be deleted")
+	}
+	if v := testutil.ToFloat64(metrics.UplinkPortsCached.WithLabelValues("fab1")); v != 0 {
+		t.Errorf("nd_uplink_ports_cached = %v, want 0 after invalidation", v)
+	}
+
+	if _, err := uc.GetUplinks(ctx, src, "fab1"); err != nil {
+		t.Fatal(err)
+	}
+	if src.calls != 2 {
+		t.Errorf("fetches = %d, want 2 (refetch after invalidation)", src.calls)
+	}
+}
+
+func TestUplinkCache_NilClientAlwaysFetches(t *testing.T) {
+	src := &fakeUplinkSource{uplinks: map[string]bool{"SN1:Ethernet1/49": true}}
+	uc := NewUplinkCache(nil, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := uc.GetUplinks(ctx, src, "fab1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if src.calls != 2 {
+		t.Errorf("fetches = %d, want 2", src.calls)
+	}
+	if err := uc.Invalidate(ctx, "fab1"); err != nil {
+		t.Errorf("invalidate with nil client: %v", err)
+	}
+}
+
+func TestUplinkCache_FetchErrorNotCached(t *testing.T) {
+	mr, client := newTestCache(t)
+	src := &fakeUplinkSource{err: errors.New("ndfc down")}
+
+	if _, err := GetUplinks(context.Background(), src, "fab1", client, time.Minute); err == nil {
+		t.Fatal("expected error")
+	}
+	if mr.Exists(cache.Uplinks("fab1")) {
+		t.Error("failed fetch should not be cached")
+	}
+}
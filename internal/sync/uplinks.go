@@ -3,20 +3,19 @@ package sync
 import (
 	"context"
 
-	"github.com/banglin/go-nd/internal/cache"
+	"github.com/banglin/go-nd/internal/logger"
 	"github.com/banglin/go-nd/internal/ndclient/lanfabric"
+	"go.uber.org/zap"
 )
 
-// Note: uplinksCacheTTL and cacheOpTimeout are defined in worker.go
-
-// GetUplinksWithCache returns uplink ports for a fabric, using Valkey cache when available.
-// This is the shared implementation used by both the HTTP handler and background worker.
+// GetUplinksWithCache returns uplink ports for a fabric, using the uplink cache when available.
+// This is the shared implementation used by the HTTP handler, gRPC service, and background worker.
 //
 // Parameters:
 //   - ctx: context for the operation
 //   - lanFabricSvc: LAN fabric service for NDFC calls
 //   - fabricName: fabric name for NDFC API and cache key
-//   - cacheClient: optional Valkey client (pass nil to skip caching)
+//   - uplinkCache: optional cache (pass nil to always fetch live)
 //
 // Returns a map of "serial:ifName" -> true for all uplink ports.
 // On error, returns an empty map (graceful degradation).
@@ -24,34 +23,17 @@ func GetUplinksWithCache(
 	ctx context.Context,
 	lanFabricSvc *lanfabric.Service,
 	fabricName string,
-	cacheClient *cache.ValkeyClient,
+	uplinkCache *UplinkCache,
 ) map[string]bool {
-	cacheKey := "cache:v1:uplinks:" + fabricName
-
-	// Try cache first with bounded context
-	if cacheClient != nil {
-		cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
-		var cached map[string]bool
-		err := cacheClient.Get(cacheCtx, cacheKey, &cached)
-		cancel()
-		if err == nil && cached != nil {
-			return cached
-		}
+	if uplinkCache == nil {
+		uplinkCache = NewUplinkCache(nil, 0)
 	}
 
-	// Fetch from NDFC
-	uplinks, err := lanFabricSvc.GetUplinkPortsNDFC(ctx, fabricName)
+	uplinks, err := uplinkCache.GetUplinks(ctx, lanFabricSvc, fabricName)
 	if err != nil {
 		// Graceful degradation - return empty map on error
+		logger.Warn("Failed to get uplink ports, not excluding any", zap.String("fabric", fabricName), zap.Error(err))
 		return make(map[string]bool)
 	}
-
-	// Cache the result with bounded context
-	if cacheClient != nil {
-		cacheCtx, cancel := context.WithTimeout(ctx, cacheOpTimeout)
-		_ = cacheClient.Set(cacheCtx, cacheKey, uplinks, uplinksCacheTTL)
-		cancel()
-	}
-
 	return uplinks
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/banglin/go-nd/internal/config"
 	"github.com/banglin/go-nd/internal/database"
 	"github.com/banglin/go-nd/internal/logger"
+	"github.com/banglin/go-nd/internal/metrics"
 	"github.com/banglin/go-nd/internal/models"
 	"github.com/banglin/go-nd/internal/ndclient"
 	"go.uber.org/zap"
@@ -27,11 +28,76 @@ type Worker struct {
 	fabricName string
 	instanceID string // Unique identifier for this worker instance (for debugging)
 
-	ctx     context.Context
-	cancel  context.CancelFunc
-	wg      sync.WaitGroup
-	running atomic.Bool
-	started atomic.Bool // Prevents double Start()
+	uplinkCacheTTL time.Duration
+
+	leader *LeaderElector // Nil without Valkey; sync then runs on every instance
+
+	tasks []PeriodicTask
+
+	ctx      context.Context // Canceled to abort in-flight syncs
+	cancel   context.CancelFunc
+	stopCh   chan struct{} // Closed to stop starting new sync cycles and tasks
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+	running  atomic.Bool
+	started  atomic.Bool // Prevents double Start()
+
+	phase atomic.Pointer[syncPhase] // Phase of the sync cycle in progress (nil when idle)
+
+	syncFailures   int                        // Consecutive failed sync cycles; only touched by syncAll
+	onHealthChange atomic.Pointer[func(bool)] // See OnSyncHealthChange
+}
+
+// SyncFailureThreshold is how many consecutive sync cycles must fail before the sync is
+// reported unhealthy
+const SyncFailureThreshold = 3
+
+// OnSyncHealthChange registers fn to be called with false once SyncFailureThreshold
+// consecutive sync cycles have failed, and with true when a cycle succeeds again.
+// May be called before or after Start.
+func (w *Worker) OnSyncHealthChange(fn func(healthy bool)) {
+	w.onHealthChange.Store(&fn)
+}
+
+// recordSyncOutcome tracks consecutive sync failures and reports health changes
+func (w *Worker) recordSyncOutcome(err error) {
+	wasHealthy := w.syncFailures < SyncFailureThreshold
+	if err != nil {
+		w.syncFailures++
+	} else {
+		w.syncFailures = 0
+	}
+	healthy := w.syncFailures < SyncFailureThreshold
+	if fn := w.onHealthChange.Load(); fn != nil && healthy != wasHealthy {
+		(*fn)(healthy)
+	}
+}
+
+// syncPhase names the step a sync cycle is in, for shutdown logs
+type syncPhase string
+
+// Sync cycle phases
+const (
+	phaseFabricSync syncPhase = "fabric sync"
+	phaseSwitchSync syncPhase = "switch sync"
+	phasePortSync   syncPhase = "port sync"
+)
+
+// setPhase records the current sync phase; empty marks the worker idle
+func (w *Worker) setPhase(phase syncPhase) {
+	if phase == "" {
+		w.phase.Store(nil)
+		return
+	}
+	w.phase.Store(&phase)
+}
+
+// currentPhase returns the phase of the sync cycle in progress, or "idle"
+func (w *Worker) currentPhase() string {
+	if p := w.phase.Load(); p != nil {
+		return string(*p)
+	}
+	return "idle"
 }
 
 // NewWorker creates a new sync worker
@@ -48,9 +114,25 @@ func NewWorker(ndClient *ndclient.Client, cfg *config.NexusDashboardConfig, inst
 		instanceID: instanceID,
 		ctx:        ctx,
 		cancel:     cancel,
+		stopCh:     make(chan struct{}),
+
+		uplinkCacheTTL: time.Duration(cfg.UplinkCacheTTLMinutes) * time.Minute,
 	}
 }
 
+// PeriodicTask is local housekeeping the worker runs on its own interval alongside the NDFC sync
+type PeriodicTask struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// AddTask registers a periodic task. Must be called before Start. Tasks only run while
+// sync is enabled and, with Valkey, only on the sync leader.
+func (w *Worker) AddTask(task PeriodicTask) {
+	w.tasks = append(w.tasks, task)
+}
+
 // generateInstanceID creates a unique identifier for this worker instance
 func generateInstanceID() string {
 	hostname, _ := os.Hostname()
@@ -76,6 +158,12 @@ func (w *Worker) Start() {
 	// Clean up any stale locks from previous crashed instances
 	w.cleanupStaleLocks()
 
+	// Elect one instance to sync this fabric; the others skip until it stops refreshing
+	if cache.Client != nil && w.fabricName != "" {
+		w.leader = NewLeaderElector(cache.Client, LeaderKey(w.fabricName), w.instanceID)
+		w.leader.Start()
+	}
+
 	logger.Info("Starting NDFC sync worker",
 		zap.Duration("interval", w.interval),
 		zap.String("fabric", w.fabricName),
@@ -95,18 +183,97 @@ func (w *Worker) Start() {
 			select {
 			case <-ticker.C:
 				w.syncAll()
-			case <-w.ctx.Done():
+			case <-w.stopCh:
 				logger.Info("NDFC sync worker stopped")
 				return
 			}
 		}
 	}()
+
+	for _, task := range w.tasks {
+		if task.Interval <= 0 {
+			continue
+		}
+		w.wg.Add(1)
+		go func(task PeriodicTask) {
+			defer w.wg.Done()
+			ticker := time.NewTicker(task.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					w.runTask(task)
+				case <-w.stopCh:
+					return
+				}
+			}
+		}(task)
+	}
 }
 
-// Stop stops the background sync routine and waits for completion
+// runTask runs one periodic task on the sync leader, bounded by the task interval
+func (w *Worker) runTask(task PeriodicTask) {
+	if w.leader != nil && !w.leader.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(w.ctx, task.Interval)
+	defer cancel()
+	if err := task.Run(ctx); err != nil {
+		logger.Warn("Periodic sync task failed", zap.String("task", task.Name), zap.Error(err))
+	}
+}
+
+// Stop stops the background sync routine, aborting any sync in progress, and waits for completion
 func (w *Worker) Stop() {
+	w.stopOnce.Do(func() { close(w.stopCh) })
+	if w.running.Load() {
+		logger.Info("Aborting NDFC sync", zap.String("fabric", w.fabricName), zap.String("phase", w.currentPhase()))
+	}
 	w.cancel()
 	w.wg.Wait()
+	if w.leader != nil {
+		w.leader.Stop()
+	}
+}
+
+// DefaultDrainTimeout is how long shutdown waits for an in-progress sync to finish
+const DefaultDrainTimeout = 30 * time.Second
+
+// StopWithDrain stops starting new sync cycles and periodic tasks and waits up to timeout for
+// the ones in progress to finish, so a sync is not abandoned halfway through updating the
+// database. If they have not finished by then they are aborted and context.DeadlineExceeded
+// is returned.
+func (w *Worker) StopWithDrain(timeout time.Duration) error {
+	w.stopOnce.Do(func() { close(w.stopCh) })
+	if w.running.Load() {
+		logger.Info("Draining NDFC sync", zap.String("fabric", w.fabricName),
+			zap.String("phase", w.currentPhase()), zap.Duration("timeout", timeout))
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-time.After(timeout):
+		logger.Warn("NDFC sync did not drain in time, aborting",
+			zap.String("fabric", w.fabricName),
+			zap.String("phase", w.currentPhase()),
+			zap.Duration("timeout", timeout))
+		err = context.DeadlineExceeded
+		w.cancel()
+		<-drained
+	}
+
+	w.cancel()
+	if w.leader != nil {
+		w.leader.Stop()
+	}
+	return err
 }
 
 // Sync lock and cache key formats and TTLs
@@ -115,12 +282,17 @@ const (
 	syncLockTTL        = 1 * time.Minute  // Short TTL, extended periodically during sync
 	lockExtendInterval = 30 * time.Second // Extend lock every 30s during sync
 	staleLockThreshold = 2 * time.Minute  // Force-release locks older than this on startup
-	uplinksCacheTTL    = 30 * time.Minute
 	statusTTL          = 24 * time.Hour
 	cooldownDuration   = 5 * time.Minute
 	cacheOpTimeout     = 2 * time.Second
 )
 
+// StalePortThreshold is how long a port can go unseen by sync before it is marked not present
+const StalePortThreshold = 24 * time.Hour
+
+// portMappingHistoryRetention is how long port mapping history rows are kept
+const portMappingHistoryRetention = 365 * 24 * time.Hour
+
 // syncKeyFor builds a Valkey key for the given fabric and suffix
 func (w *Worker) syncKeyFor(suffix string) string {
 	return syncKeyPrefix + w.fabricName + ":" + suffix
@@ -221,12 +393,19 @@ func (w *Worker) syncAll() {
 		return
 	}
 	defer w.running.Store(false)
+	defer w.setPhase("")
 
 	if w.fabricName == "" {
 		logger.Warn("NDFC sync skipped: no fabric name configured")
 		return
 	}
 
+	if w.leader != nil && !w.leader.IsLeader() {
+		logger.Debug("NDFC sync skipped: another instance is sync leader",
+			zap.String("fabric", w.fabricName))
+		return
+	}
+
 	// Check cooldown (skip if we recently had failures)
 	if w.isOnCooldown() {
 		logger.Debug("NDFC sync skipped: on cooldown after recent failures",
@@ -298,6 +477,7 @@ func (w *Worker) syncAll() {
 		w.setInProgress(false)
 		w.updateSyncStatus(time.Since(start), portErrors, syncErr)
 		w.setFinishStatus(syncErr)
+		w.recordSyncOutcome(syncErr)
 		// Stop lock extender first
 		if stopLockExtender != nil {
 			close(stopLockExtender)
@@ -339,6 +519,9 @@ func (w *Worker) syncAll() {
 		w.setCooldown() // Set cooldown on failure
 	}
 
+	// Local housekeeping; runs even if the port sync failed
+	w.prunePortMappingHistory(ctx)
+
 	logger.Info("NDFC sync completed",
 		zap.String("fabric", w.fabricName),
 		zap.Int("switches", switchCount),
@@ -354,12 +537,14 @@ func (w *Worker) syncSwitches(ctx context.Context) (int, error) {
 	db := database.DB.WithContext(ctx)
 
 	// Ensure fabric exists using shared helper
+	w.setPhase(phaseFabricSync)
 	fabric, err := EnsureFabric(ctx, db, w.fabricName, "VXLAN")
 	if err != nil {
 		return 0, fmt.Errorf("ensure fabric: %w", err)
 	}
 
 	// Sync switches using shared helper
+	w.setPhase(phaseSwitchSync)
 	result, err := SyncFabricSwitches(ctx, db, w.ndClient.LANFabric(), fabric)
 	if err != nil {
 		return 0, fmt.Errorf("sync switches: %w", err)
@@ -369,6 +554,7 @@ func (w *Worker) syncSwitches(ctx context.Context) (int, error) {
 }
 
 func (w *Worker) syncPorts(ctx context.Context) (int, int, error) {
+	w.setPhase(phasePortSync)
 	db := database.DB.WithContext(ctx)
 
 	// Get fabric
@@ -413,7 +599,7 @@ func (w *Worker) syncPorts(ctx context.Context) (int, int, error) {
 
 	// Mark stale ports as not present (not seen in recent sync)
 	// This keeps inventory accurate when ports are removed from switches
-	staleThreshold := now.Add(-24 * time.Hour) // Ports not seen for 24h are marked not present
+	staleThreshold := now.Add(-StalePortThreshold)
 	var switchIDs []string
 	for _, sw := range switches {
 		switchIDs = append(switchIDs, sw.ID)
@@ -431,9 +617,28 @@ func (w *Worker) syncPorts(ctx context.Context) (int, int, error) {
 		}
 	}
 
+	if _, available, err := CountLeafPorts(ctx, db, fabric.ID); err != nil {
+		logger.Warn("Failed to count available leaf ports", zap.Error(err))
+	} else {
+		metrics.FabricLeafPortsAvailable.WithLabelValues(w.fabricName).Set(float64(available))
+	}
+
 	return totalPorts, totalErrors, nil
 }
 
+// prunePortMappingHistory deletes port mapping history older than the retention period
+func (w *Worker) prunePortMappingHistory(ctx context.Context) {
+	cutoff := time.Now().Add(-portMappingHistoryRetention)
+	result := database.DB.WithContext(ctx).
+		Where("changed_at < ?", cutoff).
+		Delete(&models.ComputeNodePortMappingHistory{})
+	if result.Error != nil {
+		logger.Warn("Failed to prune port mapping history", zap.Error(result.Error))
+	} else if result.RowsAffected > 0 {
+		logger.Info("Pruned port mapping history", zap.Int64("count", result.RowsAffected))
+	}
+}
+
 // isOnCooldown checks if we're in a cooldown period after recent failures
 func (w *Worker) isOnCooldown() bool {
 	valkeyClient := cache.Client
@@ -556,5 +761,5 @@ func (w *Worker) updateSyncStatus(duration time.Duration, errorCount int, syncEr
 
 // getUplinksWithCache returns uplink ports, using Valkey cache when available
 func (w *Worker) getUplinksWithCache(ctx context.Context) map[string]bool {
-	return GetUplinksWithCache(ctx, w.ndClient.LANFabric(), w.fabricName, cache.Client)
+	return GetUplinksWithCache(ctx, w.ndClient.LANFabric(), w.fabricName, NewUplinkCache(cache.Default(), w.uplinkCacheTTL))
 }
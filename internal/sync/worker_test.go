@@ -0,0 +1,123 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banglin/go-nd/internal/config"
+	"github.com/banglin/go-nd/internal/database"
+	"github.com/banglin/go-nd/internal/models"
+	"github.com/banglin/go-nd/internal/ndclient"
+)
+
+// newDrainTestWorker returns a worker for fabric fab1 whose NDFC answers the switch inventory
+// after delay, and a channel receiving a value when the inventory request arrives
+func newDrainTestWorker(t *testing.T, delay time.Duration) (*Worker, <-chan struct{}) {
+	t.Helper()
+	db := newSwitchDB(t)
+	if err := db.AutoMigrate(&models.Fabric{}, &models.SwitchPort{}, &models.ComputeNodePortMappingHistory{}); err != nil {
+		t.Fatal(err)
+	}
+	prev := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = prev })
+
+	inventoryRequested := make(chan struct{}, 1)
+	fake := &fakeInventoryNDFC{inventory: `[{"serialNumber": "SN1", "logicalName": "leaf1", "switchRoleEnum": "Leaf"}]`}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/inventory") {
+			select {
+			case inventoryRequested <- struct{}{}:
+			default:
+			}
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client, err := ndclient.NewClient(&config.NexusDashboardConfig{BaseURL: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	w := NewWorker(client, &config.NexusDashboardConfig{SyncIntervalHours: 1, ComputeFabricName: "fab1"}, "test")
+	return w, inventoryRequested
+}
+
+func TestStopWithDrain_WaitsForSyncInProgress(t *testing.T) {
+	w, inventoryRequested := newDrainTestWorker(t, 300*time.Millisecond)
+	w.Start()
+	<-inventoryRequested
+
+	if got := w.currentPhase(); got != string(phaseSwitchSync) {
+		t.Errorf("phase = %q, want %q", got, phaseSwitchSync)
+	}
+
+	start := time.Now()
+	if err := w.StopWithDrain(5 * time.Second); err != nil {
+		t.Fatalf("StopWithDrain: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("StopWithDrain returned after %s, before the delayed sync finished", elapsed)
+	}
+
+	// The sync ran to completion: switch and ports were written
+	var ports int64
+	if err := database.DB.Model(&models.SwitchPort{}).Count(&ports).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := switchRoles(t, database.DB); got["SN1"] != models.SwitchRoleLeaf || ports == 0 {
+		t.Errorf("switches = %v, ports = %d; want the drained sync to finish", got, ports)
+	}
+	if got := w.currentPhase(); got != "idle" {
+		t.Errorf("phase after drain = %q, want idle", got)
+	}
+}
+
+func TestStopWithDrain_Timeout(t *testing.T) {
+	w, inventoryRequested := newDrainTestWorker(t, 5*time.Second)
+	w.Start()
+	<-inventoryRequested
+
+	start := time.Now()
+	err := w.StopWithDrain(100 * time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("StopWithDrain took %s, want the sync aborted after the timeout", elapsed)
+	}
+}
+
+func TestStopWithDrain_NotStarted(t *testing.T) {
+	w := NewWorker(nil, &config.NexusDashboardConfig{}, "test")
+	if err := w.StopWithDrain(time.Second); err != nil {
+		t.Errorf("StopWithDrain on idle worker = %v", err)
+	}
+}
+
+func TestRecordSyncOutcome_ReportsPersistentFailures(t *testing.T) {
+	w := NewWorker(nil, &config.NexusDashboardConfig{}, "test")
+	var changes []bool
+	w.OnSyncHealthChange(func(healthy bool) { changes = append(changes, healthy) })
+
+	failed := errors.New("sync switches: NDFC unavailable")
+	for i := 0; i < SyncFailureThreshold+1; i++ {
+		w.recordSyncOutcome(failed)
+	}
+	w.recordSyncOutcome(nil)
+	w.recordSyncOutcome(nil)
+
+	if len(changes) != 2 || changes[0] || !changes[1] {
+		t.Errorf("health changes = %v, want [false true]", changes)
+	}
+}
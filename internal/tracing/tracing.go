@@ -0,0 +1,38 @@
+// Package tracing sets up OpenTelemetry trace export. Instrumented packages take their
+// tracer from the global provider, so spans are only recorded once Init has installed one.
+package tracing
+
+import (
+	"context"
+
+	"github.com/banglin/go-nd/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Init installs a global tracer provider exporting spans over OTLP/HTTP when
+// cfg.OTLPEndpoint is set, and does nothing otherwise. The exporter reads the endpoint and
+// the other OTEL_EXPORTER_OTLP_* settings from the environment itself, so traces go to
+// <endpoint>/v1/traces as the OpenTelemetry specification requires. The returned function
+// flushes pending spans and stops the exporter; call it on shutdown.
+func Init(ctx context.Context, cfg config.TracingConfig) (shutdown func(context.Context) error, err error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,20 @@
+// Package util contains small helpers shared across packages.
+package util
+
+import "unicode/utf8"
+
+// NDFCDescriptionMaxLen is the maximum interface description length NDFC accepts
+const NDFCDescriptionMaxLen = 64
+
+// TruncateDescription shortens s to at most maxLen bytes without splitting a UTF-8 character
+func TruncateDescription(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	for i, r := range s {
+		if i+utf8.RuneLen(r) > maxLen {
+			return s[:i]
+		}
+	}
+	return s
+}
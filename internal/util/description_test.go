@@ -0,0 +1,29 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateDescription(t *testing.T) {
+	long := strings.Repeat("a", 80)
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"short unchanged", "HPC Job 123", 64, "HPC Job 123"},
+		{"exact length", long[:64], 64, long[:64]},
+		{"truncated at 64", long, 64, long[:64]},
+		{"zero max", "abc", 0, ""},
+		{"no split rune", "ab€", 4, "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateDescription(tt.in, tt.max); got != tt.want {
+				t.Errorf("TruncateDescription(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+}
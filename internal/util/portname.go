@@ -0,0 +1,37 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Port name formats for displayed switch port names. Stored names and names sent to
+// NDFC are always the NDFC (long) format.
+const (
+	PortNameFormatLong  = "long"  // NDFC format, e.g. Ethernet1/1
+	PortNameFormatShort = "short" // Abbreviated, e.g. Eth1/1
+)
+
+const (
+	ethernetLongPrefix  = "Ethernet"
+	ethernetShortPrefix = "Eth"
+)
+
+// ValidatePortNameFormat checks ND_PORT_NAME_FORMAT. Empty means PortNameFormatLong.
+func ValidatePortNameFormat(format string) error {
+	switch format {
+	case "", PortNameFormatLong, PortNameFormatShort:
+		return nil
+	}
+	return fmt.Errorf("port name format %q must be %q or %q", format, PortNameFormatLong, PortNameFormatShort)
+}
+
+// DisplayPortName converts an NDFC port name to format for display: "short" abbreviates
+// Ethernet1/1 to Eth1/1, anything else keeps the NDFC name. Non-Ethernet names are
+// never changed. The result must not be stored or sent to NDFC.
+func DisplayPortName(name, format string) string {
+	if format != PortNameFormatShort || !strings.HasPrefix(name, ethernetLongPrefix) {
+		return name
+	}
+	return ethernetShortPrefix + strings.TrimPrefix(name, ethernetLongPrefix)
+}
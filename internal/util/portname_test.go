@@ -0,0 +1,33 @@
+package util
+
+import "testing"
+
+func TestDisplayPortName(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"Ethernet1/1", PortNameFormatShort, "Eth1/1"},
+		{"Ethernet1/49/2", PortNameFormatShort, "Eth1/49/2"},
+		{"port-channel10", PortNameFormatShort, "port-channel10"},
+		{"Ethernet1/1", PortNameFormatLong, "Ethernet1/1"},
+		{"Ethernet1/1", "", "Ethernet1/1"},
+	}
+	for _, tt := range tests {
+		if got := DisplayPortName(tt.name, tt.format); got != tt.want {
+			t.Errorf("DisplayPortName(%q, %q) = %q, want %q", tt.name, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePortNameFormat(t *testing.T) {
+	for _, format := range []string{"", PortNameFormatLong, PortNameFormatShort} {
+		if err := ValidatePortNameFormat(format); err != nil {
+			t.Errorf("ValidatePortNameFormat(%q) = %v", format, err)
+		}
+	}
+	if err := ValidatePortNameFormat("Short"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidSelector is returned for malformed port selector expressions
+var ErrInvalidSelector = errors.New("invalid selector expression")
+
+// Selector expression patterns, compiled once
+var (
+	// ethernetInterfaceRE matches full NDFC Ethernet interface names:
+	// Ethernetx/x (standard port) or Ethernetx/x/x (breakout port)
+	ethernetInterfaceRE = regexp.MustCompile(`^Ethernet\d+/\d+(/\d+)?$`)
+
+	// serialNumberRE matches NDFC switch serial numbers
+	serialNumberRE = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// IsEthernetInterface reports whether name is a full Ethernet interface name
+// (Ethernetx/x or Ethernetx/x/x) after trimming whitespace
+func IsEthernetInterface(name string) bool {
+	return ethernetInterfaceRE.MatchString(strings.TrimSpace(name))
+}
+
+// SelectorExpression identifies a switch port as "serial:interface",
+// the format stored in PortSelector.Expression
+type SelectorExpression struct {
+	SerialNumber  string
+	InterfaceName string
+}
+
+// String returns the canonical "serial:interface" form
+func (e SelectorExpression) String() string {
+	return e.SerialNumber + ":" + e.InterfaceName
+}
+
+// ParseSelectorExpression parses a "serial:interface" expression. The serial number must be
+// non-empty and alphanumeric and the interface a full Ethernet name (breakout ports included).
+func ParseSelectorExpression(s string) (*SelectorExpression, error) {
+	serial, iface, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, fmt.Errorf("%w %q: missing ':' between serial number and interface", ErrInvalidSelector, s)
+	}
+	serial = strings.TrimSpace(serial)
+	iface = strings.TrimSpace(iface)
+
+	if serial == "" {
+		return nil, fmt.Errorf("%w %q: serial number is empty", ErrInvalidSelector, s)
+	}
+	if !serialNumberRE.MatchString(serial) {
+		return nil, fmt.Errorf("%w %q: invalid serial number: must be alphanumeric", ErrInvalidSelector, s)
+	}
+	if !IsEthernetInterface(iface) {
+		return nil, fmt.Errorf("%w %q: invalid interface name: must be Ethernet format (Ethernetx/x or Ethernetx/x/x)", ErrInvalidSelector, s)
+	}
+	return &SelectorExpression{SerialNumber: serial, InterfaceName: iface}, nil
+}
+
+// ValidateSelectorExpression returns a detailed ErrInvalidSelector error if s is not a valid
+// "serial:interface" expression
+func ValidateSelectorExpression(s string) error {
+	_, err := ParseSelectorExpression(s)
+	return err
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSelectorExpression(t *testing.T) {
+	tests := []struct {
+		expr      string
+		want      SelectorExpression
+		wantError string
+	}{
+		{expr: "FDO12345ABC:Ethernet1/1", want: SelectorExpression{"FDO12345ABC", "Ethernet1/1"}},
+		{expr: " SN1 : Ethernet1/49 ", want: SelectorExpression{"SN1", "Ethernet1/49"}},
+		{expr: "SN1:Ethernet1/49/4", want: SelectorExpression{"SN1", "Ethernet1/49/4"}},
+		{expr: "SN1 Ethernet1/1", wantError: "missing ':'"},
+		{expr: ":Ethernet1/1", wantError: "serial number is empty"},
+		{expr: "SN-1:Ethernet1/1", wantError: "must be alphanumeric"},
+		{expr: "SN1:Eth1/1", wantError: "must be Ethernet format"},
+		{expr: "SN1:", wantError: "must be Ethernet format"},
+		{expr: "SN1:Ethernet1/1:extra", wantError: "must be Ethernet format"},
+	}
+	for _, tt := range tests {
+		got, err := ParseSelectorExpression(tt.expr)
+		if tt.wantError != "" {
+			if !errors.Is(err, ErrInvalidSelector) || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("ParseSelectorExpression(%q) err = %v, want ErrInvalidSelector containing %q", tt.expr, err, tt.wantError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSelectorExpression(%q) = %v", tt.expr, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseSelectorExpression(%q) = %+v, want %+v", tt.expr, *got, tt.want)
+		}
+	}
+}
+
+func TestSelectorExpressionRoundTrip(t *testing.T) {
+	expr := SelectorExpression{SerialNumber: "SN1", InterfaceName: "Ethernet1/1/2"}
+	if got := expr.String(); got != "SN1:Ethernet1/1/2" {
+		t.Fatalf("String() = %q", got)
+	}
+	parsed, err := ParseSelectorExpression(expr.String())
+	if err != nil || *parsed != expr {
+		t.Errorf("round trip = %+v, %v", parsed, err)
+	}
+	if err := ValidateSelectorExpression(expr.String()); err != nil {
+		t.Errorf("ValidateSelectorExpression = %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidHostname is returned for compute node hostnames that are not valid RFC 1123 names
+	ErrInvalidHostname = errors.New("invalid hostname")
+	// ErrInvalidIPAddress is returned for compute node addresses that are not IPv4 or IPv6 addresses
+	ErrInvalidIPAddress = errors.New("invalid IP address")
+	// ErrInvalidMACAddress is returned for compute node MAC addresses that cannot be parsed
+	ErrInvalidMACAddress = errors.New("invalid MAC address")
+	// ErrInvalidBMCAddress is returned for BMC addresses that are neither IP addresses nor host names
+	ErrInvalidBMCAddress = errors.New("invalid BMC address")
+)
+
+// Hostname limits from RFC 1123
+const (
+	MaxHostnameLength      = 253
+	MaxHostnameLabelLength = 63
+)
+
+// hostnameRE matches lowercase letters, digits, hyphens and dots, starting and ending alphanumeric
+var hostnameRE = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-\.]{0,251}[a-z0-9])?$`)
+
+// ValidateHostname returns ErrInvalidHostname unless hostname is a valid RFC 1123 host name or
+// FQDN: lowercase, at most 253 characters, with dot-separated labels of 1-63 characters that do
+// not start or end with a hyphen. An empty hostname is allowed for nodes without management access.
+func ValidateHostname(hostname string) error {
+	if hostname == "" {
+		return nil
+	}
+	if !hostnameRE.MatchString(hostname) {
+		return fmt.Errorf("%w: %q must be at most %d lowercase letters, digits, hyphens and dots, starting and ending with a letter or digit",
+			ErrInvalidHostname, hostname, MaxHostnameLength)
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		switch {
+		case label == "":
+			return fmt.Errorf("%w: %q has an empty label", ErrInvalidHostname, hostname)
+		case len(label) > MaxHostnameLabelLength:
+			return fmt.Errorf("%w: label %q is longer than %d characters", ErrInvalidHostname, label, MaxHostnameLabelLength)
+		case strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-"):
+			return fmt.Errorf("%w: label %q starts or ends with a hyphen", ErrInvalidHostname, label)
+		}
+	}
+	return nil
+}
+
+// ValidateIPAddress returns ErrInvalidIPAddress unless ip is an IPv4 or IPv6 address. An empty
+// address is allowed.
+func ValidateIPAddress(ip string) error {
+	if ip != "" && net.ParseIP(ip) == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidIPAddress, ip)
+	}
+	return nil
+}
+
+// ValidateMACAddress returns ErrInvalidMACAddress unless mac is a 48-bit MAC address written
+// with colons, hyphens or dots (00:1a:2b:3c:4d:5e, 00-1A-2B-3C-4D-5E, 001a.2b3c.4d5e). An
+// empty address is allowed.
+func ValidateMACAddress(mac string) error {
+	if mac == "" {
+		return nil
+	}
+	if hw, err := net.ParseMAC(mac); err != nil || len(hw) != 6 {
+		return fmt.Errorf("%w: %q", ErrInvalidMACAddress, mac)
+	}
+	return nil
+}
+
+// ValidateBMCAddress returns ErrInvalidBMCAddress unless addr is an IPv4 or IPv6 address or an
+// RFC 1123 host name (in any case). The address is passed as an argument to the power-cycle
+// command, so anything that could be read as an option or hold shell metacharacters is
+// rejected. An empty address is allowed.
+func ValidateBMCAddress(addr string) error {
+	if addr == "" || net.ParseIP(addr) != nil {
+		return nil
+	}
+	if ValidateHostname(strings.ToLower(addr)) != nil {
+		return fmt.Errorf("%w: %q must be an IP address or host name", ErrInvalidBMCAddress, addr)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateHostname(t *testing.T) {
+	label63 := strings.Repeat("a", 63)
+	// 4 labels of 63 plus 3 dots = 255; trim to exactly 253
+	maxLength := strings.Join([]string{label63, label63, label63, label63}, ".")[:253]
+
+	tests := []struct {
+		name     string
+		hostname string
+		valid    bool
+	}{
+		{"empty allowed", "", true},
+		{"single label", "node1", true},
+		{"fqdn", "gpu-node-01.cluster.example.com", true},
+		{"single character", "a", true},
+		{"63 character label", label63 + ".example.com", true},
+		{"max length", maxLength, true},
+		{"too long", maxLength + "a", false},
+		{"64 character label", label63 + "a.example.com", false},
+		{"leading hyphen", "-node1", false},
+		{"label starting with hyphen", "node1.-cluster.example.com", false},
+		{"label ending with hyphen", "node1-.cluster", false},
+		{"empty label", "node1..example.com", false},
+		{"trailing dot", "node1.example.com.", false},
+		{"uppercase", "Node1", false},
+		{"underscore", "node_1", false},
+		{"space", "node 1", false},
+	}
+	for _, tt := range tests {
+		err := ValidateHostname(tt.hostname)
+		if tt.valid && err != nil {
+			t.Errorf("%s: ValidateHostname(%q) = %v, want nil", tt.name, tt.hostname, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidHostname) {
+			t.Errorf("%s: ValidateHostname(%q) = %v, want ErrInvalidHostname", tt.name, tt.hostname, err)
+		}
+	}
+}
+
+func TestValidateIPAddress(t *testing.T) {
+	for _, ip := range []string{"", "10.0.0.1", "fd00::1"} {
+		if err := ValidateIPAddress(ip); err != nil {
+			t.Errorf("ValidateIPAddress(%q) = %v, want nil", ip, err)
+		}
+	}
+	for _, ip := range []string{"10.0.0", "10.0.0.256", "node1", "10.0.0.1/24"} {
+		if err := ValidateIPAddress(ip); !errors.Is(err, ErrInvalidIPAddress) {
+			t.Errorf("ValidateIPAddress(%q) = %v, want ErrInvalidIPAddress", ip, err)
+		}
+	}
+}
+
+func TestValidateMACAddress(t *testing.T) {
+	for _, mac := range []string{"", "00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e"} {
+		if err := ValidateMACAddress(mac); err != nil {
+			t.Errorf("ValidateMACAddress(%q) = %v, want nil", mac, err)
+		}
+	}
+	for _, mac := range []string{"00:1a:2b:3c:4d", "00:1a:2b:3c:4d:zz", "02:00:5e:10:00:00:00:01"} {
+		if err := ValidateMACAddress(mac); !errors.Is(err, ErrInvalidMACAddress) {
+			t.Errorf("ValidateMACAddress(%q) = %v, want ErrInvalidMACAddress", mac, err)
+		}
+	}
+}
+
+func TestValidateBMCAddress(t *testing.T) {
+	for _, addr := range []string{"", "10.0.0.50", "fd00::50", "bmc-node01.mgmt", "BMC-Node01"} {
+		if err := ValidateBMCAddress(addr); err != nil {
+			t.Errorf("ValidateBMCAddress(%q) = %v, want nil", addr, err)
+		}
+	}
+	for _, addr := range []string{"-H", "--password=x", "10.0.0.50 -P secret", "bmc;reboot", "$(id)"} {
+		if err := ValidateBMCAddress(addr); !errors.Is(err, ErrInvalidBMCAddress) {
+			t.Errorf("ValidateBMCAddress(%q) = %v, want ErrInvalidBMCAddress", addr, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Valid 802.1Q VLAN IDs; 0 and 4095 are reserved
+const (
+	MinVLAN = 1
+	MaxVLAN = 4094
+)
+
+// ErrInvalidVLAN is returned for VLAN IDs outside MinVLAN-MaxVLAN
+var ErrInvalidVLAN = errors.New("invalid VLAN")
+
+// ValidateVLAN returns ErrInvalidVLAN unless vlan is a usable VLAN ID (1-4094)
+func ValidateVLAN(vlan int) error {
+	if vlan < MinVLAN || vlan > MaxVLAN {
+		return fmt.Errorf("%w: %d must be between %d and %d", ErrInvalidVLAN, vlan, MinVLAN, MaxVLAN)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateVLAN(t *testing.T) {
+	tests := []struct {
+		vlan  int
+		valid bool
+	}{
+		{-1, false},
+		{0, false},
+		{1, true},
+		{100, true},
+		{4094, true},
+		{4095, false},
+	}
+	for _, tt := range tests {
+		err := ValidateVLAN(tt.vlan)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateVLAN(%d) = %v, want nil", tt.vlan, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidVLAN) {
+			t.Errorf("ValidateVLAN(%d) = %v, want ErrInvalidVLAN", tt.vlan, err)
+		}
+	}
+}
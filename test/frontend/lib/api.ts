@@ -177,6 +177,12 @@ export interface Job {
   security_group_id?: string;
 }
 
+export interface JobPage {
+  jobs: Job[];
+  total_count: number;
+  next_cursor?: string;
+}
+
 export interface JobComputeNode {
   id: string;
   job_id: string;
@@ -186,7 +192,13 @@ export interface JobComputeNode {
 
 // Jobs API
 export const jobsAPI = {
-  list: (status?: string) => fetchAPI<Job[]>(`/api/v1/jobs${status ? `?status=${status}` : ''}`),
+  list: (status?: string, cursor?: string) => {
+    const params = new URLSearchParams();
+    if (status) params.set('status', status);
+    if (cursor) params.set('cursor', cursor);
+    const query = params.toString();
+    return fetchAPI<JobPage>(`/api/v1/jobs${query ? `?${query}` : ''}`);
+  },
   get: (slurmJobId: string) => fetchAPI<Job>(`/api/v1/jobs/${slurmJobId}`),
   submit: (data: { slurm_job_id: string; name?: string; tenant?: string; compute_nodes: string[] }) =>
     fetchAPI<Job>('/api/v1/jobs', { method: 'POST', body: JSON.stringify(data) }),